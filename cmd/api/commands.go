@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/internal-transfers-service/internal/boot"
+	"github.com/internal-transfers-service/internal/boot/app_context"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/interest/entities"
+	journalentities "github.com/internal-transfers-service/internal/modules/journal/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+)
+
+// Commands accepted as the first argument; without one the service starts its servers
+const (
+	commandInterestBackfill = "interest-backfill"
+	commandJournalExport    = "journal-export"
+	commandReconcile        = "reconcile"
+	commandVerifyChain      = "verify-chain"
+)
+
+// Process exit codes for commands
+const (
+	exitOK       = 0
+	exitFailure  = 1
+	exitUsage    = 2
+	exitFindings = 3
+)
+
+// usage describes the available commands
+const usage = `usage: api [command] [flags]
+
+Without a command the service starts its HTTP servers and background workers.
+
+Commands:
+  interest-backfill -from YYYY-MM-DD [-to YYYY-MM-DD]
+        Accrue interest for past business dates, snapshotting any dates that have not been
+        snapshotted yet, then pay out the periods that closed by -to.
+
+  journal-export [-from YYYY-MM-DD] [-to YYYY-MM-DD] | -id N
+        Write the journal of closed business dates to stdout as CSV and record the export;
+        -from defaults to the day after the last export and -to to the last closed date.
+        With -id, write a recorded export again. The export summary goes to stderr as JSON.
+
+  reconcile
+        Recompute every account's balance from its transactions and ledger entries and
+        print the drift report as JSON. Exits with status 3 if any account drifted.
+
+  verify-chain
+        Walk the transaction hash chain from the first link to the head, recomputing every
+        hash, and print the result as JSON. Exits with status 3 if the chain is broken.
+`
+
+// runCommand runs a one-off command and returns the process exit code.
+// Changes it makes are audited as made by the cli actor.
+func runCommand(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	ctx = app_context.SetActor(ctx, constants.ActorCLI)
+	switch args[0] {
+	case commandInterestBackfill:
+		return runInterestBackfill(ctx, args[1:], stdout, stderr)
+	case commandJournalExport:
+		return runJournalExport(ctx, args[1:], stdout, stderr)
+	case commandReconcile:
+		return runReconcile(ctx, args[1:], stdout, stderr)
+	case commandVerifyChain:
+		return runVerifyChain(ctx, args[1:], stdout, stderr)
+	}
+	fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+	return exitUsage
+}
+
+// runInterestBackfill runs the interest-backfill command and prints its summary as JSON
+func runInterestBackfill(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	from, to, err := parseBackfillFlags(args, stderr)
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(stderr, err)
+		}
+		return exitUsage
+	}
+
+	app, err := boot.InitializeForCommand(ctx)
+	if err != nil {
+		fmt.Fprintln(stderr, "failed to initialize application:", err)
+		return exitFailure
+	}
+	defer app.Close()
+	defer logger.Sync()
+
+	result, err := app.Modules.Interest.GetCore().Backfill(ctx, from, to)
+	if result != nil {
+		if encodeErr := json.NewEncoder(stdout).Encode(result); encodeErr != nil {
+			fmt.Fprintln(stderr, encodeErr)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "interest backfill failed:", err)
+		return exitFailure
+	}
+	return exitOK
+}
+
+// runJournalExport runs the journal-export command: the journal goes to stdout and the
+// export summary to stderr as JSON
+func runJournalExport(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(commandJournalExport, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	fromFlag := flags.String("from", "", "first business date to export (YYYY-MM-DD); defaults to the day after the last export")
+	toFlag := flags.String("to", "", "last business date to export (YYYY-MM-DD); defaults to the last closed date")
+	idFlag := flags.Int64("id", 0, "write the recorded export with this ID again")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *idFlag < 0 || (*idFlag > 0 && (*fromFlag != "" || *toFlag != "")) {
+		fmt.Fprintln(stderr, "-id cannot be combined with -from or -to")
+		return exitUsage
+	}
+
+	app, err := boot.InitializeForCommand(ctx)
+	if err != nil {
+		fmt.Fprintln(stderr, "failed to initialize application:", err)
+		return exitFailure
+	}
+	defer app.Close()
+	defer logger.Sync()
+
+	core := app.Modules.Journal.GetCore()
+	var result *journalentities.ExportResponse
+	var appErr apperror.IError
+	if *idFlag > 0 {
+		result, appErr = core.Download(ctx, *idFlag, stdout)
+	} else {
+		result, appErr = core.Export(ctx, *fromFlag, *toFlag, stdout)
+	}
+	if appErr != nil {
+		fmt.Fprintln(stderr, "journal export failed:", appErr.PublicMessage(), appErr.Fields())
+		return exitFailure
+	}
+	if result == nil {
+		fmt.Fprintln(stderr, "nothing to export: no business date has closed since the last export")
+		return exitOK
+	}
+	if err := json.NewEncoder(stderr).Encode(result); err != nil {
+		fmt.Fprintln(stderr, err)
+	}
+	return exitOK
+}
+
+// runReconcile runs the reconcile command and prints its report as JSON
+func runReconcile(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(commandReconcile, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	app, err := boot.InitializeForCommand(ctx)
+	if err != nil {
+		fmt.Fprintln(stderr, "failed to initialize application:", err)
+		return exitFailure
+	}
+	defer app.Close()
+	defer logger.Sync()
+
+	report, err := app.Modules.Reconcile.GetCore().Run(ctx)
+	if err != nil {
+		fmt.Fprintln(stderr, "reconciliation failed:", err)
+		return exitFailure
+	}
+	if err := json.NewEncoder(stdout).Encode(report); err != nil {
+		fmt.Fprintln(stderr, err)
+	}
+	if report.DriftedAccounts > 0 {
+		return exitFindings
+	}
+	return exitOK
+}
+
+// runVerifyChain runs the verify-chain command and prints its result as JSON
+func runVerifyChain(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(commandVerifyChain, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	app, err := boot.InitializeForCommand(ctx)
+	if err != nil {
+		fmt.Fprintln(stderr, "failed to initialize application:", err)
+		return exitFailure
+	}
+	defer app.Close()
+	defer logger.Sync()
+
+	result, err := app.Modules.Chain.GetCore().Verify(ctx)
+	if err != nil {
+		fmt.Fprintln(stderr, "chain verification failed:", err)
+		return exitFailure
+	}
+	if err := json.NewEncoder(stdout).Encode(result); err != nil {
+		fmt.Fprintln(stderr, err)
+	}
+	if !result.Valid {
+		return exitFindings
+	}
+	return exitOK
+}
+
+// parseBackfillFlags parses -from and -to; -to defaults to -from
+func parseBackfillFlags(args []string, stderr io.Writer) (time.Time, time.Time, error) {
+	flags := flag.NewFlagSet(commandInterestBackfill, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	fromFlag := flags.String("from", "", "first business date to accrue (YYYY-MM-DD)")
+	toFlag := flags.String("to", "", "last business date to accrue (YYYY-MM-DD); defaults to -from")
+	if err := flags.Parse(args); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	from, err := time.Parse(entities.DateLayout, *fromFlag)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("-from: %s", entities.ErrMsgInvalidDate)
+	}
+
+	to := from
+	if *toFlag != "" {
+		if to, err = time.Parse(entities.DateLayout, *toFlag); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("-to: %s", entities.ErrMsgInvalidDate)
+		}
+	}
+	return from, to, nil
+}
@@ -12,6 +12,10 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(runCommandWithSignals(os.Args[1:]))
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -35,3 +39,11 @@ func waitForShutdown(ctx context.Context, app *boot.App, cancel context.CancelFu
 	app.Shutdown(ctx)
 	cancel()
 }
+
+// runCommandWithSignals runs a one-off command, cancelling it on SIGTERM or SIGINT
+func runCommandWithSignals(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	return runCommand(ctx, args, os.Stdout, os.Stderr)
+}
@@ -58,3 +58,29 @@ func AddRequestIDToResponse(ctx context.Context, w http.ResponseWriter) {
 		w.Header().Set(constants.HeaderRequestID, reqID)
 	}
 }
+
+// SetActor adds the actor making the request to the context.
+func SetActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, contextkeys.Actor, actor)
+}
+
+// GetActor extracts the actor from context, or "" if none was set.
+func GetActor(ctx context.Context) string {
+	if actor, ok := ctx.Value(contextkeys.Actor).(string); ok {
+		return actor
+	}
+	return ""
+}
+
+// SetClientIP adds the client IP address to the context.
+func SetClientIP(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, contextkeys.ClientIP, clientIP)
+}
+
+// GetClientIP extracts the client IP address from context, or "" if none was set.
+func GetClientIP(ctx context.Context) string {
+	if clientIP, ok := ctx.Value(contextkeys.ClientIP).(string); ok {
+		return clientIP
+	}
+	return ""
+}
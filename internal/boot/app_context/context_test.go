@@ -125,3 +125,22 @@ func (s *ContextTestSuite) TestAddRequestIDToResponseDoesNothingWhenNoID() {
 
 	s.Empty(rec.Header().Get(constants.HeaderRequestID))
 }
+
+// TestSetActorSetsValue verifies the actor round-trips through the context
+func (s *ContextTestSuite) TestSetActorSetsValue() {
+	ctx := app_context.SetActor(context.Background(), "ops-alice")
+
+	s.Equal("ops-alice", app_context.GetActor(ctx))
+}
+
+// TestGetActorReturnsEmptyForEmptyContext verifies empty context returns empty string
+func (s *ContextTestSuite) TestGetActorReturnsEmptyForEmptyContext() {
+	s.Empty(app_context.GetActor(context.Background()))
+}
+
+// TestSetClientIPSetsValue verifies the client IP round-trips through the context
+func (s *ContextTestSuite) TestSetClientIPSetsValue() {
+	ctx := app_context.SetClientIP(context.Background(), "10.0.0.7")
+
+	s.Equal("10.0.0.7", app_context.GetClientIP(ctx))
+}
@@ -13,8 +13,20 @@ import (
 	"github.com/internal-transfers-service/internal/interceptors"
 	"github.com/internal-transfers-service/internal/logger"
 	"github.com/internal-transfers-service/internal/modules/account"
+	"github.com/internal-transfers-service/internal/modules/alert"
+	"github.com/internal-transfers-service/internal/modules/archive"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	"github.com/internal-transfers-service/internal/modules/chain"
+	"github.com/internal-transfers-service/internal/modules/conservation"
 	"github.com/internal-transfers-service/internal/modules/health"
 	"github.com/internal-transfers-service/internal/modules/idempotency"
+	"github.com/internal-transfers-service/internal/modules/interest"
+	"github.com/internal-transfers-service/internal/modules/journal"
+	"github.com/internal-transfers-service/internal/modules/ledger"
+	"github.com/internal-transfers-service/internal/modules/period"
+	"github.com/internal-transfers-service/internal/modules/reconciliation"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/internal/modules/statement"
 	"github.com/internal-transfers-service/internal/modules/transaction"
 	"github.com/internal-transfers-service/internal/tracing"
 	"github.com/internal-transfers-service/pkg/database"
@@ -38,10 +50,22 @@ type App struct {
 
 // Modules holds all application modules
 type Modules struct {
-	Account     account.IModule
-	Transaction transaction.IModule
-	Health      health.IModule
-	Idempotency idempotency.IModule
+	Account      account.IModule
+	Transaction  transaction.IModule
+	Health       health.IModule
+	Idempotency  idempotency.IModule
+	Snapshot     snapshot.IModule
+	Statement    statement.IModule
+	Interest     interest.IModule
+	Alert        alert.IModule
+	Reconcile    reconciliation.IModule
+	Chain        chain.IModule
+	Audit        audit.IModule
+	Ledger       ledger.IModule
+	Period       period.IModule
+	Conservation conservation.IModule
+	Archive      archive.IModule
+	Journal      journal.IModule
 }
 
 // Initialize creates and initializes all application dependencies.
@@ -66,13 +90,54 @@ func Initialize(ctx context.Context) (*App, error) {
 		return nil, err
 	}
 
-	app.initModules(ctx)
+	if err := app.initModules(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := app.ensureSystemAccounts(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := app.ensurePartitions(ctx); err != nil {
+		return nil, err
+	}
+
+	app.startWorkers(ctx)
 	app.setupRouters()
 	app.createServers()
 
 	return app, nil
 }
 
+// InitializeForCommand creates the configuration, logger, database and modules needed to
+// run a one-off command. Unlike Initialize it starts no servers or background workers;
+// call Close when the command finishes.
+func InitializeForCommand(ctx context.Context) (*App, error) {
+	app := &App{}
+
+	if err := app.loadConfig(); err != nil {
+		return nil, err
+	}
+
+	if err := app.initLogger(); err != nil {
+		return nil, err
+	}
+
+	if err := app.initDatabase(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := app.initModules(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := app.ensureSystemAccounts(ctx); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
 // loadConfig loads the application configuration
 func (a *App) loadConfig() error {
 	cfg, err := config.Load()
@@ -121,31 +186,123 @@ func (a *App) initDatabase(ctx context.Context) error {
 }
 
 // initModules initializes all application modules
-func (a *App) initModules(ctx context.Context) {
-	accountModule := account.NewModule(ctx, a.Database.GetPool())
-	transactionModule := transaction.NewModule(ctx, a.Database.GetPool(), accountModule.GetRepository())
+func (a *App) initModules(ctx context.Context) error {
+	auditModule := audit.NewModule(ctx, a.Database.GetPool())
+	accountModule := account.NewModule(ctx, a.Database.GetPool(), a.Config.Account, auditModule.GetRepository())
+
+	alertModule, err := alert.NewModule(ctx, a.Database.GetPool(), a.Config.Alert, accountModule.GetRepository(), auditModule.GetRepository())
+	if err != nil {
+		logger.Error(constants.LogMsgInvalidAlertConfig, constants.LogKeyError, err)
+		return err
+	}
+
+	snapshotModule, err := snapshot.NewModule(ctx, a.Database.GetPool(), a.Config.Snapshot, accountModule.GetRepository())
+	if err != nil {
+		logger.Error(constants.LogMsgInvalidSnapshotConfig, constants.LogKeyError, err)
+		return err
+	}
+
+	periodModule := period.NewModule(ctx, a.Database.GetPool(), snapshotModule, auditModule.GetRepository())
+	transactionModule := transaction.NewModule(ctx, a.Database.GetPool(), accountModule.GetRepository(), periodModule.GetCore(), alertModule.GetCore())
 	healthModule := health.NewModule(ctx, a.Database)
-	idempotencyModule := idempotency.NewModule(ctx, a.Database.GetPool())
+	idempotencyModule, err := idempotency.NewModule(ctx, a.Database.GetPool(), a.Config.Idempotency)
+	if err != nil {
+		logger.Error(constants.LogMsgInvalidIdempotencyConfig, constants.LogKeyError, err)
+		return err
+	}
 
-	// Start idempotency cleanup worker
-	ttl := a.getIdempotencyTTL()
-	idempotencyModule.StartCleanupWorker(ctx, ttl, idempotencyCleanupInterval)
+	statementModule := statement.NewModule(ctx, a.Database.GetPool(), accountModule.GetRepository())
+	interestModule := interest.NewModule(ctx, a.Database.GetPool(), accountModule.GetRepository(), snapshotModule, transactionModule.GetCore(), auditModule.GetRepository())
+	reconcileModule := reconciliation.NewModule(ctx, a.Database.GetPool(), a.Config.Reconciliation)
+	conservationModule := conservation.NewModule(ctx, a.Database.GetPool())
+	chainModule := chain.NewModule(ctx, a.Database.GetPool())
+	ledgerModule := ledger.NewModule(ctx, a.Database.GetPool(), snapshotModule)
+	archiveModule := archive.NewModule(ctx, a.Database.GetPool(), a.Config.Archive)
+
+	journalModule, err := journal.NewModule(ctx, a.Database.GetPool(), a.Config.Journal, a.Config.Account.Currencies, snapshotModule, auditModule.GetRepository())
+	if err != nil {
+		logger.Error(constants.LogMsgInvalidJournalConfig, constants.LogKeyError, err)
+		return err
+	}
 
 	a.Modules = &Modules{
-		Account:     accountModule,
-		Transaction: transactionModule,
-		Health:      healthModule,
-		Idempotency: idempotencyModule,
+		Account:      accountModule,
+		Transaction:  transactionModule,
+		Health:       healthModule,
+		Idempotency:  idempotencyModule,
+		Snapshot:     snapshotModule,
+		Statement:    statementModule,
+		Interest:     interestModule,
+		Alert:        alertModule,
+		Reconcile:    reconcileModule,
+		Chain:        chainModule,
+		Audit:        auditModule,
+		Ledger:       ledgerModule,
+		Period:       periodModule,
+		Conservation: conservationModule,
+		Archive:      archiveModule,
+		Journal:      journalModule,
 	}
+	return nil
 }
 
-// getIdempotencyTTL parses the idempotency TTL from configuration
-func (a *App) getIdempotencyTTL() time.Duration {
-	ttl, err := time.ParseDuration(a.Config.Idempotency.TTL)
-	if err != nil {
-		return 24 * time.Hour // Default to 24 hours
+// startWorkers starts the background workers of the server process
+func (a *App) startWorkers(ctx context.Context) {
+	// Start idempotency cleanup worker
+	a.Modules.Idempotency.StartCleanupWorker(ctx, a.Config.Idempotency.GetTTL(), idempotencyCleanupInterval)
+
+	// Start balance snapshot worker
+	if a.Config.Snapshot.Enabled {
+		a.Modules.Snapshot.StartWorker(ctx, a.Config.Snapshot.GetInterval())
+		logger.Info(constants.LogMsgSnapshotWorkerStarted)
+	}
+
+	// Start interest accrual and payout worker
+	if a.Config.Interest.Enabled {
+		a.Modules.Interest.StartWorker(ctx, a.Config.Interest.GetInterval())
+		logger.Info(constants.LogMsgInterestWorkerStarted)
+	}
+
+	// Start balance reconciliation worker
+	if a.Config.Reconciliation.Enabled {
+		a.Modules.Reconcile.StartWorker(ctx, a.Config.Reconciliation.GetInterval())
+		logger.Info(constants.LogMsgReconcileWorkerStarted)
+	}
+
+	// Start money conservation worker
+	if a.Config.Conservation.Enabled {
+		a.Modules.Conservation.StartWorker(ctx, a.Config.Conservation.GetInterval())
+		logger.Info(constants.LogMsgConservationWorkerStarted)
+	}
+
+	// Start transaction partition and archive worker. Postings need upcoming partitions
+	// whether or not archival is enabled.
+	a.Modules.Archive.StartWorker(ctx, a.Config.Archive.GetInterval(), a.Config.Archive.Enabled)
+	if a.Config.Archive.Enabled {
+		logger.Info(constants.LogMsgArchiveWorkerStarted)
+	} else {
+		logger.Info(constants.LogMsgPartitionWorkerStarted)
 	}
-	return ttl
+}
+
+// ensureSystemAccounts makes sure every supported currency has a clearing account for deposits
+// and withdrawals and an interest-expense account for interest payouts
+func (a *App) ensureSystemAccounts(ctx context.Context) error {
+	if err := a.Modules.Account.GetCore().EnsureSystemAccounts(ctx); err != nil {
+		logger.Error(constants.LogMsgFailedToEnsureSystemAcct, constants.LogKeyError, err)
+		return err
+	}
+	return nil
+}
+
+// ensurePartitions creates the transactions partitions for the current month and the
+// months ahead before the service takes postings
+func (a *App) ensurePartitions(ctx context.Context) error {
+	if _, err := a.Modules.Archive.GetCore().EnsurePartitions(ctx); err != nil {
+		logger.Error(constants.LogMsgFailedToManagePartitions, constants.LogKeyError, err)
+		return err
+	}
+	return nil
 }
 
 // setupRouters creates and configures the HTTP routers
@@ -172,12 +329,17 @@ func (a *App) createMainRouter() chi.Router {
 	router.Route(constants.APIVersionPrefix, func(r chi.Router) {
 		a.Modules.Account.GetHandler().RegisterRoutes(r)
 		a.Modules.Transaction.GetHandler().RegisterRoutes(r)
+		a.Modules.Snapshot.GetHandler().RegisterRoutes(r)
+		a.Modules.Statement.GetHandler().RegisterRoutes(r)
+		a.Modules.Alert.GetHandler().RegisterRoutes(r)
 	})
 
 	return router
 }
 
-// createOpsRouter creates the operations router for health and metrics
+// createOpsRouter creates the operations router for health, metrics and admin endpoints.
+// It is served on the ops port, which is not exposed to clients, so every module's
+// admin routes are mounted here.
 func (a *App) createOpsRouter() chi.Router {
 	router := chi.NewRouter()
 
@@ -186,6 +348,24 @@ func (a *App) createOpsRouter() chi.Router {
 
 	router.Handle(constants.RouteMetrics, promhttp.Handler())
 
+	// Administrative endpoints get the standard chain (request IDs, logging, body
+	// limits, JSON validation) that health and metrics do without
+	router.Route(constants.RouteAdminPrefix, func(r chi.Router) {
+		for _, mw := range interceptors.GetChiMiddleware() {
+			r.Use(mw)
+		}
+		a.Modules.Account.GetHandler().RegisterAdminRoutes(r)
+		a.Modules.Interest.GetHandler().RegisterAdminRoutes(r)
+		a.Modules.Reconcile.GetHandler().RegisterAdminRoutes(r)
+		a.Modules.Chain.GetHandler().RegisterAdminRoutes(r)
+		a.Modules.Audit.GetHandler().RegisterAdminRoutes(r)
+		a.Modules.Ledger.GetHandler().RegisterAdminRoutes(r)
+		a.Modules.Period.GetHandler().RegisterAdminRoutes(r)
+		a.Modules.Conservation.GetHandler().RegisterAdminRoutes(r)
+		a.Modules.Archive.GetHandler().RegisterAdminRoutes(r)
+		a.Modules.Journal.GetHandler().RegisterAdminRoutes(r)
+	})
+
 	return router
 }
 
@@ -234,6 +414,21 @@ func (a *App) Shutdown(ctx context.Context) {
 	// Stop idempotency cleanup worker
 	a.Modules.Idempotency.StopCleanupWorker()
 
+	// Stop balance snapshot worker
+	a.Modules.Snapshot.StopWorker()
+
+	// Stop interest worker
+	a.Modules.Interest.StopWorker()
+
+	// Stop balance reconciliation worker
+	a.Modules.Reconcile.StopWorker()
+
+	// Stop money conservation worker
+	a.Modules.Conservation.StopWorker()
+
+	// Stop transaction partition and archive worker
+	a.Modules.Archive.StopWorker()
+
 	// Wait for load balancer to drain connections
 	a.waitForConnectionDrain()
 
@@ -253,6 +448,11 @@ func (a *App) Shutdown(ctx context.Context) {
 	logger.Info(constants.LogMsgServiceStopped)
 }
 
+// Close releases the resources of an App created by InitializeForCommand
+func (a *App) Close() {
+	a.Database.Close()
+}
+
 // waitForConnectionDrain waits for load balancer to drain connections
 func (a *App) waitForConnectionDrain() {
 	delay := a.Config.App.ShutdownDelay
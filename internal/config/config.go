@@ -23,14 +23,22 @@ const (
 
 // Config holds all application configuration
 type Config struct {
-	App         AppConfig         `mapstructure:"app"`
-	Database    DatabaseConfig    `mapstructure:"database"`
-	Logging     LoggingConfig     `mapstructure:"logging"`
-	Metrics     MetricsConfig     `mapstructure:"metrics"`
-	Idempotency IdempotencyConfig `mapstructure:"idempotency"`
-	Security    SecurityConfig    `mapstructure:"security"`
-	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
-	Tracing     TracingConfig     `mapstructure:"tracing"`
+	App            AppConfig            `mapstructure:"app"`
+	Database       DatabaseConfig       `mapstructure:"database"`
+	Logging        LoggingConfig        `mapstructure:"logging"`
+	Metrics        MetricsConfig        `mapstructure:"metrics"`
+	Idempotency    IdempotencyConfig    `mapstructure:"idempotency"`
+	Security       SecurityConfig       `mapstructure:"security"`
+	RateLimit      RateLimitConfig      `mapstructure:"rate_limit"`
+	Tracing        TracingConfig        `mapstructure:"tracing"`
+	Account        AccountConfig        `mapstructure:"account"`
+	Snapshot       SnapshotConfig       `mapstructure:"snapshot"`
+	Interest       InterestConfig       `mapstructure:"interest"`
+	Reconciliation ReconciliationConfig `mapstructure:"reconciliation"`
+	Conservation   ConservationConfig   `mapstructure:"conservation"`
+	Archive        ArchiveConfig        `mapstructure:"archive"`
+	Journal        JournalConfig        `mapstructure:"journal"`
+	Alert          AlertConfig          `mapstructure:"alert"`
 }
 
 // AppConfig holds application-level configuration
@@ -99,6 +107,231 @@ type MetricsConfig struct {
 // IdempotencyConfig holds idempotency configuration
 type IdempotencyConfig struct {
 	TTL string `mapstructure:"ttl"`
+	// Backend selects where idempotency keys are kept ("postgres", "memory" or "tiered")
+	Backend string `mapstructure:"backend"`
+	// MemoryCapacity bounds the keys held in memory by the "memory" and "tiered" backends;
+	// the least recently used key is evicted beyond it
+	MemoryCapacity int `mapstructure:"memory_capacity"`
+}
+
+// GetTTL returns how long idempotency keys are kept
+func (c *IdempotencyConfig) GetTTL() time.Duration {
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil {
+		return 24 * time.Hour
+	}
+	return ttl
+}
+
+// GetMemoryCapacity returns the number of keys held in memory
+func (c *IdempotencyConfig) GetMemoryCapacity() int {
+	if c.MemoryCapacity <= 0 {
+		return 10000
+	}
+	return c.MemoryCapacity
+}
+
+// AccountConfig holds account module configuration
+type AccountConfig struct {
+	// IDGenerator selects how account IDs are allocated when the client omits one ("sequence" or "random")
+	IDGenerator string `mapstructure:"id_generator"`
+	// DefaultCurrency is assigned to accounts created without an explicit currency
+	DefaultCurrency string `mapstructure:"default_currency"`
+	// Currencies lists supported currencies; each gets a designated clearing account
+	Currencies []string `mapstructure:"currencies"`
+}
+
+// SnapshotConfig holds daily balance snapshot job configuration
+type SnapshotConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Cutoff is the local clock time ("HH:MM") at which a business day closes; "24:00" is midnight at its end
+	Cutoff string `mapstructure:"cutoff"`
+	// Timezone is the IANA zone the cutoff and business dates are expressed in
+	Timezone string `mapstructure:"timezone"`
+	// Grace is how long after the cutoff to wait so in-flight transfers can commit
+	Grace string `mapstructure:"grace"`
+	// Interval is how often the worker checks for business days that are due
+	Interval string `mapstructure:"interval"`
+	// CatchupDays bounds how many past days are snapshotted when no run has completed yet
+	CatchupDays int `mapstructure:"catchup_days"`
+}
+
+// GetGrace returns the grace period after the cutoff
+func (c *SnapshotConfig) GetGrace() time.Duration {
+	d, err := time.ParseDuration(c.Grace)
+	if err != nil {
+		return time.Minute
+	}
+	return d
+}
+
+// GetInterval returns the worker polling interval
+func (c *SnapshotConfig) GetInterval() time.Duration {
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil {
+		return 5 * time.Minute
+	}
+	return d
+}
+
+// InterestConfig holds interest accrual and payout job configuration
+type InterestConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is how often the worker accrues newly snapshotted business days and pays out closed periods
+	Interval string `mapstructure:"interval"`
+}
+
+// GetInterval returns the worker polling interval
+func (c *InterestConfig) GetInterval() time.Duration {
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil {
+		return 5 * time.Minute
+	}
+	return d
+}
+
+// ReconciliationConfig holds balance reconciliation job configuration
+type ReconciliationConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is how often the worker recomputes every account's balance from its history
+	Interval string `mapstructure:"interval"`
+	// BatchSize is how many accounts are read per query while streaming over the accounts table
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+// GetInterval returns the worker interval
+func (c *ReconciliationConfig) GetInterval() time.Duration {
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil {
+		return time.Hour
+	}
+	return d
+}
+
+// GetBatchSize returns the number of accounts read per query
+func (c *ReconciliationConfig) GetBatchSize() int {
+	if c.BatchSize <= 0 {
+		return 500
+	}
+	return c.BatchSize
+}
+
+// ConservationConfig holds money conservation check configuration
+type ConservationConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is how often the worker checks that balances match the money that entered and left
+	Interval string `mapstructure:"interval"`
+}
+
+// GetInterval returns the worker interval
+func (c *ConservationConfig) GetInterval() time.Duration {
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil {
+		return 5 * time.Minute
+	}
+	return d
+}
+
+// ArchiveConfig holds transaction partitioning and archival configuration
+type ArchiveConfig struct {
+	// Enabled turns archival on; upcoming partitions are created either way
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is how often the worker creates upcoming partitions and, when enabled, archives
+	// expired ones
+	Interval string `mapstructure:"interval"`
+	// PremakeMonths is how many months after the current one always have a partition
+	PremakeMonths int `mapstructure:"premake_months"`
+	// RetentionMonths is how many whole months before the current one stay in the database;
+	// 0 disables archival
+	RetentionMonths int `mapstructure:"retention_months"`
+	// Dir is the local directory archived partitions are exported to
+	Dir string `mapstructure:"dir"`
+}
+
+// GetInterval returns the worker interval
+func (c *ArchiveConfig) GetInterval() time.Duration {
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil {
+		return time.Hour
+	}
+	return d
+}
+
+// GetPremakeMonths returns how many months ahead partitions are created
+func (c *ArchiveConfig) GetPremakeMonths() int {
+	if c.PremakeMonths <= 0 {
+		return 3
+	}
+	return c.PremakeMonths
+}
+
+// GetRetentionMonths returns how many months are kept before archival, or 0 for never
+func (c *ArchiveConfig) GetRetentionMonths() int {
+	return max(c.RetentionMonths, 0)
+}
+
+// GetDir returns the archive export directory
+func (c *ArchiveConfig) GetDir() string {
+	if c.Dir == "" {
+		return "archive"
+	}
+	return c.Dir
+}
+
+// JournalConfig holds the CSV layout of journal exports
+type JournalConfig struct {
+	// Columns lists the CSV columns in order: date, gl_account, debit, credit, memo,
+	// transaction_id, currency or account_id
+	Columns []string `mapstructure:"columns"`
+	// Header writes the column names as the first row
+	Header bool `mapstructure:"header"`
+	// Delimiter is the single character that separates fields
+	Delimiter string `mapstructure:"delimiter"`
+	// DateFormat is the Go time layout of the date column
+	DateFormat string `mapstructure:"date_format"`
+}
+
+// GetColumns returns the CSV columns in order
+func (c *JournalConfig) GetColumns() []string {
+	if len(c.Columns) == 0 {
+		return []string{"date", "gl_account", "debit", "credit", "memo", "transaction_id"}
+	}
+	return c.Columns
+}
+
+// GetDelimiter returns the field delimiter
+func (c *JournalConfig) GetDelimiter() string {
+	if c.Delimiter == "" {
+		return ","
+	}
+	return c.Delimiter
+}
+
+// GetDateFormat returns the layout of the date column
+func (c *JournalConfig) GetDateFormat() string {
+	if c.DateFormat == "" {
+		return "2006-01-02"
+	}
+	return c.DateFormat
+}
+
+// AlertConfig holds alert notification configuration
+type AlertConfig struct {
+	// Notifier selects where fired alerts are delivered besides the alert log ("log", "webhook" or "memory")
+	Notifier string `mapstructure:"notifier"`
+	// WebhookURL receives a JSON POST per alert when Notifier is "webhook"
+	WebhookURL string `mapstructure:"webhook_url"`
+	// WebhookTimeout bounds each webhook delivery
+	WebhookTimeout string `mapstructure:"webhook_timeout"`
+}
+
+// GetWebhookTimeout returns the webhook delivery timeout
+func (c *AlertConfig) GetWebhookTimeout() time.Duration {
+	d, err := time.ParseDuration(c.WebhookTimeout)
+	if err != nil {
+		return 5 * time.Second
+	}
+	return d
 }
 
 // SecurityConfig holds security-related configuration
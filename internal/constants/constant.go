@@ -14,6 +14,8 @@ const (
 	HeaderRequestID      = "X-Request-ID"
 	HeaderIdempotencyKey = "X-Idempotency-Key"
 	HeaderContentType    = "Content-Type"
+	HeaderActor          = "X-Actor"
+	HeaderClientID       = "X-Client-ID"
 
 	// Content types
 	ContentTypeJSON = "application/json"
@@ -96,6 +98,13 @@ const (
 	MetricTransferFailed    = "transfers_failed_total"
 	MetricDBConnectionsOpen = "db_connections_open"
 	MetricDBConnectionsIdle = "db_connections_idle"
+	MetricLedgerDrift       = "ledger_drift_accounts"
+
+	MetricConservationDifference = "money_conservation_difference"
+	MetricConservationViolations = "money_conservation_violations"
+	MetricConservationLastCheck  = "money_conservation_last_check_timestamp_seconds"
+
+	MetricDefaultPartitionRows = "transactions_default_partition_rows"
 )
 
 // Metrics labels
@@ -104,6 +113,8 @@ const (
 	LabelPath       = "path"
 	LabelStatusCode = "status_code"
 	LabelReason     = "reason"
+	LabelCurrency   = "currency"
+	LabelScope      = "scope"
 )
 
 // Log message constants - static log messages
@@ -126,6 +137,8 @@ const (
 	LogMsgHTTPRequestCompleted     = "HTTP request completed"
 	LogMsgAccountCreatedViaHTTP    = "Account created via HTTP"
 	LogMsgTransactionCreatedHTTP   = "Transaction created via HTTP"
+	LogMsgDepositCreatedHTTP       = "Deposit created via HTTP"
+	LogMsgWithdrawalCreatedHTTP    = "Withdrawal created via HTTP"
 	LogMsgFailedToEncodeResponse   = "Failed to encode response"
 	LogMsgReadinessCheckFailed     = "Readiness check failed - database ping failed"
 	LogMsgServiceMarkedUnhealthy   = "Service marked as unhealthy"
@@ -136,22 +149,151 @@ const (
 	LogMsgFailedToUpdateSourceBal = "Failed to update source account balance"
 	LogMsgFailedToUpdateDestBal   = "Failed to update destination account balance"
 	LogMsgFailedToCreateTxRecord  = "Failed to create transaction record"
+	LogMsgFailedToWriteLedger     = "Failed to write ledger entries"
 	LogMsgFailedToCommitTx        = "Failed to commit transaction"
+	LogMsgTxParticipantFailed     = "Transaction participant failed, rolling back"
 	LogMsgTransferCompleted       = "Transfer completed successfully"
+	LogMsgCurrencyMismatch        = "Currency mismatch between accounts"
 
 	// Account core log messages
-	LogMsgFailedToCheckAcctExist = "Failed to check account existence"
-	LogMsgFailedToCreateAccount  = "Failed to create account"
-	LogMsgAccountCreated         = "Account created successfully"
-	LogMsgFailedToGetAccount     = "Failed to get account"
-	LogMsgFailedToGetForUpdate   = "Failed to get account for update"
-	LogMsgFailedToUpdateBalance  = "Failed to update account balance"
+	LogMsgFailedToCreateAccount    = "Failed to create account"
+	LogMsgFailedToAllocateAcctID   = "Failed to allocate account ID"
+	LogMsgGeneratedIDCollision     = "Generated account ID already in use, retrying"
+	LogMsgFailedToEnsureSystemAcct = "Failed to ensure system account"
+	LogMsgFailedToGetSystemAccount = "Failed to get system account"
+	LogMsgSystemAccountReady       = "System account ready"
+	LogMsgAccountCreated           = "Account created successfully"
+	LogMsgFailedToGetAccount       = "Failed to get account"
+	LogMsgFailedToGetForUpdate     = "Failed to get account for update"
+	LogMsgFailedToUpdateBalance    = "Failed to update account balance"
+	LogMsgFailedToGetBalanceAsOf   = "Failed to compute point-in-time balance"
+	LogMsgFailedToCheckChildren    = "Failed to check for sub-accounts"
+	LogMsgFailedToListChildren     = "Failed to list sub-accounts"
+	LogMsgFailedToGetRollup        = "Failed to compute roll-up balance"
+	LogMsgFailedToLockHierarchy    = "Failed to lock account hierarchy"
+	LogMsgFailedToCheckAncestry    = "Failed to check account ancestry"
+	LogMsgFailedToSetParent        = "Failed to set parent account"
+	LogMsgAccountMoved             = "Account moved to new parent"
+	LogMsgHierarchyCycle           = "Move rejected, would create a cycle"
+	LogMsgPostingToParentAccount   = "Posting rejected, account has sub-accounts"
+	LogMsgFailedToSetMinBalance    = "Failed to set minimum balance"
+	LogMsgMinimumBalanceSet        = "Minimum balance set"
+	LogMsgBelowMinimumBalance      = "Debit rejected, would breach minimum balance"
+	LogMsgFailedToBulkCreate       = "Failed to bulk create accounts"
+	LogMsgBulkCreateCompleted      = "Bulk account create completed"
+	LogMsgFailedToCreateAlias      = "Failed to create account alias"
+	LogMsgFailedToListAliases      = "Failed to list account aliases"
+	LogMsgFailedToResolveAlias     = "Failed to resolve account alias"
+	LogMsgAliasCreated             = "Account alias created"
+	LogMsgAliasResolved            = "Account alias resolved"
+
+	// Snapshot log messages
+	LogMsgSnapshotWorkerStarted    = "Balance snapshot worker started"
+	LogMsgSnapshotDateCompleted    = "Balance snapshots written for business date"
+	LogMsgSnapshotRunHeldElsewhere = "Balance snapshot run in progress on another replica"
+	LogMsgSnapshotRunFailed        = "Balance snapshot run failed"
+	LogMsgSnapshotLockFailed       = "Failed to acquire balance snapshot lock"
+	LogMsgFailedToGetSnapshotRun   = "Failed to read balance snapshot run"
+	LogMsgFailedToCreateSnapshots  = "Failed to write balance snapshots"
+	LogMsgFailedToListSnapshots    = "Failed to list balance snapshots"
+	LogMsgInvalidSnapshotConfig    = "Invalid balance snapshot configuration"
+
+	// Interest log messages
+	LogMsgInterestWorkerStarted    = "Interest worker started"
+	LogMsgInterestRunFailed        = "Interest run failed"
+	LogMsgInterestLockFailed       = "Failed to acquire interest lock"
+	LogMsgInterestRunHeldElsewhere = "Interest run in progress on another replica"
+	LogMsgInterestDateAccrued      = "Interest accrued for business date"
+	LogMsgInterestPaid             = "Interest paid"
+	LogMsgInterestPayoutFailed     = "Interest payout failed"
+	LogMsgInterestBackfillDone     = "Interest backfill completed"
+	LogMsgInterestProductCreated   = "Interest product created"
+	LogMsgInterestRateSet          = "Interest rate set"
+	LogMsgInterestProductAttached  = "Interest product attached to account"
+	LogMsgFailedToReadInterest     = "Failed to read interest data"
+	LogMsgFailedToWriteInterest    = "Failed to write interest data"
+
+	// Alert log messages
+	LogMsgAlertFired            = "Alert fired"
+	LogMsgAlertRuleCreated      = "Alert rule created"
+	LogMsgAlertRuleDeleted      = "Alert rule deleted"
+	LogMsgAlertNotifyFailed     = "Failed to deliver alert notification"
+	LogMsgAlertEvaluationFailed = "Failed to evaluate alert rules"
+	LogMsgFailedToReadAlerts    = "Failed to read alert data"
+	LogMsgFailedToWriteAlerts   = "Failed to write alert data"
+	LogMsgInvalidAlertConfig    = "Invalid alert configuration"
+
+	// Reconciliation log messages
+	LogMsgReconcileWorkerStarted = "Balance reconciliation worker started"
+	LogMsgReconcileCompleted     = "Balance reconciliation completed"
+	LogMsgReconcileRunFailed     = "Balance reconciliation run failed"
+	LogMsgBalanceDriftDetected   = "Account balance drift detected"
+	LogMsgFailedToScanBalances   = "Failed to scan account balances"
+
+	// Money conservation log messages
+	LogMsgConservationWorkerStarted = "Money conservation worker started"
+	LogMsgConservationChecked       = "Money conservation check completed"
+	LogMsgConservationViolated      = "Money conservation violated"
+	LogMsgConservationCheckFailed   = "Money conservation check failed"
+	LogMsgFailedToSumMoney          = "Failed to sum balances and money flows"
+
+	// Transaction archive log messages
+	LogMsgArchiveWorkerStarted     = "Transaction archive worker started"
+	LogMsgPartitionWorkerStarted   = "Transaction partition worker started; archival disabled"
+	LogMsgDefaultPartitionNotEmpty = "Transactions fell into the default partition; their month has no partition"
+	LogMsgPartitionCreated         = "Transaction partition created"
+	LogMsgPartitionDetached        = "Transaction partition detached"
+	LogMsgPartitionArchived        = "Transaction partition exported and dropped"
+	LogMsgArchiveLockedElsewhere   = "Transaction archive lock held by another instance; skipping"
+	LogMsgArchiveRunFailed         = "Transaction archive run failed"
+	LogMsgPartitionChainBroken     = "Refusing to archive partition with a broken hash chain"
+	LogMsgFailedToManagePartitions = "Failed to manage transaction partitions"
+
+	// Hash chain log messages
+	LogMsgChainVerified     = "Transaction hash chain verified"
+	LogMsgChainBroken       = "Transaction hash chain broken"
+	LogMsgFailedToReadChain = "Failed to read transaction hash chain"
+
+	// Audit log messages
+	LogMsgFailedToWriteAudit = "Failed to write audit events"
+	LogMsgFailedToReadAudit  = "Failed to read audit events"
+
+	// Trial balance log messages
+	LogMsgTrialBalanceImbalance   = "Trial balance does not balance"
+	LogMsgFailedToSumTrialBalance = "Failed to sum trial balance"
+
+	// Accounting period log messages
+	LogMsgPeriodClosed          = "Accounting period closed"
+	LogMsgPostingInClosedPeriod = "Posting rejected: effective date in closed accounting period"
+	LogMsgFailedToClosePeriod   = "Failed to close accounting period"
+	LogMsgFailedToReadPeriods   = "Failed to read accounting periods"
+
+	// Statement log messages
+	LogMsgStatementExported      = "Account statement exported"
+	LogMsgStatementStreamAborted = "Account statement stream aborted after response started"
+	LogMsgFailedToReadStatement  = "Failed to read statement entries"
+	LogMsgFailedToBeginStatement = "Failed to open statement transaction"
+
+	// Journal export log messages
+	LogMsgJournalExported      = "Journal exported"
+	LogMsgJournalDownloaded    = "Journal export downloaded again"
+	LogMsgJournalStreamAborted = "Journal stream aborted after response started"
+	LogMsgJournalUnmapped      = "Journal export refused: legs without a GL account mapping"
+	LogMsgJournalChanged       = "Journal export no longer matches its checksum"
+	LogMsgGLMappingSet         = "GL account mapping set"
+	LogMsgFailedToReadJournal  = "Failed to read journal data"
+	LogMsgFailedToWriteJournal = "Failed to write journal data"
+	LogMsgInvalidJournalConfig = "Invalid journal export configuration"
+
+	// Idempotency log messages
+	LogMsgInvalidIdempotencyConfig = "Invalid idempotency configuration"
 
 	// Validation debug log messages
 	LogMsgInvalidAccountIDCreate  = "Invalid account ID in create request"
 	LogMsgInvalidAccountIDGet     = "Invalid account ID in get request"
 	LogMsgInvalidDecimalFormat    = "Invalid decimal format for initial balance"
 	LogMsgNegativeBalanceProvided = "Negative initial balance provided"
+	LogMsgUnsupportedCurrency     = "Unsupported currency in create request"
 	LogMsgAccountNotFoundDebug    = "Account not found"
 
 	// HTTP handler log messages
@@ -174,6 +316,12 @@ const (
 	DefaultRequestTimeoutSeconds = 30
 )
 
+// StreamingRouteSuffixes identifies GET routes that stream their response and are
+// therefore exempt from the request timeout
+var StreamingRouteSuffixes = []string{
+	"/statement",
+}
+
 // CORS headers
 const (
 	HeaderAccessControlAllowOrigin  = "Access-Control-Allow-Origin"
@@ -182,17 +330,21 @@ const (
 
 	CORSAllowOriginAll     = "*"
 	CORSAllowMethodsAll    = "GET, POST, PUT, DELETE, OPTIONS"
-	CORSAllowHeadersCommon = "Content-Type, Authorization, X-Idempotency-Key, X-Request-ID"
+	CORSAllowHeadersCommon = "Content-Type, Authorization, X-Idempotency-Key, X-Request-ID, X-Actor, X-Client-ID"
 )
 
 // Error response messages for interceptors
 const (
-	ErrMsgInternalServerError   = "Internal server error"
-	ErrMsgRequestTimeout        = "Request timeout"
-	ErrMsgIdempotencyKeyTooLong = "Idempotency key too long"
-	ErrCodeInternalError        = "INTERNAL_ERROR"
-	ErrCodeTimeout              = "TIMEOUT"
-	ErrCodeInvalidIdempotency   = "INVALID_IDEMPOTENCY_KEY"
+	ErrMsgInternalServerError      = "Internal server error"
+	ErrMsgRequestTimeout           = "Request timeout"
+	ErrMsgIdempotencyKeyTooLong    = "Idempotency key too long"
+	ErrMsgIdempotencyKeyReused     = "Idempotency key was already used for a different request"
+	ErrMsgRequestBodyUnreadable    = "Request body could not be read"
+	ErrMsgIdempotencyKeyInProgress = "A request with this idempotency key is still in progress"
+	ErrMsgIdempotencyKeyTaken      = "Idempotency key is already used by another client or route; use a different key"
+	ErrCodeInternalError           = "INTERNAL_ERROR"
+	ErrCodeTimeout                 = "TIMEOUT"
+	ErrCodeInvalidIdempotency      = "INVALID_IDEMPOTENCY_KEY"
 )
 
 // Path normalization placeholders
@@ -228,6 +380,69 @@ const (
 	LogFieldMaxConnections = "max_connections"
 	LogFieldSourceAccount  = "source_account"
 	LogFieldDestAccount    = "destination_account"
+	LogFieldCurrency       = "currency"
+	LogFieldAccountKind    = "account_kind"
+	LogFieldProductID      = "product_id"
+	LogFieldAccrualDate    = "accrual_date"
+	LogFieldPeriodEnd      = "period_end"
+	LogFieldPayoutID       = "payout_id"
+	LogFieldEffectiveFrom  = "effective_from"
+	LogFieldAnnualRate     = "annual_rate"
+	LogFieldFrom           = "from"
+	LogFieldTo             = "to"
+	LogFieldDaysAccrued    = "days_accrued"
+	LogFieldAccrualCount   = "accrual_count"
+	LogFieldPayoutCount    = "payout_count"
+	LogFieldTxType         = "transaction_type"
+	LogFieldAsOf           = "as_of"
+	LogFieldBusinessDate   = "business_date"
+	LogFieldSnapshotDate   = "snapshot_date"
+	LogFieldCutoffAt       = "cutoff_at"
+	LogFieldAccountCount   = "account_count"
+	LogFieldFormat         = "format"
+	LogFieldEntryCount     = "entry_count"
+	LogFieldParentID       = "parent_account_id"
+	LogFieldOldParentID    = "old_parent_account_id"
+	LogFieldMinimumBalance = "minimum_balance"
+	LogFieldBulkMode       = "mode"
+	LogFieldCreatedCount   = "created_count"
+	LogFieldFailedCount    = "failed_count"
+	LogFieldAlias          = "alias"
+	LogFieldAliasKind      = "alias_kind"
+	LogFieldRuleID         = "rule_id"
+	LogFieldAlertID        = "alert_id"
+	LogFieldAlertKind      = "alert_kind"
+	LogFieldThreshold      = "threshold"
+	LogFieldNotifier       = "notifier"
+	LogFieldComputedBal    = "computed_balance"
+	LogFieldLedgerBal      = "ledger_balance"
+	LogFieldScannedCount   = "scanned_count"
+	LogFieldDriftCount     = "drift_count"
+	LogFieldAfterAccount   = "after_account_id"
+	LogFieldChainSeq       = "chain_seq"
+	LogFieldChainHash      = "chain_hash"
+	LogFieldReason         = "reason"
+	LogFieldAuditAction    = "audit_action"
+	LogFieldEventCount     = "event_count"
+	LogFieldDebitTotal     = "debit_total"
+	LogFieldCreditTotal    = "credit_total"
+	LogFieldPeriod         = "period"
+	LogFieldEffectiveDate  = "effective_date"
+	LogFieldActor          = "actor"
+	LogFieldScope          = "scope"
+	LogFieldExpected       = "expected"
+	LogFieldActual         = "actual"
+	LogFieldViolations     = "violations"
+	LogFieldPartition      = "partition"
+	LogFieldRowCount       = "row_count"
+	LogFieldFilePath       = "file_path"
+	LogFieldBrokenLinks    = "broken_links"
+	LogFieldExportID       = "export_id"
+	LogFieldFromDate       = "from_date"
+	LogFieldToDate         = "to_date"
+	LogFieldGLCategory     = "gl_category"
+	LogFieldGLCode         = "gl_code"
+	LogFieldUnmapped       = "unmapped"
 )
 
 // Database log messages
@@ -270,8 +485,9 @@ const (
 
 // Transaction repository log messages
 const (
-	LogMsgFailedToCreateTx   = "Failed to create transaction"
-	LogMsgTransactionCreated = "Transaction created"
+	LogMsgFailedToCreateTx     = "Failed to create transaction"
+	LogMsgTransactionCreated   = "Transaction created"
+	LogMsgFailedToInsertLedger = "Failed to insert ledger entries"
 )
 
 // Health module route paths
@@ -281,6 +497,9 @@ const (
 	RouteMetrics     = "/metrics"
 )
 
+// RouteAdminPrefix groups administrative endpoints on the ops server
+const RouteAdminPrefix = "/admin"
+
 // Server name constants
 const (
 	ServerNameMain = "main"
@@ -310,6 +529,7 @@ const (
 
 	// Content-Type validation
 	ContentTypeJSONPrefix = "application/json"
+	ContentTypeCSVPrefix  = "text/csv"
 )
 
 // HTTP status code for unsupported media type
@@ -319,7 +539,7 @@ const (
 
 // Error messages for security middlewares
 const (
-	ErrMsgUnsupportedMediaType = "Content-Type must be application/json"
+	ErrMsgUnsupportedMediaType = "Content-Type must be application/json or text/csv"
 	ErrMsgRequestBodyTooLarge  = "Request body too large"
 	ErrCodeUnsupportedMedia    = "UNSUPPORTED_MEDIA_TYPE"
 	ErrCodeBodyTooLarge        = "REQUEST_BODY_TOO_LARGE"
@@ -412,3 +632,17 @@ const (
 	LogFieldEndpoint   = "endpoint"
 	LogFieldSampleRate = "sample_rate"
 )
+
+// Audit actors. Requests name their actor in the X-Actor header; work not started by a
+// request is attributed to the process that ran it.
+const (
+	// ActorAnonymous is recorded for requests without an X-Actor header
+	ActorAnonymous = "anonymous"
+	// ActorSystem is recorded for background workers and startup
+	ActorSystem = "system"
+	// ActorCLI is recorded for operator commands run from the command line
+	ActorCLI = "cli"
+
+	// MaxActorLength matches audit_events.actor
+	MaxActorLength = 255
+)
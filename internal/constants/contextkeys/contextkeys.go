@@ -15,14 +15,23 @@ const (
 	// StartTime is the key for the request start time in context
 	StartTime ContextKey = "start_time"
 
-	// IdempotencyKey is the key for the idempotency key in context
+	// IdempotencyKey is the key for the idempotency key reserved for the request in context
 	IdempotencyKey ContextKey = "idempotency_key"
 
+	// TxParticipant is the key for the request's participant in a posting's database transaction
+	TxParticipant ContextKey = "tx_participant"
+
 	// TraceID is the key for the OpenTelemetry trace ID in context
 	TraceID ContextKey = "trace_id"
 
 	// SpanID is the key for the OpenTelemetry span ID in context
 	SpanID ContextKey = "span_id"
+
+	// Actor is the key for who is making the request, recorded in audit events
+	Actor ContextKey = "actor"
+
+	// ClientIP is the key for the client IP address, recorded in audit events
+	ClientIP ContextKey = "client_ip"
 )
 
 // String returns the string representation of the context key
@@ -0,0 +1,2 @@
+-- Drop account ID sequence
+DROP SEQUENCE IF EXISTS account_id_seq;
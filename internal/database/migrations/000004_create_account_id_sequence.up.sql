@@ -0,0 +1,8 @@
+-- Create sequence for server-generated account IDs
+CREATE SEQUENCE IF NOT EXISTS account_id_seq AS BIGINT START WITH 1;
+
+-- Start allocating above any client-provided IDs that already exist
+SELECT setval('account_id_seq', COALESCE((SELECT MAX(account_id) FROM accounts), 0) + 1, false);
+
+-- Add comment for documentation
+COMMENT ON SEQUENCE account_id_seq IS 'Allocates account IDs when the client omits account_id';
@@ -0,0 +1,14 @@
+-- Drop funding history (deposits/withdrawals reference clearing accounts) and transaction type
+DELETE FROM transactions WHERE type <> 'transfer';
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS valid_transaction_type;
+ALTER TABLE transactions DROP COLUMN IF EXISTS type;
+
+-- Drop clearing accounts and restore non-negative balance constraint for all accounts
+DROP INDEX IF EXISTS idx_accounts_clearing_currency;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS positive_balance;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS valid_account_kind;
+DELETE FROM accounts WHERE kind = 'clearing';
+ALTER TABLE accounts DROP COLUMN IF EXISTS kind;
+ALTER TABLE accounts DROP COLUMN IF EXISTS currency;
+ALTER TABLE accounts
+    ADD CONSTRAINT positive_balance CHECK (balance >= 0);
@@ -0,0 +1,27 @@
+-- Add currency and kind to accounts
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ADD COLUMN IF NOT EXISTS kind VARCHAR(16) NOT NULL DEFAULT 'customer';
+
+ALTER TABLE accounts
+    ADD CONSTRAINT valid_account_kind CHECK (kind IN ('customer', 'clearing'));
+
+-- Clearing accounts mirror money held outside the system, so only customer accounts must stay non-negative
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS positive_balance;
+ALTER TABLE accounts
+    ADD CONSTRAINT positive_balance CHECK (balance >= 0 OR kind <> 'customer');
+
+-- Exactly one designated clearing account per currency
+CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_clearing_currency ON accounts(currency) WHERE kind = 'clearing';
+
+-- Record what kind of money movement each transaction is
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS type VARCHAR(16) NOT NULL DEFAULT 'transfer';
+
+ALTER TABLE transactions
+    ADD CONSTRAINT valid_transaction_type CHECK (type IN ('transfer', 'deposit', 'withdrawal'));
+
+-- Add comments for documentation
+COMMENT ON COLUMN accounts.currency IS 'ISO 4217 currency code of the account';
+COMMENT ON COLUMN accounts.kind IS 'customer for regular accounts, clearing for the per-currency external funding account';
+COMMENT ON COLUMN transactions.type IS 'transfer between accounts, or deposit/withdrawal against a clearing account';
@@ -0,0 +1,14 @@
+-- Drop point-in-time balance indexes
+DROP INDEX IF EXISTS idx_transactions_destination_created_at;
+DROP INDEX IF EXISTS idx_transactions_source_created_at;
+
+-- Drop opening entries and restore the mandatory source account
+DELETE FROM transactions WHERE type = 'opening';
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS opening_has_no_source;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS valid_transaction_type;
+ALTER TABLE transactions
+    ADD CONSTRAINT valid_transaction_type CHECK (type IN ('transfer', 'deposit', 'withdrawal'));
+ALTER TABLE transactions ALTER COLUMN source_account_id SET NOT NULL;
+
+COMMENT ON COLUMN transactions.source_account_id IS NULL;
+COMMENT ON COLUMN transactions.type IS 'transfer between accounts, or deposit/withdrawal against a clearing account';
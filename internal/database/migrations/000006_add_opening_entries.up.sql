@@ -0,0 +1,30 @@
+-- Opening entries record an account's initial balance as a credit with no source account,
+-- so any past balance can be rebuilt from transaction history alone
+ALTER TABLE transactions ALTER COLUMN source_account_id DROP NOT NULL;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS valid_transaction_type;
+ALTER TABLE transactions
+    ADD CONSTRAINT valid_transaction_type CHECK (type IN ('transfer', 'deposit', 'withdrawal', 'opening'));
+
+ALTER TABLE transactions
+    ADD CONSTRAINT opening_has_no_source CHECK ((type = 'opening') = (source_account_id IS NULL));
+
+-- Backfill opening entries for existing accounts: the opening balance is the current
+-- balance with every recorded movement reversed, dated at account creation
+INSERT INTO transactions (source_account_id, destination_account_id, amount, created_at, type)
+SELECT NULL, a.account_id, opening.amount, a.created_at, 'opening'
+FROM accounts a
+CROSS JOIN LATERAL (
+    SELECT a.balance
+        - COALESCE((SELECT SUM(t.amount) FROM transactions t WHERE t.destination_account_id = a.account_id), 0)
+        + COALESCE((SELECT SUM(t.amount) FROM transactions t WHERE t.source_account_id = a.account_id), 0) AS amount
+) opening
+WHERE opening.amount > 0;
+
+-- Create indexes for point-in-time balance queries
+CREATE INDEX IF NOT EXISTS idx_transactions_source_created_at ON transactions(source_account_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_destination_created_at ON transactions(destination_account_id, created_at);
+
+-- Add comments for documentation
+COMMENT ON COLUMN transactions.source_account_id IS 'Account debited; NULL only for opening entries';
+COMMENT ON COLUMN transactions.type IS 'transfer between accounts, deposit/withdrawal against a clearing account, or opening balance at account creation';
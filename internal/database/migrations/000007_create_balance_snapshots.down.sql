@@ -0,0 +1,4 @@
+-- Drop balance snapshot tables
+DROP TABLE IF EXISTS balance_snapshot_runs;
+DROP INDEX IF EXISTS idx_balance_snapshots_account_cutoff;
+DROP TABLE IF EXISTS balance_snapshots;
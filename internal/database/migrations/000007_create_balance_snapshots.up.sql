@@ -0,0 +1,26 @@
+-- Create balance_snapshots table: one end-of-day balance per account per business date
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+    account_id BIGINT NOT NULL REFERENCES accounts(account_id),
+    snapshot_date DATE NOT NULL,
+    balance DECIMAL(19, 8) NOT NULL,
+    cutoff_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, snapshot_date)
+);
+
+-- Create index for point-in-time balance lookups (latest snapshot at or before an instant)
+CREATE INDEX IF NOT EXISTS idx_balance_snapshots_account_cutoff ON balance_snapshots(account_id, cutoff_at);
+
+-- Create balance_snapshot_runs table: marks a business date as fully snapshotted
+CREATE TABLE IF NOT EXISTS balance_snapshot_runs (
+    snapshot_date DATE PRIMARY KEY,
+    cutoff_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    account_count BIGINT NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Add comments for documentation
+COMMENT ON TABLE balance_snapshots IS 'End-of-day account balances written by the daily snapshot job';
+COMMENT ON COLUMN balance_snapshots.snapshot_date IS 'Business date in the configured snapshot time zone';
+COMMENT ON COLUMN balance_snapshots.cutoff_at IS 'Instant the business date closed; the balance includes transactions up to and including it';
+COMMENT ON TABLE balance_snapshot_runs IS 'Business dates for which every account has been snapshotted';
@@ -0,0 +1,5 @@
+-- Drop account hierarchy
+DROP INDEX IF EXISTS idx_accounts_parent_account_id;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS parent_only_for_customers;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS parent_is_not_self;
+ALTER TABLE accounts DROP COLUMN IF EXISTS parent_account_id;
@@ -0,0 +1,16 @@
+-- Allow accounts to be nested under a parent account (e.g. a customer's wallet pockets)
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS parent_account_id BIGINT REFERENCES accounts(account_id);
+
+ALTER TABLE accounts
+    ADD CONSTRAINT parent_is_not_self CHECK (parent_account_id <> account_id);
+
+-- Only customer accounts take part in hierarchies
+ALTER TABLE accounts
+    ADD CONSTRAINT parent_only_for_customers CHECK (parent_account_id IS NULL OR kind = 'customer');
+
+-- Create index for listing children and walking subtrees
+CREATE INDEX IF NOT EXISTS idx_accounts_parent_account_id ON accounts(parent_account_id) WHERE parent_account_id IS NOT NULL;
+
+-- Add comments for documentation
+COMMENT ON COLUMN accounts.parent_account_id IS 'Parent account in the hierarchy; NULL for root accounts. Only leaf accounts can be posted to';
@@ -0,0 +1,3 @@
+-- Drop minimum balance
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS non_negative_minimum_balance;
+ALTER TABLE accounts DROP COLUMN IF EXISTS minimum_balance;
@@ -0,0 +1,9 @@
+-- Add a per-account floor that debits may not take the balance below
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS minimum_balance DECIMAL(19, 8) NOT NULL DEFAULT 0;
+
+ALTER TABLE accounts
+    ADD CONSTRAINT non_negative_minimum_balance CHECK (minimum_balance >= 0);
+
+-- Add comments for documentation
+COMMENT ON COLUMN accounts.minimum_balance IS 'Floor (or reserved amount) that debits may not take the balance below; enforced when posting. May exceed the current balance, in which case debits are refused until the account is topped up';
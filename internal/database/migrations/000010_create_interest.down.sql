@@ -0,0 +1,26 @@
+-- Drop interest tables
+DROP TABLE IF EXISTS interest_accrual_runs;
+DROP INDEX IF EXISTS idx_interest_accruals_unpaid;
+DROP TABLE IF EXISTS interest_accruals;
+DROP INDEX IF EXISTS idx_interest_payouts_account;
+DROP TABLE IF EXISTS interest_payouts;
+DROP TABLE IF EXISTS account_interest;
+DROP TABLE IF EXISTS interest_rates;
+DROP TABLE IF EXISTS interest_products;
+
+-- Drop interest postings and restore the previous transaction types
+DELETE FROM transactions WHERE type = 'interest';
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS valid_transaction_type;
+ALTER TABLE transactions
+    ADD CONSTRAINT valid_transaction_type CHECK (type IN ('transfer', 'deposit', 'withdrawal', 'opening'));
+
+-- Drop interest-expense accounts and restore the previous account kinds
+DROP INDEX IF EXISTS idx_accounts_interest_expense_currency;
+DELETE FROM balance_snapshots WHERE account_id IN (SELECT account_id FROM accounts WHERE kind = 'interest_expense');
+DELETE FROM accounts WHERE kind = 'interest_expense';
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS valid_account_kind;
+ALTER TABLE accounts
+    ADD CONSTRAINT valid_account_kind CHECK (kind IN ('customer', 'clearing'));
+
+COMMENT ON COLUMN accounts.kind IS 'customer for regular accounts, clearing for the per-currency external funding account';
+COMMENT ON COLUMN transactions.type IS 'transfer between accounts, deposit/withdrawal against a clearing account, or opening balance at account creation';
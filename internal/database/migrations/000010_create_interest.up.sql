@@ -0,0 +1,96 @@
+-- Interest payouts are funded from a designated interest-expense account per currency
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS valid_account_kind;
+ALTER TABLE accounts
+    ADD CONSTRAINT valid_account_kind CHECK (kind IN ('customer', 'clearing', 'interest_expense'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_interest_expense_currency ON accounts(currency) WHERE kind = 'interest_expense';
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS valid_transaction_type;
+ALTER TABLE transactions
+    ADD CONSTRAINT valid_transaction_type CHECK (type IN ('transfer', 'deposit', 'withdrawal', 'opening', 'interest'));
+
+-- Create interest_products table: how interest on an account is computed and paid
+CREATE TABLE IF NOT EXISTS interest_products (
+    product_id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE,
+    day_count VARCHAR(8) NOT NULL,
+    rounding VARCHAR(16) NOT NULL,
+    payout_frequency VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT valid_day_count CHECK (day_count IN ('ACT/365', '30/360')),
+    CONSTRAINT valid_rounding CHECK (rounding IN ('half_even', 'half_up', 'down')),
+    CONSTRAINT valid_payout_frequency CHECK (payout_frequency IN ('monthly', 'quarterly', 'annually'))
+);
+
+-- Create interest_rates table: a product's rate schedule, each rate applying from its date until the next one
+CREATE TABLE IF NOT EXISTS interest_rates (
+    product_id BIGINT NOT NULL REFERENCES interest_products(product_id),
+    effective_from DATE NOT NULL,
+    annual_rate DECIMAL(10, 8) NOT NULL,
+    PRIMARY KEY (product_id, effective_from),
+    CONSTRAINT non_negative_rate CHECK (annual_rate >= 0)
+);
+
+-- Create account_interest table: the product an account earns interest under
+CREATE TABLE IF NOT EXISTS account_interest (
+    account_id BIGINT PRIMARY KEY REFERENCES accounts(account_id),
+    product_id BIGINT NOT NULL REFERENCES interest_products(product_id),
+    effective_from DATE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create interest_payouts table: one posting of accrued interest per account per period
+CREATE TABLE IF NOT EXISTS interest_payouts (
+    payout_id UUID PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts(account_id),
+    period_end DATE NOT NULL,
+    amount DECIMAL(19, 8) NOT NULL,
+    accrual_count INTEGER NOT NULL,
+    transaction_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for listing an account's payouts
+CREATE INDEX IF NOT EXISTS idx_interest_payouts_account ON interest_payouts(account_id, period_end);
+
+-- Create interest_accruals table: one day's interest per account, computed from its end-of-day balance
+CREATE TABLE IF NOT EXISTS interest_accruals (
+    account_id BIGINT NOT NULL REFERENCES accounts(account_id),
+    accrual_date DATE NOT NULL,
+    product_id BIGINT NOT NULL REFERENCES interest_products(product_id),
+    balance DECIMAL(19, 8) NOT NULL,
+    annual_rate DECIMAL(10, 8) NOT NULL,
+    day_count VARCHAR(8) NOT NULL,
+    days INTEGER NOT NULL,
+    amount DECIMAL(19, 8) NOT NULL,
+    period_end DATE NOT NULL,
+    payout_id UUID REFERENCES interest_payouts(payout_id) DEFERRABLE INITIALLY DEFERRED,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, accrual_date)
+);
+
+-- Create index for finding accruals that are still waiting to be paid out
+CREATE INDEX IF NOT EXISTS idx_interest_accruals_unpaid ON interest_accruals(period_end, account_id) WHERE payout_id IS NULL;
+
+-- Create interest_accrual_runs table: marks a business date as fully accrued
+CREATE TABLE IF NOT EXISTS interest_accrual_runs (
+    accrual_date DATE PRIMARY KEY,
+    account_count BIGINT NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Add comments for documentation
+COMMENT ON COLUMN accounts.kind IS 'customer for regular accounts, clearing for the per-currency external funding account, interest_expense for the per-currency account interest is paid from';
+COMMENT ON COLUMN transactions.type IS 'transfer between accounts, deposit/withdrawal against a clearing account, opening balance at account creation, or interest paid from an interest-expense account';
+COMMENT ON TABLE interest_products IS 'Interest products: day-count convention, rounding rule and payout frequency';
+COMMENT ON COLUMN interest_products.day_count IS 'ACT/365 counts actual days over a 365-day year; 30/360 counts 30-day months over a 360-day year (bond basis)';
+COMMENT ON COLUMN interest_products.rounding IS 'How each daily accrual is rounded to 8 decimal places';
+COMMENT ON COLUMN interest_rates.annual_rate IS 'Nominal annual rate as a fraction (0.035 is 3.5%)';
+COMMENT ON COLUMN account_interest.effective_from IS 'First business date the account accrues interest under the product';
+COMMENT ON TABLE interest_accruals IS 'Daily interest accruals written by the interest job from end-of-day balance snapshots';
+COMMENT ON COLUMN interest_accruals.days IS 'Days the accrual date counts for under the day-count convention (0 to 3 for 30/360)';
+COMMENT ON COLUMN interest_accruals.period_end IS 'Last business date of the payout period the accrual belongs to';
+COMMENT ON COLUMN interest_accruals.payout_id IS 'Payout that paid this accrual; NULL until the period is paid';
+COMMENT ON COLUMN interest_payouts.transaction_id IS 'Interest transaction that paid the amount; NULL when the period accrued nothing. Not a foreign key so that transactions can be partitioned';
+COMMENT ON TABLE interest_payouts IS 'Interest paid per account per period. Accruals backfilled into an already-paid period are paid by a further payout for the same period';
+COMMENT ON TABLE interest_accrual_runs IS 'Business dates for which every interest-bearing account has been accrued';
@@ -0,0 +1,3 @@
+-- Drop account aliases
+DROP INDEX IF EXISTS idx_account_aliases_account_id;
+DROP TABLE IF EXISTS account_aliases;
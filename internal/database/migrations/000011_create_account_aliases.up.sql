@@ -0,0 +1,16 @@
+-- Create account_aliases table: unique names clients can address accounts by instead of numeric IDs
+CREATE TABLE IF NOT EXISTS account_aliases (
+    alias VARCHAR(254) PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts(account_id),
+    kind VARCHAR(8) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT valid_alias_kind CHECK (kind IN ('handle', 'email', 'iban'))
+);
+
+-- Create index for listing an account's aliases
+CREATE INDEX IF NOT EXISTS idx_account_aliases_account_id ON account_aliases(account_id);
+
+-- Add comments for documentation
+COMMENT ON TABLE account_aliases IS 'Aliases that transfers may use in place of account IDs';
+COMMENT ON COLUMN account_aliases.alias IS 'Canonical form: handles are lower case with a leading @, emails are lower case, IBANs are upper case without spaces';
+COMMENT ON COLUMN account_aliases.kind IS 'handle, email, or iban for a service-generated IBAN-style number with ISO 7064 check digits';
@@ -0,0 +1,5 @@
+-- Drop alert rules and the alert log
+DROP INDEX IF EXISTS idx_alerts_account_id;
+DROP TABLE IF EXISTS alerts;
+DROP INDEX IF EXISTS idx_alert_rules_account_id;
+DROP TABLE IF EXISTS alert_rules;
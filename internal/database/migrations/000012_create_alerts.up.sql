@@ -0,0 +1,37 @@
+-- Create alert_rules table: conditions on an account that raise an alert when a posting meets them
+CREATE TABLE IF NOT EXISTS alert_rules (
+    rule_id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts(account_id),
+    kind VARCHAR(16) NOT NULL,
+    threshold DECIMAL(19, 8) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT valid_alert_rule_kind CHECK (kind IN ('low_balance', 'large_debit'))
+);
+
+-- Create index for loading an account's rules after each posting
+CREATE INDEX IF NOT EXISTS idx_alert_rules_account_id ON alert_rules(account_id);
+
+-- Create alerts table: the log of fired alerts. The rule's kind and threshold are copied
+-- so the log still reads correctly after the rule is deleted.
+CREATE TABLE IF NOT EXISTS alerts (
+    alert_id BIGSERIAL PRIMARY KEY,
+    rule_id BIGINT REFERENCES alert_rules(rule_id) ON DELETE SET NULL,
+    account_id BIGINT NOT NULL REFERENCES accounts(account_id),
+    kind VARCHAR(16) NOT NULL,
+    threshold DECIMAL(19, 8) NOT NULL,
+    transaction_id UUID NOT NULL,
+    amount DECIMAL(19, 8) NOT NULL,
+    balance DECIMAL(19, 8) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for listing an account's alerts, newest first
+CREATE INDEX IF NOT EXISTS idx_alerts_account_id ON alerts(account_id, alert_id DESC);
+
+-- Add comments for documentation
+COMMENT ON TABLE alert_rules IS 'Per-account alert conditions, evaluated after each committed transfer, deposit or withdrawal';
+COMMENT ON COLUMN alert_rules.kind IS 'low_balance: the balance falls below threshold; large_debit: a single debit exceeds threshold';
+COMMENT ON TABLE alerts IS 'Fired alerts; rule_id is cleared when the rule is deleted';
+COMMENT ON COLUMN alerts.transaction_id IS 'Posting that fired the alert';
+COMMENT ON COLUMN alerts.amount IS 'Amount debited by the posting';
+COMMENT ON COLUMN alerts.balance IS 'Account balance after the posting';
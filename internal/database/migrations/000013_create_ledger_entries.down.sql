@@ -0,0 +1,5 @@
+-- Drop ledger_entries table and its immutability trigger
+DROP TRIGGER IF EXISTS ledger_entries_immutable ON ledger_entries;
+DROP FUNCTION IF EXISTS reject_ledger_entry_change();
+DROP INDEX IF EXISTS idx_ledger_entries_account_id;
+DROP TABLE IF EXISTS ledger_entries;
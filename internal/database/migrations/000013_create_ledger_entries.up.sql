@@ -0,0 +1,55 @@
+-- Create ledger_entries table: one immutable row per side of every posting, carrying the
+-- account's running balance so accounts.balance can be proven from the entries alone.
+-- transaction_id deliberately has no foreign key so transactions can be archived independently.
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    entry_id BIGSERIAL PRIMARY KEY,
+    transaction_id UUID NOT NULL,
+    account_id BIGINT NOT NULL REFERENCES accounts(account_id),
+    direction VARCHAR(6) NOT NULL,
+    amount DECIMAL(19, 8) NOT NULL,
+    balance_after DECIMAL(19, 8) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT valid_ledger_direction CHECK (direction IN ('debit', 'credit')),
+    CONSTRAINT positive_ledger_amount CHECK (amount > 0),
+    CONSTRAINT unique_ledger_side UNIQUE (transaction_id, direction)
+);
+
+-- Create index for walking an account's entries in posting order
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account_id ON ledger_entries(account_id, entry_id);
+
+-- Entries are append-only: corrections are new postings, never edits
+CREATE OR REPLACE FUNCTION reject_ledger_entry_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_entries_immutable
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION reject_ledger_entry_change();
+
+-- Backfill entries for existing transactions, replaying history in creation order.
+-- Opening entries have no source account and so produce a credit only.
+INSERT INTO ledger_entries (transaction_id, account_id, direction, amount, balance_after, created_at)
+SELECT transaction_id, account_id, direction, amount,
+    SUM(delta) OVER (
+        PARTITION BY account_id
+        ORDER BY created_at, transaction_id, direction DESC
+        ROWS UNBOUNDED PRECEDING
+    ),
+    created_at
+FROM (
+    SELECT id AS transaction_id, source_account_id AS account_id, 'debit' AS direction,
+        amount, -amount AS delta, created_at
+    FROM transactions
+    WHERE source_account_id IS NOT NULL
+    UNION ALL
+    SELECT id, destination_account_id, 'credit', amount, amount, created_at
+    FROM transactions
+) sides
+ORDER BY created_at, transaction_id, direction DESC;
+
+-- Add comments for documentation
+COMMENT ON TABLE ledger_entries IS 'Append-only double-entry postings; accounts.balance is a cache of the latest balance_after';
+COMMENT ON COLUMN ledger_entries.direction IS 'debit decreases the account balance, credit increases it';
+COMMENT ON COLUMN ledger_entries.balance_after IS 'Account balance immediately after this entry was applied';
@@ -0,0 +1,9 @@
+-- Drop the transaction hash chain
+DROP TRIGGER IF EXISTS transactions_hash_chain ON transactions;
+DROP FUNCTION IF EXISTS link_transaction();
+DROP FUNCTION IF EXISTS transaction_chain_hash(BYTEA, UUID, BIGINT, BIGINT, NUMERIC, TEXT, TIMESTAMPTZ);
+DROP TABLE IF EXISTS transaction_chain_head;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS unique_chain_seq;
+ALTER TABLE transactions DROP COLUMN IF EXISTS hash;
+ALTER TABLE transactions DROP COLUMN IF EXISTS prev_hash;
+ALTER TABLE transactions DROP COLUMN IF EXISTS chain_seq;
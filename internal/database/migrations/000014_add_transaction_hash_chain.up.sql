@@ -0,0 +1,94 @@
+-- Tamper-evident hash chain over transactions. Every row stores the hash of the row before
+-- it (prev_hash) and the SHA-256 of that hash followed by its own canonical contents (hash).
+-- The chain is global and ordered by chain_seq; a single head row, locked for update by the
+-- insert trigger, keeps it linear. Editing or deleting any row breaks the chain from there on.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS chain_seq BIGINT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS prev_hash BYTEA;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS hash BYTEA;
+
+CREATE TABLE IF NOT EXISTS transaction_chain_head (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE,
+    seq BIGINT NOT NULL,
+    hash BYTEA NOT NULL,
+    CONSTRAINT single_chain_head CHECK (id)
+);
+
+-- The genesis link: no rows yet and a hash of 32 zero bytes
+INSERT INTO transaction_chain_head (id, seq, hash)
+VALUES (TRUE, 0, decode(repeat('00', 32), 'hex'))
+ON CONFLICT (id) DO NOTHING;
+
+-- Canonical contents, '|'-separated: id, source account ID (empty for openings), destination
+-- account ID, amount with exactly 8 decimal places, type, created_at in Unix microseconds.
+-- The verifier (internal/modules/chain) recomputes this in Go; the two must stay identical.
+CREATE OR REPLACE FUNCTION transaction_chain_hash(
+    prev BYTEA, tx_id UUID, source_id BIGINT, dest_id BIGINT, amount NUMERIC, tx_type TEXT, created TIMESTAMPTZ
+) RETURNS BYTEA AS $$
+    SELECT sha256(prev || convert_to(concat_ws('|',
+        tx_id::text,
+        COALESCE(source_id::text, ''),
+        dest_id::text,
+        round(amount, 8)::text,
+        tx_type,
+        (extract(epoch FROM created) * 1000000)::bigint::text
+    ), 'UTF8'))
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Backfill existing transactions in creation order
+DO $$
+DECLARE
+    r RECORD;
+    v_seq BIGINT := 0;
+    v_prev BYTEA := decode(repeat('00', 32), 'hex');
+BEGIN
+    FOR r IN
+        SELECT id, source_account_id, destination_account_id, amount, type, created_at
+        FROM transactions
+        ORDER BY created_at, id
+    LOOP
+        v_seq := v_seq + 1;
+        UPDATE transactions
+        SET chain_seq = v_seq,
+            prev_hash = v_prev,
+            hash = transaction_chain_hash(v_prev, r.id, r.source_account_id, r.destination_account_id, r.amount, r.type, r.created_at)
+        WHERE id = r.id
+        RETURNING hash INTO v_prev;
+    END LOOP;
+
+    UPDATE transaction_chain_head SET seq = v_seq, hash = v_prev;
+END;
+$$;
+
+ALTER TABLE transactions ALTER COLUMN chain_seq SET NOT NULL;
+ALTER TABLE transactions ALTER COLUMN prev_hash SET NOT NULL;
+ALTER TABLE transactions ALTER COLUMN hash SET NOT NULL;
+ALTER TABLE transactions ADD CONSTRAINT unique_chain_seq UNIQUE (chain_seq);
+
+-- Link every new transaction to the head. The head row lock is held until commit, so
+-- concurrent inserts queue here and the chain never forks.
+CREATE OR REPLACE FUNCTION link_transaction() RETURNS TRIGGER AS $$
+DECLARE
+    v_seq BIGINT;
+    v_prev BYTEA;
+BEGIN
+    SELECT seq, hash INTO v_seq, v_prev FROM transaction_chain_head FOR UPDATE;
+
+    NEW.chain_seq := v_seq + 1;
+    NEW.prev_hash := v_prev;
+    NEW.hash := transaction_chain_hash(v_prev, NEW.id, NEW.source_account_id, NEW.destination_account_id,
+        NEW.amount, NEW.type, NEW.created_at);
+
+    UPDATE transaction_chain_head SET seq = NEW.chain_seq, hash = NEW.hash;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transactions_hash_chain
+    BEFORE INSERT ON transactions
+    FOR EACH ROW EXECUTE FUNCTION link_transaction();
+
+-- Add comments for documentation
+COMMENT ON COLUMN transactions.chain_seq IS 'Position in the global hash chain, starting at 1';
+COMMENT ON COLUMN transactions.prev_hash IS 'hash of the previous link; 32 zero bytes for the first';
+COMMENT ON COLUMN transactions.hash IS 'SHA-256 of prev_hash followed by the canonical contents (see transaction_chain_hash)';
+COMMENT ON TABLE transaction_chain_head IS 'Single row holding the latest chain_seq and hash; locked by every transaction insert';
@@ -0,0 +1,8 @@
+-- Drop audit_events table and its immutability trigger
+DROP TRIGGER IF EXISTS audit_events_immutable ON audit_events;
+DROP FUNCTION IF EXISTS reject_audit_event_change();
+DROP INDEX IF EXISTS idx_audit_events_created_at;
+DROP INDEX IF EXISTS idx_audit_events_request_id;
+DROP INDEX IF EXISTS idx_audit_events_actor;
+DROP INDEX IF EXISTS idx_audit_events_entity;
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,38 @@
+-- Create audit_events table: one immutable row per state-changing operation, written in the
+-- same database transaction as the change it describes.
+-- entity_id is text and carries no foreign key: audited entities may be archived or deleted.
+CREATE TABLE IF NOT EXISTS audit_events (
+    event_id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255),
+    client_ip VARCHAR(64),
+    action VARCHAR(64) NOT NULL,
+    entity_type VARCHAR(64) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    before_value JSONB,
+    after_value JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for the filtered, newest-first query API
+CREATE INDEX IF NOT EXISTS idx_audit_events_entity ON audit_events(entity_type, entity_id, event_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events(actor, event_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_request_id ON audit_events(request_id) WHERE request_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at);
+
+-- Events are append-only: nothing may rewrite or remove the record of a change
+CREATE OR REPLACE FUNCTION reject_audit_event_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_immutable
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_event_change();
+
+-- Add comments for documentation
+COMMENT ON TABLE audit_events IS 'Append-only log of state-changing operations with who made them and the values before and after';
+COMMENT ON COLUMN audit_events.actor IS 'X-Actor header of the request, or system for background jobs and cli for commands';
+COMMENT ON COLUMN audit_events.before_value IS 'Changed fields before the operation; NULL for creations';
+COMMENT ON COLUMN audit_events.after_value IS 'Changed fields after the operation; NULL for deletions';
@@ -0,0 +1,3 @@
+-- Drop general-ledger category
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS valid_gl_category;
+ALTER TABLE accounts DROP COLUMN IF EXISTS gl_category;
@@ -0,0 +1,22 @@
+-- Classify every account into a general-ledger category for the trial balance.
+-- Customer balances are owed to customers (liabilities), clearing accounts mirror funds
+-- held externally (assets) and interest payouts are an expense of the service.
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS gl_category VARCHAR(16);
+
+UPDATE accounts
+SET gl_category = CASE kind
+    WHEN 'clearing' THEN 'asset'
+    WHEN 'interest_expense' THEN 'expense'
+    ELSE 'liability'
+END
+WHERE gl_category IS NULL;
+
+ALTER TABLE accounts
+    ALTER COLUMN gl_category SET NOT NULL;
+
+ALTER TABLE accounts
+    ADD CONSTRAINT valid_gl_category CHECK (gl_category IN ('asset', 'liability', 'equity', 'revenue', 'expense'));
+
+-- Add comments for documentation
+COMMENT ON COLUMN accounts.gl_category IS 'General-ledger category (asset, liability, equity, revenue or expense) the account is reported under in the trial balance';
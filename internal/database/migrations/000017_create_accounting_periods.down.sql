@@ -0,0 +1,7 @@
+-- Drop accounting periods
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS correction_follows_period;
+ALTER TABLE transactions DROP COLUMN IF EXISTS corrects_period;
+ALTER TABLE transactions DROP COLUMN IF EXISTS effective_date;
+DROP TRIGGER IF EXISTS accounting_periods_immutable ON accounting_periods;
+DROP FUNCTION IF EXISTS reject_accounting_period_change();
+DROP TABLE IF EXISTS accounting_periods;
@@ -0,0 +1,45 @@
+-- Create accounting_periods table: one row per closed calendar month. A month without a row
+-- is open. Closing is final, so rows are never updated or deleted.
+CREATE TABLE IF NOT EXISTS accounting_periods (
+    period_start DATE PRIMARY KEY,
+    closed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    closed_by VARCHAR(255) NOT NULL,
+    CONSTRAINT period_starts_on_first CHECK (EXTRACT(DAY FROM period_start) = 1)
+);
+
+CREATE OR REPLACE FUNCTION reject_accounting_period_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'closed accounting periods cannot be reopened or changed';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER accounting_periods_immutable
+    BEFORE UPDATE OR DELETE ON accounting_periods
+    FOR EACH ROW EXECUTE FUNCTION reject_accounting_period_change();
+
+-- Give every transaction the business date it belongs to in the books. Existing rows take
+-- the UTC date they were posted on; opening entries are written without one and default to
+-- the current date.
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS effective_date DATE;
+
+UPDATE transactions
+SET effective_date = (created_at AT TIME ZONE 'UTC')::date
+WHERE effective_date IS NULL;
+
+ALTER TABLE transactions
+    ALTER COLUMN effective_date SET DEFAULT CURRENT_DATE,
+    ALTER COLUMN effective_date SET NOT NULL;
+
+-- Corrections post in an open period and name the closed period they correct
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS corrects_period DATE REFERENCES accounting_periods(period_start);
+
+ALTER TABLE transactions
+    ADD CONSTRAINT correction_follows_period CHECK (corrects_period IS NULL OR corrects_period < effective_date);
+
+-- Add comments for documentation
+COMMENT ON TABLE accounting_periods IS 'Closed calendar months; no posting may carry an effective date inside one';
+COMMENT ON COLUMN accounting_periods.closed_by IS 'Actor that closed the period';
+COMMENT ON COLUMN transactions.effective_date IS 'Business date the transaction belongs to in the books; must fall in an open period when posted';
+COMMENT ON COLUMN transactions.corrects_period IS 'First day of the closed period a correction relates to; NULL for ordinary postings';
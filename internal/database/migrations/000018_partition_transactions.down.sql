@@ -0,0 +1,59 @@
+-- Move transactions back into a single table. Archived months are not restored: their rows
+-- only exist in the export files, and partitions still awaiting export are left in place.
+ALTER TABLE transactions RENAME TO transactions_partitioned;
+ALTER TABLE transactions_partitioned RENAME CONSTRAINT transactions_pkey TO transactions_partitioned_pkey;
+
+CREATE TABLE transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_account_id BIGINT REFERENCES accounts(account_id),
+    destination_account_id BIGINT NOT NULL REFERENCES accounts(account_id),
+    amount DECIMAL(19, 8) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    type VARCHAR(16) NOT NULL DEFAULT 'transfer',
+    chain_seq BIGINT NOT NULL,
+    prev_hash BYTEA NOT NULL,
+    hash BYTEA NOT NULL,
+    effective_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    corrects_period DATE REFERENCES accounting_periods(period_start),
+    CONSTRAINT positive_amount CHECK (amount > 0),
+    CONSTRAINT different_accounts CHECK (source_account_id != destination_account_id),
+    CONSTRAINT valid_transaction_type CHECK (type IN ('transfer', 'deposit', 'withdrawal', 'opening', 'interest')),
+    CONSTRAINT opening_has_no_source CHECK ((type = 'opening') = (source_account_id IS NULL)),
+    CONSTRAINT correction_follows_period CHECK (corrects_period IS NULL OR corrects_period < effective_date)
+);
+
+INSERT INTO transactions (id, source_account_id, destination_account_id, amount, created_at, type,
+    chain_seq, prev_hash, hash, effective_date, corrects_period)
+SELECT id, source_account_id, destination_account_id, amount, created_at, type,
+    chain_seq, prev_hash, hash, effective_date, corrects_period
+FROM transactions_partitioned;
+
+DROP TABLE transactions_partitioned;
+
+ALTER TABLE transactions ADD CONSTRAINT unique_chain_seq UNIQUE (chain_seq);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_source ON transactions(source_account_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_destination ON transactions(destination_account_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions(created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_source_created_at ON transactions(source_account_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_destination_created_at ON transactions(destination_account_id, created_at);
+
+CREATE TRIGGER transactions_hash_chain
+    BEFORE INSERT ON transactions
+    FOR EACH ROW EXECUTE FUNCTION link_transaction();
+
+DROP FUNCTION IF EXISTS archived_transaction_net(BIGINT, TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS create_transaction_partition(DATE);
+DROP TABLE IF EXISTS transaction_chain_archived_runs;
+DROP TABLE IF EXISTS transaction_archive_totals;
+DROP TABLE IF EXISTS transaction_archives;
+
+COMMENT ON TABLE transactions IS 'Stores all fund transfer transactions between accounts';
+COMMENT ON COLUMN transactions.amount IS 'Transfer amount with 8 decimal places precision';
+COMMENT ON COLUMN transactions.source_account_id IS 'Account debited; NULL only for opening entries';
+COMMENT ON COLUMN transactions.type IS 'transfer between accounts, deposit/withdrawal against a clearing account, opening balance at account creation, or interest paid from an interest-expense account';
+COMMENT ON COLUMN transactions.chain_seq IS 'Position in the global hash chain, starting at 1';
+COMMENT ON COLUMN transactions.prev_hash IS 'hash of the previous link; 32 zero bytes for the first';
+COMMENT ON COLUMN transactions.hash IS 'SHA-256 of prev_hash followed by the canonical contents (see transaction_chain_hash)';
+COMMENT ON COLUMN transactions.effective_date IS 'Business date the transaction belongs to in the books; must fall in an open period when posted';
+COMMENT ON COLUMN transactions.corrects_period IS 'First day of the closed period a correction relates to; NULL for ordinary postings';
@@ -0,0 +1,163 @@
+-- Partition transactions by UTC calendar month of created_at, so that old months can be
+-- detached, exported and dropped whole (see internal/modules/archive). Unique constraints
+-- of a partitioned table must include the partition key: the primary key becomes
+-- (id, created_at), and chain_seq loses its unique constraint, which the chain head row
+-- lock already guarantees. No table references transactions.id by foreign key.
+ALTER TABLE transactions RENAME TO transactions_unpartitioned;
+ALTER TABLE transactions_unpartitioned RENAME CONSTRAINT transactions_pkey TO transactions_unpartitioned_pkey;
+
+CREATE TABLE transactions (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    source_account_id BIGINT REFERENCES accounts(account_id),
+    destination_account_id BIGINT NOT NULL REFERENCES accounts(account_id),
+    amount DECIMAL(19, 8) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    type VARCHAR(16) NOT NULL DEFAULT 'transfer',
+    chain_seq BIGINT NOT NULL,
+    prev_hash BYTEA NOT NULL,
+    hash BYTEA NOT NULL,
+    effective_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    corrects_period DATE REFERENCES accounting_periods(period_start),
+    CONSTRAINT transactions_pkey PRIMARY KEY (id, created_at),
+    CONSTRAINT positive_amount CHECK (amount > 0),
+    CONSTRAINT different_accounts CHECK (source_account_id != destination_account_id),
+    CONSTRAINT valid_transaction_type CHECK (type IN ('transfer', 'deposit', 'withdrawal', 'opening', 'interest')),
+    CONSTRAINT opening_has_no_source CHECK ((type = 'opening') = (source_account_id IS NULL)),
+    CONSTRAINT correction_follows_period CHECK (corrects_period IS NULL OR corrects_period < effective_date)
+) PARTITION BY RANGE (created_at);
+
+-- One row per month whose partition has been detached. Its rows leave the transactions
+-- table in the same database transaction that records the archive and its totals, and
+-- the partition stays behind as a standalone table until it has been exported.
+CREATE TABLE IF NOT EXISTS transaction_archives (
+    month_start DATE PRIMARY KEY,
+    partition_name VARCHAR(63) NOT NULL,
+    range_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    row_count BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'detached',
+    file_path TEXT,
+    file_sha256 VARCHAR(64),
+    detached_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    archived_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT archive_month_starts_on_first CHECK (EXTRACT(DAY FROM month_start) = 1),
+    CONSTRAINT valid_archive_status CHECK (status IN ('detached', 'archived')),
+    CONSTRAINT archived_has_file CHECK ((status = 'archived') = (file_path IS NOT NULL))
+);
+
+-- What each archived month moved into and out of every account, by transaction type, so
+-- that balances can still be rebuilt from history once its rows are gone
+CREATE TABLE IF NOT EXISTS transaction_archive_totals (
+    month_start DATE NOT NULL REFERENCES transaction_archives(month_start),
+    account_id BIGINT NOT NULL REFERENCES accounts(account_id),
+    type VARCHAR(16) NOT NULL,
+    credits NUMERIC NOT NULL,
+    debits NUMERIC NOT NULL,
+    PRIMARY KEY (month_start, account_id, type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_archive_totals_account_id ON transaction_archive_totals(account_id);
+
+-- Each run of consecutive archived links, so that the chain can still be verified across
+-- them. A month's links are not always contiguous: a transaction stamped just before
+-- midnight can take its place in the chain after one stamped just after.
+CREATE TABLE IF NOT EXISTS transaction_chain_archived_runs (
+    first_seq BIGINT PRIMARY KEY,
+    last_seq BIGINT NOT NULL,
+    first_prev_hash BYTEA NOT NULL,
+    last_hash BYTEA NOT NULL,
+    month_start DATE NOT NULL REFERENCES transaction_archives(month_start),
+    CONSTRAINT ordered_run CHECK (last_seq >= first_seq)
+);
+
+-- Create the partition for the month starting on p_month, unless it exists or the
+-- month has been archived. Returns whether a partition was created. Bounds are UTC
+-- midnights, whatever the session time zone.
+CREATE OR REPLACE FUNCTION create_transaction_partition(p_month DATE) RETURNS BOOLEAN AS $$
+DECLARE
+    v_name TEXT := 'transactions_' || to_char(p_month, 'YYYY_MM');
+BEGIN
+    IF EXTRACT(DAY FROM p_month) <> 1 THEN
+        RAISE EXCEPTION 'partition month must start on the first, got %', p_month;
+    END IF;
+
+    IF to_regclass(v_name) IS NOT NULL
+        OR EXISTS (SELECT 1 FROM transaction_archives a WHERE a.month_start = p_month) THEN
+        RETURN FALSE;
+    END IF;
+
+    EXECUTE format('CREATE TABLE %I PARTITION OF transactions FOR VALUES FROM (%L) TO (%L)',
+        v_name,
+        to_char(p_month, 'YYYY-MM-DD') || ' 00:00:00+00',
+        to_char(p_month + INTERVAL '1 month', 'YYYY-MM-DD') || ' 00:00:00+00');
+    RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Net amount archived transactions moved into an account, over the archived months that
+-- ended by upto
+CREATE OR REPLACE FUNCTION archived_transaction_net(p_account_id BIGINT, upto TIMESTAMPTZ) RETURNS NUMERIC AS $$
+    SELECT COALESCE(SUM(t.credits - t.debits), 0)
+    FROM transaction_archive_totals t
+    JOIN transaction_archives a ON a.month_start = t.month_start
+    WHERE t.account_id = p_account_id AND a.range_end <= upto
+$$ LANGUAGE sql STABLE;
+
+-- A partition for every month with transactions, and for the next three
+DO $$
+DECLARE
+    v_month DATE;
+    v_last DATE;
+BEGIN
+    SELECT date_trunc('month', COALESCE(MIN(created_at), NOW()) AT TIME ZONE 'UTC')::date,
+        GREATEST(
+            date_trunc('month', COALESCE(MAX(created_at), NOW()) AT TIME ZONE 'UTC')::date,
+            (date_trunc('month', NOW() AT TIME ZONE 'UTC') + INTERVAL '3 months')::date)
+    INTO v_month, v_last
+    FROM transactions_unpartitioned;
+
+    WHILE v_month <= v_last LOOP
+        PERFORM create_transaction_partition(v_month);
+        v_month := (v_month + INTERVAL '1 month')::date;
+    END LOOP;
+END;
+$$;
+
+-- Rows are copied before the hash chain trigger exists, so they keep their links
+INSERT INTO transactions (id, source_account_id, destination_account_id, amount, created_at, type,
+    chain_seq, prev_hash, hash, effective_date, corrects_period)
+SELECT id, source_account_id, destination_account_id, amount, created_at, type,
+    chain_seq, prev_hash, hash, effective_date, corrects_period
+FROM transactions_unpartitioned;
+
+DROP TABLE transactions_unpartitioned;
+
+-- Indexes on the partitioned table are created on every partition, present and future
+CREATE INDEX IF NOT EXISTS idx_transactions_source ON transactions(source_account_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_destination ON transactions(destination_account_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions(created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_source_created_at ON transactions(source_account_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_destination_created_at ON transactions(destination_account_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_chain_seq ON transactions(chain_seq);
+
+CREATE TRIGGER transactions_hash_chain
+    BEFORE INSERT ON transactions
+    FOR EACH ROW EXECUTE FUNCTION link_transaction();
+
+-- Add comments for documentation
+COMMENT ON TABLE transactions IS 'Stores all fund transfer transactions between accounts, partitioned by month of created_at';
+COMMENT ON COLUMN transactions.amount IS 'Transfer amount with 8 decimal places precision';
+COMMENT ON COLUMN transactions.source_account_id IS 'Account debited; NULL only for opening entries';
+COMMENT ON COLUMN transactions.type IS 'transfer between accounts, deposit/withdrawal against a clearing account, opening balance at account creation, or interest paid from an interest-expense account';
+COMMENT ON COLUMN transactions.chain_seq IS 'Position in the global hash chain, starting at 1';
+COMMENT ON COLUMN transactions.prev_hash IS 'hash of the previous link; 32 zero bytes for the first';
+COMMENT ON COLUMN transactions.hash IS 'SHA-256 of prev_hash followed by the canonical contents (see transaction_chain_hash)';
+COMMENT ON COLUMN transactions.effective_date IS 'Business date the transaction belongs to in the books; must fall in an open period when posted';
+COMMENT ON COLUMN transactions.corrects_period IS 'First day of the closed period a correction relates to; NULL for ordinary postings';
+COMMENT ON TABLE transaction_archives IS 'Months whose transactions partition has been detached, and where it was exported';
+COMMENT ON COLUMN transaction_archives.range_end IS 'Exclusive upper bound of the month: every archived row has created_at before it';
+COMMENT ON COLUMN transaction_archives.status IS 'detached until the partition has been exported and dropped, then archived';
+COMMENT ON COLUMN transaction_archives.file_sha256 IS 'Hex SHA-256 of the compressed export file';
+COMMENT ON TABLE transaction_archive_totals IS 'Per-account, per-type sums of archived transactions; added to history sums in place of the rows';
+COMMENT ON COLUMN transaction_archive_totals.credits IS 'Sum of archived amounts with the account as destination';
+COMMENT ON COLUMN transaction_archive_totals.debits IS 'Sum of archived amounts with the account as source';
+COMMENT ON TABLE transaction_chain_archived_runs IS 'Runs of consecutive archived chain links, checked when they were archived';
@@ -0,0 +1,3 @@
+-- Drop journal exports and GL account mappings
+DROP TABLE IF EXISTS journal_exports;
+DROP TABLE IF EXISTS gl_account_mappings;
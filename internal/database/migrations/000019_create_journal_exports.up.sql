@@ -0,0 +1,33 @@
+-- GL account code each leg of a posting is exported under, by the general-ledger category
+-- and currency of the leg's account. The debit leg of an opening entry has no account and
+-- is mapped as equity, as in the trial balance.
+CREATE TABLE IF NOT EXISTS gl_account_mappings (
+    gl_category VARCHAR(16) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    gl_code VARCHAR(32) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (gl_category, currency),
+    CONSTRAINT valid_mapping_gl_category CHECK (gl_category IN ('asset', 'liability', 'equity', 'revenue', 'expense')),
+    CONSTRAINT non_empty_gl_code CHECK (gl_code <> '')
+);
+
+-- One row per journal export. Exports cover consecutive runs of business dates and never
+-- overlap, so the newest last_date is the watermark: every business date up to it has been
+-- exported exactly once.
+CREATE TABLE IF NOT EXISTS journal_exports (
+    export_id BIGSERIAL PRIMARY KEY,
+    first_date DATE NOT NULL UNIQUE,
+    last_date DATE NOT NULL UNIQUE,
+    entry_count BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    exported_by VARCHAR(255) NOT NULL,
+    exported_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT ordered_export_dates CHECK (first_date <= last_date)
+);
+
+-- Add comments for documentation
+COMMENT ON TABLE gl_account_mappings IS 'GL account codes journal legs are exported under, per general-ledger category and currency';
+COMMENT ON TABLE journal_exports IS 'Journal exports for external accounting systems; the newest last_date is the export watermark';
+COMMENT ON COLUMN journal_exports.first_date IS 'First business date covered; postings are assigned to business dates by created_at';
+COMMENT ON COLUMN journal_exports.entry_count IS 'Number of postings exported, each as a debit and a credit line';
+COMMENT ON COLUMN journal_exports.checksum IS 'Hex SHA-256 of the exported lines in canonical form, independent of the CSV layout';
@@ -0,0 +1,2 @@
+-- Drop idempotency key fingerprints
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS fingerprint;
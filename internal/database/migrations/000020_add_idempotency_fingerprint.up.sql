@@ -0,0 +1,7 @@
+-- Fingerprint of the request each idempotency key was first used with: the hex SHA-256 of
+-- its method, path and body. A key replayed with a different fingerprint is rejected
+-- instead of returning another request's response. Keys stored before this migration have
+-- no fingerprint and are replayed as before until they expire.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS fingerprint VARCHAR(64);
+
+COMMENT ON COLUMN idempotency_keys.fingerprint IS 'Hex SHA-256 of the method, path and body of the request the key was first used with';
@@ -0,0 +1,11 @@
+-- Reservations have no response to replay
+DELETE FROM idempotency_keys WHERE status = 'in_progress';
+
+ALTER TABLE idempotency_keys
+    DROP CONSTRAINT IF EXISTS in_progress_idempotency_has_lease,
+    DROP CONSTRAINT IF EXISTS completed_idempotency_has_response,
+    DROP CONSTRAINT IF EXISTS valid_idempotency_status;
+
+ALTER TABLE idempotency_keys ALTER COLUMN response_status SET NOT NULL;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS lease_expires_at;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS status;
@@ -0,0 +1,15 @@
+-- Idempotency keys are reserved before the request runs, so concurrent retries with the
+-- same key cannot both execute it. A reservation is 'in_progress' with no response until
+-- the request completes; its lease bounds how long a crashed request can hold the key.
+-- Existing keys all hold a response and become 'completed'.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'completed';
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE idempotency_keys ALTER COLUMN response_status DROP NOT NULL;
+
+ALTER TABLE idempotency_keys
+    ADD CONSTRAINT valid_idempotency_status CHECK (status IN ('in_progress', 'completed')),
+    ADD CONSTRAINT completed_idempotency_has_response CHECK (status <> 'completed' OR response_status IS NOT NULL),
+    ADD CONSTRAINT in_progress_idempotency_has_lease CHECK (status <> 'in_progress' OR lease_expires_at IS NOT NULL);
+
+COMMENT ON COLUMN idempotency_keys.status IS 'in_progress while the first request with the key runs, completed once its response is stored';
+COMMENT ON COLUMN idempotency_keys.lease_expires_at IS 'When an in_progress reservation lapses and the key may be reserved again';
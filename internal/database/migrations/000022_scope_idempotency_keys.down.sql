@@ -0,0 +1,6 @@
+-- The key primary key is still in place, so no key name is used in two scopes
+DROP INDEX IF EXISTS idempotency_keys_scoped_key;
+
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS route;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS method;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS client_id;
@@ -0,0 +1,22 @@
+-- Idempotency keys are scoped to the client that sent them and the route they were sent
+-- to, so clients that pick the same key, or one client using a key on two routes, no
+-- longer collide. The route is the route pattern, such as /v1/accounts/{id}.
+--
+-- This is the expand step: the scoped unique index is added next to the key primary key,
+-- so instances of the previous version keep storing keys with ON CONFLICT (key) while the
+-- new version uses ON CONFLICT (client_id, method, route, key). Until 000024 drops the key
+-- primary key, a key already stored under another scope still collides, and the new
+-- version rejects that request with 409 Conflict rather than process it without its key.
+--
+-- Existing keys keep an empty scope. The service still replays them under any scope, so a
+-- retry of a request made before the upgrade is not processed twice; they age out with the
+-- idempotency TTL.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS client_id VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS method VARCHAR(10) NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS route VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idempotency_keys_scoped_key ON idempotency_keys (client_id, method, route, key);
+
+COMMENT ON COLUMN idempotency_keys.client_id IS 'Client that sent the key: the X-Client-ID header, anonymous without one; empty for keys stored before scoping';
+COMMENT ON COLUMN idempotency_keys.method IS 'HTTP method of the request the key was sent with; empty for keys stored before scoping';
+COMMENT ON COLUMN idempotency_keys.route IS 'Route pattern of the request the key was sent with; empty for keys stored before scoping';
@@ -0,0 +1,32 @@
+-- Rows in the default partition have nowhere else to go, so refuse rather than drop them;
+-- create their months' partitions first
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM transactions_default) THEN
+        RAISE EXCEPTION 'transactions_default is not empty; create the partitions for its months first';
+    END IF;
+END;
+$$;
+
+DROP TABLE IF EXISTS transactions_default;
+
+CREATE OR REPLACE FUNCTION create_transaction_partition(p_month DATE) RETURNS BOOLEAN AS $$
+DECLARE
+    v_name TEXT := 'transactions_' || to_char(p_month, 'YYYY_MM');
+BEGIN
+    IF EXTRACT(DAY FROM p_month) <> 1 THEN
+        RAISE EXCEPTION 'partition month must start on the first, got %', p_month;
+    END IF;
+
+    IF to_regclass(v_name) IS NOT NULL
+        OR EXISTS (SELECT 1 FROM transaction_archives a WHERE a.month_start = p_month) THEN
+        RETURN FALSE;
+    END IF;
+
+    EXECUTE format('CREATE TABLE %I PARTITION OF transactions FOR VALUES FROM (%L) TO (%L)',
+        v_name,
+        to_char(p_month, 'YYYY-MM-DD') || ' 00:00:00+00',
+        to_char(p_month + INTERVAL '1 month', 'YYYY-MM-DD') || ' 00:00:00+00');
+    RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql;
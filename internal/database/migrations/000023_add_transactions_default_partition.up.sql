@@ -0,0 +1,45 @@
+-- Catch-all partition for transactions whose month has no partition yet, so that postings
+-- keep working if partition maintenance falls behind. It should stay empty: its rows are
+-- reported by the archive module and move to their month's partition once it is created.
+CREATE TABLE IF NOT EXISTS transactions_default PARTITION OF transactions DEFAULT;
+
+-- As before, but a month with rows in the default partition gets a partition built from
+-- them: the rows are moved into a standalone table, which is then attached. The table is
+-- not attached while they are copied, so the hash chain trigger leaves their links as they
+-- are. Attaching checks that the default partition holds none of the month's rows.
+CREATE OR REPLACE FUNCTION create_transaction_partition(p_month DATE) RETURNS BOOLEAN AS $$
+DECLARE
+    v_name TEXT := 'transactions_' || to_char(p_month, 'YYYY_MM');
+    v_from TEXT := to_char(p_month, 'YYYY-MM-DD') || ' 00:00:00+00';
+    v_to TEXT := to_char(p_month + INTERVAL '1 month', 'YYYY-MM-DD') || ' 00:00:00+00';
+BEGIN
+    IF EXTRACT(DAY FROM p_month) <> 1 THEN
+        RAISE EXCEPTION 'partition month must start on the first, got %', p_month;
+    END IF;
+
+    IF to_regclass(v_name) IS NOT NULL
+        OR EXISTS (SELECT 1 FROM transaction_archives a WHERE a.month_start = p_month) THEN
+        RETURN FALSE;
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM transactions_default
+            WHERE created_at >= v_from::timestamptz AND created_at < v_to::timestamptz) THEN
+        EXECUTE format('CREATE TABLE %I PARTITION OF transactions FOR VALUES FROM (%L) TO (%L)',
+            v_name, v_from, v_to);
+        RETURN TRUE;
+    END IF;
+
+    EXECUTE format('CREATE TABLE %I (LIKE transactions INCLUDING DEFAULTS INCLUDING CONSTRAINTS)', v_name);
+    EXECUTE format('WITH moved AS (
+            DELETE FROM transactions_default
+            WHERE created_at >= $1::timestamptz AND created_at < $2::timestamptz
+            RETURNING *)
+        INSERT INTO %I SELECT * FROM moved', v_name)
+        USING v_from, v_to;
+    EXECUTE format('ALTER TABLE transactions ATTACH PARTITION %I FOR VALUES FROM (%L) TO (%L)',
+        v_name, v_from, v_to);
+    RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON TABLE transactions_default IS 'Transactions whose month had no partition; should be empty';
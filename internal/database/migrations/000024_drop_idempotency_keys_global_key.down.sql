@@ -0,0 +1,10 @@
+-- Keys can only be global again once no name is used in two scopes; keep one row per key
+DELETE FROM idempotency_keys a
+USING idempotency_keys b
+WHERE a.key = b.key
+  AND (a.client_id, a.method, a.route) > (b.client_id, b.method, b.route);
+
+-- The scoped primary key becomes a unique index again, as left by 000022
+CREATE UNIQUE INDEX idempotency_keys_scoped_key ON idempotency_keys (client_id, method, route, key);
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_pkey PRIMARY KEY (key);
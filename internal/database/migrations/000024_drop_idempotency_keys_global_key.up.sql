@@ -0,0 +1,6 @@
+-- Contract step of scoping idempotency keys (000022): the scoped unique index becomes the
+-- primary key, so the same key may be used in more than one scope. Run it once no instance
+-- of the version before scoping is left, since their ON CONFLICT (key) no longer matches a
+-- constraint afterwards.
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_pkey PRIMARY KEY USING INDEX idempotency_keys_scoped_key;
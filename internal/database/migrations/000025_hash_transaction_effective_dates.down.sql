@@ -0,0 +1,69 @@
+-- Version 2 links are rehashed in the version 1 form, relinking every later link. Links
+-- already archived cannot be rehashed, so refuse if any version 2 link has been archived.
+DO $$
+DECLARE
+    r RECORD;
+    v_first BIGINT;
+    v_prev BYTEA;
+BEGIN
+    SELECT MIN(chain_seq) INTO v_first FROM transactions WHERE hash_version = 2;
+    IF v_first IS NULL THEN
+        RETURN;
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM transaction_chain_archived_runs WHERE last_seq >= v_first) THEN
+        RAISE EXCEPTION 'links from chain_seq % on are archived and cannot be rehashed', v_first;
+    END IF;
+
+    SELECT prev_hash INTO v_prev FROM transactions WHERE chain_seq = v_first;
+    FOR r IN
+        SELECT chain_seq, id, source_account_id, destination_account_id, amount, type, created_at
+        FROM transactions
+        WHERE chain_seq >= v_first
+        ORDER BY chain_seq
+    LOOP
+        UPDATE transactions
+        SET prev_hash = v_prev,
+            hash = transaction_chain_hash(v_prev, r.id, r.source_account_id, r.destination_account_id, r.amount, r.type, r.created_at)
+        WHERE chain_seq = r.chain_seq
+        RETURNING hash INTO v_prev;
+    END LOOP;
+
+    UPDATE transaction_chain_head SET hash = v_prev;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION link_transaction() RETURNS TRIGGER AS $$
+DECLARE
+    v_seq BIGINT;
+    v_prev BYTEA;
+BEGIN
+    SELECT seq, hash INTO v_seq, v_prev FROM transaction_chain_head FOR UPDATE;
+
+    NEW.chain_seq := v_seq + 1;
+    NEW.prev_hash := v_prev;
+    NEW.hash := transaction_chain_hash(v_prev, NEW.id, NEW.source_account_id, NEW.destination_account_id,
+        NEW.amount, NEW.type, NEW.created_at);
+
+    UPDATE transaction_chain_head SET seq = NEW.chain_seq, hash = NEW.hash;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS transaction_link_hash(SMALLINT, BYTEA, UUID, BIGINT, BIGINT, NUMERIC, TEXT, TIMESTAMPTZ, DATE, DATE);
+DROP FUNCTION IF EXISTS transaction_chain_hash(BYTEA, UUID, BIGINT, BIGINT, NUMERIC, TEXT, TIMESTAMPTZ, DATE, DATE);
+
+DO $$
+DECLARE
+    r RECORD;
+BEGIN
+    FOR r IN SELECT partition_name FROM transaction_archives WHERE status = 'detached' LOOP
+        EXECUTE format('ALTER TABLE %I DROP COLUMN IF EXISTS hash_version', r.partition_name);
+    END LOOP;
+END;
+$$;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS valid_hash_version;
+ALTER TABLE transactions DROP COLUMN IF EXISTS hash_version;
+
+COMMENT ON COLUMN transactions.hash IS 'SHA-256 of prev_hash followed by the canonical contents (see transaction_chain_hash)';
@@ -0,0 +1,73 @@
+-- The hash chain also covers effective_date and corrects_period, so a posting cannot be
+-- moved to another business date, or a correction re-pointed at another closed period,
+-- without breaking the chain. Links hashed before this keep their hash: hash_version
+-- records which canonical form each link was hashed with, and the verifier picks the form
+-- by it. Rewriting a version 2 link's version to 1 does not help, since its stored hash
+-- was computed over the dates.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS hash_version SMALLINT NOT NULL DEFAULT 1;
+ALTER TABLE transactions ADD CONSTRAINT valid_hash_version CHECK (hash_version IN (1, 2));
+
+-- Months detached but not yet exported are no longer partitions, so they do not get the
+-- column from transactions; the export reads it
+DO $$
+DECLARE
+    r RECORD;
+BEGIN
+    FOR r IN SELECT partition_name FROM transaction_archives WHERE status = 'detached' LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS hash_version SMALLINT NOT NULL DEFAULT 1',
+            r.partition_name);
+    END LOOP;
+END;
+$$;
+
+-- Version 2 canonical contents: the version 1 fields followed by the effective date and
+-- the corrected period's first day as YYYY-MM-DD, empty for ordinary postings. The
+-- verifier (internal/modules/chain) recomputes this in Go; the two must stay identical.
+CREATE OR REPLACE FUNCTION transaction_chain_hash(
+    prev BYTEA, tx_id UUID, source_id BIGINT, dest_id BIGINT, amount NUMERIC, tx_type TEXT, created TIMESTAMPTZ,
+    effective DATE, corrects DATE
+) RETURNS BYTEA AS $$
+    SELECT sha256(prev || convert_to(concat_ws('|',
+        tx_id::text,
+        COALESCE(source_id::text, ''),
+        dest_id::text,
+        round(amount, 8)::text,
+        tx_type,
+        (extract(epoch FROM created) * 1000000)::bigint::text,
+        to_char(effective, 'YYYY-MM-DD'),
+        COALESCE(to_char(corrects, 'YYYY-MM-DD'), '')
+    ), 'UTF8'))
+$$ LANGUAGE sql IMMUTABLE;
+
+-- The hash of a link in the canonical form of its version
+CREATE OR REPLACE FUNCTION transaction_link_hash(
+    version SMALLINT, prev BYTEA, tx_id UUID, source_id BIGINT, dest_id BIGINT, amount NUMERIC, tx_type TEXT,
+    created TIMESTAMPTZ, effective DATE, corrects DATE
+) RETURNS BYTEA AS $$
+    SELECT CASE version
+        WHEN 1 THEN transaction_chain_hash(prev, tx_id, source_id, dest_id, amount, tx_type, created)
+        ELSE transaction_chain_hash(prev, tx_id, source_id, dest_id, amount, tx_type, created, effective, corrects)
+    END
+$$ LANGUAGE sql IMMUTABLE;
+
+-- New links are always hashed with version 2, whatever the insert says
+CREATE OR REPLACE FUNCTION link_transaction() RETURNS TRIGGER AS $$
+DECLARE
+    v_seq BIGINT;
+    v_prev BYTEA;
+BEGIN
+    SELECT seq, hash INTO v_seq, v_prev FROM transaction_chain_head FOR UPDATE;
+
+    NEW.chain_seq := v_seq + 1;
+    NEW.prev_hash := v_prev;
+    NEW.hash_version := 2;
+    NEW.hash := transaction_chain_hash(v_prev, NEW.id, NEW.source_account_id, NEW.destination_account_id,
+        NEW.amount, NEW.type, NEW.created_at, NEW.effective_date, NEW.corrects_period);
+
+    UPDATE transaction_chain_head SET seq = NEW.chain_seq, hash = NEW.hash;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON COLUMN transactions.hash_version IS 'Canonical form hash covers: 1 for links hashed before effective dates were, 2 adds effective_date and corrects_period';
+COMMENT ON COLUMN transactions.hash IS 'SHA-256 of prev_hash followed by the canonical contents of hash_version (see transaction_link_hash)';
@@ -2,12 +2,14 @@
 package interceptors
 
 import (
+	"net"
 	"net/http"
 	"runtime"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/internal-transfers-service/internal/boot/app_context"
 	"github.com/internal-transfers-service/internal/constants"
 	"github.com/internal-transfers-service/internal/logger"
 	"github.com/internal-transfers-service/internal/metrics"
@@ -229,11 +231,68 @@ func RequestIDMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// AuditContextMiddleware puts the request ID, client IP and actor into the context, where
+// audit events pick them up. It must run after chi's RequestID and RealIP middleware.
+func AuditContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if requestID := middleware.GetReqID(ctx); requestID != "" {
+			ctx = app_context.SetRequestID(ctx, requestID)
+		}
+		ctx = app_context.SetClientIP(ctx, clientIP(r))
+		ctx = app_context.SetActor(ctx, actor(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// clientIP returns the request's remote address without its port
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// actor returns the X-Actor header, or anonymous when it is missing
+func actor(r *http.Request) string {
+	name := strings.TrimSpace(r.Header.Get(constants.HeaderActor))
+	if name == "" {
+		return constants.ActorAnonymous
+	}
+	if len(name) > constants.MaxActorLength {
+		name = name[:constants.MaxActorLength]
+	}
+	return name
+}
+
 // TimeoutMiddleware adds a timeout to requests.
+// Streaming downloads are exempt: http.TimeoutHandler buffers the whole response
+// and would cut off exports that legitimately run longer than the timeout.
 func TimeoutMiddleware(timeout time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		return http.TimeoutHandler(next, timeout, timeoutErrorResponse())
+		timeoutHandler := http.TimeoutHandler(next, timeout, timeoutErrorResponse())
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isStreamingRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			timeoutHandler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isStreamingRequest checks if the request targets a streaming download route
+func isStreamingRequest(r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		return false
+	}
+	for _, suffix := range constants.StreamingRouteSuffixes {
+		if strings.HasSuffix(r.URL.Path, suffix) {
+			return true
+		}
 	}
+	return false
 }
 
 // timeoutErrorResponse returns the timeout error response body
@@ -306,6 +365,8 @@ func MaxBytesMiddleware(next http.Handler) http.Handler {
 }
 
 // ContentTypeValidationMiddleware validates Content-Type header for mutating requests.
+// JSON is accepted everywhere; CSV is accepted for uploads, and handlers that do not
+// take CSV reject it when decoding the body.
 func ContentTypeValidationMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !isMutatingRequest(r) {
@@ -313,7 +374,7 @@ func ContentTypeValidationMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		if !isValidJSONContentType(r) {
+		if !isSupportedContentType(r) {
 			writeUnsupportedMediaTypeError(w, r)
 			return
 		}
@@ -332,10 +393,11 @@ func isMutatingRequest(r *http.Request) bool {
 	}
 }
 
-// isValidJSONContentType checks if the Content-Type header indicates JSON
-func isValidJSONContentType(r *http.Request) bool {
+// isSupportedContentType checks if the Content-Type header indicates JSON or CSV
+func isSupportedContentType(r *http.Request) bool {
 	contentType := r.Header.Get(constants.HeaderContentType)
-	return strings.HasPrefix(contentType, constants.ContentTypeJSONPrefix)
+	return strings.HasPrefix(contentType, constants.ContentTypeJSONPrefix) ||
+		strings.HasPrefix(contentType, constants.ContentTypeCSVPrefix)
 }
 
 // writeUnsupportedMediaTypeError writes a 415 error response
@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/internal-transfers-service/internal/boot/app_context"
 	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
 	"github.com/internal-transfers-service/internal/interceptors"
 	"github.com/stretchr/testify/suite"
 )
@@ -102,6 +104,44 @@ func (s *HTTPMiddlewareTestSuite) TestRequestIDMiddlewareAddsHeader() {
 	s.NotEmpty(rec.Header().Get("X-Request-ID"))
 }
 
+// TestAuditContextMiddlewareSetsRequestIDClientIPAndActor verifies audit fields reach the context
+func (s *HTTPMiddlewareTestSuite) TestAuditContextMiddlewareSetsRequestIDClientIPAndActor() {
+	var requestID, clientIP, actor string
+	handler := middleware.RequestID(interceptors.AuditContextMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID, _ = r.Context().Value(contextkeys.RequestID).(string)
+		clientIP = app_context.GetClientIP(r.Context())
+		actor = app_context.GetActor(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	req := httptest.NewRequest(http.MethodPost, "/test", nil)
+	req.RemoteAddr = "10.1.2.3:54321"
+	req.Header.Set(constants.HeaderActor, "ops-alice")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	s.NotEmpty(requestID)
+	s.Equal("10.1.2.3", clientIP)
+	s.Equal("ops-alice", actor)
+}
+
+// TestAuditContextMiddlewareDefaultsActorToAnonymous verifies requests without X-Actor are anonymous
+func (s *HTTPMiddlewareTestSuite) TestAuditContextMiddlewareDefaultsActorToAnonymous() {
+	var actor string
+	handler := interceptors.AuditContextMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = app_context.GetActor(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	s.Equal(constants.ActorAnonymous, actor)
+}
+
 // TestRequestIDMiddlewareWithoutIDDoesNotFail verifies middleware works without ID
 func (s *HTTPMiddlewareTestSuite) TestRequestIDMiddlewareWithoutIDDoesNotFail() {
 	handler := interceptors.RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -259,6 +299,20 @@ func (s *HTTPMiddlewareTestSuite) TestContentTypeValidationMiddlewareAllowsJSONW
 	s.Equal(http.StatusOK, rec.Code)
 }
 
+func (s *HTTPMiddlewareTestSuite) TestContentTypeValidationMiddlewareAllowsCSV() {
+	handler := interceptors.ContentTypeValidationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/test", nil)
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeCSVPrefix+"; charset=utf-8")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
 func (s *HTTPMiddlewareTestSuite) TestContentTypeValidationMiddlewareRejectsInvalidContentType() {
 	handler := interceptors.ContentTypeValidationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -298,3 +352,20 @@ func (s *HTTPMiddlewareTestSuite) TestContentTypeValidationMiddlewareRejectsPOST
 
 	s.Equal(http.StatusUnsupportedMediaType, rec.Code)
 }
+
+// TestTimeoutMiddlewareSkipsStreamingRoutes verifies streaming downloads are not cut off or buffered
+func (s *HTTPMiddlewareTestSuite) TestTimeoutMiddlewareSkipsStreamingRoutes() {
+	handler := interceptors.TimeoutMiddleware(50 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("streamed"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/accounts/1/statement", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("streamed", rec.Body.String())
+}
@@ -2,16 +2,33 @@ package interceptors
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/internal-transfers-service/internal/constants"
 	"github.com/internal-transfers-service/internal/logger"
 	"github.com/internal-transfers-service/internal/modules/idempotency"
 	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
 )
 
 // IdempotencyMiddleware provides idempotent request handling.
 // It caches responses by idempotency key and returns cached responses on retry.
+// Each key is bound to a fingerprint of the request it was first used with; a retry
+// with a different method, path or body is rejected rather than answered with the
+// cached response. The key is reserved before the handler runs, so a retry arriving
+// while the first request is still in progress is turned away instead of running it twice.
+// Keys are scoped to the client and the route, so clients cannot collide with each other
+// and a client may use the same key on different routes. The reservation travels in the
+// request context, so that handlers can store their response in their own database
+// transaction; other responses are stored once the handler returns.
 func IdempotencyMiddleware(repo idempotency.IRepository) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -31,11 +48,46 @@ func IdempotencyMiddleware(repo idempotency.IRepository) func(http.Handler) http
 				return
 			}
 
-			if handleCachedResponse(w, r, repo, key) {
+			scoped, ok := scopeKey(r, key)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			fingerprint, err := fingerprintRequest(r)
+			if err != nil {
+				logger.Ctx(r.Context()).Warnw(entities.LogMsgIdempotencyBodyReadFailed,
+					entities.LogFieldIdempotencyKey, key,
+					constants.LogKeyError, err,
+				)
+				writeErrorResponse(w, http.StatusBadRequest, constants.ErrMsgRequestBodyUnreadable, apperror.CodeBadRequest.String())
+				return
+			}
+
+			record, reserved, err := repo.Reserve(r.Context(), scoped, fingerprint, entities.ReservationLease)
+			if errors.Is(err, idempotency.ErrKeyTaken) {
+				// Not an outage: every retry would fail the same way, so processing it
+				// without the key would run it once per retry
+				writeKeyTakenError(w, r, scoped)
+				return
+			}
+			if err != nil {
+				// Fail open: the request is processed, but without the key
+				logger.Ctx(r.Context()).Warnw(entities.LogMsgIdempotencyReserveFailed,
+					entities.LogFieldIdempotencyKey, key,
+					entities.LogFieldClientID, scoped.Client,
+					constants.LogKeyError, err,
+				)
+				next.ServeHTTP(w, r)
 				return
 			}
 
-			captureAndStoreResponse(w, r, next, repo, key)
+			if !reserved {
+				handleHeldKey(w, r, record, scoped, fingerprint)
+				return
+			}
+
+			captureAndStoreResponse(w, r, next, repo, scoped, fingerprint, record.CreatedAt)
 		})
 	}
 }
@@ -56,6 +108,71 @@ func isValidIdempotencyKey(key string) bool {
 	return len(key) <= entities.MaxKeyLength
 }
 
+// scopeKey scopes the client's key to the client and route of the request. It returns
+// false if the request matches no route, in which case there is nothing to make idempotent.
+func scopeKey(r *http.Request, key string) (entities.ScopedKey, bool) {
+	route, ok := routePattern(r)
+	if !ok {
+		return entities.ScopedKey{}, false
+	}
+	return entities.ScopedKey{
+		Client: idempotencyClient(r),
+		Method: r.Method,
+		Route:  route,
+		Key:    key,
+	}, true
+}
+
+// idempotencyClient returns the client named by the X-Client-ID header, or anonymous
+// without one. The service has no authentication, so the header is taken at its word:
+// it keeps clients' keys apart but does not stop a client from using another's.
+func idempotencyClient(r *http.Request) string {
+	client := strings.TrimSpace(r.Header.Get(constants.HeaderClientID))
+	if client == "" {
+		return entities.AnonymousClient
+	}
+	if len(client) > entities.MaxClientIDLength {
+		client = client[:entities.MaxClientIDLength]
+	}
+	return client
+}
+
+// routePattern returns the pattern of the route that will serve the request. The
+// middleware runs before chi has routed the request, so the route is matched here;
+// outside a chi router the request path stands in for the pattern.
+func routePattern(r *http.Request) (string, bool) {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return r.URL.Path, true
+	}
+
+	match := chi.NewRouteContext()
+	if !rctx.Routes.Match(match, r.Method, r.URL.Path) {
+		return "", false
+	}
+	return match.RoutePattern(), true
+}
+
+// fingerprintRequest returns the hex SHA-256 of the request's method, path and body.
+// The body is read in full and replaced, so handlers can still read it.
+func fingerprintRequest(r *http.Request) (string, error) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			return "", err
+		}
+		_ = r.Body.Close()
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	sum := sha256.New()
+	// Method and path cannot contain a newline, so the parts cannot run into each other
+	_, _ = io.WriteString(sum, r.Method+"\n"+r.URL.Path+"\n")
+	_, _ = sum.Write(body)
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
 // writeIdempotencyError writes an error response for invalid idempotency key.
 func writeIdempotencyError(w http.ResponseWriter, r *http.Request, key string) {
 	truncatedKey := truncateKey(key)
@@ -64,9 +181,24 @@ func writeIdempotencyError(w http.ResponseWriter, r *http.Request, key string) {
 		entities.LogFieldKeyLength, len(key),
 	)
 
+	writeErrorResponse(w, http.StatusBadRequest, constants.ErrMsgIdempotencyKeyTooLong, constants.ErrCodeInvalidIdempotency)
+}
+
+// writeKeyTakenError rejects a request whose key is stored under another scope
+func writeKeyTakenError(w http.ResponseWriter, r *http.Request, key entities.ScopedKey) {
+	logger.Ctx(r.Context()).Warnw(entities.LogMsgIdempotencyKeyTaken,
+		entities.LogFieldIdempotencyKey, key.Key,
+		entities.LogFieldClientID, key.Client,
+	)
+
+	writeErrorResponse(w, apperror.CodeConflict.HTTPStatus(), constants.ErrMsgIdempotencyKeyTaken, apperror.CodeConflict.String())
+}
+
+// writeErrorResponse writes an error response raised by the middleware itself
+func writeErrorResponse(w http.ResponseWriter, status int, msg, code string) {
 	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
-	w.WriteHeader(http.StatusBadRequest)
-	_, _ = w.Write([]byte(buildIdempotencyErrorResponse()))
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(buildIdempotencyErrorResponse(msg, code)))
 }
 
 // truncateKey truncates long keys for safe logging
@@ -79,34 +211,41 @@ func truncateKey(key string) string {
 }
 
 // buildIdempotencyErrorResponse builds the error response JSON
-func buildIdempotencyErrorResponse() string {
-	return entities.ErrResponsePrefix + constants.ErrMsgIdempotencyKeyTooLong +
-		entities.ErrResponseCode + constants.ErrCodeInvalidIdempotency + entities.ErrResponseSuffix
+func buildIdempotencyErrorResponse(msg, code string) string {
+	return entities.ErrResponsePrefix + msg + entities.ErrResponseCode + code + entities.ErrResponseSuffix
 }
 
-// handleCachedResponse checks for a cached response and returns it if found.
-// Returns true if a cached response was returned, false otherwise.
-func handleCachedResponse(w http.ResponseWriter, r *http.Request, repo idempotency.IRepository, key string) bool {
-	record, err := repo.Get(r.Context(), key)
-	if err != nil {
-		logger.Ctx(r.Context()).Warnw(entities.LogMsgIdempotencyGetFailed,
-			entities.LogFieldIdempotencyKey, key,
-			constants.LogKeyError, err,
+// handleHeldKey answers a request whose key could not be reserved: it replays the
+// cached response, rejects the request if the key was first used for a different one,
+// or asks the client to retry if the request holding the key is still in progress.
+// A nil record means the holder released the key after the reservation attempt.
+func handleHeldKey(w http.ResponseWriter, r *http.Request, record *entities.IdempotencyRecord, key entities.ScopedKey, fingerprint string) {
+	if record != nil && record.Fingerprint != "" && record.Fingerprint != fingerprint {
+		logger.Ctx(r.Context()).Warnw(entities.LogMsgIdempotencyKeyReused,
+			entities.LogFieldIdempotencyKey, key.Key,
+			entities.LogFieldClientID, key.Client,
 		)
-		return false
+		writeErrorResponse(w, apperror.CodeDuplicateRequest.HTTPStatus(), constants.ErrMsgIdempotencyKeyReused, apperror.CodeDuplicateRequest.String())
+		return
 	}
 
-	if record == nil {
-		return false
+	if record == nil || record.InProgress() {
+		logger.Ctx(r.Context()).Infow(entities.LogMsgIdempotencyInProgress,
+			entities.LogFieldIdempotencyKey, key.Key,
+			entities.LogFieldClientID, key.Client,
+		)
+		w.Header().Set(constants.HeaderRetryAfter, constants.DefaultRetryAfterSeconds)
+		writeErrorResponse(w, apperror.CodeConflict.HTTPStatus(), constants.ErrMsgIdempotencyKeyInProgress, apperror.CodeConflict.String())
+		return
 	}
 
 	logger.Ctx(r.Context()).Infow(entities.LogMsgIdempotencyHit,
-		entities.LogFieldIdempotencyKey, key,
+		entities.LogFieldIdempotencyKey, key.Key,
+		entities.LogFieldClientID, key.Client,
 		entities.LogFieldCachedStatus, record.ResponseStatus,
 	)
 
 	writeCachedResponse(w, record)
-	return true
 }
 
 // writeCachedResponse writes the cached response to the client.
@@ -117,25 +256,69 @@ func writeCachedResponse(w http.ResponseWriter, record *entities.IdempotencyReco
 	_, _ = w.Write(record.ResponseBody)
 }
 
-// captureAndStoreResponse captures the response and stores it for future requests.
-func captureAndStoreResponse(w http.ResponseWriter, r *http.Request, next http.Handler, repo idempotency.IRepository, key string) {
+// captureAndStoreResponse runs the request holding the key's reservation, made at
+// reservedAt, and stores its response for future requests. The reservation is released
+// if the response cannot be cached or the handler panics, so a retry can run the request
+// again.
+func captureAndStoreResponse(w http.ResponseWriter, r *http.Request, next http.Handler, repo idempotency.IRepository, key entities.ScopedKey, fingerprint string, reservedAt time.Time) {
 	recorder := newResponseRecorder(w)
-	next.ServeHTTP(recorder, r)
+	reservation := idempotency.NewReservation(repo, key, fingerprint, reservedAt)
+	defer func() {
+		if p := recover(); p != nil {
+			releaseReservation(r, repo, key)
+			panic(p)
+		}
+	}()
+	next.ServeHTTP(recorder, r.WithContext(idempotency.NewContext(r.Context(), reservation)))
 
 	if shouldCacheResponse(recorder) {
-		storeResponse(r, repo, key, recorder)
+		if reservation.CompletedTx() {
+			logStoredInTx(r, key, recorder.statusCode)
+			return
+		}
+		storeResponse(r, repo, key, fingerprint, recorder)
 		return
 	}
 
 	if recorder.bodyCapped {
 		logResponseTooBig(r, key, recorder.statusCode)
 	}
+	releaseReservation(r, repo, key)
+}
+
+// releaseReservation drops the key's reservation. The request context is detached, since
+// a timed-out request's context is already cancelled when its handler returns.
+func releaseReservation(r *http.Request, repo idempotency.IRepository, key entities.ScopedKey) {
+	if err := repo.Release(context.WithoutCancel(r.Context()), key); err != nil {
+		// The lease still expires, so the key is only blocked until then
+		logger.Ctx(r.Context()).Warnw(entities.LogMsgIdempotencyReleaseFailed,
+			entities.LogFieldIdempotencyKey, key.Key,
+			entities.LogFieldClientID, key.Client,
+			constants.LogKeyError, err,
+		)
+		return
+	}
+
+	logger.Ctx(r.Context()).Debugw(entities.LogMsgIdempotencyReleased,
+		entities.LogFieldIdempotencyKey, key.Key,
+		entities.LogFieldClientID, key.Client,
+	)
+}
+
+// logStoredInTx logs a response the handler stored in its own database transaction
+func logStoredInTx(r *http.Request, key entities.ScopedKey, statusCode int) {
+	logger.Ctx(r.Context()).Debugw(entities.LogMsgIdempotencyStoredInTx,
+		entities.LogFieldIdempotencyKey, key.Key,
+		entities.LogFieldClientID, key.Client,
+		constants.LogKeyStatusCode, statusCode,
+	)
 }
 
 // logResponseTooBig logs when a response is too large to cache
-func logResponseTooBig(r *http.Request, key string, statusCode int) {
+func logResponseTooBig(r *http.Request, key entities.ScopedKey, statusCode int) {
 	logger.Ctx(r.Context()).Debugw(entities.LogMsgIdempotencyResponseTooBig,
-		entities.LogFieldIdempotencyKey, key,
+		entities.LogFieldIdempotencyKey, key.Key,
+		entities.LogFieldClientID, key.Client,
 		constants.LogKeyStatusCode, statusCode,
 	)
 }
@@ -147,19 +330,22 @@ func shouldCacheResponse(recorder *responseRecorder) bool {
 	return statusCodeCacheable && recorder.isCacheable()
 }
 
-// storeResponse stores the response in the idempotency cache.
-func storeResponse(r *http.Request, repo idempotency.IRepository, key string, recorder *responseRecorder) {
-	err := repo.Store(r.Context(), key, recorder.statusCode, recorder.body.Bytes())
+// storeResponse stores the response in the idempotency cache. Like releaseReservation it
+// detaches the request context, so a response finished after a timeout is still stored.
+func storeResponse(r *http.Request, repo idempotency.IRepository, key entities.ScopedKey, fingerprint string, recorder *responseRecorder) {
+	err := repo.Store(context.WithoutCancel(r.Context()), key, fingerprint, recorder.statusCode, recorder.body.Bytes())
 	if err != nil {
 		logger.Ctx(r.Context()).Warnw(entities.LogMsgIdempotencyStoreFailed,
-			entities.LogFieldIdempotencyKey, key,
+			entities.LogFieldIdempotencyKey, key.Key,
+			entities.LogFieldClientID, key.Client,
 			constants.LogKeyError, err,
 		)
 		return
 	}
 
 	logger.Ctx(r.Context()).Debugw(entities.LogMsgIdempotencyStored,
-		entities.LogFieldIdempotencyKey, key,
+		entities.LogFieldIdempotencyKey, key.Key,
+		entities.LogFieldClientID, key.Client,
 		constants.LogKeyStatusCode, recorder.statusCode,
 	)
 }
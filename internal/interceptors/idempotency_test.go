@@ -3,16 +3,21 @@ package interceptors_test
 import (
 	"bytes"
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/internal-transfers-service/internal/constants"
 	"github.com/internal-transfers-service/internal/interceptors"
+	"github.com/internal-transfers-service/internal/modules/idempotency"
 	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
 	"github.com/internal-transfers-service/internal/modules/idempotency/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 )
@@ -39,6 +44,28 @@ func (s *IdempotencyTestSuite) TearDownTest() {
 	s.ctrl.Finish()
 }
 
+// scopedKey returns the stored key for an anonymous client's key on the given route
+func scopedKey(method, route, key string) entities.ScopedKey {
+	return entities.ScopedKey{
+		Client: entities.AnonymousClient,
+		Method: method,
+		Route:  route,
+		Key:    key,
+	}
+}
+
+// reservedAt is when the mocked Reserve made the caller's reservation
+var reservedAt = time.Date(2026, time.March, 14, 9, 30, 0, 0, time.UTC)
+
+// reservation returns the record the mocked Reserve holds for the caller
+func reservation() *entities.IdempotencyRecord {
+	return &entities.IdempotencyRecord{
+		Status:         entities.StatusInProgress,
+		LeaseExpiresAt: reservedAt.Add(entities.ReservationLease),
+		CreatedAt:      reservedAt,
+	}
+}
+
 func (s *IdempotencyTestSuite) TestMiddlewareSkipsGetRequests() {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -77,14 +104,15 @@ func (s *IdempotencyTestSuite) TestMiddlewareSkipsRequestsWithoutKey() {
 func (s *IdempotencyTestSuite) TestMiddlewareReturnsCachedResponseOnHit() {
 	cachedRecord := &entities.IdempotencyRecord{
 		Key:            "existing-key",
+		Status:         entities.StatusCompleted,
 		ResponseStatus: 201,
 		ResponseBody:   []byte(`{"transaction_id":"cached-123"}`),
 		CreatedAt:      time.Now(),
 	}
 
 	s.mockRepo.EXPECT().
-		Get(gomock.Any(), "existing-key").
-		Return(cachedRecord, nil).
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "existing-key"), gomock.Any(), entities.ReservationLease).
+		Return(cachedRecord, false, nil).
 		Times(1)
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -107,12 +135,12 @@ func (s *IdempotencyTestSuite) TestMiddlewareReturnsCachedResponseOnHit() {
 
 func (s *IdempotencyTestSuite) TestMiddlewareProcessesAndStoresOnMiss() {
 	s.mockRepo.EXPECT().
-		Get(gomock.Any(), "new-key").
-		Return(nil, nil).
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "new-key"), gomock.Any(), entities.ReservationLease).
+		Return(reservation(), true, nil).
 		Times(1)
 
 	s.mockRepo.EXPECT().
-		Store(gomock.Any(), "new-key", 201, gomock.Any()).
+		Store(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "new-key"), gomock.Any(), 201, gomock.Any()).
 		Return(nil).
 		Times(1)
 
@@ -134,6 +162,366 @@ func (s *IdempotencyTestSuite) TestMiddlewareProcessesAndStoresOnMiss() {
 	s.Contains(rec.Body.String(), "new-123")
 }
 
+func (s *IdempotencyTestSuite) TestMiddlewareReplaysKeyWithSameRequest() {
+	body := `{"source_account_id":1,"destination_account_id":2,"amount":"10"}`
+	var fingerprint string
+	s.mockRepo.EXPECT().
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "retry-key"), gomock.Any(), entities.ReservationLease).
+		Return(reservation(), true, nil).
+		Times(1)
+	s.mockRepo.EXPECT().
+		Store(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "retry-key"), gomock.Any(), 201, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entities.ScopedKey, fp string, _ int, _ []byte) error {
+			fingerprint = fp
+			return nil
+		}).
+		Times(1)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		read, err := io.ReadAll(r.Body)
+		s.Require().NoError(err)
+		s.Equal(body, string(read), "handler must still see the body")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"transaction_id":"first-123"}`))
+	})
+	wrapped := interceptors.IdempotencyMiddleware(s.mockRepo)(handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(body))
+	req.Header.Set(constants.HeaderIdempotencyKey, "retry-key")
+	wrapped.ServeHTTP(httptest.NewRecorder(), req)
+	s.Require().Len(fingerprint, 64)
+
+	s.mockRepo.EXPECT().
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "retry-key"), gomock.Any(), entities.ReservationLease).
+		Return(&entities.IdempotencyRecord{
+			Key:            "retry-key",
+			Fingerprint:    fingerprint,
+			ResponseStatus: 201,
+			ResponseBody:   []byte(`{"transaction_id":"first-123"}`),
+		}, false, nil).
+		Times(1)
+
+	req = httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(body))
+	req.Header.Set(constants.HeaderIdempotencyKey, "retry-key")
+	rec := httptest.NewRecorder()
+	interceptors.IdempotencyMiddleware(s.mockRepo)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Fail("Handler should not be called on cache hit")
+	})).ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+	s.Contains(rec.Body.String(), "first-123")
+	s.Equal("true", rec.Header().Get(entities.HeaderIdempotentReplayed))
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareRejectsKeyReusedWithDifferentBody() {
+	s.mockRepo.EXPECT().
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "reused-key"), gomock.Any(), entities.ReservationLease).
+		Return(&entities.IdempotencyRecord{
+			Key:            "reused-key",
+			Fingerprint:    strings.Repeat("a", 64),
+			ResponseStatus: 201,
+			ResponseBody:   []byte(`{"transaction_id":"other-123"}`),
+		}, false, nil).
+		Times(1)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Fail("Handler should not be called for a reused key")
+	})
+
+	middleware := interceptors.IdempotencyMiddleware(s.mockRepo)
+	wrapped := middleware(handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{"amount":"20"}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, "reused-key")
+	rec := httptest.NewRecorder()
+
+	wrapped.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusUnprocessableEntity, rec.Code)
+	s.Contains(rec.Body.String(), apperror.CodeDuplicateRequest.String())
+	s.NotContains(rec.Body.String(), "other-123")
+	s.Empty(rec.Header().Get(entities.HeaderIdempotentReplayed))
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareScopesKeyByRoute() {
+	for _, path := range []string{"/deposits", "/withdrawals"} {
+		s.mockRepo.EXPECT().
+			Reserve(gomock.Any(), scopedKey(http.MethodPost, path, "path-key"), gomock.Any(), entities.ReservationLease).
+			Return(reservation(), true, nil).
+			Times(1)
+		s.mockRepo.EXPECT().
+			Store(gomock.Any(), scopedKey(http.MethodPost, path, "path-key"), gomock.Any(), 201, gomock.Any()).
+			Return(nil).
+			Times(1)
+	}
+
+	handled := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handled++
+		w.WriteHeader(http.StatusCreated)
+	})
+	wrapped := interceptors.IdempotencyMiddleware(s.mockRepo)(handler)
+
+	for _, path := range []string{"/deposits", "/withdrawals"} {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{}`))
+		req.Header.Set(constants.HeaderIdempotencyKey, "path-key")
+		rec := httptest.NewRecorder()
+		wrapped.ServeHTTP(rec, req)
+
+		s.Equal(http.StatusCreated, rec.Code)
+	}
+	s.Equal(2, handled, "the same key on another route is a different key")
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareScopesKeyByClientHeader() {
+	key := scopedKey(http.MethodPost, "/transactions", "client-key")
+	key.Client = "payments-api"
+	s.mockRepo.EXPECT().Reserve(gomock.Any(), key, gomock.Any(), entities.ReservationLease).Return(reservation(), true, nil).Times(1)
+	s.mockRepo.EXPECT().Store(gomock.Any(), key, gomock.Any(), 201, gomock.Any()).Return(nil).Times(1)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, "client-key")
+	req.Header.Set(constants.HeaderClientID, " payments-api ")
+	rec := httptest.NewRecorder()
+
+	interceptors.IdempotencyMiddleware(s.mockRepo)(handler).ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareScopesKeyByRoutePattern() {
+	key := scopedKey(http.MethodPut, "/v1/accounts/{id}", "pattern-key")
+	s.mockRepo.EXPECT().Reserve(gomock.Any(), key, gomock.Any(), entities.ReservationLease).Return(reservation(), true, nil).Times(2)
+	s.mockRepo.EXPECT().Store(gomock.Any(), key, gomock.Any(), 200, gomock.Any()).Return(nil).Times(2)
+
+	router := chi.NewRouter()
+	router.Use(interceptors.IdempotencyMiddleware(s.mockRepo))
+	router.Route("/v1", func(r chi.Router) {
+		r.Put("/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+
+	for _, path := range []string{"/v1/accounts/7", "/v1/accounts/8"} {
+		req := httptest.NewRequest(http.MethodPut, path, bytes.NewBufferString(`{}`))
+		req.Header.Set(constants.HeaderIdempotencyKey, "pattern-key")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		s.Equal(http.StatusOK, rec.Code)
+	}
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareSkipsRequestsMatchingNoRoute() {
+	// No repository calls are expected for a request that no route serves
+
+	router := chi.NewRouter()
+	router.Use(interceptors.IdempotencyMiddleware(s.mockRepo))
+	router.Post("/transactions", func(w http.ResponseWriter, r *http.Request) {
+		s.Fail("Handler should not be called for another route")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/nowhere", bytes.NewBufferString(`{}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, "lost-key")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusNotFound, rec.Code)
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareRejectsConcurrentDuplicateWithRetryAfter() {
+	s.mockRepo.EXPECT().
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "busy-key"), gomock.Any(), entities.ReservationLease).
+		DoAndReturn(func(_ context.Context, key entities.ScopedKey, fp string, _ time.Duration) (*entities.IdempotencyRecord, bool, error) {
+			return &entities.IdempotencyRecord{
+				Key:            key.Key,
+				Fingerprint:    fp,
+				Status:         entities.StatusInProgress,
+				LeaseExpiresAt: time.Now().Add(time.Minute),
+			}, false, nil
+		}).
+		Times(1)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Fail("Handler should not be called while the key is in progress")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, "busy-key")
+	rec := httptest.NewRecorder()
+
+	interceptors.IdempotencyMiddleware(s.mockRepo)(handler).ServeHTTP(rec, req)
+
+	s.Equal(http.StatusConflict, rec.Code)
+	s.Equal(constants.DefaultRetryAfterSeconds, rec.Header().Get(constants.HeaderRetryAfter))
+	s.Contains(rec.Body.String(), apperror.CodeConflict.String())
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareRejectsKeyReleasedDuringReservation() {
+	s.mockRepo.EXPECT().
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "released-key"), gomock.Any(), entities.ReservationLease).
+		Return(nil, false, nil).
+		Times(1)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Fail("Handler should not be called without the reservation")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, "released-key")
+	rec := httptest.NewRecorder()
+
+	interceptors.IdempotencyMiddleware(s.mockRepo)(handler).ServeHTTP(rec, req)
+
+	s.Equal(http.StatusConflict, rec.Code)
+	s.Equal(constants.DefaultRetryAfterSeconds, rec.Header().Get(constants.HeaderRetryAfter))
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareRejectsInProgressKeyReusedWithDifferentBody() {
+	s.mockRepo.EXPECT().
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "busy-key"), gomock.Any(), entities.ReservationLease).
+		Return(&entities.IdempotencyRecord{
+			Key:         "busy-key",
+			Fingerprint: strings.Repeat("a", 64),
+			Status:      entities.StatusInProgress,
+		}, false, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{"amount":"20"}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, "busy-key")
+	rec := httptest.NewRecorder()
+
+	interceptors.IdempotencyMiddleware(s.mockRepo)(http.NotFoundHandler()).ServeHTTP(rec, req)
+
+	s.Equal(http.StatusUnprocessableEntity, rec.Code)
+	s.Empty(rec.Header().Get(constants.HeaderRetryAfter))
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareReleasesKeyWhenHandlerPanics() {
+	s.mockRepo.EXPECT().
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "panic-key"), gomock.Any(), entities.ReservationLease).
+		Return(reservation(), true, nil).
+		Times(1)
+	s.mockRepo.EXPECT().Release(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "panic-key")).Return(nil).Times(1)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, "panic-key")
+
+	s.PanicsWithValue("boom", func() {
+		interceptors.IdempotencyMiddleware(s.mockRepo)(handler).ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareReleasesKeyWhenHandlerTimesOut() {
+	released := make(chan error, 1)
+	s.mockRepo.EXPECT().
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "slow-key"), gomock.Any(), entities.ReservationLease).
+		Return(reservation(), true, nil).
+		Times(1)
+	s.mockRepo.EXPECT().
+		Release(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "slow-key")).
+		DoAndReturn(func(ctx context.Context, _ entities.ScopedKey) error {
+			released <- ctx.Err()
+			return nil
+		}).
+		Times(1)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	wrapped := interceptors.TimeoutMiddleware(10 * time.Millisecond)(interceptors.IdempotencyMiddleware(s.mockRepo)(handler))
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, "slow-key")
+	rec := httptest.NewRecorder()
+	wrapped.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusServiceUnavailable, rec.Code)
+	select {
+	case err := <-released:
+		s.NoError(err, "release must not use the cancelled request context")
+	case <-time.After(time.Second):
+		s.Fail("reservation was not released")
+	}
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareProcessesRequestWhenReserveFails() {
+	s.mockRepo.EXPECT().
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "db-down-key"), gomock.Any(), entities.ReservationLease).
+		Return(nil, false, errors.New("connection refused")).
+		Times(1)
+
+	// Store should NOT be called without a reservation
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, "db-down-key")
+	rec := httptest.NewRecorder()
+
+	interceptors.IdempotencyMiddleware(s.mockRepo)(handler).ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareRejectsKeyStoredUnderAnotherScope() {
+	s.mockRepo.EXPECT().
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "taken-key"), gomock.Any(), entities.ReservationLease).
+		Return(nil, false, idempotency.ErrKeyTaken).
+		Times(2)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Fail("Handler should not be called for a key that cannot be reserved")
+	})
+
+	// A retry fails the same way, so it must not be processed either
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{}`))
+		req.Header.Set(constants.HeaderIdempotencyKey, "taken-key")
+		rec := httptest.NewRecorder()
+
+		interceptors.IdempotencyMiddleware(s.mockRepo)(handler).ServeHTTP(rec, req)
+
+		s.Equal(http.StatusConflict, rec.Code)
+		s.Contains(rec.Body.String(), "CONFLICT")
+	}
+}
+
+func (s *IdempotencyTestSuite) TestMiddlewareSkipsStoreWhenHandlerStoredInTx() {
+	key := scopedKey(http.MethodPost, "/transactions", "tx-key")
+	s.mockRepo.EXPECT().Reserve(gomock.Any(), key, gomock.Any(), entities.ReservationLease).Return(reservation(), true, nil).Times(1)
+	s.mockRepo.EXPECT().StoreTx(gomock.Any(), nil, key, gomock.Any(), reservedAt, 201, []byte(`{"transaction_id":"tx-123"}`)).Return(nil).Times(1)
+
+	// Store should NOT be called: the handler's transaction already stored the response
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reservation := idempotency.FromContext(r.Context())
+		s.Require().NotNil(reservation)
+		s.Require().NoError(reservation.CompleteTx(r.Context(), nil, 201, []byte(`{"transaction_id":"tx-123"}`)))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"transaction_id":"tx-123"}`))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, "tx-key")
+	rec := httptest.NewRecorder()
+
+	interceptors.IdempotencyMiddleware(s.mockRepo)(handler).ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+}
+
 func (s *IdempotencyTestSuite) TestMiddlewareRejectsKeyTooLong() {
 	longKey := strings.Repeat("x", 300)
 
@@ -156,11 +544,12 @@ func (s *IdempotencyTestSuite) TestMiddlewareRejectsKeyTooLong() {
 
 func (s *IdempotencyTestSuite) TestMiddlewareDoesNotCache5xxErrors() {
 	s.mockRepo.EXPECT().
-		Get(gomock.Any(), "error-key").
-		Return(nil, nil).
+		Reserve(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "error-key"), gomock.Any(), entities.ReservationLease).
+		Return(reservation(), true, nil).
 		Times(1)
 
-	// Store should NOT be called for 5xx errors
+	// Store should NOT be called for 5xx errors; the reservation is released instead
+	s.mockRepo.EXPECT().Release(gomock.Any(), scopedKey(http.MethodPost, "/transactions", "error-key")).Return(nil).Times(1)
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -181,12 +570,12 @@ func (s *IdempotencyTestSuite) TestMiddlewareDoesNotCache5xxErrors() {
 
 func (s *IdempotencyTestSuite) TestMiddlewareHandlesPutRequests() {
 	s.mockRepo.EXPECT().
-		Get(gomock.Any(), "put-key").
-		Return(nil, nil).
+		Reserve(gomock.Any(), scopedKey(http.MethodPut, "/accounts/1", "put-key"), gomock.Any(), entities.ReservationLease).
+		Return(reservation(), true, nil).
 		Times(1)
 
 	s.mockRepo.EXPECT().
-		Store(gomock.Any(), "put-key", 200, gomock.Any()).
+		Store(gomock.Any(), scopedKey(http.MethodPut, "/accounts/1", "put-key"), gomock.Any(), 200, gomock.Any()).
 		Return(nil).
 		Times(1)
 
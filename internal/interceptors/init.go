@@ -82,6 +82,7 @@ func GetChiMiddleware() []func(http.Handler) http.Handler {
 		middleware.RealIP,
 		RecoveryMiddleware,
 		RequestIDMiddleware,
+		AuditContextMiddleware,
 		SecurityHeadersMiddleware,
 		MaxBytesMiddleware,
 		ContentTypeValidationMiddleware,
@@ -134,14 +135,15 @@ func GetChiMiddlewareWithConfig(idempotencyRepo idempotency.IRepository, rateLim
 // 4. TraceContextMiddleware - Add trace IDs to context for logging
 // 5. RecoveryMiddleware - Panic recovery (must be early to catch all panics)
 // 6. RequestIDMiddleware - Add request ID to response headers
-// 7. SecurityHeadersMiddleware - Add security headers early
-// 8. RateLimitMiddleware - Rate limiting early (before heavy processing)
-// 9. MaxBytesMiddleware - Limit body size before parsing (DoS protection)
-// 10. ContentTypeValidationMiddleware - Validate content-type before parsing
-// 11. TimeoutMiddleware - Request timeout protection
-// 12. MetricsMiddleware - Record metrics (after timeout to measure actual time)
-// 13. RequestLoggerMiddleware - Log requests (captures response details)
-// 14. IdempotencyMiddleware - Handle idempotent requests last
+// 7. AuditContextMiddleware - Add request ID, client IP and actor to context for audit events
+// 8. SecurityHeadersMiddleware - Add security headers early
+// 9. RateLimitMiddleware - Rate limiting early (before heavy processing)
+// 10. MaxBytesMiddleware - Limit body size before parsing (DoS protection)
+// 11. ContentTypeValidationMiddleware - Validate content-type before parsing
+// 12. TimeoutMiddleware - Request timeout protection
+// 13. MetricsMiddleware - Record metrics (after timeout to measure actual time)
+// 14. RequestLoggerMiddleware - Log requests (captures response details)
+// 15. IdempotencyMiddleware - Handle idempotent requests last
 func GetChiMiddlewareWithFullConfig(cfg MiddlewareConfig) []func(http.Handler) http.Handler {
 	return []func(http.Handler) http.Handler{
 		middleware.RequestID,
@@ -150,6 +152,7 @@ func GetChiMiddlewareWithFullConfig(cfg MiddlewareConfig) []func(http.Handler) h
 		TraceContextMiddleware,
 		RecoveryMiddleware,
 		RequestIDMiddleware,
+		AuditContextMiddleware,
 		SecurityHeadersMiddleware,
 		RateLimitMiddleware(cfg.RateLimit),
 		MaxBytesMiddleware,
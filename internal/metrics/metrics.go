@@ -76,6 +76,59 @@ var (
 	)
 )
 
+// Reconciliation metrics
+var (
+	// LedgerDriftAccounts tracks accounts whose stored balance disagreed with their history
+	// in the last completed reconciliation
+	LedgerDriftAccounts = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: constants.MetricLedgerDrift,
+			Help: "Number of accounts whose balance drifted from their transaction history at the last reconciliation",
+		},
+	)
+)
+
+// Money conservation metrics
+var (
+	// ConservationDifference tracks, per currency and scope, how far the balances were from
+	// the money that entered and left the system at the last conservation check
+	ConservationDifference = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.MetricConservationDifference,
+			Help: "Sum of balances minus the amount implied by money flows at the last conservation check; non-zero means money was created or destroyed",
+		},
+		[]string{constants.LabelCurrency, constants.LabelScope},
+	)
+
+	// ConservationViolations tracks the currencies that failed the last conservation check
+	ConservationViolations = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: constants.MetricConservationViolations,
+			Help: "Number of currencies whose balances did not match their money flows at the last conservation check",
+		},
+	)
+
+	// ConservationLastCheck tracks when the last conservation check completed, so stale checks can be alerted on
+	ConservationLastCheck = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: constants.MetricConservationLastCheck,
+			Help: "Unix time of the last completed money conservation check",
+		},
+	)
+)
+
+// Transaction partition metrics
+var (
+	// DefaultPartitionRows tracks the transactions that fell into the default partition
+	// because their month had no partition
+	DefaultPartitionRows = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: constants.MetricDefaultPartitionRows,
+			Help: "Number of transactions in the default partition at the last partition check; non-zero means partition maintenance has fallen behind",
+		},
+	)
+)
+
 // RecordHTTPRequest records an HTTP request metric
 func RecordHTTPRequest(method, path string, statusCode int, durationSeconds float64) {
 	statusCodeStr := statusCodeToLabel(statusCode)
@@ -104,6 +157,27 @@ func UpdateDBConnectionMetrics(open, idle int64) {
 	DBConnectionsIdle.Set(float64(idle))
 }
 
+// SetLedgerDriftAccounts records the drift count of a completed reconciliation
+func SetLedgerDriftAccounts(count int) {
+	LedgerDriftAccounts.Set(float64(count))
+}
+
+// SetConservationDifference records a currency's difference in one scope of a completed conservation check
+func SetConservationDifference(currency, scope string, difference float64) {
+	ConservationDifference.WithLabelValues(currency, scope).Set(difference)
+}
+
+// SetConservationResult records the outcome of a completed conservation check
+func SetConservationResult(violations int, checkedAt float64) {
+	ConservationViolations.Set(float64(violations))
+	ConservationLastCheck.Set(checkedAt)
+}
+
+// SetDefaultPartitionRows records the rows found in the default partition
+func SetDefaultPartitionRows(count int64) {
+	DefaultPartitionRows.Set(float64(count))
+}
+
 // statusCodeToLabel converts HTTP status code to a label
 func statusCodeToLabel(code int) string {
 	switch {
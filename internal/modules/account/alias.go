@@ -0,0 +1,116 @@
+package account
+
+import (
+	"crypto/rand"
+	"math/big"
+	"net/mail"
+	"strings"
+
+	"github.com/internal-transfers-service/internal/modules/account/entities"
+)
+
+// CanonicalAlias converts an alias as typed by a client to the form it is stored in:
+// handles and emails are lower-cased, and IBANs are upper-cased with spaces removed.
+// Input without an @ is read as an IBAN if its check digits are valid and as a handle
+// otherwise, so a handle can be typed without its prefix as it was registered.
+// It does not validate the alias; an invalid one simply resolves to nothing.
+func CanonicalAlias(alias string) string {
+	alias = strings.TrimSpace(alias)
+	if strings.Contains(alias, "@") {
+		return strings.ToLower(alias)
+	}
+	iban := strings.ToUpper(strings.Join(strings.Fields(alias), ""))
+	if handle, ok := canonicalHandle(alias); ok && !ValidIBAN(iban) {
+		return handle
+	}
+	return iban
+}
+
+// canonicalHandle validates a handle, with or without its @ prefix, and returns it in canonical form.
+// A handle that reads as a valid IBAN is accepted here; registration rejects it separately.
+func canonicalHandle(alias string) (string, bool) {
+	name := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(alias)), entities.HandlePrefix)
+	if len(name) < entities.MinHandleLength || len(name) > entities.MaxHandleLength {
+		return "", false
+	}
+	for i, ch := range name {
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= '0' && ch <= '9':
+		case i > 0 && (ch == '.' || ch == '_' || ch == '-'):
+		default:
+			return "", false
+		}
+	}
+	return entities.HandlePrefix + name, true
+}
+
+// handleReadsAsIBAN reports whether a canonical handle, typed without its prefix, would
+// resolve as an IBAN instead (see CanonicalAlias)
+func handleReadsAsIBAN(handle string) bool {
+	return ValidIBAN(strings.ToUpper(strings.TrimPrefix(handle, entities.HandlePrefix)))
+}
+
+// canonicalEmail validates a bare email address and returns it in canonical form
+func canonicalEmail(alias string) (string, bool) {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if len(alias) > entities.MaxAliasLength {
+		return "", false
+	}
+	addr, err := mail.ParseAddress(alias)
+	if err != nil || addr.Address != alias {
+		return "", false
+	}
+	at := strings.LastIndex(alias, "@")
+	if at < 1 || !strings.Contains(alias[at+1:], ".") {
+		return "", false
+	}
+	return alias, true
+}
+
+// GenerateIBAN returns a random IBAN-style number: the IBANCountryCode, two check digits
+// and IBANBBANLength random digits
+func GenerateIBAN() (string, error) {
+	bban := make([]byte, entities.IBANBBANLength)
+	for i := range bban {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		bban[i] = byte('0' + digit.Int64())
+	}
+	return withCheckDigits(entities.IBANCountryCode, string(bban)), nil
+}
+
+// withCheckDigits assembles an IBAN from its country code and BBAN, computing the
+// ISO 7064 MOD 97-10 check digits
+func withCheckDigits(country, bban string) string {
+	check := 98 - ibanMod97(bban+country+"00")
+	return country + string([]byte{byte('0' + check/10), byte('0' + check%10)}) + bban
+}
+
+// ValidIBAN reports whether an upper-case IBAN without spaces has valid check digits
+func ValidIBAN(iban string) bool {
+	if len(iban) < 5 {
+		return false
+	}
+	for _, ch := range iban {
+		if (ch < '0' || ch > '9') && (ch < 'A' || ch > 'Z') {
+			return false
+		}
+	}
+	return ibanMod97(iban[4:]+iban[:4]) == 1
+}
+
+// ibanMod97 returns the remainder mod 97 of an IBAN string with letters expanded to
+// two-digit numbers (A=10 ... Z=35), computed digit by digit to avoid big integers
+func ibanMod97(s string) int {
+	rem := 0
+	for _, ch := range s {
+		if ch >= 'A' && ch <= 'Z' {
+			rem = (rem*100 + int(ch-'A') + 10) % 97
+			continue
+		}
+		rem = (rem*10 + int(ch-'0')) % 97
+	}
+	return rem
+}
@@ -0,0 +1,59 @@
+package account_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/internal-transfers-service/internal/modules/account"
+	"github.com/internal-transfers-service/internal/modules/account/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+// AliasTestSuite contains tests for alias formats
+type AliasTestSuite struct {
+	suite.Suite
+}
+
+func TestAliasSuite(t *testing.T) {
+	suite.Run(t, new(AliasTestSuite))
+}
+
+func (s *AliasTestSuite) TestCanonicalAliasNormalizesEachForm() {
+	s.Equal("@alice", account.CanonicalAlias(" @Alice "))
+	s.Equal("alice@example.com", account.CanonicalAlias("Alice@Example.COM"))
+	s.Equal("GB82WEST12345698765432", account.CanonicalAlias("gb82 west 1234 5698 7654 32"))
+}
+
+func (s *AliasTestSuite) TestCanonicalAliasReadsBareHandleAsHandle() {
+	s.Equal("@alice.smith", account.CanonicalAlias("Alice.Smith"))
+	s.Equal("GB82WEST12345698765432", account.CanonicalAlias("gb82west12345698765432"))
+	s.Equal("@gb83west12345698765432", account.CanonicalAlias("GB83WEST12345698765432"))
+	s.Equal("GB83WEST12345698765432", account.CanonicalAlias("GB83 WEST 1234 5698 7654 32"))
+}
+
+func (s *AliasTestSuite) TestValidIBANChecksCheckDigits() {
+	s.True(account.ValidIBAN("GB82WEST12345698765432"))
+	s.False(account.ValidIBAN("GB83WEST12345698765432"))
+	s.False(account.ValidIBAN("GB82WEST1234569876543"))
+	s.False(account.ValidIBAN("GB82-WEST"))
+	s.False(account.ValidIBAN("GB8"))
+}
+
+func (s *AliasTestSuite) TestGenerateIBANProducesValidNumbers() {
+	for range 50 {
+		iban, err := account.GenerateIBAN()
+		s.Require().NoError(err)
+		s.Len(iban, 4+entities.IBANBBANLength)
+		s.True(strings.HasPrefix(iban, entities.IBANCountryCode), iban)
+		s.True(account.ValidIBAN(iban), iban)
+	}
+}
+
+func (s *AliasTestSuite) TestGeneratedIBANDetectsSingleDigitTypos() {
+	iban, err := account.GenerateIBAN()
+	s.Require().NoError(err)
+
+	typo := []byte(iban)
+	typo[10] = '0' + (typo[10]-'0'+1)%10
+	s.False(account.ValidIBAN(string(typo)))
+}
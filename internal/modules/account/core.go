@@ -5,33 +5,70 @@ package account
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/internal-transfers-service/internal/config"
 	"github.com/internal-transfers-service/internal/constants"
 	"github.com/internal-transfers-service/internal/logger"
 	"github.com/internal-transfers-service/internal/modules/account/entities"
 	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/jackc/pgx/v5"
 	"github.com/shopspring/decimal"
 )
 
 // Domain errors
 var (
-	ErrAccountNotFound      = errors.New(entities.ErrMsgAccountNotFound)
-	ErrAccountExists        = errors.New(entities.ErrMsgAccountExists)
-	ErrInvalidAccountID     = errors.New(entities.ErrMsgInvalidAccountID)
-	ErrInvalidBalance       = errors.New(entities.ErrMsgInvalidBalance)
-	ErrInvalidDecimal       = errors.New(entities.ErrMsgInvalidDecimal)
-	ErrTooManyDecimalPlaces = errors.New(entities.ErrMsgTooManyDecimalPlaces)
+	ErrAccountNotFound         = errors.New(entities.ErrMsgAccountNotFound)
+	ErrAccountExists           = errors.New(entities.ErrMsgAccountExists)
+	ErrInvalidAccountID        = errors.New(entities.ErrMsgInvalidAccountID)
+	ErrInvalidBalance          = errors.New(entities.ErrMsgInvalidBalance)
+	ErrInvalidDecimal          = errors.New(entities.ErrMsgInvalidDecimal)
+	ErrTooManyDecimalPlaces    = errors.New(entities.ErrMsgTooManyDecimalPlaces)
+	ErrInvalidCurrency         = errors.New(entities.ErrMsgInvalidCurrency)
+	ErrClearingNotFound        = errors.New(entities.ErrMsgClearingNotFound)
+	ErrInterestExpenseNotFound = errors.New(entities.ErrMsgInterestExpenseNotFound)
+	ErrInvalidAsOf             = errors.New(entities.ErrMsgInvalidAsOf)
+	ErrFutureAsOf              = errors.New(entities.ErrMsgFutureAsOf)
+	ErrParentNotFound          = errors.New(entities.ErrMsgParentNotFound)
+	ErrInvalidParent           = errors.New(entities.ErrMsgInvalidParent)
+	ErrParentHasBalance        = errors.New(entities.ErrMsgParentHasBalance)
+	ErrHierarchyCycle          = errors.New(entities.ErrMsgHierarchyCycle)
+	ErrParentRequired          = errors.New(entities.ErrMsgParentRequired)
+	ErrInvalidMinBalance       = errors.New(entities.ErrMsgInvalidMinBalance)
+	ErrMinBalanceSystem        = errors.New(entities.ErrMsgMinBalanceSystem)
+	ErrInvalidBulkMode         = errors.New(entities.ErrMsgInvalidBulkMode)
+	ErrInvalidBulkSize         = errors.New(entities.ErrMsgInvalidBulkSize)
+	ErrInvalidCSV              = errors.New(entities.ErrMsgInvalidCSV)
+	ErrInvalidAlias            = errors.New(entities.ErrMsgInvalidAlias)
+	ErrInvalidAliasKind        = errors.New(entities.ErrMsgInvalidAliasKind)
+	ErrAliasTaken              = errors.New(entities.ErrMsgAliasTaken)
+	ErrAliasNotFound           = errors.New(entities.ErrMsgAliasNotFound)
+	ErrAliasSystem             = errors.New(entities.ErrMsgAliasSystem)
+	ErrAliasGenerated          = errors.New(entities.ErrMsgAliasGenerated)
 )
 
 // ICore defines the interface for account business logic
 type ICore interface {
-	Create(ctx context.Context, req *entities.CreateAccountRequest) apperror.IError
+	Create(ctx context.Context, req *entities.CreateAccountRequest) (*entities.AccountResponse, apperror.IError)
+	BulkCreate(ctx context.Context, req *entities.BulkCreateAccountsRequest) (*entities.BulkCreateResponse, apperror.IError)
 	GetByID(ctx context.Context, accountID int64) (*entities.AccountResponse, apperror.IError)
+	GetWithRollup(ctx context.Context, accountID int64) (*entities.AccountResponse, apperror.IError)
+	ListChildren(ctx context.Context, accountID int64) (*entities.ChildrenResponse, apperror.IError)
+	Move(ctx context.Context, accountID int64, req *entities.MoveAccountRequest) (*entities.AccountResponse, apperror.IError)
+	SetMinimumBalance(ctx context.Context, accountID int64, req *entities.SetMinimumBalanceRequest) (*entities.AccountResponse, apperror.IError)
+	GetBalanceAsOf(ctx context.Context, accountID int64, asOf time.Time) (*entities.BalanceResponse, apperror.IError)
+	CreateAlias(ctx context.Context, accountID int64, req *entities.CreateAliasRequest) (*entities.AliasResponse, apperror.IError)
+	ListAliases(ctx context.Context, accountID int64) (*entities.AliasesResponse, apperror.IError)
+	EnsureSystemAccounts(ctx context.Context) error
 }
 
 // Core implements ICore
 type Core struct {
-	repo IRepository
+	repo  IRepository
+	idGen IDGenerator
+	cfg   config.AccountConfig
 }
 
 // Compile-time interface check
@@ -41,36 +78,110 @@ var _ ICore = (*Core)(nil)
 var coreInstance ICore
 
 // NewCore creates a new Core instance
-func NewCore(_ context.Context, repo IRepository) ICore {
+func NewCore(_ context.Context, repo IRepository, cfg config.AccountConfig) ICore {
+	cfg = withConfigDefaults(cfg)
 	coreInstance = &Core{
-		repo: repo,
+		repo:  repo,
+		idGen: NewIDGenerator(cfg.IDGenerator, repo),
+		cfg:   cfg,
 	}
 	return coreInstance
 }
 
-// NewCoreWithRepo creates a new Core instance with the given repository (for testing)
+// NewCoreWithRepo creates a new Core instance with the given repository (for testing).
+// Generated account IDs come from the repository's sequence and only the default currency is supported.
 func NewCoreWithRepo(_ context.Context, repo IRepository) ICore {
 	return &Core{
-		repo: repo,
+		repo:  repo,
+		idGen: NewSequenceIDGenerator(repo),
+		cfg:   withConfigDefaults(config.AccountConfig{}),
 	}
 }
 
+// withConfigDefaults fills in the default currency and ensures it is in the supported list
+func withConfigDefaults(cfg config.AccountConfig) config.AccountConfig {
+	if cfg.DefaultCurrency == "" {
+		cfg.DefaultCurrency = entities.DefaultCurrency
+	}
+	cfg.DefaultCurrency = strings.ToUpper(cfg.DefaultCurrency)
+
+	currencies := make([]string, 0, len(cfg.Currencies)+1)
+	for _, currency := range cfg.Currencies {
+		currencies = append(currencies, strings.ToUpper(currency))
+	}
+	if !slices.Contains(currencies, cfg.DefaultCurrency) {
+		currencies = append(currencies, cfg.DefaultCurrency)
+	}
+	cfg.Currencies = currencies
+	return cfg
+}
+
 // GetCore returns the singleton Core instance
 func GetCore() ICore {
 	return coreInstance
 }
 
-// Create creates a new account with the given initial balance
-func (c *Core) Create(ctx context.Context, req *entities.CreateAccountRequest) apperror.IError {
-	// Validate account ID
-	if req.AccountID <= 0 {
+// Create creates a new account with the given initial balance.
+// When the request omits account_id, an ID is allocated by the configured IDGenerator.
+func (c *Core) Create(ctx context.Context, req *entities.CreateAccountRequest) (*entities.AccountResponse, apperror.IError) {
+	balance, appErr := c.validateCreateRequest(ctx, req)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	currency, appErr := c.resolveCurrency(ctx, req.Currency)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	account := &Account{
+		AccountID:       req.AccountID,
+		Balance:         balance,
+		Currency:        currency,
+		Kind:            entities.KindCustomer,
+		ParentAccountID: req.ParentAccountID,
+	}
+
+	if account.AccountID != 0 {
+		return c.insertAccount(ctx, account)
+	}
+
+	return c.createWithGeneratedID(ctx, account)
+}
+
+// resolveCurrency applies the default currency and checks the currency is supported
+func (c *Core) resolveCurrency(ctx context.Context, currency string) (string, apperror.IError) {
+	if currency == "" {
+		return c.cfg.DefaultCurrency, nil
+	}
+
+	currency = strings.ToUpper(currency)
+	if !slices.Contains(c.cfg.Currencies, currency) {
+		logger.Ctx(ctx).Debugw(constants.LogMsgUnsupportedCurrency,
+			constants.LogFieldCurrency, currency,
+		)
+		return "", apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidCurrency, apperror.MsgInvalidCurrency).
+			WithField(apperror.FieldCurrency, currency)
+	}
+	return currency, nil
+}
+
+// validateCreateRequest validates the create request and returns the parsed initial balance
+func (c *Core) validateCreateRequest(ctx context.Context, req *entities.CreateAccountRequest) (decimal.Decimal, apperror.IError) {
+	// Validate account ID (zero means "allocate one for me")
+	if req.AccountID < 0 {
 		logger.Ctx(ctx).Debugw(constants.LogMsgInvalidAccountIDCreate,
 			constants.LogKeyAccountID, req.AccountID,
 		)
-		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+		return decimal.Zero, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
 			WithField(apperror.FieldAccountID, req.AccountID)
 	}
 
+	if req.ParentAccountID != nil && *req.ParentAccountID <= 0 {
+		return decimal.Zero, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldParentAccount, *req.ParentAccountID)
+	}
+
 	// Parse and validate initial balance
 	balance, err := decimal.NewFromString(req.InitialBalance)
 	if err != nil {
@@ -78,7 +189,7 @@ func (c *Core) Create(ctx context.Context, req *entities.CreateAccountRequest) a
 			constants.LogFieldInitialBalance, req.InitialBalance,
 			constants.LogKeyError, err,
 		)
-		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidDecimal, apperror.MsgInvalidDecimalFormat).
+		return decimal.Zero, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidDecimal, apperror.MsgInvalidDecimalFormat).
 			WithField(constants.LogFieldInitialBalance, req.InitialBalance)
 	}
 
@@ -86,54 +197,498 @@ func (c *Core) Create(ctx context.Context, req *entities.CreateAccountRequest) a
 		logger.Ctx(ctx).Debugw(constants.LogMsgNegativeBalanceProvided,
 			constants.LogFieldInitialBalance, req.InitialBalance,
 		)
-		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidBalance, apperror.MsgNegativeBalance).
+		return decimal.Zero, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidBalance, apperror.MsgNegativeBalance).
 			WithField(constants.LogFieldInitialBalance, req.InitialBalance)
 	}
 
 	// Validate decimal precision (max 8 places to match DB schema)
 	if appErr := validateDecimalPrecision(ctx, balance, constants.LogFieldInitialBalance); appErr != nil {
-		return appErr
+		return decimal.Zero, appErr
 	}
 
-	// Check if account already exists
-	exists, err := c.repo.Exists(ctx, req.AccountID)
-	if err != nil {
-		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToCheckAcctExist,
-			constants.LogKeyAccountID, req.AccountID,
+	return balance, nil
+}
+
+// createWithGeneratedID allocates an ID and inserts the account, retrying if the
+// generated ID collides with an existing (e.g. client-chosen) account ID.
+func (c *Core) createWithGeneratedID(ctx context.Context, account *Account) (*entities.AccountResponse, apperror.IError) {
+	var appErr apperror.IError
+	for attempt := 1; attempt <= entities.MaxGeneratedIDAttempts; attempt++ {
+		accountID, err := c.idGen.NextID(ctx)
+		if err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToAllocateAcctID,
+				constants.LogKeyError, err,
+			)
+			return nil, apperror.New(apperror.CodeInternalError, err)
+		}
+
+		account.AccountID = accountID
+
+		var response *entities.AccountResponse
+		response, appErr = c.insertAccount(ctx, account)
+		if appErr == nil || appErr.Code() != apperror.CodeConflict {
+			return response, appErr
+		}
+
+		logger.Ctx(ctx).Warnw(constants.LogMsgGeneratedIDCollision,
+			constants.LogKeyAccountID, accountID,
+		)
+	}
+	return nil, appErr
+}
+
+// insertAccount persists the account, relying on the primary key to reject duplicates
+func (c *Core) insertAccount(ctx context.Context, account *Account) (*entities.AccountResponse, apperror.IError) {
+	if account.ParentAccountID != nil {
+		return c.insertChildAccount(ctx, account)
+	}
+
+	if err := c.repo.Create(ctx, account); err != nil {
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToCreateAccount,
+			constants.LogKeyAccountID, account.AccountID,
 			constants.LogKeyError, err,
 		)
-		return apperror.New(apperror.CodeInternalError, err).
-			WithField(apperror.FieldAccountID, req.AccountID)
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, account.AccountID)
 	}
 
-	if exists {
-		return apperror.NewWithMessage(apperror.CodeConflict, ErrAccountExists, apperror.MsgDuplicateAccount).
-			WithField(apperror.FieldAccountID, req.AccountID)
+	logger.Ctx(ctx).Infow(constants.LogMsgAccountCreated,
+		constants.LogKeyAccountID, account.AccountID,
+		constants.LogFieldInitialBalance, account.Balance.String(),
+		constants.LogFieldCurrency, account.Currency,
+	)
+
+	return toAccountResponse(account), nil
+}
+
+// insertChildAccount inserts an account under its parent. The parent is locked for the
+// insert so that no posting can land on it between the zero-balance check and the
+// moment it stops being a leaf.
+func (c *Core) insertChildAccount(ctx context.Context, account *Account) (*entities.AccountResponse, apperror.IError) {
+	tx, err := c.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, account.AccountID)
 	}
+	// Rollback is a no-op once the transaction has committed
+	defer func() { _ = tx.Rollback(ctx) }()
 
-	// Create the account
-	account := &Account{
-		AccountID: req.AccountID,
-		Balance:   balance,
+	parent, appErr := c.lockParent(ctx, tx, *account.ParentAccountID)
+	if appErr != nil {
+		return nil, appErr
 	}
 
-	if err := c.repo.Create(ctx, account); err != nil {
+	if appErr := validateParent(account, parent); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := c.repo.CreateTx(ctx, tx, account); err != nil {
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, account.AccountID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
 		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToCreateAccount,
-			constants.LogKeyAccountID, req.AccountID,
+			constants.LogKeyAccountID, account.AccountID,
 			constants.LogKeyError, err,
 		)
-		return apperror.New(apperror.CodeInternalError, err).
-			WithField(apperror.FieldAccountID, req.AccountID)
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, account.AccountID)
 	}
 
-	logger.Ctx(ctx).Infow(constants.LogMsgAccountCreated,
-		constants.LogKeyAccountID, req.AccountID,
-		constants.LogFieldInitialBalance, balance.String(),
+	return toAccountResponse(account), nil
+}
+
+// lockParent locks the would-be parent account, reporting a missing one as PARENT not found
+func (c *Core) lockParent(ctx context.Context, tx pgx.Tx, parentID int64) (*Account, apperror.IError) {
+	parent, err := c.repo.GetForUpdate(ctx, tx, parentID)
+	if err != nil {
+		return nil, parentLookupError(err, parentID)
+	}
+	return parent, nil
+}
+
+// parentLookupError converts a repository error for the parent account to an API error
+func parentLookupError(err error, parentID int64) apperror.IError {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) && appErr.Code() == apperror.CodeNotFound {
+		return apperror.NewWithMessage(apperror.CodeNotFound, ErrParentNotFound, apperror.MsgParentNotFound).
+			WithField(apperror.FieldParentAccount, parentID)
+	}
+	return apperror.New(apperror.CodeInternalError, err).
+		WithField(apperror.FieldParentAccount, parentID)
+}
+
+// validateParent checks that parent can take account as a sub-account: both must be
+// customer accounts in the same currency, and the parent must hold no funds, since
+// only leaf accounts can be posted to and the funds would otherwise be stranded.
+func validateParent(account, parent *Account) apperror.IError {
+	if account.IsSystem() || parent.IsSystem() || account.Currency != parent.Currency {
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidParent, apperror.MsgInvalidParentAccount).
+			WithField(apperror.FieldAccountID, account.AccountID).
+			WithField(apperror.FieldParentAccount, parent.AccountID)
+	}
+
+	if !parent.Balance.IsZero() {
+		return apperror.NewWithMessage(apperror.CodeConflict, ErrParentHasBalance, apperror.MsgParentHasBalance).
+			WithField(apperror.FieldParentAccount, parent.AccountID).
+			WithField(constants.LogFieldCurrentBalance, parent.Balance.String())
+	}
+
+	return nil
+}
+
+// toAccountResponse maps the domain model to the API response
+func toAccountResponse(account *Account) *entities.AccountResponse {
+	return &entities.AccountResponse{
+		AccountID:       account.AccountID,
+		Balance:         account.Balance.String(),
+		Currency:        account.Currency,
+		MinimumBalance:  account.MinimumBalance.String(),
+		GLCategory:      account.GLCategory,
+		ParentAccountID: account.ParentAccountID,
+	}
+}
+
+// bulkRow tracks one row of a bulk create from validation to insertion
+type bulkRow struct {
+	account   *Account
+	generated bool
+	inserted  bool
+	err       apperror.IError
+}
+
+// BulkCreate creates many accounts in one transaction, validating every row with the same
+// rules as Create and reporting the outcome of each. In all_or_nothing mode a single
+// failing row leaves every account uncreated; in best_effort mode the valid rows are kept.
+func (c *Core) BulkCreate(ctx context.Context, req *entities.BulkCreateAccountsRequest) (*entities.BulkCreateResponse, apperror.IError) {
+	mode, appErr := validateBulkRequest(req)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rows := c.validateBulkRows(ctx, req.Accounts)
+
+	committed := false
+	if mode == entities.BulkModeBestEffort || !anyBulkRowFailed(rows) {
+		committed, appErr = c.insertBulkRows(ctx, mode, rows)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	response := toBulkCreateResponse(mode, rows, committed)
+	logger.Ctx(ctx).Infow(constants.LogMsgBulkCreateCompleted,
+		constants.LogFieldBulkMode, mode,
+		constants.LogFieldCreatedCount, response.Created,
+		constants.LogFieldFailedCount, response.Failed,
 	)
+	return response, nil
+}
 
+// validateBulkRequest checks the mode and row count, returning the effective mode
+func validateBulkRequest(req *entities.BulkCreateAccountsRequest) (string, apperror.IError) {
+	mode := req.Mode
+	if mode == "" {
+		mode = entities.BulkModeAllOrNothing
+	}
+	if mode != entities.BulkModeAllOrNothing && mode != entities.BulkModeBestEffort {
+		return "", apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidBulkMode, apperror.MsgInvalidBulkMode).
+			WithField(apperror.FieldMode, req.Mode)
+	}
+
+	if len(req.Accounts) == 0 || len(req.Accounts) > entities.MaxBulkAccounts {
+		return "", apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidBulkSize, apperror.MsgInvalidBulkSize).
+			WithField(apperror.FieldMaxAllowed, entities.MaxBulkAccounts)
+	}
+	return mode, nil
+}
+
+// validateBulkRows validates each row as Create would, and rejects account IDs that
+// appear more than once in the request
+func (c *Core) validateBulkRows(ctx context.Context, reqs []entities.CreateAccountRequest) []*bulkRow {
+	rows := make([]*bulkRow, len(reqs))
+	seen := make(map[int64]bool, len(reqs))
+	for i := range reqs {
+		rows[i] = c.validateBulkRow(ctx, &reqs[i], seen)
+	}
+	return rows
+}
+
+// validateBulkRow validates a single row, recording its account ID in seen
+func (c *Core) validateBulkRow(ctx context.Context, req *entities.CreateAccountRequest, seen map[int64]bool) *bulkRow {
+	row := &bulkRow{
+		account:   &Account{AccountID: req.AccountID, ParentAccountID: req.ParentAccountID},
+		generated: req.AccountID == 0,
+	}
+
+	balance, appErr := c.validateCreateRequest(ctx, req)
+	if appErr != nil {
+		row.err = appErr
+		return row
+	}
+
+	currency, appErr := c.resolveCurrency(ctx, req.Currency)
+	if appErr != nil {
+		row.err = appErr
+		return row
+	}
+	row.account.Balance = balance
+	row.account.Currency = currency
+	row.account.Kind = entities.KindCustomer
+
+	if row.generated {
+		return row
+	}
+
+	if seen[req.AccountID] {
+		row.err = apperror.NewWithMessage(apperror.CodeConflict, ErrAccountExists, apperror.MsgDuplicateAccount).
+			WithField(apperror.FieldAccountID, req.AccountID)
+		return row
+	}
+	seen[req.AccountID] = true
+
+	// Create reports this as a missing parent, but here the account may already exist, and
+	// the parent_is_not_self constraint would then fail the whole statement
+	if req.ParentAccountID != nil && *req.ParentAccountID == req.AccountID {
+		row.err = apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidParent, apperror.MsgInvalidParentAccount).
+			WithField(apperror.FieldAccountID, req.AccountID).
+			WithField(apperror.FieldParentAccount, req.AccountID)
+	}
+	return row
+}
+
+// insertBulkRows inserts the valid rows in one transaction and reports whether it committed.
+// In all_or_nothing mode the transaction is rolled back as soon as any row has failed.
+func (c *Core) insertBulkRows(ctx context.Context, mode string, rows []*bulkRow) (bool, apperror.IError) {
+	pending := pendingBulkRows(rows)
+	if len(pending) == 0 {
+		return false, nil
+	}
+
+	taken := make(map[int64]bool, len(rows))
+	for _, row := range rows {
+		if !row.generated {
+			taken[row.account.AccountID] = true
+		}
+	}
+	if appErr := c.assignGeneratedIDs(ctx, pending, taken); appErr != nil {
+		return false, appErr
+	}
+
+	tx, err := c.repo.BeginTx(ctx)
+	if err != nil {
+		return false, apperror.New(apperror.CodeInternalError, err)
+	}
+	// Rollback is a no-op once the transaction has committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if appErr := c.lockBulkParents(ctx, tx, pending); appErr != nil {
+		return false, appErr
+	}
+	if mode == entities.BulkModeAllOrNothing && anyBulkRowFailed(rows) {
+		return false, nil
+	}
+
+	for chunk := range slices.Chunk(pending, entities.BulkInsertChunkSize) {
+		if appErr := c.insertBulkChunk(ctx, tx, chunk, taken); appErr != nil {
+			return false, appErr
+		}
+	}
+	if mode == entities.BulkModeAllOrNothing && anyBulkRowFailed(rows) {
+		return false, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToBulkCreate,
+			constants.LogFieldAccountCount, len(pending),
+			constants.LogKeyError, err,
+		)
+		return false, apperror.New(apperror.CodeInternalError, err)
+	}
+	return true, nil
+}
+
+// assignGeneratedIDs allocates IDs for rows that omitted account_id, skipping any ID
+// already used by another row of the request
+func (c *Core) assignGeneratedIDs(ctx context.Context, rows []*bulkRow, taken map[int64]bool) apperror.IError {
+	for attempt := 1; attempt <= entities.MaxGeneratedIDAttempts; attempt++ {
+		unassigned := make([]*bulkRow, 0, len(rows))
+		for _, row := range rows {
+			if row.generated && row.account.AccountID == 0 {
+				unassigned = append(unassigned, row)
+			}
+		}
+		if len(unassigned) == 0 {
+			return nil
+		}
+
+		ids, err := c.idGen.NextIDs(ctx, len(unassigned))
+		if err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToAllocateAcctID,
+				constants.LogKeyError, err,
+			)
+			return apperror.New(apperror.CodeInternalError, err)
+		}
+		for i, id := range ids {
+			if taken[id] {
+				continue
+			}
+			taken[id] = true
+			unassigned[i].account.AccountID = id
+		}
+	}
+
+	for _, row := range rows {
+		if row.generated && row.account.AccountID == 0 {
+			row.err = apperror.NewWithMessage(apperror.CodeConflict, ErrAccountExists, apperror.MsgDuplicateAccount)
+		}
+	}
 	return nil
 }
 
+// lockBulkParents locks every parent named by the rows, in ascending ID order like
+// transfers do, and checks each row against its parent with the rules Create applies
+func (c *Core) lockBulkParents(ctx context.Context, tx pgx.Tx, rows []*bulkRow) apperror.IError {
+	parentIDs := make([]int64, 0)
+	for _, row := range rows {
+		if row.account.ParentAccountID != nil {
+			parentIDs = append(parentIDs, *row.account.ParentAccountID)
+		}
+	}
+	slices.Sort(parentIDs)
+	parentIDs = slices.Compact(parentIDs)
+
+	parents := make(map[int64]*Account, len(parentIDs))
+	lookupErrs := make(map[int64]apperror.IError)
+	for _, parentID := range parentIDs {
+		parent, appErr := c.lockParent(ctx, tx, parentID)
+		if appErr != nil {
+			if appErr.Code() != apperror.CodeNotFound {
+				return appErr
+			}
+			lookupErrs[parentID] = appErr
+			continue
+		}
+		parents[parentID] = parent
+	}
+
+	for _, row := range rows {
+		if row.account.ParentAccountID == nil {
+			continue
+		}
+		parentID := *row.account.ParentAccountID
+		if appErr, ok := lookupErrs[parentID]; ok {
+			row.err = appErr
+			continue
+		}
+		row.err = validateParent(row.account, parents[parentID])
+	}
+	return nil
+}
+
+// insertBulkChunk inserts one chunk of rows. Rows whose generated ID turns out to be taken
+// are given fresh IDs and retried; rows whose requested ID is taken fail as duplicates.
+func (c *Core) insertBulkChunk(ctx context.Context, tx pgx.Tx, chunk []*bulkRow, taken map[int64]bool) apperror.IError {
+	rows := pendingBulkRows(chunk)
+	for attempt := 1; len(rows) > 0; attempt++ {
+		accounts := make([]*Account, len(rows))
+		for i, row := range rows {
+			accounts[i] = row.account
+		}
+
+		inserted, err := c.repo.CreateBatch(ctx, tx, accounts)
+		if err != nil {
+			return apperror.New(apperror.CodeInternalError, err)
+		}
+
+		retry := make([]*bulkRow, 0)
+		for _, row := range rows {
+			switch {
+			case inserted[row.account.AccountID]:
+				row.inserted = true
+			case row.generated && attempt < entities.MaxGeneratedIDAttempts:
+				logger.Ctx(ctx).Warnw(constants.LogMsgGeneratedIDCollision,
+					constants.LogKeyAccountID, row.account.AccountID,
+				)
+				row.account.AccountID = 0
+				retry = append(retry, row)
+			default:
+				row.err = apperror.NewWithMessage(apperror.CodeConflict, ErrAccountExists, apperror.MsgDuplicateAccount).
+					WithField(apperror.FieldAccountID, row.account.AccountID)
+			}
+		}
+
+		if appErr := c.assignGeneratedIDs(ctx, retry, taken); appErr != nil {
+			return appErr
+		}
+		rows = pendingBulkRows(retry)
+	}
+	return nil
+}
+
+// pendingBulkRows returns the rows that have not failed
+func pendingBulkRows(rows []*bulkRow) []*bulkRow {
+	pending := make([]*bulkRow, 0, len(rows))
+	for _, row := range rows {
+		if row.err == nil {
+			pending = append(pending, row)
+		}
+	}
+	return pending
+}
+
+// anyBulkRowFailed reports whether any row has failed
+func anyBulkRowFailed(rows []*bulkRow) bool {
+	return slices.ContainsFunc(rows, func(row *bulkRow) bool { return row.err != nil })
+}
+
+// toBulkCreateResponse builds the per-row report. Rows that passed validation but were not
+// committed are reported as skipped, without the generated ID they were briefly given.
+func toBulkCreateResponse(mode string, rows []*bulkRow, committed bool) *entities.BulkCreateResponse {
+	response := &entities.BulkCreateResponse{
+		Mode:    mode,
+		Results: make([]entities.BulkCreateResult, len(rows)),
+	}
+
+	for i, row := range rows {
+		result := entities.BulkCreateResult{Row: i + 1}
+		if !row.generated {
+			result.AccountID = row.account.AccountID
+		}
+
+		switch {
+		case row.err != nil:
+			result.Status = entities.BulkStatusFailed
+			result.Error = &entities.BulkRowError{
+				Code:    row.err.Code().String(),
+				Message: row.err.PublicMessage(),
+				Details: row.err.Fields(),
+			}
+			response.Failed++
+		case committed && row.inserted:
+			result.Status = entities.BulkStatusCreated
+			result.AccountID = row.account.AccountID
+			result.Balance = row.account.Balance.String()
+			result.Currency = row.account.Currency
+			response.Created++
+		default:
+			result.Status = entities.BulkStatusSkipped
+			response.Skipped++
+		}
+		response.Results[i] = result
+	}
+	return response
+}
+
 // GetByID retrieves an account by its ID
 func (c *Core) GetByID(ctx context.Context, accountID int64) (*entities.AccountResponse, apperror.IError) {
 	// Validate account ID
@@ -145,6 +700,432 @@ func (c *Core) GetByID(ctx context.Context, accountID int64) (*entities.AccountR
 			WithField(apperror.FieldAccountID, accountID)
 	}
 
+	account, appErr := c.getAccount(ctx, accountID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return toAccountResponse(account), nil
+}
+
+// GetWithRollup retrieves an account together with the balance summed across it and all its descendants
+func (c *Core) GetWithRollup(ctx context.Context, accountID int64) (*entities.AccountResponse, apperror.IError) {
+	response, appErr := c.GetByID(ctx, accountID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rollup, err := c.repo.GetRollupBalance(ctx, accountID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	response.RollupBalance = rollup.String()
+	return response, nil
+}
+
+// ListChildren returns the direct sub-accounts of an account
+func (c *Core) ListChildren(ctx context.Context, accountID int64) (*entities.ChildrenResponse, apperror.IError) {
+	if accountID <= 0 {
+		logger.Ctx(ctx).Debugw(constants.LogMsgInvalidAccountIDGet,
+			constants.LogKeyAccountID, accountID,
+		)
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	if _, appErr := c.getAccount(ctx, accountID); appErr != nil {
+		return nil, appErr
+	}
+
+	children, err := c.repo.ListChildren(ctx, accountID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	response := &entities.ChildrenResponse{
+		AccountID: accountID,
+		Children:  make([]*entities.AccountResponse, 0, len(children)),
+	}
+	for _, child := range children {
+		response.Children = append(response.Children, toAccountResponse(child))
+	}
+	return response, nil
+}
+
+// Move places an account under a new parent, or makes it a root when the request's
+// parent is null. Moves are serialized by an advisory lock so the cycle check cannot
+// race with another move.
+func (c *Core) Move(ctx context.Context, accountID int64, req *entities.MoveAccountRequest) (*entities.AccountResponse, apperror.IError) {
+	if appErr := validateMoveRequest(accountID, req); appErr != nil {
+		return nil, appErr
+	}
+
+	tx, err := c.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+	// Rollback is a no-op once the transaction has committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := c.repo.LockHierarchy(ctx, tx); err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	account, parent, appErr := c.lockForMove(ctx, tx, accountID, req.ParentAccountID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if sameParent(account.ParentAccountID, req.ParentAccountID) {
+		return toAccountResponse(account), nil
+	}
+
+	if parent != nil {
+		if appErr := c.validateNewParent(ctx, tx, account, parent); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if err := c.repo.SetParent(ctx, tx, accountID, req.ParentAccountID); err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToSetParent,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgAccountMoved,
+		constants.LogKeyAccountID, accountID,
+		constants.LogFieldOldParentID, account.ParentAccountID,
+		constants.LogFieldParentID, req.ParentAccountID,
+	)
+
+	account.ParentAccountID = req.ParentAccountID
+	return toAccountResponse(account), nil
+}
+
+// validateMoveRequest checks the IDs in a move request
+func validateMoveRequest(accountID int64, req *entities.MoveAccountRequest) apperror.IError {
+	if accountID <= 0 {
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	if req.ParentAccountID == nil {
+		return nil
+	}
+
+	if *req.ParentAccountID <= 0 {
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldParentAccount, *req.ParentAccountID)
+	}
+
+	if *req.ParentAccountID == accountID {
+		return apperror.NewWithMessage(apperror.CodeConflict, ErrHierarchyCycle, apperror.MsgHierarchyCycle).
+			WithField(apperror.FieldAccountID, accountID).
+			WithField(apperror.FieldParentAccount, *req.ParentAccountID)
+	}
+
+	return nil
+}
+
+// lockForMove locks the account being moved and, if there is one, the new parent.
+// Both are locked in ascending ID order, as transfers do, to avoid deadlocks.
+// The returned parent is nil when the account is being made a root.
+func (c *Core) lockForMove(ctx context.Context, tx pgx.Tx, accountID int64, parentID *int64) (*Account, *Account, apperror.IError) {
+	var account, parent *Account
+	var appErr apperror.IError
+
+	if parentID != nil && *parentID < accountID {
+		if parent, appErr = c.lockParent(ctx, tx, *parentID); appErr != nil {
+			return nil, nil, appErr
+		}
+	}
+
+	account, err := c.repo.GetForUpdate(ctx, tx, accountID)
+	if err != nil {
+		var repoErr *apperror.Error
+		if errors.As(err, &repoErr) {
+			return nil, nil, repoErr
+		}
+		return nil, nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	if parentID != nil && *parentID > accountID {
+		if parent, appErr = c.lockParent(ctx, tx, *parentID); appErr != nil {
+			return nil, nil, appErr
+		}
+	}
+
+	return account, parent, nil
+}
+
+// validateNewParent checks the locked parent can take the account, and that the
+// parent is not one of the account's descendants
+func (c *Core) validateNewParent(ctx context.Context, tx pgx.Tx, account, parent *Account) apperror.IError {
+	if appErr := validateParent(account, parent); appErr != nil {
+		return appErr
+	}
+
+	parentID := parent.AccountID
+	cycle, err := c.repo.IsInSubtree(ctx, tx, account.AccountID, parentID)
+	if err != nil {
+		return apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, account.AccountID)
+	}
+	if cycle {
+		logger.Ctx(ctx).Warnw(constants.LogMsgHierarchyCycle,
+			constants.LogKeyAccountID, account.AccountID,
+			constants.LogFieldParentID, parentID,
+		)
+		return apperror.NewWithMessage(apperror.CodeConflict, ErrHierarchyCycle, apperror.MsgHierarchyCycle).
+			WithField(apperror.FieldAccountID, account.AccountID).
+			WithField(apperror.FieldParentAccount, parentID)
+	}
+
+	return nil
+}
+
+// sameParent reports whether two optional parent IDs are equal
+func sameParent(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// SetMinimumBalance sets the floor that debits may not take a customer account's balance below.
+// The floor may exceed the current balance, in which case debits are refused until the
+// account is topped up.
+func (c *Core) SetMinimumBalance(ctx context.Context, accountID int64, req *entities.SetMinimumBalanceRequest) (*entities.AccountResponse, apperror.IError) {
+	if accountID <= 0 {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	minimum, err := decimal.NewFromString(req.MinimumBalance)
+	if err != nil || minimum.IsNegative() {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidMinBalance, apperror.MsgInvalidMinimumBalance).
+			WithField(apperror.FieldMinimumBalance, req.MinimumBalance)
+	}
+
+	if appErr := validateDecimalPrecision(ctx, minimum, apperror.FieldMinimumBalance); appErr != nil {
+		return nil, appErr
+	}
+
+	account, appErr := c.getAccount(ctx, accountID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if account.IsSystem() {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrMinBalanceSystem, apperror.MsgMinimumBalanceSystem).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	account, err = c.repo.SetMinimumBalance(ctx, accountID, minimum)
+	if err != nil {
+		var repoErr *apperror.Error
+		if errors.As(err, &repoErr) {
+			return nil, repoErr
+		}
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgMinimumBalanceSet,
+		constants.LogKeyAccountID, accountID,
+		constants.LogFieldMinimumBalance, minimum.String(),
+	)
+
+	return toAccountResponse(account), nil
+}
+
+// GetBalanceAsOf returns the account balance at a past instant, rebuilt from transaction history
+func (c *Core) GetBalanceAsOf(ctx context.Context, accountID int64, asOf time.Time) (*entities.BalanceResponse, apperror.IError) {
+	if accountID <= 0 {
+		logger.Ctx(ctx).Debugw(constants.LogMsgInvalidAccountIDGet,
+			constants.LogKeyAccountID, accountID,
+		)
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	if asOf.After(time.Now()) {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrFutureAsOf, apperror.MsgFutureAsOf).
+			WithField(apperror.FieldAsOf, asOf.Format(time.RFC3339Nano))
+	}
+
+	account, appErr := c.getAccount(ctx, accountID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	balance, err := c.repo.GetBalanceAsOf(ctx, accountID, asOf)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	return &entities.BalanceResponse{
+		AccountID: account.AccountID,
+		Balance:   balance.String(),
+		Currency:  account.Currency,
+		AsOf:      asOf.UTC().Format(time.RFC3339Nano),
+	}, nil
+}
+
+// CreateAlias registers an alias for a customer account. Handles and emails are chosen by
+// the client; IBANs are generated, retrying if a generated number is already taken.
+func (c *Core) CreateAlias(ctx context.Context, accountID int64, req *entities.CreateAliasRequest) (*entities.AliasResponse, apperror.IError) {
+	if accountID <= 0 {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	value, appErr := validateAliasRequest(req)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	account, appErr := c.getAccount(ctx, accountID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if account.IsSystem() {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrAliasSystem, apperror.MsgAliasSystemAccount).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	alias := &Alias{Alias: value, AccountID: accountID, Kind: req.Kind}
+	if req.Kind == entities.AliasKindIBAN {
+		appErr = c.insertGeneratedIBAN(ctx, alias)
+	} else {
+		appErr = c.insertAlias(ctx, alias)
+	}
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgAliasCreated,
+		constants.LogKeyAccountID, accountID,
+		constants.LogFieldAliasKind, alias.Kind,
+	)
+	return toAliasResponse(alias), nil
+}
+
+// validateAliasRequest checks the alias kind and returns the alias in canonical form.
+// IBAN requests return an empty alias, to be generated.
+func validateAliasRequest(req *entities.CreateAliasRequest) (string, apperror.IError) {
+	switch req.Kind {
+	case entities.AliasKindHandle:
+		if handle, ok := canonicalHandle(req.Alias); ok && !handleReadsAsIBAN(handle) {
+			return handle, nil
+		}
+		return "", apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAlias, apperror.MsgInvalidHandle).
+			WithField(apperror.FieldAlias, req.Alias)
+	case entities.AliasKindEmail:
+		if email, ok := canonicalEmail(req.Alias); ok {
+			return email, nil
+		}
+		return "", apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAlias, apperror.MsgInvalidEmailAlias).
+			WithField(apperror.FieldAlias, req.Alias)
+	case entities.AliasKindIBAN:
+		if req.Alias != "" {
+			return "", apperror.NewWithMessage(apperror.CodeBadRequest, ErrAliasGenerated, apperror.MsgIBANAliasGenerated).
+				WithField(apperror.FieldAlias, req.Alias)
+		}
+		return "", nil
+	}
+	return "", apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAliasKind, apperror.MsgInvalidAliasKind).
+		WithField(apperror.FieldAliasKind, req.Kind)
+}
+
+// insertGeneratedIBAN generates an IBAN for the alias and inserts it, retrying if the
+// number is already registered
+func (c *Core) insertGeneratedIBAN(ctx context.Context, alias *Alias) apperror.IError {
+	var appErr apperror.IError
+	for attempt := 1; attempt <= entities.MaxGeneratedIDAttempts; attempt++ {
+		iban, err := GenerateIBAN()
+		if err != nil {
+			return apperror.New(apperror.CodeInternalError, err).
+				WithField(apperror.FieldAccountID, alias.AccountID)
+		}
+
+		alias.Alias = iban
+		appErr = c.insertAlias(ctx, alias)
+		if appErr == nil || appErr.Code() != apperror.CodeConflict {
+			return appErr
+		}
+	}
+	return appErr
+}
+
+// insertAlias persists an alias, relying on the primary key to reject duplicates
+func (c *Core) insertAlias(ctx context.Context, alias *Alias) apperror.IError {
+	if err := c.repo.CreateAlias(ctx, alias); err != nil {
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, alias.AccountID)
+	}
+	return nil
+}
+
+// ListAliases returns the aliases registered for an account, oldest first
+func (c *Core) ListAliases(ctx context.Context, accountID int64) (*entities.AliasesResponse, apperror.IError) {
+	if accountID <= 0 {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	if _, appErr := c.getAccount(ctx, accountID); appErr != nil {
+		return nil, appErr
+	}
+
+	aliases, err := c.repo.ListAliases(ctx, accountID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	response := &entities.AliasesResponse{
+		AccountID: accountID,
+		Aliases:   make([]*entities.AliasResponse, 0, len(aliases)),
+	}
+	for _, alias := range aliases {
+		response.Aliases = append(response.Aliases, toAliasResponse(alias))
+	}
+	return response, nil
+}
+
+// toAliasResponse converts an Alias to its wire form
+func toAliasResponse(alias *Alias) *entities.AliasResponse {
+	return &entities.AliasResponse{
+		Alias:     alias.Alias,
+		Kind:      alias.Kind,
+		AccountID: alias.AccountID,
+		CreatedAt: alias.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// getAccount loads an account, converting repository errors to API errors
+func (c *Core) getAccount(ctx context.Context, accountID int64) (*Account, apperror.IError) {
 	account, err := c.repo.GetByID(ctx, accountID)
 	if err != nil {
 		// Check if it's already an apperror
@@ -165,10 +1146,24 @@ func (c *Core) GetByID(ctx context.Context, accountID int64) (*entities.AccountR
 			WithField(apperror.FieldAccountID, accountID)
 	}
 
-	return &entities.AccountResponse{
-		AccountID: account.AccountID,
-		Balance:   account.Balance.String(),
-	}, nil
+	return account, nil
+}
+
+// EnsureSystemAccounts creates the designated clearing and interest-expense accounts
+// for every supported currency
+func (c *Core) EnsureSystemAccounts(ctx context.Context) error {
+	for _, currency := range c.cfg.Currencies {
+		for _, kind := range entities.SystemKinds {
+			if err := c.repo.EnsureSystemAccount(ctx, kind, currency); err != nil {
+				return err
+			}
+			logger.Ctx(ctx).Infow(constants.LogMsgSystemAccountReady,
+				constants.LogFieldCurrency, currency,
+				constants.LogFieldAccountKind, kind,
+			)
+		}
+	}
+	return nil
 }
 
 // validateDecimalPrecision checks if the value exceeds the maximum allowed decimal places.
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/internal-transfers-service/internal/config"
 	"github.com/internal-transfers-service/internal/modules/account"
 	"github.com/internal-transfers-service/internal/modules/account/entities"
 	"github.com/internal-transfers-service/internal/modules/account/mock"
 	"github.com/internal-transfers-service/pkg/apperror"
+	dbmock "github.com/internal-transfers-service/pkg/database/mock"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -53,11 +56,6 @@ func (s *CoreTestSuite) TestCreateAccountWithValidDataSucceeds() {
 		InitialBalance: "100.50",
 	}
 
-	s.mockRepo.EXPECT().
-		Exists(s.ctx, int64(123)).
-		Return(false, nil).
-		Times(1)
-
 	s.mockRepo.EXPECT().
 		Create(s.ctx, gomock.Any()).
 		DoAndReturn(func(_ context.Context, acc *account.Account) error {
@@ -67,7 +65,7 @@ func (s *CoreTestSuite) TestCreateAccountWithValidDataSucceeds() {
 		}).
 		Times(1)
 
-	err := s.core.Create(s.ctx, req)
+	_, err := s.core.Create(s.ctx, req)
 	s.Nil(err)
 }
 
@@ -77,18 +75,15 @@ func (s *CoreTestSuite) TestCreateAccountWithZeroBalanceSucceeds() {
 		InitialBalance: "0",
 	}
 
-	s.mockRepo.EXPECT().
-		Exists(s.ctx, int64(456)).
-		Return(false, nil).
-		Times(1)
-
 	s.mockRepo.EXPECT().
 		Create(s.ctx, gomock.Any()).
 		Return(nil).
 		Times(1)
 
-	err := s.core.Create(s.ctx, req)
+	response, err := s.core.Create(s.ctx, req)
 	s.Nil(err)
+	s.Equal(int64(456), response.AccountID)
+	s.Equal("0", response.Balance)
 }
 
 func (s *CoreTestSuite) TestCreateAccountWithHighPrecisionSucceeds() {
@@ -97,11 +92,6 @@ func (s *CoreTestSuite) TestCreateAccountWithHighPrecisionSucceeds() {
 		InitialBalance: "123.45678901",
 	}
 
-	s.mockRepo.EXPECT().
-		Exists(s.ctx, int64(789)).
-		Return(false, nil).
-		Times(1)
-
 	s.mockRepo.EXPECT().
 		Create(s.ctx, gomock.Any()).
 		DoAndReturn(func(_ context.Context, acc *account.Account) error {
@@ -111,30 +101,19 @@ func (s *CoreTestSuite) TestCreateAccountWithHighPrecisionSucceeds() {
 		}).
 		Times(1)
 
-	err := s.core.Create(s.ctx, req)
+	_, err := s.core.Create(s.ctx, req)
 	s.Nil(err)
 }
 
 // Test Create Account - Validation Errors
 
-func (s *CoreTestSuite) TestCreateAccountWithZeroAccountIDFails() {
-	req := &entities.CreateAccountRequest{
-		AccountID:      0,
-		InitialBalance: "100.00",
-	}
-
-	err := s.core.Create(s.ctx, req)
-	s.NotNil(err)
-	s.Equal(apperror.CodeBadRequest, err.Code())
-}
-
 func (s *CoreTestSuite) TestCreateAccountWithNegativeAccountIDFails() {
 	req := &entities.CreateAccountRequest{
 		AccountID:      -1,
 		InitialBalance: "100.00",
 	}
 
-	err := s.core.Create(s.ctx, req)
+	_, err := s.core.Create(s.ctx, req)
 	s.NotNil(err)
 	s.Equal(apperror.CodeBadRequest, err.Code())
 }
@@ -145,7 +124,7 @@ func (s *CoreTestSuite) TestCreateAccountWithNegativeBalanceFails() {
 		InitialBalance: "-50.00",
 	}
 
-	err := s.core.Create(s.ctx, req)
+	_, err := s.core.Create(s.ctx, req)
 	s.NotNil(err)
 	s.Equal(apperror.CodeBadRequest, err.Code())
 }
@@ -156,7 +135,7 @@ func (s *CoreTestSuite) TestCreateAccountWithInvalidDecimalFormatFails() {
 		InitialBalance: "not-a-number",
 	}
 
-	err := s.core.Create(s.ctx, req)
+	_, err := s.core.Create(s.ctx, req)
 	s.NotNil(err)
 	s.Equal(apperror.CodeBadRequest, err.Code())
 }
@@ -170,50 +149,109 @@ func (s *CoreTestSuite) TestCreateAccountWhenAccountExistsFails() {
 	}
 
 	s.mockRepo.EXPECT().
-		Exists(s.ctx, int64(123)).
-		Return(true, nil).
+		Create(s.ctx, gomock.Any()).
+		Return(apperror.New(apperror.CodeConflict, account.ErrAccountExists)).
 		Times(1)
 
-	err := s.core.Create(s.ctx, req)
+	_, err := s.core.Create(s.ctx, req)
 	s.NotNil(err)
 	s.Equal(apperror.CodeConflict, err.Code())
 }
 
-// Test Create Account - Repository Errors
+// Test Create Account - Generated IDs
 
-func (s *CoreTestSuite) TestCreateAccountWhenExistsCheckFailsReturnsError() {
+func (s *CoreTestSuite) TestCreateAccountWithoutIDAllocatesID() {
 	req := &entities.CreateAccountRequest{
-		AccountID:      123,
-		InitialBalance: "100.00",
+		InitialBalance: "10.00",
 	}
 
 	s.mockRepo.EXPECT().
-		Exists(s.ctx, int64(123)).
-		Return(false, errDatabaseConnectionFailed).
+		NextAccountID(s.ctx).
+		Return(int64(1001), nil).
 		Times(1)
 
-	err := s.core.Create(s.ctx, req)
+	s.mockRepo.EXPECT().
+		Create(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, acc *account.Account) error {
+			s.Equal(int64(1001), acc.AccountID)
+			return nil
+		}).
+		Times(1)
+
+	response, err := s.core.Create(s.ctx, req)
+	s.Nil(err)
+	s.Equal(int64(1001), response.AccountID)
+	s.Equal("10", response.Balance)
+}
+
+func (s *CoreTestSuite) TestCreateAccountWithoutIDRetriesOnCollision() {
+	req := &entities.CreateAccountRequest{
+		InitialBalance: "10.00",
+	}
+
+	gomock.InOrder(
+		s.mockRepo.EXPECT().NextAccountID(s.ctx).Return(int64(5), nil),
+		s.mockRepo.EXPECT().Create(s.ctx, gomock.Any()).Return(apperror.New(apperror.CodeConflict, account.ErrAccountExists)),
+		s.mockRepo.EXPECT().NextAccountID(s.ctx).Return(int64(6), nil),
+		s.mockRepo.EXPECT().Create(s.ctx, gomock.Any()).Return(nil),
+	)
+
+	response, err := s.core.Create(s.ctx, req)
+	s.Nil(err)
+	s.Equal(int64(6), response.AccountID)
+}
+
+func (s *CoreTestSuite) TestCreateAccountWithoutIDGivesUpAfterMaxCollisions() {
+	req := &entities.CreateAccountRequest{
+		InitialBalance: "10.00",
+	}
+
+	s.mockRepo.EXPECT().
+		NextAccountID(s.ctx).
+		Return(int64(5), nil).
+		Times(entities.MaxGeneratedIDAttempts)
+
+	s.mockRepo.EXPECT().
+		Create(s.ctx, gomock.Any()).
+		Return(apperror.New(apperror.CodeConflict, account.ErrAccountExists)).
+		Times(entities.MaxGeneratedIDAttempts)
+
+	response, err := s.core.Create(s.ctx, req)
+	s.Nil(response)
+	s.NotNil(err)
+	s.Equal(apperror.CodeConflict, err.Code())
+}
+
+func (s *CoreTestSuite) TestCreateAccountWhenIDAllocationFailsReturnsError() {
+	req := &entities.CreateAccountRequest{
+		InitialBalance: "10.00",
+	}
+
+	s.mockRepo.EXPECT().
+		NextAccountID(s.ctx).
+		Return(int64(0), errDatabaseConnectionFailed).
+		Times(1)
+
+	response, err := s.core.Create(s.ctx, req)
+	s.Nil(response)
 	s.NotNil(err)
 	s.Equal(apperror.CodeInternalError, err.Code())
 }
 
+// Test Create Account - Repository Errors
+
 func (s *CoreTestSuite) TestCreateAccountWhenCreateFailsReturnsError() {
 	req := &entities.CreateAccountRequest{
 		AccountID:      123,
 		InitialBalance: "100.00",
 	}
 
-	s.mockRepo.EXPECT().
-		Exists(s.ctx, int64(123)).
-		Return(false, nil).
-		Times(1)
-
 	s.mockRepo.EXPECT().
 		Create(s.ctx, gomock.Any()).
 		Return(errInsertFailed).
 		Times(1)
 
-	err := s.core.Create(s.ctx, req)
+	_, err := s.core.Create(s.ctx, req)
 	s.NotNil(err)
 	s.Equal(apperror.CodeInternalError, err.Code())
 }
@@ -287,6 +325,145 @@ func (s *CoreTestSuite) TestGetByIDWhenAccountNotFoundReturnsNotFoundError() {
 	s.Equal(apperror.CodeNotFound, err.Code())
 }
 
+// Test Create Account - Currency
+
+func (s *CoreTestSuite) TestCreateAccountWithoutCurrencyUsesDefault() {
+	req := &entities.CreateAccountRequest{
+		AccountID:      123,
+		InitialBalance: "10",
+	}
+
+	s.mockRepo.EXPECT().
+		Create(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, acc *account.Account) error {
+			s.Equal(entities.DefaultCurrency, acc.Currency)
+			s.Equal(entities.KindCustomer, acc.Kind)
+			return nil
+		}).
+		Times(1)
+
+	response, err := s.core.Create(s.ctx, req)
+	s.Nil(err)
+	s.Equal(entities.DefaultCurrency, response.Currency)
+}
+
+func (s *CoreTestSuite) TestCreateAccountWithConfiguredCurrencySucceeds() {
+	core := account.NewCore(s.ctx, s.mockRepo, config.AccountConfig{Currencies: []string{"usd", "eur"}})
+	req := &entities.CreateAccountRequest{
+		AccountID:      123,
+		InitialBalance: "10",
+		Currency:       "eur",
+	}
+
+	s.mockRepo.EXPECT().
+		Create(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, acc *account.Account) error {
+			s.Equal("EUR", acc.Currency)
+			return nil
+		}).
+		Times(1)
+
+	response, err := core.Create(s.ctx, req)
+	s.Nil(err)
+	s.Equal("EUR", response.Currency)
+}
+
+func (s *CoreTestSuite) TestCreateAccountWithUnsupportedCurrencyFails() {
+	req := &entities.CreateAccountRequest{
+		AccountID:      123,
+		InitialBalance: "10",
+		Currency:       "GBP",
+	}
+
+	response, err := s.core.Create(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidCurrency, err.PublicMessage())
+}
+
+// Test EnsureSystemAccounts
+
+func (s *CoreTestSuite) TestEnsureSystemAccountsCreatesEachKindPerCurrency() {
+	core := account.NewCore(s.ctx, s.mockRepo, config.AccountConfig{DefaultCurrency: "EUR", Currencies: []string{"USD"}})
+
+	for _, currency := range []string{"USD", "EUR"} {
+		s.mockRepo.EXPECT().EnsureSystemAccount(s.ctx, entities.KindClearing, currency).Return(nil).Times(1)
+		s.mockRepo.EXPECT().EnsureSystemAccount(s.ctx, entities.KindInterestExpense, currency).Return(nil).Times(1)
+	}
+
+	s.Nil(core.EnsureSystemAccounts(s.ctx))
+}
+
+func (s *CoreTestSuite) TestEnsureSystemAccountsWhenRepoFailsReturnsError() {
+	s.mockRepo.EXPECT().
+		EnsureSystemAccount(s.ctx, entities.KindClearing, entities.DefaultCurrency).
+		Return(errDatabaseError).
+		Times(1)
+
+	s.Equal(errDatabaseError, s.core.EnsureSystemAccounts(s.ctx))
+}
+
+// Test GetBalanceAsOf
+
+func (s *CoreTestSuite) TestGetBalanceAsOfReturnsHistoricalBalance() {
+	asOf := time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	s.mockRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123, Balance: decimal.NewFromInt(900), Currency: "USD"}, nil).
+		Times(1)
+	s.mockRepo.EXPECT().
+		GetBalanceAsOf(s.ctx, int64(123), asOf).
+		Return(decimal.RequireFromString("250.5"), nil).
+		Times(1)
+
+	response, err := s.core.GetBalanceAsOf(s.ctx, 123, asOf)
+	s.Nil(err)
+	s.Equal(int64(123), response.AccountID)
+	s.Equal("250.5", response.Balance)
+	s.Equal("USD", response.Currency)
+	s.Equal("2026-01-31T23:59:59Z", response.AsOf)
+}
+
+func (s *CoreTestSuite) TestGetBalanceAsOfWithInvalidAccountIDFails() {
+	response, err := s.core.GetBalanceAsOf(s.ctx, 0, time.Now())
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+}
+
+func (s *CoreTestSuite) TestGetBalanceAsOfInFutureFails() {
+	response, err := s.core.GetBalanceAsOf(s.ctx, 123, time.Now().Add(time.Hour))
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgFutureAsOf, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestGetBalanceAsOfWhenAccountNotFoundReturnsNotFound() {
+	s.mockRepo.EXPECT().
+		GetByID(s.ctx, int64(999)).
+		Return(nil, apperror.New(apperror.CodeNotFound, account.ErrAccountNotFound)).
+		Times(1)
+
+	response, err := s.core.GetBalanceAsOf(s.ctx, 999, time.Now())
+	s.Nil(response)
+	s.Equal(apperror.CodeNotFound, err.Code())
+}
+
+func (s *CoreTestSuite) TestGetBalanceAsOfWhenRepoFailsReturnsInternalError() {
+	s.mockRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123}, nil).
+		Times(1)
+	s.mockRepo.EXPECT().
+		GetBalanceAsOf(s.ctx, int64(123), gomock.Any()).
+		Return(decimal.Zero, errDatabaseError).
+		Times(1)
+
+	response, err := s.core.GetBalanceAsOf(s.ctx, 123, time.Now())
+	s.Nil(response)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
+
 // Test Get Account By ID - Repository Errors
 
 func (s *CoreTestSuite) TestGetByIDWhenRepoFailsReturnsInternalError() {
@@ -300,3 +477,642 @@ func (s *CoreTestSuite) TestGetByIDWhenRepoFailsReturnsInternalError() {
 	s.Nil(response)
 	s.Equal(apperror.CodeInternalError, err.Code())
 }
+
+// Test Sub-accounts
+
+// customer returns a customer account in the default currency
+func customer(accountID int64, balance string, parentID *int64) *account.Account {
+	return &account.Account{
+		AccountID:       accountID,
+		Balance:         decimal.RequireFromString(balance),
+		Currency:        entities.DefaultCurrency,
+		Kind:            entities.KindCustomer,
+		ParentAccountID: parentID,
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func (s *CoreTestSuite) TestCreateAccountUnderParentLocksParentAndInserts() {
+	tx := dbmock.NewMockTx(s.ctrl)
+	parentID := int64(10)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, parentID).Return(customer(parentID, "0", nil), nil).Times(1)
+	s.mockRepo.EXPECT().
+		CreateTx(s.ctx, tx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, acc *account.Account) error {
+			s.Equal(int64(11), acc.AccountID)
+			s.Equal(&parentID, acc.ParentAccountID)
+			return nil
+		}).
+		Times(1)
+	tx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Create(s.ctx, &entities.CreateAccountRequest{
+		AccountID:       11,
+		InitialBalance:  "25",
+		ParentAccountID: &parentID,
+	})
+	s.Nil(err)
+	s.Equal(&parentID, response.ParentAccountID)
+}
+
+func (s *CoreTestSuite) TestCreateAccountUnderParentWithBalanceFails() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(10)).Return(customer(10, "5", nil), nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Create(s.ctx, &entities.CreateAccountRequest{
+		AccountID:       11,
+		InitialBalance:  "0",
+		ParentAccountID: int64Ptr(10),
+	})
+	s.Nil(response)
+	s.Equal(apperror.CodeConflict, err.Code())
+	s.Equal(apperror.MsgParentHasBalance, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateAccountUnderMissingParentReturnsNotFound() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().
+		GetForUpdate(s.ctx, tx, int64(10)).
+		Return(nil, apperror.New(apperror.CodeNotFound, account.ErrAccountNotFound)).
+		Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Create(s.ctx, &entities.CreateAccountRequest{
+		AccountID:       11,
+		InitialBalance:  "0",
+		ParentAccountID: int64Ptr(10),
+	})
+	s.Nil(response)
+	s.Equal(apperror.CodeNotFound, err.Code())
+	s.Equal(apperror.MsgParentNotFound, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateAccountUnderClearingAccountFails() {
+	tx := dbmock.NewMockTx(s.ctrl)
+	clearing := customer(1, "0", nil)
+	clearing.Kind = entities.KindClearing
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(1)).Return(clearing, nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Create(s.ctx, &entities.CreateAccountRequest{
+		AccountID:       11,
+		InitialBalance:  "0",
+		ParentAccountID: int64Ptr(1),
+	})
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidParentAccount, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateAccountWithInvalidParentIDFails() {
+	response, err := s.core.Create(s.ctx, &entities.CreateAccountRequest{
+		InitialBalance:  "0",
+		ParentAccountID: int64Ptr(0),
+	})
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+}
+
+func (s *CoreTestSuite) TestGetWithRollupSumsDescendants() {
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(10)).Return(customer(10, "0", nil), nil).Times(1)
+	s.mockRepo.EXPECT().GetRollupBalance(s.ctx, int64(10)).Return(decimal.RequireFromString("175.25"), nil).Times(1)
+
+	response, err := s.core.GetWithRollup(s.ctx, 10)
+	s.Nil(err)
+	s.Equal("0", response.Balance)
+	s.Equal("175.25", response.RollupBalance)
+}
+
+func (s *CoreTestSuite) TestGetWithRollupWhenRepoFailsReturnsInternalError() {
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(10)).Return(customer(10, "0", nil), nil).Times(1)
+	s.mockRepo.EXPECT().GetRollupBalance(s.ctx, int64(10)).Return(decimal.Zero, errDatabaseError).Times(1)
+
+	response, err := s.core.GetWithRollup(s.ctx, 10)
+	s.Nil(response)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
+
+func (s *CoreTestSuite) TestListChildrenReturnsDirectChildren() {
+	parentID := int64(10)
+	s.mockRepo.EXPECT().GetByID(s.ctx, parentID).Return(customer(parentID, "0", nil), nil).Times(1)
+	s.mockRepo.EXPECT().
+		ListChildren(s.ctx, parentID).
+		Return([]*account.Account{customer(11, "25", &parentID), customer(12, "150.25", &parentID)}, nil).
+		Times(1)
+
+	response, err := s.core.ListChildren(s.ctx, parentID)
+	s.Nil(err)
+	s.Equal(parentID, response.AccountID)
+	s.Len(response.Children, 2)
+	s.Equal(int64(12), response.Children[1].AccountID)
+	s.Equal(&parentID, response.Children[1].ParentAccountID)
+}
+
+func (s *CoreTestSuite) TestListChildrenWhenAccountNotFoundReturnsNotFound() {
+	s.mockRepo.EXPECT().
+		GetByID(s.ctx, int64(10)).
+		Return(nil, apperror.New(apperror.CodeNotFound, account.ErrAccountNotFound)).
+		Times(1)
+
+	response, err := s.core.ListChildren(s.ctx, 10)
+	s.Nil(response)
+	s.Equal(apperror.CodeNotFound, err.Code())
+}
+
+func (s *CoreTestSuite) TestMoveLocksInIDOrderAndSetsParent() {
+	tx := dbmock.NewMockTx(s.ctrl)
+	parentID := int64(10)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().LockHierarchy(s.ctx, tx).Return(nil).Times(1)
+	// Parent (10) is locked before the account being moved (20)
+	gomock.InOrder(
+		s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, parentID).Return(customer(parentID, "0", nil), nil),
+		s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(20)).Return(customer(20, "40", nil), nil),
+	)
+	s.mockRepo.EXPECT().IsInSubtree(s.ctx, tx, int64(20), parentID).Return(false, nil).Times(1)
+	s.mockRepo.EXPECT().SetParent(s.ctx, tx, int64(20), &parentID).Return(nil).Times(1)
+	tx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Move(s.ctx, 20, &entities.MoveAccountRequest{ParentAccountID: &parentID})
+	s.Nil(err)
+	s.Equal(&parentID, response.ParentAccountID)
+}
+
+func (s *CoreTestSuite) TestMoveUnderDescendantFailsWithCycle() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().LockHierarchy(s.ctx, tx).Return(nil).Times(1)
+	s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(10)).Return(customer(10, "0", nil), nil).Times(1)
+	s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(30)).Return(customer(30, "0", int64Ptr(20)), nil).Times(1)
+	s.mockRepo.EXPECT().IsInSubtree(s.ctx, tx, int64(10), int64(30)).Return(true, nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Move(s.ctx, 10, &entities.MoveAccountRequest{ParentAccountID: int64Ptr(30)})
+	s.Nil(response)
+	s.Equal(apperror.CodeConflict, err.Code())
+	s.Equal(apperror.MsgHierarchyCycle, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestMoveUnderItselfFailsWithCycle() {
+	response, err := s.core.Move(s.ctx, 10, &entities.MoveAccountRequest{ParentAccountID: int64Ptr(10)})
+	s.Nil(response)
+	s.Equal(apperror.CodeConflict, err.Code())
+	s.Equal(apperror.MsgHierarchyCycle, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestMoveUnderParentInOtherCurrencyFails() {
+	tx := dbmock.NewMockTx(s.ctrl)
+	parent := customer(30, "0", nil)
+	parent.Currency = "EUR"
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().LockHierarchy(s.ctx, tx).Return(nil).Times(1)
+	s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(20)).Return(customer(20, "0", nil), nil).Times(1)
+	s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(30)).Return(parent, nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Move(s.ctx, 20, &entities.MoveAccountRequest{ParentAccountID: int64Ptr(30)})
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidParentAccount, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestMoveToRootDetachesAccount() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().LockHierarchy(s.ctx, tx).Return(nil).Times(1)
+	s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(20)).Return(customer(20, "5", int64Ptr(10)), nil).Times(1)
+	s.mockRepo.EXPECT().SetParent(s.ctx, tx, int64(20), nil).Return(nil).Times(1)
+	tx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Move(s.ctx, 20, &entities.MoveAccountRequest{})
+	s.Nil(err)
+	s.Nil(response.ParentAccountID)
+}
+
+func (s *CoreTestSuite) TestMoveToCurrentParentIsNoOp() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().LockHierarchy(s.ctx, tx).Return(nil).Times(1)
+	s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(10)).Return(customer(10, "0", nil), nil).Times(1)
+	s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(20)).Return(customer(20, "5", int64Ptr(10)), nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Move(s.ctx, 20, &entities.MoveAccountRequest{ParentAccountID: int64Ptr(10)})
+	s.Nil(err)
+	s.Equal(int64(10), *response.ParentAccountID)
+}
+
+func (s *CoreTestSuite) TestMoveWhenLockFailsReturnsInternalError() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().LockHierarchy(s.ctx, tx).Return(errDatabaseError).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Move(s.ctx, 20, &entities.MoveAccountRequest{})
+	s.Nil(response)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
+
+// Test SetMinimumBalance
+
+func (s *CoreTestSuite) TestSetMinimumBalanceUpdatesFloor() {
+	updated := customer(20, "500", nil)
+	updated.MinimumBalance = decimal.RequireFromString("100.5")
+
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(20)).Return(customer(20, "500", nil), nil).Times(1)
+	s.mockRepo.EXPECT().
+		SetMinimumBalance(s.ctx, int64(20), decimal.RequireFromString("100.50")).
+		Return(updated, nil).
+		Times(1)
+
+	response, err := s.core.SetMinimumBalance(s.ctx, 20, &entities.SetMinimumBalanceRequest{MinimumBalance: "100.50"})
+	s.Nil(err)
+	s.Equal("100.5", response.MinimumBalance)
+}
+
+func (s *CoreTestSuite) TestSetMinimumBalanceAboveBalanceIsAllowed() {
+	updated := customer(20, "5", nil)
+	updated.MinimumBalance = decimal.NewFromInt(1000)
+
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(20)).Return(customer(20, "5", nil), nil).Times(1)
+	s.mockRepo.EXPECT().SetMinimumBalance(s.ctx, int64(20), decimal.RequireFromString("1000")).Return(updated, nil).Times(1)
+
+	response, err := s.core.SetMinimumBalance(s.ctx, 20, &entities.SetMinimumBalanceRequest{MinimumBalance: "1000"})
+	s.Nil(err)
+	s.Equal("1000", response.MinimumBalance)
+}
+
+func (s *CoreTestSuite) TestSetMinimumBalanceWithInvalidValuesFails() {
+	for _, value := range []string{"", "abc", "-1"} {
+		response, err := s.core.SetMinimumBalance(s.ctx, 20, &entities.SetMinimumBalanceRequest{MinimumBalance: value})
+		s.Nil(response, value)
+		s.Equal(apperror.CodeBadRequest, err.Code(), value)
+		s.Equal(apperror.MsgInvalidMinimumBalance, err.PublicMessage(), value)
+	}
+}
+
+func (s *CoreTestSuite) TestSetMinimumBalanceWithTooManyDecimalPlacesFails() {
+	response, err := s.core.SetMinimumBalance(s.ctx, 20, &entities.SetMinimumBalanceRequest{MinimumBalance: "1.123456789"})
+	s.Nil(response)
+	s.Equal(apperror.MsgTooManyDecimalPlaces, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestSetMinimumBalanceOnClearingAccountFails() {
+	clearing := customer(1, "0", nil)
+	clearing.Kind = entities.KindClearing
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(1)).Return(clearing, nil).Times(1)
+
+	response, err := s.core.SetMinimumBalance(s.ctx, 1, &entities.SetMinimumBalanceRequest{MinimumBalance: "10"})
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgMinimumBalanceSystem, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestSetMinimumBalanceWhenAccountNotFoundReturnsNotFound() {
+	s.mockRepo.EXPECT().
+		GetByID(s.ctx, int64(20)).
+		Return(nil, apperror.New(apperror.CodeNotFound, account.ErrAccountNotFound)).
+		Times(1)
+
+	response, err := s.core.SetMinimumBalance(s.ctx, 20, &entities.SetMinimumBalanceRequest{MinimumBalance: "10"})
+	s.Nil(response)
+	s.Equal(apperror.CodeNotFound, err.Code())
+}
+
+func (s *CoreTestSuite) TestSetMinimumBalanceWhenRepoFailsReturnsInternalError() {
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(20)).Return(customer(20, "5", nil), nil).Times(1)
+	s.mockRepo.EXPECT().SetMinimumBalance(s.ctx, int64(20), gomock.Any()).Return(nil, errDatabaseError).Times(1)
+
+	response, err := s.core.SetMinimumBalance(s.ctx, 20, &entities.SetMinimumBalanceRequest{MinimumBalance: "10"})
+	s.Nil(response)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
+
+// Test BulkCreate
+
+func (s *CoreTestSuite) TestBulkCreateAllValidCreatesEveryRow() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	// The first generated ID clashes with a requested one and is drawn again
+	gomock.InOrder(
+		s.mockRepo.EXPECT().NextAccountIDs(s.ctx, 1).Return([]int64{1}, nil),
+		s.mockRepo.EXPECT().NextAccountIDs(s.ctx, 1).Return([]int64{50}, nil),
+	)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().
+		CreateBatch(s.ctx, tx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, accounts []*account.Account) (map[int64]bool, error) {
+			s.Len(accounts, 2)
+			s.Equal(int64(1), accounts[0].AccountID)
+			s.Equal(int64(50), accounts[1].AccountID)
+			return map[int64]bool{1: true, 50: true}, nil
+		}).
+		Times(1)
+	tx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.BulkCreate(s.ctx, &entities.BulkCreateAccountsRequest{
+		Accounts: []entities.CreateAccountRequest{
+			{AccountID: 1, InitialBalance: "10"},
+			{InitialBalance: "5.5"},
+		},
+	})
+	s.Nil(err)
+	s.Equal(entities.BulkModeAllOrNothing, response.Mode)
+	s.Equal(2, response.Created)
+	s.Equal(entities.BulkCreateResult{Row: 2, Status: entities.BulkStatusCreated, AccountID: 50, Balance: "5.5", Currency: "USD"}, response.Results[1])
+}
+
+func (s *CoreTestSuite) TestBulkCreateAllOrNothingWithInvalidRowCreatesNothing() {
+	response, err := s.core.BulkCreate(s.ctx, &entities.BulkCreateAccountsRequest{
+		Mode: entities.BulkModeAllOrNothing,
+		Accounts: []entities.CreateAccountRequest{
+			{AccountID: 1, InitialBalance: "10"},
+			{AccountID: 2, InitialBalance: "-1"},
+		},
+	})
+	s.Nil(err)
+	s.Equal(0, response.Created)
+	s.Equal(1, response.Failed)
+	s.Equal(1, response.Skipped)
+	s.Equal(entities.BulkStatusSkipped, response.Results[0].Status)
+	s.Equal(apperror.CodeBadRequest.String(), response.Results[1].Error.Code)
+	s.Equal(apperror.MsgNegativeBalance, response.Results[1].Error.Message)
+}
+
+func (s *CoreTestSuite) TestBulkCreateBestEffortKeepsValidRows() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().
+		CreateBatch(s.ctx, tx, gomock.Len(2)).
+		Return(map[int64]bool{1: true}, nil).
+		Times(1)
+	tx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.BulkCreate(s.ctx, &entities.BulkCreateAccountsRequest{
+		Mode: entities.BulkModeBestEffort,
+		Accounts: []entities.CreateAccountRequest{
+			{AccountID: 1, InitialBalance: "10"},
+			{AccountID: 2, InitialBalance: "1", Currency: "XYZ"},
+			{AccountID: 3, InitialBalance: "1"},
+			{AccountID: 1, InitialBalance: "1"},
+		},
+	})
+	s.Nil(err)
+	s.Equal(1, response.Created)
+	s.Equal(3, response.Failed)
+	s.Equal(entities.BulkStatusCreated, response.Results[0].Status)
+	s.Equal(apperror.MsgInvalidCurrency, response.Results[1].Error.Message)
+	// Row 3 already existed; row 4 repeats row 1's ID
+	s.Equal(apperror.CodeConflict.String(), response.Results[2].Error.Code)
+	s.Equal(apperror.CodeConflict.String(), response.Results[3].Error.Code)
+}
+
+func (s *CoreTestSuite) TestBulkCreateAllOrNothingRollsBackOnExistingID() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().CreateBatch(s.ctx, tx, gomock.Any()).Return(map[int64]bool{1: true}, nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.BulkCreate(s.ctx, &entities.BulkCreateAccountsRequest{
+		Accounts: []entities.CreateAccountRequest{
+			{AccountID: 1, InitialBalance: "10"},
+			{AccountID: 2, InitialBalance: "10"},
+		},
+	})
+	s.Nil(err)
+	s.Equal(0, response.Created)
+	s.Equal(entities.BulkStatusSkipped, response.Results[0].Status)
+	s.Equal(entities.BulkStatusFailed, response.Results[1].Status)
+}
+
+func (s *CoreTestSuite) TestBulkCreateRetriesGeneratedIDThatIsTaken() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	gomock.InOrder(
+		s.mockRepo.EXPECT().NextAccountIDs(s.ctx, 1).Return([]int64{100}, nil),
+		s.mockRepo.EXPECT().NextAccountIDs(s.ctx, 1).Return([]int64{101}, nil),
+	)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	gomock.InOrder(
+		s.mockRepo.EXPECT().CreateBatch(s.ctx, tx, gomock.Any()).Return(map[int64]bool{}, nil),
+		s.mockRepo.EXPECT().CreateBatch(s.ctx, tx, gomock.Any()).Return(map[int64]bool{101: true}, nil),
+	)
+	tx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.BulkCreate(s.ctx, &entities.BulkCreateAccountsRequest{
+		Accounts: []entities.CreateAccountRequest{{InitialBalance: "1"}},
+	})
+	s.Nil(err)
+	s.Equal(1, response.Created)
+	s.Equal(int64(101), response.Results[0].AccountID)
+}
+
+func (s *CoreTestSuite) TestBulkCreateChecksParents() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	gomock.InOrder(
+		s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(10)).Return(customer(10, "0", nil), nil),
+		s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(20)).
+			Return(nil, apperror.New(apperror.CodeNotFound, account.ErrAccountNotFound)),
+		s.mockRepo.EXPECT().GetForUpdate(s.ctx, tx, int64(30)).Return(customer(30, "5", nil), nil),
+	)
+	s.mockRepo.EXPECT().
+		CreateBatch(s.ctx, tx, gomock.Len(1)).
+		Return(map[int64]bool{1: true}, nil).
+		Times(1)
+	tx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.BulkCreate(s.ctx, &entities.BulkCreateAccountsRequest{
+		Mode: entities.BulkModeBestEffort,
+		Accounts: []entities.CreateAccountRequest{
+			{AccountID: 1, InitialBalance: "1", ParentAccountID: int64Ptr(10)},
+			{AccountID: 2, InitialBalance: "1", ParentAccountID: int64Ptr(30)},
+			{AccountID: 3, InitialBalance: "1", ParentAccountID: int64Ptr(20)},
+			{AccountID: 4, InitialBalance: "1", ParentAccountID: int64Ptr(4)},
+		},
+	})
+	s.Nil(err)
+	s.Equal(1, response.Created)
+	s.Equal(apperror.MsgParentHasBalance, response.Results[1].Error.Message)
+	s.Equal(apperror.MsgParentNotFound, response.Results[2].Error.Message)
+	s.Equal(apperror.MsgInvalidParentAccount, response.Results[3].Error.Message)
+}
+
+func (s *CoreTestSuite) TestBulkCreateWhenInsertFailsReturnsInternalError() {
+	tx := dbmock.NewMockTx(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(tx, nil).Times(1)
+	s.mockRepo.EXPECT().CreateBatch(s.ctx, tx, gomock.Any()).Return(nil, errDatabaseError).Times(1)
+	tx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.BulkCreate(s.ctx, &entities.BulkCreateAccountsRequest{
+		Accounts: []entities.CreateAccountRequest{{AccountID: 1, InitialBalance: "1"}},
+	})
+	s.Nil(response)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
+
+func (s *CoreTestSuite) TestBulkCreateWithInvalidModeFails() {
+	response, err := s.core.BulkCreate(s.ctx, &entities.BulkCreateAccountsRequest{
+		Mode:     "sometimes",
+		Accounts: []entities.CreateAccountRequest{{AccountID: 1, InitialBalance: "1"}},
+	})
+	s.Nil(response)
+	s.Equal(apperror.MsgInvalidBulkMode, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestBulkCreateWithNoRowsFails() {
+	response, err := s.core.BulkCreate(s.ctx, &entities.BulkCreateAccountsRequest{})
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidBulkSize, err.PublicMessage())
+}
+
+// Test CreateAlias
+
+func (s *CoreTestSuite) TestCreateAliasStoresCanonicalHandle() {
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(20)).Return(customer(20, "0", nil), nil).Times(1)
+	s.mockRepo.EXPECT().
+		CreateAlias(s.ctx, &account.Alias{Alias: "@alice.smith", AccountID: 20, Kind: entities.AliasKindHandle}).
+		Return(nil).
+		Times(1)
+
+	response, err := s.core.CreateAlias(s.ctx, 20, &entities.CreateAliasRequest{Kind: entities.AliasKindHandle, Alias: " Alice.Smith "})
+	s.Nil(err)
+	s.Equal("@alice.smith", response.Alias)
+	s.Equal(int64(20), response.AccountID)
+}
+
+func (s *CoreTestSuite) TestCreateAliasLowerCasesEmail() {
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(20)).Return(customer(20, "0", nil), nil).Times(1)
+	s.mockRepo.EXPECT().
+		CreateAlias(s.ctx, &account.Alias{Alias: "alice@example.com", AccountID: 20, Kind: entities.AliasKindEmail}).
+		Return(nil).
+		Times(1)
+
+	response, err := s.core.CreateAlias(s.ctx, 20, &entities.CreateAliasRequest{Kind: entities.AliasKindEmail, Alias: "Alice@Example.com"})
+	s.Nil(err)
+	s.Equal("alice@example.com", response.Alias)
+}
+
+func (s *CoreTestSuite) TestCreateAliasWithShortHandleFails() {
+	response, err := s.core.CreateAlias(s.ctx, 20, &entities.CreateAliasRequest{Kind: entities.AliasKindHandle, Alias: "ab"})
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidHandle, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateAliasWithHandleReadingAsIBANFails() {
+	_, err := s.core.CreateAlias(s.ctx, 20, &entities.CreateAliasRequest{Kind: entities.AliasKindHandle, Alias: "gb82west12345698765432"})
+	s.Equal(apperror.MsgInvalidHandle, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateAliasWithHandleStartingWithPunctuationFails() {
+	_, err := s.core.CreateAlias(s.ctx, 20, &entities.CreateAliasRequest{Kind: entities.AliasKindHandle, Alias: "_alice"})
+	s.Equal(apperror.MsgInvalidHandle, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateAliasWithDisplayNameEmailFails() {
+	_, err := s.core.CreateAlias(s.ctx, 20, &entities.CreateAliasRequest{Kind: entities.AliasKindEmail, Alias: "Alice <alice@example.com>"})
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidEmailAlias, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateAliasWithDotlessEmailDomainFails() {
+	_, err := s.core.CreateAlias(s.ctx, 20, &entities.CreateAliasRequest{Kind: entities.AliasKindEmail, Alias: "alice@localhost"})
+	s.Equal(apperror.MsgInvalidEmailAlias, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateAliasWithUnknownKindFails() {
+	_, err := s.core.CreateAlias(s.ctx, 20, &entities.CreateAliasRequest{Kind: "phone", Alias: "+15550100"})
+	s.Equal(apperror.MsgInvalidAliasKind, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateAliasWithSuppliedIBANFails() {
+	_, err := s.core.CreateAlias(s.ctx, 20, &entities.CreateAliasRequest{Kind: entities.AliasKindIBAN, Alias: "ZZ00123"})
+	s.Equal(apperror.MsgIBANAliasGenerated, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateAliasGeneratesIBANAndRetriesWhenTaken() {
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(20)).Return(customer(20, "0", nil), nil).Times(1)
+	gomock.InOrder(
+		s.mockRepo.EXPECT().
+			CreateAlias(s.ctx, gomock.Any()).
+			Return(apperror.NewWithMessage(apperror.CodeConflict, account.ErrAliasTaken, apperror.MsgAliasTaken)),
+		s.mockRepo.EXPECT().CreateAlias(s.ctx, gomock.Any()).Return(nil),
+	)
+
+	response, err := s.core.CreateAlias(s.ctx, 20, &entities.CreateAliasRequest{Kind: entities.AliasKindIBAN})
+	s.Nil(err)
+	s.Equal(entities.AliasKindIBAN, response.Kind)
+	s.True(account.ValidIBAN(response.Alias), response.Alias)
+}
+
+func (s *CoreTestSuite) TestCreateAliasWhenTakenReturnsConflict() {
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(20)).Return(customer(20, "0", nil), nil).Times(1)
+	s.mockRepo.EXPECT().
+		CreateAlias(s.ctx, gomock.Any()).
+		Return(apperror.NewWithMessage(apperror.CodeConflict, account.ErrAliasTaken, apperror.MsgAliasTaken)).
+		Times(1)
+
+	_, err := s.core.CreateAlias(s.ctx, 20, &entities.CreateAliasRequest{Kind: entities.AliasKindHandle, Alias: "alice"})
+	s.Equal(apperror.CodeConflict, err.Code())
+}
+
+func (s *CoreTestSuite) TestCreateAliasOnClearingAccountFails() {
+	clearing := customer(1, "0", nil)
+	clearing.Kind = entities.KindClearing
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(1)).Return(clearing, nil).Times(1)
+
+	_, err := s.core.CreateAlias(s.ctx, 1, &entities.CreateAliasRequest{Kind: entities.AliasKindHandle, Alias: "treasury"})
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgAliasSystemAccount, err.PublicMessage())
+}
+
+// Test ListAliases
+
+func (s *CoreTestSuite) TestListAliasesReturnsAccountAliases() {
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.mockRepo.EXPECT().GetByID(s.ctx, int64(20)).Return(customer(20, "0", nil), nil).Times(1)
+	s.mockRepo.EXPECT().
+		ListAliases(s.ctx, int64(20)).
+		Return([]*account.Alias{{Alias: "@alice", AccountID: 20, Kind: entities.AliasKindHandle, CreatedAt: createdAt}}, nil).
+		Times(1)
+
+	response, err := s.core.ListAliases(s.ctx, 20)
+	s.Nil(err)
+	s.Equal(&entities.AliasesResponse{
+		AccountID: 20,
+		Aliases: []*entities.AliasResponse{
+			{Alias: "@alice", Kind: entities.AliasKindHandle, AccountID: 20, CreatedAt: "2026-03-01T12:00:00Z"},
+		},
+	}, response)
+}
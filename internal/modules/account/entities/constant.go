@@ -3,17 +3,162 @@ package entities
 
 // Error messages for the account module
 const (
-	ErrMsgAccountNotFound      = "account not found"
-	ErrMsgAccountExists        = "account already exists"
-	ErrMsgInvalidAccountID     = "invalid account ID"
-	ErrMsgInvalidBalance       = "initial balance must be non-negative"
-	ErrMsgInvalidDecimal       = "invalid decimal format for balance"
-	ErrMsgTooManyDecimalPlaces = "value exceeds maximum precision"
+	ErrMsgAccountNotFound         = "account not found"
+	ErrMsgAccountExists           = "account already exists"
+	ErrMsgInvalidAccountID        = "invalid account ID"
+	ErrMsgInvalidBalance          = "initial balance must be non-negative"
+	ErrMsgInvalidDecimal          = "invalid decimal format for balance"
+	ErrMsgTooManyDecimalPlaces    = "value exceeds maximum precision"
+	ErrMsgInvalidCurrency         = "unsupported currency"
+	ErrMsgClearingNotFound        = "no clearing account for currency"
+	ErrMsgInterestExpenseNotFound = "no interest-expense account for currency"
+	ErrMsgInvalidAsOf             = "invalid as_of timestamp"
+	ErrMsgFutureAsOf              = "as_of is in the future"
+	ErrMsgParentNotFound          = "parent account not found"
+	ErrMsgInvalidParent           = "parent must be a customer account in the same currency"
+	ErrMsgParentHasBalance        = "parent account has a non-zero balance"
+	ErrMsgHierarchyCycle          = "move would create a cycle in the account hierarchy"
+	ErrMsgParentRequired          = "parent_account_id is required"
+	ErrMsgInvalidMinBalance       = "minimum balance must be a non-negative decimal"
+	ErrMsgMinBalanceSystem        = "minimum balance cannot be set on system accounts"
+	ErrMsgInvalidBulkMode         = "unsupported bulk mode"
+	ErrMsgInvalidBulkSize         = "bulk request has no rows or too many rows"
+	ErrMsgInvalidCSV              = "malformed account CSV"
+	ErrMsgInvalidAlias            = "invalid alias"
+	ErrMsgInvalidAliasKind        = "unsupported alias kind"
+	ErrMsgAliasTaken              = "alias already registered"
+	ErrMsgAliasNotFound           = "alias not found"
+	ErrMsgAliasSystem             = "aliases cannot be registered for system accounts"
+	ErrMsgAliasGenerated          = "iban aliases are generated by the service"
 )
 
 // Route path constants for the account module
 const (
-	RouteAccounts    = "/accounts"
-	RouteAccountByID = "/accounts/{accountID}"
-	ParamAccountID   = "accountID"
+	RouteAccounts        = "/accounts"
+	RouteAccountsBulk    = "/accounts/bulk"
+	RouteAccountByID     = "/accounts/{accountID}"
+	RouteAccountBalance  = "/accounts/{accountID}/balance"
+	RouteAccountChildren = "/accounts/{accountID}/children"
+	RouteAccountParent   = "/accounts/{accountID}/parent"
+	RouteMinimumBalance  = "/accounts/{accountID}/minimum-balance"
+	RouteAccountAliases  = "/accounts/{accountID}/aliases"
+	ParamAccountID       = "accountID"
+	QueryParamAsOf       = "as_of"
+	QueryParamRollup     = "rollup"
+	QueryParamMode       = "mode"
+	FieldParentAccountID = "parent_account_id"
 )
+
+// Account ID generator kinds (config: account.id_generator)
+const (
+	IDGeneratorSequence = "sequence"
+	IDGeneratorRandom   = "random"
+)
+
+// MaxGeneratedIDAttempts bounds retries when a generated account ID collides with an existing one
+const MaxGeneratedIDAttempts = 3
+
+// Bulk create modes (query: mode)
+const (
+	// BulkModeAllOrNothing creates every row or none of them
+	BulkModeAllOrNothing = "all_or_nothing"
+	// BulkModeBestEffort creates the rows that pass validation and reports the rest
+	BulkModeBestEffort = "best_effort"
+)
+
+// Bulk create row statuses
+const (
+	BulkStatusCreated = "created"
+	BulkStatusFailed  = "failed"
+	// BulkStatusSkipped marks a valid row that was not created because another row
+	// failed in all_or_nothing mode
+	BulkStatusSkipped = "skipped"
+)
+
+// Bulk create limits. MaxBulkAccounts keeps one request inside a single transaction
+// of reasonable size; rows are inserted BulkInsertChunkSize at a time.
+const (
+	MaxBulkAccounts     = 10000
+	BulkInsertChunkSize = 1000
+)
+
+// Bulk create CSV columns. The header row is required; columns may appear in any order
+// and only initial_balance is mandatory.
+const (
+	CSVColumnAccountID       = "account_id"
+	CSVColumnInitialBalance  = "initial_balance"
+	CSVColumnCurrency        = "currency"
+	CSVColumnParentAccountID = "parent_account_id"
+)
+
+// CSVColumns lists the recognised bulk create CSV columns
+var CSVColumns = []string{CSVColumnAccountID, CSVColumnInitialBalance, CSVColumnCurrency, CSVColumnParentAccountID}
+
+// Account kinds
+const (
+	// KindCustomer is a regular account that can never go negative
+	KindCustomer = "customer"
+	// KindClearing is the per-currency external funding account used by deposits and withdrawals
+	KindClearing = "clearing"
+	// KindInterestExpense is the per-currency account interest payouts are funded from
+	KindInterestExpense = "interest_expense"
+)
+
+// SystemKinds lists the account kinds created once per supported currency at startup
+var SystemKinds = []string{KindClearing, KindInterestExpense}
+
+// General-ledger categories (accounts.gl_category). Balances are stored credit-positive:
+// a posting credits its destination and debits its source.
+const (
+	GLCategoryAsset     = "asset"
+	GLCategoryLiability = "liability"
+	GLCategoryEquity    = "equity"
+	GLCategoryRevenue   = "revenue"
+	GLCategoryExpense   = "expense"
+)
+
+// GLCategories lists the general-ledger categories in chart-of-accounts order
+var GLCategories = []string{GLCategoryAsset, GLCategoryLiability, GLCategoryEquity, GLCategoryRevenue, GLCategoryExpense}
+
+// KindGLCategories maps each account kind to the general-ledger category its accounts are
+// classified under: customer funds are owed to customers, clearing accounts mirror funds
+// held externally and interest paid is an expense of the service
+var KindGLCategories = map[string]string{
+	KindCustomer:        GLCategoryLiability,
+	KindClearing:        GLCategoryAsset,
+	KindInterestExpense: GLCategoryExpense,
+}
+
+// Alias kinds
+const (
+	// AliasKindHandle is a user-chosen handle, stored with a leading HandlePrefix
+	AliasKindHandle = "handle"
+	// AliasKindEmail is an email-like string
+	AliasKindEmail = "email"
+	// AliasKindIBAN is an IBAN-style number generated by the service
+	AliasKindIBAN = "iban"
+)
+
+// Alias formats. Handles are 3-32 lower-case letters, digits, '.', '_' or '-' starting
+// with a letter or digit; the @ prefix keeps them apart from IBANs and emails.
+const (
+	HandlePrefix    = "@"
+	MinHandleLength = 3
+	MaxHandleLength = 32
+	MaxAliasLength  = 254
+)
+
+// Generated IBAN format: IBANCountryCode, two ISO 7064 MOD 97-10 check digits and an
+// IBANBBANLength-digit account number. ZZ is a user-assigned ISO 3166 code, so generated
+// numbers can never be mistaken for real bank accounts.
+const (
+	IBANCountryCode = "ZZ"
+	IBANBBANLength  = 16
+)
+
+// DefaultCurrency is used when the configuration does not specify one
+const DefaultCurrency = "USD"
+
+// HierarchyLockKey serializes account moves (pg_advisory_xact_lock), so that two
+// concurrent moves cannot together form a cycle
+const HierarchyLockKey int64 = 0x6869657261726368 // "hierarch"
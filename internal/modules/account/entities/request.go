@@ -1,12 +1,41 @@
 package entities
 
-// CreateAccountRequest represents the request to create a new account
+// CreateAccountRequest represents the request to create a new account.
+// AccountID is optional; when omitted (zero) the service allocates one.
+// ParentAccountID optionally nests the new account under an existing one.
 type CreateAccountRequest struct {
-	AccountID      int64  `json:"account_id"`
-	InitialBalance string `json:"initial_balance"`
+	AccountID       int64  `json:"account_id,omitempty"`
+	InitialBalance  string `json:"initial_balance"`
+	Currency        string `json:"currency,omitempty"`
+	ParentAccountID *int64 `json:"parent_account_id,omitempty"`
+}
+
+// BulkCreateAccountsRequest represents a bulk create: the rows of a JSON array or CSV
+// upload, each validated like a CreateAccountRequest. Mode defaults to all_or_nothing.
+type BulkCreateAccountsRequest struct {
+	Mode     string
+	Accounts []CreateAccountRequest
 }
 
 // GetAccountRequest represents the request to get an account by ID
 type GetAccountRequest struct {
 	AccountID int64 `json:"account_id"`
 }
+
+// SetMinimumBalanceRequest represents the admin request to set an account's floor
+type SetMinimumBalanceRequest struct {
+	MinimumBalance string `json:"minimum_balance"`
+}
+
+// MoveAccountRequest represents the request to move an account under a new parent.
+// A null ParentAccountID detaches the account, making it a root.
+type MoveAccountRequest struct {
+	ParentAccountID *int64 `json:"parent_account_id"`
+}
+
+// CreateAliasRequest represents the request to register an alias for an account.
+// Alias is required for handles and emails and must be omitted for IBANs, which are generated.
+type CreateAliasRequest struct {
+	Kind  string `json:"kind"`
+	Alias string `json:"alias,omitempty"`
+}
@@ -1,9 +1,71 @@
 package entities
 
-// AccountResponse represents the response for account operations
+// AccountResponse represents the response for account operations.
+// RollupBalance, the balance summed across the account and all its descendants,
+// is only set when requested.
 type AccountResponse struct {
+	AccountID       int64  `json:"account_id"`
+	Balance         string `json:"balance"`
+	Currency        string `json:"currency"`
+	MinimumBalance  string `json:"minimum_balance"`
+	GLCategory      string `json:"gl_category"`
+	ParentAccountID *int64 `json:"parent_account_id,omitempty"`
+	RollupBalance   string `json:"rollup_balance,omitempty"`
+}
+
+// BulkCreateResponse reports the outcome of every row of a bulk create
+type BulkCreateResponse struct {
+	Mode    string             `json:"mode"`
+	Created int                `json:"created"`
+	Failed  int                `json:"failed"`
+	Skipped int                `json:"skipped"`
+	Results []BulkCreateResult `json:"results"`
+}
+
+// BulkCreateResult is the outcome of one row. Row is the 1-based position of the row
+// in the JSON array, or its data row number in the CSV (not counting the header).
+type BulkCreateResult struct {
+	Row       int           `json:"row"`
+	Status    string        `json:"status"`
+	AccountID int64         `json:"account_id,omitempty"`
+	Balance   string        `json:"balance,omitempty"`
+	Currency  string        `json:"currency,omitempty"`
+	Error     *BulkRowError `json:"error,omitempty"`
+}
+
+// BulkRowError describes why a row failed, using the same codes as single-account errors
+type BulkRowError struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// ChildrenResponse lists the direct sub-accounts of an account
+type ChildrenResponse struct {
+	AccountID int64              `json:"account_id"`
+	Children  []*AccountResponse `json:"children"`
+}
+
+// BalanceResponse represents an account balance as of a point in time
+type BalanceResponse struct {
 	AccountID int64  `json:"account_id"`
 	Balance   string `json:"balance"`
+	Currency  string `json:"currency"`
+	AsOf      string `json:"as_of"`
+}
+
+// AliasResponse represents an alias registered for an account, in canonical form
+type AliasResponse struct {
+	Alias     string `json:"alias"`
+	Kind      string `json:"kind"`
+	AccountID int64  `json:"account_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// AliasesResponse lists the aliases registered for an account
+type AliasesResponse struct {
+	AccountID int64            `json:"account_id"`
+	Aliases   []*AliasResponse `json:"aliases"`
 }
 
 // NOTE: ErrorResponse has been consolidated to pkg/apperror/response.go
@@ -0,0 +1,82 @@
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"math"
+
+	"github.com/internal-transfers-service/internal/modules/account/entities"
+)
+
+// IDGenerator allocates account IDs for create requests that omit account_id
+type IDGenerator interface {
+	NextID(ctx context.Context) (int64, error)
+	// NextIDs allocates n IDs at once, for bulk creates
+	NextIDs(ctx context.Context, n int) ([]int64, error)
+}
+
+// SequenceIDGenerator allocates IDs from the account_id_seq database sequence
+type SequenceIDGenerator struct {
+	repo IRepository
+}
+
+// Compile-time interface check
+var _ IDGenerator = (*SequenceIDGenerator)(nil)
+
+// NewSequenceIDGenerator creates a generator backed by the repository's sequence
+func NewSequenceIDGenerator(repo IRepository) *SequenceIDGenerator {
+	return &SequenceIDGenerator{repo: repo}
+}
+
+// NextID returns the next value of the account ID sequence
+func (g *SequenceIDGenerator) NextID(ctx context.Context) (int64, error) {
+	return g.repo.NextAccountID(ctx)
+}
+
+// NextIDs returns the next n values of the account ID sequence in one round trip
+func (g *SequenceIDGenerator) NextIDs(ctx context.Context, n int) ([]int64, error) {
+	return g.repo.NextAccountIDs(ctx, n)
+}
+
+// RandomIDGenerator allocates uniformly random positive int64 IDs.
+// Useful when sequential IDs would leak account counts to clients.
+type RandomIDGenerator struct{}
+
+// Compile-time interface check
+var _ IDGenerator = (*RandomIDGenerator)(nil)
+
+// NewRandomIDGenerator creates a random ID generator
+func NewRandomIDGenerator() *RandomIDGenerator {
+	return &RandomIDGenerator{}
+}
+
+// NextID returns a random ID in the range [1, MaxInt64]
+func (g *RandomIDGenerator) NextID(_ context.Context) (int64, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(buf[:])%math.MaxInt64) + 1, nil
+}
+
+// NextIDs returns n random IDs
+func (g *RandomIDGenerator) NextIDs(ctx context.Context, n int) ([]int64, error) {
+	ids := make([]int64, 0, n)
+	for range n {
+		id, err := g.NextID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// NewIDGenerator returns the generator configured by kind, defaulting to the sequence generator
+func NewIDGenerator(kind string, repo IRepository) IDGenerator {
+	if kind == entities.IDGeneratorRandom {
+		return NewRandomIDGenerator()
+	}
+	return NewSequenceIDGenerator(repo)
+}
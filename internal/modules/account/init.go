@@ -3,6 +3,8 @@ package account
 import (
 	"context"
 
+	"github.com/internal-transfers-service/internal/config"
+	"github.com/internal-transfers-service/internal/modules/audit"
 	"github.com/internal-transfers-service/pkg/database"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -11,11 +13,11 @@ import (
 var AccModule IModule
 
 // NewModule initializes the account module
-var NewModule = func(ctx context.Context, pool *pgxpool.Pool) IModule {
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool, cfg config.AccountConfig, recorder audit.IRecorder) IModule {
 	if AccModule == nil {
 		poolWrapper := database.NewPoolWrapper(pool)
-		repo := NewRepository(poolWrapper)
-		core := NewCore(ctx, repo)
+		repo := NewRepository(poolWrapper, recorder)
+		core := NewCore(ctx, repo, cfg)
 		handler := NewHTTPHandler(core)
 
 		AccModule = &Module{
@@ -5,10 +5,15 @@ package account
 import (
 	"context"
 	"errors"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/internal-transfers-service/internal/constants"
 	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/account/entities"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	auditentities "github.com/internal-transfers-service/internal/modules/audit/entities"
 	"github.com/internal-transfers-service/pkg/apperror"
 	"github.com/internal-transfers-service/pkg/database"
 	"github.com/jackc/pgx/v5"
@@ -21,70 +26,299 @@ type Account struct {
 	Balance   decimal.Decimal `json:"balance"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
+	Currency  string          `json:"currency"`
+	Kind      string          `json:"kind"`
+	// ParentAccountID is the parent in the account hierarchy; nil for root accounts
+	ParentAccountID *int64 `json:"parent_account_id,omitempty"`
+	// MinimumBalance is the floor debits may not take the balance below
+	MinimumBalance decimal.Decimal `json:"minimum_balance"`
+	// GLCategory is the general-ledger category the account is reported under
+	GLCategory string `json:"gl_category"`
+}
+
+// IsSystem reports whether the account is a system account (not owned by a customer).
+// System accounts may carry negative balances and cannot be used in plain transfers.
+func (a *Account) IsSystem() bool {
+	return a.Kind != entities.KindCustomer
+}
+
+// Alias is a unique name an account can be addressed by instead of its ID.
+// Alias holds the canonical form (see CanonicalAlias).
+type Alias struct {
+	Alias     string    `json:"alias"`
+	AccountID int64     `json:"account_id"`
+	Kind      string    `json:"kind"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // IRepository defines the interface for account data access
 type IRepository interface {
 	Create(ctx context.Context, account *Account) error
+	CreateTx(ctx context.Context, tx pgx.Tx, account *Account) error
+	CreateBatch(ctx context.Context, tx pgx.Tx, accounts []*Account) (map[int64]bool, error)
+	BeginTx(ctx context.Context) (pgx.Tx, error)
 	GetByID(ctx context.Context, accountID int64) (*Account, error)
 	GetForUpdate(ctx context.Context, tx pgx.Tx, accountID int64) (*Account, error)
 	UpdateBalance(ctx context.Context, tx pgx.Tx, accountID int64, newBalance decimal.Decimal) error
-	Exists(ctx context.Context, accountID int64) (bool, error)
+	NextAccountID(ctx context.Context) (int64, error)
+	NextAccountIDs(ctx context.Context, n int) ([]int64, error)
+	GetClearingAccount(ctx context.Context, currency string) (*Account, error)
+	GetInterestExpenseAccount(ctx context.Context, currency string) (*Account, error)
+	EnsureSystemAccount(ctx context.Context, kind, currency string) error
+	GetBalanceAsOf(ctx context.Context, accountID int64, asOf time.Time) (decimal.Decimal, error)
+	GetBalanceAsOfTx(ctx context.Context, tx pgx.Tx, accountID int64, asOf time.Time) (decimal.Decimal, error)
+	HasChildren(ctx context.Context, tx pgx.Tx, accountID int64) (bool, error)
+	ListChildren(ctx context.Context, accountID int64) ([]*Account, error)
+	GetRollupBalance(ctx context.Context, accountID int64) (decimal.Decimal, error)
+	LockHierarchy(ctx context.Context, tx pgx.Tx) error
+	IsInSubtree(ctx context.Context, tx pgx.Tx, rootID, accountID int64) (bool, error)
+	SetParent(ctx context.Context, tx pgx.Tx, accountID int64, parentID *int64) error
+	SetMinimumBalance(ctx context.Context, accountID int64, minimum decimal.Decimal) (*Account, error)
+	CreateAlias(ctx context.Context, alias *Alias) error
+	ListAliases(ctx context.Context, accountID int64) ([]*Alias, error)
+	ResolveAlias(ctx context.Context, alias string) (int64, error)
 }
 
-// Repository implements IRepository
+// Repository implements IRepository. Every change it makes is recorded in the audit log
+// within the same transaction.
 type Repository struct {
-	pool database.IPool
+	pool  database.IPool
+	audit audit.IRecorder
 }
 
 // Compile-time interface check
 var _ IRepository = (*Repository)(nil)
 
 // NewRepository creates a new account repository
-func NewRepository(pool database.IPool) *Repository {
-	return &Repository{pool: pool}
+func NewRepository(pool database.IPool, recorder audit.IRecorder) *Repository {
+	return &Repository{pool: pool, audit: recorder}
 }
 
 // SQL queries
 const (
+	// The initial balance is recorded as an opening entry in the same statement,
+	// so balances at any past instant can be rebuilt from transactions alone. The
+	// opening is also credited to the ledger; it has no source and so no debit side.
 	queryInsertAccount = `
-		INSERT INTO accounts (account_id, balance, created_at, updated_at)
-		VALUES ($1, $2, $3, $4)`
+		WITH inserted AS (
+			INSERT INTO accounts (account_id, balance, created_at, updated_at, currency, kind, parent_account_id, gl_category)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING account_id, balance, created_at
+		), opening AS (
+			INSERT INTO transactions (source_account_id, destination_account_id, amount, created_at, type)
+			SELECT NULL, account_id, balance, created_at, 'opening'
+			FROM inserted
+			WHERE balance > 0
+			RETURNING id, destination_account_id, amount, created_at
+		)
+		INSERT INTO ledger_entries (transaction_id, account_id, direction, amount, balance_after, created_at)
+		SELECT id, destination_account_id, 'credit', amount, amount, created_at
+		FROM opening`
+
+	// Bulk variant of queryInsertAccount. Rows whose ID is taken are skipped rather than
+	// failing the statement, and only the IDs actually inserted are returned.
+	queryInsertAccountBatch = `
+		WITH rows AS (
+			SELECT * FROM unnest($1::bigint[], $2::numeric[], $3::text[], $4::bigint[])
+				AS r(account_id, balance, currency, parent_account_id)
+		), inserted AS (
+			INSERT INTO accounts (account_id, balance, created_at, updated_at, currency, kind, parent_account_id, gl_category)
+			SELECT account_id, balance, $5, $5, currency, 'customer', parent_account_id, 'liability'
+			FROM rows
+			ON CONFLICT (account_id) DO NOTHING
+			RETURNING account_id, balance, created_at
+		), opening AS (
+			INSERT INTO transactions (source_account_id, destination_account_id, amount, created_at, type)
+			SELECT NULL, account_id, balance, created_at, 'opening'
+			FROM inserted
+			WHERE balance > 0
+			RETURNING id, destination_account_id, amount, created_at
+		), ledger AS (
+			INSERT INTO ledger_entries (transaction_id, account_id, direction, amount, balance_after, created_at)
+			SELECT id, destination_account_id, 'credit', amount, amount, created_at
+			FROM opening
+		)
+		SELECT account_id FROM inserted`
 
 	querySelectByID = `
-		SELECT account_id, balance, created_at, updated_at
+		SELECT ` + accountColumns + `
 		FROM accounts
 		WHERE account_id = $1`
 
 	querySelectForUpdate = `
-		SELECT account_id, balance, created_at, updated_at
+		SELECT ` + accountColumns + `
 		FROM accounts
 		WHERE account_id = $1
 		FOR UPDATE`
 
+	querySelectSystemAccount = `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE currency = $1 AND kind = $2`
+
+	// ON CONFLICT without a target also covers the one-system-account-per-currency-and-kind
+	// partial unique indexes, so concurrent replicas can run this safely.
+	queryEnsureSystemAccount = `
+		INSERT INTO accounts (account_id, balance, currency, kind, gl_category)
+		SELECT nextval('account_id_seq'), 0, $1, $2, $3
+		WHERE NOT EXISTS (SELECT 1 FROM accounts WHERE currency = $1 AND kind = $2)
+		ON CONFLICT DO NOTHING
+		RETURNING ` + accountColumns
+
+	// Returns the balance being replaced. Callers already hold the row lock, so prev
+	// cannot have moved on since it was read.
 	queryUpdateBalance = `
+		WITH prev AS (
+			SELECT balance FROM accounts WHERE account_id = $1 FOR UPDATE
+		)
 		UPDATE accounts
 		SET balance = $2, updated_at = $3
-		WHERE account_id = $1`
+		WHERE account_id = $1
+		RETURNING (SELECT balance FROM prev)`
+
+	queryHasChildren = `
+		SELECT EXISTS(SELECT 1 FROM accounts WHERE parent_account_id = $1)`
 
-	queryExists = `
-		SELECT EXISTS(SELECT 1 FROM accounts WHERE account_id = $1)`
+	querySelectChildren = `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE parent_account_id = $1
+		ORDER BY account_id`
+
+	// UNION (rather than UNION ALL) stops the walk even if the hierarchy were ever to contain a cycle
+	queryRollupBalance = `
+		WITH RECURSIVE subtree AS (
+			SELECT account_id, balance FROM accounts WHERE account_id = $1
+			UNION
+			SELECT a.account_id, a.balance
+			FROM accounts a
+			JOIN subtree s ON a.parent_account_id = s.account_id
+		)
+		SELECT COALESCE(SUM(balance), 0) FROM subtree`
+
+	queryLockHierarchy = `
+		SELECT pg_advisory_xact_lock($1)`
+
+	// Walks up from $2, which is shorter than walking down from $1
+	queryIsInSubtree = `
+		WITH RECURSIVE ancestors AS (
+			SELECT account_id, parent_account_id FROM accounts WHERE account_id = $2
+			UNION
+			SELECT a.account_id, a.parent_account_id
+			FROM accounts a
+			JOIN ancestors an ON a.account_id = an.parent_account_id
+		)
+		SELECT EXISTS(SELECT 1 FROM ancestors WHERE account_id = $1)`
+
+	querySetMinimumBalance = `
+		UPDATE accounts
+		SET minimum_balance = $2, updated_at = $3
+		WHERE account_id = $1
+		RETURNING ` + accountColumns
+
+	// Returns the parent being replaced
+	querySetParent = `
+		WITH prev AS (
+			SELECT parent_account_id FROM accounts WHERE account_id = $1 FOR UPDATE
+		)
+		UPDATE accounts
+		SET parent_account_id = $2, updated_at = $3
+		WHERE account_id = $1
+		RETURNING (SELECT parent_account_id FROM prev)`
+
+	queryInsertAlias = `
+		INSERT INTO account_aliases (alias, account_id, kind, created_at)
+		VALUES ($1, $2, $3, $4)`
+
+	querySelectAliases = `
+		SELECT alias, account_id, kind, created_at
+		FROM account_aliases
+		WHERE account_id = $1
+		ORDER BY created_at, alias`
+
+	queryResolveAlias = `
+		SELECT account_id
+		FROM account_aliases
+		WHERE alias = $1`
+
+	queryNextAccountID = `
+		SELECT nextval('account_id_seq')`
+
+	queryNextAccountIDs = `
+		SELECT nextval('account_id_seq') FROM generate_series(1, $1)`
+
+	// Starts from the latest daily snapshot taken at or before $2 and replays only the
+	// movements after it, so the cost is bounded by one day of history per account.
+	// Without a snapshot, archived months that ended by $2 count through their totals.
+	queryBalanceAsOf = `
+		WITH snap AS (
+			SELECT balance, cutoff_at FROM balance_snapshots
+			WHERE account_id = $1 AND cutoff_at <= $2
+			ORDER BY cutoff_at DESC
+			LIMIT 1
+		)
+		SELECT
+			COALESCE((SELECT balance FROM snap), 0)
+			+
+			CASE WHEN EXISTS (SELECT 1 FROM snap) THEN 0 ELSE archived_transaction_net($1, $2) END
+			+
+			(SELECT COALESCE(SUM(amount), 0) FROM transactions
+			 WHERE destination_account_id = $1 AND created_at <= $2
+			   AND created_at > COALESCE((SELECT cutoff_at FROM snap), '-infinity'))
+			-
+			(SELECT COALESCE(SUM(amount), 0) FROM transactions
+			 WHERE source_account_id = $1 AND created_at <= $2
+			   AND created_at > COALESCE((SELECT cutoff_at FROM snap), '-infinity'))`
 )
 
-// Create inserts a new account into the database
+// Create inserts a new account into the database.
+// Returns a CONFLICT apperror if the account ID is already taken.
 func (r *Repository) Create(ctx context.Context, account *Account) error {
+	return database.InTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return r.insert(ctx, tx, account)
+	})
+}
+
+// CreateTx is Create within a transaction, so the parent account can be locked and
+// checked in the same transaction that inserts its child
+func (r *Repository) CreateTx(ctx context.Context, tx pgx.Tx, account *Account) error {
+	return r.insert(ctx, tx, account)
+}
+
+// accountEntityID is the audit entity ID of an account
+func accountEntityID(accountID int64) string {
+	return strconv.FormatInt(accountID, 10)
+}
+
+// insert runs queryInsertAccount in tx and records the creation
+func (r *Repository) insert(ctx context.Context, tx pgx.Tx, account *Account) error {
 	now := time.Now().UTC()
 	account.CreatedAt = now
 	account.UpdatedAt = now
 
-	_, err := r.pool.Exec(ctx, queryInsertAccount,
+	if account.Kind == "" {
+		account.Kind = entities.KindCustomer
+	}
+	if account.GLCategory == "" {
+		account.GLCategory = entities.KindGLCategories[account.Kind]
+	}
+
+	_, err := tx.Exec(ctx, queryInsertAccount,
 		account.AccountID,
 		account.Balance,
 		account.CreatedAt,
 		account.UpdatedAt,
+		account.Currency,
+		account.Kind,
+		account.ParentAccountID,
+		account.GLCategory,
 	)
 
 	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return apperror.NewWithMessage(apperror.CodeConflict, ErrAccountExists, apperror.MsgDuplicateAccount).
+				WithField(apperror.FieldAccountID, account.AccountID)
+		}
 		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToCreateAccount,
 			constants.LogKeyAccountID, account.AccountID,
 			constants.LogKeyError, err,
@@ -92,6 +326,11 @@ func (r *Repository) Create(ctx context.Context, account *Account) error {
 		return err
 	}
 
+	if err := r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionAccountCreated, auditentities.EntityAccount,
+		accountEntityID(account.AccountID), nil, account)); err != nil {
+		return err
+	}
+
 	logger.Ctx(ctx).Infow(constants.LogMsgAccountCreated,
 		constants.LogKeyAccountID, account.AccountID,
 		constants.LogFieldInitialBalance, account.Balance.String(),
@@ -99,15 +338,98 @@ func (r *Repository) Create(ctx context.Context, account *Account) error {
 	return nil
 }
 
-// GetByID retrieves an account by its ID
-func (r *Repository) GetByID(ctx context.Context, accountID int64) (*Account, error) {
+// CreateBatch inserts customer accounts, with their opening entries, in a single statement.
+// Accounts whose ID already exists are skipped; the returned set holds the IDs inserted.
+func (r *Repository) CreateBatch(ctx context.Context, tx pgx.Tx, accounts []*Account) (map[int64]bool, error) {
+	now := time.Now().UTC()
+	ids := make([]int64, len(accounts))
+	balances := make([]string, len(accounts))
+	currencies := make([]string, len(accounts))
+	parentIDs := make([]*int64, len(accounts))
+	for i, account := range accounts {
+		account.CreatedAt = now
+		account.UpdatedAt = now
+		account.Kind = entities.KindCustomer
+		account.GLCategory = entities.KindGLCategories[entities.KindCustomer]
+		ids[i] = account.AccountID
+		balances[i] = account.Balance.String()
+		currencies[i] = account.Currency
+		parentIDs[i] = account.ParentAccountID
+	}
+
+	rows, err := tx.Query(ctx, queryInsertAccountBatch, ids, balances, currencies, parentIDs, now)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToBulkCreate,
+			constants.LogFieldAccountCount, len(accounts),
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	inserted := make(map[int64]bool, len(accounts))
+	for rows.Next() {
+		var accountID int64
+		if err := rows.Scan(&accountID); err != nil {
+			return nil, err
+		}
+		inserted[accountID] = true
+	}
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToBulkCreate,
+			constants.LogFieldAccountCount, len(accounts),
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+
+	events := make([]*audit.Event, 0, len(inserted))
+	for _, account := range accounts {
+		if inserted[account.AccountID] {
+			events = append(events, audit.NewEvent(ctx, auditentities.ActionAccountCreated, auditentities.EntityAccount,
+				accountEntityID(account.AccountID), nil, account))
+		}
+	}
+	if err := r.audit.Record(ctx, tx, events...); err != nil {
+		return nil, err
+	}
+	return inserted, nil
+}
+
+// accountColumns lists the columns scanned by scanAccount, in order
+const accountColumns = `account_id, balance, created_at, updated_at, currency, kind, parent_account_id, minimum_balance, gl_category`
+
+// scanAccount scans a row selected with accountColumns into an Account
+func scanAccount(row pgx.Row) (*Account, error) {
 	var account Account
-	err := r.pool.QueryRow(ctx, querySelectByID, accountID).Scan(
+	err := row.Scan(
 		&account.AccountID,
 		&account.Balance,
 		&account.CreatedAt,
 		&account.UpdatedAt,
+		&account.Currency,
+		&account.Kind,
+		&account.ParentAccountID,
+		&account.MinimumBalance,
+		&account.GLCategory,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// BeginTx starts a read-committed transaction, matching the transfer path so that
+// row locks taken here and there interleave the same way
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel: pgx.ReadCommitted,
+	})
+}
+
+// GetByID retrieves an account by its ID
+func (r *Repository) GetByID(ctx context.Context, accountID int64) (*Account, error) {
+	account, err := scanAccount(r.pool.QueryRow(ctx, querySelectByID, accountID))
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -121,18 +443,12 @@ func (r *Repository) GetByID(ctx context.Context, accountID int64) (*Account, er
 		return nil, err
 	}
 
-	return &account, nil
+	return account, nil
 }
 
 // GetForUpdate retrieves an account with a row-level lock for update
 func (r *Repository) GetForUpdate(ctx context.Context, tx pgx.Tx, accountID int64) (*Account, error) {
-	var account Account
-	err := tx.QueryRow(ctx, querySelectForUpdate, accountID).Scan(
-		&account.AccountID,
-		&account.Balance,
-		&account.CreatedAt,
-		&account.UpdatedAt,
-	)
+	account, err := scanAccount(tx.QueryRow(ctx, querySelectForUpdate, accountID))
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -146,14 +462,15 @@ func (r *Repository) GetForUpdate(ctx context.Context, tx pgx.Tx, accountID int6
 		return nil, err
 	}
 
-	return &account, nil
+	return account, nil
 }
 
-// UpdateBalance updates the balance of an account within a transaction
+// UpdateBalance updates the balance of an account within a transaction and records the
+// old and new balances. The caller must hold the account's row lock (see GetForUpdate).
 func (r *Repository) UpdateBalance(ctx context.Context, tx pgx.Tx, accountID int64, newBalance decimal.Decimal) error {
 	now := time.Now().UTC()
-	_, err := tx.Exec(ctx, queryUpdateBalance, accountID, newBalance, now)
-	if err != nil {
+	var oldBalance decimal.Decimal
+	if err := tx.QueryRow(ctx, queryUpdateBalance, accountID, newBalance, now).Scan(&oldBalance); err != nil {
 		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToUpdateBalance,
 			constants.LogKeyAccountID, accountID,
 			constants.LogFieldNewBalance, newBalance.String(),
@@ -161,15 +478,153 @@ func (r *Repository) UpdateBalance(ctx context.Context, tx pgx.Tx, accountID int
 		)
 		return err
 	}
-	return nil
+
+	return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionBalanceChanged, auditentities.EntityAccount,
+		accountEntityID(accountID), balanceValue{Balance: oldBalance}, balanceValue{Balance: newBalance}))
 }
 
-// Exists checks if an account exists
-func (r *Repository) Exists(ctx context.Context, accountID int64) (bool, error) {
-	var exists bool
-	err := r.pool.QueryRow(ctx, queryExists, accountID).Scan(&exists)
+// balanceValue is the audited state of a balance change
+type balanceValue struct {
+	Balance decimal.Decimal `json:"balance"`
+}
+
+// parentValue is the audited state of an account move
+type parentValue struct {
+	ParentAccountID *int64 `json:"parent_account_id"`
+}
+
+// minimumBalanceValue is the audited state of a minimum balance change
+type minimumBalanceValue struct {
+	MinimumBalance decimal.Decimal `json:"minimum_balance"`
+}
+
+// NextAccountID allocates the next server-generated account ID from the sequence
+func (r *Repository) NextAccountID(ctx context.Context) (int64, error) {
+	var accountID int64
+	if err := r.pool.QueryRow(ctx, queryNextAccountID).Scan(&accountID); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToAllocateAcctID,
+			constants.LogKeyError, err,
+		)
+		return 0, err
+	}
+	return accountID, nil
+}
+
+// NextAccountIDs allocates n server-generated account IDs from the sequence
+func (r *Repository) NextAccountIDs(ctx context.Context, n int) ([]int64, error) {
+	rows, err := r.pool.Query(ctx, queryNextAccountIDs, n)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToAllocateAcctID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, n)
+	for rows.Next() {
+		var accountID int64
+		if err := rows.Scan(&accountID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, accountID)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToAllocateAcctID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetClearingAccount returns the designated clearing account for a currency.
+// Returns a NOT_FOUND apperror if none exists.
+func (r *Repository) GetClearingAccount(ctx context.Context, currency string) (*Account, error) {
+	return r.getSystemAccount(ctx, currency, entities.KindClearing, ErrClearingNotFound, apperror.MsgClearingNotFound)
+}
+
+// GetInterestExpenseAccount returns the account interest payouts are funded from for a currency.
+// Returns a NOT_FOUND apperror if none exists.
+func (r *Repository) GetInterestExpenseAccount(ctx context.Context, currency string) (*Account, error) {
+	return r.getSystemAccount(ctx, currency, entities.KindInterestExpense, ErrInterestExpenseNotFound, apperror.MsgInterestExpenseNotFound)
+}
+
+// getSystemAccount returns the system account of the given kind for a currency
+func (r *Repository) getSystemAccount(ctx context.Context, currency, kind string, notFound error, notFoundMsg string) (*Account, error) {
+	account, err := scanAccount(r.pool.QueryRow(ctx, querySelectSystemAccount, currency, kind))
+
 	if err != nil {
-		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToCheckAcctExist,
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewWithMessage(apperror.CodeNotFound, notFound, notFoundMsg).
+				WithField(apperror.FieldCurrency, currency)
+		}
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToGetSystemAccount,
+			constants.LogFieldCurrency, currency,
+			constants.LogFieldAccountKind, kind,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// EnsureSystemAccount creates the system account of the given kind for a currency if it
+// does not exist, recording the creation when it does
+func (r *Repository) EnsureSystemAccount(ctx context.Context, kind, currency string) error {
+	return database.InTx(ctx, r.pool, func(tx pgx.Tx) error {
+		account, err := scanAccount(tx.QueryRow(ctx, queryEnsureSystemAccount, currency, kind, entities.KindGLCategories[kind]))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToEnsureSystemAcct,
+				constants.LogFieldCurrency, currency,
+				constants.LogFieldAccountKind, kind,
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+
+		return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionAccountCreated, auditentities.EntityAccount,
+			accountEntityID(account.AccountID), nil, account))
+	})
+}
+
+// GetBalanceAsOf rebuilds an account's balance at asOf from the latest snapshot before it
+// plus its later credits (including the opening entry) minus its later debits
+func (r *Repository) GetBalanceAsOf(ctx context.Context, accountID int64, asOf time.Time) (decimal.Decimal, error) {
+	return scanBalanceAsOf(ctx, r.pool.QueryRow(ctx, queryBalanceAsOf, accountID, asOf), accountID, asOf)
+}
+
+// GetBalanceAsOfTx is GetBalanceAsOf within a transaction, so that several balances
+// can be read from one consistent view of the history
+func (r *Repository) GetBalanceAsOfTx(ctx context.Context, tx pgx.Tx, accountID int64, asOf time.Time) (decimal.Decimal, error) {
+	return scanBalanceAsOf(ctx, tx.QueryRow(ctx, queryBalanceAsOf, accountID, asOf), accountID, asOf)
+}
+
+// scanBalanceAsOf scans the result of queryBalanceAsOf
+func scanBalanceAsOf(ctx context.Context, row pgx.Row, accountID int64, asOf time.Time) (decimal.Decimal, error) {
+	var balance decimal.Decimal
+	if err := row.Scan(&balance); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToGetBalanceAsOf,
+			constants.LogKeyAccountID, accountID,
+			constants.LogFieldAsOf, asOf,
+			constants.LogKeyError, err,
+		)
+		return decimal.Zero, err
+	}
+	return balance, nil
+}
+
+// HasChildren reports whether an account has any sub-accounts.
+// Called after the account is locked, so under read committed it sees any child
+// committed by a concurrent create or move that held the lock first.
+func (r *Repository) HasChildren(ctx context.Context, tx pgx.Tx, accountID int64) (bool, error) {
+	var exists bool
+	if err := tx.QueryRow(ctx, queryHasChildren, accountID).Scan(&exists); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToCheckChildren,
 			constants.LogKeyAccountID, accountID,
 			constants.LogKeyError, err,
 		)
@@ -177,3 +632,197 @@ func (r *Repository) Exists(ctx context.Context, accountID int64) (bool, error)
 	}
 	return exists, nil
 }
+
+// ListChildren returns the direct sub-accounts of an account, ordered by ID
+func (r *Repository) ListChildren(ctx context.Context, accountID int64) ([]*Account, error) {
+	rows, err := r.pool.Query(ctx, querySelectChildren, accountID)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToListChildren,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	children := make([]*Account, 0)
+	for rows.Next() {
+		child, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToListChildren,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	return children, nil
+}
+
+// GetRollupBalance sums the balances of an account and all of its descendants
+func (r *Repository) GetRollupBalance(ctx context.Context, accountID int64) (decimal.Decimal, error) {
+	var balance decimal.Decimal
+	if err := r.pool.QueryRow(ctx, queryRollupBalance, accountID).Scan(&balance); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToGetRollup,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return decimal.Zero, err
+	}
+	return balance, nil
+}
+
+// LockHierarchy takes the hierarchy advisory lock for the lifetime of tx
+func (r *Repository) LockHierarchy(ctx context.Context, tx pgx.Tx) error {
+	if _, err := tx.Exec(ctx, queryLockHierarchy, entities.HierarchyLockKey); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToLockHierarchy,
+			constants.LogKeyError, err,
+		)
+		return err
+	}
+	return nil
+}
+
+// IsInSubtree reports whether accountID is rootID or one of its descendants
+func (r *Repository) IsInSubtree(ctx context.Context, tx pgx.Tx, rootID, accountID int64) (bool, error) {
+	var inSubtree bool
+	if err := tx.QueryRow(ctx, queryIsInSubtree, rootID, accountID).Scan(&inSubtree); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToCheckAncestry,
+			constants.LogKeyAccountID, rootID,
+			constants.LogFieldParentID, accountID,
+			constants.LogKeyError, err,
+		)
+		return false, err
+	}
+	return inSubtree, nil
+}
+
+// SetParent moves an account under parentID, or makes it a root when parentID is nil
+func (r *Repository) SetParent(ctx context.Context, tx pgx.Tx, accountID int64, parentID *int64) error {
+	now := time.Now().UTC()
+	var oldParentID *int64
+	if err := tx.QueryRow(ctx, querySetParent, accountID, parentID, now).Scan(&oldParentID); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToSetParent,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return err
+	}
+
+	return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionAccountMoved, auditentities.EntityAccount,
+		accountEntityID(accountID), parentValue{ParentAccountID: oldParentID}, parentValue{ParentAccountID: parentID}))
+}
+
+// SetMinimumBalance sets an account's floor and returns the updated account.
+// Returns a NOT_FOUND apperror if the account does not exist.
+func (r *Repository) SetMinimumBalance(ctx context.Context, accountID int64, minimum decimal.Decimal) (*Account, error) {
+	var account *Account
+	err := database.InTx(ctx, r.pool, func(tx pgx.Tx) error {
+		previous, err := r.GetForUpdate(ctx, tx, accountID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		account, err = scanAccount(tx.QueryRow(ctx, querySetMinimumBalance, accountID, minimum, now))
+		if err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToSetMinBalance,
+				constants.LogKeyAccountID, accountID,
+				constants.LogFieldMinimumBalance, minimum.String(),
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+
+		return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionMinBalanceSet, auditentities.EntityAccount,
+			accountEntityID(accountID),
+			minimumBalanceValue{MinimumBalance: previous.MinimumBalance},
+			minimumBalanceValue{MinimumBalance: account.MinimumBalance}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// CreateAlias registers an alias for an account.
+// Returns a CONFLICT apperror if the alias is already registered to any account.
+func (r *Repository) CreateAlias(ctx context.Context, alias *Alias) error {
+	alias.CreatedAt = time.Now().UTC()
+
+	return database.InTx(ctx, r.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, queryInsertAlias, alias.Alias, alias.AccountID, alias.Kind, alias.CreatedAt)
+		if err != nil {
+			if database.IsUniqueViolation(err) {
+				return apperror.NewWithMessage(apperror.CodeConflict, ErrAliasTaken, apperror.MsgAliasTaken).
+					WithField(apperror.FieldAlias, alias.Alias)
+			}
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToCreateAlias,
+				constants.LogKeyAccountID, alias.AccountID,
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+
+		return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionAliasCreated, auditentities.EntityAlias,
+			alias.Alias, nil, alias))
+	})
+}
+
+// ListAliases returns the aliases registered for an account, oldest first
+func (r *Repository) ListAliases(ctx context.Context, accountID int64) ([]*Alias, error) {
+	rows, err := r.pool.Query(ctx, querySelectAliases, accountID)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToListAliases,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	aliases := make([]*Alias, 0)
+	for rows.Next() {
+		var alias Alias
+		if err := rows.Scan(&alias.Alias, &alias.AccountID, &alias.Kind, &alias.CreatedAt); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, &alias)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToListAliases,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// ResolveAlias returns the ID of the account an alias is registered to. The alias is
+// matched in canonical form, and IBANs with wrong check digits are rejected without a
+// lookup. Returns a NOT_FOUND apperror if no account has the alias.
+func (r *Repository) ResolveAlias(ctx context.Context, alias string) (int64, error) {
+	canonical := CanonicalAlias(alias)
+	if !strings.Contains(canonical, entities.HandlePrefix) && !ValidIBAN(canonical) {
+		return 0, apperror.New(apperror.CodeNotFound, ErrAliasNotFound).
+			WithField(apperror.FieldAlias, alias)
+	}
+
+	var accountID int64
+	if err := r.pool.QueryRow(ctx, queryResolveAlias, canonical).Scan(&accountID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, apperror.New(apperror.CodeNotFound, ErrAliasNotFound).
+				WithField(apperror.FieldAlias, alias)
+		}
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToResolveAlias,
+			constants.LogKeyError, err,
+		)
+		return 0, err
+	}
+	return accountID, nil
+}
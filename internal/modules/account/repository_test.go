@@ -7,6 +7,12 @@ import (
 	"time"
 
 	"github.com/internal-transfers-service/internal/modules/account"
+	"github.com/internal-transfers-service/internal/modules/account/entities"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	auditentities "github.com/internal-transfers-service/internal/modules/audit/entities"
+	auditMock "github.com/internal-transfers-service/internal/modules/audit/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/database"
 	dbmock "github.com/internal-transfers-service/pkg/database/mock"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -28,12 +34,13 @@ var (
 // RepositoryTestSuite contains tests for account Repository
 type RepositoryTestSuite struct {
 	suite.Suite
-	ctrl     *gomock.Controller
-	mockPool *dbmock.MockIPool
-	mockRow  *dbmock.MockRow
-	mockTx   *dbmock.MockTx
-	repo     account.IRepository
-	ctx      context.Context
+	ctrl      *gomock.Controller
+	mockPool  *dbmock.MockIPool
+	mockRow   *dbmock.MockRow
+	mockTx    *dbmock.MockTx
+	mockAudit *auditMock.MockIRecorder
+	repo      account.IRepository
+	ctx       context.Context
 }
 
 func TestRepositorySuite(t *testing.T) {
@@ -45,14 +52,38 @@ func (s *RepositoryTestSuite) SetupTest() {
 	s.mockPool = dbmock.NewMockIPool(s.ctrl)
 	s.mockRow = dbmock.NewMockRow(s.ctrl)
 	s.mockTx = dbmock.NewMockTx(s.ctrl)
+	s.mockAudit = auditMock.NewMockIRecorder(s.ctrl)
 	s.ctx = context.Background()
-	s.repo = account.NewRepository(s.mockPool)
+	s.repo = account.NewRepository(s.mockPool, s.mockAudit)
 }
 
 func (s *RepositoryTestSuite) TearDownTest() {
 	s.ctrl.Finish()
 }
 
+// expectTx expects the repository to open its own transaction, rolled back unless committed
+func (s *RepositoryTestSuite) expectTx() {
+	s.mockPool.EXPECT().BeginTx(s.ctx, gomock.Any()).Return(s.mockTx, nil).Times(1)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil).AnyTimes()
+}
+
+// expectAudit expects one audit event with the given action to be recorded in the transaction
+func (s *RepositoryTestSuite) expectAudit(action string) {
+	s.mockAudit.EXPECT().
+		Record(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, events ...*audit.Event) error {
+			s.Require().Len(events, 1)
+			s.Equal(action, events[0].Action)
+			return nil
+		}).
+		Times(1)
+}
+
+// expectCommit expects the repository's transaction to commit
+func (s *RepositoryTestSuite) expectCommit() {
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+}
+
 // Test Create - Success Cases
 
 func (s *RepositoryTestSuite) TestCreateAccountSucceeds() {
@@ -61,10 +92,13 @@ func (s *RepositoryTestSuite) TestCreateAccountSucceeds() {
 		Balance:   decimal.NewFromFloat(100.50),
 	}
 
-	s.mockPool.EXPECT().
-		Exec(s.ctx, gomock.Any(), int64(123), acc.Balance, gomock.Any(), gomock.Any()).
+	s.expectTx()
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), int64(123), acc.Balance, gomock.Any(), gomock.Any(), acc.Currency, entities.KindCustomer, gomock.Any(), entities.GLCategoryLiability).
 		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
 		Times(1)
+	s.expectAudit(auditentities.ActionAccountCreated)
+	s.expectCommit()
 
 	err := s.repo.Create(s.ctx, acc)
 	s.Nil(err)
@@ -80,10 +114,13 @@ func (s *RepositoryTestSuite) TestCreateAccountSetsTimestamps() {
 
 	before := time.Now().UTC()
 
-	s.mockPool.EXPECT().
-		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+	s.expectTx()
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
 		Times(1)
+	s.expectAudit(auditentities.ActionAccountCreated)
+	s.expectCommit()
 
 	err := s.repo.Create(s.ctx, acc)
 	s.Nil(err)
@@ -103,8 +140,9 @@ func (s *RepositoryTestSuite) TestCreateAccountWhenExecFailsReturnsError() {
 		Balance:   decimal.NewFromFloat(100.50),
 	}
 
-	s.mockPool.EXPECT().
-		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+	s.expectTx()
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgconn.CommandTag{}, errRepoDBConnectionFailed).
 		Times(1)
 
@@ -119,8 +157,9 @@ func (s *RepositoryTestSuite) TestCreateAccountWithDuplicateKeyReturnsError() {
 		Balance:   decimal.NewFromFloat(100.50),
 	}
 
-	s.mockPool.EXPECT().
-		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+	s.expectTx()
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgconn.CommandTag{}, errRepoDuplicateKey).
 		Times(1)
 
@@ -129,6 +168,25 @@ func (s *RepositoryTestSuite) TestCreateAccountWithDuplicateKeyReturnsError() {
 	s.Contains(err.Error(), "duplicate key")
 }
 
+func (s *RepositoryTestSuite) TestCreateAccountWithUniqueViolationReturnsConflict() {
+	acc := &account.Account{
+		AccountID: 123,
+		Balance:   decimal.NewFromFloat(100.50),
+	}
+
+	s.expectTx()
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgconn.CommandTag{}, &pgconn.PgError{Code: database.PgCodeUniqueViolation}).
+		Times(1)
+
+	err := s.repo.Create(s.ctx, acc)
+
+	var appErr *apperror.Error
+	s.True(errors.As(err, &appErr))
+	s.Equal(apperror.CodeConflict, appErr.Code())
+}
+
 // Test GetByID - Success Cases
 
 func (s *RepositoryTestSuite) TestGetByIDSucceeds() {
@@ -142,12 +200,14 @@ func (s *RepositoryTestSuite) TestGetByIDSucceeds() {
 		Times(1)
 
 	s.mockRow.EXPECT().
-		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(func(dest ...any) error {
 			*dest[0].(*int64) = 123
 			*dest[1].(*decimal.Decimal) = expectedBalance
 			*dest[2].(*time.Time) = expectedCreatedAt
 			*dest[3].(*time.Time) = expectedUpdatedAt
+			*dest[4].(*string) = entities.DefaultCurrency
+			*dest[5].(*string) = entities.KindCustomer
 			return nil
 		}).
 		Times(1)
@@ -166,12 +226,14 @@ func (s *RepositoryTestSuite) TestGetByIDWithZeroBalance() {
 		Times(1)
 
 	s.mockRow.EXPECT().
-		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(func(dest ...any) error {
 			*dest[0].(*int64) = 456
 			*dest[1].(*decimal.Decimal) = decimal.Zero
 			*dest[2].(*time.Time) = time.Now().UTC()
 			*dest[3].(*time.Time) = time.Now().UTC()
+			*dest[4].(*string) = entities.DefaultCurrency
+			*dest[5].(*string) = entities.KindCustomer
 			return nil
 		}).
 		Times(1)
@@ -191,7 +253,7 @@ func (s *RepositoryTestSuite) TestGetByIDWhenNotFoundReturnsNotFoundError() {
 		Times(1)
 
 	s.mockRow.EXPECT().
-		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgx.ErrNoRows).
 		Times(1)
 
@@ -211,7 +273,7 @@ func (s *RepositoryTestSuite) TestGetByIDWhenDatabaseFailsReturnsError() {
 		Times(1)
 
 	s.mockRow.EXPECT().
-		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(dbError).
 		Times(1)
 
@@ -232,12 +294,14 @@ func (s *RepositoryTestSuite) TestGetForUpdateSucceeds() {
 		Times(1)
 
 	s.mockRow.EXPECT().
-		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(func(dest ...any) error {
 			*dest[0].(*int64) = 123
 			*dest[1].(*decimal.Decimal) = expectedBalance
 			*dest[2].(*time.Time) = time.Now().UTC()
 			*dest[3].(*time.Time) = time.Now().UTC()
+			*dest[4].(*string) = entities.DefaultCurrency
+			*dest[5].(*string) = entities.KindCustomer
 			return nil
 		}).
 		Times(1)
@@ -258,7 +322,7 @@ func (s *RepositoryTestSuite) TestGetForUpdateWhenNotFoundReturnsNotFoundError()
 		Times(1)
 
 	s.mockRow.EXPECT().
-		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgx.ErrNoRows).
 		Times(1)
 
@@ -278,7 +342,7 @@ func (s *RepositoryTestSuite) TestGetForUpdateWhenDatabaseFailsReturnsError() {
 		Times(1)
 
 	s.mockRow.EXPECT().
-		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(dbError).
 		Times(1)
 
@@ -290,23 +354,33 @@ func (s *RepositoryTestSuite) TestGetForUpdateWhenDatabaseFailsReturnsError() {
 
 // Test UpdateBalance - Success Cases
 
-func (s *RepositoryTestSuite) TestUpdateBalanceSucceeds() {
-	newBalance := decimal.NewFromFloat(750.50)
-
+// expectBalanceUpdate expects the balance update to return oldBalance as the replaced balance
+func (s *RepositoryTestSuite) expectBalanceUpdate(accountID int64, newBalance, oldBalance decimal.Decimal) {
 	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), int64(123), newBalance, gomock.Any()).
-		Return(pgconn.NewCommandTag("UPDATE 1"), nil).
+		QueryRow(s.ctx, gomock.Any(), accountID, newBalance, gomock.Any()).
+		Return(s.mockRow).
+		Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*decimal.Decimal) = oldBalance
+			return nil
+		}).
 		Times(1)
+}
+
+func (s *RepositoryTestSuite) TestUpdateBalanceSucceeds() {
+	newBalance := decimal.NewFromFloat(750.50)
+	s.expectBalanceUpdate(123, newBalance, decimal.NewFromFloat(1000))
+	s.expectAudit(auditentities.ActionBalanceChanged)
 
 	err := s.repo.UpdateBalance(s.ctx, s.mockTx, 123, newBalance)
 	s.Nil(err)
 }
 
 func (s *RepositoryTestSuite) TestUpdateBalanceWithZeroSucceeds() {
-	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), int64(456), decimal.Zero, gomock.Any()).
-		Return(pgconn.NewCommandTag("UPDATE 1"), nil).
-		Times(1)
+	s.expectBalanceUpdate(456, decimal.Zero, decimal.NewFromFloat(10))
+	s.expectAudit(auditentities.ActionBalanceChanged)
 
 	err := s.repo.UpdateBalance(s.ctx, s.mockTx, 456, decimal.Zero)
 	s.Nil(err)
@@ -314,89 +388,624 @@ func (s *RepositoryTestSuite) TestUpdateBalanceWithZeroSucceeds() {
 
 func (s *RepositoryTestSuite) TestUpdateBalanceWithHighPrecision() {
 	highPrecisionBalance, _ := decimal.NewFromString("123.45678901")
-
-	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), int64(789), highPrecisionBalance, gomock.Any()).
-		Return(pgconn.NewCommandTag("UPDATE 1"), nil).
-		Times(1)
+	s.expectBalanceUpdate(789, highPrecisionBalance, decimal.Zero)
+	s.expectAudit(auditentities.ActionBalanceChanged)
 
 	err := s.repo.UpdateBalance(s.ctx, s.mockTx, 789, highPrecisionBalance)
 	s.Nil(err)
 }
 
+func (s *RepositoryTestSuite) TestUpdateBalanceRecordsOldAndNewBalances() {
+	s.expectBalanceUpdate(123, decimal.RequireFromString("75.5"), decimal.RequireFromString("100"))
+	s.mockAudit.EXPECT().
+		Record(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, events ...*audit.Event) error {
+			s.Require().Len(events, 1)
+			s.Equal(auditentities.EntityAccount, events[0].EntityType)
+			s.Equal("123", events[0].EntityID)
+			s.JSONEq(`{"balance":"100"}`, string(events[0].Before))
+			s.JSONEq(`{"balance":"75.5"}`, string(events[0].After))
+			return nil
+		}).
+		Times(1)
+
+	s.NoError(s.repo.UpdateBalance(s.ctx, s.mockTx, 123, decimal.RequireFromString("75.5")))
+}
+
 // Test UpdateBalance - Error Cases
 
-func (s *RepositoryTestSuite) TestUpdateBalanceWhenExecFailsReturnsError() {
+func (s *RepositoryTestSuite) TestUpdateBalanceWhenQueryFailsReturnsError() {
 	newBalance := decimal.NewFromFloat(100.00)
 	dbError := errRepoTxAborted
 
 	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), int64(123), newBalance, gomock.Any()).
-		Return(pgconn.CommandTag{}, dbError).
+		QueryRow(s.ctx, gomock.Any(), int64(123), newBalance, gomock.Any()).
+		Return(s.mockRow).
 		Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(dbError).Times(1)
 
 	err := s.repo.UpdateBalance(s.ctx, s.mockTx, 123, newBalance)
 	s.NotNil(err)
 	s.Equal(dbError, err)
 }
 
-// Test Exists - Success Cases
+func (s *RepositoryTestSuite) TestUpdateBalanceWhenAuditFailsReturnsError() {
+	newBalance := decimal.NewFromFloat(100.00)
+	s.expectBalanceUpdate(123, newBalance, decimal.Zero)
+	s.mockAudit.EXPECT().Record(s.ctx, s.mockTx, gomock.Any()).Return(errRepoTxAborted).Times(1)
+
+	s.Equal(errRepoTxAborted, s.repo.UpdateBalance(s.ctx, s.mockTx, 123, newBalance))
+}
+
+// Test NextAccountID
 
-func (s *RepositoryTestSuite) TestExistsWhenAccountExistsReturnsTrue() {
+func (s *RepositoryTestSuite) TestNextAccountIDReturnsSequenceValue() {
 	s.mockPool.EXPECT().
-		QueryRow(s.ctx, gomock.Any(), int64(123)).
+		QueryRow(s.ctx, gomock.Any()).
 		Return(s.mockRow).
 		Times(1)
 
 	s.mockRow.EXPECT().
 		Scan(gomock.Any()).
 		DoAndReturn(func(dest ...any) error {
-			*dest[0].(*bool) = true
+			*dest[0].(*int64) = 77
 			return nil
 		}).
 		Times(1)
 
-	exists, err := s.repo.Exists(s.ctx, 123)
+	accountID, err := s.repo.NextAccountID(s.ctx)
 	s.Nil(err)
-	s.True(exists)
+	s.Equal(int64(77), accountID)
 }
 
-func (s *RepositoryTestSuite) TestExistsWhenAccountDoesNotExistReturnsFalse() {
+func (s *RepositoryTestSuite) TestNextAccountIDWhenQueryFailsReturnsError() {
 	s.mockPool.EXPECT().
-		QueryRow(s.ctx, gomock.Any(), int64(999)).
+		QueryRow(s.ctx, gomock.Any()).
 		Return(s.mockRow).
 		Times(1)
 
 	s.mockRow.EXPECT().
 		Scan(gomock.Any()).
+		Return(errRepoQueryFailed).
+		Times(1)
+
+	accountID, err := s.repo.NextAccountID(s.ctx)
+	s.Equal(errRepoQueryFailed, err)
+	s.Zero(accountID)
+}
+
+// Test GetClearingAccount
+
+func (s *RepositoryTestSuite) TestGetClearingAccountSucceeds() {
+	s.mockPool.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), "EUR", entities.KindClearing).
+		Return(s.mockRow).
+		Times(1)
+
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(func(dest ...any) error {
-			*dest[0].(*bool) = false
+			*dest[0].(*int64) = 9
+			*dest[1].(*decimal.Decimal) = decimal.NewFromInt(-100)
+			*dest[2].(*time.Time) = time.Now().UTC()
+			*dest[3].(*time.Time) = time.Now().UTC()
+			*dest[4].(*string) = "EUR"
+			*dest[5].(*string) = entities.KindClearing
 			return nil
 		}).
 		Times(1)
 
-	exists, err := s.repo.Exists(s.ctx, 999)
+	result, err := s.repo.GetClearingAccount(s.ctx, "EUR")
 	s.Nil(err)
-	s.False(exists)
+	s.Equal(int64(9), result.AccountID)
+	s.Equal("EUR", result.Currency)
+	s.True(result.IsSystem())
 }
 
-// Test Exists - Error Cases
+func (s *RepositoryTestSuite) TestGetClearingAccountWhenMissingReturnsNotFound() {
+	s.mockPool.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), "EUR", entities.KindClearing).
+		Return(s.mockRow).
+		Times(1)
 
-func (s *RepositoryTestSuite) TestExistsWhenDatabaseFailsReturnsError() {
-	dbError := errRepoQueryFailed
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgx.ErrNoRows).
+		Times(1)
+
+	result, err := s.repo.GetClearingAccount(s.ctx, "EUR")
+	s.Nil(result)
+	var appErr *apperror.Error
+	s.True(errors.As(err, &appErr))
+	s.Equal(apperror.CodeNotFound, appErr.Code())
+}
 
+// Test GetInterestExpenseAccount
+
+func (s *RepositoryTestSuite) TestGetInterestExpenseAccountWhenMissingReturnsNotFound() {
 	s.mockPool.EXPECT().
-		QueryRow(s.ctx, gomock.Any(), int64(123)).
+		QueryRow(s.ctx, gomock.Any(), "EUR", entities.KindInterestExpense).
+		Return(s.mockRow).
+		Times(1)
+
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgx.ErrNoRows).
+		Times(1)
+
+	result, err := s.repo.GetInterestExpenseAccount(s.ctx, "EUR")
+	s.Nil(result)
+	var appErr *apperror.Error
+	s.True(errors.As(err, &appErr))
+	s.Equal(apperror.CodeNotFound, appErr.Code())
+	s.Equal(apperror.MsgInterestExpenseNotFound, appErr.PublicMessage())
+}
+
+// Test EnsureSystemAccount
+
+func (s *RepositoryTestSuite) TestEnsureSystemAccountRecordsCreation() {
+	s.expectTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), "USD", entities.KindClearing, entities.GLCategoryAsset).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = 7
+			return nil
+		}).
+		Times(1)
+	s.expectAudit(auditentities.ActionAccountCreated)
+	s.expectCommit()
+
+	s.Nil(s.repo.EnsureSystemAccount(s.ctx, entities.KindClearing, "USD"))
+}
+
+func (s *RepositoryTestSuite) TestEnsureSystemAccountWhenExistingRecordsNothing() {
+	s.expectTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), "USD", entities.KindClearing, entities.GLCategoryAsset).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgx.ErrNoRows).
+		Times(1)
+	s.expectCommit()
+
+	s.Nil(s.repo.EnsureSystemAccount(s.ctx, entities.KindClearing, "USD"))
+}
+
+func (s *RepositoryTestSuite) TestEnsureSystemAccountWhenQueryFailsReturnsError() {
+	s.expectTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), "USD", entities.KindClearing, entities.GLCategoryAsset).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errRepoDBConnectionFailed).
+		Times(1)
+
+	s.Equal(errRepoDBConnectionFailed, s.repo.EnsureSystemAccount(s.ctx, entities.KindClearing, "USD"))
+}
+
+// Test GetBalanceAsOf
+
+func (s *RepositoryTestSuite) TestGetBalanceAsOfReturnsSummedBalance() {
+	asOf := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	s.mockPool.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), int64(123), asOf).
 		Return(s.mockRow).
 		Times(1)
 
 	s.mockRow.EXPECT().
 		Scan(gomock.Any()).
-		Return(dbError).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*decimal.Decimal) = decimal.RequireFromString("42.125")
+			return nil
+		}).
 		Times(1)
 
-	exists, err := s.repo.Exists(s.ctx, 123)
-	s.NotNil(err)
-	s.False(exists)
-	s.Equal(dbError, err)
+	balance, err := s.repo.GetBalanceAsOf(s.ctx, 123, asOf)
+	s.Nil(err)
+	s.True(balance.Equal(decimal.RequireFromString("42.125")))
+}
+
+func (s *RepositoryTestSuite) TestGetBalanceAsOfWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), int64(123), gomock.Any()).
+		Return(s.mockRow).
+		Times(1)
+
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		Return(errRepoQueryFailed).
+		Times(1)
+
+	balance, err := s.repo.GetBalanceAsOf(s.ctx, 123, time.Now())
+	s.Equal(errRepoQueryFailed, err)
+	s.True(balance.IsZero())
+}
+
+func (s *RepositoryTestSuite) TestGetBalanceAsOfTxQueriesWithinTransaction() {
+	asOf := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	s.mockTx.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), int64(123), asOf).
+		Return(s.mockRow).
+		Times(1)
+
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*decimal.Decimal) = decimal.RequireFromString("-7.5")
+			return nil
+		}).
+		Times(1)
+
+	balance, err := s.repo.GetBalanceAsOfTx(s.ctx, s.mockTx, 123, asOf)
+	s.Nil(err)
+	s.True(balance.Equal(decimal.RequireFromString("-7.5")))
+}
+
+// Test hierarchy
+
+func (s *RepositoryTestSuite) TestCreateTxInsertsWithinTransaction() {
+	parentID := int64(10)
+	acc := &account.Account{AccountID: 11, Balance: decimal.Zero, Currency: entities.DefaultCurrency, ParentAccountID: &parentID}
+
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), int64(11), acc.Balance, gomock.Any(), gomock.Any(), acc.Currency, entities.KindCustomer, &parentID, entities.GLCategoryLiability).
+		Return(pgconn.NewCommandTag("INSERT 0 0"), nil).
+		Times(1)
+	s.expectAudit(auditentities.ActionAccountCreated)
+
+	s.NoError(s.repo.CreateTx(s.ctx, s.mockTx, acc))
+}
+
+func (s *RepositoryTestSuite) TestBeginTxUsesReadCommitted() {
+	s.mockPool.EXPECT().
+		BeginTx(s.ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+		Return(s.mockTx, nil).
+		Times(1)
+
+	tx, err := s.repo.BeginTx(s.ctx)
+	s.NoError(err)
+	s.Equal(s.mockTx, tx)
+}
+
+func (s *RepositoryTestSuite) TestHasChildrenReturnsExists() {
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), int64(10)).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*bool) = true
+			return nil
+		}).
+		Times(1)
+
+	hasChildren, err := s.repo.HasChildren(s.ctx, s.mockTx, 10)
+	s.NoError(err)
+	s.True(hasChildren)
+}
+
+func (s *RepositoryTestSuite) TestHasChildrenWhenQueryFailsReturnsError() {
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), int64(10)).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(errRepoQueryFailed).Times(1)
+
+	hasChildren, err := s.repo.HasChildren(s.ctx, s.mockTx, 10)
+	s.Equal(errRepoQueryFailed, err)
+	s.False(hasChildren)
+}
+
+func (s *RepositoryTestSuite) TestListChildrenScansEachRow() {
+	mockRows := dbmock.NewMockRows(s.ctrl)
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), int64(10)).Return(mockRows, nil).Times(1)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	ids := []int64{11, 12}
+	parentID := int64(10)
+	call := 0
+	mockRows.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = ids[call]
+			*dest[6].(**int64) = &parentID
+			call++
+			return nil
+		}).
+		Times(2)
+	mockRows.EXPECT().Err().Return(nil).Times(1)
+	mockRows.EXPECT().Close().Times(1)
+
+	children, err := s.repo.ListChildren(s.ctx, 10)
+	s.NoError(err)
+	s.Len(children, 2)
+	s.Equal(int64(12), children[1].AccountID)
+	s.Equal(int64(10), *children[1].ParentAccountID)
+}
+
+func (s *RepositoryTestSuite) TestListChildrenWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), int64(10)).Return(nil, errRepoQueryFailed).Times(1)
+
+	children, err := s.repo.ListChildren(s.ctx, 10)
+	s.Equal(errRepoQueryFailed, err)
+	s.Nil(children)
+}
+
+func (s *RepositoryTestSuite) TestGetRollupBalanceReturnsSum() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any(), int64(10)).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*decimal.Decimal) = decimal.RequireFromString("175.25")
+			return nil
+		}).
+		Times(1)
+
+	balance, err := s.repo.GetRollupBalance(s.ctx, 10)
+	s.NoError(err)
+	s.True(balance.Equal(decimal.RequireFromString("175.25")))
+}
+
+func (s *RepositoryTestSuite) TestLockHierarchyTakesAdvisoryLock() {
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), entities.HierarchyLockKey).
+		Return(pgconn.NewCommandTag("SELECT 1"), nil).
+		Times(1)
+
+	s.NoError(s.repo.LockHierarchy(s.ctx, s.mockTx))
+}
+
+func (s *RepositoryTestSuite) TestIsInSubtreePassesRootThenCandidate() {
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), int64(10), int64(30)).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*bool) = true
+			return nil
+		}).
+		Times(1)
+
+	inSubtree, err := s.repo.IsInSubtree(s.ctx, s.mockTx, 10, 30)
+	s.NoError(err)
+	s.True(inSubtree)
+}
+
+func (s *RepositoryTestSuite) TestSetParentUpdatesParent() {
+	parentID := int64(10)
+	s.mockTx.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), int64(20), &parentID, gomock.Any()).
+		Return(s.mockRow).
+		Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(nil).Times(1)
+	s.expectAudit(auditentities.ActionAccountMoved)
+
+	s.NoError(s.repo.SetParent(s.ctx, s.mockTx, 20, &parentID))
+}
+
+func (s *RepositoryTestSuite) TestSetParentWhenQueryFailsReturnsError() {
+	s.mockTx.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), int64(20), gomock.Any(), gomock.Any()).
+		Return(s.mockRow).
+		Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(errRepoQueryFailed).Times(1)
+
+	s.Equal(errRepoQueryFailed, s.repo.SetParent(s.ctx, s.mockTx, 20, nil))
+}
+
+// Test SetMinimumBalance
+
+func (s *RepositoryTestSuite) TestSetMinimumBalanceReturnsUpdatedAccount() {
+	minimum := decimal.RequireFromString("100")
+	s.expectTx()
+	lockedRow := dbmock.NewMockRow(s.ctrl)
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), int64(20)).Return(lockedRow).Times(1)
+	lockedRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = 20
+			*dest[7].(*decimal.Decimal) = decimal.RequireFromString("25")
+			return nil
+		}).
+		Times(1)
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), int64(20), minimum, gomock.Any()).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = 20
+			*dest[7].(*decimal.Decimal) = minimum
+			return nil
+		}).
+		Times(1)
+	s.mockAudit.EXPECT().
+		Record(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, events ...*audit.Event) error {
+			s.Require().Len(events, 1)
+			s.Equal(auditentities.ActionMinBalanceSet, events[0].Action)
+			s.JSONEq(`{"minimum_balance":"25"}`, string(events[0].Before))
+			s.JSONEq(`{"minimum_balance":"100"}`, string(events[0].After))
+			return nil
+		}).
+		Times(1)
+	s.expectCommit()
+
+	acc, err := s.repo.SetMinimumBalance(s.ctx, 20, minimum)
+	s.NoError(err)
+	s.True(acc.MinimumBalance.Equal(minimum))
+}
+
+func (s *RepositoryTestSuite) TestSetMinimumBalanceWhenMissingReturnsNotFound() {
+	s.expectTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), int64(20)).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgx.ErrNoRows).
+		Times(1)
+
+	acc, err := s.repo.SetMinimumBalance(s.ctx, 20, decimal.Zero)
+	s.Nil(acc)
+
+	var appErr *apperror.Error
+	s.True(errors.As(err, &appErr))
+	s.Equal(apperror.CodeNotFound, appErr.Code())
+}
+
+// Test CreateBatch
+
+func (s *RepositoryTestSuite) TestCreateBatchReturnsInsertedIDs() {
+	mockRows := dbmock.NewMockRows(s.ctrl)
+	parentID := int64(10)
+	accounts := []*account.Account{
+		{AccountID: 1, Balance: decimal.RequireFromString("10.5"), Currency: "USD"},
+		{AccountID: 2, Balance: decimal.Zero, Currency: "EUR", ParentAccountID: &parentID},
+	}
+
+	s.mockTx.EXPECT().
+		Query(s.ctx, gomock.Any(), []int64{1, 2}, []string{"10.5", "0"}, []string{"USD", "EUR"}, []*int64{nil, &parentID}, gomock.Any()).
+		Return(mockRows, nil).
+		Times(1)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	mockRows.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = 2
+			return nil
+		}).
+		Times(1)
+	mockRows.EXPECT().Err().Return(nil).Times(1)
+	mockRows.EXPECT().Close().Times(1)
+	s.mockAudit.EXPECT().
+		Record(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, events ...*audit.Event) error {
+			s.Require().Len(events, 1)
+			s.Equal("2", events[0].EntityID)
+			return nil
+		}).
+		Times(1)
+
+	inserted, err := s.repo.CreateBatch(s.ctx, s.mockTx, accounts)
+	s.NoError(err)
+	s.Equal(map[int64]bool{2: true}, inserted)
+	s.Equal(entities.KindCustomer, accounts[0].Kind)
+	s.False(accounts[0].CreatedAt.IsZero())
+}
+
+func (s *RepositoryTestSuite) TestCreateBatchWhenQueryFailsReturnsError() {
+	s.mockTx.EXPECT().
+		Query(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errRepoQueryFailed).
+		Times(1)
+
+	inserted, err := s.repo.CreateBatch(s.ctx, s.mockTx, []*account.Account{{AccountID: 1}})
+	s.Equal(errRepoQueryFailed, err)
+	s.Nil(inserted)
+}
+
+// Test NextAccountIDs
+
+func (s *RepositoryTestSuite) TestNextAccountIDsReturnsSequenceValues() {
+	mockRows := dbmock.NewMockRows(s.ctrl)
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), 2).Return(mockRows, nil).Times(1)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	next := int64(41)
+	mockRows.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			next++
+			*dest[0].(*int64) = next
+			return nil
+		}).
+		Times(2)
+	mockRows.EXPECT().Err().Return(nil).Times(1)
+	mockRows.EXPECT().Close().Times(1)
+
+	ids, err := s.repo.NextAccountIDs(s.ctx, 2)
+	s.NoError(err)
+	s.Equal([]int64{42, 43}, ids)
+}
+
+// Test CreateAlias
+
+func (s *RepositoryTestSuite) TestCreateAliasRecordsCreation() {
+	s.expectTx()
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), "@alice", int64(20), entities.AliasKindHandle, gomock.Any()).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
+		Times(1)
+	s.expectAudit(auditentities.ActionAliasCreated)
+	s.expectCommit()
+
+	s.NoError(s.repo.CreateAlias(s.ctx, &account.Alias{Alias: "@alice", AccountID: 20, Kind: entities.AliasKindHandle}))
+}
+
+func (s *RepositoryTestSuite) TestCreateAliasWhenTakenReturnsConflict() {
+	s.expectTx()
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), "@alice", int64(20), entities.AliasKindHandle, gomock.Any()).
+		Return(pgconn.CommandTag{}, &pgconn.PgError{Code: "23505"}).
+		Times(1)
+
+	err := s.repo.CreateAlias(s.ctx, &account.Alias{Alias: "@alice", AccountID: 20, Kind: entities.AliasKindHandle})
+
+	var appErr *apperror.Error
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(apperror.CodeConflict, appErr.Code())
+	s.Equal(apperror.MsgAliasTaken, appErr.PublicMessage())
+}
+
+// Test ResolveAlias
+
+func (s *RepositoryTestSuite) TestResolveAliasMatchesCanonicalForm() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any(), "GB82WEST12345698765432").Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = 20
+			return nil
+		}).
+		Times(1)
+
+	accountID, err := s.repo.ResolveAlias(s.ctx, "gb82 west 1234 5698 7654 32")
+	s.NoError(err)
+	s.Equal(int64(20), accountID)
+}
+
+func (s *RepositoryTestSuite) TestResolveAliasWithBadCheckDigitsSkipsLookup() {
+	accountID, err := s.repo.ResolveAlias(s.ctx, "GB83 WEST 1234 5698 7654 32")
+	s.Equal(int64(0), accountID)
+
+	var appErr *apperror.Error
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(apperror.CodeNotFound, appErr.Code())
+}
+
+func (s *RepositoryTestSuite) TestResolveAliasMatchesBareHandle() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any(), "@alice").Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = 20
+			return nil
+		}).
+		Times(1)
+
+	accountID, err := s.repo.ResolveAlias(s.ctx, "Alice")
+	s.NoError(err)
+	s.Equal(int64(20), accountID)
+}
+
+func (s *RepositoryTestSuite) TestResolveAliasWhenMissingReturnsNotFound() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any(), "@nobody").Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows).Times(1)
+
+	_, err := s.repo.ResolveAlias(s.ctx, "@Nobody")
+
+	var appErr *apperror.Error
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(apperror.CodeNotFound, appErr.Code())
 }
@@ -1,9 +1,15 @@
 package account
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/internal-transfers-service/internal/constants"
@@ -26,7 +32,18 @@ func NewHTTPHandler(core ICore) *HTTPHandler {
 // RegisterRoutes registers the account routes with the router
 func (h *HTTPHandler) RegisterRoutes(r chi.Router) {
 	r.Post(entities.RouteAccounts, h.CreateAccount)
+	r.Post(entities.RouteAccountsBulk, h.BulkCreateAccounts)
 	r.Get(entities.RouteAccountByID, h.GetAccount)
+	r.Get(entities.RouteAccountBalance, h.GetBalance)
+	r.Get(entities.RouteAccountChildren, h.ListChildren)
+	r.Put(entities.RouteAccountParent, h.MoveAccount)
+	r.Post(entities.RouteAccountAliases, h.CreateAlias)
+	r.Get(entities.RouteAccountAliases, h.ListAliases)
+}
+
+// RegisterAdminRoutes registers the administrative account routes.
+func (h *HTTPHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Put(entities.RouteMinimumBalance, h.SetMinimumBalance)
 }
 
 // CreateAccount handles POST /accounts
@@ -39,19 +56,131 @@ func (h *HTTPHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if appErr := h.core.Create(ctx, &req); appErr != nil {
+	response, appErr := h.core.Create(ctx, &req)
+	if appErr != nil {
 		h.writeErrorWithContext(w, r, appErr)
 		return
 	}
 
 	logger.Ctx(ctx).Infow(constants.LogMsgAccountCreatedViaHTTP,
-		constants.LogKeyAccountID, req.AccountID,
+		constants.LogKeyAccountID, response.AccountID,
 	)
 
-	w.WriteHeader(http.StatusCreated)
+	h.writeJSON(w, http.StatusCreated, response)
 }
 
-// GetAccount handles GET /accounts/{accountID}
+// BulkCreateAccounts handles POST /accounts/bulk[?mode=best_effort].
+// The body is a JSON array of create requests, or CSV with a header row when sent as text/csv.
+// Responds 201 when every row was created, 207 when only some were and 422 when none were.
+func (h *HTTPHandler) BulkCreateAccounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := entities.BulkCreateAccountsRequest{Mode: r.URL.Query().Get(entities.QueryParamMode)}
+	if strings.HasPrefix(r.Header.Get(constants.HeaderContentType), constants.ContentTypeCSVPrefix) {
+		accounts, appErr := parseAccountsCSV(r.Body)
+		if appErr != nil {
+			h.writeErrorWithContext(w, r, appErr)
+			return
+		}
+		req.Accounts = accounts
+	} else if err := json.NewDecoder(r.Body).Decode(&req.Accounts); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	response, appErr := h.core.BulkCreate(ctx, &req)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	status := http.StatusCreated
+	switch {
+	case response.Created == 0:
+		status = http.StatusUnprocessableEntity
+	case response.Created < len(response.Results):
+		status = http.StatusMultiStatus
+	}
+	h.writeJSON(w, status, response)
+}
+
+// parseAccountsCSV reads bulk create rows from CSV. Columns are matched by header name;
+// a value that cannot be parsed rejects the whole upload, as malformed JSON would.
+func parseAccountsCSV(body io.Reader) ([]entities.CreateAccountRequest, apperror.IError) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidCSV)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, dup := columns[name]; dup || !slices.Contains(entities.CSVColumns, name) {
+			return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidCSV, apperror.MsgInvalidCSV).
+				WithField(apperror.FieldColumn, name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns[entities.CSVColumnInitialBalance]; !ok {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidCSV, apperror.MsgInvalidCSV).
+			WithField(apperror.FieldColumn, entities.CSVColumnInitialBalance)
+	}
+
+	accounts := make([]entities.CreateAccountRequest, 0)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return accounts, nil
+		}
+		if err != nil {
+			return nil, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidCSV).
+				WithField(apperror.FieldRow, row)
+		}
+
+		req, column, err := parseAccountRecord(record, columns)
+		if err != nil {
+			return nil, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidCSV).
+				WithField(apperror.FieldRow, row).
+				WithField(apperror.FieldColumn, column)
+		}
+		accounts = append(accounts, req)
+	}
+}
+
+// parseAccountRecord maps one CSV record to a create request, returning the offending
+// column on error. Empty cells are treated as omitted fields.
+func parseAccountRecord(record []string, columns map[string]int) (entities.CreateAccountRequest, string, error) {
+	req := entities.CreateAccountRequest{
+		InitialBalance: record[columns[entities.CSVColumnInitialBalance]],
+	}
+	if i, ok := columns[entities.CSVColumnCurrency]; ok {
+		req.Currency = record[i]
+	}
+
+	if i, ok := columns[entities.CSVColumnAccountID]; ok && record[i] != "" {
+		accountID, err := strconv.ParseInt(record[i], 10, 64)
+		if err != nil {
+			return req, entities.CSVColumnAccountID, err
+		}
+		req.AccountID = accountID
+	}
+
+	if i, ok := columns[entities.CSVColumnParentAccountID]; ok && record[i] != "" {
+		parentID, err := strconv.ParseInt(record[i], 10, 64)
+		if err != nil {
+			return req, entities.CSVColumnParentAccountID, err
+		}
+		req.ParentAccountID = &parentID
+	}
+
+	return req, "", nil
+}
+
+// GetAccount handles GET /accounts/{accountID}[?rollup=true].
+// With rollup=true the response includes the balance summed across all descendants.
 func (h *HTTPHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -63,7 +192,181 @@ func (h *HTTPHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, appErr := h.core.GetByID(ctx, accountID)
+	getAccount := h.core.GetByID
+	if r.URL.Query().Get(entities.QueryParamRollup) == "true" {
+		getAccount = h.core.GetWithRollup
+	}
+
+	response, appErr := getAccount(ctx, accountID)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// ListChildren handles GET /accounts/{accountID}/children
+func (h *HTTPHandler) ListChildren(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountIDStr := chi.URLParam(r, entities.ParamAccountID)
+	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountIDStr))
+		return
+	}
+
+	response, appErr := h.core.ListChildren(ctx, accountID)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// MoveAccount handles PUT /accounts/{accountID}/parent.
+// parent_account_id must be present; an explicit null makes the account a root,
+// so that a malformed body cannot detach an account by accident.
+func (h *HTTPHandler) MoveAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountIDStr := chi.URLParam(r, entities.ParamAccountID)
+	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountIDStr))
+		return
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	parentJSON, ok := body[entities.FieldParentAccountID]
+	if !ok {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrParentRequired, apperror.MsgParentRequired))
+		return
+	}
+
+	var req entities.MoveAccountRequest
+	if err := json.Unmarshal(parentJSON, &req.ParentAccountID); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	response, appErr := h.core.Move(ctx, accountID, &req)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// GetBalance handles GET /accounts/{accountID}/balance?as_of=<RFC3339>.
+// Without as_of, the balance is computed as of now.
+func (h *HTTPHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountIDStr := chi.URLParam(r, entities.ParamAccountID)
+	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountIDStr))
+		return
+	}
+
+	asOf := time.Now().UTC()
+	if asOfStr := r.URL.Query().Get(entities.QueryParamAsOf); asOfStr != "" {
+		asOf, err = time.Parse(time.RFC3339Nano, asOfStr)
+		if err != nil {
+			h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAsOf, apperror.MsgInvalidAsOf).
+				WithField(apperror.FieldAsOf, asOfStr))
+			return
+		}
+	}
+
+	response, appErr := h.core.GetBalanceAsOf(ctx, accountID, asOf)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// SetMinimumBalance handles PUT /admin/accounts/{accountID}/minimum-balance
+func (h *HTTPHandler) SetMinimumBalance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountIDStr := chi.URLParam(r, entities.ParamAccountID)
+	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountIDStr))
+		return
+	}
+
+	var req entities.SetMinimumBalanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	response, appErr := h.core.SetMinimumBalance(ctx, accountID, &req)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// CreateAlias handles POST /accounts/{accountID}/aliases
+func (h *HTTPHandler) CreateAlias(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountIDStr := chi.URLParam(r, entities.ParamAccountID)
+	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountIDStr))
+		return
+	}
+
+	var req entities.CreateAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	response, appErr := h.core.CreateAlias(ctx, accountID, &req)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, response)
+}
+
+// ListAliases handles GET /accounts/{accountID}/aliases
+func (h *HTTPHandler) ListAliases(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountIDStr := chi.URLParam(r, entities.ParamAccountID)
+	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountIDStr))
+		return
+	}
+
+	response, appErr := h.core.ListAliases(ctx, accountID)
 	if appErr != nil {
 		h.writeErrorWithContext(w, r, appErr)
 		return
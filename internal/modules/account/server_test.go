@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/config"
 	"github.com/internal-transfers-service/internal/constants"
 	"github.com/internal-transfers-service/internal/modules/account"
 	"github.com/internal-transfers-service/internal/modules/account/entities"
@@ -60,7 +62,7 @@ func (s *ServerTestSuite) TestRegisterRoutesAddsRoutes() {
 
 	s.mockCore.EXPECT().
 		Create(gomock.Any(), expectedRequest).
-		Return(nil).
+		Return(&entities.AccountResponse{AccountID: 1, Balance: "100"}, nil).
 		Times(1)
 
 	body := `{"account_id": 1, "initial_balance": "100.00"}`
@@ -81,7 +83,7 @@ func (s *ServerTestSuite) TestCreateAccountSuccessReturnsCreated() {
 			AccountID:      int64(123),
 			InitialBalance: "500.00",
 		}).
-		Return(nil).
+		Return(&entities.AccountResponse{AccountID: 123, Balance: "500"}, nil).
 		Times(1)
 
 	body := `{"account_id": 123, "initial_balance": "500.00"}`
@@ -92,6 +94,35 @@ func (s *ServerTestSuite) TestCreateAccountSuccessReturnsCreated() {
 	s.router.ServeHTTP(rec, req)
 
 	s.Equal(http.StatusCreated, rec.Code)
+
+	var response entities.AccountResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	s.NoError(err)
+	s.Equal(int64(123), response.AccountID)
+}
+
+func (s *ServerTestSuite) TestCreateAccountWithoutIDReturnsGeneratedID() {
+	s.mockCore.EXPECT().
+		Create(gomock.Any(), &entities.CreateAccountRequest{
+			InitialBalance: "25.00",
+		}).
+		Return(&entities.AccountResponse{AccountID: 42, Balance: "25"}, nil).
+		Times(1)
+
+	body := `{"initial_balance": "25.00"}`
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(body))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+
+	var response entities.AccountResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	s.NoError(err)
+	s.Equal(int64(42), response.AccountID)
+	s.Equal("25", response.Balance)
 }
 
 func (s *ServerTestSuite) TestCreateAccountWithInvalidJSONReturnsBadRequest() {
@@ -119,7 +150,7 @@ func (s *ServerTestSuite) TestCreateAccountWhenCoreReturnsErrorReturnsError() {
 
 	s.mockCore.EXPECT().
 		Create(gomock.Any(), expectedRequest).
-		Return(coreError).
+		Return(nil, coreError).
 		Times(1)
 
 	body := `{"account_id": 123, "initial_balance": "100.00"}`
@@ -208,6 +239,393 @@ func (s *ServerTestSuite) TestGetAccountWithZeroBalanceReturnsCorrectBalance() {
 	s.Equal("0", response.Balance)
 }
 
+// GetBalance Tests
+
+func (s *ServerTestSuite) TestGetBalanceWithAsOfReturnsBalance() {
+	asOf := time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	s.mockCore.EXPECT().
+		GetBalanceAsOf(gomock.Any(), int64(123), asOf).
+		Return(&entities.BalanceResponse{
+			AccountID: 123,
+			Balance:   "75.5",
+			Currency:  "USD",
+			AsOf:      asOf.Format(time.RFC3339Nano),
+		}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/123/balance?as_of=2026-01-31T23:59:59Z", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+
+	var response entities.BalanceResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal("75.5", response.Balance)
+	s.Equal("2026-01-31T23:59:59Z", response.AsOf)
+}
+
+func (s *ServerTestSuite) TestGetBalanceWithoutAsOfUsesNow() {
+	before := time.Now()
+
+	s.mockCore.EXPECT().
+		GetBalanceAsOf(gomock.Any(), int64(123), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, asOf time.Time) (*entities.BalanceResponse, apperror.IError) {
+			s.False(asOf.Before(before.Truncate(time.Second)))
+			return &entities.BalanceResponse{AccountID: 123, Balance: "1"}, nil
+		}).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/123/balance", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *ServerTestSuite) TestGetBalanceWithInvalidAsOfReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodGet, "/accounts/123/balance?as_of=yesterday", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+
+	var response apperror.ErrorResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal(apperror.MsgInvalidAsOf, response.Error)
+}
+
+func (s *ServerTestSuite) TestGetBalanceWithInvalidIDReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodGet, "/accounts/abc/balance", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+// Sub-account Tests
+
+func (s *ServerTestSuite) TestGetAccountWithRollupReturnsRollupBalance() {
+	s.mockCore.EXPECT().
+		GetWithRollup(gomock.Any(), int64(10)).
+		Return(&entities.AccountResponse{AccountID: 10, Balance: "0", Currency: "USD", RollupBalance: "175.25"}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/10?rollup=true", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+
+	var response entities.AccountResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal("175.25", response.RollupBalance)
+}
+
+func (s *ServerTestSuite) TestListChildrenReturnsChildren() {
+	parentID := int64(10)
+	s.mockCore.EXPECT().
+		ListChildren(gomock.Any(), parentID).
+		Return(&entities.ChildrenResponse{
+			AccountID: parentID,
+			Children:  []*entities.AccountResponse{{AccountID: 11, Balance: "25", Currency: "USD", MinimumBalance: "0", GLCategory: entities.GLCategoryLiability, ParentAccountID: &parentID}},
+		}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/10/children", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.JSONEq(`{"account_id":10,"children":[{"account_id":11,"balance":"25","currency":"USD","minimum_balance":"0","gl_category":"liability","parent_account_id":10}]}`, rec.Body.String())
+}
+
+func (s *ServerTestSuite) TestListChildrenWithInvalidIDReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodGet, "/accounts/abc/children", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestMoveAccountPassesParent() {
+	parentID := int64(10)
+	s.mockCore.EXPECT().
+		Move(gomock.Any(), int64(20), &entities.MoveAccountRequest{ParentAccountID: &parentID}).
+		Return(&entities.AccountResponse{AccountID: 20, Balance: "5", Currency: "USD", ParentAccountID: &parentID}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodPut, "/accounts/20/parent", bytes.NewBufferString(`{"parent_account_id": 10}`))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *ServerTestSuite) TestMoveAccountWithNullParentDetaches() {
+	s.mockCore.EXPECT().
+		Move(gomock.Any(), int64(20), &entities.MoveAccountRequest{}).
+		Return(&entities.AccountResponse{AccountID: 20, Balance: "5", Currency: "USD"}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodPut, "/accounts/20/parent", bytes.NewBufferString(`{"parent_account_id": null}`))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *ServerTestSuite) TestMoveAccountWithoutParentFieldReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodPut, "/accounts/20/parent", bytes.NewBufferString(`{}`))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+
+	var response apperror.ErrorResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal(apperror.MsgParentRequired, response.Error)
+}
+
+func (s *ServerTestSuite) TestMoveAccountWhenCycleReturnsConflict() {
+	s.mockCore.EXPECT().
+		Move(gomock.Any(), int64(10), gomock.Any()).
+		Return(nil, apperror.NewWithMessage(apperror.CodeConflict, account.ErrHierarchyCycle, apperror.MsgHierarchyCycle)).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodPut, "/accounts/10/parent", bytes.NewBufferString(`{"parent_account_id": 30}`))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusConflict, rec.Code)
+}
+
+// Bulk Create Tests
+
+func (s *ServerTestSuite) TestBulkCreateAccountsFromJSONReturnsCreated() {
+	s.mockCore.EXPECT().
+		BulkCreate(gomock.Any(), &entities.BulkCreateAccountsRequest{
+			Mode: entities.BulkModeBestEffort,
+			Accounts: []entities.CreateAccountRequest{
+				{AccountID: 1, InitialBalance: "10"},
+				{InitialBalance: "5", Currency: "EUR"},
+			},
+		}).
+		Return(&entities.BulkCreateResponse{
+			Mode:    entities.BulkModeBestEffort,
+			Created: 2,
+			Results: []entities.BulkCreateResult{
+				{Row: 1, Status: entities.BulkStatusCreated, AccountID: 1},
+				{Row: 2, Status: entities.BulkStatusCreated, AccountID: 2},
+			},
+		}, nil).
+		Times(1)
+
+	body := `[{"account_id": 1, "initial_balance": "10"}, {"initial_balance": "5", "currency": "EUR"}]`
+	req := httptest.NewRequest(http.MethodPost, "/accounts/bulk?mode=best_effort", bytes.NewBufferString(body))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+}
+
+func (s *ServerTestSuite) TestBulkCreateAccountsFromCSVParsesColumnsByName() {
+	parentID := int64(10)
+	s.mockCore.EXPECT().
+		BulkCreate(gomock.Any(), &entities.BulkCreateAccountsRequest{
+			Accounts: []entities.CreateAccountRequest{
+				{AccountID: 11, InitialBalance: "10.50", Currency: "USD", ParentAccountID: &parentID},
+				{InitialBalance: "0", Currency: "EUR"},
+			},
+		}).
+		Return(&entities.BulkCreateResponse{
+			Created: 1,
+			Failed:  1,
+			Results: []entities.BulkCreateResult{{Row: 1}, {Row: 2}},
+		}, nil).
+		Times(1)
+
+	body := "\ufeffcurrency,initial_balance,account_id,parent_account_id\nUSD,10.50,11,10\nEUR,0,,\n"
+	req := httptest.NewRequest(http.MethodPost, "/accounts/bulk", bytes.NewBufferString(body))
+	req.Header.Set(constants.HeaderContentType, "text/csv")
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusMultiStatus, rec.Code)
+}
+
+func (s *ServerTestSuite) TestBulkCreateAccountsWithNothingCreatedReturnsUnprocessable() {
+	s.mockCore.EXPECT().
+		BulkCreate(gomock.Any(), gomock.Any()).
+		Return(&entities.BulkCreateResponse{
+			Failed:  1,
+			Results: []entities.BulkCreateResult{{Row: 1, Status: entities.BulkStatusFailed}},
+		}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts/bulk", bytes.NewBufferString(`[{"initial_balance": "-1"}]`))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusUnprocessableEntity, rec.Code)
+}
+
+func (s *ServerTestSuite) TestBulkCreateAccountsWithMalformedCSVReturnsBadRequest() {
+	cases := map[string]string{
+		"unknown column":          "account_id,initial_balance,nickname\n1,10,bob\n",
+		"missing initial_balance": "account_id,currency\n1,USD\n",
+		"non-numeric account_id":  "account_id,initial_balance\none,10\n",
+		"ragged row":              "account_id,initial_balance\n1,10,extra\n",
+		"empty upload":            "",
+	}
+	for name, body := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/accounts/bulk", bytes.NewBufferString(body))
+		req.Header.Set(constants.HeaderContentType, "text/csv; charset=utf-8")
+		rec := httptest.NewRecorder()
+
+		s.router.ServeHTTP(rec, req)
+
+		s.Equal(http.StatusBadRequest, rec.Code, name)
+
+		var response apperror.ErrorResponse
+		s.NoError(json.NewDecoder(rec.Body).Decode(&response), name)
+		s.Equal(apperror.MsgInvalidCSV, response.Error, name)
+	}
+}
+
+func (s *ServerTestSuite) TestBulkCreateAccountsWithInvalidJSONReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodPost, "/accounts/bulk", bytes.NewBufferString(`{"account_id": 1}`))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+// Admin Tests
+
+func (s *ServerTestSuite) TestSetMinimumBalanceReturnsAccount() {
+	router := chi.NewRouter()
+	s.handler.RegisterAdminRoutes(router)
+
+	s.mockCore.EXPECT().
+		SetMinimumBalance(gomock.Any(), int64(20), &entities.SetMinimumBalanceRequest{MinimumBalance: "100.00"}).
+		Return(&entities.AccountResponse{AccountID: 20, Balance: "500", Currency: "USD", MinimumBalance: "100"}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodPut, "/accounts/20/minimum-balance", bytes.NewBufferString(`{"minimum_balance": "100.00"}`))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+
+	var response entities.AccountResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal("100", response.MinimumBalance)
+}
+
+func (s *ServerTestSuite) TestSetMinimumBalanceIsNotOnPublicRoutes() {
+	req := httptest.NewRequest(http.MethodPut, "/accounts/20/minimum-balance", bytes.NewBufferString(`{"minimum_balance": "100.00"}`))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusNotFound, rec.Code)
+}
+
+func (s *ServerTestSuite) TestSetMinimumBalanceWithInvalidJSONReturnsBadRequest() {
+	router := chi.NewRouter()
+	s.handler.RegisterAdminRoutes(router)
+
+	req := httptest.NewRequest(http.MethodPut, "/accounts/20/minimum-balance", bytes.NewBufferString(`{`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+// TestCreateAliasReturnsCreated verifies the alias request is passed to the core
+func (s *ServerTestSuite) TestCreateAliasReturnsCreated() {
+	s.mockCore.EXPECT().
+		CreateAlias(gomock.Any(), int64(20), &entities.CreateAliasRequest{Kind: entities.AliasKindHandle, Alias: "alice"}).
+		Return(&entities.AliasResponse{Alias: "@alice", Kind: entities.AliasKindHandle, AccountID: 20}, nil).
+		Times(1)
+
+	body := `{"kind":"handle","alias":"alice"}`
+	req := httptest.NewRequest(http.MethodPost, "/accounts/20/aliases", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+
+	var response entities.AliasResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal("@alice", response.Alias)
+}
+
+// TestCreateAliasWithInvalidJSONReturnsBadRequest verifies malformed bodies are rejected
+func (s *ServerTestSuite) TestCreateAliasWithInvalidJSONReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodPost, "/accounts/20/aliases", bytes.NewBufferString("{"))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+// TestListAliasesReturnsOK verifies the aliases of an account are listed
+func (s *ServerTestSuite) TestListAliasesReturnsOK() {
+	s.mockCore.EXPECT().
+		ListAliases(gomock.Any(), int64(20)).
+		Return(&entities.AliasesResponse{AccountID: 20, Aliases: []*entities.AliasResponse{{Alias: "@alice"}}}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/20/aliases", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+
+	var response entities.AliasesResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Len(response.Aliases, 1)
+}
+
+// TestListAliasesWithInvalidIDReturnsBadRequest verifies account ID parsing
+func (s *ServerTestSuite) TestListAliasesWithInvalidIDReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodGet, "/accounts/abc/aliases", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
 // InitTestSuite contains tests for account module initialization
 type InitTestSuite struct {
 	suite.Suite
@@ -248,7 +666,7 @@ func (s *InitTestSuite) TestNewCoreCreatesCore() {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockIRepository(ctrl)
-	core := account.NewCore(s.ctx, mockRepo)
+	core := account.NewCore(s.ctx, mockRepo, config.AccountConfig{IDGenerator: entities.IDGeneratorSequence})
 
 	s.NotNil(core)
 }
@@ -260,7 +678,7 @@ func (s *InitTestSuite) TestGetCoreReturnsSingleton() {
 
 	mockRepo := mock.NewMockIRepository(ctrl)
 
-	core1 := account.NewCore(s.ctx, mockRepo)
+	core1 := account.NewCore(s.ctx, mockRepo, config.AccountConfig{IDGenerator: entities.IDGeneratorRandom})
 	core2 := account.GetCore()
 
 	s.Equal(core1, core2)
@@ -0,0 +1,336 @@
+package alert
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/account"
+	"github.com/internal-transfers-service/internal/modules/alert/entities"
+	"github.com/internal-transfers-service/internal/modules/transaction"
+	txEntities "github.com/internal-transfers-service/internal/modules/transaction/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/shopspring/decimal"
+)
+
+// Domain errors
+var (
+	ErrInvalidRuleKind    = errors.New(entities.ErrMsgInvalidRuleKind)
+	ErrInvalidThreshold   = errors.New(entities.ErrMsgInvalidThreshold)
+	ErrInvalidRuleID      = errors.New(entities.ErrMsgInvalidRuleID)
+	ErrRuleNotFound       = errors.New(entities.ErrMsgRuleNotFound)
+	ErrTooManyRules       = errors.New(entities.ErrMsgTooManyRules)
+	ErrInvalidAccountID   = errors.New(entities.ErrMsgInvalidAccountID)
+	ErrAccountNotFound    = errors.New(entities.ErrMsgAccountNotFound)
+	ErrInvalidLimit       = errors.New(entities.ErrMsgInvalidLimit)
+	ErrInvalidBefore      = errors.New(entities.ErrMsgInvalidBefore)
+	ErrInvalidNotifier    = errors.New(entities.ErrMsgInvalidNotifier)
+	ErrWebhookURLRequired = errors.New(entities.ErrMsgWebhookURLRequired)
+	ErrWebhookStatus      = errors.New(entities.ErrMsgWebhookStatus)
+)
+
+// ICore defines the interface for alert business logic. It is also a commit listener
+// of the transaction module, evaluating the rules of the debited account after every
+// committed transfer, deposit and withdrawal.
+type ICore interface {
+	CreateRule(ctx context.Context, accountID int64, req *entities.CreateRuleRequest) (*entities.RuleResponse, apperror.IError)
+	ListRules(ctx context.Context, accountID int64) (*entities.RulesResponse, apperror.IError)
+	DeleteRule(ctx context.Context, accountID, ruleID int64) apperror.IError
+	ListAlerts(ctx context.Context, accountID, before int64, limit int) (*entities.AlertsResponse, apperror.IError)
+	OnCommitted(ctx context.Context, posting *txEntities.CommittedPosting)
+}
+
+// Core implements ICore
+type Core struct {
+	repo        IRepository
+	accountRepo account.IRepository
+	notifier    INotifier
+}
+
+// Compile-time interface checks
+var (
+	_ ICore                       = (*Core)(nil)
+	_ transaction.ICommitListener = (*Core)(nil)
+)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance. Fired alerts are written to the alert log and
+// then handed to notifier.
+func NewCore(_ context.Context, repo IRepository, accountRepo account.IRepository, notifier INotifier) ICore {
+	coreInstance = &Core{
+		repo:        repo,
+		accountRepo: accountRepo,
+		notifier:    notifier,
+	}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// CreateRule validates and adds an alert rule to an account. Rules may be set on system
+// accounts too, so operations can watch the float of the clearing accounts.
+func (c *Core) CreateRule(ctx context.Context, accountID int64, req *entities.CreateRuleRequest) (*entities.RuleResponse, apperror.IError) {
+	rule, appErr := validateRuleRequest(accountID, req)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := c.checkAccount(ctx, accountID); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := c.repo.CreateRule(ctx, rule, entities.MaxRulesPerAccount); err != nil {
+		return nil, toAppError(err, apperror.FieldAccountID, accountID)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgAlertRuleCreated,
+		constants.LogFieldRuleID, rule.RuleID,
+		constants.LogKeyAccountID, accountID,
+		constants.LogFieldAlertKind, rule.Kind,
+		constants.LogFieldThreshold, rule.Threshold.String(),
+	)
+	return toRuleResponse(rule), nil
+}
+
+// ListRules returns the alert rules of an account, oldest first
+func (c *Core) ListRules(ctx context.Context, accountID int64) (*entities.RulesResponse, apperror.IError) {
+	if appErr := c.checkAccount(ctx, accountID); appErr != nil {
+		return nil, appErr
+	}
+
+	rules, err := c.repo.ListRules(ctx, accountID)
+	if err != nil {
+		return nil, toAppError(err, apperror.FieldAccountID, accountID)
+	}
+
+	response := &entities.RulesResponse{
+		AccountID: accountID,
+		Rules:     make([]*entities.RuleResponse, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		response.Rules = append(response.Rules, toRuleResponse(rule))
+	}
+	return response, nil
+}
+
+// DeleteRule removes an alert rule from an account. Alerts it already fired are kept.
+func (c *Core) DeleteRule(ctx context.Context, accountID, ruleID int64) apperror.IError {
+	if accountID <= 0 {
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+	if ruleID <= 0 {
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidRuleID, apperror.MsgInvalidRuleID).
+			WithField(apperror.FieldRuleID, ruleID)
+	}
+
+	if err := c.repo.DeleteRule(ctx, accountID, ruleID); err != nil {
+		return toAppError(err, apperror.FieldRuleID, ruleID)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgAlertRuleDeleted,
+		constants.LogFieldRuleID, ruleID,
+		constants.LogKeyAccountID, accountID,
+	)
+	return nil
+}
+
+// ListAlerts returns a page of an account's fired alerts, newest first. A zero before
+// starts from the newest alert and a zero limit uses the default page size.
+func (c *Core) ListAlerts(ctx context.Context, accountID, before int64, limit int) (*entities.AlertsResponse, apperror.IError) {
+	if limit == 0 {
+		limit = entities.DefaultListLimit
+	}
+	if limit < 0 || limit > entities.MaxListLimit {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidLimit, apperror.MsgInvalidLimit).
+			WithField(apperror.FieldLimit, limit)
+	}
+	if before < 0 {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidBefore, apperror.MsgInvalidBefore).
+			WithField(apperror.FieldBefore, before)
+	}
+
+	if appErr := c.checkAccount(ctx, accountID); appErr != nil {
+		return nil, appErr
+	}
+
+	alerts, err := c.repo.ListAlerts(ctx, accountID, before, limit)
+	if err != nil {
+		return nil, toAppError(err, apperror.FieldAccountID, accountID)
+	}
+
+	response := &entities.AlertsResponse{
+		AccountID: accountID,
+		Alerts:    make([]*entities.AlertResponse, 0, len(alerts)),
+	}
+	for _, alert := range alerts {
+		response.Alerts = append(response.Alerts, toAlertResponse(alert))
+	}
+	if len(alerts) == limit {
+		response.NextBefore = alerts[len(alerts)-1].AlertID
+	}
+	return response, nil
+}
+
+// OnCommitted evaluates the alert rules of the account a committed posting debited and
+// records the alerts it fires. The posting has already succeeded, so failures are only
+// logged, and the evaluation is detached from the request so a client disconnecting
+// cannot cut it short. Notifications are delivered in the background.
+func (c *Core) OnCommitted(ctx context.Context, posting *txEntities.CommittedPosting) {
+	ctx = context.WithoutCancel(ctx)
+
+	alerts, err := c.evaluate(ctx, posting)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgAlertEvaluationFailed,
+			constants.LogFieldTransactionID, posting.TransactionID.String(),
+			constants.LogKeyAccountID, posting.SourceAccountID,
+			constants.LogKeyError, err,
+		)
+		return
+	}
+
+	if len(alerts) > 0 {
+		go c.notify(ctx, alerts)
+	}
+}
+
+// evaluate checks the debited account's rules against a posting and writes the alerts
+// that fire to the alert log
+func (c *Core) evaluate(ctx context.Context, posting *txEntities.CommittedPosting) ([]*Alert, error) {
+	rules, err := c.repo.ListRules(ctx, posting.SourceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []*Alert
+	for _, rule := range rules {
+		if !fires(rule, posting) {
+			continue
+		}
+		alerts = append(alerts, &Alert{
+			RuleID:        &rule.RuleID,
+			AccountID:     rule.AccountID,
+			Kind:          rule.Kind,
+			Threshold:     rule.Threshold,
+			TransactionID: posting.TransactionID,
+			Amount:        posting.Amount,
+			Balance:       posting.SourceBalance,
+		})
+	}
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+
+	if err := c.repo.CreateAlerts(ctx, alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// fires reports whether a posting meets a rule on its debited account. A low-balance
+// rule fires only on the debit that crosses the threshold, not on every debit made
+// while the balance stays below it.
+func fires(rule *Rule, posting *txEntities.CommittedPosting) bool {
+	switch rule.Kind {
+	case entities.RuleLowBalance:
+		before := posting.SourceBalance.Add(posting.Amount)
+		return !before.LessThan(rule.Threshold) && posting.SourceBalance.LessThan(rule.Threshold)
+	case entities.RuleLargeDebit:
+		return posting.Amount.GreaterThan(rule.Threshold)
+	default:
+		return false
+	}
+}
+
+// notify hands alerts to the notifier, logging failed deliveries
+func (c *Core) notify(ctx context.Context, alerts []*Alert) {
+	for _, alert := range alerts {
+		if err := c.notifier.Notify(ctx, toAlertResponse(alert)); err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgAlertNotifyFailed,
+				constants.LogFieldAlertID, alert.AlertID,
+				constants.LogKeyAccountID, alert.AccountID,
+				constants.LogKeyError, err,
+			)
+		}
+	}
+}
+
+// checkAccount validates an account ID and makes sure the account exists
+func (c *Core) checkAccount(ctx context.Context, accountID int64) apperror.IError {
+	if accountID <= 0 {
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	if _, err := c.accountRepo.GetByID(ctx, accountID); err != nil {
+		var repoErr *apperror.Error
+		if errors.As(err, &repoErr) && repoErr.Code() == apperror.CodeNotFound {
+			return apperror.NewWithMessage(apperror.CodeNotFound, ErrAccountNotFound, apperror.MsgAccountNotFound).
+				WithField(apperror.FieldAccountID, accountID)
+		}
+		return apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+	return nil
+}
+
+// validateRuleRequest checks the rule kind and threshold and builds the rule
+func validateRuleRequest(accountID int64, req *entities.CreateRuleRequest) (*Rule, apperror.IError) {
+	if req.Kind != entities.RuleLowBalance && req.Kind != entities.RuleLargeDebit {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidRuleKind, apperror.MsgInvalidAlertRuleKind).
+			WithField(apperror.FieldRuleKind, req.Kind)
+	}
+
+	threshold, err := decimal.NewFromString(req.Threshold)
+	if err != nil || -threshold.Exponent() > entities.ThresholdPlaces ||
+		(req.Kind == entities.RuleLargeDebit && !threshold.IsPositive()) {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidThreshold, apperror.MsgInvalidAlertThreshold).
+			WithField(apperror.FieldThreshold, req.Threshold)
+	}
+
+	return &Rule{AccountID: accountID, Kind: req.Kind, Threshold: threshold}, nil
+}
+
+// toAppError passes apperrors from the repository through and wraps anything else as internal
+func toAppError(err error, field string, value any) apperror.IError {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return apperror.New(apperror.CodeInternalError, err).WithField(field, value)
+}
+
+// toRuleResponse converts a Rule to its wire form
+func toRuleResponse(rule *Rule) *entities.RuleResponse {
+	return &entities.RuleResponse{
+		RuleID:    rule.RuleID,
+		AccountID: rule.AccountID,
+		Kind:      rule.Kind,
+		Threshold: rule.Threshold.String(),
+		CreatedAt: rule.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// toAlertResponse converts an Alert to its wire form
+func toAlertResponse(alert *Alert) *entities.AlertResponse {
+	return &entities.AlertResponse{
+		AlertID:       alert.AlertID,
+		RuleID:        alert.RuleID,
+		AccountID:     alert.AccountID,
+		Kind:          alert.Kind,
+		Threshold:     alert.Threshold.String(),
+		TransactionID: alert.TransactionID.String(),
+		Amount:        alert.Amount.String(),
+		Balance:       alert.Balance.String(),
+		CreatedAt:     alert.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,337 @@
+package alert_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/modules/account"
+	accountEntities "github.com/internal-transfers-service/internal/modules/account/entities"
+	accountMock "github.com/internal-transfers-service/internal/modules/account/mock"
+	"github.com/internal-transfers-service/internal/modules/alert"
+	"github.com/internal-transfers-service/internal/modules/alert/entities"
+	"github.com/internal-transfers-service/internal/modules/alert/mock"
+	txEntities "github.com/internal-transfers-service/internal/modules/transaction/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test constants
+const (
+	testAccountID = int64(100)
+	testRuleID    = int64(7)
+)
+
+// Test error constants - used for simulating database errors in tests
+var (
+	errDatabaseError = errors.New("database error")
+	errDeliveryError = errors.New("delivery failed")
+)
+
+// CoreTestSuite contains tests for alert Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl            *gomock.Controller
+	mockRepo        *mock.MockIRepository
+	mockAccountRepo *accountMock.MockIRepository
+	notifier        *alert.MemoryNotifier
+	core            alert.ICore
+	ctx             context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.mockAccountRepo = accountMock.NewMockIRepository(s.ctrl)
+	s.notifier = alert.NewMemoryNotifier()
+	s.ctx = context.Background()
+	s.core = alert.NewCore(s.ctx, s.mockRepo, s.mockAccountRepo, s.notifier)
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// expectAccount expects the account to be looked up and found
+func (s *CoreTestSuite) expectAccount() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, testAccountID).
+		Return(&account.Account{AccountID: testAccountID, Kind: accountEntities.KindCustomer}, nil).
+		Times(1)
+}
+
+// rule returns a rule of the test account
+func rule(kind, threshold string) *alert.Rule {
+	return &alert.Rule{RuleID: testRuleID, AccountID: testAccountID, Kind: kind, Threshold: decimal.RequireFromString(threshold)}
+}
+
+// debit returns a committed posting debiting amount from the test account and leaving balance
+func debit(amount, balance string) *txEntities.CommittedPosting {
+	return &txEntities.CommittedPosting{
+		TransactionID:        uuid.New(),
+		Type:                 txEntities.TypeTransfer,
+		SourceAccountID:      testAccountID,
+		DestinationAccountID: 200,
+		Amount:               decimal.RequireFromString(amount),
+		SourceBalance:        decimal.RequireFromString(balance),
+	}
+}
+
+// Test CreateRule
+
+func (s *CoreTestSuite) TestCreateRuleStoresRule() {
+	s.expectAccount()
+	s.mockRepo.EXPECT().
+		CreateRule(s.ctx, gomock.Any(), entities.MaxRulesPerAccount).
+		DoAndReturn(func(_ context.Context, r *alert.Rule, _ int) error {
+			s.Equal(testAccountID, r.AccountID)
+			s.Equal(entities.RuleLowBalance, r.Kind)
+			s.Equal("100.5", r.Threshold.String())
+			r.RuleID = testRuleID
+			return nil
+		}).
+		Times(1)
+
+	response, err := s.core.CreateRule(s.ctx, testAccountID, &entities.CreateRuleRequest{Kind: entities.RuleLowBalance, Threshold: "100.50"})
+	s.Nil(err)
+	s.Equal(testRuleID, response.RuleID)
+	s.Equal("100.5", response.Threshold)
+}
+
+func (s *CoreTestSuite) TestCreateRuleWithUnknownKindFails() {
+	_, err := s.core.CreateRule(s.ctx, testAccountID, &entities.CreateRuleRequest{Kind: "high_balance", Threshold: "10"})
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidAlertRuleKind, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateRuleWithInvalidThresholdFails() {
+	_, err := s.core.CreateRule(s.ctx, testAccountID, &entities.CreateRuleRequest{Kind: entities.RuleLowBalance, Threshold: "ten"})
+	s.Equal(apperror.MsgInvalidAlertThreshold, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateRuleWithTooManyDecimalPlacesFails() {
+	_, err := s.core.CreateRule(s.ctx, testAccountID, &entities.CreateRuleRequest{Kind: entities.RuleLowBalance, Threshold: "1.123456789"})
+	s.Equal(apperror.MsgInvalidAlertThreshold, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateLargeDebitRuleWithZeroThresholdFails() {
+	_, err := s.core.CreateRule(s.ctx, testAccountID, &entities.CreateRuleRequest{Kind: entities.RuleLargeDebit, Threshold: "0"})
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidAlertThreshold, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateRuleForMissingAccountReturnsNotFound() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, testAccountID).
+		Return(nil, apperror.New(apperror.CodeNotFound, account.ErrAccountNotFound)).
+		Times(1)
+
+	_, err := s.core.CreateRule(s.ctx, testAccountID, &entities.CreateRuleRequest{Kind: entities.RuleLargeDebit, Threshold: "1000"})
+	s.Equal(apperror.CodeNotFound, err.Code())
+	s.Equal(apperror.MsgAccountNotFound, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateRuleOverLimitReturnsConflict() {
+	s.expectAccount()
+	s.mockRepo.EXPECT().
+		CreateRule(s.ctx, gomock.Any(), entities.MaxRulesPerAccount).
+		Return(apperror.NewWithMessage(apperror.CodeConflict, alert.ErrTooManyRules, apperror.MsgTooManyAlertRules)).
+		Times(1)
+
+	_, err := s.core.CreateRule(s.ctx, testAccountID, &entities.CreateRuleRequest{Kind: entities.RuleLargeDebit, Threshold: "1000"})
+	s.Equal(apperror.CodeConflict, err.Code())
+}
+
+// Test ListRules
+
+func (s *CoreTestSuite) TestListRulesReturnsAccountRules() {
+	s.expectAccount()
+	s.mockRepo.EXPECT().
+		ListRules(s.ctx, testAccountID).
+		Return([]*alert.Rule{rule(entities.RuleLargeDebit, "500")}, nil).
+		Times(1)
+
+	response, err := s.core.ListRules(s.ctx, testAccountID)
+	s.Nil(err)
+	s.Require().Len(response.Rules, 1)
+	s.Equal(entities.RuleLargeDebit, response.Rules[0].Kind)
+	s.Equal("500", response.Rules[0].Threshold)
+}
+
+// Test DeleteRule
+
+func (s *CoreTestSuite) TestDeleteRuleRemovesRule() {
+	s.mockRepo.EXPECT().DeleteRule(s.ctx, testAccountID, testRuleID).Return(nil).Times(1)
+
+	s.Nil(s.core.DeleteRule(s.ctx, testAccountID, testRuleID))
+}
+
+func (s *CoreTestSuite) TestDeleteRuleWithInvalidRuleIDFails() {
+	err := s.core.DeleteRule(s.ctx, testAccountID, 0)
+	s.Equal(apperror.MsgInvalidRuleID, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestDeleteMissingRuleReturnsNotFound() {
+	s.mockRepo.EXPECT().
+		DeleteRule(s.ctx, testAccountID, testRuleID).
+		Return(apperror.NewWithMessage(apperror.CodeNotFound, alert.ErrRuleNotFound, apperror.MsgAlertRuleNotFound)).
+		Times(1)
+
+	err := s.core.DeleteRule(s.ctx, testAccountID, testRuleID)
+	s.Equal(apperror.CodeNotFound, err.Code())
+}
+
+// Test ListAlerts
+
+func (s *CoreTestSuite) TestListAlertsUsesDefaultLimit() {
+	s.expectAccount()
+	s.mockRepo.EXPECT().ListAlerts(s.ctx, testAccountID, int64(0), entities.DefaultListLimit).Return([]*alert.Alert{}, nil).Times(1)
+
+	response, err := s.core.ListAlerts(s.ctx, testAccountID, 0, 0)
+	s.Nil(err)
+	s.Empty(response.Alerts)
+	s.Zero(response.NextBefore)
+}
+
+func (s *CoreTestSuite) TestListAlertsFullPageSetsCursor() {
+	s.expectAccount()
+	s.mockRepo.EXPECT().
+		ListAlerts(s.ctx, testAccountID, int64(50), 2).
+		Return([]*alert.Alert{{AlertID: 42, AccountID: testAccountID}, {AlertID: 40, AccountID: testAccountID}}, nil).
+		Times(1)
+
+	response, err := s.core.ListAlerts(s.ctx, testAccountID, 50, 2)
+	s.Nil(err)
+	s.Len(response.Alerts, 2)
+	s.Equal(int64(40), response.NextBefore)
+}
+
+func (s *CoreTestSuite) TestListAlertsWithLimitAboveMaximumFails() {
+	_, err := s.core.ListAlerts(s.ctx, testAccountID, 0, entities.MaxListLimit+1)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidLimit, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestListAlertsWithNegativeCursorFails() {
+	_, err := s.core.ListAlerts(s.ctx, testAccountID, -1, 10)
+	s.Equal(apperror.MsgInvalidBefore, err.PublicMessage())
+}
+
+// Test OnCommitted
+
+func (s *CoreTestSuite) TestOnCommittedFiresLowBalanceWhenCrossingThreshold() {
+	posting := debit("30", "90")
+	s.mockRepo.EXPECT().ListRules(gomock.Any(), testAccountID).Return([]*alert.Rule{rule(entities.RuleLowBalance, "100")}, nil).Times(1)
+	s.mockRepo.EXPECT().
+		CreateAlerts(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, alerts []*alert.Alert) error {
+			s.Require().Len(alerts, 1)
+			s.Equal(testRuleID, *alerts[0].RuleID)
+			s.Equal(posting.TransactionID, alerts[0].TransactionID)
+			s.Equal("90", alerts[0].Balance.String())
+			alerts[0].AlertID = 1
+			return nil
+		}).
+		Times(1)
+
+	s.core.OnCommitted(s.ctx, posting)
+
+	s.Eventually(func() bool { return len(s.notifier.Alerts()) == 1 }, time.Second, 5*time.Millisecond)
+	delivered := s.notifier.Alerts()[0]
+	s.Equal(int64(1), delivered.AlertID)
+	s.Equal(entities.RuleLowBalance, delivered.Kind)
+	s.Equal("30", delivered.Amount)
+}
+
+func (s *CoreTestSuite) TestOnCommittedFiresLowBalanceWhenLandingBelowFromExactlyThreshold() {
+	s.mockRepo.EXPECT().ListRules(gomock.Any(), testAccountID).Return([]*alert.Rule{rule(entities.RuleLowBalance, "100")}, nil).Times(1)
+	s.mockRepo.EXPECT().CreateAlerts(gomock.Any(), gomock.Len(1)).Return(nil).Times(1)
+
+	s.core.OnCommitted(s.ctx, debit("0.01", "99.99"))
+}
+
+func (s *CoreTestSuite) TestOnCommittedDoesNotRepeatLowBalanceWhileBelowThreshold() {
+	s.mockRepo.EXPECT().ListRules(gomock.Any(), testAccountID).Return([]*alert.Rule{rule(entities.RuleLowBalance, "100")}, nil).Times(1)
+
+	s.core.OnCommitted(s.ctx, debit("10", "80"))
+
+	s.Empty(s.notifier.Alerts())
+}
+
+func (s *CoreTestSuite) TestOnCommittedDoesNotFireLowBalanceAtThreshold() {
+	s.mockRepo.EXPECT().ListRules(gomock.Any(), testAccountID).Return([]*alert.Rule{rule(entities.RuleLowBalance, "100")}, nil).Times(1)
+
+	s.core.OnCommitted(s.ctx, debit("20", "100"))
+}
+
+func (s *CoreTestSuite) TestOnCommittedFiresLargeDebitOnlyAboveThreshold() {
+	s.mockRepo.EXPECT().
+		ListRules(gomock.Any(), testAccountID).
+		Return([]*alert.Rule{rule(entities.RuleLargeDebit, "1000")}, nil).
+		Times(2)
+	s.mockRepo.EXPECT().CreateAlerts(gomock.Any(), gomock.Len(1)).Return(nil).Times(1)
+
+	s.core.OnCommitted(s.ctx, debit("1000", "5000"))
+	s.core.OnCommitted(s.ctx, debit("1000.01", "3999.99"))
+}
+
+func (s *CoreTestSuite) TestOnCommittedWithoutRulesWritesNothing() {
+	s.mockRepo.EXPECT().ListRules(gomock.Any(), testAccountID).Return([]*alert.Rule{}, nil).Times(1)
+
+	s.core.OnCommitted(s.ctx, debit("5000", "0"))
+}
+
+func (s *CoreTestSuite) TestOnCommittedSurvivesCancelledRequest() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	cancel()
+
+	s.mockRepo.EXPECT().
+		ListRules(gomock.Any(), testAccountID).
+		DoAndReturn(func(ctx context.Context, _ int64) ([]*alert.Rule, error) {
+			s.NoError(ctx.Err())
+			return []*alert.Rule{}, nil
+		}).
+		Times(1)
+
+	s.core.OnCommitted(ctx, debit("10", "90"))
+}
+
+func (s *CoreTestSuite) TestOnCommittedWhenRulesUnavailableNotifiesNothing() {
+	s.mockRepo.EXPECT().ListRules(gomock.Any(), testAccountID).Return(nil, errDatabaseError).Times(1)
+
+	s.core.OnCommitted(s.ctx, debit("5000", "0"))
+
+	s.Empty(s.notifier.Alerts())
+}
+
+func (s *CoreTestSuite) TestOnCommittedWhenDeliveryFailsKeepsLoggedAlert() {
+	mockNotifier := mock.NewMockINotifier(s.ctrl)
+	core := alert.NewCore(s.ctx, s.mockRepo, s.mockAccountRepo, mockNotifier)
+	delivered := make(chan struct{})
+
+	s.mockRepo.EXPECT().ListRules(gomock.Any(), testAccountID).Return([]*alert.Rule{rule(entities.RuleLargeDebit, "1")}, nil).Times(1)
+	s.mockRepo.EXPECT().CreateAlerts(gomock.Any(), gomock.Len(1)).Return(nil).Times(1)
+	mockNotifier.EXPECT().
+		Notify(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *entities.AlertResponse) error {
+			close(delivered)
+			return errDeliveryError
+		}).
+		Times(1)
+
+	core.OnCommitted(s.ctx, debit("5", "95"))
+
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		s.Fail("alert was not delivered")
+	}
+}
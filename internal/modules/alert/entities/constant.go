@@ -0,0 +1,54 @@
+// Package entities provides request/response types and constants for the alert module.
+package entities
+
+// Error messages for the alert module
+const (
+	ErrMsgInvalidRuleKind    = "unsupported alert rule kind"
+	ErrMsgInvalidThreshold   = "invalid alert threshold"
+	ErrMsgInvalidRuleID      = "invalid alert rule ID"
+	ErrMsgRuleNotFound       = "alert rule not found"
+	ErrMsgTooManyRules       = "alert rule limit reached for account"
+	ErrMsgInvalidAccountID   = "invalid account ID"
+	ErrMsgAccountNotFound    = "account not found"
+	ErrMsgInvalidLimit       = "invalid alert list limit"
+	ErrMsgInvalidBefore      = "invalid alert list cursor"
+	ErrMsgInvalidNotifier    = "unsupported alert notifier"
+	ErrMsgWebhookURLRequired = "alert webhook URL required"
+	ErrMsgWebhookStatus      = "alert webhook returned non-2xx status"
+)
+
+// Route path constants for the alert module
+const (
+	RouteAlertRules    = "/accounts/{accountID}/alert-rules"
+	RouteAlertRuleByID = "/accounts/{accountID}/alert-rules/{ruleID}"
+	RouteAlerts        = "/accounts/{accountID}/alerts"
+	ParamAccountID     = "accountID"
+	ParamRuleID        = "ruleID"
+	QueryParamLimit    = "limit"
+	QueryParamBefore   = "before"
+)
+
+// Alert rule kinds (alert_rules.kind)
+const (
+	// RuleLowBalance fires when a debit takes the balance from at or above the threshold to below it
+	RuleLowBalance = "low_balance"
+	// RuleLargeDebit fires when a single debit is larger than the threshold
+	RuleLargeDebit = "large_debit"
+)
+
+// Notifier kinds (alert.notifier configuration)
+const (
+	NotifierLog     = "log"
+	NotifierWebhook = "webhook"
+	NotifierMemory  = "memory"
+)
+
+// Rule and listing limits
+const (
+	// MaxRulesPerAccount bounds the rules evaluated after each posting
+	MaxRulesPerAccount = 20
+	// ThresholdPlaces matches the 8 decimal places of DECIMAL(19,8) balances
+	ThresholdPlaces  = 8
+	DefaultListLimit = 50
+	MaxListLimit     = 500
+)
@@ -0,0 +1,9 @@
+package entities
+
+// CreateRuleRequest represents the request to add an alert rule to an account.
+// Threshold is a decimal string: the balance floor for low_balance rules and the
+// debit size for large_debit rules.
+type CreateRuleRequest struct {
+	Kind      string `json:"kind"`
+	Threshold string `json:"threshold"`
+}
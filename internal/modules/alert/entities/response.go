@@ -0,0 +1,38 @@
+package entities
+
+// RuleResponse represents an alert rule
+type RuleResponse struct {
+	RuleID    int64  `json:"rule_id"`
+	AccountID int64  `json:"account_id"`
+	Kind      string `json:"kind"`
+	Threshold string `json:"threshold"`
+	CreatedAt string `json:"created_at"`
+}
+
+// RulesResponse lists the alert rules of an account
+type RulesResponse struct {
+	AccountID int64           `json:"account_id"`
+	Rules     []*RuleResponse `json:"rules"`
+}
+
+// AlertResponse represents a fired alert. RuleID is omitted once the rule is deleted.
+// Amount is the debit that fired it and Balance the account balance it left behind.
+type AlertResponse struct {
+	AlertID       int64  `json:"alert_id"`
+	RuleID        *int64 `json:"rule_id,omitempty"`
+	AccountID     int64  `json:"account_id"`
+	Kind          string `json:"kind"`
+	Threshold     string `json:"threshold"`
+	TransactionID string `json:"transaction_id"`
+	Amount        string `json:"amount"`
+	Balance       string `json:"balance"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// AlertsResponse lists fired alerts of an account, newest first. NextBefore is the
+// cursor for the next page, set when more alerts may follow.
+type AlertsResponse struct {
+	AccountID  int64            `json:"account_id"`
+	Alerts     []*AlertResponse `json:"alerts"`
+	NextBefore int64            `json:"next_before,omitempty"`
+}
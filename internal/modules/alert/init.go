@@ -0,0 +1,78 @@
+// Package alert lets account owners set low-balance and large-debit rules, evaluates them
+// after each committed posting, and records and delivers the alerts they fire.
+package alert
+
+import (
+	"context"
+
+	"github.com/internal-transfers-service/internal/config"
+	"github.com/internal-transfers-service/internal/modules/account"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var AlertModule IModule
+
+// NewModule initializes the alert module. It fails if the notifier configuration is invalid.
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool, cfg config.AlertConfig, accountRepo account.IRepository, recorder audit.IRecorder) (IModule, error) {
+	if AlertModule == nil {
+		notifier, err := NewNotifier(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper, recorder)
+		core := NewCore(ctx, repo, accountRepo, notifier)
+		handler := NewHTTPHandler(core)
+
+		AlertModule = &Module{
+			Core:     core,
+			Handler:  handler,
+			Repo:     repo,
+			Notifier: notifier,
+		}
+	}
+	return AlertModule, nil
+}
+
+// IModule defines the interface for the alert module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+	GetNotifier() INotifier
+}
+
+// Module implements IModule
+type Module struct {
+	Core     ICore
+	Handler  *HTTPHandler
+	Repo     IRepository
+	Notifier INotifier
+}
+
+// Compile-time interface check
+var _ IModule = (*Module)(nil)
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
+
+// GetNotifier returns the notifier fired alerts are delivered to
+func (m *Module) GetNotifier() INotifier {
+	return m.Notifier
+}
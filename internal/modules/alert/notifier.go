@@ -0,0 +1,127 @@
+package alert
+
+//go:generate mockgen -source=notifier.go -destination=mock/mock_notifier.go -package=mock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/internal-transfers-service/internal/config"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/alert/entities"
+)
+
+// INotifier delivers fired alerts outside the service. Alerts are already in the alert
+// log when Notify is called, so a failed delivery loses nothing but the push.
+type INotifier interface {
+	Notify(ctx context.Context, alert *entities.AlertResponse) error
+}
+
+// NewNotifier creates the notifier selected by the configuration
+func NewNotifier(cfg config.AlertConfig) (INotifier, error) {
+	switch cfg.Notifier {
+	case "", entities.NotifierLog:
+		return LogNotifier{}, nil
+	case entities.NotifierWebhook:
+		if cfg.WebhookURL == "" {
+			return nil, ErrWebhookURLRequired
+		}
+		return NewWebhookNotifier(cfg.WebhookURL, &http.Client{Timeout: cfg.GetWebhookTimeout()}), nil
+	case entities.NotifierMemory:
+		return NewMemoryNotifier(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidNotifier, cfg.Notifier)
+	}
+}
+
+// LogNotifier writes each alert to the service log as a warning
+type LogNotifier struct{}
+
+// Notify logs the alert
+func (LogNotifier) Notify(ctx context.Context, alert *entities.AlertResponse) error {
+	logger.Ctx(ctx).Warnw(constants.LogMsgAlertFired,
+		constants.LogFieldAlertID, alert.AlertID,
+		constants.LogFieldAlertKind, alert.Kind,
+		constants.LogKeyAccountID, alert.AccountID,
+		constants.LogFieldThreshold, alert.Threshold,
+		constants.LogFieldTransactionID, alert.TransactionID,
+		constants.LogKeyAmount, alert.Amount,
+		constants.LogFieldNewBalance, alert.Balance,
+	)
+	return nil
+}
+
+// WebhookNotifier POSTs each alert as JSON to a fixed URL. Any non-2xx response is
+// a failed delivery; deliveries are not retried.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a WebhookNotifier. The client's timeout bounds each delivery.
+func NewWebhookNotifier(url string, client *http.Client) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: client}
+}
+
+// Notify posts the alert to the webhook
+func (n *WebhookNotifier) Notify(ctx context.Context, alert *entities.AlertResponse) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", ErrWebhookStatus, resp.StatusCode)
+	}
+	return nil
+}
+
+// MemoryNotifier keeps delivered alerts in memory, for tests and local runs
+type MemoryNotifier struct {
+	mu     sync.Mutex
+	alerts []*entities.AlertResponse
+}
+
+// NewMemoryNotifier creates an empty MemoryNotifier
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+// Notify records the alert
+func (n *MemoryNotifier) Notify(_ context.Context, alert *entities.AlertResponse) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+// Alerts returns the alerts delivered so far, oldest first
+func (n *MemoryNotifier) Alerts() []*entities.AlertResponse {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*entities.AlertResponse(nil), n.alerts...)
+}
+
+// Compile-time interface checks
+var (
+	_ INotifier = LogNotifier{}
+	_ INotifier = (*WebhookNotifier)(nil)
+	_ INotifier = (*MemoryNotifier)(nil)
+)
@@ -0,0 +1,93 @@
+package alert_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/config"
+	"github.com/internal-transfers-service/internal/modules/alert"
+	"github.com/internal-transfers-service/internal/modules/alert/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+// NotifierTestSuite contains tests for alert notifiers
+type NotifierTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestNotifierSuite(t *testing.T) {
+	suite.Run(t, new(NotifierTestSuite))
+}
+
+func (s *NotifierTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+func (s *NotifierTestSuite) TestNewNotifierSelectsConfiguredKind() {
+	notifier, err := alert.NewNotifier(config.AlertConfig{})
+	s.NoError(err)
+	s.IsType(alert.LogNotifier{}, notifier)
+
+	notifier, err = alert.NewNotifier(config.AlertConfig{Notifier: entities.NotifierMemory})
+	s.NoError(err)
+	s.IsType(&alert.MemoryNotifier{}, notifier)
+
+	notifier, err = alert.NewNotifier(config.AlertConfig{Notifier: entities.NotifierWebhook, WebhookURL: "http://localhost/hook"})
+	s.NoError(err)
+	s.IsType(&alert.WebhookNotifier{}, notifier)
+}
+
+func (s *NotifierTestSuite) TestNewNotifierWithoutWebhookURLFails() {
+	_, err := alert.NewNotifier(config.AlertConfig{Notifier: entities.NotifierWebhook})
+	s.ErrorIs(err, alert.ErrWebhookURLRequired)
+}
+
+func (s *NotifierTestSuite) TestNewNotifierWithUnknownKindFails() {
+	_, err := alert.NewNotifier(config.AlertConfig{Notifier: "sms"})
+	s.ErrorIs(err, alert.ErrInvalidNotifier)
+}
+
+func (s *NotifierTestSuite) TestWebhookNotifierPostsAlertJSON() {
+	received := make(chan entities.AlertResponse, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPost, r.Method)
+		var alert entities.AlertResponse
+		s.NoError(json.NewDecoder(r.Body).Decode(&alert))
+		received <- alert
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := alert.NewWebhookNotifier(server.URL, &http.Client{Timeout: time.Second})
+	s.NoError(notifier.Notify(s.ctx, &entities.AlertResponse{AlertID: 3, Kind: entities.RuleLargeDebit}))
+
+	delivered := <-received
+	s.Equal(int64(3), delivered.AlertID)
+	s.Equal(entities.RuleLargeDebit, delivered.Kind)
+}
+
+func (s *NotifierTestSuite) TestWebhookNotifierWithErrorStatusFails() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier := alert.NewWebhookNotifier(server.URL, &http.Client{Timeout: time.Second})
+	s.ErrorIs(notifier.Notify(s.ctx, &entities.AlertResponse{AlertID: 3}), alert.ErrWebhookStatus)
+}
+
+func (s *NotifierTestSuite) TestMemoryNotifierKeepsAlertsInOrder() {
+	notifier := alert.NewMemoryNotifier()
+	s.NoError(notifier.Notify(s.ctx, &entities.AlertResponse{AlertID: 1}))
+	s.NoError(notifier.Notify(s.ctx, &entities.AlertResponse{AlertID: 2}))
+
+	alerts := notifier.Alerts()
+	s.Require().Len(alerts, 2)
+	s.Equal(int64(1), alerts[0].AlertID)
+	s.Equal(int64(2), alerts[1].AlertID)
+}
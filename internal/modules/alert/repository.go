@@ -0,0 +1,232 @@
+package alert
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	auditentities "github.com/internal-transfers-service/internal/modules/audit/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// Rule is a condition on an account that raises an alert when a posting meets it
+type Rule struct {
+	RuleID    int64           `json:"rule_id"`
+	AccountID int64           `json:"account_id"`
+	Kind      string          `json:"kind"`
+	Threshold decimal.Decimal `json:"threshold"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Alert is an entry of the alert log. Kind and Threshold are copied from the rule,
+// and RuleID becomes nil once the rule is deleted.
+type Alert struct {
+	AlertID       int64           `json:"alert_id"`
+	RuleID        *int64          `json:"rule_id"`
+	AccountID     int64           `json:"account_id"`
+	Kind          string          `json:"kind"`
+	Threshold     decimal.Decimal `json:"threshold"`
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Balance       decimal.Decimal `json:"balance"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// IRepository defines the interface for alert data access
+type IRepository interface {
+	CreateRule(ctx context.Context, rule *Rule, maxRules int) error
+	ListRules(ctx context.Context, accountID int64) ([]*Rule, error)
+	DeleteRule(ctx context.Context, accountID, ruleID int64) error
+	CreateAlerts(ctx context.Context, alerts []*Alert) error
+	ListAlerts(ctx context.Context, accountID, before int64, limit int) ([]*Alert, error)
+}
+
+// Repository implements IRepository. Rule changes are recorded in the audit log within
+// the same transaction; fired alerts are derived data and are not.
+type Repository struct {
+	pool  database.IPool
+	audit audit.IRecorder
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new alert repository
+func NewRepository(pool database.IPool, recorder audit.IRecorder) *Repository {
+	return &Repository{pool: pool, audit: recorder}
+}
+
+// SQL queries
+const (
+	// The rule is only inserted while the account is below the rule limit
+	queryInsertRule = `
+		INSERT INTO alert_rules (account_id, kind, threshold, created_at)
+		SELECT $1, $2, $3, $4
+		WHERE (SELECT COUNT(*) FROM alert_rules WHERE account_id = $1) < $5
+		RETURNING rule_id`
+
+	querySelectRules = `
+		SELECT rule_id, account_id, kind, threshold, created_at
+		FROM alert_rules
+		WHERE account_id = $1
+		ORDER BY rule_id`
+
+	queryDeleteRule = `
+		DELETE FROM alert_rules
+		WHERE rule_id = $1 AND account_id = $2
+		RETURNING rule_id, account_id, kind, threshold, created_at`
+
+	queryInsertAlert = `
+		INSERT INTO alerts (rule_id, account_id, kind, threshold, transaction_id, amount, balance, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING alert_id`
+
+	// A zero cursor lists from the newest alert
+	querySelectAlerts = `
+		SELECT alert_id, rule_id, account_id, kind, threshold, transaction_id, amount, balance, created_at
+		FROM alerts
+		WHERE account_id = $1 AND ($2 = 0 OR alert_id < $2)
+		ORDER BY alert_id DESC
+		LIMIT $3`
+)
+
+// CreateRule inserts an alert rule and sets its ID and creation time.
+// Returns a CONFLICT apperror if the account already has maxRules rules.
+func (r *Repository) CreateRule(ctx context.Context, rule *Rule, maxRules int) error {
+	rule.CreatedAt = time.Now().UTC()
+
+	return database.InTx(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, queryInsertRule, rule.AccountID, rule.Kind, rule.Threshold, rule.CreatedAt, maxRules).
+			Scan(&rule.RuleID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperror.NewWithMessage(apperror.CodeConflict, ErrTooManyRules, apperror.MsgTooManyAlertRules).
+					WithField(apperror.FieldAccountID, rule.AccountID)
+			}
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteAlerts,
+				constants.LogKeyAccountID, rule.AccountID,
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+
+		return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionAlertRuleCreated, auditentities.EntityAlertRule,
+			strconv.FormatInt(rule.RuleID, 10), nil, rule))
+	})
+}
+
+// ListRules returns the alert rules of an account, oldest first
+func (r *Repository) ListRules(ctx context.Context, accountID int64) ([]*Rule, error) {
+	rows, err := r.pool.Query(ctx, querySelectRules, accountID)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadAlerts,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]*Rule, 0)
+	for rows.Next() {
+		var rule Rule
+		if err := rows.Scan(&rule.RuleID, &rule.AccountID, &rule.Kind, &rule.Threshold, &rule.CreatedAt); err != nil {
+			return nil, err
+		}
+		rules = append(rules, &rule)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadAlerts,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	return rules, nil
+}
+
+// DeleteRule removes an account's alert rule. Alerts it fired stay in the log.
+// Returns a NOT_FOUND apperror if the account has no such rule.
+func (r *Repository) DeleteRule(ctx context.Context, accountID, ruleID int64) error {
+	return database.InTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var rule Rule
+		err := tx.QueryRow(ctx, queryDeleteRule, ruleID, accountID).
+			Scan(&rule.RuleID, &rule.AccountID, &rule.Kind, &rule.Threshold, &rule.CreatedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NewWithMessage(apperror.CodeNotFound, ErrRuleNotFound, apperror.MsgAlertRuleNotFound).
+				WithField(apperror.FieldRuleID, ruleID)
+		}
+		if err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteAlerts,
+				constants.LogFieldRuleID, ruleID,
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+
+		return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionAlertRuleDeleted, auditentities.EntityAlertRule,
+			strconv.FormatInt(ruleID, 10), &rule, nil))
+	})
+}
+
+// CreateAlerts appends alerts to the log and sets their IDs and creation times
+func (r *Repository) CreateAlerts(ctx context.Context, alerts []*Alert) error {
+	createdAt := time.Now().UTC()
+	for _, alert := range alerts {
+		alert.CreatedAt = createdAt
+		err := r.pool.QueryRow(ctx, queryInsertAlert,
+			alert.RuleID, alert.AccountID, alert.Kind, alert.Threshold,
+			alert.TransactionID, alert.Amount, alert.Balance, alert.CreatedAt,
+		).Scan(&alert.AlertID)
+		if err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteAlerts,
+				constants.LogKeyAccountID, alert.AccountID,
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+	}
+	return nil
+}
+
+// ListAlerts returns up to limit alerts of an account, newest first, starting below
+// the before alert ID (0 for the newest)
+func (r *Repository) ListAlerts(ctx context.Context, accountID, before int64, limit int) ([]*Alert, error) {
+	rows, err := r.pool.Query(ctx, querySelectAlerts, accountID, before, limit)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadAlerts,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	alerts := make([]*Alert, 0)
+	for rows.Next() {
+		var alert Alert
+		if err := rows.Scan(&alert.AlertID, &alert.RuleID, &alert.AccountID, &alert.Kind, &alert.Threshold,
+			&alert.TransactionID, &alert.Amount, &alert.Balance, &alert.CreatedAt); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, &alert)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadAlerts,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	return alerts, nil
+}
@@ -0,0 +1,232 @@
+package alert_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/modules/alert"
+	"github.com/internal-transfers-service/internal/modules/alert/entities"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	auditentities "github.com/internal-transfers-service/internal/modules/audit/entities"
+	auditMock "github.com/internal-transfers-service/internal/modules/audit/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	dbMock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in repository tests
+var (
+	errRepoQueryFailed = errors.New("query execution failed")
+)
+
+// RepositoryTestSuite contains tests for alert Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	mockPool  *dbMock.MockIPool
+	mockRow   *dbMock.MockRow
+	mockRows  *dbMock.MockRows
+	mockTx    *dbMock.MockTx
+	mockAudit *auditMock.MockIRecorder
+	repo      alert.IRepository
+	ctx       context.Context
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbMock.NewMockIPool(s.ctrl)
+	s.mockRow = dbMock.NewMockRow(s.ctrl)
+	s.mockRows = dbMock.NewMockRows(s.ctrl)
+	s.mockTx = dbMock.NewMockTx(s.ctrl)
+	s.mockAudit = auditMock.NewMockIRecorder(s.ctrl)
+	s.ctx = context.Background()
+	s.repo = alert.NewRepository(s.mockPool, s.mockAudit)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// expectTx expects the repository to open its own transaction, rolled back unless committed
+func (s *RepositoryTestSuite) expectTx() {
+	s.mockPool.EXPECT().BeginTx(s.ctx, gomock.Any()).Return(s.mockTx, nil).Times(1)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil).AnyTimes()
+}
+
+// expectAuditedCommit expects one audit event with the given action and a commit
+func (s *RepositoryTestSuite) expectAuditedCommit(action string) {
+	s.mockAudit.EXPECT().
+		Record(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, events ...*audit.Event) error {
+			s.Require().Len(events, 1)
+			s.Equal(action, events[0].Action)
+			s.Equal(auditentities.EntityAlertRule, events[0].EntityType)
+			return nil
+		}).
+		Times(1)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+}
+
+// Test CreateRule
+
+func (s *RepositoryTestSuite) TestCreateRuleSetsID() {
+	r := rule(entities.RuleLargeDebit, "1000")
+	r.RuleID = 0
+
+	s.expectTx()
+	s.mockTx.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), testAccountID, entities.RuleLargeDebit, r.Threshold, gomock.Any(), entities.MaxRulesPerAccount).
+		Return(s.mockRow).
+		Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = testRuleID
+			return nil
+		}).
+		Times(1)
+	s.expectAuditedCommit(auditentities.ActionAlertRuleCreated)
+
+	s.NoError(s.repo.CreateRule(s.ctx, r, entities.MaxRulesPerAccount))
+	s.Equal(testRuleID, r.RuleID)
+	s.False(r.CreatedAt.IsZero())
+}
+
+func (s *RepositoryTestSuite) TestCreateRuleAtLimitReturnsConflict() {
+	s.expectTx()
+	s.mockTx.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(s.mockRow).
+		Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows).Times(1)
+
+	err := s.repo.CreateRule(s.ctx, rule(entities.RuleLargeDebit, "1000"), entities.MaxRulesPerAccount)
+
+	var appErr *apperror.Error
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(apperror.CodeConflict, appErr.Code())
+	s.Equal(apperror.MsgTooManyAlertRules, appErr.PublicMessage())
+}
+
+// Test ListRules
+
+func (s *RepositoryTestSuite) TestListRulesScansRules() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), testAccountID).Return(s.mockRows, nil).Times(1)
+	gomock.InOrder(
+		s.mockRows.EXPECT().Next().Return(true),
+		s.mockRows.EXPECT().
+			Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(dest ...any) error {
+				*dest[0].(*int64) = testRuleID
+				*dest[1].(*int64) = testAccountID
+				*dest[2].(*string) = entities.RuleLowBalance
+				*dest[3].(*decimal.Decimal) = decimal.NewFromInt(100)
+				return nil
+			}),
+		s.mockRows.EXPECT().Next().Return(false),
+	)
+	s.mockRows.EXPECT().Err().Return(nil).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	rules, err := s.repo.ListRules(s.ctx, testAccountID)
+	s.NoError(err)
+	s.Require().Len(rules, 1)
+	s.Equal(entities.RuleLowBalance, rules[0].Kind)
+}
+
+func (s *RepositoryTestSuite) TestListRulesWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), testAccountID).Return(nil, errRepoQueryFailed).Times(1)
+
+	rules, err := s.repo.ListRules(s.ctx, testAccountID)
+	s.Nil(rules)
+	s.Equal(errRepoQueryFailed, err)
+}
+
+// Test DeleteRule
+
+func (s *RepositoryTestSuite) TestDeleteRuleOfOtherAccountReturnsNotFound() {
+	s.expectTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), testRuleID, testAccountID).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgx.ErrNoRows).
+		Times(1)
+
+	err := s.repo.DeleteRule(s.ctx, testAccountID, testRuleID)
+
+	var appErr *apperror.Error
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(apperror.CodeNotFound, appErr.Code())
+}
+
+func (s *RepositoryTestSuite) TestDeleteRuleRecordsDeletedRule() {
+	s.expectTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), testRuleID, testAccountID).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = testRuleID
+			*dest[1].(*int64) = testAccountID
+			*dest[2].(*string) = entities.RuleLowBalance
+			return nil
+		}).
+		Times(1)
+	s.expectAuditedCommit(auditentities.ActionAlertRuleDeleted)
+
+	s.NoError(s.repo.DeleteRule(s.ctx, testAccountID, testRuleID))
+}
+
+// Test CreateAlerts
+
+func (s *RepositoryTestSuite) TestCreateAlertsSetsIDs() {
+	ruleID := testRuleID
+	txID := uuid.New()
+	alerts := []*alert.Alert{{
+		RuleID:        &ruleID,
+		AccountID:     testAccountID,
+		Kind:          entities.RuleLargeDebit,
+		Threshold:     decimal.NewFromInt(1000),
+		TransactionID: txID,
+		Amount:        decimal.NewFromInt(1500),
+		Balance:       decimal.NewFromInt(200),
+	}}
+
+	s.mockPool.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), &ruleID, testAccountID, entities.RuleLargeDebit, alerts[0].Threshold,
+			txID, alerts[0].Amount, alerts[0].Balance, gomock.Any()).
+		Return(s.mockRow).
+		Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = 11
+			return nil
+		}).
+		Times(1)
+
+	s.NoError(s.repo.CreateAlerts(s.ctx, alerts))
+	s.Equal(int64(11), alerts[0].AlertID)
+	s.False(alerts[0].CreatedAt.IsZero())
+}
+
+// Test ListAlerts
+
+func (s *RepositoryTestSuite) TestListAlertsPassesCursorAndLimit() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), testAccountID, int64(40), 10).Return(s.mockRows, nil).Times(1)
+	s.mockRows.EXPECT().Next().Return(false).Times(1)
+	s.mockRows.EXPECT().Err().Return(nil).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	alerts, err := s.repo.ListAlerts(s.ctx, testAccountID, 40, 10)
+	s.NoError(err)
+	s.Empty(alerts)
+}
@@ -0,0 +1,181 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/alert/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+)
+
+// HTTPHandler handles HTTP requests for alert rules and the alert log
+type HTTPHandler struct {
+	core ICore
+}
+
+// NewHTTPHandler creates a new HTTPHandler
+func NewHTTPHandler(core ICore) *HTTPHandler {
+	return &HTTPHandler{core: core}
+}
+
+// RegisterRoutes registers the alert routes on the public router
+func (h *HTTPHandler) RegisterRoutes(r chi.Router) {
+	r.Post(entities.RouteAlertRules, h.CreateRule)
+	r.Get(entities.RouteAlertRules, h.ListRules)
+	r.Delete(entities.RouteAlertRuleByID, h.DeleteRule)
+	r.Get(entities.RouteAlerts, h.ListAlerts)
+}
+
+// CreateRule handles POST /accounts/{accountID}/alert-rules
+func (h *HTTPHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	accountID, appErr := accountIDParam(r)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	var req entities.CreateRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	response, appErr := h.core.CreateRule(r.Context(), accountID, &req)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, response)
+}
+
+// ListRules handles GET /accounts/{accountID}/alert-rules
+func (h *HTTPHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	accountID, appErr := accountIDParam(r)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	response, appErr := h.core.ListRules(r.Context(), accountID)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// DeleteRule handles DELETE /accounts/{accountID}/alert-rules/{ruleID}
+func (h *HTTPHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	accountID, appErr := accountIDParam(r)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	ruleIDStr := chi.URLParam(r, entities.ParamRuleID)
+	ruleID, err := strconv.ParseInt(ruleIDStr, 10, 64)
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidRuleID, apperror.MsgInvalidRuleID).
+			WithField(apperror.FieldRuleID, ruleIDStr))
+		return
+	}
+
+	if appErr := h.core.DeleteRule(r.Context(), accountID, ruleID); appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAlerts handles GET /accounts/{accountID}/alerts?limit=&before=
+func (h *HTTPHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	accountID, appErr := accountIDParam(r)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	query := r.URL.Query()
+	limit := 0
+	if value := query.Get(entities.QueryParamLimit); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidLimit, apperror.MsgInvalidLimit).
+				WithField(apperror.FieldLimit, value))
+			return
+		}
+		limit = parsed
+	}
+
+	var before int64
+	if value := query.Get(entities.QueryParamBefore); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidBefore, apperror.MsgInvalidBefore).
+				WithField(apperror.FieldBefore, value))
+			return
+		}
+		before = parsed
+	}
+
+	response, appErr := h.core.ListAlerts(r.Context(), accountID, before, limit)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// accountIDParam parses the account ID path parameter
+func accountIDParam(r *http.Request) (int64, apperror.IError) {
+	accountIDStr := chi.URLParam(r, entities.ParamAccountID)
+	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+	if err != nil {
+		return 0, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountIDStr)
+	}
+	return accountID, nil
+}
+
+// writeJSON writes a JSON response
+func (h *HTTPHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(status)
+	if data != nil {
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			logger.Error(constants.LogMsgFailedToEncodeResponse, constants.LogKeyError, err)
+		}
+	}
+}
+
+// writeErrorWithContext writes an error response with request ID for tracing
+func (h *HTTPHandler) writeErrorWithContext(w http.ResponseWriter, r *http.Request, err apperror.IError) {
+	requestID := ""
+	if id, ok := r.Context().Value(contextkeys.RequestID).(string); ok {
+		requestID = id
+	}
+
+	response := apperror.ErrorResponse{
+		Error:     err.PublicMessage(),
+		Code:      err.Code().String(),
+		RequestID: requestID,
+		Details:   err.Fields(),
+	}
+
+	// Log error for debugging
+	logger.Ctx(r.Context()).Errorw(constants.LogMsgRequestFailed,
+		constants.LogKeyError, err.Error(),
+		constants.LogKeyStatusCode, err.HTTPStatus(),
+	)
+
+	h.writeJSON(w, err.HTTPStatus(), response)
+}
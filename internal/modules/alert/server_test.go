@@ -0,0 +1,146 @@
+package alert_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/modules/alert"
+	"github.com/internal-transfers-service/internal/modules/alert/entities"
+	"github.com/internal-transfers-service/internal/modules/alert/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for alert HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	handler  *alert.HTTPHandler
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.handler = alert.NewHTTPHandler(s.mockCore)
+	s.router = chi.NewRouter()
+	s.handler.RegisterRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestCreateRuleReturnsCreated() {
+	s.mockCore.EXPECT().
+		CreateRule(gomock.Any(), testAccountID, &entities.CreateRuleRequest{Kind: entities.RuleLowBalance, Threshold: "100"}).
+		Return(&entities.RuleResponse{RuleID: testRuleID, AccountID: testAccountID}, nil).
+		Times(1)
+
+	body := `{"kind":"low_balance","threshold":"100"}`
+	req := httptest.NewRequest(http.MethodPost, "/accounts/100/alert-rules", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+
+	var response entities.RuleResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal(testRuleID, response.RuleID)
+}
+
+func (s *ServerTestSuite) TestCreateRuleWithInvalidJSONReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodPost, "/accounts/100/alert-rules", strings.NewReader("{"))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestListRulesReturnsOK() {
+	s.mockCore.EXPECT().
+		ListRules(gomock.Any(), testAccountID).
+		Return(&entities.RulesResponse{AccountID: testAccountID, Rules: []*entities.RuleResponse{}}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/100/alert-rules", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *ServerTestSuite) TestDeleteRuleReturnsNoContent() {
+	s.mockCore.EXPECT().DeleteRule(gomock.Any(), testAccountID, testRuleID).Return(nil).Times(1)
+
+	req := httptest.NewRequest(http.MethodDelete, "/accounts/100/alert-rules/7", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusNoContent, rec.Code)
+	s.Empty(rec.Body.String())
+}
+
+func (s *ServerTestSuite) TestDeleteMissingRuleReturnsNotFound() {
+	s.mockCore.EXPECT().
+		DeleteRule(gomock.Any(), testAccountID, testRuleID).
+		Return(apperror.NewWithMessage(apperror.CodeNotFound, alert.ErrRuleNotFound, apperror.MsgAlertRuleNotFound)).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodDelete, "/accounts/100/alert-rules/7", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusNotFound, rec.Code)
+
+	var response apperror.ErrorResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal(apperror.MsgAlertRuleNotFound, response.Error)
+}
+
+func (s *ServerTestSuite) TestDeleteRuleWithInvalidRuleIDReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodDelete, "/accounts/100/alert-rules/abc", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestListAlertsPassesPaging() {
+	s.mockCore.EXPECT().
+		ListAlerts(gomock.Any(), testAccountID, int64(40), 10).
+		Return(&entities.AlertsResponse{AccountID: testAccountID, Alerts: []*entities.AlertResponse{}}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/100/alerts?limit=10&before=40", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *ServerTestSuite) TestListAlertsWithInvalidLimitReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodGet, "/accounts/100/alerts?limit=ten", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,358 @@
+package archive
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/metrics"
+	"github.com/internal-transfers-service/internal/modules/archive/entities"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/jackc/pgx/v5"
+)
+
+// Domain errors
+var (
+	ErrRunInProgress   = errors.New(entities.ErrMsgRunInProgress)
+	ErrChainBroken     = errors.New(entities.ErrMsgChainBroken)
+	ErrRowCountChanged = errors.New(entities.ErrMsgRowCountChanged)
+)
+
+// ICore defines the interface for transaction partition maintenance
+type ICore interface {
+	Run(ctx context.Context) (*entities.RunResponse, error)
+	EnsurePartitions(ctx context.Context) ([]string, error)
+	ListArchives(ctx context.Context) (*entities.ArchivesResponse, error)
+}
+
+// Core implements ICore
+type Core struct {
+	repo            IRepository
+	clock           clock.Clock
+	dir             string
+	premakeMonths   int
+	retentionMonths int
+
+	// running is held for the duration of a run so scheduled and on-demand runs never overlap
+	running sync.Mutex
+}
+
+// Compile-time interface check
+var _ ICore = (*Core)(nil)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance. Partitions are kept premakeMonths ahead of the
+// current month; months more than retentionMonths before it are archived to dir, and a
+// retentionMonths of 0 keeps every month.
+func NewCore(_ context.Context, repo IRepository, clk clock.Clock, dir string, premakeMonths, retentionMonths int) ICore {
+	coreInstance = &Core{
+		repo:            repo,
+		clock:           clk,
+		dir:             dir,
+		premakeMonths:   max(premakeMonths, 0),
+		retentionMonths: max(retentionMonths, 0),
+	}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// Run creates the partitions for the current month and the next premakeMonths, then
+// archives expired months oldest first. Each month is detached in one database
+// transaction, then exported and dropped in another; a month left detached by an earlier
+// failure is exported before anything else is detached. Steps another instance is
+// running are skipped.
+func (c *Core) Run(ctx context.Context) (*entities.RunResponse, error) {
+	if !c.running.TryLock() {
+		return nil, ErrRunInProgress
+	}
+	defer c.running.Unlock()
+
+	now := c.clock.Now().UTC()
+	response := &entities.RunResponse{
+		StartedAt:         now.Format(time.RFC3339),
+		PartitionsCreated: make([]string, 0),
+		Archived:          make([]*entities.ArchiveResponse, 0),
+	}
+
+	created, err := c.EnsurePartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response.PartitionsCreated = append(response.PartitionsCreated, created...)
+	if err := c.archiveExpired(ctx, monthOf(now).AddDate(0, -c.retentionMonths, 0), response); err != nil {
+		return nil, err
+	}
+
+	response.FinishedAt = c.clock.Now().UTC().Format(time.RFC3339)
+	return response, nil
+}
+
+// ListArchives returns every archived month, newest first
+func (c *Core) ListArchives(ctx context.Context) (*entities.ArchivesResponse, error) {
+	archives, err := c.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &entities.ArchivesResponse{Archives: make([]*entities.ArchiveResponse, 0, len(archives))}
+	for _, a := range archives {
+		response.Archives = append(response.Archives, toArchiveResponse(a))
+	}
+	return response, nil
+}
+
+// EnsurePartitions creates any missing partition for the current month and the next
+// premakeMonths, and returns the names of those it created. Postings need these whether
+// or not archival is enabled. It then reports the transactions that fell into the
+// default partition because their month had none.
+func (c *Core) EnsurePartitions(ctx context.Context) ([]string, error) {
+	current := monthOf(c.clock.Now())
+	created := make([]string, 0)
+	err := c.inLockedTx(ctx, func(tx pgx.Tx) error {
+		for i := 0; i <= c.premakeMonths; i++ {
+			month := current.AddDate(0, i, 0)
+			ok, err := c.repo.CreatePartition(ctx, tx, month)
+			if err != nil {
+				return err
+			}
+			if ok {
+				name := month.Format(entities.PartitionLayout)
+				created = append(created, name)
+				logger.Ctx(ctx).Infow(constants.LogMsgPartitionCreated, constants.LogFieldPartition, name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkDefaultPartition(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// checkDefaultPartition sets the default partition gauge and logs any rows in it. Rows only
+// land there when partition maintenance has fallen behind, and move to their month's
+// partition once it is created.
+func (c *Core) checkDefaultPartition(ctx context.Context) error {
+	rows, err := c.repo.CountDefaultRows(ctx)
+	if err != nil {
+		return err
+	}
+
+	metrics.SetDefaultPartitionRows(rows)
+	if rows > 0 {
+		logger.Ctx(ctx).Errorw(constants.LogMsgDefaultPartitionNotEmpty,
+			constants.LogFieldPartition, entities.DefaultPartition,
+			constants.LogFieldRowCount, rows,
+		)
+	}
+	return nil
+}
+
+// archiveExpired exports detached partitions and detaches those of months before keepFrom
+// until none is left
+func (c *Core) archiveExpired(ctx context.Context, keepFrom time.Time, response *entities.RunResponse) error {
+	for {
+		archive, err := c.exportNext(ctx)
+		if err != nil {
+			return err
+		}
+		if archive != nil {
+			response.Archived = append(response.Archived, toArchiveResponse(archive))
+			continue
+		}
+
+		if c.retentionMonths == 0 {
+			return nil
+		}
+		detached, err := c.detachNext(ctx, keepFrom)
+		if err != nil || !detached {
+			return err
+		}
+	}
+}
+
+// detachNext detaches the partition of the oldest month before keepFrom, if any, after
+// checking its hash chain. Reports whether a partition was detached.
+func (c *Core) detachNext(ctx context.Context, keepFrom time.Time) (bool, error) {
+	var detached bool
+	err := c.inLockedTx(ctx, func(tx pgx.Tx) error {
+		partitions, err := c.repo.ListPartitions(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		month, name, ok := oldestExpired(partitions, keepFrom)
+		if !ok {
+			return nil
+		}
+
+		rows, broken, err := c.repo.CheckPartition(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+		if broken > 0 {
+			logger.Ctx(ctx).Errorw(constants.LogMsgPartitionChainBroken,
+				constants.LogFieldPartition, name,
+				constants.LogFieldBrokenLinks, broken,
+			)
+			return fmt.Errorf("%w: %d links in %s", ErrChainBroken, broken, name)
+		}
+
+		archive := &Archive{
+			MonthStart:    month,
+			PartitionName: name,
+			RangeEnd:      month.AddDate(0, 1, 0),
+			RowCount:      rows,
+		}
+		if err := c.repo.Detach(ctx, tx, archive); err != nil {
+			return err
+		}
+
+		detached = true
+		logger.Ctx(ctx).Infow(constants.LogMsgPartitionDetached,
+			constants.LogFieldPartition, name,
+			constants.LogFieldRowCount, rows,
+		)
+		return nil
+	})
+	return detached, err
+}
+
+// exportNext exports and drops the oldest detached partition, if any, and returns its archive
+func (c *Core) exportNext(ctx context.Context) (*Archive, error) {
+	var exported *Archive
+	err := c.inLockedTx(ctx, func(tx pgx.Tx) error {
+		archive, err := c.repo.NextDetached(ctx, tx)
+		if err != nil || archive == nil {
+			return err
+		}
+
+		if err := c.export(ctx, tx, archive); err != nil {
+			return err
+		}
+		if err := c.repo.MarkArchived(ctx, tx, archive); err != nil {
+			return err
+		}
+		exported = archive
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if exported != nil {
+		logger.Ctx(ctx).Infow(constants.LogMsgPartitionArchived,
+			constants.LogFieldPartition, exported.PartitionName,
+			constants.LogFieldRowCount, exported.RowCount,
+			constants.LogFieldFilePath, *exported.FilePath,
+		)
+	}
+	return exported, nil
+}
+
+// export writes a detached partition to its archive file and records the file on archive.
+// The partition must still hold the rows counted when it was detached.
+func (c *Core) export(ctx context.Context, tx pgx.Tx, archive *Archive) error {
+	w, err := newFileWriter(c.dir, archive.PartitionName+entities.FileExtension)
+	if err != nil {
+		return err
+	}
+	defer w.Abort()
+
+	if err := c.repo.StreamRows(ctx, tx, archive.PartitionName, w.Write); err != nil {
+		return err
+	}
+	if w.rows != archive.RowCount {
+		return fmt.Errorf("%w: %s has %d rows, %d were detached", ErrRowCountChanged, archive.PartitionName, w.rows, archive.RowCount)
+	}
+
+	sum, err := w.Commit()
+	if err != nil {
+		return err
+	}
+	archive.FilePath = &w.path
+	archive.FileSHA256 = &sum
+	return nil
+}
+
+// inLockedTx runs fn in a transaction holding the archive lock and commits it if fn
+// succeeds. fn is not called when another instance holds the lock.
+func (c *Core) inLockedTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := c.repo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	// Rollback is a no-op once the transaction has committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	locked, err := c.repo.TryLock(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		logger.Ctx(ctx).Infow(constants.LogMsgArchiveLockedElsewhere)
+		return nil
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// oldestExpired returns the month and name of the oldest partition for a month before
+// keepFrom. Partitions are listed oldest first; names that are not monthly partitions are
+// ignored.
+func oldestExpired(partitions []string, keepFrom time.Time) (time.Time, string, bool) {
+	for _, name := range partitions {
+		month, err := time.Parse(entities.PartitionLayout, name)
+		if err != nil {
+			continue
+		}
+		if month.Before(keepFrom) {
+			return month, name, true
+		}
+		return time.Time{}, "", false
+	}
+	return time.Time{}, "", false
+}
+
+// monthOf returns the first instant of the UTC month containing t
+func monthOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// toArchiveResponse converts an archive to its wire form
+func toArchiveResponse(a *Archive) *entities.ArchiveResponse {
+	response := &entities.ArchiveResponse{
+		Month:      a.MonthStart.Format(entities.MonthLayout),
+		Partition:  a.PartitionName,
+		RowCount:   a.RowCount,
+		Status:     a.Status,
+		FilePath:   a.FilePath,
+		FileSHA256: a.FileSHA256,
+		DetachedAt: a.DetachedAt.UTC().Format(time.RFC3339),
+	}
+	if a.ArchivedAt != nil {
+		archivedAt := a.ArchivedAt.UTC().Format(time.RFC3339)
+		response.ArchivedAt = &archivedAt
+	}
+	return response
+}
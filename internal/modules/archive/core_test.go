@@ -0,0 +1,353 @@
+package archive_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/metrics"
+	"github.com/internal-transfers-service/internal/modules/archive"
+	"github.com/internal-transfers-service/internal/modules/archive/entities"
+	"github.com/internal-transfers-service/internal/modules/archive/mock"
+	"github.com/internal-transfers-service/pkg/clock"
+	dbMock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in tests
+var errDatabaseError = errors.New("database error")
+
+// CoreTestSuite contains tests for archive Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockRepo *mock.MockIRepository
+	mockTx   *dbMock.MockTx
+	clock    *clock.Fake
+	dir      string
+	core     archive.ICore
+	ctx      context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.mockTx = dbMock.NewMockTx(s.ctrl)
+	s.clock = clock.NewFake(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC))
+	s.dir = s.T().TempDir()
+	s.ctx = context.Background()
+	s.core = archive.NewCore(s.ctx, s.mockRepo, s.clock, s.dir, 1, 12)
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// month returns the first instant of a UTC month
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// expectLockedTxs sets up n transactions that take the archive lock, of which the first
+// commits commit
+func (s *CoreTestSuite) expectLockedTxs(n, commits int) {
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil).Times(n)
+	s.mockRepo.EXPECT().TryLock(s.ctx, s.mockTx).Return(true, nil).Times(n)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil).Times(commits)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil).Times(n)
+}
+
+// expectPartitionsExist sets up the current and next month's partitions as existing and
+// the default partition as empty
+func (s *CoreTestSuite) expectPartitionsExist() {
+	s.mockRepo.EXPECT().CreatePartition(s.ctx, s.mockTx, month(2026, time.March)).Return(false, nil)
+	s.mockRepo.EXPECT().CreatePartition(s.ctx, s.mockTx, month(2026, time.April)).Return(false, nil)
+	s.mockRepo.EXPECT().CountDefaultRows(s.ctx).Return(int64(0), nil)
+}
+
+// streamRows returns a StreamRows stand-in that yields n rows
+func streamRows(n int) func(context.Context, pgx.Tx, string, func(*archive.Row) error) error {
+	return func(_ context.Context, _ pgx.Tx, _ string, fn func(*archive.Row) error) error {
+		for i := 1; i <= n; i++ {
+			row := &archive.Row{
+				ID:                   uuid.New(),
+				DestinationAccountID: 2,
+				Amount:               decimal.NewFromInt(int64(i)),
+				Type:                 "transfer",
+				ChainSeq:             int64(i),
+			}
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// readArchiveFile decodes every row of a compressed archive file
+func (s *CoreTestSuite) readArchiveFile(path string) []*archive.Row {
+	file, err := os.Open(path)
+	s.Require().NoError(err)
+	defer func() { _ = file.Close() }()
+
+	gz, err := gzip.NewReader(file)
+	s.Require().NoError(err)
+
+	rows := make([]*archive.Row, 0)
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var row archive.Row
+		s.Require().NoError(json.Unmarshal(scanner.Bytes(), &row))
+		rows = append(rows, &row)
+	}
+	s.Require().NoError(scanner.Err())
+	return rows
+}
+
+// Test Run
+
+func (s *CoreTestSuite) TestRunCreatesMissingPartitions() {
+	s.expectLockedTxs(3, 3)
+	s.mockRepo.EXPECT().CreatePartition(s.ctx, s.mockTx, month(2026, time.March)).Return(false, nil)
+	s.mockRepo.EXPECT().CreatePartition(s.ctx, s.mockTx, month(2026, time.April)).Return(true, nil)
+	s.mockRepo.EXPECT().CountDefaultRows(s.ctx).Return(int64(0), nil)
+	s.mockRepo.EXPECT().NextDetached(s.ctx, s.mockTx).Return(nil, nil)
+	s.mockRepo.EXPECT().ListPartitions(s.ctx, s.mockTx).Return([]string{"transactions_2025_03", "transactions_2026_03"}, nil)
+
+	response, err := s.core.Run(s.ctx)
+	s.Require().NoError(err)
+	s.Equal([]string{"transactions_2026_04"}, response.PartitionsCreated)
+	s.Empty(response.Archived)
+	s.Equal("2026-03-15T12:00:00Z", response.StartedAt)
+}
+
+func (s *CoreTestSuite) TestRunDetachesAndExportsExpiredMonth() {
+	detached := &archive.Archive{
+		MonthStart:    month(2025, time.January),
+		PartitionName: "transactions_2025_01",
+		RangeEnd:      month(2025, time.February),
+		RowCount:      2,
+		Status:        entities.StatusDetached,
+		DetachedAt:    time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC),
+	}
+
+	s.expectLockedTxs(6, 6)
+	s.expectPartitionsExist()
+	gomock.InOrder(
+		s.mockRepo.EXPECT().NextDetached(s.ctx, s.mockTx).Return(nil, nil),
+		s.mockRepo.EXPECT().ListPartitions(s.ctx, s.mockTx).
+			Return([]string{"transactions_legacy", "transactions_2025_01", "transactions_2025_03", "transactions_default"}, nil),
+		s.mockRepo.EXPECT().CheckPartition(s.ctx, s.mockTx, "transactions_2025_01").Return(int64(2), int64(0), nil),
+		s.mockRepo.EXPECT().Detach(s.ctx, s.mockTx, gomock.Any()).DoAndReturn(func(_ context.Context, _ pgx.Tx, a *archive.Archive) error {
+			s.Equal(month(2025, time.January), a.MonthStart)
+			s.Equal(month(2025, time.February), a.RangeEnd)
+			s.Equal(int64(2), a.RowCount)
+			return nil
+		}),
+		s.mockRepo.EXPECT().NextDetached(s.ctx, s.mockTx).Return(detached, nil),
+		s.mockRepo.EXPECT().StreamRows(s.ctx, s.mockTx, "transactions_2025_01", gomock.Any()).DoAndReturn(streamRows(2)),
+		s.mockRepo.EXPECT().MarkArchived(s.ctx, s.mockTx, detached).DoAndReturn(func(_ context.Context, _ pgx.Tx, a *archive.Archive) error {
+			a.Status = entities.StatusArchived
+			return nil
+		}),
+		s.mockRepo.EXPECT().NextDetached(s.ctx, s.mockTx).Return(nil, nil),
+		s.mockRepo.EXPECT().ListPartitions(s.ctx, s.mockTx).Return([]string{"transactions_2025_03"}, nil),
+	)
+
+	response, err := s.core.Run(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Len(response.Archived, 1)
+	archived := response.Archived[0]
+	s.Equal("2025-01", archived.Month)
+	s.Equal(entities.StatusArchived, archived.Status)
+
+	path := filepath.Join(s.dir, "transactions_2025_01.jsonl.gz")
+	s.Require().NotNil(archived.FilePath)
+	s.Equal(path, *archived.FilePath)
+	s.Require().NotNil(archived.FileSHA256)
+	s.Len(*archived.FileSHA256, 64)
+
+	rows := s.readArchiveFile(path)
+	s.Require().Len(rows, 2)
+	s.Equal(int64(1), rows[0].ChainSeq)
+	s.True(decimal.NewFromInt(2).Equal(rows[1].Amount))
+}
+
+func (s *CoreTestSuite) TestRunWithBrokenChainReturnsErrorWithoutDetaching() {
+	s.expectLockedTxs(3, 2)
+	s.expectPartitionsExist()
+	s.mockRepo.EXPECT().NextDetached(s.ctx, s.mockTx).Return(nil, nil)
+	s.mockRepo.EXPECT().ListPartitions(s.ctx, s.mockTx).Return([]string{"transactions_2025_01"}, nil)
+	s.mockRepo.EXPECT().CheckPartition(s.ctx, s.mockTx, "transactions_2025_01").Return(int64(5), int64(1), nil)
+	s.mockRepo.EXPECT().Detach(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	response, err := s.core.Run(s.ctx)
+	s.ErrorIs(err, archive.ErrChainBroken)
+	s.Nil(response)
+}
+
+func (s *CoreTestSuite) TestRunWhenRowCountChangedLeavesPartitionDetached() {
+	detached := &archive.Archive{
+		MonthStart:    month(2025, time.January),
+		PartitionName: "transactions_2025_01",
+		RowCount:      3,
+		Status:        entities.StatusDetached,
+	}
+
+	s.expectLockedTxs(2, 1)
+	s.expectPartitionsExist()
+	s.mockRepo.EXPECT().NextDetached(s.ctx, s.mockTx).Return(detached, nil)
+	s.mockRepo.EXPECT().StreamRows(s.ctx, s.mockTx, "transactions_2025_01", gomock.Any()).DoAndReturn(streamRows(2))
+	s.mockRepo.EXPECT().MarkArchived(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	response, err := s.core.Run(s.ctx)
+	s.ErrorIs(err, archive.ErrRowCountChanged)
+	s.Nil(response)
+
+	entries, err := os.ReadDir(s.dir)
+	s.Require().NoError(err)
+	s.Empty(entries)
+}
+
+func (s *CoreTestSuite) TestRunWithZeroRetentionKeepsEveryMonth() {
+	s.core = archive.NewCore(s.ctx, s.mockRepo, s.clock, s.dir, 1, 0)
+	s.expectLockedTxs(2, 2)
+	s.expectPartitionsExist()
+	s.mockRepo.EXPECT().NextDetached(s.ctx, s.mockTx).Return(nil, nil)
+	s.mockRepo.EXPECT().ListPartitions(gomock.Any(), gomock.Any()).Times(0)
+
+	response, err := s.core.Run(s.ctx)
+	s.Require().NoError(err)
+	s.Empty(response.Archived)
+}
+
+func (s *CoreTestSuite) TestRunWhenLockedElsewhereSkipsEveryStep() {
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil).Times(3)
+	s.mockRepo.EXPECT().TryLock(s.ctx, s.mockTx).Return(false, nil).Times(3)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil).Times(3)
+	s.mockTx.EXPECT().Commit(gomock.Any()).Times(0)
+	s.mockRepo.EXPECT().CountDefaultRows(s.ctx).Return(int64(0), nil)
+
+	response, err := s.core.Run(s.ctx)
+	s.Require().NoError(err)
+	s.Empty(response.PartitionsCreated)
+	s.Empty(response.Archived)
+}
+
+func (s *CoreTestSuite) TestRunWhenCreatePartitionFailsReturnsError() {
+	s.expectLockedTxs(1, 0)
+	s.mockRepo.EXPECT().CreatePartition(s.ctx, s.mockTx, month(2026, time.March)).Return(false, errDatabaseError)
+
+	response, err := s.core.Run(s.ctx)
+	s.Equal(errDatabaseError, err)
+	s.Nil(response)
+}
+
+func (s *CoreTestSuite) TestRunWhileRunningReturnsErrRunInProgress() {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s.mockRepo.EXPECT().BeginTx(s.ctx).DoAndReturn(func(context.Context) (pgx.Tx, error) {
+		close(started)
+		<-release
+		return nil, errDatabaseError
+	})
+
+	done := make(chan error)
+	go func() {
+		_, err := s.core.Run(s.ctx)
+		done <- err
+	}()
+	<-started
+
+	response, err := s.core.Run(s.ctx)
+	s.Equal(archive.ErrRunInProgress, err)
+	s.Nil(response)
+
+	close(release)
+	s.Equal(errDatabaseError, <-done)
+}
+
+// Test EnsurePartitions
+
+func (s *CoreTestSuite) TestEnsurePartitionsCreatesPartitionsWithoutArchiving() {
+	s.expectLockedTxs(1, 1)
+	s.mockRepo.EXPECT().CreatePartition(s.ctx, s.mockTx, month(2026, time.March)).Return(true, nil)
+	s.mockRepo.EXPECT().CreatePartition(s.ctx, s.mockTx, month(2026, time.April)).Return(true, nil)
+	s.mockRepo.EXPECT().CountDefaultRows(s.ctx).Return(int64(0), nil)
+
+	created, err := s.core.EnsurePartitions(s.ctx)
+	s.Require().NoError(err)
+	s.Equal([]string{"transactions_2026_03", "transactions_2026_04"}, created)
+	s.Equal(float64(0), testutil.ToFloat64(metrics.DefaultPartitionRows))
+}
+
+func (s *CoreTestSuite) TestEnsurePartitionsReportsDefaultPartitionRows() {
+	s.expectLockedTxs(1, 1)
+	s.mockRepo.EXPECT().CreatePartition(s.ctx, s.mockTx, gomock.Any()).Return(false, nil).Times(2)
+	s.mockRepo.EXPECT().CountDefaultRows(s.ctx).Return(int64(4), nil)
+
+	created, err := s.core.EnsurePartitions(s.ctx)
+	s.Require().NoError(err)
+	s.Empty(created)
+	s.Equal(float64(4), testutil.ToFloat64(metrics.DefaultPartitionRows))
+}
+
+func (s *CoreTestSuite) TestEnsurePartitionsWhenCountFailsReturnsError() {
+	s.expectLockedTxs(1, 1)
+	s.mockRepo.EXPECT().CreatePartition(s.ctx, s.mockTx, gomock.Any()).Return(false, nil).Times(2)
+	s.mockRepo.EXPECT().CountDefaultRows(s.ctx).Return(int64(0), errDatabaseError)
+
+	created, err := s.core.EnsurePartitions(s.ctx)
+	s.Equal(errDatabaseError, err)
+	s.Nil(created)
+}
+
+// Test ListArchives
+
+func (s *CoreTestSuite) TestListArchivesConvertsArchives() {
+	path, sum := "/archive/transactions_2025_01.jsonl.gz", "ab"
+	archivedAt := time.Date(2026, 3, 1, 1, 0, 0, 0, time.UTC)
+	s.mockRepo.EXPECT().List(s.ctx).Return([]*archive.Archive{{
+		MonthStart:    month(2025, time.January),
+		PartitionName: "transactions_2025_01",
+		RowCount:      7,
+		Status:        entities.StatusArchived,
+		FilePath:      &path,
+		FileSHA256:    &sum,
+		DetachedAt:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		ArchivedAt:    &archivedAt,
+	}}, nil)
+
+	response, err := s.core.ListArchives(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Len(response.Archives, 1)
+	a := response.Archives[0]
+	s.Equal("2025-01", a.Month)
+	s.Equal(int64(7), a.RowCount)
+	s.Equal("2026-03-01T00:00:00Z", a.DetachedAt)
+	s.Require().NotNil(a.ArchivedAt)
+	s.Equal("2026-03-01T01:00:00Z", *a.ArchivedAt)
+}
+
+func (s *CoreTestSuite) TestListArchivesWhenListFailsReturnsError() {
+	s.mockRepo.EXPECT().List(s.ctx).Return(nil, errDatabaseError)
+
+	response, err := s.core.ListArchives(s.ctx)
+	s.Equal(errDatabaseError, err)
+	s.Nil(response)
+}
@@ -0,0 +1,44 @@
+// Package entities provides response types and constants for the archive module.
+package entities
+
+// Error messages for the archive module
+const (
+	ErrMsgRunInProgress   = "transaction archive run already running"
+	ErrMsgChainBroken     = "partition hash chain is broken"
+	ErrMsgRowCountChanged = "exported row count does not match the detached partition"
+)
+
+// Route path constants for the archive module (admin router)
+const (
+	RouteArchives = "/transaction-archives"
+)
+
+// Archive states (ArchiveResponse.Status)
+const (
+	// StatusDetached means the partition has left the transactions table but has not been
+	// exported yet; it stays behind as a standalone table until it has
+	StatusDetached = "detached"
+	// StatusArchived means the partition has been exported and dropped
+	StatusArchived = "archived"
+)
+
+// Partition and export file naming. Partitions hold one UTC calendar month of created_at.
+const (
+	// PartitionLayout names a month's partition, e.g. transactions_2026_03
+	PartitionLayout = "transactions_2006_01"
+	// DefaultPartition receives transactions whose month has no partition
+	DefaultPartition = "transactions_default"
+	// FileExtension is appended to the partition name to name its export file
+	FileExtension = ".jsonl.gz"
+	// MonthLayout is the wire format of an archived month
+	MonthLayout = "2006-01"
+)
+
+// DetachLockTimeout bounds how long detaching waits for the exclusive lock on transactions.
+// Postings queue behind a waiting detach, so it gives up and retries on the next run
+// rather than stall them behind a long-running read.
+const DetachLockTimeout = "5s"
+
+// LockKey serializes partition maintenance across instances (pg_try_advisory_xact_lock);
+// an instance that cannot take it skips the step, which another instance is doing.
+const LockKey int64 = 0x6172636869766521 // "archive!"
@@ -0,0 +1,26 @@
+package entities
+
+// ArchiveResponse describes one archived month
+type ArchiveResponse struct {
+	Month      string  `json:"month"`
+	Partition  string  `json:"partition"`
+	RowCount   int64   `json:"row_count"`
+	Status     string  `json:"status"`
+	FilePath   *string `json:"file_path,omitempty"`
+	FileSHA256 *string `json:"file_sha256,omitempty"`
+	DetachedAt string  `json:"detached_at"`
+	ArchivedAt *string `json:"archived_at,omitempty"`
+}
+
+// ArchivesResponse lists archived months, newest first
+type ArchivesResponse struct {
+	Archives []*ArchiveResponse `json:"archives"`
+}
+
+// RunResponse is the outcome of one partition maintenance run
+type RunResponse struct {
+	StartedAt         string             `json:"started_at"`
+	FinishedAt        string             `json:"finished_at"`
+	PartitionsCreated []string           `json:"partitions_created"`
+	Archived          []*ArchiveResponse `json:"archived"`
+}
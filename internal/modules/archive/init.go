@@ -0,0 +1,116 @@
+// Package archive keeps the transactions table partitioned by month and archives expired
+// months to compressed files on local disk.
+package archive
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/internal-transfers-service/internal/config"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var ArchiveModule IModule
+
+// NewModule initializes the archive module
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool, cfg config.ArchiveConfig) IModule {
+	if ArchiveModule == nil {
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper)
+		core := NewCore(ctx, repo, clock.Real{}, cfg.GetDir(), cfg.GetPremakeMonths(), cfg.GetRetentionMonths())
+		handler := NewHTTPHandler(core)
+
+		ArchiveModule = &Module{
+			Core:    core,
+			Handler: handler,
+			Repo:    repo,
+		}
+	}
+	return ArchiveModule
+}
+
+// IModule defines the interface for the archive module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+	StartWorker(ctx context.Context, interval time.Duration, archive bool)
+	StopWorker()
+}
+
+// Module implements IModule
+type Module struct {
+	Core       ICore
+	Handler    *HTTPHandler
+	Repo       IRepository
+	cancelFunc context.CancelFunc
+}
+
+// Compile-time interface check
+var _ IModule = (*Module)(nil)
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
+
+// StartWorker starts a background goroutine that creates upcoming partitions on every
+// tick and, if archive is set, archives expired months
+func (m *Module) StartWorker(ctx context.Context, interval time.Duration, archive bool) {
+	workerCtx, cancel := context.WithCancel(ctx)
+	m.cancelFunc = cancel
+
+	go m.runLoop(workerCtx, interval, archive)
+}
+
+// StopWorker stops the archive worker
+func (m *Module) StopWorker() {
+	if m.cancelFunc != nil {
+		m.cancelFunc()
+	}
+}
+
+// runLoop runs immediately and then on every tick
+func (m *Module) runLoop(ctx context.Context, interval time.Duration, archive bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	m.run(ctx, archive)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.run(ctx, archive)
+		}
+	}
+}
+
+// run maintains the partitions once and logs failures; a run already in progress is not an error
+func (m *Module) run(ctx context.Context, archive bool) {
+	var err error
+	if archive {
+		_, err = m.Core.Run(ctx)
+	} else {
+		_, err = m.Core.EnsurePartitions(ctx)
+	}
+	if err != nil && !errors.Is(err, ErrRunInProgress) && ctx.Err() == nil {
+		logger.Error(constants.LogMsgArchiveRunFailed, constants.LogKeyError, err)
+	}
+}
@@ -0,0 +1,410 @@
+package archive
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/archive/entities"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// Archive is a month whose transactions partition has been detached. FilePath,
+// FileSHA256 and ArchivedAt are set once the partition has been exported and dropped.
+type Archive struct {
+	MonthStart    time.Time
+	PartitionName string
+	RangeEnd      time.Time
+	RowCount      int64
+	Status        string
+	FilePath      *string
+	FileSHA256    *string
+	DetachedAt    time.Time
+	ArchivedAt    *time.Time
+}
+
+// Row is a transaction as it is written to an archive file, one JSON object per line.
+// Hashes are hex; dates are YYYY-MM-DD.
+type Row struct {
+	ID                   uuid.UUID       `json:"id"`
+	SourceAccountID      *int64          `json:"source_account_id"`
+	DestinationAccountID int64           `json:"destination_account_id"`
+	Amount               decimal.Decimal `json:"amount"`
+	Type                 string          `json:"type"`
+	CreatedAt            time.Time       `json:"created_at"`
+	EffectiveDate        string          `json:"effective_date"`
+	CorrectsPeriod       *string         `json:"corrects_period"`
+	HashVersion          int             `json:"hash_version"`
+	ChainSeq             int64           `json:"chain_seq"`
+	PrevHash             string          `json:"prev_hash"`
+	Hash                 string          `json:"hash"`
+}
+
+// IRepository defines the interface for transaction partition data access. Every method
+// that takes a tx expects it to hold the archive lock (see TryLock).
+type IRepository interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	TryLock(ctx context.Context, tx pgx.Tx) (bool, error)
+	CreatePartition(ctx context.Context, tx pgx.Tx, month time.Time) (bool, error)
+	ListPartitions(ctx context.Context, tx pgx.Tx) ([]string, error)
+	CountDefaultRows(ctx context.Context) (int64, error)
+	CheckPartition(ctx context.Context, tx pgx.Tx, partition string) (rows int64, broken int64, err error)
+	Detach(ctx context.Context, tx pgx.Tx, archive *Archive) error
+	NextDetached(ctx context.Context, tx pgx.Tx) (*Archive, error)
+	StreamRows(ctx context.Context, tx pgx.Tx, partition string, fn func(*Row) error) error
+	MarkArchived(ctx context.Context, tx pgx.Tx, archive *Archive) error
+	List(ctx context.Context) ([]*Archive, error)
+}
+
+// Repository implements IRepository
+type Repository struct {
+	pool database.IPool
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new archive repository
+func NewRepository(pool database.IPool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// SQL queries. In the templates, %s is the quoted partition name.
+const (
+	queryTryLock = `
+		SELECT pg_try_advisory_xact_lock($1)`
+
+	queryCreatePartition = `
+		SELECT create_transaction_partition($1::date)`
+
+	queryListPartitions = `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'transactions'::regclass
+		ORDER BY c.relname`
+
+	queryCountDefaultRows = `
+		SELECT COUNT(*) FROM transactions_default`
+
+	// Old partitions receive no inserts, so this only makes sure none arrive between the
+	// check and the detach; it does not block postings to the current month
+	queryLockPartitionTemplate = `
+		LOCK TABLE %s IN SHARE MODE`
+
+	// Counts the partition's rows and the links whose hash does not match their contents,
+	// or that do not point at the hash of the link before them in the same partition.
+	// Links that continue a chain from another partition are checked by chain verification.
+	queryCheckPartitionTemplate = `
+		SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE hash <> transaction_link_hash(hash_version, prev_hash, id, source_account_id,
+					destination_account_id, amount, type, created_at, effective_date, corrects_period)
+				OR lag_seq = chain_seq
+				OR (lag_seq = chain_seq - 1 AND prev_hash <> lag_hash))
+		FROM (
+			SELECT id, source_account_id, destination_account_id, amount, type, created_at,
+				effective_date, corrects_period, hash_version, chain_seq, prev_hash, hash,
+				LAG(chain_seq) OVER w AS lag_seq,
+				LAG(hash) OVER w AS lag_hash
+			FROM %s
+			WINDOW w AS (ORDER BY chain_seq)
+		) l`
+
+	// The partition's totals and chain runs are staged before the detach, so the exclusive
+	// lock on transactions is only held while they are copied
+	queryStageTotalsTemplate = `
+		CREATE TEMP TABLE archive_totals ON COMMIT DROP AS
+		SELECT account_id, type, SUM(credit) AS credits, SUM(debit) AS debits
+		FROM (
+			SELECT destination_account_id AS account_id, type, amount AS credit, 0 AS debit
+			FROM %[1]s
+			UNION ALL
+			SELECT source_account_id, type, 0, amount
+			FROM %[1]s
+			WHERE source_account_id IS NOT NULL
+		) m
+		GROUP BY account_id, type`
+
+	// A run starts at every link whose predecessor is not in the partition and ends at
+	// every link whose successor is not; the nth start pairs with the nth end
+	queryStageRunsTemplate = `
+		CREATE TEMP TABLE archive_runs ON COMMIT DROP AS
+		WITH l AS (
+			SELECT chain_seq, prev_hash, hash,
+				LAG(chain_seq) OVER w AS lag_seq,
+				LEAD(chain_seq) OVER w AS lead_seq
+			FROM %s
+			WINDOW w AS (ORDER BY chain_seq)
+		), starts AS (
+			SELECT chain_seq, prev_hash, ROW_NUMBER() OVER (ORDER BY chain_seq) AS n
+			FROM l WHERE lag_seq IS DISTINCT FROM chain_seq - 1
+		), ends AS (
+			SELECT chain_seq, hash, ROW_NUMBER() OVER (ORDER BY chain_seq) AS n
+			FROM l WHERE lead_seq IS DISTINCT FROM chain_seq + 1
+		)
+		SELECT s.chain_seq AS first_seq, e.chain_seq AS last_seq, s.prev_hash AS first_prev_hash, e.hash AS last_hash
+		FROM starts s
+		JOIN ends e ON e.n = s.n`
+
+	querySetLockTimeout = `
+		SELECT set_config('lock_timeout', $1, true)`
+
+	queryDetachTemplate = `
+		ALTER TABLE transactions DETACH PARTITION %s`
+
+	queryInsertArchive = `
+		INSERT INTO transaction_archives (month_start, partition_name, range_end, row_count)
+		VALUES ($1, $2, $3, $4)
+		RETURNING status, detached_at`
+
+	queryInsertTotals = `
+		INSERT INTO transaction_archive_totals (month_start, account_id, type, credits, debits)
+		SELECT $1::date, account_id, type, credits, debits
+		FROM archive_totals`
+
+	queryInsertRuns = `
+		INSERT INTO transaction_chain_archived_runs (first_seq, last_seq, first_prev_hash, last_hash, month_start)
+		SELECT first_seq, last_seq, first_prev_hash, last_hash, $1::date
+		FROM archive_runs`
+
+	querySelectNextDetached = `
+		SELECT month_start, partition_name, range_end, row_count, status, file_path, file_sha256, detached_at, archived_at
+		FROM transaction_archives
+		WHERE status = 'detached'
+		ORDER BY month_start
+		LIMIT 1`
+
+	queryStreamRowsTemplate = `
+		SELECT id, source_account_id, destination_account_id, amount, type, created_at,
+			to_char(effective_date, 'YYYY-MM-DD'), to_char(corrects_period, 'YYYY-MM-DD'),
+			hash_version, chain_seq, encode(prev_hash, 'hex'), encode(hash, 'hex')
+		FROM %s
+		ORDER BY chain_seq`
+
+	queryDropPartitionTemplate = `
+		DROP TABLE %s`
+
+	queryMarkArchived = `
+		UPDATE transaction_archives
+		SET status = 'archived', file_path = $2, file_sha256 = $3, archived_at = NOW()
+		WHERE month_start = $1 AND status = 'detached'
+		RETURNING archived_at`
+
+	querySelectArchives = `
+		SELECT month_start, partition_name, range_end, row_count, status, file_path, file_sha256, detached_at, archived_at
+		FROM transaction_archives
+		ORDER BY month_start DESC`
+)
+
+// BeginTx starts a read-committed transaction for one maintenance step
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+}
+
+// TryLock takes the archive lock for the lifetime of tx if no other session holds it
+func (r *Repository) TryLock(ctx context.Context, tx pgx.Tx) (bool, error) {
+	var locked bool
+	if err := tx.QueryRow(ctx, queryTryLock, entities.LockKey).Scan(&locked); err != nil {
+		return false, r.logFailure(ctx, "", err)
+	}
+	return locked, nil
+}
+
+// CreatePartition creates the partition for the month starting on month unless it exists
+// or the month has been archived, and reports whether it did
+func (r *Repository) CreatePartition(ctx context.Context, tx pgx.Tx, month time.Time) (bool, error) {
+	var created bool
+	if err := tx.QueryRow(ctx, queryCreatePartition, month).Scan(&created); err != nil {
+		return false, r.logFailure(ctx, month.Format(entities.PartitionLayout), err)
+	}
+	return created, nil
+}
+
+// ListPartitions returns the names of the partitions attached to transactions, oldest first
+func (r *Repository) ListPartitions(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	rows, err := tx.Query(ctx, queryListPartitions)
+	if err != nil {
+		return nil, r.logFailure(ctx, "", err)
+	}
+	defer rows.Close()
+
+	partitions := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, r.logFailure(ctx, "", err)
+		}
+		partitions = append(partitions, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.logFailure(ctx, "", err)
+	}
+	return partitions, nil
+}
+
+// CountDefaultRows returns the number of transactions in the default partition
+func (r *Repository) CountDefaultRows(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.pool.QueryRow(ctx, queryCountDefaultRows).Scan(&count); err != nil {
+		return 0, r.logFailure(ctx, entities.DefaultPartition, err)
+	}
+	return count, nil
+}
+
+// CheckPartition locks a partition against inserts for the rest of tx, then returns its
+// row count and the number of links in it that fail verification
+func (r *Repository) CheckPartition(ctx context.Context, tx pgx.Tx, partition string) (int64, int64, error) {
+	quoted := pgx.Identifier{partition}.Sanitize()
+	if _, err := tx.Exec(ctx, fmt.Sprintf(queryLockPartitionTemplate, quoted)); err != nil {
+		return 0, 0, r.logFailure(ctx, partition, err)
+	}
+
+	var count, broken int64
+	if err := tx.QueryRow(ctx, fmt.Sprintf(queryCheckPartitionTemplate, quoted)).Scan(&count, &broken); err != nil {
+		return 0, 0, r.logFailure(ctx, partition, err)
+	}
+	return count, broken, nil
+}
+
+// Detach takes a partition out of transactions and records the archive with the partition's
+// totals and chain runs, all in tx: once it commits, history sums read the totals in place
+// of the rows. Sets the archive's Status and DetachedAt.
+func (r *Repository) Detach(ctx context.Context, tx pgx.Tx, archive *Archive) error {
+	quoted := pgx.Identifier{archive.PartitionName}.Sanitize()
+	statements := []string{
+		fmt.Sprintf(queryStageTotalsTemplate, quoted),
+		fmt.Sprintf(queryStageRunsTemplate, quoted),
+	}
+	for _, sql := range statements {
+		if _, err := tx.Exec(ctx, sql); err != nil {
+			return r.logFailure(ctx, archive.PartitionName, err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, querySetLockTimeout, entities.DetachLockTimeout); err != nil {
+		return r.logFailure(ctx, archive.PartitionName, err)
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf(queryDetachTemplate, quoted)); err != nil {
+		return r.logFailure(ctx, archive.PartitionName, err)
+	}
+
+	err := tx.QueryRow(ctx, queryInsertArchive, archive.MonthStart, archive.PartitionName, archive.RangeEnd, archive.RowCount).
+		Scan(&archive.Status, &archive.DetachedAt)
+	if err != nil {
+		return r.logFailure(ctx, archive.PartitionName, err)
+	}
+
+	for _, sql := range []string{queryInsertTotals, queryInsertRuns} {
+		if _, err := tx.Exec(ctx, sql, archive.MonthStart); err != nil {
+			return r.logFailure(ctx, archive.PartitionName, err)
+		}
+	}
+	return nil
+}
+
+// NextDetached returns the oldest archive that has not been exported yet, or nil if there
+// is none
+func (r *Repository) NextDetached(ctx context.Context, tx pgx.Tx) (*Archive, error) {
+	archive, err := scanArchive(tx.QueryRow(ctx, querySelectNextDetached))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, r.logFailure(ctx, "", err)
+	}
+	return archive, nil
+}
+
+// StreamRows calls fn for each row of a detached partition in chain order. Rows are read
+// one at a time; fn must not retain the row, which is reused between calls.
+func (r *Repository) StreamRows(ctx context.Context, tx pgx.Tx, partition string, fn func(*Row) error) error {
+	rows, err := tx.Query(ctx, fmt.Sprintf(queryStreamRowsTemplate, pgx.Identifier{partition}.Sanitize()))
+	if err != nil {
+		return r.logFailure(ctx, partition, err)
+	}
+	defer rows.Close()
+
+	var row Row
+	for rows.Next() {
+		if err := rows.Scan(&row.ID, &row.SourceAccountID, &row.DestinationAccountID, &row.Amount, &row.Type,
+			&row.CreatedAt, &row.EffectiveDate, &row.CorrectsPeriod, &row.HashVersion, &row.ChainSeq, &row.PrevHash,
+			&row.Hash); err != nil {
+			return r.logFailure(ctx, partition, err)
+		}
+		row.CreatedAt = row.CreatedAt.UTC()
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return r.logFailure(ctx, partition, err)
+	}
+	return nil
+}
+
+// MarkArchived drops an exported partition and records where it was exported to. Sets the
+// archive's Status and ArchivedAt.
+func (r *Repository) MarkArchived(ctx context.Context, tx pgx.Tx, archive *Archive) error {
+	if _, err := tx.Exec(ctx, fmt.Sprintf(queryDropPartitionTemplate, pgx.Identifier{archive.PartitionName}.Sanitize())); err != nil {
+		return r.logFailure(ctx, archive.PartitionName, err)
+	}
+
+	var archivedAt time.Time
+	err := tx.QueryRow(ctx, queryMarkArchived, archive.MonthStart, archive.FilePath, archive.FileSHA256).Scan(&archivedAt)
+	if err != nil {
+		return r.logFailure(ctx, archive.PartitionName, err)
+	}
+	archive.Status = entities.StatusArchived
+	archive.ArchivedAt = &archivedAt
+	return nil
+}
+
+// List returns every archived month, newest first
+func (r *Repository) List(ctx context.Context) ([]*Archive, error) {
+	rows, err := r.pool.Query(ctx, querySelectArchives)
+	if err != nil {
+		return nil, r.logFailure(ctx, "", err)
+	}
+	defer rows.Close()
+
+	archives := make([]*Archive, 0)
+	for rows.Next() {
+		archive, err := scanArchive(rows)
+		if err != nil {
+			return nil, r.logFailure(ctx, "", err)
+		}
+		archives = append(archives, archive)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.logFailure(ctx, "", err)
+	}
+	return archives, nil
+}
+
+// scanArchive scans a transaction_archives row
+func scanArchive(row pgx.Row) (*Archive, error) {
+	var a Archive
+	if err := row.Scan(&a.MonthStart, &a.PartitionName, &a.RangeEnd, &a.RowCount, &a.Status,
+		&a.FilePath, &a.FileSHA256, &a.DetachedAt, &a.ArchivedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// logFailure logs a failed partition operation and returns the error
+func (r *Repository) logFailure(ctx context.Context, partition string, err error) error {
+	logger.Ctx(ctx).Errorw(constants.LogMsgFailedToManagePartitions,
+		constants.LogFieldPartition, partition,
+		constants.LogKeyError, err,
+	)
+	return err
+}
@@ -0,0 +1,196 @@
+package archive_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/archive"
+	"github.com/internal-transfers-service/internal/modules/archive/entities"
+	dbMock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in repository tests
+var errRepoQueryFailed = errors.New("query execution failed")
+
+// RepositoryTestSuite contains tests for archive Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockPool *dbMock.MockIPool
+	mockRow  *dbMock.MockRow
+	mockRows *dbMock.MockRows
+	mockTx   *dbMock.MockTx
+	repo     archive.IRepository
+	ctx      context.Context
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbMock.NewMockIPool(s.ctrl)
+	s.mockRow = dbMock.NewMockRow(s.ctrl)
+	s.mockRows = dbMock.NewMockRows(s.ctrl)
+	s.mockTx = dbMock.NewMockTx(s.ctrl)
+	s.ctx = context.Background()
+	s.repo = archive.NewRepository(s.mockPool)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// sqlContaining matches a statement that contains every fragment
+func sqlContaining(fragments ...string) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		sql, ok := x.(string)
+		if !ok {
+			return false
+		}
+		for _, f := range fragments {
+			if !strings.Contains(sql, f) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Test TryLock
+
+func (s *RepositoryTestSuite) TestTryLockUsesArchiveLockKey() {
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), entities.LockKey).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*bool) = true
+		return nil
+	})
+
+	locked, err := s.repo.TryLock(s.ctx, s.mockTx)
+	s.NoError(err)
+	s.True(locked)
+}
+
+// Test CreatePartition
+
+func (s *RepositoryTestSuite) TestCreatePartitionWhenQueryFailsReturnsError() {
+	month := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), month).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(errRepoQueryFailed)
+
+	created, err := s.repo.CreatePartition(s.ctx, s.mockTx, month)
+	s.Equal(errRepoQueryFailed, err)
+	s.False(created)
+}
+
+// Test CheckPartition
+
+func (s *RepositoryTestSuite) TestCheckPartitionLocksThenCountsQuotedPartition() {
+	gomock.InOrder(
+		s.mockTx.EXPECT().Exec(s.ctx, sqlContaining(`LOCK TABLE "transactions_2025_01" IN SHARE MODE`)).
+			Return(pgconn.CommandTag{}, nil),
+		s.mockTx.EXPECT().QueryRow(s.ctx, sqlContaining(`FROM "transactions_2025_01"`)).Return(s.mockRow),
+	)
+	s.mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int64) = 10
+		*dest[1].(*int64) = 1
+		return nil
+	})
+
+	rows, broken, err := s.repo.CheckPartition(s.ctx, s.mockTx, "transactions_2025_01")
+	s.NoError(err)
+	s.Equal(int64(10), rows)
+	s.Equal(int64(1), broken)
+}
+
+// Test Detach
+
+func (s *RepositoryTestSuite) TestDetachStagesBeforeDetachingAndRecordsArchive() {
+	month := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := &archive.Archive{
+		MonthStart:    month,
+		PartitionName: "transactions_2025_01",
+		RangeEnd:      month.AddDate(0, 1, 0),
+		RowCount:      10,
+	}
+	detachedAt := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	gomock.InOrder(
+		s.mockTx.EXPECT().Exec(s.ctx, sqlContaining("CREATE TEMP TABLE archive_totals")).Return(pgconn.CommandTag{}, nil),
+		s.mockTx.EXPECT().Exec(s.ctx, sqlContaining("CREATE TEMP TABLE archive_runs")).Return(pgconn.CommandTag{}, nil),
+		s.mockTx.EXPECT().Exec(s.ctx, sqlContaining("lock_timeout"), entities.DetachLockTimeout).Return(pgconn.CommandTag{}, nil),
+		s.mockTx.EXPECT().Exec(s.ctx, sqlContaining(`DETACH PARTITION "transactions_2025_01"`)).Return(pgconn.CommandTag{}, nil),
+		s.mockTx.EXPECT().QueryRow(s.ctx, sqlContaining("INSERT INTO transaction_archives"),
+			month, "transactions_2025_01", month.AddDate(0, 1, 0), int64(10)).Return(s.mockRow),
+		s.mockTx.EXPECT().Exec(s.ctx, sqlContaining("INSERT INTO transaction_archive_totals"), month).Return(pgconn.CommandTag{}, nil),
+		s.mockTx.EXPECT().Exec(s.ctx, sqlContaining("INSERT INTO transaction_chain_archived_runs"), month).Return(pgconn.CommandTag{}, nil),
+	)
+	s.mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = entities.StatusDetached
+		*dest[1].(*time.Time) = detachedAt
+		return nil
+	})
+
+	err := s.repo.Detach(s.ctx, s.mockTx, a)
+	s.NoError(err)
+	s.Equal(entities.StatusDetached, a.Status)
+	s.Equal(detachedAt, a.DetachedAt)
+}
+
+func (s *RepositoryTestSuite) TestDetachWhenLockTimesOutReturnsError() {
+	s.mockTx.EXPECT().Exec(s.ctx, gomock.Any()).Return(pgconn.CommandTag{}, nil).Times(2)
+	s.mockTx.EXPECT().Exec(s.ctx, gomock.Any(), entities.DetachLockTimeout).Return(pgconn.CommandTag{}, nil)
+	s.mockTx.EXPECT().Exec(s.ctx, sqlContaining("DETACH PARTITION")).Return(pgconn.CommandTag{}, errRepoQueryFailed)
+
+	err := s.repo.Detach(s.ctx, s.mockTx, &archive.Archive{PartitionName: "transactions_2025_01"})
+	s.Equal(errRepoQueryFailed, err)
+}
+
+// Test NextDetached
+
+func (s *RepositoryTestSuite) TestNextDetachedWhenNoneReturnsNil() {
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any()).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(pgx.ErrNoRows)
+
+	a, err := s.repo.NextDetached(s.ctx, s.mockTx)
+	s.NoError(err)
+	s.Nil(a)
+}
+
+// Test MarkArchived
+
+func (s *RepositoryTestSuite) TestMarkArchivedDropsPartitionAndRecordsFile() {
+	month := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	path, sum := "archive/transactions_2025_01.jsonl.gz", "ab"
+	a := &archive.Archive{MonthStart: month, PartitionName: "transactions_2025_01", FilePath: &path, FileSHA256: &sum}
+
+	gomock.InOrder(
+		s.mockTx.EXPECT().Exec(s.ctx, sqlContaining(`DROP TABLE "transactions_2025_01"`)).Return(pgconn.CommandTag{}, nil),
+		s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), month, &path, &sum).Return(s.mockRow),
+	)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(nil)
+
+	err := s.repo.MarkArchived(s.ctx, s.mockTx, a)
+	s.NoError(err)
+	s.Equal(entities.StatusArchived, a.Status)
+	s.NotNil(a.ArchivedAt)
+}
+
+// Test List
+
+func (s *RepositoryTestSuite) TestListWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any()).Return(nil, errRepoQueryFailed).Times(1)
+
+	archives, err := s.repo.List(s.ctx)
+	s.Equal(errRepoQueryFailed, err)
+	s.Nil(archives)
+}
@@ -0,0 +1,91 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/archive/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+)
+
+// HTTPHandler handles HTTP requests for transaction partition maintenance
+type HTTPHandler struct {
+	core ICore
+}
+
+// NewHTTPHandler creates a new HTTPHandler
+func NewHTTPHandler(core ICore) *HTTPHandler {
+	return &HTTPHandler{core: core}
+}
+
+// RegisterAdminRoutes registers the transaction archive routes.
+func (h *HTTPHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get(entities.RouteArchives, h.List)
+	r.Post(entities.RouteArchives, h.Run)
+}
+
+// List handles GET /transaction-archives
+func (h *HTTPHandler) List(w http.ResponseWriter, r *http.Request) {
+	archives, err := h.core.ListArchives(r.Context())
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.New(apperror.CodeInternalError, err))
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, archives)
+}
+
+// Run handles POST /transaction-archives by running partition maintenance now and
+// returning what it did
+func (h *HTTPHandler) Run(w http.ResponseWriter, r *http.Request) {
+	report, err := h.core.Run(r.Context())
+	if err != nil {
+		if errors.Is(err, ErrRunInProgress) {
+			h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeConflict, err, apperror.MsgArchiveRunning))
+			return
+		}
+		h.writeErrorWithContext(w, r, apperror.New(apperror.CodeInternalError, err))
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// writeJSON writes a JSON response
+func (h *HTTPHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(status)
+	if data != nil {
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			logger.Error(constants.LogMsgFailedToEncodeResponse, constants.LogKeyError, err)
+		}
+	}
+}
+
+// writeErrorWithContext writes an error response with request ID for tracing
+func (h *HTTPHandler) writeErrorWithContext(w http.ResponseWriter, r *http.Request, err apperror.IError) {
+	requestID := ""
+	if id, ok := r.Context().Value(contextkeys.RequestID).(string); ok {
+		requestID = id
+	}
+
+	response := apperror.ErrorResponse{
+		Error:     err.PublicMessage(),
+		Code:      err.Code().String(),
+		RequestID: requestID,
+		Details:   err.Fields(),
+	}
+
+	// Log error for debugging
+	logger.Ctx(r.Context()).Errorw(constants.LogMsgRequestFailed,
+		constants.LogKeyError, err.Error(),
+		constants.LogKeyStatusCode, err.HTTPStatus(),
+	)
+
+	h.writeJSON(w, err.HTTPStatus(), response)
+}
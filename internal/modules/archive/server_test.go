@@ -0,0 +1,90 @@
+package archive_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/modules/archive"
+	"github.com/internal-transfers-service/internal/modules/archive/entities"
+	"github.com/internal-transfers-service/internal/modules/archive/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for archive HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.router = chi.NewRouter()
+	archive.NewHTTPHandler(s.mockCore).RegisterAdminRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestListReturnsArchives() {
+	s.mockCore.EXPECT().ListArchives(gomock.Any()).Return(&entities.ArchivesResponse{
+		Archives: []*entities.ArchiveResponse{{Month: "2025-01", Status: entities.StatusArchived}},
+	}, nil)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/transaction-archives", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	var body entities.ArchivesResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	s.Require().Len(body.Archives, 1)
+	s.Equal("2025-01", body.Archives[0].Month)
+}
+
+func (s *ServerTestSuite) TestRunReturnsReport() {
+	s.mockCore.EXPECT().Run(gomock.Any()).Return(&entities.RunResponse{
+		PartitionsCreated: []string{"transactions_2026_06"},
+		Archived:          []*entities.ArchiveResponse{},
+	}, nil)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/transaction-archives", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	var body entities.RunResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	s.Equal([]string{"transactions_2026_06"}, body.PartitionsCreated)
+}
+
+func (s *ServerTestSuite) TestRunWhileRunningReturnsConflict() {
+	s.mockCore.EXPECT().Run(gomock.Any()).Return(nil, archive.ErrRunInProgress)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/transaction-archives", nil))
+
+	s.Equal(http.StatusConflict, rec.Code)
+	var body apperror.ErrorResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	s.Equal(apperror.MsgArchiveRunning, body.Error)
+}
+
+func (s *ServerTestSuite) TestRunWhenChainBrokenReturnsInternalError() {
+	s.mockCore.EXPECT().Run(gomock.Any()).Return(nil, archive.ErrChainBroken)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/transaction-archives", nil))
+
+	s.Equal(http.StatusInternalServerError, rec.Code)
+}
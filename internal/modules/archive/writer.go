@@ -0,0 +1,93 @@
+package archive
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// fileWriter writes rows to a gzip-compressed JSON lines file. Rows go to a temporary file
+// that Commit syncs and renames into place, so a crash never leaves a partial export under
+// the final name; Abort removes the temporary file.
+type fileWriter struct {
+	path string
+	file *os.File
+	sum  hash.Hash
+	gz   *gzip.Writer
+	enc  *json.Encoder
+	rows int64
+}
+
+// newFileWriter creates dir if needed and opens a temporary file next to path
+func newFileWriter(dir, name string) (*fileWriter, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, name)
+	file, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.New()
+	gz := gzip.NewWriter(io.MultiWriter(file, sum))
+	return &fileWriter{
+		path: path,
+		file: file,
+		sum:  sum,
+		gz:   gz,
+		enc:  json.NewEncoder(gz),
+	}, nil
+}
+
+// Write appends one row as a JSON line
+func (w *fileWriter) Write(row *Row) error {
+	if err := w.enc.Encode(row); err != nil {
+		return err
+	}
+	w.rows++
+	return nil
+}
+
+// Commit completes the file and moves it to its final path, replacing any earlier export of
+// the same partition. It returns the hex SHA-256 of the compressed file.
+func (w *fileWriter) Commit() (string, error) {
+	if err := w.gz.Close(); err != nil {
+		return "", err
+	}
+	if err := w.file.Sync(); err != nil {
+		return "", err
+	}
+	if err := w.file.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(w.file.Name(), w.path); err != nil {
+		return "", err
+	}
+	if err := syncDir(filepath.Dir(w.path)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(w.sum.Sum(nil)), nil
+}
+
+// Abort discards the temporary file; it is a no-op after a successful Commit
+func (w *fileWriter) Abort() {
+	_ = w.file.Close()
+	_ = os.Remove(w.file.Name())
+}
+
+// syncDir flushes a directory, so that a rename into it survives a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = d.Close() }()
+	return d.Sync()
+}
@@ -0,0 +1,99 @@
+package audit
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/audit/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+)
+
+// Domain errors
+var (
+	ErrInvalidLimit     = errors.New(entities.ErrMsgInvalidLimit)
+	ErrInvalidBefore    = errors.New(entities.ErrMsgInvalidBefore)
+	ErrInvalidTimeRange = errors.New(entities.ErrMsgInvalidTimeRange)
+)
+
+// ICore defines the interface for querying the audit log
+type ICore interface {
+	List(ctx context.Context, filter *Filter) (*entities.EventsResponse, apperror.IError)
+}
+
+// Core implements ICore
+type Core struct {
+	repo IRepository
+}
+
+// Compile-time interface check
+var _ ICore = (*Core)(nil)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance
+func NewCore(_ context.Context, repo IRepository) ICore {
+	coreInstance = &Core{repo: repo}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// List returns a page of audit events matching filter, newest first. A zero Before
+// starts from the newest event and a zero Limit uses the default page size.
+func (c *Core) List(ctx context.Context, filter *Filter) (*entities.EventsResponse, apperror.IError) {
+	if filter.Limit == 0 {
+		filter.Limit = entities.DefaultListLimit
+	}
+	if filter.Limit < 0 || filter.Limit > entities.MaxListLimit {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidLimit, apperror.MsgInvalidLimit).
+			WithField(apperror.FieldLimit, filter.Limit)
+	}
+	if filter.Before < 0 {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidBefore, apperror.MsgInvalidAuditBefore).
+			WithField(apperror.FieldBefore, filter.Before)
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidTimeRange, apperror.MsgInvalidAuditRange).
+			WithField(apperror.FieldFrom, filter.From.Format(time.RFC3339)).
+			WithField(apperror.FieldTo, filter.To.Format(time.RFC3339))
+	}
+
+	events, err := c.repo.List(ctx, filter)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err)
+	}
+
+	response := &entities.EventsResponse{
+		Events: make([]*entities.EventResponse, 0, len(events)),
+	}
+	for _, event := range events {
+		response.Events = append(response.Events, toEventResponse(event))
+	}
+	if len(events) == filter.Limit {
+		response.NextBefore = events[len(events)-1].EventID
+	}
+	return response, nil
+}
+
+// toEventResponse converts an audit event to its API response
+func toEventResponse(event *Event) *entities.EventResponse {
+	return &entities.EventResponse{
+		EventID:    event.EventID,
+		Actor:      event.Actor,
+		RequestID:  event.RequestID,
+		ClientIP:   event.ClientIP,
+		Action:     event.Action,
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		Before:     event.Before,
+		After:      event.After,
+		CreatedAt:  event.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
@@ -0,0 +1,105 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/audit"
+	"github.com/internal-transfers-service/internal/modules/audit/entities"
+	"github.com/internal-transfers-service/internal/modules/audit/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in tests
+var errDatabaseError = errors.New("database error")
+
+// CoreTestSuite contains tests for audit Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockRepo *mock.MockIRepository
+	core     audit.ICore
+	ctx      context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.ctx = context.Background()
+	s.core = audit.NewCore(s.ctx, s.mockRepo)
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *CoreTestSuite) TestListWithoutLimitUsesDefault() {
+	s.mockRepo.EXPECT().
+		List(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter *audit.Filter) ([]*audit.Event, error) {
+			s.Equal(entities.DefaultListLimit, filter.Limit)
+			return []*audit.Event{{EventID: 7, Action: entities.ActionAccountCreated}}, nil
+		}).
+		Times(1)
+
+	response, err := s.core.List(s.ctx, &audit.Filter{})
+	s.Nil(err)
+	s.Require().Len(response.Events, 1)
+	s.Equal(int64(7), response.Events[0].EventID)
+	s.Zero(response.NextBefore)
+}
+
+func (s *CoreTestSuite) TestListWithFullPageReturnsCursor() {
+	s.mockRepo.EXPECT().
+		List(s.ctx, gomock.Any()).
+		Return([]*audit.Event{{EventID: 9}, {EventID: 8}}, nil).
+		Times(1)
+
+	response, err := s.core.List(s.ctx, &audit.Filter{Limit: 2})
+	s.Nil(err)
+	s.Equal(int64(8), response.NextBefore)
+}
+
+func (s *CoreTestSuite) TestListWithLimitAboveMaximumReturnsBadRequest() {
+	response, err := s.core.List(s.ctx, &audit.Filter{Limit: entities.MaxListLimit + 1})
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+}
+
+func (s *CoreTestSuite) TestListWithNegativeBeforeReturnsBadRequest() {
+	response, err := s.core.List(s.ctx, &audit.Filter{Before: -1})
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+}
+
+func (s *CoreTestSuite) TestListWithFromNotBeforeToReturnsBadRequest() {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	response, err := s.core.List(s.ctx, &audit.Filter{From: &from, To: &to})
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+}
+
+func (s *CoreTestSuite) TestListWhenRepositoryFailsReturnsInternalError() {
+	s.mockRepo.EXPECT().
+		List(s.ctx, gomock.Any()).
+		Return(nil, errDatabaseError).
+		Times(1)
+
+	response, err := s.core.List(s.ctx, &audit.Filter{})
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
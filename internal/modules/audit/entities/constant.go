@@ -0,0 +1,57 @@
+// Package entities provides response types and constants for the audit module.
+package entities
+
+// Error messages for the audit module
+const (
+	ErrMsgInvalidLimit     = "invalid audit event list limit"
+	ErrMsgInvalidBefore    = "invalid audit event list cursor"
+	ErrMsgInvalidTimeRange = "invalid audit event time range"
+)
+
+// Route path constants for the audit module (admin router)
+const (
+	RouteAuditEvents = "/audit-events"
+
+	QueryParamActor      = "actor"
+	QueryParamAction     = "action"
+	QueryParamEntityType = "entity_type"
+	QueryParamEntityID   = "entity_id"
+	QueryParamRequestID  = "request_id"
+	QueryParamFrom       = "from"
+	QueryParamTo         = "to"
+	QueryParamLimit      = "limit"
+	QueryParamBefore     = "before"
+)
+
+// Audited entity types (audit_events.entity_type)
+const (
+	EntityAccount         = "account"
+	EntityAlias           = "account_alias"
+	EntityInterestProduct = "interest_product"
+	EntityAccountInterest = "account_interest"
+	EntityAlertRule       = "alert_rule"
+	EntityPeriod          = "accounting_period"
+	EntityGLMapping       = "gl_account_mapping"
+)
+
+// Audited actions (audit_events.action)
+const (
+	ActionAccountCreated    = "account.created"
+	ActionBalanceChanged    = "account.balance_changed"
+	ActionAccountMoved      = "account.moved"
+	ActionMinBalanceSet     = "account.minimum_balance_set"
+	ActionAliasCreated      = "account_alias.created"
+	ActionProductCreated    = "interest_product.created"
+	ActionRateSet           = "interest_product.rate_set"
+	ActionAccountProductSet = "account_interest.set"
+	ActionAlertRuleCreated  = "alert_rule.created"
+	ActionAlertRuleDeleted  = "alert_rule.deleted"
+	ActionPeriodClosed      = "accounting_period.closed"
+	ActionGLMappingSet      = "gl_account_mapping.set"
+)
+
+// Listing limits
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 500
+)
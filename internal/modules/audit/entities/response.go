@@ -0,0 +1,25 @@
+package entities
+
+import "encoding/json"
+
+// EventResponse represents an audit event. Before and After hold the changed fields as
+// they were stored and are omitted for creations and deletions respectively.
+type EventResponse struct {
+	EventID    int64           `json:"event_id"`
+	Actor      string          `json:"actor"`
+	RequestID  string          `json:"request_id,omitempty"`
+	ClientIP   string          `json:"client_ip,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+// EventsResponse lists audit events, newest first. NextBefore is the cursor for the
+// next page, set when more events may follow.
+type EventsResponse struct {
+	Events     []*EventResponse `json:"events"`
+	NextBefore int64            `json:"next_before,omitempty"`
+}
@@ -0,0 +1,61 @@
+// Package audit keeps the append-only log of state-changing operations.
+package audit
+
+import (
+	"context"
+
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var AuditModule IModule
+
+// NewModule initializes the audit module
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool) IModule {
+	if AuditModule == nil {
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper)
+		core := NewCore(ctx, repo)
+		handler := NewHTTPHandler(core)
+
+		AuditModule = &Module{
+			Core:    core,
+			Handler: handler,
+			Repo:    repo,
+		}
+	}
+	return AuditModule
+}
+
+// IModule defines the interface for the audit module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+}
+
+// Module implements IModule
+type Module struct {
+	Core    ICore
+	Handler *HTTPHandler
+	Repo    IRepository
+}
+
+// Compile-time interface check
+var _ IModule = (*Module)(nil)
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
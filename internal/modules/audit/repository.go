@@ -0,0 +1,227 @@
+package audit
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/internal-transfers-service/internal/boot/app_context"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// Event is an entry of the audit log. Before and After hold the changed fields as JSON;
+// Before is nil for creations and After is nil for deletions.
+type Event struct {
+	EventID    int64
+	Actor      string
+	RequestID  string
+	ClientIP   string
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     json.RawMessage
+	After      json.RawMessage
+	CreatedAt  time.Time
+
+	// err holds a failure to encode Before or After, reported by Record
+	err error
+}
+
+// NewEvent builds an audit event for a change made under ctx. The actor, request ID and
+// client IP come from the context; work not started by a request is attributed to the
+// system. before and after are encoded as JSON, and a nil value is stored as NULL.
+func NewEvent(ctx context.Context, action, entityType, entityID string, before, after any) *Event {
+	event := &Event{
+		Actor:      app_context.GetActor(ctx),
+		RequestID:  app_context.GetRequestID(ctx),
+		ClientIP:   app_context.GetClientIP(ctx),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+	if event.Actor == "" {
+		event.Actor = constants.ActorSystem
+	}
+	event.Before, event.err = encodeValue(before)
+	if event.err == nil {
+		event.After, event.err = encodeValue(after)
+	}
+	return event
+}
+
+// encodeValue encodes v as JSON, leaving nil as nil
+func encodeValue(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// Filter selects audit events. Empty fields match everything; From is inclusive and To
+// exclusive. Before is a cursor: only events with a smaller ID are returned when it is set.
+type Filter struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Before     int64
+	Limit      int
+}
+
+// IRecorder writes audit events. Other modules' repositories record their changes through
+// it, inside the transaction that makes the change.
+type IRecorder interface {
+	Record(ctx context.Context, tx pgx.Tx, events ...*Event) error
+}
+
+// IRepository defines the interface for audit data access
+type IRepository interface {
+	IRecorder
+	List(ctx context.Context, filter *Filter) ([]*Event, error)
+}
+
+// Repository implements IRepository
+type Repository struct {
+	pool database.IPool
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new audit repository
+func NewRepository(pool database.IPool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// SQL queries
+const (
+	// Empty request IDs and client IPs are stored as NULL
+	queryInsertEvents = `
+		INSERT INTO audit_events (actor, request_id, client_ip, action, entity_type, entity_id, before_value, after_value, created_at)
+		SELECT e.actor, NULLIF(e.request_id, ''), NULLIF(e.client_ip, ''), e.action, e.entity_type, e.entity_id,
+			e.before_value::jsonb, e.after_value::jsonb, $9
+		FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::text[], $6::text[], $7::text[], $8::text[])
+			AS e(actor, request_id, client_ip, action, entity_type, entity_id, before_value, after_value)`
+
+	// Empty filters match everything; a zero cursor lists from the newest event
+	querySelectEvents = `
+		SELECT event_id, actor, COALESCE(request_id, ''), COALESCE(client_ip, ''), action, entity_type, entity_id,
+			before_value, after_value, created_at
+		FROM audit_events
+		WHERE ($1 = '' OR actor = $1)
+		  AND ($2 = '' OR action = $2)
+		  AND ($3 = '' OR entity_type = $3)
+		  AND ($4 = '' OR entity_id = $4)
+		  AND ($5 = '' OR request_id = $5)
+		  AND ($6::timestamptz IS NULL OR created_at >= $6)
+		  AND ($7::timestamptz IS NULL OR created_at < $7)
+		  AND ($8 = 0 OR event_id < $8)
+		ORDER BY event_id DESC
+		LIMIT $9`
+)
+
+// Record appends events to the audit log within tx, so they commit or roll back with
+// the change they describe, and sets their creation times
+func (r *Repository) Record(ctx context.Context, tx pgx.Tx, events ...*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	createdAt := time.Now().UTC()
+	actors := make([]string, len(events))
+	requestIDs := make([]string, len(events))
+	clientIPs := make([]string, len(events))
+	actions := make([]string, len(events))
+	entityTypes := make([]string, len(events))
+	entityIDs := make([]string, len(events))
+	befores := make([]*string, len(events))
+	afters := make([]*string, len(events))
+	for i, event := range events {
+		if event.err != nil {
+			return r.logWriteFailure(ctx, event, event.err)
+		}
+		event.CreatedAt = createdAt
+		actors[i] = event.Actor
+		requestIDs[i] = event.RequestID
+		clientIPs[i] = event.ClientIP
+		actions[i] = event.Action
+		entityTypes[i] = event.EntityType
+		entityIDs[i] = event.EntityID
+		befores[i] = rawString(event.Before)
+		afters[i] = rawString(event.After)
+	}
+
+	if _, err := tx.Exec(ctx, queryInsertEvents,
+		actors, requestIDs, clientIPs, actions, entityTypes, entityIDs, befores, afters, createdAt,
+	); err != nil {
+		return r.logWriteFailure(ctx, events[0], err)
+	}
+	return nil
+}
+
+// rawString returns the JSON text of raw, or nil for SQL NULL
+func rawString(raw json.RawMessage) *string {
+	if raw == nil {
+		return nil
+	}
+	s := string(raw)
+	return &s
+}
+
+// logWriteFailure logs a failed audit write and returns the error
+func (r *Repository) logWriteFailure(ctx context.Context, event *Event, err error) error {
+	logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteAudit,
+		constants.LogFieldAuditAction, event.Action,
+		constants.LogKeyError, err,
+	)
+	return err
+}
+
+// List returns up to filter.Limit events matching filter, newest first
+func (r *Repository) List(ctx context.Context, filter *Filter) ([]*Event, error) {
+	rows, err := r.pool.Query(ctx, querySelectEvents,
+		filter.Actor,
+		filter.Action,
+		filter.EntityType,
+		filter.EntityID,
+		filter.RequestID,
+		filter.From,
+		filter.To,
+		filter.Before,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, r.logReadFailure(ctx, err)
+	}
+	defer rows.Close()
+
+	events := make([]*Event, 0, filter.Limit)
+	for rows.Next() {
+		var e Event
+		if err := rows.Scan(&e.EventID, &e.Actor, &e.RequestID, &e.ClientIP, &e.Action, &e.EntityType, &e.EntityID,
+			&e.Before, &e.After, &e.CreatedAt); err != nil {
+			return nil, r.logReadFailure(ctx, err)
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.logReadFailure(ctx, err)
+	}
+	return events, nil
+}
+
+// logReadFailure logs a failed audit read and returns the error
+func (r *Repository) logReadFailure(ctx context.Context, err error) error {
+	logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadAudit,
+		constants.LogKeyError, err,
+	)
+	return err
+}
@@ -0,0 +1,166 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/boot/app_context"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	"github.com/internal-transfers-service/internal/modules/audit/entities"
+	dbmock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in repository tests
+var (
+	errRepoQueryFailed = errors.New("query execution failed")
+)
+
+// RepositoryTestSuite contains tests for audit Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockPool *dbmock.MockIPool
+	mockTx   *dbmock.MockTx
+	repo     audit.IRepository
+	ctx      context.Context
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbmock.NewMockIPool(s.ctrl)
+	s.mockTx = dbmock.NewMockTx(s.ctrl)
+	s.ctx = context.Background()
+	s.repo = audit.NewRepository(s.mockPool)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// Test NewEvent
+
+func (s *RepositoryTestSuite) TestNewEventTakesActorRequestIDAndClientIPFromContext() {
+	ctx := app_context.SetRequestID(s.ctx, "req-1")
+	ctx = app_context.SetClientIP(ctx, "10.0.0.7")
+	ctx = app_context.SetActor(ctx, "ops-alice")
+
+	event := audit.NewEvent(ctx, entities.ActionAccountCreated, entities.EntityAccount, "42", nil, map[string]int{"balance": 5})
+
+	s.Equal("ops-alice", event.Actor)
+	s.Equal("req-1", event.RequestID)
+	s.Equal("10.0.0.7", event.ClientIP)
+	s.Nil(event.Before)
+	s.JSONEq(`{"balance":5}`, string(event.After))
+}
+
+func (s *RepositoryTestSuite) TestNewEventWithoutActorIsAttributedToSystem() {
+	event := audit.NewEvent(s.ctx, entities.ActionBalanceChanged, entities.EntityAccount, "42", nil, nil)
+
+	s.Equal(constants.ActorSystem, event.Actor)
+	s.Empty(event.RequestID)
+}
+
+// Test Record
+
+func (s *RepositoryTestSuite) TestRecordInsertsAllEventsInOneStatement() {
+	first := audit.NewEvent(s.ctx, entities.ActionAccountCreated, entities.EntityAccount, "1", nil, map[string]string{"currency": "USD"})
+	second := audit.NewEvent(s.ctx, entities.ActionAccountCreated, entities.EntityAccount, "2", nil, nil)
+
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(),
+			[]string{constants.ActorSystem, constants.ActorSystem},
+			[]string{"", ""},
+			[]string{"", ""},
+			[]string{entities.ActionAccountCreated, entities.ActionAccountCreated},
+			[]string{entities.EntityAccount, entities.EntityAccount},
+			[]string{"1", "2"},
+			[]*string{nil, nil},
+			gomock.Any(),
+			gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, args ...any) (pgconn.CommandTag, error) {
+			afters := args[7].([]*string)
+			s.Require().NotNil(afters[0])
+			s.JSONEq(`{"currency":"USD"}`, *afters[0])
+			s.Nil(afters[1])
+			return pgconn.NewCommandTag("INSERT 0 2"), nil
+		}).
+		Times(1)
+
+	s.NoError(s.repo.Record(s.ctx, s.mockTx, first, second))
+	s.False(first.CreatedAt.IsZero())
+}
+
+func (s *RepositoryTestSuite) TestRecordWithNoEventsDoesNothing() {
+	s.NoError(s.repo.Record(s.ctx, s.mockTx))
+}
+
+func (s *RepositoryTestSuite) TestRecordWithUnencodableValueReturnsError() {
+	event := audit.NewEvent(s.ctx, entities.ActionAccountCreated, entities.EntityAccount, "1", nil, make(chan int))
+
+	s.Error(s.repo.Record(s.ctx, s.mockTx, event))
+}
+
+func (s *RepositoryTestSuite) TestRecordWhenExecFailsReturnsError() {
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgconn.CommandTag{}, errRepoQueryFailed).
+		Times(1)
+
+	event := audit.NewEvent(s.ctx, entities.ActionAccountCreated, entities.EntityAccount, "1", nil, nil)
+	s.Equal(errRepoQueryFailed, s.repo.Record(s.ctx, s.mockTx, event))
+}
+
+// Test List
+
+func (s *RepositoryTestSuite) TestListPassesFiltersAndScansEvents() {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	filter := &audit.Filter{Actor: "ops-alice", EntityType: entities.EntityAccount, From: &from, Before: 100, Limit: 10}
+	mockRows := dbmock.NewMockRows(s.ctrl)
+
+	s.mockPool.EXPECT().
+		Query(s.ctx, gomock.Any(), "ops-alice", "", entities.EntityAccount, "", "", &from, (*time.Time)(nil), int64(100), 10).
+		Return(mockRows, nil).
+		Times(1)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().
+			Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(dest ...any) error {
+				*dest[0].(*int64) = 99
+				*dest[1].(*string) = "ops-alice"
+				*dest[4].(*string) = entities.ActionMinBalanceSet
+				return nil
+			}),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	mockRows.EXPECT().Err().Return(nil).Times(1)
+	mockRows.EXPECT().Close().Times(1)
+
+	events, err := s.repo.List(s.ctx, filter)
+	s.NoError(err)
+	s.Require().Len(events, 1)
+	s.Equal(int64(99), events[0].EventID)
+	s.Equal(entities.ActionMinBalanceSet, events[0].Action)
+}
+
+func (s *RepositoryTestSuite) TestListWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().
+		Query(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errRepoQueryFailed).
+		Times(1)
+
+	events, err := s.repo.List(s.ctx, &audit.Filter{Limit: 10})
+	s.Nil(events)
+	s.Equal(errRepoQueryFailed, err)
+}
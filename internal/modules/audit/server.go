@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/audit/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+)
+
+// HTTPHandler handles HTTP requests for the audit log
+type HTTPHandler struct {
+	core ICore
+}
+
+// NewHTTPHandler creates a new HTTPHandler
+func NewHTTPHandler(core ICore) *HTTPHandler {
+	return &HTTPHandler{core: core}
+}
+
+// RegisterAdminRoutes registers the audit log routes.
+func (h *HTTPHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get(entities.RouteAuditEvents, h.ListEvents)
+}
+
+// ListEvents handles GET /audit-events?actor=&action=&entity_type=&entity_id=&request_id=&from=&to=&limit=&before=
+func (h *HTTPHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	filter, appErr := parseFilter(r.URL.Query())
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	response, appErr := h.core.List(r.Context(), filter)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// parseFilter builds a Filter from the query string
+func parseFilter(query url.Values) (*Filter, apperror.IError) {
+	filter := &Filter{
+		Actor:      query.Get(entities.QueryParamActor),
+		Action:     query.Get(entities.QueryParamAction),
+		EntityType: query.Get(entities.QueryParamEntityType),
+		EntityID:   query.Get(entities.QueryParamEntityID),
+		RequestID:  query.Get(entities.QueryParamRequestID),
+	}
+
+	if value := query.Get(entities.QueryParamLimit); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidLimit, apperror.MsgInvalidLimit).
+				WithField(apperror.FieldLimit, value)
+		}
+		filter.Limit = parsed
+	}
+
+	if value := query.Get(entities.QueryParamBefore); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidBefore, apperror.MsgInvalidAuditBefore).
+				WithField(apperror.FieldBefore, value)
+		}
+		filter.Before = parsed
+	}
+
+	var appErr apperror.IError
+	if filter.From, appErr = timeParam(query, entities.QueryParamFrom, apperror.FieldFrom); appErr != nil {
+		return nil, appErr
+	}
+	if filter.To, appErr = timeParam(query, entities.QueryParamTo, apperror.FieldTo); appErr != nil {
+		return nil, appErr
+	}
+	return filter, nil
+}
+
+// timeParam parses an optional RFC3339 query parameter
+func timeParam(query url.Values, name, field string) (*time.Time, apperror.IError) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidTimestamp).
+			WithField(field, value)
+	}
+	return &parsed, nil
+}
+
+// writeJSON writes a JSON response
+func (h *HTTPHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(status)
+	if data != nil {
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			logger.Error(constants.LogMsgFailedToEncodeResponse, constants.LogKeyError, err)
+		}
+	}
+}
+
+// writeErrorWithContext writes an error response with request ID for tracing
+func (h *HTTPHandler) writeErrorWithContext(w http.ResponseWriter, r *http.Request, err apperror.IError) {
+	requestID := ""
+	if id, ok := r.Context().Value(contextkeys.RequestID).(string); ok {
+		requestID = id
+	}
+
+	response := apperror.ErrorResponse{
+		Error:     err.PublicMessage(),
+		Code:      err.Code().String(),
+		RequestID: requestID,
+		Details:   err.Fields(),
+	}
+
+	// Log error for debugging
+	logger.Ctx(r.Context()).Errorw(constants.LogMsgRequestFailed,
+		constants.LogKeyError, err.Error(),
+		constants.LogKeyStatusCode, err.HTTPStatus(),
+	)
+
+	h.writeJSON(w, err.HTTPStatus(), response)
+}
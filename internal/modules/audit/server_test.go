@@ -0,0 +1,89 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	"github.com/internal-transfers-service/internal/modules/audit/entities"
+	"github.com/internal-transfers-service/internal/modules/audit/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for audit HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.router = chi.NewRouter()
+	audit.NewHTTPHandler(s.mockCore).RegisterAdminRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestListEventsParsesFilterFromQuery() {
+	s.mockCore.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter *audit.Filter) (*entities.EventsResponse, apperror.IError) {
+			s.Equal("ops-alice", filter.Actor)
+			s.Equal(entities.EntityAccount, filter.EntityType)
+			s.Equal("42", filter.EntityID)
+			s.Equal(25, filter.Limit)
+			s.Equal(int64(100), filter.Before)
+			s.Require().NotNil(filter.From)
+			s.True(filter.From.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)))
+			s.Nil(filter.To)
+			return &entities.EventsResponse{Events: []*entities.EventResponse{{EventID: 99}}}, nil
+		}).
+		Times(1)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/audit-events?actor=ops-alice&entity_type=account&entity_id=42&limit=25&before=100&from=2026-03-01T00:00:00Z", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	var result entities.EventsResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &result))
+	s.Require().Len(result.Events, 1)
+	s.Equal(int64(99), result.Events[0].EventID)
+}
+
+func (s *ServerTestSuite) TestListEventsWithInvalidLimitReturnsBadRequest() {
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/audit-events?limit=abc", nil))
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestListEventsWithInvalidBeforeReturnsBadRequest() {
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/audit-events?before=abc", nil))
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestListEventsWithInvalidFromReturnsBadRequest() {
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/audit-events?from=yesterday", nil))
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,215 @@
+package chain
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/chain/entities"
+)
+
+// Domain errors
+var (
+	ErrVerifyInProgress = errors.New(entities.ErrMsgVerifyInProgress)
+)
+
+// ICore defines the interface for hash chain verification
+type ICore interface {
+	Verify(ctx context.Context) (*entities.VerifyResponse, error)
+}
+
+// Core implements ICore
+type Core struct {
+	repo IRepository
+
+	// running is held for the duration of a walk so concurrent requests don't repeat it
+	running sync.Mutex
+}
+
+// Compile-time interface check
+var _ ICore = (*Core)(nil)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance
+func NewCore(_ context.Context, repo IRepository) ICore {
+	coreInstance = &Core{repo: repo}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// Verify walks the chain from the first link to the head as it stood when the walk began,
+// recomputing every hash, and reports the first break. Runs of archived links are stepped
+// over once their first link is shown to follow on from the link before it. Links added
+// during the walk are left for the next verification. An error is only returned if the
+// chain could not be read.
+func (c *Core) Verify(ctx context.Context) (*entities.VerifyResponse, error) {
+	if !c.running.TryLock() {
+		return nil, ErrVerifyInProgress
+	}
+	defer c.running.Unlock()
+
+	headSeq, headHash, err := c.repo.GetHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := c.repo.ListArchivedRuns(ctx, headSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.walk(ctx, headSeq, headHash, runs)
+	if err != nil {
+		return nil, err
+	}
+
+	// A month archived during the walk takes its links out of the table between batches,
+	// which shows up as a gap; walk again with the runs it left behind
+	if brk := response.FirstBreak; brk != nil && brk.Reason == entities.BreakSequenceGap {
+		again, err := c.repo.ListArchivedRuns(ctx, headSeq)
+		if err != nil {
+			return nil, err
+		}
+		if len(again) != len(runs) {
+			if response, err = c.walk(ctx, headSeq, headHash, again); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	response.Valid = response.FirstBreak == nil
+	c.logResult(ctx, response)
+	return response, nil
+}
+
+// walk checks every link up to headSeq, stepping over the archived runs, and then the head
+func (c *Core) walk(ctx context.Context, headSeq int64, headHash []byte, runs []*ArchivedRun) (*entities.VerifyResponse, error) {
+	response := &entities.VerifyResponse{
+		HeadSeq:  headSeq,
+		HeadHash: hex.EncodeToString(headHash),
+	}
+
+	var lastSeq int64
+	prev := make([]byte, entities.HashSize)
+
+	// skipArchived steps over the archived runs that continue the chain from lastSeq
+	skipArchived := func() *entities.ChainBreak {
+		for len(runs) > 0 && runs[0].FirstSeq == lastSeq+1 {
+			run := runs[0]
+			if !bytes.Equal(run.FirstPrevHash, prev) {
+				return &entities.ChainBreak{
+					ChainSeq: run.FirstSeq,
+					Reason:   entities.BreakPrevHash,
+					Expected: hex.EncodeToString(prev),
+					Actual:   hex.EncodeToString(run.FirstPrevHash),
+				}
+			}
+			response.LinksArchived += run.LastSeq - run.FirstSeq + 1
+			lastSeq, prev = run.LastSeq, run.LastHash
+			runs = runs[1:]
+		}
+		return nil
+	}
+
+	for response.FirstBreak == nil {
+		links, err := c.repo.ListLinks(ctx, lastSeq, headSeq, entities.VerifyBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, link := range links {
+			if response.FirstBreak = skipArchived(); response.FirstBreak != nil {
+				break
+			}
+			if response.FirstBreak = checkLink(link, lastSeq, prev); response.FirstBreak != nil {
+				break
+			}
+			response.LinksChecked++
+			lastSeq, prev = link.ChainSeq, link.Hash
+		}
+
+		if len(links) < entities.VerifyBatchSize {
+			break
+		}
+	}
+
+	if response.FirstBreak == nil {
+		response.FirstBreak = skipArchived()
+	}
+
+	if response.FirstBreak == nil && (lastSeq != headSeq || !bytes.Equal(prev, headHash)) {
+		response.FirstBreak = &entities.ChainBreak{
+			ChainSeq: headSeq,
+			Reason:   entities.BreakHead,
+			Expected: hex.EncodeToString(headHash),
+			Actual:   hex.EncodeToString(prev),
+		}
+	}
+
+	return response, nil
+}
+
+// checkLink verifies one link against the link before it
+func checkLink(link *Link, lastSeq int64, prev []byte) *entities.ChainBreak {
+	if link.ChainSeq != lastSeq+1 {
+		return &entities.ChainBreak{
+			ChainSeq:      lastSeq + 1,
+			TransactionID: link.TransactionID.String(),
+			Reason:        entities.BreakSequenceGap,
+			Expected:      strconv.FormatInt(lastSeq+1, 10),
+			Actual:        strconv.FormatInt(link.ChainSeq, 10),
+		}
+	}
+
+	if !bytes.Equal(link.PrevHash, prev) {
+		return &entities.ChainBreak{
+			ChainSeq:      link.ChainSeq,
+			TransactionID: link.TransactionID.String(),
+			Reason:        entities.BreakPrevHash,
+			Expected:      hex.EncodeToString(prev),
+			Actual:        hex.EncodeToString(link.PrevHash),
+		}
+	}
+
+	if computed := link.ComputeHash(prev); !bytes.Equal(computed, link.Hash) {
+		return &entities.ChainBreak{
+			ChainSeq:      link.ChainSeq,
+			TransactionID: link.TransactionID.String(),
+			Reason:        entities.BreakHash,
+			Expected:      hex.EncodeToString(computed),
+			Actual:        hex.EncodeToString(link.Hash),
+		}
+	}
+
+	return nil
+}
+
+// logResult logs the outcome of a verification
+func (c *Core) logResult(ctx context.Context, response *entities.VerifyResponse) {
+	if brk := response.FirstBreak; brk != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgChainBroken,
+			constants.LogFieldChainSeq, brk.ChainSeq,
+			constants.LogFieldTransactionID, brk.TransactionID,
+			constants.LogFieldReason, brk.Reason,
+		)
+		return
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgChainVerified,
+		constants.LogFieldChainSeq, response.HeadSeq,
+		constants.LogFieldChainHash, response.HeadHash,
+	)
+}
@@ -0,0 +1,353 @@
+package chain_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/modules/chain"
+	"github.com/internal-transfers-service/internal/modules/chain/entities"
+	"github.com/internal-transfers-service/internal/modules/chain/mock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in tests
+var errDatabaseError = errors.New("database error")
+
+// CoreTestSuite contains tests for chain Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockRepo *mock.MockIRepository
+	core     chain.ICore
+	ctx      context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.ctx = context.Background()
+	s.core = chain.NewCore(s.ctx, s.mockRepo)
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// buildChain returns n correctly linked transfers starting from the genesis hash
+func buildChain(n int) []*chain.Link {
+	links := make([]*chain.Link, n)
+	prev := make([]byte, entities.HashSize)
+	source := int64(1)
+	for i := range links {
+		link := &chain.Link{
+			ChainSeq:             int64(i + 1),
+			TransactionID:        uuid.New(),
+			SourceAccountID:      &source,
+			DestinationAccountID: 2,
+			Amount:               decimal.NewFromInt(int64(10 * (i + 1))),
+			Type:                 "transfer",
+			CreatedAt:            time.Date(2026, 3, 10, 12, 0, i, 0, time.UTC),
+			EffectiveDate:        time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+			HashVersion:          entities.HashVersionDates,
+			PrevHash:             prev,
+		}
+		link.Hash = link.ComputeHash(prev)
+		links[i] = link
+		prev = link.Hash
+	}
+	return links
+}
+
+// expectChain sets up the head, no archived runs and a single batch of links
+func (s *CoreTestSuite) expectChain(headSeq int64, headHash []byte, links []*chain.Link) {
+	s.mockRepo.EXPECT().GetHead(s.ctx).Return(headSeq, headHash, nil)
+	s.mockRepo.EXPECT().ListArchivedRuns(s.ctx, headSeq).Return([]*chain.ArchivedRun{}, nil)
+	s.mockRepo.EXPECT().ListLinks(s.ctx, int64(0), headSeq, entities.VerifyBatchSize).Return(links, nil)
+}
+
+// archivedRun returns the run covering links[first:last+1]
+func archivedRun(links []*chain.Link, first, last int) *chain.ArchivedRun {
+	return &chain.ArchivedRun{
+		FirstSeq:      links[first].ChainSeq,
+		LastSeq:       links[last].ChainSeq,
+		FirstPrevHash: links[first].PrevHash,
+		LastHash:      links[last].Hash,
+	}
+}
+
+// Test ComputeHash
+
+func (s *CoreTestSuite) TestComputeHashUsesCanonicalContents() {
+	prev := make([]byte, entities.HashSize)
+	link := &chain.Link{
+		TransactionID:        uuid.MustParse("6f1c2a9e-3b4d-4c5e-8f70-1a2b3c4d5e6f"),
+		DestinationAccountID: 42,
+		Amount:               decimal.RequireFromString("100.5"),
+		Type:                 "opening",
+		CreatedAt:            time.Date(2026, 3, 10, 12, 0, 0, 123456000, time.UTC),
+		EffectiveDate:        time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		HashVersion:          entities.HashVersionBase,
+	}
+
+	expected := sha256.Sum256(append(prev, []byte("6f1c2a9e-3b4d-4c5e-8f70-1a2b3c4d5e6f||42|100.50000000|opening|1773144000123456")...))
+	s.Equal(expected[:], link.ComputeHash(prev))
+}
+
+func (s *CoreTestSuite) TestComputeHashCoversDatesFromVersionTwo() {
+	prev := make([]byte, entities.HashSize)
+	source := int64(7)
+	corrects := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	link := &chain.Link{
+		TransactionID:        uuid.MustParse("6f1c2a9e-3b4d-4c5e-8f70-1a2b3c4d5e6f"),
+		SourceAccountID:      &source,
+		DestinationAccountID: 42,
+		Amount:               decimal.RequireFromString("100.5"),
+		Type:                 "transfer",
+		CreatedAt:            time.Date(2026, 3, 10, 12, 0, 0, 123456000, time.UTC),
+		EffectiveDate:        time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+		CorrectsPeriod:       &corrects,
+		HashVersion:          entities.HashVersionDates,
+	}
+
+	expected := sha256.Sum256(append(prev, []byte("6f1c2a9e-3b4d-4c5e-8f70-1a2b3c4d5e6f|7|42|100.50000000|transfer|1773144000123456|2026-02-28|2026-01-01")...))
+	s.Equal(expected[:], link.ComputeHash(prev))
+
+	link.CorrectsPeriod = nil
+	expected = sha256.Sum256(append(prev, []byte("6f1c2a9e-3b4d-4c5e-8f70-1a2b3c4d5e6f|7|42|100.50000000|transfer|1773144000123456|2026-02-28|")...))
+	s.Equal(expected[:], link.ComputeHash(prev))
+}
+
+// Test Verify
+
+func (s *CoreTestSuite) TestVerifyIntactChainIsValid() {
+	links := buildChain(3)
+	s.expectChain(3, links[2].Hash, links)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.True(result.Valid)
+	s.Nil(result.FirstBreak)
+	s.Equal(int64(3), result.LinksChecked)
+	s.Equal(hex.EncodeToString(links[2].Hash), result.HeadHash)
+}
+
+func (s *CoreTestSuite) TestVerifyEmptyChainIsValid() {
+	s.expectChain(0, make([]byte, entities.HashSize), []*chain.Link{})
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.True(result.Valid)
+	s.Zero(result.LinksChecked)
+}
+
+func (s *CoreTestSuite) TestVerifyEditedAmountReportsHashMismatch() {
+	links := buildChain(3)
+	links[1].Amount = decimal.NewFromInt(1)
+	s.expectChain(3, links[2].Hash, links)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.False(result.Valid)
+	s.Require().NotNil(result.FirstBreak)
+	s.Equal(entities.BreakHash, result.FirstBreak.Reason)
+	s.Equal(int64(2), result.FirstBreak.ChainSeq)
+	s.Equal(links[1].TransactionID.String(), result.FirstBreak.TransactionID)
+	s.Equal(int64(1), result.LinksChecked)
+}
+
+func (s *CoreTestSuite) TestVerifyBackdatedEffectiveDateReportsHashMismatch() {
+	links := buildChain(3)
+	links[1].EffectiveDate = time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC)
+	s.expectChain(3, links[2].Hash, links)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.False(result.Valid)
+	s.Require().NotNil(result.FirstBreak)
+	s.Equal(entities.BreakHash, result.FirstBreak.Reason)
+	s.Equal(int64(2), result.FirstBreak.ChainSeq)
+}
+
+func (s *CoreTestSuite) TestVerifyRepointedCorrectionReportsHashMismatch() {
+	links := buildChain(3)
+	period := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	links[2].CorrectsPeriod = &period
+	s.expectChain(3, links[2].Hash, links)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(result.FirstBreak)
+	s.Equal(entities.BreakHash, result.FirstBreak.Reason)
+	s.Equal(int64(3), result.FirstBreak.ChainSeq)
+}
+
+func (s *CoreTestSuite) TestVerifyDowngradedHashVersionReportsHashMismatch() {
+	// Claiming a link predates date hashing does not let its dates be edited
+	links := buildChain(3)
+	links[1].HashVersion = entities.HashVersionBase
+	links[1].EffectiveDate = time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC)
+	s.expectChain(3, links[2].Hash, links)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(result.FirstBreak)
+	s.Equal(entities.BreakHash, result.FirstBreak.Reason)
+	s.Equal(int64(2), result.FirstBreak.ChainSeq)
+}
+
+func (s *CoreTestSuite) TestVerifyChainSpanningHashVersionsIsValid() {
+	links := buildChain(3)
+	links[0].HashVersion = entities.HashVersionBase
+	links[0].Hash = links[0].ComputeHash(links[0].PrevHash)
+	links[1].PrevHash = links[0].Hash
+	links[1].Hash = links[1].ComputeHash(links[1].PrevHash)
+	links[2].PrevHash = links[1].Hash
+	links[2].Hash = links[2].ComputeHash(links[2].PrevHash)
+	s.expectChain(3, links[2].Hash, links)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.True(result.Valid)
+	s.Equal(int64(3), result.LinksChecked)
+}
+
+func (s *CoreTestSuite) TestVerifyRehashedLinkReportsPrevHashMismatchOnNextLink() {
+	links := buildChain(3)
+	links[1].Amount = decimal.NewFromInt(1)
+	links[1].Hash = links[1].ComputeHash(links[1].PrevHash)
+	s.expectChain(3, links[2].Hash, links)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(result.FirstBreak)
+	s.Equal(entities.BreakPrevHash, result.FirstBreak.Reason)
+	s.Equal(int64(3), result.FirstBreak.ChainSeq)
+}
+
+func (s *CoreTestSuite) TestVerifyDeletedLinkReportsSequenceGap() {
+	links := buildChain(3)
+	s.expectChain(3, links[2].Hash, []*chain.Link{links[0], links[2]})
+	s.mockRepo.EXPECT().ListArchivedRuns(s.ctx, int64(3)).Return([]*chain.ArchivedRun{}, nil)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(result.FirstBreak)
+	s.Equal(entities.BreakSequenceGap, result.FirstBreak.Reason)
+	s.Equal(int64(2), result.FirstBreak.ChainSeq)
+	s.Equal("3", result.FirstBreak.Actual)
+}
+
+func (s *CoreTestSuite) TestVerifyDeletedNewestLinkReportsHeadMismatch() {
+	links := buildChain(3)
+	s.expectChain(3, links[2].Hash, links[:2])
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(result.FirstBreak)
+	s.Equal(entities.BreakHead, result.FirstBreak.Reason)
+	s.Equal(hex.EncodeToString(links[1].Hash), result.FirstBreak.Actual)
+}
+
+func (s *CoreTestSuite) TestVerifySkipsArchivedRuns() {
+	links := buildChain(5)
+	runs := []*chain.ArchivedRun{archivedRun(links, 0, 1), archivedRun(links, 3, 3)}
+	s.mockRepo.EXPECT().GetHead(s.ctx).Return(int64(5), links[4].Hash, nil)
+	s.mockRepo.EXPECT().ListArchivedRuns(s.ctx, int64(5)).Return(runs, nil)
+	s.mockRepo.EXPECT().ListLinks(s.ctx, int64(0), int64(5), entities.VerifyBatchSize).
+		Return([]*chain.Link{links[2], links[4]}, nil)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.True(result.Valid)
+	s.Equal(int64(2), result.LinksChecked)
+	s.Equal(int64(3), result.LinksArchived)
+}
+
+func (s *CoreTestSuite) TestVerifyArchivedTailReachesHead() {
+	links := buildChain(3)
+	s.mockRepo.EXPECT().GetHead(s.ctx).Return(int64(3), links[2].Hash, nil)
+	s.mockRepo.EXPECT().ListArchivedRuns(s.ctx, int64(3)).Return([]*chain.ArchivedRun{archivedRun(links, 1, 2)}, nil)
+	s.mockRepo.EXPECT().ListLinks(s.ctx, int64(0), int64(3), entities.VerifyBatchSize).
+		Return([]*chain.Link{links[0]}, nil)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.True(result.Valid)
+	s.Equal(int64(1), result.LinksChecked)
+	s.Equal(int64(2), result.LinksArchived)
+}
+
+func (s *CoreTestSuite) TestVerifyArchivedRunNotFollowingOnReportsPrevHashMismatch() {
+	links := buildChain(3)
+	run := archivedRun(links, 1, 1)
+	run.FirstPrevHash = []byte{0xff}
+	s.mockRepo.EXPECT().GetHead(s.ctx).Return(int64(3), links[2].Hash, nil)
+	s.mockRepo.EXPECT().ListArchivedRuns(s.ctx, int64(3)).Return([]*chain.ArchivedRun{run}, nil)
+	s.mockRepo.EXPECT().ListLinks(s.ctx, int64(0), int64(3), entities.VerifyBatchSize).
+		Return([]*chain.Link{links[0], links[2]}, nil)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.False(result.Valid)
+	s.Require().NotNil(result.FirstBreak)
+	s.Equal(entities.BreakPrevHash, result.FirstBreak.Reason)
+	s.Equal(int64(2), result.FirstBreak.ChainSeq)
+	s.Equal("ff", result.FirstBreak.Actual)
+}
+
+func (s *CoreTestSuite) TestVerifyRetriesWhenMonthArchivedDuringWalk() {
+	links := buildChain(3)
+	s.mockRepo.EXPECT().GetHead(s.ctx).Return(int64(3), links[2].Hash, nil)
+	gomock.InOrder(
+		s.mockRepo.EXPECT().ListArchivedRuns(s.ctx, int64(3)).Return([]*chain.ArchivedRun{}, nil),
+		s.mockRepo.EXPECT().ListLinks(s.ctx, int64(0), int64(3), entities.VerifyBatchSize).
+			Return([]*chain.Link{links[1], links[2]}, nil),
+		s.mockRepo.EXPECT().ListArchivedRuns(s.ctx, int64(3)).Return([]*chain.ArchivedRun{archivedRun(links, 0, 0)}, nil),
+		s.mockRepo.EXPECT().ListLinks(s.ctx, int64(0), int64(3), entities.VerifyBatchSize).
+			Return([]*chain.Link{links[1], links[2]}, nil),
+	)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Require().NoError(err)
+	s.True(result.Valid)
+	s.Equal(int64(2), result.LinksChecked)
+	s.Equal(int64(1), result.LinksArchived)
+}
+
+func (s *CoreTestSuite) TestVerifyWhenHeadReadFailsReturnsError() {
+	s.mockRepo.EXPECT().GetHead(s.ctx).Return(int64(0), nil, errDatabaseError)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Equal(errDatabaseError, err)
+	s.Nil(result)
+}
+
+func (s *CoreTestSuite) TestVerifyWhenLinksReadFailsReturnsError() {
+	s.mockRepo.EXPECT().GetHead(s.ctx).Return(int64(3), []byte{1}, nil)
+	s.mockRepo.EXPECT().ListArchivedRuns(s.ctx, int64(3)).Return([]*chain.ArchivedRun{}, nil)
+	s.mockRepo.EXPECT().ListLinks(s.ctx, int64(0), int64(3), entities.VerifyBatchSize).Return(nil, errDatabaseError)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Equal(errDatabaseError, err)
+	s.Nil(result)
+}
+
+func (s *CoreTestSuite) TestVerifyWhenArchivedRunsReadFailsReturnsError() {
+	s.mockRepo.EXPECT().GetHead(s.ctx).Return(int64(3), []byte{1}, nil)
+	s.mockRepo.EXPECT().ListArchivedRuns(s.ctx, int64(3)).Return(nil, errDatabaseError)
+
+	result, err := s.core.Verify(s.ctx)
+	s.Equal(errDatabaseError, err)
+	s.Nil(result)
+}
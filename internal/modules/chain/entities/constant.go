@@ -0,0 +1,43 @@
+// Package entities provides response types and constants for the chain module.
+package entities
+
+// Error messages for the chain module
+const (
+	ErrMsgVerifyInProgress = "transaction chain verification already running"
+)
+
+// Route path constants for the chain module (admin router)
+const (
+	RouteVerifyChain = "/transaction-chain/verify"
+)
+
+// Reasons a link fails verification
+const (
+	// BreakSequenceGap means a link is missing: chain_seq skipped a value
+	BreakSequenceGap = "sequence_gap"
+	// BreakPrevHash means a link does not point at the hash of the link before it
+	BreakPrevHash = "prev_hash_mismatch"
+	// BreakHash means a link's contents no longer hash to its stored hash
+	BreakHash = "hash_mismatch"
+	// BreakHead means the newest links were removed, or the head was moved, after the head was recorded
+	BreakHead = "head_mismatch"
+)
+
+// Hash versions: which canonical contents a link's hash covers
+const (
+	// HashVersionBase covers id, accounts, amount, type and created_at
+	HashVersionBase = 1
+	// HashVersionDates also covers the effective date and the corrected period
+	HashVersionDates = 2
+)
+
+// DateLayout is how dates appear in a link's canonical contents
+const DateLayout = "2006-01-02"
+
+// Verification limits
+const (
+	// VerifyBatchSize is how many links are read per query while walking the chain
+	VerifyBatchSize = 1000
+	// HashSize is the length in bytes of a link hash (SHA-256)
+	HashSize = 32
+)
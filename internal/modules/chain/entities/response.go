@@ -0,0 +1,24 @@
+package entities
+
+// ChainBreak describes the first link that failed verification
+type ChainBreak struct {
+	ChainSeq      int64  `json:"chain_seq"`
+	TransactionID string `json:"transaction_id,omitempty"`
+	Reason        string `json:"reason"`
+	// Expected and Actual are hex hashes, or chain positions for a sequence gap
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// VerifyResponse is the outcome of walking the chain from the first link to the head
+type VerifyResponse struct {
+	Valid        bool  `json:"valid"`
+	LinksChecked int64 `json:"links_checked"`
+	// LinksArchived counts the links stepped over because their transactions were archived
+	LinksArchived int64 `json:"links_archived"`
+	// HeadSeq and HeadHash identify the head the walk ended at; publishing them elsewhere
+	// lets later verifications prove that nothing up to this point was rewritten
+	HeadSeq    int64       `json:"head_seq"`
+	HeadHash   string      `json:"head_hash"`
+	FirstBreak *ChainBreak `json:"first_break,omitempty"`
+}
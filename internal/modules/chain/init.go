@@ -0,0 +1,61 @@
+// Package chain verifies the tamper-evident hash chain over transactions.
+package chain
+
+import (
+	"context"
+
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var ChainModule IModule
+
+// NewModule initializes the chain module
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool) IModule {
+	if ChainModule == nil {
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper)
+		core := NewCore(ctx, repo)
+		handler := NewHTTPHandler(core)
+
+		ChainModule = &Module{
+			Core:    core,
+			Handler: handler,
+			Repo:    repo,
+		}
+	}
+	return ChainModule
+}
+
+// IModule defines the interface for the chain module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+}
+
+// Module implements IModule
+type Module struct {
+	Core    ICore
+	Handler *HTTPHandler
+	Repo    IRepository
+}
+
+// Compile-time interface check
+var _ IModule = (*Module)(nil)
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
@@ -0,0 +1,186 @@
+package chain
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+	"crypto/sha256"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/chain/entities"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/shopspring/decimal"
+)
+
+// Link is a transaction row as it takes part in the hash chain
+type Link struct {
+	ChainSeq             int64
+	TransactionID        uuid.UUID
+	SourceAccountID      *int64
+	DestinationAccountID int64
+	Amount               decimal.Decimal
+	Type                 string
+	CreatedAt            time.Time
+	EffectiveDate        time.Time
+	CorrectsPeriod       *time.Time
+	// HashVersion is the canonical form the link was hashed with (entities.HashVersion*)
+	HashVersion int
+	PrevHash    []byte
+	Hash        []byte
+}
+
+// ComputeHash returns SHA-256 of prev followed by the link's canonical contents in the
+// form of its hash version. It must match transaction_link_hash in the database, which
+// computes the stored hashes.
+func (l *Link) ComputeHash(prev []byte) []byte {
+	source := ""
+	if l.SourceAccountID != nil {
+		source = strconv.FormatInt(*l.SourceAccountID, 10)
+	}
+	fields := []string{
+		l.TransactionID.String(),
+		source,
+		strconv.FormatInt(l.DestinationAccountID, 10),
+		l.Amount.StringFixed(8),
+		l.Type,
+		strconv.FormatInt(l.CreatedAt.UnixMicro(), 10),
+	}
+	if l.HashVersion >= entities.HashVersionDates {
+		corrects := ""
+		if l.CorrectsPeriod != nil {
+			corrects = l.CorrectsPeriod.Format(entities.DateLayout)
+		}
+		fields = append(fields, l.EffectiveDate.Format(entities.DateLayout), corrects)
+	}
+
+	h := sha256.New()
+	h.Write(prev)
+	h.Write([]byte(strings.Join(fields, "|")))
+	return h.Sum(nil)
+}
+
+// ArchivedRun is a run of consecutive links whose transactions have been archived. Its
+// links were checked when they were archived; the chain continues from LastHash.
+type ArchivedRun struct {
+	FirstSeq      int64
+	LastSeq       int64
+	FirstPrevHash []byte
+	LastHash      []byte
+}
+
+// IRepository defines the interface for hash chain data access
+type IRepository interface {
+	GetHead(ctx context.Context) (int64, []byte, error)
+	ListLinks(ctx context.Context, afterSeq, uptoSeq int64, limit int) ([]*Link, error)
+	ListArchivedRuns(ctx context.Context, uptoSeq int64) ([]*ArchivedRun, error)
+}
+
+// Repository implements IRepository
+type Repository struct {
+	pool database.IPool
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new chain repository
+func NewRepository(pool database.IPool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// SQL queries
+const (
+	querySelectHead = `
+		SELECT seq, hash FROM transaction_chain_head`
+
+	querySelectLinks = `
+		SELECT chain_seq, id, source_account_id, destination_account_id, amount, type, created_at,
+			effective_date, corrects_period, hash_version, prev_hash, hash
+		FROM transactions
+		WHERE chain_seq > $1 AND chain_seq <= $2
+		ORDER BY chain_seq
+		LIMIT $3`
+
+	querySelectArchivedRuns = `
+		SELECT first_seq, last_seq, first_prev_hash, last_hash
+		FROM transaction_chain_archived_runs
+		WHERE first_seq <= $1
+		ORDER BY first_seq`
+)
+
+// GetHead returns the sequence number and hash of the newest link
+func (r *Repository) GetHead(ctx context.Context) (int64, []byte, error) {
+	var seq int64
+	var hash []byte
+	if err := r.pool.QueryRow(ctx, querySelectHead).Scan(&seq, &hash); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadChain,
+			constants.LogKeyError, err,
+		)
+		return 0, nil, err
+	}
+	return seq, hash, nil
+}
+
+// ListLinks returns up to limit links with afterSeq < chain_seq <= uptoSeq, in chain order
+func (r *Repository) ListLinks(ctx context.Context, afterSeq, uptoSeq int64, limit int) ([]*Link, error) {
+	rows, err := r.pool.Query(ctx, querySelectLinks, afterSeq, uptoSeq, limit)
+	if err != nil {
+		return nil, r.logReadFailure(ctx, afterSeq, err)
+	}
+	defer rows.Close()
+
+	links := make([]*Link, 0, limit)
+	for rows.Next() {
+		var l Link
+		if err := rows.Scan(&l.ChainSeq, &l.TransactionID, &l.SourceAccountID, &l.DestinationAccountID,
+			&l.Amount, &l.Type, &l.CreatedAt, &l.EffectiveDate, &l.CorrectsPeriod, &l.HashVersion,
+			&l.PrevHash, &l.Hash); err != nil {
+			return nil, r.logReadFailure(ctx, afterSeq, err)
+		}
+		links = append(links, &l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, r.logReadFailure(ctx, afterSeq, err)
+	}
+
+	return links, nil
+}
+
+// ListArchivedRuns returns the archived runs that start at or before uptoSeq, in chain order
+func (r *Repository) ListArchivedRuns(ctx context.Context, uptoSeq int64) ([]*ArchivedRun, error) {
+	rows, err := r.pool.Query(ctx, querySelectArchivedRuns, uptoSeq)
+	if err != nil {
+		return nil, r.logReadFailure(ctx, 0, err)
+	}
+	defer rows.Close()
+
+	runs := make([]*ArchivedRun, 0)
+	for rows.Next() {
+		var run ArchivedRun
+		if err := rows.Scan(&run.FirstSeq, &run.LastSeq, &run.FirstPrevHash, &run.LastHash); err != nil {
+			return nil, r.logReadFailure(ctx, 0, err)
+		}
+		runs = append(runs, &run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, r.logReadFailure(ctx, 0, err)
+	}
+
+	return runs, nil
+}
+
+// logReadFailure logs a failed chain read and returns the error
+func (r *Repository) logReadFailure(ctx context.Context, afterSeq int64, err error) error {
+	logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadChain,
+		constants.LogFieldChainSeq, afterSeq,
+		constants.LogKeyError, err,
+	)
+	return err
+}
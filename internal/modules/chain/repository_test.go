@@ -0,0 +1,124 @@
+package chain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/internal-transfers-service/internal/modules/chain"
+	"github.com/internal-transfers-service/internal/modules/chain/entities"
+	dbmock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// RepositoryTestSuite contains tests for chain Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockPool *dbmock.MockIPool
+	repo     chain.IRepository
+	ctx      context.Context
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbmock.NewMockIPool(s.ctrl)
+	s.ctx = context.Background()
+	s.repo = chain.NewRepository(s.mockPool)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *RepositoryTestSuite) TestGetHeadScansSeqAndHash() {
+	mockRow := dbmock.NewMockRow(s.ctrl)
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any()).Return(mockRow).Times(1)
+	mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int64) = 7
+		*dest[1].(*[]byte) = []byte{0xab}
+		return nil
+	})
+
+	seq, hash, err := s.repo.GetHead(s.ctx)
+	s.NoError(err)
+	s.Equal(int64(7), seq)
+	s.Equal([]byte{0xab}, hash)
+}
+
+func (s *RepositoryTestSuite) TestGetHeadWhenScanFailsReturnsError() {
+	mockRow := dbmock.NewMockRow(s.ctrl)
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any()).Return(mockRow).Times(1)
+	mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).Return(errDatabaseError)
+
+	_, _, err := s.repo.GetHead(s.ctx)
+	s.Equal(errDatabaseError, err)
+}
+
+func (s *RepositoryTestSuite) TestListLinksScansEachRow() {
+	mockRows := dbmock.NewMockRows(s.ctrl)
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), int64(0), int64(9), 2).Return(mockRows, nil).Times(1)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	mockRows.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = 1
+			*dest[3].(*int64) = 42
+			*dest[9].(*int) = entities.HashVersionDates
+			return nil
+		})
+	mockRows.EXPECT().Err().Return(nil).Times(1)
+	mockRows.EXPECT().Close().Times(1)
+
+	links, err := s.repo.ListLinks(s.ctx, 0, 9, 2)
+	s.NoError(err)
+	s.Require().Len(links, 1)
+	s.Equal(int64(42), links[0].DestinationAccountID)
+	s.Nil(links[0].SourceAccountID)
+	s.Equal(entities.HashVersionDates, links[0].HashVersion)
+}
+
+func (s *RepositoryTestSuite) TestListLinksWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), int64(0), int64(9), 2).Return(nil, errDatabaseError).Times(1)
+
+	links, err := s.repo.ListLinks(s.ctx, 0, 9, 2)
+	s.Equal(errDatabaseError, err)
+	s.Nil(links)
+}
+
+func (s *RepositoryTestSuite) TestListArchivedRunsScansEachRow() {
+	mockRows := dbmock.NewMockRows(s.ctrl)
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), int64(9)).Return(mockRows, nil).Times(1)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	mockRows.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int64) = 1
+		*dest[1].(*int64) = 4
+		return nil
+	})
+	mockRows.EXPECT().Err().Return(nil).Times(1)
+	mockRows.EXPECT().Close().Times(1)
+
+	runs, err := s.repo.ListArchivedRuns(s.ctx, 9)
+	s.NoError(err)
+	s.Require().Len(runs, 1)
+	s.Equal(int64(4), runs[0].LastSeq)
+}
+
+func (s *RepositoryTestSuite) TestListArchivedRunsWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), int64(9)).Return(nil, errDatabaseError).Times(1)
+
+	runs, err := s.repo.ListArchivedRuns(s.ctx, 9)
+	s.Equal(errDatabaseError, err)
+	s.Nil(runs)
+}
@@ -0,0 +1,79 @@
+package chain
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/chain/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+)
+
+// HTTPHandler handles HTTP requests for the transaction hash chain
+type HTTPHandler struct {
+	core ICore
+}
+
+// NewHTTPHandler creates a new HTTPHandler
+func NewHTTPHandler(core ICore) *HTTPHandler {
+	return &HTTPHandler{core: core}
+}
+
+// RegisterAdminRoutes registers the hash chain routes.
+func (h *HTTPHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get(entities.RouteVerifyChain, h.Verify)
+}
+
+// Verify handles GET /transaction-chain/verify. A broken chain is still a 200: the
+// report is the result, and valid is false.
+func (h *HTTPHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	response, err := h.core.Verify(r.Context())
+	if err != nil {
+		if errors.Is(err, ErrVerifyInProgress) {
+			h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeConflict, err, apperror.MsgChainVerifyRunning))
+			return
+		}
+		h.writeErrorWithContext(w, r, apperror.New(apperror.CodeInternalError, err))
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// writeJSON writes a JSON response
+func (h *HTTPHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(status)
+	if data != nil {
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			logger.Error(constants.LogMsgFailedToEncodeResponse, constants.LogKeyError, err)
+		}
+	}
+}
+
+// writeErrorWithContext writes an error response with request ID for tracing
+func (h *HTTPHandler) writeErrorWithContext(w http.ResponseWriter, r *http.Request, err apperror.IError) {
+	requestID := ""
+	if id, ok := r.Context().Value(contextkeys.RequestID).(string); ok {
+		requestID = id
+	}
+
+	response := apperror.ErrorResponse{
+		Error:     err.PublicMessage(),
+		Code:      err.Code().String(),
+		RequestID: requestID,
+		Details:   err.Fields(),
+	}
+
+	// Log error for debugging
+	logger.Ctx(r.Context()).Errorw(constants.LogMsgRequestFailed,
+		constants.LogKeyError, err.Error(),
+		constants.LogKeyStatusCode, err.HTTPStatus(),
+	)
+
+	h.writeJSON(w, err.HTTPStatus(), response)
+}
@@ -0,0 +1,73 @@
+package chain_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/modules/chain"
+	"github.com/internal-transfers-service/internal/modules/chain/entities"
+	"github.com/internal-transfers-service/internal/modules/chain/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for chain HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.router = chi.NewRouter()
+	chain.NewHTTPHandler(s.mockCore).RegisterAdminRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestVerifyBrokenChainReturnsOKWithFirstBreak() {
+	s.mockCore.EXPECT().Verify(gomock.Any()).Return(&entities.VerifyResponse{
+		LinksChecked: 4,
+		HeadSeq:      9,
+		FirstBreak:   &entities.ChainBreak{ChainSeq: 5, Reason: entities.BreakHash},
+	}, nil)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/transaction-chain/verify", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	var result entities.VerifyResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &result))
+	s.False(result.Valid)
+	s.Equal(int64(5), result.FirstBreak.ChainSeq)
+}
+
+func (s *ServerTestSuite) TestVerifyWhileRunningReturnsConflict() {
+	s.mockCore.EXPECT().Verify(gomock.Any()).Return(nil, chain.ErrVerifyInProgress)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/transaction-chain/verify", nil))
+
+	s.Equal(http.StatusConflict, rec.Code)
+}
+
+func (s *ServerTestSuite) TestVerifyWhenCoreFailsReturnsInternalError() {
+	s.mockCore.EXPECT().Verify(gomock.Any()).Return(nil, errDatabaseError)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/transaction-chain/verify", nil))
+
+	s.Equal(http.StatusInternalServerError, rec.Code)
+}
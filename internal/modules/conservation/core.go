@@ -0,0 +1,159 @@
+package conservation
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/metrics"
+	"github.com/internal-transfers-service/internal/modules/conservation/entities"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/shopspring/decimal"
+)
+
+// Domain errors
+var (
+	ErrCheckInProgress = errors.New(entities.ErrMsgCheckInProgress)
+	ErrNoCheck         = errors.New(entities.ErrMsgNoCheck)
+)
+
+// ICore defines the interface for the money conservation check
+type ICore interface {
+	Check(ctx context.Context) (*entities.ReportResponse, error)
+	Latest() (*entities.ReportResponse, error)
+}
+
+// Core implements ICore
+type Core struct {
+	repo  IRepository
+	clock clock.Clock
+
+	// running is held for the duration of a check so scheduled and on-demand checks never overlap
+	running sync.Mutex
+
+	mu     sync.RWMutex
+	latest *entities.ReportResponse
+}
+
+// Compile-time interface check
+var _ ICore = (*Core)(nil)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance
+func NewCore(_ context.Context, repo IRepository, clk clock.Clock) ICore {
+	coreInstance = &Core{
+		repo:  repo,
+		clock: clk,
+	}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// Check verifies, per currency, that money was neither created nor destroyed: the sum of
+// all balances must equal the opening balances, and the sum of customer balances must
+// equal their openings plus deposits and interest, minus withdrawals. A posting that
+// updated one side's balance wrongly breaks both. Violations are logged, and the gauges
+// are only updated when the check completes.
+func (c *Core) Check(ctx context.Context) (*entities.ReportResponse, error) {
+	if !c.running.TryLock() {
+		return nil, ErrCheckInProgress
+	}
+	defer c.running.Unlock()
+
+	totals, err := c.repo.SumMoney(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.ReportResponse{
+		CheckedAt:  c.clock.Now().UTC().Format(time.RFC3339),
+		Status:     entities.StatusConserved,
+		Currencies: make([]*entities.CurrencyConservation, 0, len(totals)),
+	}
+	violations := 0
+	for _, t := range totals {
+		result := c.check(ctx, t)
+		if !result.Conserved {
+			report.Status = entities.StatusViolated
+			violations++
+		}
+		report.Currencies = append(report.Currencies, result)
+	}
+
+	c.publish(ctx, report, violations)
+	return report, nil
+}
+
+// Latest returns the report of the last completed check
+func (c *Core) Latest() (*entities.ReportResponse, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.latest == nil {
+		return nil, ErrNoCheck
+	}
+	return c.latest, nil
+}
+
+// check compares one currency's balances with its money flows and exports the differences
+func (c *Core) check(ctx context.Context, t *MoneyTotals) *entities.CurrencyConservation {
+	totalDiff := t.TotalBalance.Sub(t.Openings)
+	expectedCustomer := t.CustomerOpenings.Add(t.Deposits).Add(t.Interest).Sub(t.Withdrawals)
+	customerDiff := t.CustomerBalance.Sub(expectedCustomer)
+
+	c.report(ctx, t.Currency, entities.ScopeTotal, t.Openings, t.TotalBalance, totalDiff)
+	c.report(ctx, t.Currency, entities.ScopeCustomer, expectedCustomer, t.CustomerBalance, customerDiff)
+
+	return &entities.CurrencyConservation{
+		Currency:           t.Currency,
+		TotalBalance:       t.TotalBalance.String(),
+		Openings:           t.Openings.String(),
+		TotalDifference:    totalDiff.String(),
+		CustomerBalance:    t.CustomerBalance.String(),
+		CustomerOpenings:   t.CustomerOpenings.String(),
+		Deposits:           t.Deposits.String(),
+		Withdrawals:        t.Withdrawals.String(),
+		Interest:           t.Interest.String(),
+		CustomerDifference: customerDiff.String(),
+		Conserved:          totalDiff.IsZero() && customerDiff.IsZero(),
+	}
+}
+
+// report exports the difference of one scope and logs it when it is not zero
+func (c *Core) report(ctx context.Context, currency, scope string, expected, actual, difference decimal.Decimal) {
+	metrics.SetConservationDifference(currency, scope, difference.InexactFloat64())
+	if difference.IsZero() {
+		return
+	}
+
+	logger.Ctx(ctx).Errorw(constants.LogMsgConservationViolated,
+		constants.LogFieldCurrency, currency,
+		constants.LogFieldScope, scope,
+		constants.LogFieldExpected, expected.String(),
+		constants.LogFieldActual, actual.String(),
+	)
+}
+
+// publish exports a completed check's outcome and keeps the report for Latest
+func (c *Core) publish(ctx context.Context, report *entities.ReportResponse, violations int) {
+	metrics.SetConservationResult(violations, float64(c.clock.Now().Unix()))
+
+	c.mu.Lock()
+	c.latest = report
+	c.mu.Unlock()
+
+	logger.Ctx(ctx).Infow(constants.LogMsgConservationChecked,
+		constants.LogFieldViolations, violations,
+	)
+}
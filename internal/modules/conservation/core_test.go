@@ -0,0 +1,156 @@
+package conservation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/metrics"
+	"github.com/internal-transfers-service/internal/modules/conservation"
+	"github.com/internal-transfers-service/internal/modules/conservation/entities"
+	"github.com/internal-transfers-service/internal/modules/conservation/mock"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in tests
+var errDatabaseError = errors.New("database error")
+
+// CoreTestSuite contains tests for conservation Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockRepo *mock.MockIRepository
+	core     conservation.ICore
+	ctx      context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.ctx = context.Background()
+	s.core = conservation.NewCore(s.ctx, s.mockRepo, clock.NewFake(time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)))
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// totals builds a currency's totals in which 1000 was opened on customer accounts, 300
+// deposited, 100 withdrawn and 5 paid as interest. The clearing account is at -200 and
+// the interest-expense account at -5, so the customers hold 1205 and everything sums to 1000.
+func totals(currency string) *conservation.MoneyTotals {
+	return &conservation.MoneyTotals{
+		Currency:         currency,
+		TotalBalance:     decimal.NewFromInt(1000),
+		CustomerBalance:  decimal.NewFromInt(1205),
+		Openings:         decimal.NewFromInt(1000),
+		CustomerOpenings: decimal.NewFromInt(1000),
+		Deposits:         decimal.NewFromInt(300),
+		Withdrawals:      decimal.NewFromInt(100),
+		Interest:         decimal.NewFromInt(5),
+	}
+}
+
+// Test Check
+
+func (s *CoreTestSuite) TestCheckWithConservedMoneyReportsConserved() {
+	s.mockRepo.EXPECT().SumMoney(s.ctx).Return([]*conservation.MoneyTotals{totals("EUR"), totals("USD")}, nil).Times(1)
+
+	report, err := s.core.Check(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(entities.StatusConserved, report.Status)
+	s.Equal("2026-03-10T12:00:00Z", report.CheckedAt)
+	s.Require().Len(report.Currencies, 2)
+	s.True(report.Currencies[0].Conserved)
+	s.Equal("0", report.Currencies[0].TotalDifference)
+	s.Equal("0", report.Currencies[0].CustomerDifference)
+	s.Equal(float64(0), testutil.ToFloat64(metrics.ConservationViolations))
+	s.Equal(float64(time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC).Unix()), testutil.ToFloat64(metrics.ConservationLastCheck))
+}
+
+func (s *CoreTestSuite) TestCheckDetectsCreditWithoutMatchingDebit() {
+	// A transfer credited the destination but left the source untouched
+	broken := totals("USD")
+	broken.TotalBalance = decimal.NewFromInt(1050)
+	broken.CustomerBalance = decimal.NewFromInt(1255)
+	s.mockRepo.EXPECT().SumMoney(s.ctx).Return([]*conservation.MoneyTotals{totals("EUR"), broken}, nil).Times(1)
+
+	report, err := s.core.Check(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(entities.StatusViolated, report.Status)
+	s.True(report.Currencies[0].Conserved)
+	s.False(report.Currencies[1].Conserved)
+	s.Equal("50", report.Currencies[1].TotalDifference)
+	s.Equal("50", report.Currencies[1].CustomerDifference)
+	s.Equal(float64(1), testutil.ToFloat64(metrics.ConservationViolations))
+	s.Equal(float64(50), testutil.ToFloat64(metrics.ConservationDifference.WithLabelValues("USD", entities.ScopeTotal)))
+}
+
+func (s *CoreTestSuite) TestCheckDetectsLossOnSystemAccount() {
+	// A deposit credited the customer but never debited the clearing account
+	broken := totals("USD")
+	broken.TotalBalance = decimal.NewFromInt(1300)
+	s.mockRepo.EXPECT().SumMoney(s.ctx).Return([]*conservation.MoneyTotals{broken}, nil).Times(1)
+
+	report, err := s.core.Check(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(entities.StatusViolated, report.Status)
+	s.Equal("300", report.Currencies[0].TotalDifference)
+	s.Equal("0", report.Currencies[0].CustomerDifference)
+}
+
+func (s *CoreTestSuite) TestCheckWhenRepositoryFailsReturnsErrorAndKeepsPreviousReport() {
+	s.mockRepo.EXPECT().SumMoney(s.ctx).Return([]*conservation.MoneyTotals{totals("USD")}, nil).Times(1)
+	_, err := s.core.Check(s.ctx)
+	s.Require().NoError(err)
+
+	s.mockRepo.EXPECT().SumMoney(s.ctx).Return(nil, errDatabaseError).Times(1)
+	report, err := s.core.Check(s.ctx)
+	s.Equal(errDatabaseError, err)
+	s.Nil(report)
+
+	latest, err := s.core.Latest()
+	s.Require().NoError(err)
+	s.Equal(entities.StatusConserved, latest.Status)
+}
+
+func (s *CoreTestSuite) TestCheckWhileCheckingReturnsCheckInProgress() {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s.mockRepo.EXPECT().SumMoney(gomock.Any()).
+		DoAndReturn(func(context.Context) ([]*conservation.MoneyTotals, error) {
+			close(started)
+			<-release
+			return nil, nil
+		})
+
+	done := make(chan error)
+	go func() {
+		_, err := s.core.Check(s.ctx)
+		done <- err
+	}()
+	<-started
+
+	_, err := s.core.Check(s.ctx)
+	s.ErrorIs(err, conservation.ErrCheckInProgress)
+
+	close(release)
+	s.NoError(<-done)
+}
+
+// Test Latest
+
+func (s *CoreTestSuite) TestLatestBeforeAnyCheckReturnsNoCheck() {
+	report, err := s.core.Latest()
+	s.ErrorIs(err, conservation.ErrNoCheck)
+	s.Nil(report)
+}
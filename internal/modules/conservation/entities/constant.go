@@ -0,0 +1,31 @@
+// Package entities provides response types and constants for the conservation module.
+package entities
+
+// Error messages for the conservation module
+const (
+	ErrMsgCheckInProgress = "money conservation check already running"
+	ErrMsgNoCheck         = "no money conservation check has completed yet"
+)
+
+// Route path constants for the conservation module (admin router)
+const (
+	RouteConservation = "/conservation"
+)
+
+// Check outcomes (ReportResponse.Status)
+const (
+	// StatusConserved means every currency's balances match its money flows
+	StatusConserved = "conserved"
+	// StatusViolated means money was created or destroyed in at least one currency
+	StatusViolated = "violated"
+)
+
+// Conservation scopes, reported as the scope label of the difference gauge
+const (
+	// ScopeTotal compares the sum of all balances, system accounts included, with the
+	// opening balances; every other posting moves money between two accounts
+	ScopeTotal = "total"
+	// ScopeCustomer compares the sum of customer balances with the money that entered
+	// and left through openings, deposits, interest and withdrawals
+	ScopeCustomer = "customer"
+)
@@ -0,0 +1,30 @@
+package entities
+
+// CurrencyConservation is the conservation check of one currency. Each difference is the
+// actual balance minus the expected one and is zero when money is conserved.
+type CurrencyConservation struct {
+	Currency string `json:"currency"`
+
+	// TotalBalance sums every account, system accounts included; it must equal Openings
+	TotalBalance    string `json:"total_balance"`
+	Openings        string `json:"openings"`
+	TotalDifference string `json:"total_difference"`
+
+	// CustomerBalance sums the customer accounts; it must equal their openings plus
+	// deposits and interest, minus withdrawals
+	CustomerBalance    string `json:"customer_balance"`
+	CustomerOpenings   string `json:"customer_openings"`
+	Deposits           string `json:"deposits"`
+	Withdrawals        string `json:"withdrawals"`
+	Interest           string `json:"interest"`
+	CustomerDifference string `json:"customer_difference"`
+
+	Conserved bool `json:"conserved"`
+}
+
+// ReportResponse is the outcome of one money conservation check
+type ReportResponse struct {
+	CheckedAt  string                  `json:"checked_at"`
+	Status     string                  `json:"status"`
+	Currencies []*CurrencyConservation `json:"currencies"`
+}
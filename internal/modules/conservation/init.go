@@ -0,0 +1,107 @@
+// Package conservation checks that the system neither creates nor destroys money.
+package conservation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var ConservationModule IModule
+
+// NewModule initializes the conservation module
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool) IModule {
+	if ConservationModule == nil {
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper)
+		core := NewCore(ctx, repo, clock.Real{})
+		handler := NewHTTPHandler(core)
+
+		ConservationModule = &Module{
+			Core:    core,
+			Handler: handler,
+			Repo:    repo,
+		}
+	}
+	return ConservationModule
+}
+
+// IModule defines the interface for the conservation module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+	StartWorker(ctx context.Context, interval time.Duration)
+	StopWorker()
+}
+
+// Module implements IModule
+type Module struct {
+	Core       ICore
+	Handler    *HTTPHandler
+	Repo       IRepository
+	cancelFunc context.CancelFunc
+}
+
+// Compile-time interface check
+var _ IModule = (*Module)(nil)
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
+
+// StartWorker starts a background goroutine that checks money conservation on every tick
+func (m *Module) StartWorker(ctx context.Context, interval time.Duration) {
+	workerCtx, cancel := context.WithCancel(ctx)
+	m.cancelFunc = cancel
+
+	go m.runLoop(workerCtx, interval)
+}
+
+// StopWorker stops the conservation worker
+func (m *Module) StopWorker() {
+	if m.cancelFunc != nil {
+		m.cancelFunc()
+	}
+}
+
+// runLoop checks immediately and then on every tick
+func (m *Module) runLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	m.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.run(ctx)
+		}
+	}
+}
+
+// run checks once and logs failures; a check already in progress is not an error
+func (m *Module) run(ctx context.Context) {
+	if _, err := m.Core.Check(ctx); err != nil && !errors.Is(err, ErrCheckInProgress) && ctx.Err() == nil {
+		logger.Error(constants.LogMsgConservationCheckFailed, constants.LogKeyError, err)
+	}
+}
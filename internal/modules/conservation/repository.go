@@ -0,0 +1,114 @@
+package conservation
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/shopspring/decimal"
+)
+
+// MoneyTotals are the balances of one currency alongside the money that entered and left it
+type MoneyTotals struct {
+	Currency        string
+	TotalBalance    decimal.Decimal
+	CustomerBalance decimal.Decimal
+	// Openings sums every opening entry; CustomerOpenings only those of customer accounts
+	Openings         decimal.Decimal
+	CustomerOpenings decimal.Decimal
+	Deposits         decimal.Decimal
+	Withdrawals      decimal.Decimal
+	Interest         decimal.Decimal
+}
+
+// IRepository defines the interface for money conservation data access
+type IRepository interface {
+	SumMoney(ctx context.Context) ([]*MoneyTotals, error)
+}
+
+// Repository implements IRepository
+type Repository struct {
+	pool database.IPool
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new conservation repository
+func NewRepository(pool database.IPool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// SQL queries
+const (
+	// Balances and flows are summed in a single statement, so they are read from one
+	// snapshot: a posting updates both balances and inserts its transaction in one database
+	// transaction, and is seen either entirely or not at all. Every posting moves money
+	// between two accounts of one currency, so each flow takes the currency of its
+	// destination. Archived months count through their totals, whose credits are the
+	// amounts each account received.
+	querySumMoney = `
+		WITH balances AS (
+			SELECT currency,
+				SUM(balance) AS total,
+				COALESCE(SUM(balance) FILTER (WHERE kind = 'customer'), 0) AS customer
+			FROM accounts
+			GROUP BY currency
+		), flows AS (
+			SELECT a.currency,
+				COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'opening'), 0) AS openings,
+				COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'opening' AND a.kind = 'customer'), 0) AS customer_openings,
+				COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'deposit'), 0) AS deposits,
+				COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'withdrawal'), 0) AS withdrawals,
+				COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'interest'), 0) AS interest
+			FROM (
+				SELECT type, destination_account_id, amount FROM transactions
+				UNION ALL
+				SELECT type, account_id, credits FROM transaction_archive_totals
+			) t
+			JOIN accounts a ON a.account_id = t.destination_account_id
+			GROUP BY a.currency
+		)
+		SELECT b.currency, b.total, b.customer,
+			COALESCE(f.openings, 0), COALESCE(f.customer_openings, 0),
+			COALESCE(f.deposits, 0), COALESCE(f.withdrawals, 0), COALESCE(f.interest, 0)
+		FROM balances b
+		LEFT JOIN flows f ON f.currency = b.currency
+		ORDER BY b.currency`
+)
+
+// SumMoney returns the balances and money flows of every currency that has accounts
+func (r *Repository) SumMoney(ctx context.Context) ([]*MoneyTotals, error) {
+	rows, err := r.pool.Query(ctx, querySumMoney)
+	if err != nil {
+		return nil, r.logSumFailure(ctx, err)
+	}
+	defer rows.Close()
+
+	totals := make([]*MoneyTotals, 0)
+	for rows.Next() {
+		var t MoneyTotals
+		if err := rows.Scan(&t.Currency, &t.TotalBalance, &t.CustomerBalance, &t.Openings, &t.CustomerOpenings,
+			&t.Deposits, &t.Withdrawals, &t.Interest); err != nil {
+			return nil, r.logSumFailure(ctx, err)
+		}
+		totals = append(totals, &t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, r.logSumFailure(ctx, err)
+	}
+
+	return totals, nil
+}
+
+// logSumFailure logs a failed sum and returns the error
+func (r *Repository) logSumFailure(ctx context.Context, err error) error {
+	logger.Ctx(ctx).Errorw(constants.LogMsgFailedToSumMoney,
+		constants.LogKeyError, err,
+	)
+	return err
+}
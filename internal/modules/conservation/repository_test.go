@@ -0,0 +1,73 @@
+package conservation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/internal-transfers-service/internal/modules/conservation"
+	dbmock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// RepositoryTestSuite contains tests for conservation Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockPool *dbmock.MockIPool
+	repo     conservation.IRepository
+	ctx      context.Context
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbmock.NewMockIPool(s.ctrl)
+	s.ctx = context.Background()
+	s.repo = conservation.NewRepository(s.mockPool)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *RepositoryTestSuite) TestSumMoneyScansEachCurrency() {
+	mockRows := dbmock.NewMockRows(s.ctrl)
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any()).Return(mockRows, nil).Times(1)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	mockRows.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*string) = "USD"
+			*dest[1].(*decimal.Decimal) = decimal.NewFromInt(1000)
+			*dest[2].(*decimal.Decimal) = decimal.NewFromInt(1205)
+			*dest[3].(*decimal.Decimal) = decimal.NewFromInt(1000)
+			*dest[5].(*decimal.Decimal) = decimal.NewFromInt(300)
+			return nil
+		}).
+		Times(1)
+	mockRows.EXPECT().Err().Return(nil).Times(1)
+	mockRows.EXPECT().Close().Times(1)
+
+	totals, err := s.repo.SumMoney(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Len(totals, 1)
+	s.Equal("USD", totals[0].Currency)
+	s.True(totals[0].CustomerBalance.Equal(decimal.NewFromInt(1205)))
+	s.True(totals[0].Deposits.Equal(decimal.NewFromInt(300)))
+}
+
+func (s *RepositoryTestSuite) TestSumMoneyWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any()).Return(nil, errDatabaseError).Times(1)
+
+	totals, err := s.repo.SumMoney(s.ctx)
+	s.ErrorIs(err, errDatabaseError)
+	s.Nil(totals)
+}
@@ -0,0 +1,102 @@
+package conservation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/conservation/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+)
+
+// HTTPHandler handles HTTP requests for the money conservation check
+type HTTPHandler struct {
+	core ICore
+}
+
+// NewHTTPHandler creates a new HTTPHandler
+func NewHTTPHandler(core ICore) *HTTPHandler {
+	return &HTTPHandler{core: core}
+}
+
+// RegisterAdminRoutes registers the money conservation routes.
+func (h *HTTPHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get(entities.RouteConservation, h.GetLatest)
+	r.Post(entities.RouteConservation, h.Check)
+}
+
+// GetLatest handles GET /conservation with the report of the last completed check. The
+// status code can be alerted on directly: 200 when money is conserved, 500 when it is
+// not and 503 until the first check completes.
+func (h *HTTPHandler) GetLatest(w http.ResponseWriter, r *http.Request) {
+	report, err := h.core.Latest()
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeServiceUnavailable, err, apperror.MsgNoConservationCheck))
+		return
+	}
+
+	h.writeReport(w, report)
+}
+
+// Check handles POST /conservation by running a check and returning its report, with the
+// same status codes as GET
+func (h *HTTPHandler) Check(w http.ResponseWriter, r *http.Request) {
+	report, err := h.core.Check(r.Context())
+	if err != nil {
+		if errors.Is(err, ErrCheckInProgress) {
+			h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeConflict, err, apperror.MsgConservationRunning))
+			return
+		}
+		h.writeErrorWithContext(w, r, apperror.New(apperror.CodeInternalError, err))
+		return
+	}
+
+	h.writeReport(w, report)
+}
+
+// writeReport writes a report with 200 if money is conserved and 500 if it is not
+func (h *HTTPHandler) writeReport(w http.ResponseWriter, report *entities.ReportResponse) {
+	status := http.StatusOK
+	if report.Status != entities.StatusConserved {
+		status = http.StatusInternalServerError
+	}
+	h.writeJSON(w, status, report)
+}
+
+// writeJSON writes a JSON response
+func (h *HTTPHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(status)
+	if data != nil {
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			logger.Error(constants.LogMsgFailedToEncodeResponse, constants.LogKeyError, err)
+		}
+	}
+}
+
+// writeErrorWithContext writes an error response with request ID for tracing
+func (h *HTTPHandler) writeErrorWithContext(w http.ResponseWriter, r *http.Request, err apperror.IError) {
+	requestID := ""
+	if id, ok := r.Context().Value(contextkeys.RequestID).(string); ok {
+		requestID = id
+	}
+
+	response := apperror.ErrorResponse{
+		Error:     err.PublicMessage(),
+		Code:      err.Code().String(),
+		RequestID: requestID,
+		Details:   err.Fields(),
+	}
+
+	// Log error for debugging
+	logger.Ctx(r.Context()).Errorw(constants.LogMsgRequestFailed,
+		constants.LogKeyError, err.Error(),
+		constants.LogKeyStatusCode, err.HTTPStatus(),
+	)
+
+	h.writeJSON(w, err.HTTPStatus(), response)
+}
@@ -0,0 +1,106 @@
+package conservation_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/modules/conservation"
+	"github.com/internal-transfers-service/internal/modules/conservation/entities"
+	"github.com/internal-transfers-service/internal/modules/conservation/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for conservation HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.router = chi.NewRouter()
+	conservation.NewHTTPHandler(s.mockCore).RegisterAdminRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestGetLatestWhenConservedReturnsOK() {
+	s.mockCore.EXPECT().Latest().Return(&entities.ReportResponse{Status: entities.StatusConserved}, nil)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/conservation", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *ServerTestSuite) TestGetLatestWhenViolatedReturnsInternalServerErrorWithReport() {
+	s.mockCore.EXPECT().Latest().Return(&entities.ReportResponse{
+		Status:     entities.StatusViolated,
+		Currencies: []*entities.CurrencyConservation{{Currency: "USD", TotalDifference: "50"}},
+	}, nil)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/conservation", nil))
+
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	var report entities.ReportResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &report))
+	s.Equal(entities.StatusViolated, report.Status)
+	s.Equal("50", report.Currencies[0].TotalDifference)
+}
+
+func (s *ServerTestSuite) TestGetLatestBeforeAnyCheckReturnsServiceUnavailable() {
+	s.mockCore.EXPECT().Latest().Return(nil, conservation.ErrNoCheck)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/conservation", nil))
+
+	s.Equal(http.StatusServiceUnavailable, rec.Code)
+	var body apperror.ErrorResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	s.Equal(apperror.MsgNoConservationCheck, body.Error)
+}
+
+func (s *ServerTestSuite) TestCheckReturnsReport() {
+	s.mockCore.EXPECT().Check(gomock.Any()).Return(&entities.ReportResponse{Status: entities.StatusConserved}, nil)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/conservation", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *ServerTestSuite) TestCheckWhileCheckingReturnsConflict() {
+	s.mockCore.EXPECT().Check(gomock.Any()).Return(nil, conservation.ErrCheckInProgress)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/conservation", nil))
+
+	s.Equal(http.StatusConflict, rec.Code)
+}
+
+func (s *ServerTestSuite) TestCheckWhenRepositoryFailsReturnsInternalError() {
+	s.mockCore.EXPECT().Check(gomock.Any()).Return(nil, errDatabaseError)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/conservation", nil))
+
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	var body apperror.ErrorResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	s.Equal(apperror.CodeInternalError.String(), body.Code)
+}
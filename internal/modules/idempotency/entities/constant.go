@@ -1,6 +1,8 @@
 // Package entities provides types and constants for the idempotency module.
 package entities
 
+import "time"
+
 // Error messages for the idempotency module
 const (
 	ErrMsgKeyNotFound  = "idempotency key not found"
@@ -8,6 +10,11 @@ const (
 	ErrMsgStoreFailed  = "failed to store idempotency key"
 	ErrMsgGetFailed    = "failed to get idempotency key"
 	ErrMsgDeleteFailed = "failed to delete expired keys"
+
+	ErrMsgInvalidBackend  = "unsupported idempotency backend"
+	ErrMsgTxNotSupported  = "idempotency store cannot join a database transaction"
+	ErrMsgReservationLost = "idempotency key is no longer reserved by this request"
+	ErrMsgKeyTaken        = "idempotency key is stored under another scope"
 )
 
 // Error response JSON template parts
@@ -25,13 +32,45 @@ const (
 	// MaxCachedResponseSize is the maximum response body size to cache (64 KB)
 	// Larger responses will not be cached for idempotency
 	MaxCachedResponseSize = 64 * 1024
+
+	// MaxClientIDLength matches idempotency_keys.client_id; longer client IDs are truncated
+	MaxClientIDLength = 255
+)
+
+// Store backends (idempotency.backend configuration)
+const (
+	BackendPostgres = "postgres"
+	BackendMemory   = "memory"
+	BackendTiered   = "tiered"
+)
+
+// AnonymousClient scopes the keys of requests that identify no client
+const AnonymousClient = "anonymous"
+
+// Reservation constants
+const (
+	// StatusInProgress marks a key reserved by a request that has not completed yet
+	StatusInProgress = "in_progress"
+	// StatusCompleted marks a key holding the response of the request that reserved it
+	StatusCompleted = "completed"
+
+	// ReservationLease is how long a reservation holds its key without completing.
+	// It outlives the request timeout, since a handler keeps running after its client
+	// has been told the request timed out, and bounds how long a crashed request blocks retries
+	ReservationLease = 2 * time.Minute
 )
 
 // Database column names
 const (
+	ColClientID       = "client_id"
+	ColMethod         = "method"
+	ColRoute          = "route"
 	ColKey            = "key"
 	ColResponseStatus = "response_status"
+	ColFingerprint    = "fingerprint"
 	ColResponseBody   = "response_body"
+	ColStatus         = "status"
+	ColLeaseExpiresAt = "lease_expires_at"
 	ColCreatedAt      = "created_at"
 )
 
@@ -40,17 +79,26 @@ const (
 	LogMsgIdempotencyHit            = "Idempotency cache hit, returning cached response"
 	LogMsgIdempotencyMiss           = "Idempotency cache miss, processing request"
 	LogMsgIdempotencyStored         = "Stored idempotency response"
+	LogMsgIdempotencyStoredInTx     = "Idempotency response was stored with the request's transaction"
 	LogMsgIdempotencyStoreFailed    = "Failed to store idempotency response"
 	LogMsgIdempotencyGetFailed      = "Failed to check idempotency key"
 	LogMsgIdempotencyKeyTooLong     = "Idempotency key exceeds maximum length"
 	LogMsgIdempotencyCleanup        = "Cleaned up expired idempotency keys"
 	LogMsgIdempotencyCleanupFailed  = "Failed to cleanup expired idempotency keys"
 	LogMsgIdempotencyResponseTooBig = "Response too large to cache for idempotency"
+	LogMsgIdempotencyKeyReused      = "Idempotency key reused for a different request"
+	LogMsgIdempotencyBodyReadFailed = "Failed to read request body for idempotency fingerprint"
+	LogMsgIdempotencyReserveFailed  = "Failed to reserve idempotency key, processing request without it"
+	LogMsgIdempotencyInProgress     = "Idempotency key is held by a request in progress"
+	LogMsgIdempotencyKeyTaken       = "Idempotency key is stored under another scope, rejecting request"
+	LogMsgIdempotencyReleased       = "Released idempotency key reservation"
+	LogMsgIdempotencyReleaseFailed  = "Failed to release idempotency key reservation"
 )
 
 // Log field keys
 const (
 	LogFieldIdempotencyKey = "idempotency_key"
+	LogFieldClientID       = "client_id"
 	LogFieldCachedStatus   = "cached_status"
 	LogFieldDeletedCount   = "deleted_count"
 	LogFieldKeyLength      = "key_length"
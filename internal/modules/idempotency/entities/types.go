@@ -3,10 +3,34 @@ package entities
 
 import "time"
 
+// ScopedKey is an idempotency key as stored: the client's key together with the client
+// that sent it and the route it was sent to, so that keys of different clients, or of
+// one client on different routes, never collide.
+type ScopedKey struct {
+	Client string
+	Method string
+	// Route is the route pattern, such as /v1/accounts/{id}, rather than the request path
+	Route string
+	Key   string
+}
+
 // IdempotencyRecord represents a cached response for an idempotency key.
 type IdempotencyRecord struct {
-	Key            string
+	Key string
+	// Fingerprint identifies the request the key was first used with; empty for keys
+	// stored before requests were fingerprinted
+	Fingerprint string
+	// Status is StatusInProgress while the request that reserved the key runs; such a
+	// record has no response yet
+	Status         string
 	ResponseStatus int
 	ResponseBody   []byte
+	// LeaseExpiresAt is when an in-progress reservation lapses; zero once completed
+	LeaseExpiresAt time.Time
 	CreatedAt      time.Time
 }
+
+// InProgress reports whether the key is reserved by a request that has not completed.
+func (r *IdempotencyRecord) InProgress() bool {
+	return r.Status == StatusInProgress
+}
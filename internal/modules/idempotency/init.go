@@ -5,6 +5,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/internal-transfers-service/internal/config"
 	"github.com/internal-transfers-service/internal/logger"
 	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -26,12 +27,16 @@ type Module struct {
 // Compile-time interface check
 var _ IModule = (*Module)(nil)
 
-// NewModule creates a new idempotency module.
-func NewModule(_ context.Context, pool *pgxpool.Pool) IModule {
-	repo := NewRepository(pool)
+// NewModule creates a new idempotency module keeping keys in the configured backend.
+// It fails if the backend is unknown.
+func NewModule(_ context.Context, pool *pgxpool.Pool, cfg config.IdempotencyConfig) (IModule, error) {
+	repo, err := NewStore(cfg, pool, cfg.GetTTL())
+	if err != nil {
+		return nil, err
+	}
 	return &Module{
 		Repo: repo,
-	}
+	}, nil
 }
 
 // GetRepository returns the idempotency repository.
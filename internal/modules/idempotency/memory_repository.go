@@ -0,0 +1,203 @@
+package idempotency
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/jackc/pgx/v5"
+)
+
+// MemoryRepository implements IRepository in process memory, for local development and
+// tests. It holds at most capacity keys, evicting the least recently used, and treats keys
+// older than the TTL as gone. Keys are lost on restart and are not shared between
+// instances, so it gives no protection against retries that reach another instance.
+type MemoryRepository struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	clk      clock.Clock
+	entries  map[entities.ScopedKey]*list.Element
+	// order holds the records, most recently used first
+	order *list.List
+}
+
+// Compile-time interface check
+var _ IRepository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates an empty in-memory repository holding at most capacity keys
+// for ttl each.
+func NewMemoryRepository(capacity int, ttl time.Duration, clk clock.Clock) *MemoryRepository {
+	return &MemoryRepository{
+		capacity: capacity,
+		ttl:      ttl,
+		clk:      clk,
+		entries:  make(map[entities.ScopedKey]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// memoryEntry is a record together with the key it is held under
+type memoryEntry struct {
+	key    entities.ScopedKey
+	record entities.IdempotencyRecord
+}
+
+// Get retrieves a cached response by key.
+// Returns nil, nil if the key doesn't exist.
+func (m *MemoryRepository) Get(_ context.Context, key entities.ScopedKey) (*entities.IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry == nil {
+		return nil, nil
+	}
+	record := entry.record
+	return &record, nil
+}
+
+// Reserve atomically claims key for the request with the given fingerprint until the
+// lease runs out. It returns true and the caller's reservation if the caller now holds
+// the key; otherwise it returns the record already stored under it. An in-progress
+// reservation whose lease has run out is taken over.
+func (m *MemoryRepository) Reserve(_ context.Context, key entities.ScopedKey, fingerprint string, lease time.Duration) (*entities.IdempotencyRecord, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clk.Now()
+	entry := m.lookup(key)
+	if entry != nil && !(entry.record.InProgress() && entry.record.LeaseExpiresAt.Before(now)) {
+		record := entry.record
+		return &record, false, nil
+	}
+
+	record := entities.IdempotencyRecord{
+		Key:            key.Key,
+		Fingerprint:    fingerprint,
+		Status:         entities.StatusInProgress,
+		LeaseExpiresAt: now.Add(lease),
+		CreatedAt:      now,
+	}
+	m.put(key, record)
+	return &record, true, nil
+}
+
+// Store saves a response for future retrieval, with the fingerprint of the request
+// that produced it, completing the key's reservation. A completed key is left as is.
+func (m *MemoryRepository) Store(_ context.Context, key entities.ScopedKey, fingerprint string, status int, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	createdAt := m.clk.Now()
+	if entry := m.lookup(key); entry != nil {
+		if !entry.record.InProgress() {
+			return nil
+		}
+		createdAt = entry.record.CreatedAt
+	}
+
+	m.put(key, entities.IdempotencyRecord{
+		Key:            key.Key,
+		Fingerprint:    fingerprint,
+		Status:         entities.StatusCompleted,
+		ResponseStatus: status,
+		ResponseBody:   append([]byte(nil), body...),
+		CreatedAt:      createdAt,
+	})
+	return nil
+}
+
+// StoreTx always fails with ErrTxNotSupported: memory cannot take part in a database
+// transaction, so the response is stored with Store once the request completes.
+func (m *MemoryRepository) StoreTx(_ context.Context, _ pgx.Tx, _ entities.ScopedKey, _ string, _ time.Time, _ int, _ []byte) error {
+	return ErrTxNotSupported
+}
+
+// Release drops the key's reservation if it has not completed, so that a retry can
+// run the request again.
+func (m *MemoryRepository) Release(_ context.Context, key entities.ScopedKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok && element.Value.(*memoryEntry).record.InProgress() {
+		m.remove(element)
+	}
+	return nil
+}
+
+// DeleteExpired removes keys older than the specified TTL.
+// Returns the number of deleted keys.
+func (m *MemoryRepository) DeleteExpired(_ context.Context, ttl time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := m.clk.Now().Add(-ttl)
+	var deleted int64
+	for element := m.order.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*memoryEntry).record.CreatedAt.Before(cutoff) {
+			m.remove(element)
+			deleted++
+		}
+		element = next
+	}
+	return deleted, nil
+}
+
+// Len returns the number of keys held, expired ones included until they are removed.
+func (m *MemoryRepository) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// cache holds a record read from another store under key, keeping its creation time so
+// that it expires with the original. The caller must not hold the lock.
+func (m *MemoryRepository) cache(key entities.ScopedKey, record *entities.IdempotencyRecord) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.put(key, *record)
+}
+
+// lookup returns the live entry for key and marks it most recently used. An expired
+// entry is removed and reported missing. The caller must hold the lock.
+func (m *MemoryRepository) lookup(key entities.ScopedKey) *memoryEntry {
+	element, ok := m.entries[key]
+	if !ok {
+		return nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if !m.clk.Now().Before(entry.record.CreatedAt.Add(m.ttl)) {
+		m.remove(element)
+		return nil
+	}
+
+	m.order.MoveToFront(element)
+	return entry
+}
+
+// put stores record under key as the most recently used, evicting the least recently
+// used keys beyond capacity. The caller must hold the lock.
+func (m *MemoryRepository) put(key entities.ScopedKey, record entities.IdempotencyRecord) {
+	if element, ok := m.entries[key]; ok {
+		element.Value.(*memoryEntry).record = record
+		m.order.MoveToFront(element)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, record: record})
+	for m.order.Len() > m.capacity {
+		m.remove(m.order.Back())
+	}
+}
+
+// remove drops an entry. The caller must hold the lock.
+func (m *MemoryRepository) remove(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,143 @@
+package idempotency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/idempotency"
+	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/stretchr/testify/suite"
+)
+
+// testTTL is how long the memory repository under test keeps keys
+const testTTL = time.Hour
+
+// MemoryRepositoryTestSuite contains tests for the in-memory idempotency repository
+type MemoryRepositoryTestSuite struct {
+	suite.Suite
+	clk  *clock.Fake
+	repo *idempotency.MemoryRepository
+	ctx  context.Context
+}
+
+func TestMemoryRepositorySuite(t *testing.T) {
+	suite.Run(t, new(MemoryRepositoryTestSuite))
+}
+
+func (s *MemoryRepositoryTestSuite) SetupTest() {
+	s.clk = clock.NewFake(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC))
+	s.repo = idempotency.NewMemoryRepository(2, testTTL, s.clk)
+	s.ctx = context.Background()
+}
+
+func (s *MemoryRepositoryTestSuite) TestReserveClaimsNewKeyOnce() {
+	record, reserved, err := s.repo.Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute)
+	s.NoError(err)
+	s.True(reserved)
+	s.Require().NotNil(record)
+	s.True(record.InProgress())
+	s.Equal(s.clk.Now(), record.CreatedAt)
+
+	record, reserved, err = s.repo.Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute)
+	s.NoError(err)
+	s.False(reserved)
+	s.Require().NotNil(record)
+	s.True(record.InProgress())
+	s.Equal("fp", record.Fingerprint)
+}
+
+func (s *MemoryRepositoryTestSuite) TestReserveTakesOverExpiredLease() {
+	_, _, _ = s.repo.Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute)
+
+	s.clk.Advance(2 * time.Minute)
+	record, reserved, err := s.repo.Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute)
+
+	s.NoError(err)
+	s.True(reserved)
+	s.Require().NotNil(record)
+	s.Equal(s.clk.Now(), record.CreatedAt, "a takeover is a new reservation")
+}
+
+func (s *MemoryRepositoryTestSuite) TestKeysAreScoped() {
+	other := scopedKey("key-1")
+	other.Client = "other-client"
+
+	_, first, _ := s.repo.Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute)
+	_, second, _ := s.repo.Reserve(s.ctx, other, "fp", time.Minute)
+
+	s.True(first)
+	s.True(second)
+}
+
+func (s *MemoryRepositoryTestSuite) TestStoreCompletesReservationOnce() {
+	_, _, _ = s.repo.Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute)
+
+	s.NoError(s.repo.Store(s.ctx, scopedKey("key-1"), "fp", 201, []byte(`{"id":"first"}`)))
+	s.NoError(s.repo.Store(s.ctx, scopedKey("key-1"), "fp", 201, []byte(`{"id":"second"}`)))
+
+	record, reserved, err := s.repo.Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute)
+	s.NoError(err)
+	s.False(reserved)
+	s.Require().NotNil(record)
+	s.Equal(entities.StatusCompleted, record.Status)
+	s.Equal(201, record.ResponseStatus)
+	s.Equal(`{"id":"first"}`, string(record.ResponseBody))
+}
+
+func (s *MemoryRepositoryTestSuite) TestStoreTxIsNotSupported() {
+	err := s.repo.StoreTx(s.ctx, nil, scopedKey("key-1"), "fp", time.Time{}, 201, nil)
+
+	s.ErrorIs(err, idempotency.ErrTxNotSupported)
+}
+
+func (s *MemoryRepositoryTestSuite) TestReleaseDropsOnlyReservations() {
+	_, _, _ = s.repo.Reserve(s.ctx, scopedKey("reserved"), "fp", time.Minute)
+	_ = s.repo.Store(s.ctx, scopedKey("completed"), "fp", 201, nil)
+
+	s.NoError(s.repo.Release(s.ctx, scopedKey("reserved")))
+	s.NoError(s.repo.Release(s.ctx, scopedKey("completed")))
+
+	record, _ := s.repo.Get(s.ctx, scopedKey("reserved"))
+	s.Nil(record)
+	record, _ = s.repo.Get(s.ctx, scopedKey("completed"))
+	s.NotNil(record)
+}
+
+func (s *MemoryRepositoryTestSuite) TestGetTreatsKeysPastTTLAsGone() {
+	_ = s.repo.Store(s.ctx, scopedKey("key-1"), "fp", 201, nil)
+
+	s.clk.Advance(testTTL)
+	record, err := s.repo.Get(s.ctx, scopedKey("key-1"))
+
+	s.NoError(err)
+	s.Nil(record)
+	s.Equal(0, s.repo.Len())
+}
+
+func (s *MemoryRepositoryTestSuite) TestEvictsLeastRecentlyUsedBeyondCapacity() {
+	_ = s.repo.Store(s.ctx, scopedKey("key-1"), "fp", 201, nil)
+	_ = s.repo.Store(s.ctx, scopedKey("key-2"), "fp", 201, nil)
+	_, _ = s.repo.Get(s.ctx, scopedKey("key-1"))
+
+	_ = s.repo.Store(s.ctx, scopedKey("key-3"), "fp", 201, nil)
+
+	s.Equal(2, s.repo.Len())
+	record, _ := s.repo.Get(s.ctx, scopedKey("key-2"))
+	s.Nil(record, "key-2 was least recently used")
+	record, _ = s.repo.Get(s.ctx, scopedKey("key-1"))
+	s.NotNil(record)
+}
+
+func (s *MemoryRepositoryTestSuite) TestDeleteExpiredRemovesOldKeys() {
+	_ = s.repo.Store(s.ctx, scopedKey("old"), "fp", 201, nil)
+	s.clk.Advance(30 * time.Minute)
+	_ = s.repo.Store(s.ctx, scopedKey("new"), "fp", 201, nil)
+
+	deleted, err := s.repo.DeleteExpired(s.ctx, 15*time.Minute)
+
+	s.NoError(err)
+	s.Equal(int64(1), deleted)
+	s.Equal(1, s.repo.Len())
+}
@@ -7,16 +7,38 @@ import (
 	"time"
 
 	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	"github.com/jackc/pgx/v5"
 )
 
 // IRepository defines the interface for idempotency storage operations.
+// Keys stored before keys were scoped are still found by Get and Reserve under any scope
+// until they expire.
 type IRepository interface {
 	// Get retrieves a cached response by key.
 	// Returns nil, nil if the key doesn't exist.
-	Get(ctx context.Context, key string) (*entities.IdempotencyRecord, error)
+	Get(ctx context.Context, key entities.ScopedKey) (*entities.IdempotencyRecord, error)
 
-	// Store saves a response for future retrieval.
-	Store(ctx context.Context, key string, status int, body []byte) error
+	// Reserve atomically claims key for the request with the given fingerprint until the
+	// lease runs out. It returns true and the caller's reservation if the caller now holds
+	// the key; otherwise it returns the record already stored under it, which is nil if that
+	// was released meanwhile. An in-progress reservation whose lease has run out is taken over.
+	// It fails with ErrKeyTaken if the key is stored under another scope and the store
+	// cannot hold it under two.
+	Reserve(ctx context.Context, key entities.ScopedKey, fingerprint string, lease time.Duration) (*entities.IdempotencyRecord, bool, error)
+
+	// Store saves a response for future retrieval, with the fingerprint of the request
+	// that produced it, completing the key's reservation. A completed key is left as is.
+	Store(ctx context.Context, key entities.ScopedKey, fingerprint string, status int, body []byte) error
+
+	// StoreTx completes the reservation Reserve made at reservedAt inside tx: the response is
+	// only stored if tx commits. It fails with ErrReservationLost if the key has been
+	// completed or taken over since, so that tx rolls back rather than commit work the key
+	// no longer vouches for.
+	StoreTx(ctx context.Context, tx pgx.Tx, key entities.ScopedKey, fingerprint string, reservedAt time.Time, status int, body []byte) error
+
+	// Release drops the key's reservation if it has not completed, so that a retry can
+	// run the request again.
+	Release(ctx context.Context, key entities.ScopedKey) error
 
 	// DeleteExpired removes keys older than the specified TTL.
 	// Returns the number of deleted keys.
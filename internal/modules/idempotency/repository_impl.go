@@ -7,6 +7,7 @@ import (
 
 	"github.com/internal-transfers-service/internal/constants"
 	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	"github.com/internal-transfers-service/pkg/database"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -24,20 +25,33 @@ func NewRepository(pool *pgxpool.Pool) *Repository {
 	return &Repository{pool: pool}
 }
 
+// legacyScope matches keys stored before keys were scoped, which have an empty client,
+// method and route. They are read under any scope until they expire.
+const legacyScope = `client_id = '' AND method = '' AND route = ''`
+
 // Get retrieves a cached response by key.
 // Returns nil, nil if the key doesn't exist.
-func (r *Repository) Get(ctx context.Context, key string) (*entities.IdempotencyRecord, error) {
+func (r *Repository) Get(ctx context.Context, key entities.ScopedKey) (*entities.IdempotencyRecord, error) {
+	// A scoped key sorts before a legacy one with the same name
 	query := `
-		SELECT key, response_status, response_body, created_at
-		FROM idempotency_keys
-		WHERE key = $1
+		SELECT key, COALESCE(fingerprint, ''), status, COALESCE(response_status, 0), response_body,
+			lease_expires_at, created_at
+		FROM ` + constants.TableIdempotencyKeys + `
+		WHERE key = $4
+			AND ((client_id = $1 AND method = $2 AND route = $3) OR (` + legacyScope + `))
+		ORDER BY client_id = ''
+		LIMIT 1
 	`
 
 	record := &entities.IdempotencyRecord{}
-	err := r.pool.QueryRow(ctx, query, key).Scan(
+	var leaseExpiresAt *time.Time
+	err := r.pool.QueryRow(ctx, query, key.Client, key.Method, key.Route, key.Key).Scan(
 		&record.Key,
+		&record.Fingerprint,
+		&record.Status,
 		&record.ResponseStatus,
 		&record.ResponseBody,
+		&leaseExpiresAt,
 		&record.CreatedAt,
 	)
 
@@ -48,18 +62,123 @@ func (r *Repository) Get(ctx context.Context, key string) (*entities.Idempotency
 		return nil, err
 	}
 
+	if leaseExpiresAt != nil {
+		record.LeaseExpiresAt = *leaseExpiresAt
+	}
 	return record, nil
 }
 
-// Store saves a response for future retrieval.
-func (r *Repository) Store(ctx context.Context, key string, status int, body []byte) error {
+// Reserve atomically claims key for the request with the given fingerprint until the
+// lease runs out. It returns true and the caller's reservation if the caller now holds
+// the key; otherwise it returns the record already stored under it, which is nil if that
+// was released meanwhile. An in-progress reservation whose lease has run out is taken over.
+func (r *Repository) Reserve(ctx context.Context, key entities.ScopedKey, fingerprint string, lease time.Duration) (*entities.IdempotencyRecord, bool, error) {
+	// The conflicting row is locked by ON CONFLICT, so of two concurrent requests exactly
+	// one inserts or takes over the key and the other sees it held. A legacy key with the
+	// same name is not reserved over, so that its response is replayed
 	query := `
-		INSERT INTO idempotency_keys (key, response_status, response_body, created_at)
-		VALUES ($1, $2, $3, $4)
-		ON CONFLICT (key) DO NOTHING
+		INSERT INTO ` + constants.TableIdempotencyKeys + ` (client_id, method, route, key, fingerprint, status, lease_expires_at, created_at)
+		SELECT $1, $2, $3, $4, $5, '` + entities.StatusInProgress + `', $6::timestamptz, $7::timestamptz
+		WHERE NOT EXISTS (
+			SELECT 1 FROM ` + constants.TableIdempotencyKeys + ` WHERE key = $4 AND ` + legacyScope + `
+		)
+		ON CONFLICT (client_id, method, route, key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint,
+			lease_expires_at = EXCLUDED.lease_expires_at,
+			created_at = EXCLUDED.created_at
+		WHERE ` + constants.TableIdempotencyKeys + `.status = '` + entities.StatusInProgress + `'
+			AND ` + constants.TableIdempotencyKeys + `.lease_expires_at < EXCLUDED.created_at
+	`
+
+	// created_at identifies the reservation to StoreTx, so it is kept at the precision
+	// PostgreSQL stores
+	now := time.Now().Truncate(time.Microsecond)
+	result, err := r.pool.Exec(ctx, query, key.Client, key.Method, key.Route, key.Key, fingerprint, now.Add(lease), now)
+	if err != nil {
+		// Only the key primary key left by migration 000022 can be violated: the scoped
+		// unique index is the conflict target
+		if database.IsUniqueViolation(err) {
+			return nil, false, ErrKeyTaken
+		}
+		return nil, false, err
+	}
+	if result.RowsAffected() == 1 {
+		return &entities.IdempotencyRecord{
+			Key:            key.Key,
+			Fingerprint:    fingerprint,
+			Status:         entities.StatusInProgress,
+			LeaseExpiresAt: now.Add(lease),
+			CreatedAt:      now,
+		}, true, nil
+	}
+
+	record, err := r.Get(ctx, key)
+	if err != nil {
+		return nil, false, err
+	}
+	return record, false, nil
+}
+
+// Store saves a response for future retrieval, with the fingerprint of the request
+// that produced it, completing the key's reservation. A completed key is left as is.
+func (r *Repository) Store(ctx context.Context, key entities.ScopedKey, fingerprint string, status int, body []byte) error {
+	// Completes a reservation with its response, or stores the response of a request
+	// that ran without one
+	query := `
+		INSERT INTO ` + constants.TableIdempotencyKeys + ` (client_id, method, route, key, fingerprint, status, response_status, response_body, created_at)
+		VALUES ($1, $2, $3, $4, $5, '` + entities.StatusCompleted + `', $6, $7, $8)
+		ON CONFLICT (client_id, method, route, key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint,
+			status = EXCLUDED.status,
+			response_status = EXCLUDED.response_status,
+			response_body = EXCLUDED.response_body,
+			lease_expires_at = NULL
+		WHERE ` + constants.TableIdempotencyKeys + `.status = '` + entities.StatusInProgress + `'
+	`
+
+	_, err := r.pool.Exec(ctx, query, key.Client, key.Method, key.Route, key.Key, fingerprint, status, body, time.Now())
+	return err
+}
+
+// StoreTx completes the reservation Reserve made at reservedAt inside tx: the response is
+// only stored if tx commits. It fails with ErrReservationLost if the key has been
+// completed or taken over since, so that tx rolls back rather than commit work the key
+// no longer vouches for.
+func (r *Repository) StoreTx(ctx context.Context, tx pgx.Tx, key entities.ScopedKey, fingerprint string, reservedAt time.Time, status int, body []byte) error {
+	// A takeover rewrites created_at, so the reservation is matched by it together with
+	// the fingerprint. The row lock taken by the update holds off a takeover until tx ends
+	query := `
+		UPDATE ` + constants.TableIdempotencyKeys + `
+		SET status = '` + entities.StatusCompleted + `',
+			response_status = $7,
+			response_body = $8,
+			lease_expires_at = NULL
+		WHERE client_id = $1 AND method = $2 AND route = $3 AND key = $4
+			AND status = '` + entities.StatusInProgress + `'
+			AND fingerprint = $5
+			AND created_at = $6
+	`
+
+	result, err := tx.Exec(ctx, query, key.Client, key.Method, key.Route, key.Key, fingerprint, reservedAt, status, body)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrReservationLost
+	}
+	return nil
+}
+
+// Release drops the key's reservation if it has not completed, so that a retry can
+// run the request again.
+func (r *Repository) Release(ctx context.Context, key entities.ScopedKey) error {
+	query := `
+		DELETE FROM ` + constants.TableIdempotencyKeys + `
+		WHERE client_id = $1 AND method = $2 AND route = $3 AND key = $4
+			AND status = '` + entities.StatusInProgress + `'
 	`
 
-	_, err := r.pool.Exec(ctx, query, key, status, body, time.Now())
+	_, err := r.pool.Exec(ctx, query, key.Client, key.Method, key.Route, key.Key)
 	return err
 }
 
@@ -34,6 +34,16 @@ func (s *RepositoryTestSuite) TearDownTest() {
 	s.ctrl.Finish()
 }
 
+// scopedKey returns key as sent by an anonymous client to the transfers route
+func scopedKey(key string) entities.ScopedKey {
+	return entities.ScopedKey{
+		Client: entities.AnonymousClient,
+		Method: "POST",
+		Route:  "/v1/transactions",
+		Key:    key,
+	}
+}
+
 func (s *RepositoryTestSuite) TestGetReturnsRecordWhenKeyExists() {
 	expectedRecord := &entities.IdempotencyRecord{
 		Key:            "test-key-123",
@@ -43,11 +53,11 @@ func (s *RepositoryTestSuite) TestGetReturnsRecordWhenKeyExists() {
 	}
 
 	s.mockRepo.EXPECT().
-		Get(s.ctx, "test-key-123").
+		Get(s.ctx, scopedKey("test-key-123")).
 		Return(expectedRecord, nil).
 		Times(1)
 
-	record, err := s.mockRepo.Get(s.ctx, "test-key-123")
+	record, err := s.mockRepo.Get(s.ctx, scopedKey("test-key-123"))
 
 	s.NoError(err)
 	s.NotNil(record)
@@ -57,27 +67,58 @@ func (s *RepositoryTestSuite) TestGetReturnsRecordWhenKeyExists() {
 
 func (s *RepositoryTestSuite) TestGetReturnsNilWhenKeyNotFound() {
 	s.mockRepo.EXPECT().
-		Get(s.ctx, "nonexistent-key").
+		Get(s.ctx, scopedKey("nonexistent-key")).
 		Return(nil, nil).
 		Times(1)
 
-	record, err := s.mockRepo.Get(s.ctx, "nonexistent-key")
+	record, err := s.mockRepo.Get(s.ctx, scopedKey("nonexistent-key"))
 
 	s.NoError(err)
 	s.Nil(record)
 }
 
 func (s *RepositoryTestSuite) TestStoreSuccessfullyStoresRecord() {
-	key := "new-key-456"
+	key := scopedKey("new-key-456")
+	fingerprint := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
 	status := 201
 	body := []byte(`{"transaction_id":"xyz-789"}`)
 
 	s.mockRepo.EXPECT().
-		Store(s.ctx, key, status, body).
+		Store(s.ctx, key, fingerprint, status, body).
+		Return(nil).
+		Times(1)
+
+	err := s.mockRepo.Store(s.ctx, key, fingerprint, status, body)
+
+	s.NoError(err)
+}
+
+func (s *RepositoryTestSuite) TestReserveReturnsHeldRecordWhenKeyInProgress() {
+	held := &entities.IdempotencyRecord{
+		Key:            "busy-key",
+		Status:         entities.StatusInProgress,
+		LeaseExpiresAt: time.Now().Add(entities.ReservationLease),
+	}
+
+	s.mockRepo.EXPECT().
+		Reserve(s.ctx, scopedKey("busy-key"), "fp", entities.ReservationLease).
+		Return(held, false, nil).
+		Times(1)
+
+	record, reserved, err := s.mockRepo.Reserve(s.ctx, scopedKey("busy-key"), "fp", entities.ReservationLease)
+
+	s.NoError(err)
+	s.False(reserved)
+	s.True(record.InProgress())
+}
+
+func (s *RepositoryTestSuite) TestReleaseDropsReservation() {
+	s.mockRepo.EXPECT().
+		Release(s.ctx, scopedKey("busy-key")).
 		Return(nil).
 		Times(1)
 
-	err := s.mockRepo.Store(s.ctx, key, status, body)
+	err := s.mockRepo.Release(s.ctx, scopedKey("busy-key"))
 
 	s.NoError(err)
 }
@@ -0,0 +1,71 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
+	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	"github.com/jackc/pgx/v5"
+)
+
+// Reservation is the idempotency key reserved for the request in progress. The middleware
+// puts it in the request context, so that a handler whose work commits in a database
+// transaction can complete the key in that same transaction: the key is then stored if
+// and only if the work is.
+type Reservation struct {
+	repo        IRepository
+	key         entities.ScopedKey
+	fingerprint string
+	// reservedAt is when Reserve made the reservation, which tells it apart from a later
+	// takeover of the same key
+	reservedAt  time.Time
+	completedTx bool
+}
+
+// NewReservation creates the reservation Reserve made of key at reservedAt for the request
+// with the given fingerprint.
+func NewReservation(repo IRepository, key entities.ScopedKey, fingerprint string, reservedAt time.Time) *Reservation {
+	return &Reservation{
+		repo:        repo,
+		key:         key,
+		fingerprint: fingerprint,
+		reservedAt:  reservedAt,
+	}
+}
+
+// NewContext returns a copy of ctx carrying the reservation.
+func NewContext(ctx context.Context, reservation *Reservation) context.Context {
+	return context.WithValue(ctx, contextkeys.IdempotencyKey, reservation)
+}
+
+// FromContext returns the reservation carried by ctx, or nil if the request has no
+// reserved idempotency key.
+func FromContext(ctx context.Context) *Reservation {
+	reservation, _ := ctx.Value(contextkeys.IdempotencyKey).(*Reservation)
+	return reservation
+}
+
+// CompleteTx stores the response under the reserved key inside tx. If tx rolls back, the
+// key stays reserved and is released or expires as usual. A store that cannot join tx is
+// not an error: the response is then stored once the request completes. It fails with
+// ErrReservationLost if another request completed or took over the key meanwhile, in
+// which case tx must roll back.
+func (r *Reservation) CompleteTx(ctx context.Context, tx pgx.Tx, status int, body []byte) error {
+	err := r.repo.StoreTx(ctx, tx, r.key, r.fingerprint, r.reservedAt, status, body)
+	if errors.Is(err, ErrTxNotSupported) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	r.completedTx = true
+	return nil
+}
+
+// CompletedTx reports whether the response was stored with CompleteTx, in which case
+// there is nothing left to store once the request completes.
+func (r *Reservation) CompletedTx() bool {
+	return r.completedTx
+}
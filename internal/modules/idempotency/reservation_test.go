@@ -0,0 +1,102 @@
+package idempotency_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/idempotency"
+	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	"github.com/internal-transfers-service/internal/modules/idempotency/mock"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ReservationTestSuite contains tests for idempotency key reservations
+type ReservationTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	mockRepo   *mock.MockIRepository
+	ctx        context.Context
+	key        entities.ScopedKey
+	reservedAt time.Time
+}
+
+func TestReservationSuite(t *testing.T) {
+	suite.Run(t, new(ReservationTestSuite))
+}
+
+func (s *ReservationTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.ctx = context.Background()
+	s.key = scopedKey("tx-key")
+	s.reservedAt = time.Date(2026, time.March, 14, 9, 30, 0, 0, time.UTC)
+}
+
+func (s *ReservationTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ReservationTestSuite) TestFromContextReturnsReservation() {
+	reservation := idempotency.NewReservation(s.mockRepo, s.key, "fp", s.reservedAt)
+
+	ctx := idempotency.NewContext(s.ctx, reservation)
+
+	s.Same(reservation, idempotency.FromContext(ctx))
+}
+
+func (s *ReservationTestSuite) TestFromContextReturnsNilWithoutReservation() {
+	s.Nil(idempotency.FromContext(s.ctx))
+}
+
+func (s *ReservationTestSuite) TestCompleteTxStoresResponseInTransaction() {
+	reservation := idempotency.NewReservation(s.mockRepo, s.key, "fp", s.reservedAt)
+	s.mockRepo.EXPECT().
+		StoreTx(s.ctx, nil, s.key, "fp", s.reservedAt, 201, []byte(`{}`)).
+		Return(nil).
+		Times(1)
+
+	err := reservation.CompleteTx(s.ctx, nil, 201, []byte(`{}`))
+
+	s.NoError(err)
+	s.True(reservation.CompletedTx())
+}
+
+func (s *ReservationTestSuite) TestCompleteTxFailureLeavesKeyToMiddleware() {
+	reservation := idempotency.NewReservation(s.mockRepo, s.key, "fp", s.reservedAt)
+	s.mockRepo.EXPECT().
+		StoreTx(s.ctx, nil, s.key, "fp", s.reservedAt, 201, gomock.Any()).
+		Return(errors.New("insert failed")).
+		Times(1)
+
+	err := reservation.CompleteTx(s.ctx, nil, 201, []byte(`{}`))
+
+	s.Error(err)
+	s.False(reservation.CompletedTx())
+}
+
+func (s *ReservationTestSuite) TestCompleteTxWhenReservationLostReturnsError() {
+	reservation := idempotency.NewReservation(s.mockRepo, s.key, "fp", s.reservedAt)
+	s.mockRepo.EXPECT().
+		StoreTx(s.ctx, nil, s.key, "fp", s.reservedAt, 201, gomock.Any()).
+		Return(idempotency.ErrReservationLost).
+		Times(1)
+
+	err := reservation.CompleteTx(s.ctx, nil, 201, []byte(`{}`))
+
+	s.ErrorIs(err, idempotency.ErrReservationLost)
+	s.False(reservation.CompletedTx())
+}
+
+func (s *ReservationTestSuite) TestCompleteTxOnMemoryStoreLeavesKeyToMiddleware() {
+	memory := idempotency.NewMemoryRepository(10, time.Hour, clock.Real{})
+	reservation := idempotency.NewReservation(memory, s.key, "fp", s.reservedAt)
+
+	err := reservation.CompleteTx(s.ctx, nil, 201, []byte(`{}`))
+
+	s.NoError(err)
+	s.False(reservation.CompletedTx())
+}
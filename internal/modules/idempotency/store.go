@@ -0,0 +1,39 @@
+package idempotency
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/internal-transfers-service/internal/config"
+	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Store errors
+var (
+	ErrInvalidBackend = errors.New(entities.ErrMsgInvalidBackend)
+	ErrTxNotSupported = errors.New(entities.ErrMsgTxNotSupported)
+	// ErrReservationLost means the key was completed or taken over by another request
+	// before the reservation holding it could complete it
+	ErrReservationLost = errors.New(entities.ErrMsgReservationLost)
+	// ErrKeyTaken means the key cannot be reserved because it is stored under another
+	// scope, which collides while the key alone is still unique (before migration 000024)
+	ErrKeyTaken = errors.New(entities.ErrMsgKeyTaken)
+)
+
+// NewStore creates the repository selected by the configuration. Keys are kept for ttl.
+func NewStore(cfg config.IdempotencyConfig, pool *pgxpool.Pool, ttl time.Duration) (IRepository, error) {
+	switch cfg.Backend {
+	case "", entities.BackendPostgres:
+		return NewRepository(pool), nil
+	case entities.BackendMemory:
+		return NewMemoryRepository(cfg.GetMemoryCapacity(), ttl, clock.Real{}), nil
+	case entities.BackendTiered:
+		hot := NewMemoryRepository(cfg.GetMemoryCapacity(), ttl, clock.Real{})
+		return NewTieredRepository(hot, NewRepository(pool)), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBackend, cfg.Backend)
+	}
+}
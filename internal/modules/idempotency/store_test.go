@@ -0,0 +1,45 @@
+package idempotency_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/config"
+	"github.com/internal-transfers-service/internal/modules/idempotency"
+	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+// StoreTestSuite contains tests for idempotency backend selection
+type StoreTestSuite struct {
+	suite.Suite
+}
+
+func TestStoreSuite(t *testing.T) {
+	suite.Run(t, new(StoreTestSuite))
+}
+
+func (s *StoreTestSuite) TestNewStoreSelectsConfiguredBackend() {
+	repo, err := idempotency.NewStore(config.IdempotencyConfig{}, nil, time.Hour)
+	s.NoError(err)
+	s.IsType(&idempotency.Repository{}, repo)
+
+	repo, err = idempotency.NewStore(config.IdempotencyConfig{Backend: entities.BackendPostgres}, nil, time.Hour)
+	s.NoError(err)
+	s.IsType(&idempotency.Repository{}, repo)
+
+	repo, err = idempotency.NewStore(config.IdempotencyConfig{Backend: entities.BackendMemory}, nil, time.Hour)
+	s.NoError(err)
+	s.IsType(&idempotency.MemoryRepository{}, repo)
+
+	repo, err = idempotency.NewStore(config.IdempotencyConfig{Backend: entities.BackendTiered}, nil, time.Hour)
+	s.NoError(err)
+	s.IsType(&idempotency.TieredRepository{}, repo)
+}
+
+func (s *StoreTestSuite) TestNewStoreRejectsUnknownBackend() {
+	repo, err := idempotency.NewStore(config.IdempotencyConfig{Backend: "redis"}, nil, time.Hour)
+
+	s.Nil(repo)
+	s.ErrorIs(err, idempotency.ErrInvalidBackend)
+}
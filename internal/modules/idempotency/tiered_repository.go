@@ -0,0 +1,90 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	"github.com/jackc/pgx/v5"
+)
+
+// TieredRepository serves completed keys from memory in front of a durable store. Only
+// completed keys are cached: they never change until they expire, so every instance can
+// replay them from its own memory, while reservations, which instances must agree on, are
+// always made in the durable store. A retry of a recently completed request is answered
+// without a database round trip.
+type TieredRepository struct {
+	hot   *MemoryRepository
+	store IRepository
+}
+
+// Compile-time interface check
+var _ IRepository = (*TieredRepository)(nil)
+
+// NewTieredRepository creates a repository caching store's completed keys in hot.
+func NewTieredRepository(hot *MemoryRepository, store IRepository) *TieredRepository {
+	return &TieredRepository{
+		hot:   hot,
+		store: store,
+	}
+}
+
+// Get retrieves a cached response by key, from memory if it is there.
+// Returns nil, nil if the key doesn't exist.
+func (t *TieredRepository) Get(ctx context.Context, key entities.ScopedKey) (*entities.IdempotencyRecord, error) {
+	if record, _ := t.hot.Get(ctx, key); record != nil {
+		return record, nil
+	}
+
+	record, err := t.store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	t.cacheCompleted(key, record)
+	return record, nil
+}
+
+// Reserve answers a completed key from memory and otherwise reserves it in the durable store.
+func (t *TieredRepository) Reserve(ctx context.Context, key entities.ScopedKey, fingerprint string, lease time.Duration) (*entities.IdempotencyRecord, bool, error) {
+	if record, _ := t.hot.Get(ctx, key); record != nil {
+		return record, false, nil
+	}
+
+	record, reserved, err := t.store.Reserve(ctx, key, fingerprint, lease)
+	if err != nil {
+		return nil, false, err
+	}
+	t.cacheCompleted(key, record)
+	return record, reserved, nil
+}
+
+// Store saves a response in the durable store. It is cached once it is read back, so that
+// memory only ever holds what the durable store kept.
+func (t *TieredRepository) Store(ctx context.Context, key entities.ScopedKey, fingerprint string, status int, body []byte) error {
+	return t.store.Store(ctx, key, fingerprint, status, body)
+}
+
+// StoreTx completes the reservation in the durable store inside tx.
+func (t *TieredRepository) StoreTx(ctx context.Context, tx pgx.Tx, key entities.ScopedKey, fingerprint string, reservedAt time.Time, status int, body []byte) error {
+	return t.store.StoreTx(ctx, tx, key, fingerprint, reservedAt, status, body)
+}
+
+// Release drops the key's reservation in the durable store; reservations are never cached.
+func (t *TieredRepository) Release(ctx context.Context, key entities.ScopedKey) error {
+	return t.store.Release(ctx, key)
+}
+
+// DeleteExpired removes keys older than the specified TTL from both tiers.
+// Returns the number of keys deleted from the durable store.
+func (t *TieredRepository) DeleteExpired(ctx context.Context, ttl time.Duration) (int64, error) {
+	_, _ = t.hot.DeleteExpired(ctx, ttl)
+	return t.store.DeleteExpired(ctx, ttl)
+}
+
+// cacheCompleted keeps a completed record read from the durable store in memory
+func (t *TieredRepository) cacheCompleted(key entities.ScopedKey, record *entities.IdempotencyRecord) {
+	if record == nil || record.InProgress() {
+		return
+	}
+	t.hot.cache(key, record)
+}
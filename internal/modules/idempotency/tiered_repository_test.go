@@ -0,0 +1,110 @@
+package idempotency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/idempotency"
+	"github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	"github.com/internal-transfers-service/internal/modules/idempotency/mock"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// TieredRepositoryTestSuite contains tests for the two-tier idempotency repository
+type TieredRepositoryTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	mockStore *mock.MockIRepository
+	clk       *clock.Fake
+	repo      *idempotency.TieredRepository
+	ctx       context.Context
+}
+
+func TestTieredRepositorySuite(t *testing.T) {
+	suite.Run(t, new(TieredRepositoryTestSuite))
+}
+
+func (s *TieredRepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockStore = mock.NewMockIRepository(s.ctrl)
+	s.clk = clock.NewFake(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC))
+	hot := idempotency.NewMemoryRepository(10, time.Hour, s.clk)
+	s.repo = idempotency.NewTieredRepository(hot, s.mockStore)
+	s.ctx = context.Background()
+}
+
+func (s *TieredRepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *TieredRepositoryTestSuite) TestReserveServesCompletedKeyFromMemory() {
+	completed := &entities.IdempotencyRecord{
+		Key:            "key-1",
+		Fingerprint:    "fp",
+		Status:         entities.StatusCompleted,
+		ResponseStatus: 201,
+		CreatedAt:      s.clk.Now(),
+	}
+	s.mockStore.EXPECT().
+		Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute).
+		Return(completed, false, nil).
+		Times(1)
+
+	for range 3 {
+		record, reserved, err := s.repo.Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute)
+		s.NoError(err)
+		s.False(reserved)
+		s.Equal(201, record.ResponseStatus)
+	}
+}
+
+func (s *TieredRepositoryTestSuite) TestReserveNeverCachesReservations() {
+	inProgress := &entities.IdempotencyRecord{Key: "key-1", Status: entities.StatusInProgress, CreatedAt: s.clk.Now()}
+	s.mockStore.EXPECT().
+		Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute).
+		Return(inProgress, false, nil).
+		Times(2)
+
+	_, _, _ = s.repo.Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute)
+	_, _, _ = s.repo.Reserve(s.ctx, scopedKey("key-1"), "fp", time.Minute)
+}
+
+func (s *TieredRepositoryTestSuite) TestCachedKeyExpiresWithTheOriginal() {
+	completed := &entities.IdempotencyRecord{
+		Key:       "key-1",
+		Status:    entities.StatusCompleted,
+		CreatedAt: s.clk.Now().Add(-59 * time.Minute),
+	}
+	s.mockStore.EXPECT().Get(s.ctx, scopedKey("key-1")).Return(completed, nil).Times(1)
+	_, _ = s.repo.Get(s.ctx, scopedKey("key-1"))
+
+	s.clk.Advance(time.Minute)
+	s.mockStore.EXPECT().Get(s.ctx, scopedKey("key-1")).Return(nil, nil).Times(1)
+	record, err := s.repo.Get(s.ctx, scopedKey("key-1"))
+
+	s.NoError(err)
+	s.Nil(record)
+}
+
+func (s *TieredRepositoryTestSuite) TestStoreWritesThroughWithoutCaching() {
+	s.mockStore.EXPECT().Store(s.ctx, scopedKey("key-1"), "fp", 201, []byte(`{}`)).Return(nil).Times(1)
+	s.mockStore.EXPECT().Get(s.ctx, scopedKey("key-1")).Return(nil, nil).Times(1)
+
+	s.NoError(s.repo.Store(s.ctx, scopedKey("key-1"), "fp", 201, []byte(`{}`)))
+	record, err := s.repo.Get(s.ctx, scopedKey("key-1"))
+
+	s.NoError(err)
+	s.Nil(record, "memory only holds what was read back from the durable store")
+}
+
+func (s *TieredRepositoryTestSuite) TestStoreTxAndReleaseGoToDurableStore() {
+	reservedAt := time.Date(2026, time.March, 14, 9, 30, 0, 0, time.UTC)
+	s.mockStore.EXPECT().StoreTx(s.ctx, nil, scopedKey("key-1"), "fp", reservedAt, 201, nil).Return(nil).Times(1)
+	s.mockStore.EXPECT().Release(s.ctx, scopedKey("key-2")).Return(nil).Times(1)
+
+	s.NoError(s.repo.StoreTx(s.ctx, nil, scopedKey("key-1"), "fp", reservedAt, 201, nil))
+	s.NoError(s.repo.Release(s.ctx, scopedKey("key-2")))
+}
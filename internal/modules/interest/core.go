@@ -0,0 +1,612 @@
+package interest
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/account"
+	"github.com/internal-transfers-service/internal/modules/interest/entities"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/internal/modules/transaction"
+	txEntities "github.com/internal-transfers-service/internal/modules/transaction/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// Domain errors
+var (
+	ErrProductNotFound     = errors.New(entities.ErrMsgProductNotFound)
+	ErrInvalidProductID    = errors.New(entities.ErrMsgInvalidProductID)
+	ErrInvalidProductName  = errors.New(entities.ErrMsgInvalidProductName)
+	ErrProductExists       = errors.New(entities.ErrMsgProductExists)
+	ErrInvalidDayCount     = errors.New(entities.ErrMsgInvalidDayCount)
+	ErrInvalidRounding     = errors.New(entities.ErrMsgInvalidRounding)
+	ErrInvalidFrequency    = errors.New(entities.ErrMsgInvalidFrequency)
+	ErrInvalidAnnualRate   = errors.New(entities.ErrMsgInvalidAnnualRate)
+	ErrRatesRequired       = errors.New(entities.ErrMsgRatesRequired)
+	ErrDuplicateRateDate   = errors.New(entities.ErrMsgDuplicateRateDate)
+	ErrInvalidDate         = errors.New(entities.ErrMsgInvalidDate)
+	ErrInvalidRange        = errors.New(entities.ErrMsgInvalidRange)
+	ErrAccountNotFound     = errors.New(entities.ErrMsgAccountNotFound)
+	ErrInvalidAccountID    = errors.New(entities.ErrMsgInvalidAccountID)
+	ErrSystemAccount       = errors.New(entities.ErrMsgSystemAccount)
+	ErrLockHeldElsewhere   = errors.New(entities.ErrMsgLockHeldElsewhere)
+	ErrSnapshotUnavailable = errors.New(entities.ErrMsgSnapshotUnavailable)
+)
+
+// ICore defines the interface for interest business logic
+type ICore interface {
+	CreateProduct(ctx context.Context, req *entities.CreateProductRequest) (*entities.ProductResponse, apperror.IError)
+	GetProduct(ctx context.Context, productID int64) (*entities.ProductResponse, apperror.IError)
+	SetRate(ctx context.Context, productID int64, req *entities.RateRequest) (*entities.ProductResponse, apperror.IError)
+	SetAccountProduct(ctx context.Context, accountID int64, req *entities.SetAccountProductRequest) (*entities.AccountProductResponse, apperror.IError)
+	RunDue(ctx context.Context) (int, error)
+	Backfill(ctx context.Context, from, to time.Time) (*entities.BackfillResponse, error)
+}
+
+// Core implements ICore
+type Core struct {
+	repo         IRepository
+	accountRepo  account.IRepository
+	snapshotRepo snapshot.IRepository
+	snapshotCore snapshot.ICore
+	postings     transaction.ICore
+	clock        clock.Clock
+}
+
+// Compile-time interface check
+var _ ICore = (*Core)(nil)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance.
+// Interest is accrued from the end-of-day balances written by the snapshot module and
+// paid out through transaction postings.
+func NewCore(
+	_ context.Context,
+	repo IRepository,
+	accountRepo account.IRepository,
+	snapshotRepo snapshot.IRepository,
+	snapshotCore snapshot.ICore,
+	postings transaction.ICore,
+	clk clock.Clock,
+) ICore {
+	coreInstance = &Core{
+		repo:         repo,
+		accountRepo:  accountRepo,
+		snapshotRepo: snapshotRepo,
+		snapshotCore: snapshotCore,
+		postings:     postings,
+		clock:        clk,
+	}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// CreateProduct validates and stores a new interest product with its rate schedule
+func (c *Core) CreateProduct(ctx context.Context, req *entities.CreateProductRequest) (*entities.ProductResponse, apperror.IError) {
+	product, appErr := validateProductRequest(req)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if err := c.repo.CreateProduct(ctx, product); err != nil {
+		return nil, toAppError(err, apperror.FieldName, product.Name)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgInterestProductCreated,
+		constants.LogFieldProductID, product.ProductID,
+		constants.LogFieldName, product.Name,
+	)
+	return toProductResponse(product), nil
+}
+
+// GetProduct returns a product with its rate schedule
+func (c *Core) GetProduct(ctx context.Context, productID int64) (*entities.ProductResponse, apperror.IError) {
+	product, appErr := c.getProduct(ctx, productID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return toProductResponse(product), nil
+}
+
+// SetRate adds a rate to a product's schedule, replacing any rate with the same effective date.
+// Days that have already accrued keep the rate they accrued at.
+func (c *Core) SetRate(ctx context.Context, productID int64, req *entities.RateRequest) (*entities.ProductResponse, apperror.IError) {
+	rate, appErr := parseRate(req)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if _, appErr := c.getProduct(ctx, productID); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := c.repo.SetRate(ctx, productID, rate); err != nil {
+		return nil, toAppError(err, apperror.FieldProductID, productID)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgInterestRateSet,
+		constants.LogFieldProductID, productID,
+		constants.LogFieldEffectiveFrom, rate.EffectiveFrom.Format(entities.DateLayout),
+		constants.LogFieldAnnualRate, rate.AnnualRate.String(),
+	)
+	return c.GetProduct(ctx, productID)
+}
+
+// SetAccountProduct puts a customer account on an interest product from a business date onwards,
+// replacing its current product. Past dates are only accrued by a backfill.
+func (c *Core) SetAccountProduct(ctx context.Context, accountID int64, req *entities.SetAccountProductRequest) (*entities.AccountProductResponse, apperror.IError) {
+	if accountID <= 0 {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	effectiveFrom, appErr := parseDate(req.EffectiveFrom, apperror.FieldEffectiveFrom)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	acc, err := c.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		var repoErr *apperror.Error
+		if errors.As(err, &repoErr) && repoErr.Code() == apperror.CodeNotFound {
+			return nil, apperror.NewWithMessage(apperror.CodeNotFound, ErrAccountNotFound, apperror.MsgAccountNotFound).
+				WithField(apperror.FieldAccountID, accountID)
+		}
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	if acc.IsSystem() {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrSystemAccount, apperror.MsgInterestSystemAccount).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	if _, appErr := c.getProduct(ctx, req.ProductID); appErr != nil {
+		return nil, appErr
+	}
+
+	link := &AccountProduct{AccountID: accountID, ProductID: req.ProductID, EffectiveFrom: effectiveFrom}
+	if err := c.repo.SetAccountProduct(ctx, link); err != nil {
+		return nil, toAppError(err, apperror.FieldAccountID, accountID)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgInterestProductAttached,
+		constants.LogKeyAccountID, accountID,
+		constants.LogFieldProductID, req.ProductID,
+		constants.LogFieldEffectiveFrom, req.EffectiveFrom,
+	)
+	return &entities.AccountProductResponse{
+		AccountID:     accountID,
+		ProductID:     req.ProductID,
+		EffectiveFrom: effectiveFrom.Format(entities.DateLayout),
+	}, nil
+}
+
+// RunDue accrues every business date snapshotted since the last accrual run, oldest first,
+// then pays out every period that has closed. The first run starts at the latest snapshot;
+// earlier dates are only accrued by a backfill. Returns the number of dates accrued.
+func (c *Core) RunDue(ctx context.Context) (int, error) {
+	through, ok, err := c.snapshotRepo.LastCompletedDate(ctx)
+	if err != nil || !ok {
+		return 0, err
+	}
+	through = snapshot.DateOf(through)
+
+	start := through
+	last, ok, err := c.repo.LastAccruedDate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		start = snapshot.DateOf(last).AddDate(0, 0, 1)
+	}
+
+	accrued := 0
+	for date := start; !date.After(through); date = date.AddDate(0, 0, 1) {
+		_, done, err := c.accrueDate(ctx, date)
+		if err != nil {
+			return accrued, err
+		}
+		if !done {
+			logger.Ctx(ctx).Debugw(constants.LogMsgInterestRunHeldElsewhere,
+				constants.LogFieldAccrualDate, date.Format(entities.DateLayout),
+			)
+			return accrued, nil
+		}
+		accrued++
+	}
+
+	_, err = c.payDue(ctx, through)
+	return accrued, err
+}
+
+// Backfill accrues the business dates from..to (inclusive), snapshotting any that have not
+// been snapshotted yet, then pays out the periods that closed by to. Once the worker has
+// accrued a date, to may not be after it, so that backfills never leave gaps behind it.
+func (c *Core) Backfill(ctx context.Context, from, to time.Time) (*entities.BackfillResponse, error) {
+	from, to = snapshot.DateOf(from), snapshot.DateOf(to)
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from %s is after to %s", ErrInvalidRange,
+			from.Format(entities.DateLayout), to.Format(entities.DateLayout))
+	}
+
+	last, ok, err := c.repo.LastAccruedDate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ok && to.After(snapshot.DateOf(last)) {
+		return nil, fmt.Errorf("%w: to %s is after the last accrued date %s", ErrInvalidRange,
+			to.Format(entities.DateLayout), snapshot.DateOf(last).Format(entities.DateLayout))
+	}
+
+	response := &entities.BackfillResponse{
+		From: from.Format(entities.DateLayout),
+		To:   to.Format(entities.DateLayout),
+	}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		snapshotted, err := c.snapshotCore.EnsureDate(ctx, date, c.clock.Now())
+		if err != nil {
+			return response, err
+		}
+		if !snapshotted {
+			return response, fmt.Errorf("%w: %s", ErrSnapshotUnavailable, date.Format(entities.DateLayout))
+		}
+
+		count, done, err := c.accrueDate(ctx, date)
+		if err != nil {
+			return response, err
+		}
+		if !done {
+			return response, fmt.Errorf("%w: %s", ErrLockHeldElsewhere, date.Format(entities.DateLayout))
+		}
+		response.DaysAccrued++
+		response.AccrualCount += count
+	}
+
+	paid, err := c.payDue(ctx, to)
+	response.PayoutCount = paid
+	if err != nil {
+		return response, err
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgInterestBackfillDone,
+		constants.LogFieldFrom, response.From,
+		constants.LogFieldTo, response.To,
+		constants.LogFieldDaysAccrued, response.DaysAccrued,
+		constants.LogFieldAccrualCount, response.AccrualCount,
+		constants.LogFieldPayoutCount, response.PayoutCount,
+	)
+	return response, nil
+}
+
+// accrueDate writes one business date's accruals in a single transaction.
+// Returns the number of accruals written, or false if another replica holds the interest lock.
+func (c *Core) accrueDate(ctx context.Context, date time.Time) (int64, bool, error) {
+	tx, err := c.repo.BeginTx(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	committed := false
+	defer c.rollbackIfNotCommitted(ctx, tx, &committed)
+
+	locked, err := c.repo.TryLock(ctx, tx)
+	if err != nil || !locked {
+		return 0, false, err
+	}
+
+	// Products are read under the lock so that products and rates added since the
+	// previous date are picked up
+	products, err := c.repo.ListProducts(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	basis, err := c.repo.ListAccrualBasis(ctx, tx, date)
+	if err != nil {
+		return 0, false, err
+	}
+
+	count, err := c.repo.CreateAccruals(ctx, tx, computeAccruals(date, basis, products))
+	if err != nil {
+		return 0, false, err
+	}
+
+	if err := c.repo.MarkAccrued(ctx, tx, date, count, c.clock.Now().UTC()); err != nil {
+		return 0, false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, false, err
+	}
+	committed = true
+
+	logger.Ctx(ctx).Infow(constants.LogMsgInterestDateAccrued,
+		constants.LogFieldAccrualDate, date.Format(entities.DateLayout),
+		constants.LogFieldAccrualCount, count,
+	)
+	return count, true, nil
+}
+
+// computeAccruals applies each account's product to its end-of-day balance.
+// Accounts whose product has no rate in force on date accrue nothing.
+func computeAccruals(date time.Time, basis []*AccrualBasis, products map[int64]*Product) []*Accrual {
+	next := date.AddDate(0, 0, 1)
+	accruals := make([]*Accrual, 0, len(basis))
+	for _, b := range basis {
+		product, ok := products[b.ProductID]
+		if !ok {
+			continue
+		}
+		rate, ok := product.RateOn(date)
+		if !ok {
+			continue
+		}
+		dc, ok := DayCountFor(product.DayCount)
+		if !ok {
+			continue
+		}
+
+		days := dc.Days(date, next)
+		accruals = append(accruals, &Accrual{
+			AccountID:   b.AccountID,
+			AccrualDate: date,
+			ProductID:   product.ProductID,
+			Balance:     b.Balance,
+			AnnualRate:  rate,
+			DayCount:    product.DayCount,
+			Days:        days,
+			Amount:      DailyInterest(b.Balance, rate, days, dc, product.Rounding),
+			PeriodEnd:   PeriodEnd(product.PayoutFrequency, date),
+		})
+	}
+	return accruals
+}
+
+// payDue pays out every account period ending on or before through that has unpaid accruals.
+// A failing payout is logged and retried on the next run without holding up the others.
+// Returns the number of payouts made and the first error met.
+func (c *Core) payDue(ctx context.Context, through time.Time) (int, error) {
+	dues, err := c.repo.ListPayoutsDue(ctx, through)
+	if err != nil {
+		return 0, err
+	}
+
+	paid := 0
+	var firstErr error
+	for _, due := range dues {
+		done, err := c.payOut(ctx, due)
+		if err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgInterestPayoutFailed,
+				constants.LogKeyAccountID, due.AccountID,
+				constants.LogFieldPeriodEnd, due.PeriodEnd.Format(entities.DateLayout),
+				constants.LogKeyError, err,
+			)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if done {
+			paid++
+		}
+	}
+	return paid, firstErr
+}
+
+// payOut claims an account period's unpaid accruals and posts their total from the currency's
+// interest-expense account, all in one transaction. Returns false if there was nothing left
+// to claim. Periods that accrued nothing are recorded without a posting.
+func (c *Core) payOut(ctx context.Context, due *PayoutDue) (bool, error) {
+	acc, err := c.accountRepo.GetByID(ctx, due.AccountID)
+	if err != nil {
+		return false, err
+	}
+
+	expense, err := c.accountRepo.GetInterestExpenseAccount(ctx, acc.Currency)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := c.repo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	committed := false
+	defer c.rollbackIfNotCommitted(ctx, tx, &committed)
+
+	payout := &Payout{
+		PayoutID:  uuid.New(),
+		AccountID: due.AccountID,
+		PeriodEnd: due.PeriodEnd,
+		CreatedAt: c.clock.Now().UTC(),
+	}
+
+	payout.Amount, payout.AccrualCount, err = c.repo.ClaimAccruals(ctx, tx, payout.PayoutID, due.AccountID, due.PeriodEnd)
+	if err != nil || payout.AccrualCount == 0 {
+		return false, err
+	}
+
+	if payout.Amount.IsPositive() {
+		transactionID := uuid.New()
+		if _, appErr := c.postings.PostTx(ctx, tx, &txEntities.PostingRequest{
+			TransactionID:        transactionID,
+			SourceAccountID:      expense.AccountID,
+			DestinationAccountID: due.AccountID,
+			Amount:               payout.Amount,
+			Type:                 txEntities.TypeInterest,
+		}); appErr != nil {
+			return false, appErr
+		}
+		payout.TransactionID = &transactionID
+	}
+
+	if err := c.repo.CreatePayout(ctx, tx, payout); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	committed = true
+
+	logger.Ctx(ctx).Infow(constants.LogMsgInterestPaid,
+		constants.LogFieldPayoutID, payout.PayoutID.String(),
+		constants.LogKeyAccountID, due.AccountID,
+		constants.LogFieldPeriodEnd, due.PeriodEnd.Format(entities.DateLayout),
+		constants.LogKeyAmount, payout.Amount.String(),
+	)
+	return true, nil
+}
+
+// rollbackIfNotCommitted rolls back the transaction if it was not committed
+func (c *Core) rollbackIfNotCommitted(ctx context.Context, tx pgx.Tx, committed *bool) {
+	if !*committed {
+		_ = tx.Rollback(ctx)
+	}
+}
+
+// getProduct loads a product, mapping repository errors to apperrors
+func (c *Core) getProduct(ctx context.Context, productID int64) (*Product, apperror.IError) {
+	if productID <= 0 {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidProductID, apperror.MsgInvalidProductID).
+			WithField(apperror.FieldProductID, productID)
+	}
+
+	product, err := c.repo.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, toAppError(err, apperror.FieldProductID, productID)
+	}
+	return product, nil
+}
+
+// validateProductRequest checks a create request and converts it to a Product,
+// applying the default rounding rule and payout frequency
+func validateProductRequest(req *entities.CreateProductRequest) (*Product, apperror.IError) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > entities.MaxNameLength {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidProductName, apperror.MsgInvalidProductName).
+			WithField(apperror.FieldName, req.Name)
+	}
+
+	if _, ok := DayCountFor(req.DayCount); !ok {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidDayCount, apperror.MsgInvalidDayCount).
+			WithField(apperror.FieldDayCount, req.DayCount)
+	}
+
+	product := &Product{
+		Name:            name,
+		DayCount:        req.DayCount,
+		Rounding:        cmp.Or(req.Rounding, entities.DefaultRounding),
+		PayoutFrequency: cmp.Or(req.PayoutFrequency, entities.DefaultFrequency),
+		Rates:           make([]*Rate, 0, len(req.Rates)),
+	}
+
+	if !IsRounding(product.Rounding) {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidRounding, apperror.MsgInvalidRounding).
+			WithField(apperror.FieldRounding, req.Rounding)
+	}
+
+	if !IsPayoutFrequency(product.PayoutFrequency) {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidFrequency, apperror.MsgInvalidPayoutFrequency).
+			WithField(apperror.FieldFrequency, req.PayoutFrequency)
+	}
+
+	if len(req.Rates) == 0 {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrRatesRequired, apperror.MsgRatesRequired)
+	}
+
+	seen := make(map[time.Time]bool, len(req.Rates))
+	for i := range req.Rates {
+		rate, appErr := parseRate(&req.Rates[i])
+		if appErr != nil {
+			return nil, appErr
+		}
+		if seen[rate.EffectiveFrom] {
+			return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrDuplicateRateDate, apperror.MsgDuplicateRateDate).
+				WithField(apperror.FieldEffectiveFrom, req.Rates[i].EffectiveFrom)
+		}
+		seen[rate.EffectiveFrom] = true
+		product.Rates = append(product.Rates, rate)
+	}
+	slices.SortFunc(product.Rates, func(a, b *Rate) int {
+		return a.EffectiveFrom.Compare(b.EffectiveFrom)
+	})
+	return product, nil
+}
+
+// parseRate validates a rate schedule entry
+func parseRate(req *entities.RateRequest) (*Rate, apperror.IError) {
+	effectiveFrom, appErr := parseDate(req.EffectiveFrom, apperror.FieldEffectiveFrom)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rate, err := decimal.NewFromString(req.AnnualRate)
+	if err != nil || rate.IsNegative() || rate.GreaterThanOrEqual(decimal.NewFromInt(entities.MaxAnnualRate)) ||
+		-rate.Exponent() > entities.RatePlaces {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAnnualRate, apperror.MsgInvalidAnnualRate).
+			WithField(apperror.FieldAnnualRate, req.AnnualRate)
+	}
+
+	return &Rate{EffectiveFrom: effectiveFrom, AnnualRate: rate}, nil
+}
+
+// parseDate parses a YYYY-MM-DD business date
+func parseDate(value, field string) (time.Time, apperror.IError) {
+	date, err := time.Parse(entities.DateLayout, value)
+	if err != nil {
+		return time.Time{}, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidDate, apperror.MsgInvalidDate).
+			WithField(field, value)
+	}
+	return date, nil
+}
+
+// toAppError passes apperrors from the repository through and wraps anything else as internal
+func toAppError(err error, field string, value any) apperror.IError {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return apperror.New(apperror.CodeInternalError, err).WithField(field, value)
+}
+
+// toProductResponse converts a Product to its wire form
+func toProductResponse(product *Product) *entities.ProductResponse {
+	rates := make([]*entities.RateResponse, 0, len(product.Rates))
+	for _, rate := range product.Rates {
+		rates = append(rates, &entities.RateResponse{
+			EffectiveFrom: rate.EffectiveFrom.Format(entities.DateLayout),
+			AnnualRate:    rate.AnnualRate.String(),
+		})
+	}
+	return &entities.ProductResponse{
+		ProductID:       product.ProductID,
+		Name:            product.Name,
+		DayCount:        product.DayCount,
+		Rounding:        product.Rounding,
+		PayoutFrequency: product.PayoutFrequency,
+		Rates:           rates,
+		CreatedAt:       product.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
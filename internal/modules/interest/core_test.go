@@ -0,0 +1,474 @@
+package interest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/modules/account"
+	accountEntities "github.com/internal-transfers-service/internal/modules/account/entities"
+	accountMock "github.com/internal-transfers-service/internal/modules/account/mock"
+	"github.com/internal-transfers-service/internal/modules/interest"
+	"github.com/internal-transfers-service/internal/modules/interest/entities"
+	"github.com/internal-transfers-service/internal/modules/interest/mock"
+	snapshotMock "github.com/internal-transfers-service/internal/modules/snapshot/mock"
+	txEntities "github.com/internal-transfers-service/internal/modules/transaction/entities"
+	txMock "github.com/internal-transfers-service/internal/modules/transaction/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/clock"
+	dbMock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test constants
+const (
+	testProductID        = int64(7)
+	testAccountID        = int64(200)
+	testExpenseAccountID = int64(3)
+)
+
+// Test error constants - used for simulating database errors in tests
+var (
+	errDatabaseError = errors.New("database error")
+)
+
+// CoreTestSuite contains tests for interest Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl             *gomock.Controller
+	mockRepo         *mock.MockIRepository
+	mockAccountRepo  *accountMock.MockIRepository
+	mockSnapshotRepo *snapshotMock.MockIRepository
+	mockSnapshotCore *snapshotMock.MockICore
+	mockPostings     *txMock.MockICore
+	mockTx           *dbMock.MockTx
+	clock            *clock.Fake
+	core             interest.ICore
+	ctx              context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.mockAccountRepo = accountMock.NewMockIRepository(s.ctrl)
+	s.mockSnapshotRepo = snapshotMock.NewMockIRepository(s.ctrl)
+	s.mockSnapshotCore = snapshotMock.NewMockICore(s.ctrl)
+	s.mockPostings = txMock.NewMockICore(s.ctrl)
+	s.mockTx = dbMock.NewMockTx(s.ctrl)
+	s.clock = clock.NewFake(time.Date(2026, 4, 1, 9, 30, 0, 0, time.UTC))
+	s.ctx = context.Background()
+	s.core = interest.NewCore(s.ctx, s.mockRepo, s.mockAccountRepo, s.mockSnapshotRepo, s.mockSnapshotCore, s.mockPostings, s.clock)
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// date returns the business date for the given day of March 2026
+func date(day int) time.Time {
+	return time.Date(2026, 3, day, 0, 0, 0, 0, time.UTC)
+}
+
+// product returns a monthly ACT/365 product paying 5% from the start of 2026
+func product() *interest.Product {
+	return &interest.Product{
+		ProductID:       testProductID,
+		Name:            "Savings",
+		DayCount:        entities.DayCountACT365,
+		Rounding:        entities.RoundingHalfEven,
+		PayoutFrequency: entities.PayoutMonthly,
+		Rates: []*interest.Rate{
+			{EffectiveFrom: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), AnnualRate: decimal.RequireFromString("0.05")},
+		},
+	}
+}
+
+// expectAccrual sets up a successful accrual of one business date for the given balances
+func (s *CoreTestSuite) expectAccrual(d time.Time, basis []*interest.AccrualBasis, written int64, check func([]*interest.Accrual)) {
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().TryLock(s.ctx, s.mockTx).Return(true, nil)
+	s.mockRepo.EXPECT().ListProducts(s.ctx).Return(map[int64]*interest.Product{testProductID: product()}, nil)
+	s.mockRepo.EXPECT().ListAccrualBasis(s.ctx, s.mockTx, d).Return(basis, nil)
+	s.mockRepo.EXPECT().
+		CreateAccruals(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, accruals []*interest.Accrual) (int64, error) {
+			if check != nil {
+				check(accruals)
+			}
+			return written, nil
+		})
+	s.mockRepo.EXPECT().MarkAccrued(s.ctx, s.mockTx, d, written, s.clock.Now().UTC()).Return(nil)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil)
+}
+
+// expectPayoutAccounts sets up the customer and interest-expense account lookups of a payout
+func (s *CoreTestSuite) expectPayoutAccounts() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, testAccountID).
+		Return(&account.Account{AccountID: testAccountID, Currency: "USD", Kind: accountEntities.KindCustomer}, nil)
+	s.mockAccountRepo.EXPECT().
+		GetInterestExpenseAccount(s.ctx, "USD").
+		Return(&account.Account{AccountID: testExpenseAccountID, Currency: "USD", Kind: accountEntities.KindInterestExpense}, nil)
+}
+
+// Test CreateProduct
+
+func (s *CoreTestSuite) TestCreateProductAppliesDefaultsAndSortsRates() {
+	req := &entities.CreateProductRequest{
+		Name:     " Savings ",
+		DayCount: entities.DayCount30360,
+		Rates: []entities.RateRequest{
+			{EffectiveFrom: "2026-06-01", AnnualRate: "0.04"},
+			{EffectiveFrom: "2026-01-01", AnnualRate: "0.035"},
+		},
+	}
+
+	s.mockRepo.EXPECT().
+		CreateProduct(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, p *interest.Product) error {
+			s.Equal("Savings", p.Name)
+			s.Equal(entities.RoundingHalfEven, p.Rounding)
+			s.Equal(entities.PayoutMonthly, p.PayoutFrequency)
+			p.ProductID = testProductID
+			return nil
+		})
+
+	response, err := s.core.CreateProduct(s.ctx, req)
+	s.Nil(err)
+	s.Equal(testProductID, response.ProductID)
+	s.Require().Len(response.Rates, 2)
+	s.Equal("2026-01-01", response.Rates[0].EffectiveFrom)
+	s.Equal("0.035", response.Rates[0].AnnualRate)
+	s.Equal("2026-06-01", response.Rates[1].EffectiveFrom)
+}
+
+func (s *CoreTestSuite) TestCreateProductWithUnknownDayCountFails() {
+	response, err := s.core.CreateProduct(s.ctx, &entities.CreateProductRequest{
+		Name:     "Savings",
+		DayCount: "ACT/ACT",
+		Rates:    []entities.RateRequest{{EffectiveFrom: "2026-01-01", AnnualRate: "0.05"}},
+	})
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidDayCount, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateProductWithInvalidRateFails() {
+	for _, rate := range []string{"abc", "-0.01", "100", "0.000000001"} {
+		response, err := s.core.CreateProduct(s.ctx, &entities.CreateProductRequest{
+			Name:     "Savings",
+			DayCount: entities.DayCountACT365,
+			Rates:    []entities.RateRequest{{EffectiveFrom: "2026-01-01", AnnualRate: rate}},
+		})
+		s.Nil(response, rate)
+		s.Equal(apperror.MsgInvalidAnnualRate, err.PublicMessage(), rate)
+	}
+}
+
+func (s *CoreTestSuite) TestCreateProductWithoutRatesFails() {
+	_, err := s.core.CreateProduct(s.ctx, &entities.CreateProductRequest{Name: "Savings", DayCount: entities.DayCountACT365})
+	s.Equal(apperror.MsgRatesRequired, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateProductWithDuplicateRateDatesFails() {
+	_, err := s.core.CreateProduct(s.ctx, &entities.CreateProductRequest{
+		Name:     "Savings",
+		DayCount: entities.DayCountACT365,
+		Rates: []entities.RateRequest{
+			{EffectiveFrom: "2026-01-01", AnnualRate: "0.05"},
+			{EffectiveFrom: "2026-01-01", AnnualRate: "0.04"},
+		},
+	})
+	s.Equal(apperror.MsgDuplicateRateDate, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestCreateProductWithTakenNameReturnsConflict() {
+	s.mockRepo.EXPECT().
+		CreateProduct(s.ctx, gomock.Any()).
+		Return(apperror.NewWithMessage(apperror.CodeConflict, interest.ErrProductExists, apperror.MsgDuplicateProduct))
+
+	_, err := s.core.CreateProduct(s.ctx, &entities.CreateProductRequest{
+		Name:     "Savings",
+		DayCount: entities.DayCountACT365,
+		Rates:    []entities.RateRequest{{EffectiveFrom: "2026-01-01", AnnualRate: "0.05"}},
+	})
+	s.Equal(apperror.CodeConflict, err.Code())
+}
+
+// Test SetRate
+
+func (s *CoreTestSuite) TestSetRateOnUnknownProductReturnsNotFound() {
+	s.mockRepo.EXPECT().
+		GetProduct(s.ctx, testProductID).
+		Return(nil, apperror.NewWithMessage(apperror.CodeNotFound, interest.ErrProductNotFound, apperror.MsgProductNotFound))
+
+	_, err := s.core.SetRate(s.ctx, testProductID, &entities.RateRequest{EffectiveFrom: "2026-04-01", AnnualRate: "0.045"})
+	s.Equal(apperror.CodeNotFound, err.Code())
+}
+
+func (s *CoreTestSuite) TestSetRateStoresRateAndReturnsSchedule() {
+	rate := &interest.Rate{EffectiveFrom: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), AnnualRate: decimal.RequireFromString("0.045")}
+	updated := product()
+	updated.Rates = append(updated.Rates, rate)
+
+	gomock.InOrder(
+		s.mockRepo.EXPECT().GetProduct(s.ctx, testProductID).Return(product(), nil),
+		s.mockRepo.EXPECT().SetRate(s.ctx, testProductID, rate).Return(nil),
+		s.mockRepo.EXPECT().GetProduct(s.ctx, testProductID).Return(updated, nil),
+	)
+
+	response, err := s.core.SetRate(s.ctx, testProductID, &entities.RateRequest{EffectiveFrom: "2026-04-01", AnnualRate: "0.045"})
+	s.Nil(err)
+	s.Len(response.Rates, 2)
+}
+
+// Test SetAccountProduct
+
+func (s *CoreTestSuite) TestSetAccountProductLinksCustomerAccount() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, testAccountID).
+		Return(&account.Account{AccountID: testAccountID, Kind: accountEntities.KindCustomer}, nil)
+	s.mockRepo.EXPECT().GetProduct(s.ctx, testProductID).Return(product(), nil)
+	s.mockRepo.EXPECT().
+		SetAccountProduct(s.ctx, &interest.AccountProduct{AccountID: testAccountID, ProductID: testProductID, EffectiveFrom: date(1)}).
+		Return(nil)
+
+	response, err := s.core.SetAccountProduct(s.ctx, testAccountID, &entities.SetAccountProductRequest{ProductID: testProductID, EffectiveFrom: "2026-03-01"})
+	s.Nil(err)
+	s.Equal(&entities.AccountProductResponse{AccountID: testAccountID, ProductID: testProductID, EffectiveFrom: "2026-03-01"}, response)
+}
+
+func (s *CoreTestSuite) TestSetAccountProductOnSystemAccountFails() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, testExpenseAccountID).
+		Return(&account.Account{AccountID: testExpenseAccountID, Kind: accountEntities.KindInterestExpense}, nil)
+
+	_, err := s.core.SetAccountProduct(s.ctx, testExpenseAccountID, &entities.SetAccountProductRequest{ProductID: testProductID, EffectiveFrom: "2026-03-01"})
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInterestSystemAccount, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestSetAccountProductWithoutDateFails() {
+	_, err := s.core.SetAccountProduct(s.ctx, testAccountID, &entities.SetAccountProductRequest{ProductID: testProductID})
+	s.Equal(apperror.MsgInvalidDate, err.PublicMessage())
+}
+
+// Test RunDue
+
+func (s *CoreTestSuite) TestRunDueFirstRunAccruesLatestSnapshotDate() {
+	s.mockSnapshotRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(9), true, nil)
+	s.mockRepo.EXPECT().LastAccruedDate(s.ctx).Return(time.Time{}, false, nil)
+	s.expectAccrual(date(9), []*interest.AccrualBasis{
+		{AccountID: testAccountID, ProductID: testProductID, Balance: decimal.NewFromInt(1000)},
+	}, 1, func(accruals []*interest.Accrual) {
+		s.Require().Len(accruals, 1)
+		s.Equal(date(9), accruals[0].AccrualDate)
+		s.Equal(1, accruals[0].Days)
+		s.Equal("0.1369863", accruals[0].Amount.String())
+		s.Equal("0.05", accruals[0].AnnualRate.String())
+		s.Equal(date(31), accruals[0].PeriodEnd)
+	})
+	s.mockRepo.EXPECT().ListPayoutsDue(s.ctx, date(9)).Return([]*interest.PayoutDue{}, nil)
+
+	accrued, err := s.core.RunDue(s.ctx)
+	s.NoError(err)
+	s.Equal(1, accrued)
+}
+
+func (s *CoreTestSuite) TestRunDueSkipsAccountsWithoutRateInForce() {
+	s.mockSnapshotRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(9), true, nil)
+	s.mockRepo.EXPECT().LastAccruedDate(s.ctx).Return(date(8), true, nil)
+	s.expectAccrual(date(9), []*interest.AccrualBasis{
+		{AccountID: testAccountID, ProductID: testProductID + 1, Balance: decimal.NewFromInt(1000)},
+	}, 0, func(accruals []*interest.Accrual) {
+		s.Empty(accruals)
+	})
+	s.mockRepo.EXPECT().ListPayoutsDue(s.ctx, date(9)).Return([]*interest.PayoutDue{}, nil)
+
+	accrued, err := s.core.RunDue(s.ctx)
+	s.NoError(err)
+	s.Equal(1, accrued)
+}
+
+func (s *CoreTestSuite) TestRunDueWithoutSnapshotsDoesNothing() {
+	s.mockSnapshotRepo.EXPECT().LastCompletedDate(s.ctx).Return(time.Time{}, false, nil)
+
+	accrued, err := s.core.RunDue(s.ctx)
+	s.NoError(err)
+	s.Equal(0, accrued)
+}
+
+func (s *CoreTestSuite) TestRunDueWhenLockHeldElsewhereStopsBeforePayouts() {
+	s.mockSnapshotRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(9), true, nil)
+	s.mockRepo.EXPECT().LastAccruedDate(s.ctx).Return(date(7), true, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().TryLock(s.ctx, s.mockTx).Return(false, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+
+	accrued, err := s.core.RunDue(s.ctx)
+	s.NoError(err)
+	s.Equal(0, accrued)
+}
+
+func (s *CoreTestSuite) TestRunDuePaysClosedPeriodFromInterestExpenseAccount() {
+	s.mockSnapshotRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(31), true, nil)
+	s.mockRepo.EXPECT().LastAccruedDate(s.ctx).Return(date(30), true, nil)
+	s.expectAccrual(date(31), nil, 0, nil)
+	s.mockRepo.EXPECT().
+		ListPayoutsDue(s.ctx, date(31)).
+		Return([]*interest.PayoutDue{{AccountID: testAccountID, PeriodEnd: date(31)}}, nil)
+	s.expectPayoutAccounts()
+
+	var postedID uuid.UUID
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().
+		ClaimAccruals(s.ctx, s.mockTx, gomock.Any(), testAccountID, date(31)).
+		Return(decimal.RequireFromString("4.24657534"), 31, nil)
+	s.mockPostings.EXPECT().
+		PostTx(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, req *txEntities.PostingRequest) (*txEntities.TransferResponse, apperror.IError) {
+			s.Equal(testExpenseAccountID, req.SourceAccountID)
+			s.Equal(testAccountID, req.DestinationAccountID)
+			s.Equal("4.24657534", req.Amount.String())
+			s.Equal(txEntities.TypeInterest, req.Type)
+			postedID = req.TransactionID
+			return &txEntities.TransferResponse{TransactionID: req.TransactionID.String()}, nil
+		})
+	s.mockRepo.EXPECT().
+		CreatePayout(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, payout *interest.Payout) error {
+			s.Require().NotNil(payout.TransactionID)
+			s.Equal(postedID, *payout.TransactionID)
+			s.Equal(31, payout.AccrualCount)
+			s.Equal(s.clock.Now().UTC(), payout.CreatedAt)
+			return nil
+		})
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil)
+
+	accrued, err := s.core.RunDue(s.ctx)
+	s.NoError(err)
+	s.Equal(1, accrued)
+}
+
+func (s *CoreTestSuite) TestRunDueRecordsZeroPayoutWithoutPosting() {
+	s.mockSnapshotRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(31), true, nil)
+	s.mockRepo.EXPECT().LastAccruedDate(s.ctx).Return(date(31), true, nil)
+	s.mockRepo.EXPECT().
+		ListPayoutsDue(s.ctx, date(31)).
+		Return([]*interest.PayoutDue{{AccountID: testAccountID, PeriodEnd: date(31)}}, nil)
+	s.expectPayoutAccounts()
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().
+		ClaimAccruals(s.ctx, s.mockTx, gomock.Any(), testAccountID, date(31)).
+		Return(decimal.Zero, 2, nil)
+	s.mockRepo.EXPECT().
+		CreatePayout(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, payout *interest.Payout) error {
+			s.Nil(payout.TransactionID)
+			return nil
+		})
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil)
+
+	accrued, err := s.core.RunDue(s.ctx)
+	s.NoError(err)
+	s.Equal(0, accrued)
+}
+
+func (s *CoreTestSuite) TestRunDueWhenPeriodAlreadyClaimedSkipsPosting() {
+	s.mockSnapshotRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(31), true, nil)
+	s.mockRepo.EXPECT().LastAccruedDate(s.ctx).Return(date(31), true, nil)
+	s.mockRepo.EXPECT().
+		ListPayoutsDue(s.ctx, date(31)).
+		Return([]*interest.PayoutDue{{AccountID: testAccountID, PeriodEnd: date(31)}}, nil)
+	s.expectPayoutAccounts()
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().
+		ClaimAccruals(s.ctx, s.mockTx, gomock.Any(), testAccountID, date(31)).
+		Return(decimal.Zero, 0, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+
+	_, err := s.core.RunDue(s.ctx)
+	s.NoError(err)
+}
+
+func (s *CoreTestSuite) TestRunDueWhenPostingFailsRollsBackAndContinues() {
+	other := int64(201)
+	s.mockSnapshotRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(31), true, nil)
+	s.mockRepo.EXPECT().LastAccruedDate(s.ctx).Return(date(31), true, nil)
+	s.mockRepo.EXPECT().
+		ListPayoutsDue(s.ctx, date(31)).
+		Return([]*interest.PayoutDue{
+			{AccountID: testAccountID, PeriodEnd: date(31)},
+			{AccountID: other, PeriodEnd: date(31)},
+		}, nil)
+	s.expectPayoutAccounts()
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().
+		ClaimAccruals(s.ctx, s.mockTx, gomock.Any(), testAccountID, date(31)).
+		Return(decimal.NewFromInt(1), 31, nil)
+	postErr := apperror.NewWithMessage(apperror.CodeBadRequest, errDatabaseError, apperror.MsgNotLeafAccount)
+	s.mockPostings.EXPECT().PostTx(s.ctx, s.mockTx, gomock.Any()).Return(nil, postErr)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+	s.mockAccountRepo.EXPECT().GetByID(s.ctx, other).Return(nil, errDatabaseError)
+
+	_, err := s.core.RunDue(s.ctx)
+	s.Equal(postErr, err)
+}
+
+// Test Backfill
+
+func (s *CoreTestSuite) TestBackfillSnapshotsAndAccruesEachDate() {
+	s.mockRepo.EXPECT().LastAccruedDate(s.ctx).Return(time.Time{}, false, nil)
+	for _, d := range []time.Time{date(1), date(2)} {
+		s.mockSnapshotCore.EXPECT().EnsureDate(s.ctx, d, s.clock.Now()).Return(true, nil)
+		s.expectAccrual(d, []*interest.AccrualBasis{
+			{AccountID: testAccountID, ProductID: testProductID, Balance: decimal.NewFromInt(500)},
+		}, 1, nil)
+	}
+	s.mockRepo.EXPECT().ListPayoutsDue(s.ctx, date(2)).Return([]*interest.PayoutDue{}, nil)
+
+	response, err := s.core.Backfill(s.ctx, date(1), date(2))
+	s.NoError(err)
+	s.Equal(&entities.BackfillResponse{From: "2026-03-01", To: "2026-03-02", DaysAccrued: 2, AccrualCount: 2}, response)
+}
+
+func (s *CoreTestSuite) TestBackfillWhenSnapshotLockHeldFails() {
+	s.mockRepo.EXPECT().LastAccruedDate(s.ctx).Return(time.Time{}, false, nil)
+	s.mockSnapshotCore.EXPECT().EnsureDate(s.ctx, date(1), s.clock.Now()).Return(false, nil)
+
+	response, err := s.core.Backfill(s.ctx, date(1), date(1))
+	s.ErrorIs(err, interest.ErrSnapshotUnavailable)
+	s.Equal(0, response.DaysAccrued)
+}
+
+func (s *CoreTestSuite) TestBackfillUsesClockToDecideWhichDatesClosed() {
+	s.clock.Set(time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC))
+	s.mockRepo.EXPECT().LastAccruedDate(s.ctx).Return(time.Time{}, false, nil)
+	s.mockSnapshotCore.EXPECT().
+		EnsureDate(s.ctx, date(5), time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC)).
+		Return(false, errDatabaseError)
+
+	_, err := s.core.Backfill(s.ctx, date(5), date(5))
+	s.Equal(errDatabaseError, err)
+}
+
+func (s *CoreTestSuite) TestBackfillWithInvertedRangeFails() {
+	_, err := s.core.Backfill(s.ctx, date(5), date(4))
+	s.ErrorIs(err, interest.ErrInvalidRange)
+}
+
+func (s *CoreTestSuite) TestBackfillPastLastAccruedDateFails() {
+	s.mockRepo.EXPECT().LastAccruedDate(s.ctx).Return(date(9), true, nil)
+
+	_, err := s.core.Backfill(s.ctx, date(1), date(10))
+	s.ErrorIs(err, interest.ErrInvalidRange)
+}
@@ -0,0 +1,128 @@
+package interest
+
+import (
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/interest/entities"
+	"github.com/shopspring/decimal"
+)
+
+// DayCount is a day-count convention: how many days a period counts for, out of a year of Basis days.
+// Dates are civil dates represented as midnight UTC.
+type DayCount interface {
+	Days(from, to time.Time) int
+	Basis() int
+}
+
+// actual365 counts actual calendar days over a 365-day year, leap years included
+type actual365 struct{}
+
+// Days returns the number of calendar days from from to to
+func (actual365) Days(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// Basis returns 365
+func (actual365) Basis() int {
+	return 365
+}
+
+// thirty360 counts every month as 30 days over a 360-day year (30/360 bond basis).
+// A day accrued on the 31st counts for nothing and the last day of February makes up
+// the rest of the month, so a whole month always counts for 30 days.
+type thirty360 struct{}
+
+// Days returns the 30/360 day count from from to to
+func (thirty360) Days(from, to time.Time) int {
+	d1, d2 := from.Day(), to.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	return 360*(to.Year()-from.Year()) + 30*(int(to.Month())-int(from.Month())) + d2 - d1
+}
+
+// Basis returns 360
+func (thirty360) Basis() int {
+	return 360
+}
+
+// DayCountFor returns the named day-count convention
+func DayCountFor(name string) (DayCount, bool) {
+	switch name {
+	case entities.DayCountACT365:
+		return actual365{}, true
+	case entities.DayCount30360:
+		return thirty360{}, true
+	}
+	return nil, false
+}
+
+// IsRounding reports whether name is a supported rounding rule
+func IsRounding(name string) bool {
+	switch name {
+	case entities.RoundingHalfEven, entities.RoundingHalfUp, entities.RoundingDown:
+		return true
+	}
+	return false
+}
+
+// DailyInterest returns balance * annualRate * days / basis rounded to AmountPlaces with the
+// given rule. The division is done exactly, so the result is rounded once and never off by a
+// unit in the last place. Non-positive balances earn nothing.
+func DailyInterest(balance, annualRate decimal.Decimal, days int, dc DayCount, rounding string) decimal.Decimal {
+	numerator := balance.Mul(annualRate).Mul(decimal.NewFromInt(int64(days)))
+	if !numerator.IsPositive() {
+		return decimal.Zero
+	}
+	return roundQuotient(numerator, decimal.NewFromInt(int64(dc.Basis())), rounding)
+}
+
+// roundQuotient divides two positive decimals and rounds the quotient to AmountPlaces
+func roundQuotient(numerator, denominator decimal.Decimal, rounding string) decimal.Decimal {
+	quotient, remainder := numerator.QuoRem(denominator, entities.AmountPlaces)
+	if remainder.IsZero() || rounding == entities.RoundingDown {
+		return quotient
+	}
+
+	unit := decimal.New(1, -entities.AmountPlaces)
+	// Compare the remainder with half the divisor, scaled back to the quotient's last place
+	half := remainder.Mul(decimal.NewFromInt(2)).Cmp(denominator.Mul(unit))
+	switch {
+	case half > 0:
+		return quotient.Add(unit)
+	case half == 0 && rounding == entities.RoundingHalfUp:
+		return quotient.Add(unit)
+	case half == 0 && quotient.Shift(entities.AmountPlaces).BigInt().Bit(0) == 1:
+		return quotient.Add(unit)
+	}
+	return quotient
+}
+
+// IsPayoutFrequency reports whether name is a supported payout frequency
+func IsPayoutFrequency(name string) bool {
+	return periodMonths(name) > 0
+}
+
+// PeriodEnd returns the last date of the payout period containing date
+func PeriodEnd(frequency string, date time.Time) time.Time {
+	months := periodMonths(frequency)
+	endMonth := ((int(date.Month())-1)/months + 1) * months
+	// Day 0 of the following month is the last day of endMonth
+	return time.Date(date.Year(), time.Month(endMonth+1), 0, 0, 0, 0, 0, time.UTC)
+}
+
+// periodMonths returns the length of a payout period in months, or 0 if unsupported
+func periodMonths(frequency string) int {
+	switch frequency {
+	case entities.PayoutMonthly:
+		return 1
+	case entities.PayoutQuarterly:
+		return 3
+	case entities.PayoutAnnually:
+		return 12
+	}
+	return 0
+}
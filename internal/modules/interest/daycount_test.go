@@ -0,0 +1,148 @@
+package interest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/interest"
+	"github.com/internal-transfers-service/internal/modules/interest/entities"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+)
+
+// DayCountTestSuite contains tests for day-count conventions, rounding and payout periods
+type DayCountTestSuite struct {
+	suite.Suite
+}
+
+func TestDayCountSuite(t *testing.T) {
+	suite.Run(t, new(DayCountTestSuite))
+}
+
+// civil returns a business date
+func civil(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// dailyDays returns the days a single business date counts for under the convention
+func dailyDays(dc interest.DayCount, date time.Time) int {
+	return dc.Days(date, date.AddDate(0, 0, 1))
+}
+
+// Test ACT/365
+
+func (s *DayCountTestSuite) TestActual365CountsCalendarDays() {
+	dc, ok := interest.DayCountFor(entities.DayCountACT365)
+	s.Require().True(ok)
+
+	s.Equal(365, dc.Basis())
+	s.Equal(1, dailyDays(dc, civil(2026, time.January, 31)))
+	s.Equal(1, dailyDays(dc, civil(2026, time.February, 28)))
+	s.Equal(366, dc.Days(civil(2024, time.January, 1), civil(2025, time.January, 1)))
+}
+
+// Test 30/360
+
+func (s *DayCountTestSuite) TestThirty360SkipsThe31st() {
+	dc, ok := interest.DayCountFor(entities.DayCount30360)
+	s.Require().True(ok)
+
+	s.Equal(360, dc.Basis())
+	s.Equal(1, dailyDays(dc, civil(2026, time.March, 10)))
+	s.Equal(0, dailyDays(dc, civil(2026, time.January, 30)))
+	s.Equal(1, dailyDays(dc, civil(2026, time.January, 31)))
+	s.Equal(1, dailyDays(dc, civil(2026, time.December, 31)))
+}
+
+func (s *DayCountTestSuite) TestThirty360EndOfFebruaryMakesUpTheMonth() {
+	dc, _ := interest.DayCountFor(entities.DayCount30360)
+
+	s.Equal(3, dailyDays(dc, civil(2026, time.February, 28)))
+	s.Equal(1, dailyDays(dc, civil(2024, time.February, 28)))
+	s.Equal(2, dailyDays(dc, civil(2024, time.February, 29)))
+}
+
+func (s *DayCountTestSuite) TestThirty360EveryMonthCountsThirtyDays() {
+	dc, _ := interest.DayCountFor(entities.DayCount30360)
+
+	for month := time.January; month <= time.December; month++ {
+		total := 0
+		for date := civil(2024, month, 1); date.Month() == month; date = date.AddDate(0, 0, 1) {
+			total += dailyDays(dc, date)
+		}
+		s.Equal(30, total, month.String())
+	}
+}
+
+func (s *DayCountTestSuite) TestDayCountForUnknownConventionFails() {
+	_, ok := interest.DayCountFor("ACT/ACT")
+	s.False(ok)
+}
+
+// Test DailyInterest
+
+func (s *DayCountTestSuite) TestDailyInterestRoundsToEightPlaces() {
+	dc, _ := interest.DayCountFor(entities.DayCountACT365)
+
+	// 1000 * 0.05 / 365 = 0.136986301369...
+	amount := interest.DailyInterest(decimal.NewFromInt(1000), decimal.RequireFromString("0.05"), 1, dc, entities.RoundingHalfEven)
+	s.Equal("0.13698630", amount.StringFixed(entities.AmountPlaces))
+	s.True(amount.Equal(amount.Truncate(entities.AmountPlaces)))
+}
+
+func (s *DayCountTestSuite) TestDailyInterestTieRoundsPerRule() {
+	dc, _ := interest.DayCountFor(entities.DayCount30360)
+	rate := decimal.RequireFromString("0.1")
+
+	// 444.444426 * 0.1 / 360 = 0.123456785 exactly, a tie on the 9th place with an even 8th
+	evenTie := decimal.RequireFromString("444.444426")
+	s.Equal("0.12345678", interest.DailyInterest(evenTie, rate, 1, dc, entities.RoundingHalfEven).String())
+	s.Equal("0.12345679", interest.DailyInterest(evenTie, rate, 1, dc, entities.RoundingHalfUp).String())
+	s.Equal("0.12345678", interest.DailyInterest(evenTie, rate, 1, dc, entities.RoundingDown).String())
+
+	// 444.44439 * 0.1 / 360 = 0.123456775 exactly, a tie with an odd 8th place
+	oddTie := decimal.RequireFromString("444.44439")
+	s.Equal("0.12345678", interest.DailyInterest(oddTie, rate, 1, dc, entities.RoundingHalfEven).String())
+	s.Equal("0.12345678", interest.DailyInterest(oddTie, rate, 1, dc, entities.RoundingHalfUp).String())
+	s.Equal("0.12345677", interest.DailyInterest(oddTie, rate, 1, dc, entities.RoundingDown).String())
+}
+
+func (s *DayCountTestSuite) TestDailyInterestAboveHalfRoundsUpUnlessDown() {
+	dc, _ := interest.DayCountFor(entities.DayCountACT365)
+
+	// 100 * 0.02 / 365 = 0.0054794520547...
+	balance := decimal.NewFromInt(100)
+	rate := decimal.RequireFromString("0.02")
+	s.Equal("0.00547945", interest.DailyInterest(balance, rate, 1, dc, entities.RoundingHalfEven).String())
+	s.Equal("0.00547945", interest.DailyInterest(balance, rate, 1, dc, entities.RoundingDown).String())
+
+	// 100 * 0.03 / 365 = 0.0082191780821...
+	s.Equal("0.00821918", interest.DailyInterest(balance, decimal.RequireFromString("0.03"), 1, dc, entities.RoundingHalfEven).String())
+	s.Equal("0.00821917", interest.DailyInterest(balance, decimal.RequireFromString("0.03"), 1, dc, entities.RoundingDown).String())
+}
+
+func (s *DayCountTestSuite) TestDailyInterestOnZeroDaysOrBalanceIsZero() {
+	dc, _ := interest.DayCountFor(entities.DayCount30360)
+	rate := decimal.RequireFromString("0.05")
+
+	s.True(interest.DailyInterest(decimal.NewFromInt(1000), rate, 0, dc, entities.RoundingHalfEven).IsZero())
+	s.True(interest.DailyInterest(decimal.Zero, rate, 1, dc, entities.RoundingHalfEven).IsZero())
+	s.True(interest.DailyInterest(decimal.NewFromInt(-5), rate, 1, dc, entities.RoundingHalfEven).IsZero())
+}
+
+// Test PeriodEnd
+
+func (s *DayCountTestSuite) TestPeriodEndByFrequency() {
+	s.Equal(civil(2024, time.February, 29), interest.PeriodEnd(entities.PayoutMonthly, civil(2024, time.February, 10)))
+	s.Equal(civil(2026, time.January, 31), interest.PeriodEnd(entities.PayoutMonthly, civil(2026, time.January, 31)))
+	s.Equal(civil(2026, time.June, 30), interest.PeriodEnd(entities.PayoutQuarterly, civil(2026, time.May, 5)))
+	s.Equal(civil(2026, time.December, 31), interest.PeriodEnd(entities.PayoutQuarterly, civil(2026, time.December, 31)))
+	s.Equal(civil(2026, time.December, 31), interest.PeriodEnd(entities.PayoutAnnually, civil(2026, time.March, 1)))
+}
+
+func (s *DayCountTestSuite) TestRoundingAndFrequencyValidation() {
+	s.True(interest.IsRounding(entities.RoundingHalfUp))
+	s.False(interest.IsRounding("ceiling"))
+	s.True(interest.IsPayoutFrequency(entities.PayoutQuarterly))
+	s.False(interest.IsPayoutFrequency("weekly"))
+}
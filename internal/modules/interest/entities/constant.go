@@ -0,0 +1,76 @@
+// Package entities provides request/response types and constants for the interest module.
+package entities
+
+// Error messages for the interest module
+const (
+	ErrMsgProductNotFound     = "interest product not found"
+	ErrMsgInvalidProductID    = "invalid interest product ID"
+	ErrMsgInvalidProductName  = "invalid interest product name"
+	ErrMsgProductExists       = "interest product name already in use"
+	ErrMsgInvalidDayCount     = "unsupported day-count convention"
+	ErrMsgInvalidRounding     = "unsupported rounding rule"
+	ErrMsgInvalidFrequency    = "unsupported payout frequency"
+	ErrMsgInvalidAnnualRate   = "invalid annual rate"
+	ErrMsgRatesRequired       = "interest product has no rates"
+	ErrMsgDuplicateRateDate   = "duplicate rate effective date"
+	ErrMsgInvalidDate         = "invalid date, expected YYYY-MM-DD"
+	ErrMsgInvalidRange        = "invalid backfill date range"
+	ErrMsgAccountNotFound     = "account not found"
+	ErrMsgInvalidAccountID    = "invalid account ID"
+	ErrMsgSystemAccount       = "interest products cannot be attached to system accounts"
+	ErrMsgLockHeldElsewhere   = "interest run in progress on another replica"
+	ErrMsgSnapshotUnavailable = "end-of-day snapshots unavailable for business date"
+)
+
+// Route path constants for the interest module (admin router)
+const (
+	RouteProducts        = "/interest-products"
+	RouteProductByID     = "/interest-products/{productID}"
+	RouteProductRates    = "/interest-products/{productID}/rates"
+	RouteAccountInterest = "/accounts/{accountID}/interest-product"
+	ParamProductID       = "productID"
+	ParamAccountID       = "accountID"
+)
+
+// Day-count conventions (interest_products.day_count)
+const (
+	// DayCountACT365 counts actual calendar days over a 365-day year
+	DayCountACT365 = "ACT/365"
+	// DayCount30360 counts 30-day months over a 360-day year (bond basis)
+	DayCount30360 = "30/360"
+)
+
+// Rounding rules applied to each daily accrual (interest_products.rounding)
+const (
+	// RoundingHalfEven rounds ties to the even digit (banker's rounding)
+	RoundingHalfEven = "half_even"
+	// RoundingHalfUp rounds ties away from zero
+	RoundingHalfUp = "half_up"
+	// RoundingDown truncates towards zero
+	RoundingDown = "down"
+)
+
+// Payout frequencies (interest_products.payout_frequency)
+const (
+	PayoutMonthly   = "monthly"
+	PayoutQuarterly = "quarterly"
+	PayoutAnnually  = "annually"
+)
+
+// Product defaults and limits
+const (
+	DefaultRounding  = RoundingHalfEven
+	DefaultFrequency = PayoutMonthly
+	MaxNameLength    = 64
+	// AmountPlaces matches the 8 decimal places of DECIMAL(19,8) balances
+	AmountPlaces = 8
+	// RatePlaces and MaxAnnualRate match DECIMAL(10,8) rates
+	RatePlaces    = 8
+	MaxAnnualRate = 100
+)
+
+// DateLayout is the wire and query format of business dates
+const DateLayout = "2006-01-02"
+
+// AdvisoryLockKey serialises accrual and payout runs across replicas (pg_try_advisory_xact_lock)
+const AdvisoryLockKey int64 = 0x696e746572657374 // "interest"
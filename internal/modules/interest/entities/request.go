@@ -0,0 +1,25 @@
+package entities
+
+// CreateProductRequest represents the request to create an interest product.
+// Rounding defaults to half_even and PayoutFrequency to monthly.
+type CreateProductRequest struct {
+	Name            string        `json:"name"`
+	DayCount        string        `json:"day_count"`
+	Rounding        string        `json:"rounding"`
+	PayoutFrequency string        `json:"payout_frequency"`
+	Rates           []RateRequest `json:"rates"`
+}
+
+// RateRequest represents one entry of a product's rate schedule.
+// AnnualRate is a decimal fraction: "0.035" is 3.5% a year.
+type RateRequest struct {
+	EffectiveFrom string `json:"effective_from"`
+	AnnualRate    string `json:"annual_rate"`
+}
+
+// SetAccountProductRequest represents the request to put an account on an interest product
+// from a business date onwards
+type SetAccountProductRequest struct {
+	ProductID     int64  `json:"product_id"`
+	EffectiveFrom string `json:"effective_from"`
+}
@@ -0,0 +1,34 @@
+package entities
+
+// ProductResponse represents an interest product and its rate schedule
+type ProductResponse struct {
+	ProductID       int64           `json:"product_id"`
+	Name            string          `json:"name"`
+	DayCount        string          `json:"day_count"`
+	Rounding        string          `json:"rounding"`
+	PayoutFrequency string          `json:"payout_frequency"`
+	Rates           []*RateResponse `json:"rates"`
+	CreatedAt       string          `json:"created_at"`
+}
+
+// RateResponse represents one entry of a product's rate schedule
+type RateResponse struct {
+	EffectiveFrom string `json:"effective_from"`
+	AnnualRate    string `json:"annual_rate"`
+}
+
+// AccountProductResponse represents the interest product an account earns under
+type AccountProductResponse struct {
+	AccountID     int64  `json:"account_id"`
+	ProductID     int64  `json:"product_id"`
+	EffectiveFrom string `json:"effective_from"`
+}
+
+// BackfillResponse summarises an interest backfill run
+type BackfillResponse struct {
+	From         string `json:"from"`
+	To           string `json:"to"`
+	DaysAccrued  int    `json:"days_accrued"`
+	AccrualCount int64  `json:"accrual_count"`
+	PayoutCount  int    `json:"payout_count"`
+}
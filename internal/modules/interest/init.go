@@ -0,0 +1,111 @@
+// Package interest accrues daily interest on account balances and pays it out periodically.
+package interest
+
+import (
+	"context"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/account"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/internal/modules/transaction"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var InterestModule IModule
+
+// NewModule initializes the interest module
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool, accountRepo account.IRepository, snapshotModule snapshot.IModule, postings transaction.ICore, recorder audit.IRecorder) IModule {
+	if InterestModule == nil {
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper, recorder)
+		core := NewCore(ctx, repo, accountRepo, snapshotModule.GetRepository(), snapshotModule.GetCore(), postings, clock.Real{})
+		handler := NewHTTPHandler(core)
+
+		InterestModule = &Module{
+			Core:    core,
+			Handler: handler,
+			Repo:    repo,
+		}
+	}
+	return InterestModule
+}
+
+// IModule defines the interface for the interest module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+	StartWorker(ctx context.Context, interval time.Duration)
+	StopWorker()
+}
+
+// Module implements IModule
+type Module struct {
+	Core       ICore
+	Handler    *HTTPHandler
+	Repo       IRepository
+	cancelFunc context.CancelFunc
+}
+
+// Compile-time interface check
+var _ IModule = (*Module)(nil)
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
+
+// StartWorker starts a background goroutine that accrues each business date once it has
+// been snapshotted and pays out periods as they close
+func (m *Module) StartWorker(ctx context.Context, interval time.Duration) {
+	workerCtx, cancel := context.WithCancel(ctx)
+	m.cancelFunc = cancel
+
+	go m.runLoop(workerCtx, interval)
+}
+
+// StopWorker stops the interest worker
+func (m *Module) StopWorker() {
+	if m.cancelFunc != nil {
+		m.cancelFunc()
+	}
+}
+
+// runLoop runs the interest job immediately and then on every tick
+func (m *Module) runLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	m.runDue(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.runDue(ctx)
+		}
+	}
+}
+
+// runDue accrues and pays out any due interest and logs failures
+func (m *Module) runDue(ctx context.Context) {
+	if _, err := m.Core.RunDue(ctx); err != nil && ctx.Err() == nil {
+		logger.Error(constants.LogMsgInterestRunFailed, constants.LogKeyError, err)
+	}
+}
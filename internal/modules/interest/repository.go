@@ -0,0 +1,638 @@
+package interest
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	auditentities "github.com/internal-transfers-service/internal/modules/audit/entities"
+	"github.com/internal-transfers-service/internal/modules/interest/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// Product is an interest product with its rate schedule, oldest rate first
+type Product struct {
+	ProductID       int64     `json:"product_id"`
+	Name            string    `json:"name"`
+	DayCount        string    `json:"day_count"`
+	Rounding        string    `json:"rounding"`
+	PayoutFrequency string    `json:"payout_frequency"`
+	CreatedAt       time.Time `json:"created_at"`
+	Rates           []*Rate   `json:"rates"`
+}
+
+// RateOn returns the annual rate in force on date, if the schedule has started by then
+func (p *Product) RateOn(date time.Time) (decimal.Decimal, bool) {
+	for i := len(p.Rates) - 1; i >= 0; i-- {
+		if !p.Rates[i].EffectiveFrom.After(date) {
+			return p.Rates[i].AnnualRate, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// Rate is one entry of a product's rate schedule; it applies until the next entry
+type Rate struct {
+	EffectiveFrom time.Time       `json:"effective_from"`
+	AnnualRate    decimal.Decimal `json:"annual_rate"`
+}
+
+// AccountProduct links an account to the product it accrues interest under
+type AccountProduct struct {
+	AccountID     int64     `json:"account_id"`
+	ProductID     int64     `json:"product_id"`
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+// AccrualBasis is an interest-bearing account's end-of-day balance for a business date
+type AccrualBasis struct {
+	AccountID int64
+	ProductID int64
+	Balance   decimal.Decimal
+}
+
+// Accrual is one day's interest on an account
+type Accrual struct {
+	AccountID   int64           `json:"account_id"`
+	AccrualDate time.Time       `json:"accrual_date"`
+	ProductID   int64           `json:"product_id"`
+	Balance     decimal.Decimal `json:"balance"`
+	AnnualRate  decimal.Decimal `json:"annual_rate"`
+	DayCount    string          `json:"day_count"`
+	Days        int             `json:"days"`
+	Amount      decimal.Decimal `json:"amount"`
+	PeriodEnd   time.Time       `json:"period_end"`
+}
+
+// PayoutDue identifies an account's payout period with unpaid accruals
+type PayoutDue struct {
+	AccountID int64
+	PeriodEnd time.Time
+}
+
+// Payout is the interest paid to an account for a period
+type Payout struct {
+	PayoutID      uuid.UUID       `json:"payout_id"`
+	AccountID     int64           `json:"account_id"`
+	PeriodEnd     time.Time       `json:"period_end"`
+	Amount        decimal.Decimal `json:"amount"`
+	AccrualCount  int             `json:"accrual_count"`
+	TransactionID *uuid.UUID      `json:"transaction_id"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// IRepository defines the interface for interest data access
+type IRepository interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	TryLock(ctx context.Context, tx pgx.Tx) (bool, error)
+	CreateProduct(ctx context.Context, product *Product) error
+	GetProduct(ctx context.Context, productID int64) (*Product, error)
+	ListProducts(ctx context.Context) (map[int64]*Product, error)
+	SetRate(ctx context.Context, productID int64, rate *Rate) error
+	SetAccountProduct(ctx context.Context, link *AccountProduct) error
+	LastAccruedDate(ctx context.Context) (time.Time, bool, error)
+	ListAccrualBasis(ctx context.Context, tx pgx.Tx, date time.Time) ([]*AccrualBasis, error)
+	CreateAccruals(ctx context.Context, tx pgx.Tx, accruals []*Accrual) (int64, error)
+	MarkAccrued(ctx context.Context, tx pgx.Tx, date time.Time, accountCount int64, completedAt time.Time) error
+	ListPayoutsDue(ctx context.Context, through time.Time) ([]*PayoutDue, error)
+	ClaimAccruals(ctx context.Context, tx pgx.Tx, payoutID uuid.UUID, accountID int64, periodEnd time.Time) (decimal.Decimal, int, error)
+	CreatePayout(ctx context.Context, tx pgx.Tx, payout *Payout) error
+}
+
+// Repository implements IRepository. Product and rate changes are recorded in the audit
+// log within the same transaction; accruals and payouts are derived data and are not.
+type Repository struct {
+	pool  database.IPool
+	audit audit.IRecorder
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new interest repository
+func NewRepository(pool database.IPool, recorder audit.IRecorder) *Repository {
+	return &Repository{pool: pool, audit: recorder}
+}
+
+// accrualInsertChunkSize bounds the rows written by a single accrual insert
+const accrualInsertChunkSize = 1000
+
+// SQL queries
+const (
+	queryTryLock = `
+		SELECT pg_try_advisory_xact_lock($1)`
+
+	// The product and its initial rates are written by one statement, so a rejected
+	// rate leaves no product behind
+	queryInsertProduct = `
+		WITH product AS (
+			INSERT INTO interest_products (name, day_count, rounding, payout_frequency, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING product_id
+		), rates AS (
+			INSERT INTO interest_rates (product_id, effective_from, annual_rate)
+			SELECT product.product_id, r.effective_from, r.annual_rate
+			FROM product, unnest($6::date[], $7::numeric[]) AS r(effective_from, annual_rate)
+		)
+		SELECT product_id FROM product`
+
+	querySelectProduct = `
+		SELECT ` + productColumns + `
+		FROM interest_products
+		WHERE product_id = $1`
+
+	querySelectProducts = `
+		SELECT ` + productColumns + `
+		FROM interest_products
+		ORDER BY product_id`
+
+	querySelectRates = `
+		SELECT product_id, effective_from, annual_rate
+		FROM interest_rates
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, effective_from`
+
+	// Returns the rate being replaced, or NULL when the date is new
+	queryUpsertRate = `
+		WITH prev AS (
+			SELECT annual_rate FROM interest_rates
+			WHERE product_id = $1 AND effective_from = $2
+			FOR UPDATE
+		)
+		INSERT INTO interest_rates (product_id, effective_from, annual_rate)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (product_id, effective_from) DO UPDATE SET annual_rate = EXCLUDED.annual_rate
+		RETURNING (SELECT annual_rate FROM prev)`
+
+	// Returns the product link being replaced, or NULLs when the account had none
+	queryUpsertAccountProduct = `
+		WITH prev AS (
+			SELECT product_id, effective_from FROM account_interest
+			WHERE account_id = $1
+			FOR UPDATE
+		)
+		INSERT INTO account_interest (account_id, product_id, effective_from, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (account_id) DO UPDATE
+		SET product_id = EXCLUDED.product_id, effective_from = EXCLUDED.effective_from, updated_at = EXCLUDED.updated_at
+		RETURNING (SELECT product_id FROM prev), (SELECT effective_from FROM prev)`
+
+	queryLastAccruedDate = `
+		SELECT accrual_date
+		FROM interest_accrual_runs
+		ORDER BY accrual_date DESC
+		LIMIT 1`
+
+	// Accounts already accrued for the date are left out, so re-running a date is harmless
+	querySelectAccrualBasis = `
+		SELECT ai.account_id, ai.product_id, s.balance
+		FROM account_interest ai
+		JOIN balance_snapshots s ON s.account_id = ai.account_id AND s.snapshot_date = $1
+		WHERE ai.effective_from <= $1
+			AND s.balance > 0
+			AND NOT EXISTS (
+				SELECT 1 FROM interest_accruals ia
+				WHERE ia.account_id = ai.account_id AND ia.accrual_date = $1
+			)
+		ORDER BY ai.account_id`
+
+	queryInsertAccruals = `
+		INSERT INTO interest_accruals
+			(account_id, accrual_date, product_id, balance, annual_rate, day_count, days, amount, period_end, created_at)
+		SELECT r.account_id, $1, r.product_id, r.balance, r.annual_rate, r.day_count, r.days, r.amount, r.period_end, $10
+		FROM unnest($2::bigint[], $3::bigint[], $4::numeric[], $5::numeric[], $6::text[], $7::int[], $8::numeric[], $9::date[])
+			AS r(account_id, product_id, balance, annual_rate, day_count, days, amount, period_end)
+		ON CONFLICT (account_id, accrual_date) DO NOTHING`
+
+	queryInsertAccrualRun = `
+		INSERT INTO interest_accrual_runs (accrual_date, account_count, completed_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (accrual_date) DO NOTHING`
+
+	querySelectPayoutsDue = `
+		SELECT DISTINCT account_id, period_end
+		FROM interest_accruals
+		WHERE payout_id IS NULL AND period_end <= $1
+		ORDER BY period_end, account_id`
+
+	// Claiming marks the accruals before the payout row exists; the foreign key is
+	// deferred to commit. A concurrent claim blocks on the row locks and then finds
+	// nothing left to claim.
+	queryClaimAccruals = `
+		WITH claimed AS (
+			UPDATE interest_accruals
+			SET payout_id = $1
+			WHERE account_id = $2 AND period_end = $3 AND payout_id IS NULL
+			RETURNING amount
+		)
+		SELECT COALESCE(SUM(amount), 0), COUNT(*) FROM claimed`
+
+	queryInsertPayout = `
+		INSERT INTO interest_payouts (payout_id, account_id, period_end, amount, accrual_count, transaction_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+)
+
+// BeginTx starts a new database transaction
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel: pgx.ReadCommitted,
+	})
+}
+
+// TryLock takes the interest advisory lock for the lifetime of tx.
+// Returns false if another replica holds it.
+func (r *Repository) TryLock(ctx context.Context, tx pgx.Tx) (bool, error) {
+	var locked bool
+	if err := tx.QueryRow(ctx, queryTryLock, entities.AdvisoryLockKey).Scan(&locked); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgInterestLockFailed,
+			constants.LogKeyError, err,
+		)
+		return false, err
+	}
+	return locked, nil
+}
+
+// CreateProduct inserts a product with its initial rates and sets its ID.
+// Returns a CONFLICT apperror if the name is already in use.
+func (r *Repository) CreateProduct(ctx context.Context, product *Product) error {
+	product.CreatedAt = time.Now().UTC()
+	dates := make([]time.Time, len(product.Rates))
+	rates := make([]string, len(product.Rates))
+	for i, rate := range product.Rates {
+		dates[i] = rate.EffectiveFrom
+		rates[i] = rate.AnnualRate.String()
+	}
+
+	return database.InTx(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, queryInsertProduct,
+			product.Name,
+			product.DayCount,
+			product.Rounding,
+			product.PayoutFrequency,
+			product.CreatedAt,
+			dates,
+			rates,
+		).Scan(&product.ProductID)
+
+		if err != nil {
+			if database.IsUniqueViolation(err) {
+				return apperror.NewWithMessage(apperror.CodeConflict, ErrProductExists, apperror.MsgDuplicateProduct).
+					WithField(apperror.FieldName, product.Name)
+			}
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteInterest,
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+
+		return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionProductCreated, auditentities.EntityInterestProduct,
+			strconv.FormatInt(product.ProductID, 10), nil, product))
+	})
+}
+
+// GetProduct returns a product with its rate schedule.
+// Returns a NOT_FOUND apperror if it does not exist.
+func (r *Repository) GetProduct(ctx context.Context, productID int64) (*Product, error) {
+	product, err := scanProduct(r.pool.QueryRow(ctx, querySelectProduct, productID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewWithMessage(apperror.CodeNotFound, ErrProductNotFound, apperror.MsgProductNotFound).
+				WithField(apperror.FieldProductID, productID)
+		}
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+			constants.LogFieldProductID, productID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+
+	products := map[int64]*Product{product.ProductID: product}
+	if err := r.loadRates(ctx, products); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// ListProducts returns every product with its rate schedule, keyed by ID
+func (r *Repository) ListProducts(ctx context.Context) (map[int64]*Product, error) {
+	rows, err := r.pool.Query(ctx, querySelectProducts)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make(map[int64]*Product)
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+				constants.LogKeyError, err,
+			)
+			return nil, err
+		}
+		products[product.ProductID] = product
+	}
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+
+	if err := r.loadRates(ctx, products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// loadRates attaches each product's rate schedule, oldest rate first
+func (r *Repository) loadRates(ctx context.Context, products map[int64]*Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(products))
+	for id := range products {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	rows, err := r.pool.Query(ctx, querySelectRates, ids)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+			constants.LogKeyError, err,
+		)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID int64
+		var rate Rate
+		if err := rows.Scan(&productID, &rate.EffectiveFrom, &rate.AnnualRate); err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+		if product, ok := products[productID]; ok {
+			product.Rates = append(product.Rates, &rate)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+			constants.LogKeyError, err,
+		)
+		return err
+	}
+	return nil
+}
+
+// SetRate adds a rate to a product's schedule, replacing any rate with the same effective date
+func (r *Repository) SetRate(ctx context.Context, productID int64, rate *Rate) error {
+	return database.InTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var prev decimal.NullDecimal
+		if err := tx.QueryRow(ctx, queryUpsertRate, productID, rate.EffectiveFrom, rate.AnnualRate).Scan(&prev); err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteInterest,
+				constants.LogFieldProductID, productID,
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+
+		var before any
+		if prev.Valid {
+			before = &Rate{EffectiveFrom: rate.EffectiveFrom, AnnualRate: prev.Decimal}
+		}
+		return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionRateSet, auditentities.EntityInterestProduct,
+			strconv.FormatInt(productID, 10), before, rate))
+	})
+}
+
+// SetAccountProduct puts an account on a product, replacing its current product if any
+func (r *Repository) SetAccountProduct(ctx context.Context, link *AccountProduct) error {
+	return database.InTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var prevProductID *int64
+		var prevEffectiveFrom *time.Time
+		if err := tx.QueryRow(ctx, queryUpsertAccountProduct,
+			link.AccountID,
+			link.ProductID,
+			link.EffectiveFrom,
+			time.Now().UTC(),
+		).Scan(&prevProductID, &prevEffectiveFrom); err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteInterest,
+				constants.LogKeyAccountID, link.AccountID,
+				constants.LogFieldProductID, link.ProductID,
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+
+		var before any
+		if prevProductID != nil && prevEffectiveFrom != nil {
+			before = &AccountProduct{AccountID: link.AccountID, ProductID: *prevProductID, EffectiveFrom: *prevEffectiveFrom}
+		}
+		return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionAccountProductSet, auditentities.EntityAccountInterest,
+			strconv.FormatInt(link.AccountID, 10), before, link))
+	})
+}
+
+// LastAccruedDate returns the most recent fully accrued business date, if any
+func (r *Repository) LastAccruedDate(ctx context.Context) (time.Time, bool, error) {
+	var date time.Time
+	err := r.pool.QueryRow(ctx, queryLastAccruedDate).Scan(&date)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, false, nil
+		}
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+			constants.LogKeyError, err,
+		)
+		return time.Time{}, false, err
+	}
+	return date, true, nil
+}
+
+// ListAccrualBasis returns the end-of-day balance of every positive interest-bearing
+// account that has not yet accrued for date
+func (r *Repository) ListAccrualBasis(ctx context.Context, tx pgx.Tx, date time.Time) ([]*AccrualBasis, error) {
+	rows, err := tx.Query(ctx, querySelectAccrualBasis, date)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+			constants.LogFieldAccrualDate, date,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	basis := make([]*AccrualBasis, 0)
+	for rows.Next() {
+		var b AccrualBasis
+		if err := rows.Scan(&b.AccountID, &b.ProductID, &b.Balance); err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+				constants.LogFieldAccrualDate, date,
+				constants.LogKeyError, err,
+			)
+			return nil, err
+		}
+		basis = append(basis, &b)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+			constants.LogFieldAccrualDate, date,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	return basis, nil
+}
+
+// CreateAccruals writes accruals for a single business date in chunks.
+// Returns the number of rows inserted.
+func (r *Repository) CreateAccruals(ctx context.Context, tx pgx.Tx, accruals []*Accrual) (int64, error) {
+	if len(accruals) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	var inserted int64
+	for chunk := range slices.Chunk(accruals, accrualInsertChunkSize) {
+		accountIDs := make([]int64, len(chunk))
+		productIDs := make([]int64, len(chunk))
+		balances := make([]string, len(chunk))
+		rates := make([]string, len(chunk))
+		dayCounts := make([]string, len(chunk))
+		days := make([]int32, len(chunk))
+		amounts := make([]string, len(chunk))
+		periodEnds := make([]time.Time, len(chunk))
+		for i, a := range chunk {
+			accountIDs[i] = a.AccountID
+			productIDs[i] = a.ProductID
+			balances[i] = a.Balance.String()
+			rates[i] = a.AnnualRate.String()
+			dayCounts[i] = a.DayCount
+			days[i] = int32(a.Days)
+			amounts[i] = a.Amount.String()
+			periodEnds[i] = a.PeriodEnd
+		}
+
+		tag, err := tx.Exec(ctx, queryInsertAccruals, chunk[0].AccrualDate,
+			accountIDs, productIDs, balances, rates, dayCounts, days, amounts, periodEnds, now)
+		if err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteInterest,
+				constants.LogFieldAccrualDate, chunk[0].AccrualDate,
+				constants.LogKeyError, err,
+			)
+			return 0, err
+		}
+		inserted += tag.RowsAffected()
+	}
+	return inserted, nil
+}
+
+// MarkAccrued records the business date as fully accrued
+func (r *Repository) MarkAccrued(ctx context.Context, tx pgx.Tx, date time.Time, accountCount int64, completedAt time.Time) error {
+	if _, err := tx.Exec(ctx, queryInsertAccrualRun, date, accountCount, completedAt); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteInterest,
+			constants.LogFieldAccrualDate, date,
+			constants.LogKeyError, err,
+		)
+		return err
+	}
+	return nil
+}
+
+// ListPayoutsDue returns the account periods ending on or before through that have unpaid accruals
+func (r *Repository) ListPayoutsDue(ctx context.Context, through time.Time) ([]*PayoutDue, error) {
+	rows, err := r.pool.Query(ctx, querySelectPayoutsDue, through)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	dues := make([]*PayoutDue, 0)
+	for rows.Next() {
+		var due PayoutDue
+		if err := rows.Scan(&due.AccountID, &due.PeriodEnd); err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+				constants.LogKeyError, err,
+			)
+			return nil, err
+		}
+		dues = append(dues, &due)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadInterest,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	return dues, nil
+}
+
+// ClaimAccruals assigns an account period's unpaid accruals to payoutID.
+// Returns their total and count; a count of zero means another run already paid them.
+func (r *Repository) ClaimAccruals(ctx context.Context, tx pgx.Tx, payoutID uuid.UUID, accountID int64, periodEnd time.Time) (decimal.Decimal, int, error) {
+	var total decimal.Decimal
+	var count int
+	if err := tx.QueryRow(ctx, queryClaimAccruals, payoutID, accountID, periodEnd).Scan(&total, &count); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteInterest,
+			constants.LogKeyAccountID, accountID,
+			constants.LogFieldPeriodEnd, periodEnd,
+			constants.LogKeyError, err,
+		)
+		return decimal.Zero, 0, err
+	}
+	return total, count, nil
+}
+
+// CreatePayout records a payout
+func (r *Repository) CreatePayout(ctx context.Context, tx pgx.Tx, payout *Payout) error {
+	if _, err := tx.Exec(ctx, queryInsertPayout,
+		payout.PayoutID,
+		payout.AccountID,
+		payout.PeriodEnd,
+		payout.Amount,
+		payout.AccrualCount,
+		payout.TransactionID,
+		payout.CreatedAt,
+	); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteInterest,
+			constants.LogKeyAccountID, payout.AccountID,
+			constants.LogFieldPayoutID, payout.PayoutID.String(),
+			constants.LogKeyError, err,
+		)
+		return err
+	}
+	return nil
+}
+
+// productColumns lists the columns scanned by scanProduct, in order
+const productColumns = `product_id, name, day_count, rounding, payout_frequency, created_at`
+
+// scanProduct scans a row selected with productColumns
+func scanProduct(row pgx.Row) (*Product, error) {
+	var p Product
+	if err := row.Scan(&p.ProductID, &p.Name, &p.DayCount, &p.Rounding, &p.PayoutFrequency, &p.CreatedAt); err != nil {
+		return nil, err
+	}
+	p.Rates = make([]*Rate, 0)
+	return &p, nil
+}
@@ -0,0 +1,408 @@
+package interest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	auditentities "github.com/internal-transfers-service/internal/modules/audit/entities"
+	auditMock "github.com/internal-transfers-service/internal/modules/audit/mock"
+	"github.com/internal-transfers-service/internal/modules/interest"
+	"github.com/internal-transfers-service/internal/modules/interest/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+	dbMock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in repository tests
+var (
+	errRepoQueryFailed = errors.New("query execution failed")
+)
+
+// RepositoryTestSuite contains tests for interest Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	mockPool  *dbMock.MockIPool
+	mockRow   *dbMock.MockRow
+	mockRows  *dbMock.MockRows
+	mockTx    *dbMock.MockTx
+	mockAudit *auditMock.MockIRecorder
+	repo      interest.IRepository
+	ctx       context.Context
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbMock.NewMockIPool(s.ctrl)
+	s.mockRow = dbMock.NewMockRow(s.ctrl)
+	s.mockRows = dbMock.NewMockRows(s.ctrl)
+	s.mockTx = dbMock.NewMockTx(s.ctrl)
+	s.mockAudit = auditMock.NewMockIRecorder(s.ctrl)
+	s.ctx = context.Background()
+	s.repo = interest.NewRepository(s.mockPool, s.mockAudit)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// expectTx expects the repository to open its own transaction, rolled back unless committed
+func (s *RepositoryTestSuite) expectTx() {
+	s.mockPool.EXPECT().BeginTx(s.ctx, gomock.Any()).Return(s.mockTx, nil).Times(1)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil).AnyTimes()
+}
+
+// Test BeginTx
+
+func (s *RepositoryTestSuite) TestBeginTxUsesReadCommitted() {
+	s.mockPool.EXPECT().
+		BeginTx(s.ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+		Return(s.mockTx, nil).
+		Times(1)
+
+	tx, err := s.repo.BeginTx(s.ctx)
+	s.NoError(err)
+	s.Equal(s.mockTx, tx)
+}
+
+// Test TryLock
+
+func (s *RepositoryTestSuite) TestTryLockUsesInterestLockKey() {
+	s.mockTx.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), entities.AdvisoryLockKey).
+		Return(s.mockRow).
+		Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*bool) = true
+			return nil
+		}).
+		Times(1)
+
+	locked, err := s.repo.TryLock(s.ctx, s.mockTx)
+	s.NoError(err)
+	s.True(locked)
+}
+
+// Test CreateProduct
+
+func (s *RepositoryTestSuite) TestCreateProductWritesRatesAndSetsID() {
+	product := &interest.Product{
+		Name:            "Savings",
+		DayCount:        entities.DayCountACT365,
+		Rounding:        entities.RoundingHalfEven,
+		PayoutFrequency: entities.PayoutMonthly,
+		Rates: []*interest.Rate{
+			{EffectiveFrom: date(1), AnnualRate: decimal.RequireFromString("0.05")},
+		},
+	}
+
+	s.expectTx()
+	s.mockTx.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), "Savings", entities.DayCountACT365, entities.RoundingHalfEven, entities.PayoutMonthly,
+			gomock.Any(), []time.Time{date(1)}, []string{"0.05"}).
+		Return(s.mockRow).
+		Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = testProductID
+			return nil
+		}).
+		Times(1)
+	s.mockAudit.EXPECT().
+		Record(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, events ...*audit.Event) error {
+			s.Require().Len(events, 1)
+			s.Equal(auditentities.ActionProductCreated, events[0].Action)
+			s.Equal("7", events[0].EntityID)
+			s.Nil(events[0].Before)
+			return nil
+		}).
+		Times(1)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+
+	s.NoError(s.repo.CreateProduct(s.ctx, product))
+	s.Equal(testProductID, product.ProductID)
+	s.False(product.CreatedAt.IsZero())
+}
+
+func (s *RepositoryTestSuite) TestCreateProductWithTakenNameReturnsConflict() {
+	s.expectTx()
+	s.mockTx.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(s.mockRow).
+		Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(&pgconn.PgError{Code: "23505"}).Times(1)
+
+	err := s.repo.CreateProduct(s.ctx, &interest.Product{Name: "Savings"})
+
+	var appErr *apperror.Error
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(apperror.CodeConflict, appErr.Code())
+	s.Equal(apperror.MsgDuplicateProduct, appErr.PublicMessage())
+}
+
+// Test SetRate
+
+func (s *RepositoryTestSuite) TestSetRateRecordsReplacedRate() {
+	rate := &interest.Rate{EffectiveFrom: date(1), AnnualRate: decimal.RequireFromString("0.04")}
+	s.expectTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), testProductID, date(1), rate.AnnualRate).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*decimal.NullDecimal) = decimal.NewNullDecimal(decimal.RequireFromString("0.05"))
+			return nil
+		}).
+		Times(1)
+	s.mockAudit.EXPECT().
+		Record(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, events ...*audit.Event) error {
+			s.Require().Len(events, 1)
+			s.Equal(auditentities.ActionRateSet, events[0].Action)
+			s.Contains(string(events[0].Before), `"annual_rate":"0.05"`)
+			s.Contains(string(events[0].After), `"annual_rate":"0.04"`)
+			return nil
+		}).
+		Times(1)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+
+	s.NoError(s.repo.SetRate(s.ctx, testProductID, rate))
+}
+
+func (s *RepositoryTestSuite) TestSetRateForNewDateRecordsNoBefore() {
+	rate := &interest.Rate{EffectiveFrom: date(2), AnnualRate: decimal.RequireFromString("0.04")}
+	s.expectTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), testProductID, date(2), rate.AnnualRate).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(nil).Times(1)
+	s.mockAudit.EXPECT().
+		Record(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, events ...*audit.Event) error {
+			s.Require().Len(events, 1)
+			s.Nil(events[0].Before)
+			return nil
+		}).
+		Times(1)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+
+	s.NoError(s.repo.SetRate(s.ctx, testProductID, rate))
+}
+
+func (s *RepositoryTestSuite) TestSetRateWhenAuditFailsDoesNotCommit() {
+	rate := &interest.Rate{EffectiveFrom: date(2), AnnualRate: decimal.RequireFromString("0.04")}
+	s.expectTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), testProductID, date(2), rate.AnnualRate).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(nil).Times(1)
+	s.mockAudit.EXPECT().Record(s.ctx, s.mockTx, gomock.Any()).Return(errRepoQueryFailed).Times(1)
+
+	s.Equal(errRepoQueryFailed, s.repo.SetRate(s.ctx, testProductID, rate))
+}
+
+// Test SetAccountProduct
+
+func (s *RepositoryTestSuite) TestSetAccountProductRecordsReplacedProduct() {
+	link := &interest.AccountProduct{AccountID: 20, ProductID: testProductID, EffectiveFrom: date(3)}
+	s.expectTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), int64(20), testProductID, date(3), gomock.Any()).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			previous := int64(3)
+			from := date(1)
+			*dest[0].(**int64) = &previous
+			*dest[1].(**time.Time) = &from
+			return nil
+		}).
+		Times(1)
+	s.mockAudit.EXPECT().
+		Record(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, events ...*audit.Event) error {
+			s.Require().Len(events, 1)
+			s.Equal(auditentities.ActionAccountProductSet, events[0].Action)
+			s.Equal("20", events[0].EntityID)
+			s.Contains(string(events[0].Before), `"product_id":3`)
+			return nil
+		}).
+		Times(1)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+
+	s.NoError(s.repo.SetAccountProduct(s.ctx, link))
+}
+
+// Test GetProduct
+
+func (s *RepositoryTestSuite) TestGetProductWhenMissingReturnsNotFound() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any(), testProductID).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows).Times(1)
+
+	product, err := s.repo.GetProduct(s.ctx, testProductID)
+	s.Nil(product)
+
+	var appErr *apperror.Error
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(apperror.CodeNotFound, appErr.Code())
+}
+
+func (s *RepositoryTestSuite) TestGetProductLoadsRateSchedule() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any(), testProductID).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = testProductID
+			*dest[1].(*string) = "Savings"
+			return nil
+		}).
+		Times(1)
+
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), []int64{testProductID}).Return(s.mockRows, nil).Times(1)
+	gomock.InOrder(
+		s.mockRows.EXPECT().Next().Return(true),
+		s.mockRows.EXPECT().
+			Scan(gomock.Any()).
+			DoAndReturn(func(dest ...any) error {
+				*dest[0].(*int64) = testProductID
+				*dest[1].(*time.Time) = date(1)
+				*dest[2].(*decimal.Decimal) = decimal.RequireFromString("0.05")
+				return nil
+			}),
+		s.mockRows.EXPECT().Next().Return(false),
+	)
+	s.mockRows.EXPECT().Err().Return(nil).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	product, err := s.repo.GetProduct(s.ctx, testProductID)
+	s.NoError(err)
+	s.Equal("Savings", product.Name)
+	s.Require().Len(product.Rates, 1)
+	s.Equal(date(1), product.Rates[0].EffectiveFrom)
+}
+
+// Test LastAccruedDate
+
+func (s *RepositoryTestSuite) TestLastAccruedDateWithoutRunsReturnsFalse() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any()).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows).Times(1)
+
+	_, ok, err := s.repo.LastAccruedDate(s.ctx)
+	s.NoError(err)
+	s.False(ok)
+}
+
+func (s *RepositoryTestSuite) TestLastAccruedDateWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any()).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(errRepoQueryFailed).Times(1)
+
+	_, ok, err := s.repo.LastAccruedDate(s.ctx)
+	s.Equal(errRepoQueryFailed, err)
+	s.False(ok)
+}
+
+// Test CreateAccruals
+
+func (s *RepositoryTestSuite) TestCreateAccrualsWithNoAccrualsSkipsInsert() {
+	inserted, err := s.repo.CreateAccruals(s.ctx, s.mockTx, nil)
+	s.NoError(err)
+	s.Equal(int64(0), inserted)
+}
+
+func (s *RepositoryTestSuite) TestCreateAccrualsPassesColumnArrays() {
+	accruals := []*interest.Accrual{{
+		AccountID:   testAccountID,
+		AccrualDate: date(9),
+		ProductID:   testProductID,
+		Balance:     decimal.NewFromInt(1000),
+		AnnualRate:  decimal.RequireFromString("0.05"),
+		DayCount:    entities.DayCountACT365,
+		Days:        1,
+		Amount:      decimal.RequireFromString("0.1369863"),
+		PeriodEnd:   date(31),
+	}}
+
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), date(9),
+			[]int64{testAccountID}, []int64{testProductID}, []string{"1000"}, []string{"0.05"},
+			[]string{entities.DayCountACT365}, []int32{1}, []string{"0.1369863"}, []time.Time{date(31)}, gomock.Any()).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
+		Times(1)
+
+	inserted, err := s.repo.CreateAccruals(s.ctx, s.mockTx, accruals)
+	s.NoError(err)
+	s.Equal(int64(1), inserted)
+}
+
+func (s *RepositoryTestSuite) TestCreateAccrualsWhenInsertFailsReturnsError() {
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgconn.CommandTag{}, errRepoQueryFailed).
+		Times(1)
+
+	_, err := s.repo.CreateAccruals(s.ctx, s.mockTx, []*interest.Accrual{{AccrualDate: date(9)}})
+	s.Equal(errRepoQueryFailed, err)
+}
+
+// Test ClaimAccruals
+
+func (s *RepositoryTestSuite) TestClaimAccrualsReturnsTotalAndCount() {
+	payoutID := uuid.New()
+
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), payoutID, testAccountID, date(31)).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*decimal.Decimal) = decimal.RequireFromString("4.24657534")
+			*dest[1].(*int) = 31
+			return nil
+		}).
+		Times(1)
+
+	total, count, err := s.repo.ClaimAccruals(s.ctx, s.mockTx, payoutID, testAccountID, date(31))
+	s.NoError(err)
+	s.Equal("4.24657534", total.String())
+	s.Equal(31, count)
+}
+
+// Test ListPayoutsDue
+
+func (s *RepositoryTestSuite) TestListPayoutsDueScansPeriods() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), date(31)).Return(s.mockRows, nil).Times(1)
+	gomock.InOrder(
+		s.mockRows.EXPECT().Next().Return(true),
+		s.mockRows.EXPECT().
+			Scan(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(dest ...any) error {
+				*dest[0].(*int64) = testAccountID
+				*dest[1].(*time.Time) = date(31)
+				return nil
+			}),
+		s.mockRows.EXPECT().Next().Return(false),
+	)
+	s.mockRows.EXPECT().Err().Return(nil).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	dues, err := s.repo.ListPayoutsDue(s.ctx, date(31))
+	s.NoError(err)
+	s.Equal([]*interest.PayoutDue{{AccountID: testAccountID, PeriodEnd: date(31)}}, dues)
+}
+
+func (s *RepositoryTestSuite) TestListPayoutsDueWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), date(31)).Return(nil, errRepoQueryFailed).Times(1)
+
+	dues, err := s.repo.ListPayoutsDue(s.ctx, date(31))
+	s.Nil(dues)
+	s.Equal(errRepoQueryFailed, err)
+}
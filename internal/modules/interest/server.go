@@ -0,0 +1,160 @@
+package interest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/interest/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+)
+
+// HTTPHandler handles HTTP requests for interest products
+type HTTPHandler struct {
+	core ICore
+}
+
+// NewHTTPHandler creates a new HTTPHandler
+func NewHTTPHandler(core ICore) *HTTPHandler {
+	return &HTTPHandler{core: core}
+}
+
+// RegisterAdminRoutes registers the interest product routes.
+func (h *HTTPHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Post(entities.RouteProducts, h.CreateProduct)
+	r.Get(entities.RouteProductByID, h.GetProduct)
+	r.Post(entities.RouteProductRates, h.SetRate)
+	r.Put(entities.RouteAccountInterest, h.SetAccountProduct)
+}
+
+// CreateProduct handles POST /interest-products
+func (h *HTTPHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	var req entities.CreateProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	response, appErr := h.core.CreateProduct(r.Context(), &req)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, response)
+}
+
+// GetProduct handles GET /interest-products/{productID}
+func (h *HTTPHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
+	productID, appErr := productIDParam(r)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	response, appErr := h.core.GetProduct(r.Context(), productID)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// SetRate handles POST /interest-products/{productID}/rates.
+// A rate with the same effective_from as an existing one replaces it.
+func (h *HTTPHandler) SetRate(w http.ResponseWriter, r *http.Request) {
+	productID, appErr := productIDParam(r)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	var req entities.RateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	response, appErr := h.core.SetRate(r.Context(), productID, &req)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// SetAccountProduct handles PUT /accounts/{accountID}/interest-product
+func (h *HTTPHandler) SetAccountProduct(w http.ResponseWriter, r *http.Request) {
+	accountIDStr := chi.URLParam(r, entities.ParamAccountID)
+	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountIDStr))
+		return
+	}
+
+	var req entities.SetAccountProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	response, appErr := h.core.SetAccountProduct(r.Context(), accountID, &req)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// productIDParam parses the productID path parameter
+func productIDParam(r *http.Request) (int64, apperror.IError) {
+	productIDStr := chi.URLParam(r, entities.ParamProductID)
+	productID, err := strconv.ParseInt(productIDStr, 10, 64)
+	if err != nil {
+		return 0, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidProductID, apperror.MsgInvalidProductID).
+			WithField(apperror.FieldProductID, productIDStr)
+	}
+	return productID, nil
+}
+
+// writeJSON writes a JSON response
+func (h *HTTPHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(status)
+	if data != nil {
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			logger.Error(constants.LogMsgFailedToEncodeResponse, constants.LogKeyError, err)
+		}
+	}
+}
+
+// writeErrorWithContext writes an error response with request ID for tracing
+func (h *HTTPHandler) writeErrorWithContext(w http.ResponseWriter, r *http.Request, err apperror.IError) {
+	requestID := ""
+	if id, ok := r.Context().Value(contextkeys.RequestID).(string); ok {
+		requestID = id
+	}
+
+	response := apperror.ErrorResponse{
+		Error:     err.PublicMessage(),
+		Code:      err.Code().String(),
+		RequestID: requestID,
+		Details:   err.Fields(),
+	}
+
+	// Log error for debugging
+	logger.Ctx(r.Context()).Errorw(constants.LogMsgRequestFailed,
+		constants.LogKeyError, err.Error(),
+		constants.LogKeyStatusCode, err.HTTPStatus(),
+	)
+
+	h.writeJSON(w, err.HTTPStatus(), response)
+}
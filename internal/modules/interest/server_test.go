@@ -0,0 +1,184 @@
+package interest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/modules/interest"
+	"github.com/internal-transfers-service/internal/modules/interest/entities"
+	"github.com/internal-transfers-service/internal/modules/interest/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for interest HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	handler  *interest.HTTPHandler
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.handler = interest.NewHTTPHandler(s.mockCore)
+	s.router = chi.NewRouter()
+	s.handler.RegisterAdminRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestCreateProductReturnsCreated() {
+	expectedRequest := &entities.CreateProductRequest{
+		Name:     "Savings",
+		DayCount: entities.DayCountACT365,
+		Rates:    []entities.RateRequest{{EffectiveFrom: "2026-01-01", AnnualRate: "0.05"}},
+	}
+
+	s.mockCore.EXPECT().
+		CreateProduct(gomock.Any(), expectedRequest).
+		Return(&entities.ProductResponse{ProductID: testProductID, Name: "Savings"}, nil).
+		Times(1)
+
+	body := `{"name":"Savings","day_count":"ACT/365","rates":[{"effective_from":"2026-01-01","annual_rate":"0.05"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/interest-products", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+
+	var response entities.ProductResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal(testProductID, response.ProductID)
+}
+
+func (s *ServerTestSuite) TestCreateProductWithInvalidJSONReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodPost, "/interest-products", strings.NewReader("{"))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestGetProductWhenMissingReturnsNotFound() {
+	s.mockCore.EXPECT().
+		GetProduct(gomock.Any(), int64(999)).
+		Return(nil, apperror.NewWithMessage(apperror.CodeNotFound, interest.ErrProductNotFound, apperror.MsgProductNotFound)).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/interest-products/999", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusNotFound, rec.Code)
+
+	var response apperror.ErrorResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal(apperror.MsgProductNotFound, response.Error)
+}
+
+func (s *ServerTestSuite) TestGetProductWithInvalidIDReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodGet, "/interest-products/abc", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestSetRatePassesRateToCore() {
+	s.mockCore.EXPECT().
+		SetRate(gomock.Any(), testProductID, &entities.RateRequest{EffectiveFrom: "2026-04-01", AnnualRate: "0.045"}).
+		Return(&entities.ProductResponse{ProductID: testProductID}, nil).
+		Times(1)
+
+	body := `{"effective_from":"2026-04-01","annual_rate":"0.045"}`
+	req := httptest.NewRequest(http.MethodPost, "/interest-products/7/rates", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *ServerTestSuite) TestSetAccountProductPassesLinkToCore() {
+	s.mockCore.EXPECT().
+		SetAccountProduct(gomock.Any(), testAccountID, &entities.SetAccountProductRequest{ProductID: testProductID, EffectiveFrom: "2026-03-01"}).
+		Return(&entities.AccountProductResponse{AccountID: testAccountID, ProductID: testProductID, EffectiveFrom: "2026-03-01"}, nil).
+		Times(1)
+
+	body := `{"product_id":7,"effective_from":"2026-03-01"}`
+	req := httptest.NewRequest(http.MethodPut, "/accounts/200/interest-product", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *ServerTestSuite) TestSetAccountProductWithInvalidAccountIDReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodPut, "/accounts/abc/interest-product", strings.NewReader("{}"))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+// InitTestSuite contains tests for interest module initialization
+type InitTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestInitSuite(t *testing.T) {
+	suite.Run(t, new(InitTestSuite))
+}
+
+func (s *InitTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+// TestWorkerRunsImmediatelyAndStops verifies the worker runs on start and stops cleanly
+func (s *InitTestSuite) TestWorkerRunsImmediatelyAndStops() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	mockCore := mock.NewMockICore(ctrl)
+	ran := make(chan struct{})
+	mockCore.EXPECT().
+		RunDue(gomock.Any()).
+		DoAndReturn(func(_ context.Context) (int, error) {
+			close(ran)
+			return 0, nil
+		}).
+		Times(1)
+
+	module := &interest.Module{Core: mockCore}
+	module.StartWorker(s.ctx, time.Hour)
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		s.Fail("interest worker did not run")
+	}
+	module.StopWorker()
+}
@@ -0,0 +1,448 @@
+package journal
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/internal-transfers-service/internal/boot/app_context"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	accountentities "github.com/internal-transfers-service/internal/modules/account/entities"
+	"github.com/internal-transfers-service/internal/modules/journal/entities"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/jackc/pgx/v5"
+)
+
+// Domain errors
+var (
+	ErrInvalidDate       = errors.New(entities.ErrMsgInvalidDate)
+	ErrInvalidRange      = errors.New(entities.ErrMsgInvalidRange)
+	ErrDayNotClosed      = errors.New(entities.ErrMsgDayNotClosed)
+	ErrFromRequired      = errors.New(entities.ErrMsgFromRequired)
+	ErrOutOfOrder        = errors.New(entities.ErrMsgOutOfOrder)
+	ErrUnmapped          = errors.New(entities.ErrMsgUnmapped)
+	ErrArchived          = errors.New(entities.ErrMsgArchived)
+	ErrChanged           = errors.New(entities.ErrMsgChanged)
+	ErrInvalidExportID   = errors.New(entities.ErrMsgInvalidExportID)
+	ErrExportNotFound    = errors.New(entities.ErrMsgExportNotFound)
+	ErrInvalidGLCategory = errors.New(entities.ErrMsgInvalidGLCategory)
+	ErrInvalidCurrency   = errors.New(entities.ErrMsgInvalidCurrency)
+	ErrInvalidGLCode     = errors.New(entities.ErrMsgInvalidGLCode)
+)
+
+// ICore defines the interface for journal export business logic. Postings belong to the
+// business date of the snapshot schedule they were created on; every closed business date
+// is exported exactly once, in order, and an export can be downloaded again as long as its
+// postings are unchanged.
+type ICore interface {
+	Export(ctx context.Context, from, to string, w io.Writer) (*entities.ExportResponse, apperror.IError)
+	Download(ctx context.Context, exportID int64, w io.Writer) (*entities.ExportResponse, apperror.IError)
+	ListExports(ctx context.Context) (*entities.ExportsResponse, apperror.IError)
+	ListMappings(ctx context.Context) (*entities.MappingsResponse, apperror.IError)
+	SetMapping(ctx context.Context, category, currency string, req *entities.SetMappingRequest) (*entities.MappingResponse, apperror.IError)
+}
+
+// Named is implemented by outputs that are named after the business dates they hold, such
+// as HTTP downloads. SetDates is called before anything is written.
+type Named interface {
+	SetDates(from, to string)
+}
+
+// Core implements ICore
+type Core struct {
+	repo       IRepository
+	schedule   *snapshot.Schedule
+	clock      clock.Clock
+	layout     *Layout
+	currencies []string
+}
+
+// Compile-time interface check
+var _ ICore = (*Core)(nil)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance. Exports are written in layout; mappings can be set
+// for the supported currencies only.
+func NewCore(_ context.Context, repo IRepository, schedule *snapshot.Schedule, clk clock.Clock, layout *Layout, currencies []string) ICore {
+	coreInstance = &Core{
+		repo:       repo,
+		schedule:   schedule,
+		clock:      clk,
+		layout:     layout,
+		currencies: currencies,
+	}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// Export writes the journal of the business dates from to to, two lines per posting, and
+// records the export. to defaults to the last closed business date and from to the day
+// after the last export; an explicit from must be that day, so no date is skipped or
+// exported twice. Returns nil without writing anything when from is omitted and no date
+// has closed since the last export.
+func (c *Core) Export(ctx context.Context, from, to string, w io.Writer) (*entities.ExportResponse, apperror.IError) {
+	latest := c.schedule.LatestDue(c.clock.Now())
+	last := latest
+	if to != "" {
+		var appErr apperror.IError
+		if last, appErr = parseDate(to, apperror.FieldTo); appErr != nil {
+			return nil, appErr
+		}
+		if last.After(latest) {
+			return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrDayNotClosed, apperror.MsgJournalDayNotClosed).
+				WithField(apperror.FieldTo, to)
+		}
+	}
+
+	var first time.Time
+	if from != "" {
+		var appErr apperror.IError
+		if first, appErr = parseDate(from, apperror.FieldFrom); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	tx, err := c.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err)
+	}
+	// Rollback is a no-op once the transaction has committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := c.repo.Lock(ctx, tx); err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err)
+	}
+	prev, err := c.repo.LastExport(ctx, tx)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err)
+	}
+
+	switch {
+	case prev == nil && from == "":
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrFromRequired, apperror.MsgJournalFromRequired)
+	case prev != nil:
+		next := prev.LastDate.AddDate(0, 0, 1)
+		if from == "" {
+			first = next
+		} else if !first.Equal(next) {
+			return nil, apperror.NewWithMessage(apperror.CodeConflict, ErrOutOfOrder, apperror.MsgJournalOutOfOrder).
+				WithField(apperror.FieldFrom, from).
+				WithField(apperror.FieldNextDate, next.Format(entities.DateLayout))
+		}
+	}
+
+	if first.After(last) {
+		if from == "" {
+			return nil, nil
+		}
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidRange, apperror.MsgInvalidJournalRange).
+			WithField(apperror.FieldFrom, from).
+			WithField(apperror.FieldTo, last.Format(entities.DateLayout))
+	}
+
+	export := &Export{FirstDate: first, LastDate: last}
+	if appErr := c.checkExportable(ctx, tx, export); appErr != nil {
+		return nil, appErr
+	}
+	if err := c.write(ctx, tx, export, w); err != nil {
+		return nil, toAppError(err, export)
+	}
+
+	export.ExportedBy = app_context.GetActor(ctx)
+	if export.ExportedBy == "" {
+		export.ExportedBy = constants.ActorSystem
+	}
+	if err := c.repo.InsertExport(ctx, tx, export); err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgJournalExported,
+		constants.LogFieldExportID, export.ID,
+		constants.LogFieldFromDate, first.Format(entities.DateLayout),
+		constants.LogFieldToDate, last.Format(entities.DateLayout),
+		constants.LogFieldRowCount, export.EntryCount,
+		constants.LogFieldActor, export.ExportedBy,
+	)
+	return toExportResponse(export), nil
+}
+
+// Download writes a recorded export again, in the current layout. The postings are read
+// twice: first to check they still hash to the export's checksum, then to write them, so
+// nothing is written when they have changed.
+func (c *Core) Download(ctx context.Context, exportID int64, w io.Writer) (*entities.ExportResponse, apperror.IError) {
+	export, err := c.repo.GetExport(ctx, exportID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).WithField(apperror.FieldExportID, exportID)
+	}
+	if export == nil {
+		return nil, apperror.NewWithMessage(apperror.CodeNotFound, ErrExportNotFound, apperror.MsgJournalExportNotFound).
+			WithField(apperror.FieldExportID, exportID)
+	}
+
+	tx, err := c.repo.BeginReadTx(ctx)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err)
+	}
+	// A read-only transaction has nothing to commit
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	replay := &Export{FirstDate: export.FirstDate, LastDate: export.LastDate}
+	if appErr := c.checkExportable(ctx, tx, replay); appErr != nil {
+		return nil, appErr
+	}
+	if err := c.write(ctx, tx, replay, io.Discard); err != nil {
+		return nil, toAppError(err, replay)
+	}
+	if replay.Checksum != export.Checksum || replay.EntryCount != export.EntryCount {
+		logger.Ctx(ctx).Errorw(constants.LogMsgJournalChanged,
+			constants.LogFieldExportID, export.ID,
+			constants.LogFieldFromDate, export.FirstDate.Format(entities.DateLayout),
+			constants.LogFieldToDate, export.LastDate.Format(entities.DateLayout),
+		)
+		return nil, apperror.NewWithMessage(apperror.CodeConflict, ErrChanged, apperror.MsgJournalChanged).
+			WithField(apperror.FieldExportID, exportID)
+	}
+	if err := c.write(ctx, tx, replay, w); err != nil {
+		return nil, toAppError(err, replay)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgJournalDownloaded,
+		constants.LogFieldExportID, export.ID,
+		constants.LogFieldActor, app_context.GetActor(ctx),
+	)
+	return toExportResponse(export), nil
+}
+
+// ListExports returns every export, newest first, and the watermark
+func (c *Core) ListExports(ctx context.Context) (*entities.ExportsResponse, apperror.IError) {
+	exports, err := c.repo.ListExports(ctx)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err)
+	}
+
+	response := &entities.ExportsResponse{Exports: make([]*entities.ExportResponse, 0, len(exports))}
+	if len(exports) > 0 {
+		watermark := exports[0].LastDate.Format(entities.DateLayout)
+		response.Watermark = &watermark
+	}
+	for _, e := range exports {
+		response.Exports = append(response.Exports, toExportResponse(e))
+	}
+	return response, nil
+}
+
+// ListMappings returns every GL account mapping
+func (c *Core) ListMappings(ctx context.Context) (*entities.MappingsResponse, apperror.IError) {
+	mappings, err := c.repo.ListMappings(ctx)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err)
+	}
+
+	response := &entities.MappingsResponse{Mappings: make([]*entities.MappingResponse, 0, len(mappings))}
+	for _, m := range mappings {
+		response.Mappings = append(response.Mappings, toMappingResponse(m))
+	}
+	return response, nil
+}
+
+// SetMapping maps the legs of a general-ledger category and currency to a GL account code.
+// The new code applies to every export made from then on.
+func (c *Core) SetMapping(ctx context.Context, category, currency string, req *entities.SetMappingRequest) (*entities.MappingResponse, apperror.IError) {
+	if !slices.Contains(accountentities.GLCategories, category) {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidGLCategory, apperror.MsgInvalidGLCategory).
+			WithField(apperror.FieldGLCategory, category)
+	}
+
+	currency = strings.ToUpper(currency)
+	if !slices.Contains(c.currencies, currency) {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidCurrency, apperror.MsgInvalidCurrency).
+			WithField(apperror.FieldCurrency, currency)
+	}
+
+	code := strings.TrimSpace(req.GLCode)
+	if code == "" || len(code) > entities.MaxGLCodeLength {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidGLCode, apperror.MsgInvalidGLCode).
+			WithField(apperror.FieldGLCode, req.GLCode)
+	}
+
+	mapping := &Mapping{Category: category, Currency: currency, GLCode: code}
+	if err := c.repo.SetMapping(ctx, mapping); err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldGLCategory, category).
+			WithField(apperror.FieldCurrency, currency)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgGLMappingSet,
+		constants.LogFieldGLCategory, category,
+		constants.LogFieldCurrency, currency,
+		constants.LogFieldGLCode, code,
+	)
+	return toMappingResponse(mapping), nil
+}
+
+// checkExportable fails when some postings of the export's business dates have been
+// archived, or have legs without a GL account mapping
+func (c *Core) checkExportable(ctx context.Context, tx pgx.Tx, export *Export) apperror.IError {
+	start, end := c.window(export)
+
+	archived, err := c.repo.HasArchivedAfter(ctx, tx, start)
+	if err != nil {
+		return apperror.New(apperror.CodeInternalError, err)
+	}
+	if archived {
+		return apperror.NewWithMessage(apperror.CodeConflict, ErrArchived, apperror.MsgJournalArchived).
+			WithField(apperror.FieldFrom, export.FirstDate.Format(entities.DateLayout))
+	}
+
+	unmapped, err := c.repo.ListUnmapped(ctx, tx, start, end)
+	if err != nil {
+		return apperror.New(apperror.CodeInternalError, err)
+	}
+	if len(unmapped) > 0 {
+		keys := make([]string, 0, len(unmapped))
+		for _, u := range unmapped {
+			keys = append(keys, fmt.Sprintf(entities.MappingKeyFormat, u.Category, u.Currency))
+		}
+		logger.Ctx(ctx).Warnw(constants.LogMsgJournalUnmapped,
+			constants.LogFieldUnmapped, keys,
+		)
+		return apperror.NewWithMessage(apperror.CodeConflict, ErrUnmapped, apperror.MsgJournalUnmapped).
+			WithField(apperror.FieldUnmapped, keys)
+	}
+	return nil
+}
+
+// write streams the export's postings to w as journal lines and sets its entry count and
+// checksum
+func (c *Core) write(ctx context.Context, tx pgx.Tx, export *Export, w io.Writer) error {
+	if named, ok := w.(Named); ok {
+		named.SetDates(export.FirstDate.Format(entities.DateLayout), export.LastDate.Format(entities.DateLayout))
+	}
+
+	jw, err := newJournalWriter(c.layout, w)
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	start, end := c.window(export)
+	err = c.repo.StreamPostings(ctx, tx, start, end, func(p *Posting) error {
+		count++
+		return writePosting(jw, p)
+	})
+	if err != nil {
+		return err
+	}
+
+	sum, err := jw.Close()
+	if err != nil {
+		return err
+	}
+	export.EntryCount = count
+	export.Checksum = sum
+	return nil
+}
+
+// window returns the instants bounding the export's business dates: postings created
+// after the cutoff of the day before the first date and up to the cutoff of the last
+func (c *Core) window(export *Export) (time.Time, time.Time) {
+	return c.schedule.CutoffOf(export.FirstDate.AddDate(0, 0, -1)), c.schedule.CutoffOf(export.LastDate)
+}
+
+// writePosting writes the debit line of a posting, then its credit line
+func writePosting(jw *journalWriter, p *Posting) error {
+	if p.DebitGLCode == "" || p.CreditGLCode == "" {
+		// The mapping check ran in an earlier statement; never drop a leg silently
+		return fmt.Errorf("%w: transaction %s", ErrUnmapped, p.ID)
+	}
+
+	memo := fmt.Sprintf(entities.MemoOpeningFormat, p.Type, p.DestinationAccountID)
+	if p.SourceAccountID != nil {
+		memo = fmt.Sprintf(entities.MemoTransferFormat, p.Type, *p.SourceAccountID, p.DestinationAccountID)
+	}
+
+	debit := &Line{
+		Date:          p.EffectiveDate,
+		GLCode:        p.DebitGLCode,
+		Debit:         p.Amount,
+		Memo:          memo,
+		TransactionID: p.ID,
+		Currency:      p.Currency,
+		AccountID:     p.SourceAccountID,
+	}
+	if err := jw.Write(debit); err != nil {
+		return err
+	}
+
+	destination := p.DestinationAccountID
+	return jw.Write(&Line{
+		Date:          p.EffectiveDate,
+		GLCode:        p.CreditGLCode,
+		Credit:        p.Amount,
+		Memo:          memo,
+		TransactionID: p.ID,
+		Currency:      p.Currency,
+		AccountID:     &destination,
+	})
+}
+
+// parseDate parses a YYYY-MM-DD business date
+func parseDate(value, field string) (time.Time, apperror.IError) {
+	date, err := time.Parse(entities.DateLayout, value)
+	if err != nil {
+		return time.Time{}, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidDate, apperror.MsgInvalidDate).
+			WithField(field, value)
+	}
+	return date, nil
+}
+
+// toAppError converts a failure while writing an export: a leg that lost its mapping is a
+// conflict, anything else is internal
+func toAppError(err error, export *Export) apperror.IError {
+	if errors.Is(err, ErrUnmapped) {
+		return apperror.NewWithMessage(apperror.CodeConflict, err, apperror.MsgJournalUnmapped).
+			WithField(apperror.FieldFrom, export.FirstDate.Format(entities.DateLayout))
+	}
+	return apperror.New(apperror.CodeInternalError, err)
+}
+
+// toExportResponse converts an export to its wire form
+func toExportResponse(e *Export) *entities.ExportResponse {
+	return &entities.ExportResponse{
+		ExportID:   e.ID,
+		From:       e.FirstDate.Format(entities.DateLayout),
+		To:         e.LastDate.Format(entities.DateLayout),
+		EntryCount: e.EntryCount,
+		Checksum:   e.Checksum,
+		ExportedBy: e.ExportedBy,
+		ExportedAt: e.ExportedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// toMappingResponse converts a mapping to its wire form
+func toMappingResponse(m *Mapping) *entities.MappingResponse {
+	return &entities.MappingResponse{
+		Category:  m.Category,
+		Currency:  m.Currency,
+		GLCode:    m.GLCode,
+		UpdatedAt: m.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
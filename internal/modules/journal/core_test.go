@@ -0,0 +1,421 @@
+package journal_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/boot/app_context"
+	"github.com/internal-transfers-service/internal/modules/journal"
+	"github.com/internal-transfers-service/internal/modules/journal/entities"
+	"github.com/internal-transfers-service/internal/modules/journal/mock"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/clock"
+	dbMock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// CoreTestSuite contains tests for journal Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockRepo *mock.MockIRepository
+	mockTx   *dbMock.MockTx
+	clock    *clock.Fake
+	layout   *journal.Layout
+	core     journal.ICore
+	ctx      context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.mockTx = dbMock.NewMockTx(s.ctrl)
+	// Business dates close at midnight UTC, so 2026-03-14 is the last closed date
+	s.clock = clock.NewFake(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC))
+	s.ctx = context.Background()
+
+	layout, err := journal.NewLayout([]string{"date", "gl_account", "debit", "credit", "memo", "transaction_id"}, true, ",", "2006-01-02")
+	s.Require().NoError(err)
+	s.layout = layout
+	s.core = s.newCore(layout)
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// newCore returns a core writing in layout
+func (s *CoreTestSuite) newCore(layout *journal.Layout) journal.ICore {
+	schedule, err := snapshot.NewSchedule("24:00", "UTC", 0)
+	s.Require().NoError(err)
+	return journal.NewCore(s.ctx, s.mockRepo, schedule, s.clock, layout, []string{"USD", "EUR"})
+}
+
+// date returns midnight UTC of a day in 2026
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+var (
+	txTransfer = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	txOpening  = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+)
+
+// postings returns a transfer from account 1 to 2 and an opening entry of account 3
+func postings() []*journal.Posting {
+	source := int64(1)
+	return []*journal.Posting{
+		{
+			ID: txTransfer, Type: "transfer", Amount: decimal.RequireFromString("12.50"), EffectiveDate: date(time.March, 14),
+			SourceAccountID: &source, DestinationAccountID: 2, Currency: "USD", DebitGLCode: "2000", CreditGLCode: "2000",
+		},
+		{
+			ID: txOpening, Type: "opening", Amount: decimal.RequireFromString("100"), EffectiveDate: date(time.March, 14),
+			DestinationAccountID: 3, Currency: "USD", DebitGLCode: "3000", CreditGLCode: "1000",
+		},
+	}
+}
+
+// expectStream expects the postings of the window from start to end to be read once
+func (s *CoreTestSuite) expectStream(start, end time.Time, ps []*journal.Posting) {
+	s.mockRepo.EXPECT().
+		StreamPostings(s.ctx, s.mockTx, start, end, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, _, _ time.Time, fn func(*journal.Posting) error) error {
+			for _, p := range ps {
+				if err := fn(p); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+// expectLockedExport expects the export transaction to be opened and locked, returning prev as the last export
+func (s *CoreTestSuite) expectLockedExport(prev *journal.Export) {
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().Lock(s.ctx, s.mockTx).Return(nil)
+	s.mockRepo.EXPECT().LastExport(s.ctx, s.mockTx).Return(prev, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+}
+
+// Test Export
+
+func (s *CoreTestSuite) TestExportWritesDebitAndCreditLinesAndRecordsExport() {
+	ctx := app_context.SetActor(s.ctx, "finance")
+	start, end := date(time.March, 14), date(time.March, 15)
+	s.mockRepo.EXPECT().BeginTx(ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().Lock(ctx, s.mockTx).Return(nil)
+	s.mockRepo.EXPECT().LastExport(ctx, s.mockTx).Return(&journal.Export{LastDate: date(time.March, 13)}, nil)
+	s.mockRepo.EXPECT().HasArchivedAfter(ctx, s.mockTx, start).Return(false, nil)
+	s.mockRepo.EXPECT().ListUnmapped(ctx, s.mockTx, start, end).Return(nil, nil)
+	s.mockRepo.EXPECT().
+		StreamPostings(ctx, s.mockTx, start, end, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, _, _ time.Time, fn func(*journal.Posting) error) error {
+			for _, p := range postings() {
+				if err := fn(p); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	s.mockRepo.EXPECT().
+		InsertExport(ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, e *journal.Export) error {
+			s.Equal(date(time.March, 14), e.FirstDate)
+			s.Equal(date(time.March, 14), e.LastDate)
+			s.Equal(int64(2), e.EntryCount)
+			s.Len(e.Checksum, 64)
+			s.Equal("finance", e.ExportedBy)
+			e.ID = 7
+			return nil
+		})
+	s.mockTx.EXPECT().Commit(ctx).Return(nil)
+	s.mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	var out bytes.Buffer
+	response, err := s.core.Export(ctx, "", "", &out)
+	s.Nil(err)
+	s.Require().NotNil(response)
+	s.Equal(int64(7), response.ExportID)
+	s.Equal("2026-03-14", response.From)
+	s.Equal(
+		"date,gl_account,debit,credit,memo,transaction_id\n"+
+			"2026-03-14,2000,12.5,,transfer from account 1 to account 2,"+txTransfer.String()+"\n"+
+			"2026-03-14,2000,,12.5,transfer from account 1 to account 2,"+txTransfer.String()+"\n"+
+			"2026-03-14,3000,100,,opening to account 3,"+txOpening.String()+"\n"+
+			"2026-03-14,1000,,100,opening to account 3,"+txOpening.String()+"\n",
+		out.String())
+}
+
+func (s *CoreTestSuite) TestExportChecksumDoesNotDependOnLayout() {
+	other, err := journal.NewLayout([]string{"transaction_id", "debit", "credit"}, false, ";", "02.01.2006")
+	s.Require().NoError(err)
+
+	checksums := make([]string, 0, 2)
+	for _, core := range []journal.ICore{s.core, s.newCore(other)} {
+		s.expectLockedExport(nil)
+		s.mockRepo.EXPECT().HasArchivedAfter(s.ctx, s.mockTx, gomock.Any()).Return(false, nil)
+		s.mockRepo.EXPECT().ListUnmapped(s.ctx, s.mockTx, gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.expectStream(date(time.March, 14), date(time.March, 15), postings())
+		s.mockRepo.EXPECT().InsertExport(s.ctx, s.mockTx, gomock.Any()).Return(nil)
+		s.mockTx.EXPECT().Commit(s.ctx).Return(nil)
+
+		response, appErr := core.Export(s.ctx, "2026-03-14", "2026-03-14", io.Discard)
+		s.Require().Nil(appErr)
+		checksums = append(checksums, response.Checksum)
+	}
+	s.Equal(checksums[0], checksums[1])
+}
+
+func (s *CoreTestSuite) TestExportFirstWithoutFromReturnsBadRequest() {
+	s.expectLockedExport(nil)
+
+	response, err := s.core.Export(s.ctx, "", "", io.Discard)
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgJournalFromRequired, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestExportWithGapReturnsConflictWithNextDate() {
+	s.expectLockedExport(&journal.Export{LastDate: date(time.March, 10)})
+
+	response, err := s.core.Export(s.ctx, "2026-03-13", "", io.Discard)
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeConflict, err.Code())
+	s.Equal("2026-03-11", err.Fields()[apperror.FieldNextDate])
+}
+
+func (s *CoreTestSuite) TestExportAgainReturnsConflict() {
+	s.expectLockedExport(&journal.Export{LastDate: date(time.March, 14)})
+
+	_, err := s.core.Export(s.ctx, "2026-03-14", "2026-03-14", io.Discard)
+	s.Require().NotNil(err)
+	s.Equal(apperror.MsgJournalOutOfOrder, err.PublicMessage())
+	s.Equal("2026-03-15", err.Fields()[apperror.FieldNextDate])
+}
+
+func (s *CoreTestSuite) TestExportWhenUpToDateReturnsNil() {
+	s.expectLockedExport(&journal.Export{LastDate: date(time.March, 14)})
+
+	var out bytes.Buffer
+	response, err := s.core.Export(s.ctx, "", "", &out)
+	s.Nil(err)
+	s.Nil(response)
+	s.Zero(out.Len())
+}
+
+func (s *CoreTestSuite) TestExportOfOpenDateReturnsBadRequest() {
+	response, err := s.core.Export(s.ctx, "", "2026-03-15", io.Discard)
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.MsgJournalDayNotClosed, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestExportWithInvalidDateReturnsBadRequest() {
+	_, err := s.core.Export(s.ctx, "14/03/2026", "", io.Discard)
+	s.Require().NotNil(err)
+	s.Equal(apperror.MsgInvalidDate, err.PublicMessage())
+	s.Equal("14/03/2026", err.Fields()[apperror.FieldFrom])
+}
+
+func (s *CoreTestSuite) TestExportWithUnmappedLegsWritesNothing() {
+	s.expectLockedExport(nil)
+	s.mockRepo.EXPECT().HasArchivedAfter(s.ctx, s.mockTx, gomock.Any()).Return(false, nil)
+	s.mockRepo.EXPECT().ListUnmapped(s.ctx, s.mockTx, date(time.March, 12), date(time.March, 15)).
+		Return([]*journal.Unmapped{{Category: "asset", Currency: "EUR"}, {Category: "equity", Currency: "USD"}}, nil)
+	s.mockRepo.EXPECT().StreamPostings(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	var out bytes.Buffer
+	_, err := s.core.Export(s.ctx, "2026-03-12", "", &out)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeConflict, err.Code())
+	s.Equal([]string{"asset/EUR", "equity/USD"}, err.Fields()[apperror.FieldUnmapped])
+	s.Zero(out.Len())
+}
+
+func (s *CoreTestSuite) TestExportOfArchivedDatesReturnsConflict() {
+	s.expectLockedExport(nil)
+	s.mockRepo.EXPECT().HasArchivedAfter(s.ctx, s.mockTx, date(time.January, 2)).Return(true, nil)
+
+	_, err := s.core.Export(s.ctx, "2026-01-02", "", io.Discard)
+	s.Require().NotNil(err)
+	s.Equal(apperror.MsgJournalArchived, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestExportWhenLegLosesMappingDoesNotRecordExport() {
+	ps := postings()
+	ps[1].DebitGLCode = ""
+	s.expectLockedExport(nil)
+	s.mockRepo.EXPECT().HasArchivedAfter(s.ctx, s.mockTx, gomock.Any()).Return(false, nil)
+	s.mockRepo.EXPECT().ListUnmapped(s.ctx, s.mockTx, gomock.Any(), gomock.Any()).Return(nil, nil)
+	s.expectStream(date(time.March, 14), date(time.March, 15), ps)
+	s.mockRepo.EXPECT().InsertExport(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := s.core.Export(s.ctx, "2026-03-14", "", io.Discard)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeConflict, err.Code())
+	s.Equal(apperror.MsgJournalUnmapped, err.PublicMessage())
+}
+
+// Test Download
+
+func (s *CoreTestSuite) TestDownloadWritesExportAgain() {
+	var original bytes.Buffer
+	s.expectLockedExport(nil)
+	s.mockRepo.EXPECT().HasArchivedAfter(s.ctx, s.mockTx, gomock.Any()).Return(false, nil)
+	s.mockRepo.EXPECT().ListUnmapped(s.ctx, s.mockTx, gomock.Any(), gomock.Any()).Return(nil, nil)
+	s.expectStream(date(time.March, 14), date(time.March, 15), postings())
+	var recorded *journal.Export
+	s.mockRepo.EXPECT().InsertExport(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, e *journal.Export) error {
+			e.ID = 3
+			recorded = e
+			return nil
+		})
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil)
+	_, err := s.core.Export(s.ctx, "2026-03-14", "", &original)
+	s.Require().Nil(err)
+
+	s.mockRepo.EXPECT().GetExport(s.ctx, int64(3)).Return(recorded, nil)
+	s.mockRepo.EXPECT().BeginReadTx(s.ctx).Return(s.mockTx, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+	s.mockRepo.EXPECT().HasArchivedAfter(s.ctx, s.mockTx, gomock.Any()).Return(false, nil)
+	s.mockRepo.EXPECT().ListUnmapped(s.ctx, s.mockTx, gomock.Any(), gomock.Any()).Return(nil, nil)
+	s.expectStream(date(time.March, 14), date(time.March, 15), postings())
+	s.expectStream(date(time.March, 14), date(time.March, 15), postings())
+
+	var again bytes.Buffer
+	response, err := s.core.Download(s.ctx, 3, &again)
+	s.Nil(err)
+	s.Equal(int64(3), response.ExportID)
+	s.Equal(original.String(), again.String())
+}
+
+func (s *CoreTestSuite) TestDownloadWhenPostingsChangedWritesNothing() {
+	s.mockRepo.EXPECT().GetExport(s.ctx, int64(3)).Return(&journal.Export{
+		ID: 3, FirstDate: date(time.March, 14), LastDate: date(time.March, 14), EntryCount: 2, Checksum: "stale",
+	}, nil)
+	s.mockRepo.EXPECT().BeginReadTx(s.ctx).Return(s.mockTx, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+	s.mockRepo.EXPECT().HasArchivedAfter(s.ctx, s.mockTx, gomock.Any()).Return(false, nil)
+	s.mockRepo.EXPECT().ListUnmapped(s.ctx, s.mockTx, gomock.Any(), gomock.Any()).Return(nil, nil)
+	s.expectStream(date(time.March, 14), date(time.March, 15), postings())
+
+	var out bytes.Buffer
+	response, err := s.core.Download(s.ctx, 3, &out)
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeConflict, err.Code())
+	s.Equal(apperror.MsgJournalChanged, err.PublicMessage())
+	s.Zero(out.Len())
+}
+
+func (s *CoreTestSuite) TestDownloadWhenMissingReturnsNotFound() {
+	s.mockRepo.EXPECT().GetExport(s.ctx, int64(9)).Return(nil, nil)
+
+	_, err := s.core.Download(s.ctx, 9, io.Discard)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeNotFound, err.Code())
+}
+
+// Test ListExports
+
+func (s *CoreTestSuite) TestListExportsReturnsWatermarkOfNewest() {
+	s.mockRepo.EXPECT().ListExports(s.ctx).Return([]*journal.Export{
+		{ID: 2, FirstDate: date(time.March, 11), LastDate: date(time.March, 14)},
+		{ID: 1, FirstDate: date(time.March, 1), LastDate: date(time.March, 10)},
+	}, nil)
+
+	response, err := s.core.ListExports(s.ctx)
+	s.Nil(err)
+	s.Require().NotNil(response.Watermark)
+	s.Equal("2026-03-14", *response.Watermark)
+	s.Len(response.Exports, 2)
+}
+
+func (s *CoreTestSuite) TestListExportsBeforeFirstHasNoWatermark() {
+	s.mockRepo.EXPECT().ListExports(s.ctx).Return([]*journal.Export{}, nil)
+
+	response, err := s.core.ListExports(s.ctx)
+	s.Nil(err)
+	s.Nil(response.Watermark)
+	s.Empty(response.Exports)
+}
+
+// Test SetMapping
+
+func (s *CoreTestSuite) TestSetMappingNormalizesCurrencyAndCode() {
+	s.mockRepo.EXPECT().
+		SetMapping(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, m *journal.Mapping) error {
+			s.Equal(&journal.Mapping{Category: "asset", Currency: "EUR", GLCode: "1010"}, m)
+			return nil
+		})
+
+	response, err := s.core.SetMapping(s.ctx, "asset", "eur", &entities.SetMappingRequest{GLCode: " 1010 "})
+	s.Nil(err)
+	s.Equal("EUR", response.Currency)
+	s.Equal("1010", response.GLCode)
+}
+
+func (s *CoreTestSuite) TestSetMappingWithUnknownCategoryReturnsBadRequest() {
+	_, err := s.core.SetMapping(s.ctx, "cash", "USD", &entities.SetMappingRequest{GLCode: "1000"})
+	s.Require().NotNil(err)
+	s.Equal(apperror.MsgInvalidGLCategory, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestSetMappingWithUnsupportedCurrencyReturnsBadRequest() {
+	_, err := s.core.SetMapping(s.ctx, "asset", "GBP", &entities.SetMappingRequest{GLCode: "1000"})
+	s.Require().NotNil(err)
+	s.Equal(apperror.MsgInvalidCurrency, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestSetMappingWithEmptyCodeReturnsBadRequest() {
+	_, err := s.core.SetMapping(s.ctx, "asset", "USD", &entities.SetMappingRequest{GLCode: "  "})
+	s.Require().NotNil(err)
+	s.Equal(apperror.MsgInvalidGLCode, err.PublicMessage())
+}
+
+// Test NewLayout
+
+func (s *CoreTestSuite) TestNewLayoutRejectsUnknownColumn() {
+	_, err := journal.NewLayout([]string{"date", "amount"}, true, ",", "2006-01-02")
+	s.ErrorContains(err, `"amount"`)
+}
+
+func (s *CoreTestSuite) TestNewLayoutRejectsMultiCharacterDelimiter() {
+	_, err := journal.NewLayout([]string{"date"}, true, "||", "2006-01-02")
+	s.ErrorContains(err, entities.ErrMsgInvalidDelimiter)
+}
+
+func (s *CoreTestSuite) TestLayoutWritesConfiguredColumnsAndDateFormat() {
+	layout, err := journal.NewLayout([]string{"account_id", "date", "currency"}, false, ";", "02.01.2006")
+	s.Require().NoError(err)
+	s.core = s.newCore(layout)
+
+	s.expectLockedExport(nil)
+	s.mockRepo.EXPECT().HasArchivedAfter(s.ctx, s.mockTx, gomock.Any()).Return(false, nil)
+	s.mockRepo.EXPECT().ListUnmapped(s.ctx, s.mockTx, gomock.Any(), gomock.Any()).Return(nil, nil)
+	s.expectStream(date(time.March, 14), date(time.March, 15), postings()[1:])
+	s.mockRepo.EXPECT().InsertExport(s.ctx, s.mockTx, gomock.Any()).Return(nil)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil)
+
+	var out bytes.Buffer
+	_, appErr := s.core.Export(s.ctx, "2026-03-14", "", &out)
+	s.Require().Nil(appErr)
+	s.Equal(";14.03.2026;USD\n3;14.03.2026;USD\n", out.String())
+}
@@ -0,0 +1,73 @@
+// Package entities provides request/response types and constants for the journal module.
+package entities
+
+// Error messages for the journal module
+const (
+	ErrMsgInvalidDate       = "invalid date, expected YYYY-MM-DD"
+	ErrMsgInvalidRange      = "invalid journal date range"
+	ErrMsgDayNotClosed      = "business date has not closed"
+	ErrMsgFromRequired      = "from is required for the first export"
+	ErrMsgOutOfOrder        = "business dates exported out of order"
+	ErrMsgUnmapped          = "legs without a GL account mapping"
+	ErrMsgArchived          = "business dates fall in archived months"
+	ErrMsgChanged           = "journal no longer matches its export checksum"
+	ErrMsgInvalidExportID   = "invalid export ID"
+	ErrMsgExportNotFound    = "journal export not found"
+	ErrMsgInvalidGLCategory = "invalid GL category"
+	ErrMsgInvalidCurrency   = "unsupported currency"
+	ErrMsgInvalidGLCode     = "invalid GL code"
+	ErrMsgInvalidColumn     = "unknown journal column"
+	ErrMsgNoColumns         = "journal layout has no columns"
+	ErrMsgInvalidDelimiter  = "journal delimiter must be a single character other than a quote or line break"
+)
+
+// Route path constants for the journal module (admin router)
+const (
+	RouteExports   = "/journal-exports"
+	RouteExport    = "/journal-exports/{exportID}"
+	RouteMappings  = "/gl-mappings"
+	RouteMapping   = "/gl-mappings/{category}/{currency}"
+	ParamExportID  = "exportID"
+	ParamCategory  = "category"
+	ParamCurrency  = "currency"
+	QueryParamFrom = "from"
+	QueryParamTo   = "to"
+)
+
+// MaxGLCodeLength bounds GL account codes (gl_account_mappings.gl_code)
+const MaxGLCodeLength = 32
+
+// MappingKeyFormat identifies a mapping by category and currency, as in "asset/USD"
+const MappingKeyFormat = "%s/%s"
+
+// DateLayout is the wire and query format of business dates
+const DateLayout = "2006-01-02"
+
+// CSV columns a journal layout can be built from
+const (
+	ColumnDate          = "date"
+	ColumnGLAccount     = "gl_account"
+	ColumnDebit         = "debit"
+	ColumnCredit        = "credit"
+	ColumnMemo          = "memo"
+	ColumnTransactionID = "transaction_id"
+	ColumnCurrency      = "currency"
+	ColumnAccountID     = "account_id"
+)
+
+// Memo formats of journal lines: the transaction type and the accounts money moved between
+const (
+	MemoTransferFormat = "%s from account %d to account %d"
+	MemoOpeningFormat  = "%s to account %d"
+)
+
+// Download headers
+const (
+	ContentTypeCSV           = "text/csv; charset=utf-8"
+	HeaderContentDisposition = "Content-Disposition"
+	ContentDispositionFormat = `attachment; filename="journal-%s-%s.csv"`
+)
+
+// LockKey is the advisory lock that serializes journal exports, so two exports can never
+// claim the same business dates
+const LockKey int64 = 0x6a6f75726e616c21
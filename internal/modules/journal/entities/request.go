@@ -0,0 +1,6 @@
+package entities
+
+// SetMappingRequest is the body of PUT /gl-mappings/{category}/{currency}
+type SetMappingRequest struct {
+	GLCode string `json:"gl_code"`
+}
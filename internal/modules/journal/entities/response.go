@@ -0,0 +1,32 @@
+package entities
+
+// ExportResponse describes a journal export: the business dates it covers and what it held
+type ExportResponse struct {
+	ExportID   int64  `json:"export_id"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	EntryCount int64  `json:"entry_count"`
+	Checksum   string `json:"checksum"`
+	ExportedBy string `json:"exported_by"`
+	ExportedAt string `json:"exported_at"`
+}
+
+// ExportsResponse lists journal exports, newest first. Watermark is the last exported
+// business date, absent before the first export.
+type ExportsResponse struct {
+	Watermark *string           `json:"watermark"`
+	Exports   []*ExportResponse `json:"exports"`
+}
+
+// MappingResponse is the GL account code legs of one category and currency are exported under
+type MappingResponse struct {
+	Category  string `json:"category"`
+	Currency  string `json:"currency"`
+	GLCode    string `json:"gl_code"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// MappingsResponse lists every GL account mapping
+type MappingsResponse struct {
+	Mappings []*MappingResponse `json:"mappings"`
+}
@@ -0,0 +1,71 @@
+// Package journal exports posted transactions as general-ledger journal entries for the
+// accounting system, one debit and one credit line per posting.
+package journal
+
+import (
+	"context"
+
+	"github.com/internal-transfers-service/internal/config"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var JournalModule IModule
+
+// NewModule initializes the journal export module
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool, cfg config.JournalConfig, currencies []string, snapshotModule snapshot.IModule, recorder audit.IRecorder) (IModule, error) {
+	if JournalModule == nil {
+		layout, err := NewLayout(cfg.GetColumns(), cfg.Header, cfg.GetDelimiter(), cfg.GetDateFormat())
+		if err != nil {
+			return nil, err
+		}
+
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper, recorder)
+		core := NewCore(ctx, repo, snapshotModule.GetSchedule(), clock.Real{}, layout, currencies)
+		handler := NewHTTPHandler(core)
+
+		JournalModule = &Module{
+			Core:    core,
+			Handler: handler,
+			Repo:    repo,
+		}
+	}
+	return JournalModule, nil
+}
+
+// IModule defines the interface for the journal export module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+}
+
+// Module implements IModule
+type Module struct {
+	Core    ICore
+	Handler *HTTPHandler
+	Repo    IRepository
+}
+
+// Compile-time interface check
+var _ IModule = (*Module)(nil)
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
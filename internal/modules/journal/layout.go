@@ -0,0 +1,114 @@
+package journal
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/modules/journal/entities"
+	"github.com/shopspring/decimal"
+)
+
+// Line is one side of a posting: the debit of the account money left or the credit of the
+// account it reached. Exactly one of Debit and Credit is non-zero.
+type Line struct {
+	Date          time.Time
+	GLCode        string
+	Debit         decimal.Decimal
+	Credit        decimal.Decimal
+	Memo          string
+	TransactionID uuid.UUID
+	Currency      string
+	// AccountID is nil for the debit line of an opening entry, which has no source account
+	AccountID *int64
+}
+
+// columns lists every column a layout can be built from
+var columns = []string{
+	entities.ColumnDate,
+	entities.ColumnGLAccount,
+	entities.ColumnDebit,
+	entities.ColumnCredit,
+	entities.ColumnMemo,
+	entities.ColumnTransactionID,
+	entities.ColumnCurrency,
+	entities.ColumnAccountID,
+}
+
+// Layout is the CSV shape of a journal export: which columns appear in which order, the
+// field delimiter and how dates are written
+type Layout struct {
+	columns    []string
+	header     bool
+	delimiter  rune
+	dateFormat string
+}
+
+// NewLayout validates the configured columns and delimiter
+func NewLayout(cols []string, header bool, delimiter, dateFormat string) (*Layout, error) {
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("%s", entities.ErrMsgNoColumns)
+	}
+	for _, col := range cols {
+		if !slices.Contains(columns, col) {
+			return nil, fmt.Errorf("%s: %q", entities.ErrMsgInvalidColumn, col)
+		}
+	}
+
+	comma, size := utf8.DecodeRuneInString(delimiter)
+	if size == 0 || size != len(delimiter) || comma == utf8.RuneError || comma == '"' || comma == '\r' || comma == '\n' {
+		return nil, fmt.Errorf("%s: %q", entities.ErrMsgInvalidDelimiter, delimiter)
+	}
+
+	return &Layout{
+		columns:    slices.Clone(cols),
+		header:     header,
+		delimiter:  comma,
+		dateFormat: dateFormat,
+	}, nil
+}
+
+// Record renders a line as the fields of one CSV record
+func (l *Layout) Record(line *Line) []string {
+	record := make([]string, len(l.columns))
+	for i, col := range l.columns {
+		switch col {
+		case entities.ColumnDate:
+			record[i] = line.Date.Format(l.dateFormat)
+		case entities.ColumnGLAccount:
+			record[i] = line.GLCode
+		case entities.ColumnDebit:
+			record[i] = formatAmount(line.Debit)
+		case entities.ColumnCredit:
+			record[i] = formatAmount(line.Credit)
+		case entities.ColumnMemo:
+			record[i] = line.Memo
+		case entities.ColumnTransactionID:
+			record[i] = line.TransactionID.String()
+		case entities.ColumnCurrency:
+			record[i] = line.Currency
+		case entities.ColumnAccountID:
+			record[i] = formatAccountID(line.AccountID)
+		}
+	}
+	return record
+}
+
+// formatAmount renders a debit or credit, leaving the field empty on the other side's line
+func formatAmount(amount decimal.Decimal) string {
+	if amount.IsZero() {
+		return ""
+	}
+	return amount.String()
+}
+
+// formatAccountID renders an optional account ID
+func formatAccountID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
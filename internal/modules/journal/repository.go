@@ -0,0 +1,401 @@
+package journal
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	auditentities "github.com/internal-transfers-service/internal/modules/audit/entities"
+	"github.com/internal-transfers-service/internal/modules/journal/entities"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// Posting is a transaction with the GL account codes of its two legs. DebitGLCode is the
+// code of the source account, or of opening balance equity for an opening entry;
+// CreditGLCode is the code of the destination account. A code is empty when its leg has
+// no mapping.
+type Posting struct {
+	ID                   uuid.UUID
+	Type                 string
+	Amount               decimal.Decimal
+	EffectiveDate        time.Time
+	SourceAccountID      *int64
+	DestinationAccountID int64
+	Currency             string
+	DebitGLCode          string
+	CreditGLCode         string
+}
+
+// Export is a recorded journal export of the business dates FirstDate to LastDate
+type Export struct {
+	ID         int64
+	FirstDate  time.Time
+	LastDate   time.Time
+	EntryCount int64
+	Checksum   string
+	ExportedBy string
+	ExportedAt time.Time
+}
+
+// Mapping is the GL account code legs of one general-ledger category and currency are
+// exported under
+type Mapping struct {
+	Category  string    `json:"gl_category"`
+	Currency  string    `json:"currency"`
+	GLCode    string    `json:"gl_code"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Unmapped is a general-ledger category and currency with legs but no GL account code
+type Unmapped struct {
+	Category string
+	Currency string
+}
+
+// IRepository defines the interface for journal data access
+type IRepository interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	BeginReadTx(ctx context.Context) (pgx.Tx, error)
+	Lock(ctx context.Context, tx pgx.Tx) error
+	LastExport(ctx context.Context, tx pgx.Tx) (*Export, error)
+	HasArchivedAfter(ctx context.Context, tx pgx.Tx, start time.Time) (bool, error)
+	ListUnmapped(ctx context.Context, tx pgx.Tx, start, end time.Time) ([]*Unmapped, error)
+	StreamPostings(ctx context.Context, tx pgx.Tx, start, end time.Time, fn func(*Posting) error) error
+	InsertExport(ctx context.Context, tx pgx.Tx, export *Export) error
+	GetExport(ctx context.Context, exportID int64) (*Export, error)
+	ListExports(ctx context.Context) ([]*Export, error)
+	ListMappings(ctx context.Context) ([]*Mapping, error)
+	SetMapping(ctx context.Context, mapping *Mapping) error
+}
+
+// Repository implements IRepository. Mapping changes are recorded in the audit log within
+// the same transaction.
+type Repository struct {
+	pool  database.IPool
+	audit audit.IRecorder
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new journal repository
+func NewRepository(pool database.IPool, recorder audit.IRecorder) *Repository {
+	return &Repository{pool: pool, audit: recorder}
+}
+
+// SQL queries
+const (
+	queryLock = `
+		SELECT pg_advisory_xact_lock($1)`
+
+	querySelectLastExport = `
+		SELECT export_id, first_date, last_date, entry_count, checksum, exported_by, exported_at
+		FROM journal_exports
+		ORDER BY last_date DESC
+		LIMIT 1`
+
+	querySelectExport = `
+		SELECT export_id, first_date, last_date, entry_count, checksum, exported_by, exported_at
+		FROM journal_exports
+		WHERE export_id = $1`
+
+	querySelectExports = `
+		SELECT export_id, first_date, last_date, entry_count, checksum, exported_by, exported_at
+		FROM journal_exports
+		ORDER BY last_date DESC`
+
+	queryInsertExport = `
+		INSERT INTO journal_exports (first_date, last_date, entry_count, checksum, exported_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING export_id, exported_at`
+
+	// Detached months have left the transactions table as well as archived ones
+	queryHasArchivedAfter = `
+		SELECT EXISTS(SELECT 1 FROM transaction_archives WHERE range_end > $1)`
+
+	// Both legs of every posting in ($1, $2]: the debit of the source account, or of
+	// opening balance equity for an opening entry, and the credit of the destination
+	// account. Transfers never cross currencies, so both legs are in the destination's.
+	querySelectUnmapped = `
+		WITH legs AS (
+			SELECT COALESCE(s.gl_category, 'equity') AS gl_category, d.currency
+			FROM transactions t
+			JOIN accounts d ON d.account_id = t.destination_account_id
+			LEFT JOIN accounts s ON s.account_id = t.source_account_id
+			WHERE t.created_at > $1 AND t.created_at <= $2
+			UNION
+			SELECT d.gl_category, d.currency
+			FROM transactions t
+			JOIN accounts d ON d.account_id = t.destination_account_id
+			WHERE t.created_at > $1 AND t.created_at <= $2
+		)
+		SELECT l.gl_category, l.currency
+		FROM legs l
+		LEFT JOIN gl_account_mappings m ON m.gl_category = l.gl_category AND m.currency = l.currency
+		WHERE m.gl_code IS NULL
+		ORDER BY l.currency, l.gl_category`
+
+	querySelectPostings = `
+		SELECT t.id, t.type, t.amount, t.effective_date, t.source_account_id, t.destination_account_id,
+			d.currency, COALESCE(dm.gl_code, ''), COALESCE(cm.gl_code, '')
+		FROM transactions t
+		JOIN accounts d ON d.account_id = t.destination_account_id
+		LEFT JOIN accounts s ON s.account_id = t.source_account_id
+		LEFT JOIN gl_account_mappings dm ON dm.gl_category = COALESCE(s.gl_category, 'equity') AND dm.currency = d.currency
+		LEFT JOIN gl_account_mappings cm ON cm.gl_category = d.gl_category AND cm.currency = d.currency
+		WHERE t.created_at > $1 AND t.created_at <= $2
+		ORDER BY t.chain_seq`
+
+	querySelectMappings = `
+		SELECT gl_category, currency, gl_code, updated_at
+		FROM gl_account_mappings
+		ORDER BY currency, gl_category`
+
+	// Returns the code being replaced, or NULL when the category and currency had none
+	queryUpsertMapping = `
+		WITH prev AS (
+			SELECT gl_code, updated_at FROM gl_account_mappings
+			WHERE gl_category = $1 AND currency = $2
+			FOR UPDATE
+		)
+		INSERT INTO gl_account_mappings (gl_category, currency, gl_code)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (gl_category, currency) DO UPDATE SET gl_code = EXCLUDED.gl_code, updated_at = NOW()
+		RETURNING updated_at, (SELECT gl_code FROM prev), (SELECT updated_at FROM prev)`
+)
+
+// BeginTx starts a read-committed transaction. Exports take the journal lock before
+// reading anything, and under READ COMMITTED every later statement sees the exports
+// committed while the lock was awaited.
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+}
+
+// BeginReadTx starts a read-only repeatable-read transaction, so that a repeated export
+// reads the same postings each time it passes over them
+func (r *Repository) BeginReadTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+}
+
+// Lock takes the journal lock for the lifetime of tx, waiting for any running export
+func (r *Repository) Lock(ctx context.Context, tx pgx.Tx) error {
+	if _, err := tx.Exec(ctx, queryLock, entities.LockKey); err != nil {
+		r.logReadFailure(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// LastExport returns the export with the latest business dates, or nil before the first export
+func (r *Repository) LastExport(ctx context.Context, tx pgx.Tx) (*Export, error) {
+	export, err := scanExport(tx.QueryRow(ctx, querySelectLastExport))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		r.logReadFailure(ctx, err)
+		return nil, err
+	}
+	return export, nil
+}
+
+// HasArchivedAfter reports whether any archived or detached month ends after start, so
+// that postings after start may be missing from the transactions table
+func (r *Repository) HasArchivedAfter(ctx context.Context, tx pgx.Tx, start time.Time) (bool, error) {
+	var archived bool
+	if err := tx.QueryRow(ctx, queryHasArchivedAfter, start).Scan(&archived); err != nil {
+		r.logReadFailure(ctx, err)
+		return false, err
+	}
+	return archived, nil
+}
+
+// ListUnmapped returns the categories and currencies of legs created in (start, end] that
+// have no GL account code
+func (r *Repository) ListUnmapped(ctx context.Context, tx pgx.Tx, start, end time.Time) ([]*Unmapped, error) {
+	rows, err := tx.Query(ctx, querySelectUnmapped, start, end)
+	if err != nil {
+		r.logReadFailure(ctx, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	unmapped := make([]*Unmapped, 0)
+	for rows.Next() {
+		var u Unmapped
+		if err := rows.Scan(&u.Category, &u.Currency); err != nil {
+			r.logReadFailure(ctx, err)
+			return nil, err
+		}
+		unmapped = append(unmapped, &u)
+	}
+	if err := rows.Err(); err != nil {
+		r.logReadFailure(ctx, err)
+		return nil, err
+	}
+	return unmapped, nil
+}
+
+// StreamPostings calls fn for each transaction created in (start, end], in chain order.
+// Rows are read one at a time, so memory use does not grow with the range;
+// fn must not retain the posting, which is reused between calls.
+func (r *Repository) StreamPostings(ctx context.Context, tx pgx.Tx, start, end time.Time, fn func(*Posting) error) error {
+	rows, err := tx.Query(ctx, querySelectPostings, start, end)
+	if err != nil {
+		r.logReadFailure(ctx, err)
+		return err
+	}
+	defer rows.Close()
+
+	var p Posting
+	for rows.Next() {
+		if err := rows.Scan(&p.ID, &p.Type, &p.Amount, &p.EffectiveDate, &p.SourceAccountID, &p.DestinationAccountID,
+			&p.Currency, &p.DebitGLCode, &p.CreditGLCode); err != nil {
+			r.logReadFailure(ctx, err)
+			return err
+		}
+		if err := fn(&p); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logReadFailure(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// InsertExport records an export and sets its ID and export time
+func (r *Repository) InsertExport(ctx context.Context, tx pgx.Tx, export *Export) error {
+	err := tx.QueryRow(ctx, queryInsertExport,
+		export.FirstDate, export.LastDate, export.EntryCount, export.Checksum, export.ExportedBy,
+	).Scan(&export.ID, &export.ExportedAt)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteJournal,
+			constants.LogFieldFromDate, export.FirstDate.Format(entities.DateLayout),
+			constants.LogFieldToDate, export.LastDate.Format(entities.DateLayout),
+			constants.LogKeyError, err,
+		)
+		return err
+	}
+	return nil
+}
+
+// GetExport returns an export by ID, or nil if there is none
+func (r *Repository) GetExport(ctx context.Context, exportID int64) (*Export, error) {
+	export, err := scanExport(r.pool.QueryRow(ctx, querySelectExport, exportID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		r.logReadFailure(ctx, err)
+		return nil, err
+	}
+	return export, nil
+}
+
+// ListExports returns every export, newest business dates first
+func (r *Repository) ListExports(ctx context.Context) ([]*Export, error) {
+	rows, err := r.pool.Query(ctx, querySelectExports)
+	if err != nil {
+		r.logReadFailure(ctx, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	exports := make([]*Export, 0)
+	for rows.Next() {
+		export, err := scanExport(rows)
+		if err != nil {
+			r.logReadFailure(ctx, err)
+			return nil, err
+		}
+		exports = append(exports, export)
+	}
+	if err := rows.Err(); err != nil {
+		r.logReadFailure(ctx, err)
+		return nil, err
+	}
+	return exports, nil
+}
+
+// ListMappings returns every GL account mapping, by currency then category
+func (r *Repository) ListMappings(ctx context.Context) ([]*Mapping, error) {
+	rows, err := r.pool.Query(ctx, querySelectMappings)
+	if err != nil {
+		r.logReadFailure(ctx, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	mappings := make([]*Mapping, 0)
+	for rows.Next() {
+		var m Mapping
+		if err := rows.Scan(&m.Category, &m.Currency, &m.GLCode, &m.UpdatedAt); err != nil {
+			r.logReadFailure(ctx, err)
+			return nil, err
+		}
+		mappings = append(mappings, &m)
+	}
+	if err := rows.Err(); err != nil {
+		r.logReadFailure(ctx, err)
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// SetMapping maps a category and currency to a GL account code, replacing any earlier
+// code, and sets the mapping's update time
+func (r *Repository) SetMapping(ctx context.Context, mapping *Mapping) error {
+	return database.InTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var prevCode *string
+		var prevUpdatedAt *time.Time
+		err := tx.QueryRow(ctx, queryUpsertMapping, mapping.Category, mapping.Currency, mapping.GLCode).
+			Scan(&mapping.UpdatedAt, &prevCode, &prevUpdatedAt)
+		if err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteJournal,
+				constants.LogFieldGLCategory, mapping.Category,
+				constants.LogFieldCurrency, mapping.Currency,
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+
+		var before any
+		if prevCode != nil && prevUpdatedAt != nil {
+			before = &Mapping{Category: mapping.Category, Currency: mapping.Currency, GLCode: *prevCode, UpdatedAt: *prevUpdatedAt}
+		}
+		return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionGLMappingSet, auditentities.EntityGLMapping,
+			fmt.Sprintf(entities.MappingKeyFormat, mapping.Category, mapping.Currency), before, mapping))
+	})
+}
+
+// scanExport scans one journal_exports row
+func scanExport(row pgx.Row) (*Export, error) {
+	var export Export
+	if err := row.Scan(&export.ID, &export.FirstDate, &export.LastDate, &export.EntryCount,
+		&export.Checksum, &export.ExportedBy, &export.ExportedAt); err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// logReadFailure logs a failure to read journal data
+func (r *Repository) logReadFailure(ctx context.Context, err error) {
+	logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadJournal,
+		constants.LogKeyError, err,
+	)
+}
@@ -0,0 +1,181 @@
+package journal_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/audit"
+	auditentities "github.com/internal-transfers-service/internal/modules/audit/entities"
+	auditMock "github.com/internal-transfers-service/internal/modules/audit/mock"
+	"github.com/internal-transfers-service/internal/modules/journal"
+	"github.com/internal-transfers-service/internal/modules/journal/entities"
+	dbMock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in repository tests
+var errRepoQueryFailed = errors.New("query execution failed")
+
+// RepositoryTestSuite contains tests for journal Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	mockPool  *dbMock.MockIPool
+	mockRow   *dbMock.MockRow
+	mockRows  *dbMock.MockRows
+	mockTx    *dbMock.MockTx
+	mockAudit *auditMock.MockIRecorder
+	repo      journal.IRepository
+	ctx       context.Context
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbMock.NewMockIPool(s.ctrl)
+	s.mockRow = dbMock.NewMockRow(s.ctrl)
+	s.mockRows = dbMock.NewMockRows(s.ctrl)
+	s.mockTx = dbMock.NewMockTx(s.ctrl)
+	s.ctx = context.Background()
+	s.mockAudit = auditMock.NewMockIRecorder(s.ctrl)
+	s.repo = journal.NewRepository(s.mockPool, s.mockAudit)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// sqlContaining matches a statement that contains every fragment
+func sqlContaining(fragments ...string) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		sql, ok := x.(string)
+		if !ok {
+			return false
+		}
+		for _, f := range fragments {
+			if !strings.Contains(sql, f) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Test Lock
+
+func (s *RepositoryTestSuite) TestLockUsesJournalLockKey() {
+	s.mockTx.EXPECT().Exec(s.ctx, sqlContaining("pg_advisory_xact_lock"), entities.LockKey).Times(1)
+
+	s.NoError(s.repo.Lock(s.ctx, s.mockTx))
+}
+
+// Test LastExport
+
+func (s *RepositoryTestSuite) TestLastExportBeforeFirstReturnsNil() {
+	s.mockTx.EXPECT().QueryRow(s.ctx, sqlContaining("ORDER BY last_date DESC", "LIMIT 1")).Return(s.mockRow)
+	s.mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).Return(pgx.ErrNoRows)
+
+	export, err := s.repo.LastExport(s.ctx, s.mockTx)
+	s.NoError(err)
+	s.Nil(export)
+}
+
+// Test HasArchivedAfter
+
+func (s *RepositoryTestSuite) TestHasArchivedAfterComparesRangeEnd() {
+	start := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	s.mockTx.EXPECT().QueryRow(s.ctx, sqlContaining("transaction_archives", "range_end > $1"), start).Return(s.mockRow)
+	s.mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*bool) = true
+		return nil
+	})
+
+	archived, err := s.repo.HasArchivedAfter(s.ctx, s.mockTx, start)
+	s.NoError(err)
+	s.True(archived)
+}
+
+// Test StreamPostings
+
+func (s *RepositoryTestSuite) TestStreamPostingsReadsInChainOrder() {
+	start, end := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	s.mockTx.EXPECT().Query(s.ctx, sqlContaining("gl_account_mappings", "ORDER BY t.chain_seq"), start, end).Return(s.mockRows, nil)
+	s.mockRows.EXPECT().Next().Return(false)
+	s.mockRows.EXPECT().Err().Return(nil)
+	s.mockRows.EXPECT().Close()
+
+	err := s.repo.StreamPostings(s.ctx, s.mockTx, start, end, func(*journal.Posting) error {
+		s.Fail("no posting expected")
+		return nil
+	})
+	s.NoError(err)
+}
+
+// Test InsertExport
+
+func (s *RepositoryTestSuite) TestInsertExportSetsIDAndTime() {
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	exportedAt := time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)
+	export := &journal.Export{FirstDate: day, LastDate: day, EntryCount: 2, Checksum: "ab", ExportedBy: "finance"}
+	s.mockTx.EXPECT().QueryRow(s.ctx, sqlContaining("INSERT INTO journal_exports"), day, day, int64(2), "ab", "finance").Return(s.mockRow)
+	s.mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int64) = 4
+		*dest[1].(*time.Time) = exportedAt
+		return nil
+	})
+
+	s.NoError(s.repo.InsertExport(s.ctx, s.mockTx, export))
+	s.Equal(int64(4), export.ID)
+	s.Equal(exportedAt, export.ExportedAt)
+}
+
+// Test ListExports
+
+func (s *RepositoryTestSuite) TestListExportsWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any()).Return(nil, errRepoQueryFailed).Times(1)
+
+	exports, err := s.repo.ListExports(s.ctx)
+	s.Equal(errRepoQueryFailed, err)
+	s.Nil(exports)
+}
+
+// Test SetMapping
+
+func (s *RepositoryTestSuite) TestSetMappingRecordsReplacedCode() {
+	prevUpdatedAt := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)
+	mapping := &journal.Mapping{Category: "asset", Currency: "USD", GLCode: "1010"}
+
+	s.mockPool.EXPECT().BeginTx(s.ctx, gomock.Any()).Return(s.mockTx, nil)
+	s.mockTx.EXPECT().QueryRow(s.ctx, sqlContaining("INSERT INTO gl_account_mappings"), "asset", "USD", "1010").Return(s.mockRow)
+	s.mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*time.Time) = updatedAt
+		prevCode := "1000"
+		*dest[1].(**string) = &prevCode
+		*dest[2].(**time.Time) = &prevUpdatedAt
+		return nil
+	})
+	s.mockAudit.EXPECT().
+		Record(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, events ...*audit.Event) error {
+			s.Require().Len(events, 1)
+			s.Equal(auditentities.ActionGLMappingSet, events[0].Action)
+			s.Equal(auditentities.EntityGLMapping, events[0].EntityType)
+			s.Equal("asset/USD", events[0].EntityID)
+			return nil
+		})
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+
+	s.NoError(s.repo.SetMapping(s.ctx, mapping))
+	s.Equal(updatedAt, mapping.UpdatedAt)
+}
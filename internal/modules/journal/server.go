@@ -0,0 +1,185 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/journal/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+)
+
+// HTTPHandler handles HTTP requests for journal exports and GL account mappings
+type HTTPHandler struct {
+	core ICore
+}
+
+// NewHTTPHandler creates a new HTTPHandler
+func NewHTTPHandler(core ICore) *HTTPHandler {
+	return &HTTPHandler{core: core}
+}
+
+// RegisterAdminRoutes registers the journal routes.
+func (h *HTTPHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get(entities.RouteExports, h.ListExports)
+	r.Post(entities.RouteExports, h.Export)
+	r.Get(entities.RouteExport, h.Download)
+	r.Get(entities.RouteMappings, h.ListMappings)
+	r.Put(entities.RouteMapping, h.SetMapping)
+}
+
+// Export handles POST /journal-exports?from=&to=. The journal is streamed as CSV; 204 means
+// no business date has closed since the last export.
+func (h *HTTPHandler) Export(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	out := &downloadWriter{w: w}
+	response, appErr := h.core.Export(r.Context(), query.Get(entities.QueryParamFrom), query.Get(entities.QueryParamTo), out)
+	h.finish(w, r, out, response, appErr)
+}
+
+// Download handles GET /journal-exports/{exportID}, streaming a recorded export again
+func (h *HTTPHandler) Download(w http.ResponseWriter, r *http.Request) {
+	exportIDStr := chi.URLParam(r, entities.ParamExportID)
+	exportID, err := strconv.ParseInt(exportIDStr, 10, 64)
+	if err != nil || exportID <= 0 {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidExportID, apperror.MsgInvalidExportID).
+			WithField(apperror.FieldExportID, exportIDStr))
+		return
+	}
+
+	out := &downloadWriter{w: w}
+	response, appErr := h.core.Download(r.Context(), exportID, out)
+	h.finish(w, r, out, response, appErr)
+}
+
+// ListExports handles GET /journal-exports
+func (h *HTTPHandler) ListExports(w http.ResponseWriter, r *http.Request) {
+	response, appErr := h.core.ListExports(r.Context())
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// ListMappings handles GET /gl-mappings
+func (h *HTTPHandler) ListMappings(w http.ResponseWriter, r *http.Request) {
+	response, appErr := h.core.ListMappings(r.Context())
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// SetMapping handles PUT /gl-mappings/{category}/{currency}
+func (h *HTTPHandler) SetMapping(w http.ResponseWriter, r *http.Request) {
+	var req entities.SetMappingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	response, appErr := h.core.SetMapping(r.Context(), chi.URLParam(r, entities.ParamCategory), chi.URLParam(r, entities.ParamCurrency), &req)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// finish completes a streamed export: errors raised before any output are returned as
+// JSON, and an export with nothing to write is answered with 204
+func (h *HTTPHandler) finish(w http.ResponseWriter, r *http.Request, out *downloadWriter, response *entities.ExportResponse, appErr apperror.IError) {
+	if appErr != nil {
+		if !out.started {
+			h.writeErrorWithContext(w, r, appErr)
+			return
+		}
+		// Headers are already sent; the client sees a truncated body
+		logger.Ctx(r.Context()).Errorw(constants.LogMsgJournalStreamAborted,
+			constants.LogKeyError, appErr.Error(),
+		)
+		return
+	}
+
+	if response == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if !out.started {
+		// A journal without postings or header row is an empty file, not an empty response
+		_, _ = out.Write(nil)
+	}
+}
+
+// downloadWriter sends the download headers on the first write, so that errors
+// raised before any output can still be returned as a JSON error response
+type downloadWriter struct {
+	w       http.ResponseWriter
+	from    string
+	to      string
+	started bool
+}
+
+// Compile-time interface check
+var _ Named = (*downloadWriter)(nil)
+
+// SetDates names the download after the business dates it holds
+func (d *downloadWriter) SetDates(from, to string) {
+	d.from, d.to = from, to
+}
+
+// Write writes p to the response, sending the headers first if needed
+func (d *downloadWriter) Write(p []byte) (int, error) {
+	if !d.started {
+		d.started = true
+		d.w.Header().Set(constants.HeaderContentType, entities.ContentTypeCSV)
+		d.w.Header().Set(entities.HeaderContentDisposition, fmt.Sprintf(entities.ContentDispositionFormat, d.from, d.to))
+		d.w.WriteHeader(http.StatusOK)
+	}
+	return d.w.Write(p)
+}
+
+// writeJSON writes a JSON response
+func (h *HTTPHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(status)
+	if data != nil {
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			logger.Error(constants.LogMsgFailedToEncodeResponse, constants.LogKeyError, err)
+		}
+	}
+}
+
+// writeErrorWithContext writes an error response with request ID for tracing
+func (h *HTTPHandler) writeErrorWithContext(w http.ResponseWriter, r *http.Request, err apperror.IError) {
+	requestID := ""
+	if id, ok := r.Context().Value(contextkeys.RequestID).(string); ok {
+		requestID = id
+	}
+
+	response := apperror.ErrorResponse{
+		Error:     err.PublicMessage(),
+		Code:      err.Code().String(),
+		RequestID: requestID,
+		Details:   err.Fields(),
+	}
+
+	// Log error for debugging
+	logger.Ctx(r.Context()).Errorw(constants.LogMsgRequestFailed,
+		constants.LogKeyError, err.Error(),
+		constants.LogKeyStatusCode, err.HTTPStatus(),
+	)
+
+	h.writeJSON(w, err.HTTPStatus(), response)
+}
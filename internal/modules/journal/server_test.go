@@ -0,0 +1,107 @@
+package journal_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/modules/journal"
+	"github.com/internal-transfers-service/internal/modules/journal/entities"
+	"github.com/internal-transfers-service/internal/modules/journal/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for journal HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.router = chi.NewRouter()
+	journal.NewHTTPHandler(s.mockCore).RegisterAdminRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestExportStreamsCSVNamedAfterDates() {
+	s.mockCore.EXPECT().
+		Export(gomock.Any(), "", "", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, w io.Writer) (*entities.ExportResponse, apperror.IError) {
+			w.(journal.Named).SetDates("2026-03-11", "2026-03-14")
+			_, _ = io.WriteString(w, "date,gl_account\n")
+			return &entities.ExportResponse{ExportID: 1, From: "2026-03-11", To: "2026-03-14"}, nil
+		})
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/journal-exports", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(entities.ContentTypeCSV, rec.Header().Get("Content-Type"))
+	s.Equal(`attachment; filename="journal-2026-03-11-2026-03-14.csv"`, rec.Header().Get(entities.HeaderContentDisposition))
+	s.Equal("date,gl_account\n", rec.Body.String())
+}
+
+func (s *ServerTestSuite) TestExportWhenUpToDateReturnsNoContent() {
+	s.mockCore.EXPECT().Export(gomock.Any(), "", "", gomock.Any()).Return(nil, nil)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/journal-exports", nil))
+
+	s.Equal(http.StatusNoContent, rec.Code)
+	s.Zero(rec.Body.Len())
+}
+
+func (s *ServerTestSuite) TestExportOutOfOrderReturnsConflict() {
+	s.mockCore.EXPECT().
+		Export(gomock.Any(), "2026-03-13", "2026-03-14", gomock.Any()).
+		Return(nil, apperror.NewWithMessage(apperror.CodeConflict, journal.ErrOutOfOrder, apperror.MsgJournalOutOfOrder).
+			WithField(apperror.FieldNextDate, "2026-03-11"))
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/journal-exports?from=2026-03-13&to=2026-03-14", nil))
+
+	s.Equal(http.StatusConflict, rec.Code)
+	var body apperror.ErrorResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	s.Equal(apperror.MsgJournalOutOfOrder, body.Error)
+	s.Equal("2026-03-11", body.Details[apperror.FieldNextDate])
+}
+
+func (s *ServerTestSuite) TestDownloadWithInvalidIDReturnsBadRequest() {
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/journal-exports/abc", nil))
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestSetMappingPassesPathAndBody() {
+	s.mockCore.EXPECT().
+		SetMapping(gomock.Any(), "asset", "usd", &entities.SetMappingRequest{GLCode: "1010"}).
+		Return(&entities.MappingResponse{Category: "asset", Currency: "USD", GLCode: "1010"}, nil)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/gl-mappings/asset/usd", strings.NewReader(`{"gl_code":"1010"}`)))
+
+	s.Equal(http.StatusOK, rec.Code)
+	var body entities.MappingResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	s.Equal("1010", body.GLCode)
+}
@@ -0,0 +1,162 @@
+package ledger
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	accountentities "github.com/internal-transfers-service/internal/modules/account/entities"
+	"github.com/internal-transfers-service/internal/modules/ledger/entities"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/shopspring/decimal"
+)
+
+// Domain errors
+var (
+	ErrInvalidDate = errors.New(entities.ErrMsgInvalidDate)
+	ErrFutureDate  = errors.New(entities.ErrMsgFutureDate)
+)
+
+// ICore defines the interface for general-ledger reports
+type ICore interface {
+	TrialBalance(ctx context.Context, date string) (*entities.TrialBalanceResponse, apperror.IError)
+}
+
+// Core implements ICore
+type Core struct {
+	repo     IRepository
+	schedule *snapshot.Schedule
+	clock    clock.Clock
+}
+
+// Compile-time interface check
+var _ ICore = (*Core)(nil)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance. Business dates close on the snapshot schedule, so a
+// trial balance matches the end-of-day balance snapshots of the same date.
+func NewCore(_ context.Context, repo IRepository, schedule *snapshot.Schedule, clk clock.Clock) ICore {
+	coreInstance = &Core{
+		repo:     repo,
+		schedule: schedule,
+		clock:    clk,
+	}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// TrialBalance sums stored account balances per general-ledger category and currency as
+// of the close of a business date, today when date is empty. Every currency lists all
+// categories, and a currency whose debits and credits differ is flagged and logged.
+func (c *Core) TrialBalance(ctx context.Context, date string) (*entities.TrialBalanceResponse, apperror.IError) {
+	now := c.clock.Now()
+	day := c.schedule.Today(now)
+	if date != "" {
+		parsed, err := time.Parse(entities.DateLayout, date)
+		if err != nil {
+			return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidDate, apperror.MsgInvalidDate).
+				WithField(apperror.FieldDate, date)
+		}
+		if parsed.After(day) {
+			return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrFutureDate, apperror.MsgFutureDate).
+				WithField(apperror.FieldDate, date)
+		}
+		day = parsed
+	}
+
+	asOf := c.schedule.CutoffOf(day)
+	closed := !asOf.After(now)
+	if !closed {
+		asOf = now
+	}
+
+	// A closed date is read from the ledger: its running balances at the cutoff plus the
+	// later postings backdated into it, so it can still change until its accounting period
+	// closes; today is read from the balances stored on the accounts
+	var totals []*CategoryTotal
+	var err error
+	if closed {
+		totals, err = c.repo.SumByCategory(ctx, day, asOf)
+	} else {
+		totals, err = c.repo.SumCurrentByCategory(ctx)
+	}
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err)
+	}
+
+	response := buildTrialBalance(totals)
+	response.Date = day.Format(entities.DateLayout)
+	response.AsOf = asOf.UTC().Format(time.RFC3339)
+	response.Closed = closed
+	c.logImbalances(ctx, response)
+	return response, nil
+}
+
+// buildTrialBalance lays out the totals currency by currency, in chart-of-accounts order,
+// and sums each currency
+func buildTrialBalance(totals []*CategoryTotal) *entities.TrialBalanceResponse {
+	byCurrency := make(map[string]map[string]*CategoryTotal)
+	currencies := make([]string, 0)
+	for _, t := range totals {
+		if byCurrency[t.Currency] == nil {
+			byCurrency[t.Currency] = make(map[string]*CategoryTotal)
+			currencies = append(currencies, t.Currency)
+		}
+		byCurrency[t.Currency][t.Category] = t
+	}
+
+	response := &entities.TrialBalanceResponse{
+		Balanced:   true,
+		Categories: make([]*entities.CategoryBalance, 0, len(currencies)*len(accountentities.GLCategories)),
+		Totals:     make([]*entities.CurrencyTotal, 0, len(currencies)),
+	}
+	for _, currency := range currencies {
+		debit, credit := decimal.Zero, decimal.Zero
+		for _, category := range accountentities.GLCategories {
+			line := &entities.CategoryBalance{Category: category, Currency: currency, Debit: "0", Credit: "0"}
+			if t, ok := byCurrency[currency][category]; ok {
+				line.Debit, line.Credit = t.Debit.String(), t.Credit.String()
+				debit, credit = debit.Add(t.Debit), credit.Add(t.Credit)
+			}
+			response.Categories = append(response.Categories, line)
+		}
+
+		balanced := debit.Equal(credit)
+		response.Balanced = response.Balanced && balanced
+		response.Totals = append(response.Totals, &entities.CurrencyTotal{
+			Currency:   currency,
+			Debit:      debit.String(),
+			Credit:     credit.String(),
+			Difference: debit.Sub(credit).String(),
+			Balanced:   balanced,
+		})
+	}
+	return response
+}
+
+// logImbalances logs every currency whose debits and credits differ
+func (c *Core) logImbalances(ctx context.Context, response *entities.TrialBalanceResponse) {
+	for _, total := range response.Totals {
+		if total.Balanced {
+			continue
+		}
+		logger.Ctx(ctx).Errorw(constants.LogMsgTrialBalanceImbalance,
+			constants.LogFieldSnapshotDate, response.Date,
+			constants.LogFieldCurrency, total.Currency,
+			constants.LogFieldDebitTotal, total.Debit,
+			constants.LogFieldCreditTotal, total.Credit,
+		)
+	}
+}
@@ -0,0 +1,203 @@
+package ledger_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	accountentities "github.com/internal-transfers-service/internal/modules/account/entities"
+	"github.com/internal-transfers-service/internal/modules/ledger"
+	"github.com/internal-transfers-service/internal/modules/ledger/mock"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in tests
+var errDatabaseError = errors.New("database error")
+
+// CoreTestSuite contains tests for ledger Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockRepo *mock.MockIRepository
+	clock    *clock.Fake
+	core     ledger.ICore
+	ctx      context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.clock = clock.NewFake(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC))
+	s.ctx = context.Background()
+
+	schedule, err := snapshot.NewSchedule("24:00", "UTC", 0)
+	s.Require().NoError(err)
+	s.core = ledger.NewCore(s.ctx, s.mockRepo, schedule, s.clock)
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *CoreTestSuite) TestTrialBalanceOfClosedDateSumsAtCutoff() {
+	cutoff := time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)
+	s.mockRepo.EXPECT().
+		SumByCategory(s.ctx, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), cutoff).
+		Return([]*ledger.CategoryTotal{
+			{Category: accountentities.GLCategoryAsset, Currency: "USD", Debit: decimal.NewFromInt(300), Credit: decimal.Zero},
+			{Category: accountentities.GLCategoryEquity, Currency: "USD", Debit: decimal.NewFromInt(50), Credit: decimal.Zero},
+			{Category: accountentities.GLCategoryLiability, Currency: "USD", Debit: decimal.Zero, Credit: decimal.NewFromInt(350)},
+		}, nil).
+		Times(1)
+
+	response, err := s.core.TrialBalance(s.ctx, "2026-03-10")
+	s.Nil(err)
+	s.Equal("2026-03-10", response.Date)
+	s.Equal("2026-03-11T00:00:00Z", response.AsOf)
+	s.True(response.Closed)
+	s.True(response.Balanced)
+	s.Require().Len(response.Totals, 1)
+	s.Equal("350", response.Totals[0].Debit)
+	s.Equal("350", response.Totals[0].Credit)
+	s.Equal("0", response.Totals[0].Difference)
+}
+
+func (s *CoreTestSuite) TestTrialBalanceOfClosedDateIncludesLaterBackdatedPosting() {
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)
+	gomock.InOrder(
+		s.mockRepo.EXPECT().
+			SumByCategory(s.ctx, day, cutoff).
+			Return([]*ledger.CategoryTotal{
+				{Category: accountentities.GLCategoryAsset, Currency: "USD", Debit: decimal.NewFromInt(350), Credit: decimal.Zero},
+				{Category: accountentities.GLCategoryLiability, Currency: "USD", Debit: decimal.Zero, Credit: decimal.NewFromInt(350)},
+			}, nil),
+		// A deposit posted on March 14 and effective March 9 now counts towards March 10
+		s.mockRepo.EXPECT().
+			SumByCategory(s.ctx, day, cutoff).
+			Return([]*ledger.CategoryTotal{
+				{Category: accountentities.GLCategoryAsset, Currency: "USD", Debit: decimal.NewFromInt(425), Credit: decimal.Zero},
+				{Category: accountentities.GLCategoryLiability, Currency: "USD", Debit: decimal.Zero, Credit: decimal.NewFromInt(425)},
+			}, nil),
+	)
+
+	before, err := s.core.TrialBalance(s.ctx, "2026-03-10")
+	s.Require().Nil(err)
+	s.clock.Advance(time.Hour)
+	after, err := s.core.TrialBalance(s.ctx, "2026-03-10")
+	s.Require().Nil(err)
+
+	s.Equal(before.AsOf, after.AsOf)
+	s.Equal("350", before.Totals[0].Credit)
+	s.Equal("425", after.Totals[0].Credit)
+	s.True(after.Balanced)
+}
+
+func (s *CoreTestSuite) TestTrialBalanceListsEveryCategoryInChartOrder() {
+	s.mockRepo.EXPECT().
+		SumByCategory(s.ctx, gomock.Any(), gomock.Any()).
+		Return([]*ledger.CategoryTotal{
+			{Category: accountentities.GLCategoryLiability, Currency: "EUR", Debit: decimal.Zero, Credit: decimal.NewFromInt(10)},
+		}, nil).
+		Times(1)
+
+	response, err := s.core.TrialBalance(s.ctx, "2026-03-10")
+	s.Nil(err)
+	s.Require().Len(response.Categories, len(accountentities.GLCategories))
+	for i, category := range accountentities.GLCategories {
+		s.Equal(category, response.Categories[i].Category)
+		s.Equal("EUR", response.Categories[i].Currency)
+	}
+	s.Equal("10", response.Categories[1].Credit)
+	s.Equal("0", response.Categories[0].Debit)
+}
+
+func (s *CoreTestSuite) TestTrialBalanceFlagsUnbalancedCurrency() {
+	s.mockRepo.EXPECT().
+		SumByCategory(s.ctx, gomock.Any(), gomock.Any()).
+		Return([]*ledger.CategoryTotal{
+			{Category: accountentities.GLCategoryAsset, Currency: "EUR", Debit: decimal.NewFromInt(100), Credit: decimal.Zero},
+			{Category: accountentities.GLCategoryLiability, Currency: "EUR", Debit: decimal.Zero, Credit: decimal.NewFromInt(100)},
+			{Category: accountentities.GLCategoryAsset, Currency: "USD", Debit: decimal.NewFromInt(90), Credit: decimal.Zero},
+			{Category: accountentities.GLCategoryLiability, Currency: "USD", Debit: decimal.Zero, Credit: decimal.NewFromInt(100)},
+		}, nil).
+		Times(1)
+
+	response, err := s.core.TrialBalance(s.ctx, "2026-03-10")
+	s.Nil(err)
+	s.False(response.Balanced)
+	s.Require().Len(response.Totals, 2)
+	s.True(response.Totals[0].Balanced)
+	s.False(response.Totals[1].Balanced)
+	s.Equal("-10", response.Totals[1].Difference)
+}
+
+func (s *CoreTestSuite) TestTrialBalanceWithoutDateIsTakenNowForToday() {
+	s.mockRepo.EXPECT().
+		SumCurrentByCategory(s.ctx).
+		Return([]*ledger.CategoryTotal{}, nil).
+		Times(1)
+
+	response, err := s.core.TrialBalance(s.ctx, "")
+	s.Nil(err)
+	s.Equal("2026-03-15", response.Date)
+	s.Equal("2026-03-15T12:00:00Z", response.AsOf)
+	s.False(response.Closed)
+	s.True(response.Balanced)
+	s.Empty(response.Categories)
+}
+
+func (s *CoreTestSuite) TestTrialBalanceFlagsDriftedStoredBalance() {
+	// 300 deposited through clearing and 50 opened, but the customer's stored balance is 360
+	s.mockRepo.EXPECT().
+		SumCurrentByCategory(s.ctx).
+		Return([]*ledger.CategoryTotal{
+			{Category: accountentities.GLCategoryAsset, Currency: "USD", Debit: decimal.NewFromInt(300), Credit: decimal.Zero},
+			{Category: accountentities.GLCategoryEquity, Currency: "USD", Debit: decimal.NewFromInt(50), Credit: decimal.Zero},
+			{Category: accountentities.GLCategoryLiability, Currency: "USD", Debit: decimal.Zero, Credit: decimal.NewFromInt(360)},
+		}, nil).
+		Times(1)
+
+	response, err := s.core.TrialBalance(s.ctx, "")
+	s.Nil(err)
+	s.False(response.Balanced)
+	s.Require().Len(response.Totals, 1)
+	s.False(response.Totals[0].Balanced)
+	s.Equal("-10", response.Totals[0].Difference)
+}
+
+func (s *CoreTestSuite) TestTrialBalanceWithInvalidDateReturnsBadRequest() {
+	response, err := s.core.TrialBalance(s.ctx, "10/03/2026")
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+}
+
+func (s *CoreTestSuite) TestTrialBalanceWithFutureDateReturnsBadRequest() {
+	response, err := s.core.TrialBalance(s.ctx, "2026-03-16")
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+}
+
+func (s *CoreTestSuite) TestTrialBalanceWhenRepositoryFailsReturnsInternalError() {
+	s.mockRepo.EXPECT().
+		SumByCategory(s.ctx, gomock.Any(), gomock.Any()).
+		Return(nil, errDatabaseError).
+		Times(1)
+
+	response, err := s.core.TrialBalance(s.ctx, "2026-03-10")
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
@@ -0,0 +1,17 @@
+// Package entities provides response types and constants for the ledger module.
+package entities
+
+// Error messages for the ledger module
+const (
+	ErrMsgInvalidDate = "invalid date, expected YYYY-MM-DD"
+	ErrMsgFutureDate  = "date is in the future"
+)
+
+// Route path constants for the ledger module (admin router)
+const (
+	RouteTrialBalance = "/trial-balance"
+	QueryParamDate    = "date"
+)
+
+// DateLayout is the wire and query format of business dates
+const DateLayout = "2006-01-02"
@@ -0,0 +1,31 @@
+package entities
+
+// CategoryBalance is the debit and credit total of one general-ledger category in one currency.
+// Accounts with a negative balance count as debits and those with a positive balance as credits.
+type CategoryBalance struct {
+	Category string `json:"category"`
+	Currency string `json:"currency"`
+	Debit    string `json:"debit"`
+	Credit   string `json:"credit"`
+}
+
+// CurrencyTotal sums the categories of one currency. Difference is Debit minus Credit and
+// is zero when the currency balances.
+type CurrencyTotal struct {
+	Currency   string `json:"currency"`
+	Debit      string `json:"debit"`
+	Credit     string `json:"credit"`
+	Difference string `json:"difference"`
+	Balanced   bool   `json:"balanced"`
+}
+
+// TrialBalanceResponse is the trial balance as of the end of a business date. When the date
+// has not closed yet, AsOf is the time the report was taken and Closed is false.
+type TrialBalanceResponse struct {
+	Date       string             `json:"date"`
+	AsOf       string             `json:"as_of"`
+	Closed     bool               `json:"closed"`
+	Balanced   bool               `json:"balanced"`
+	Categories []*CategoryBalance `json:"categories"`
+	Totals     []*CurrencyTotal   `json:"totals"`
+}
@@ -0,0 +1,63 @@
+// Package ledger reports on the service's books as a general ledger.
+package ledger
+
+import (
+	"context"
+
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var LedgerModule IModule
+
+// NewModule initializes the ledger module
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool, snapshotModule snapshot.IModule) IModule {
+	if LedgerModule == nil {
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper)
+		core := NewCore(ctx, repo, snapshotModule.GetSchedule(), clock.Real{})
+		handler := NewHTTPHandler(core)
+
+		LedgerModule = &Module{
+			Core:    core,
+			Handler: handler,
+			Repo:    repo,
+		}
+	}
+	return LedgerModule
+}
+
+// IModule defines the interface for the ledger module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+}
+
+// Module implements IModule
+type Module struct {
+	Core    ICore
+	Handler *HTTPHandler
+	Repo    IRepository
+}
+
+// Compile-time interface check
+var _ IModule = (*Module)(nil)
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
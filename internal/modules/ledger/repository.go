@@ -0,0 +1,175 @@
+package ledger
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/shopspring/decimal"
+)
+
+// CategoryTotal is the debit and credit total of the accounts of one general-ledger
+// category in one currency
+type CategoryTotal struct {
+	Category string
+	Currency string
+	Debit    decimal.Decimal
+	Credit   decimal.Decimal
+}
+
+// IRepository defines the interface for general-ledger data access
+type IRepository interface {
+	SumByCategory(ctx context.Context, date, asOf time.Time) ([]*CategoryTotal, error)
+	SumCurrentByCategory(ctx context.Context) ([]*CategoryTotal, error)
+}
+
+// Repository implements IRepository
+type Repository struct {
+	pool database.IPool
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new ledger repository
+func NewRepository(pool database.IPool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// SQL queries
+const (
+	// sumBalancesByCategory totals the balances and opening_equity CTEs of the queries
+	// below. An account with a negative balance is a debit and a positive one a credit.
+	sumBalancesByCategory = `
+		SELECT gl_category, currency,
+			COALESCE(SUM(-balance) FILTER (WHERE balance < 0), 0),
+			COALESCE(SUM(balance) FILTER (WHERE balance > 0), 0)
+		FROM (
+			SELECT gl_category, currency, balance FROM balances
+			UNION ALL
+			SELECT gl_category, currency, balance FROM opening_equity
+		) b
+		GROUP BY gl_category, currency
+		ORDER BY currency, gl_category`
+
+	// Balances are the stored ones: each account's running balance after its last ledger
+	// entry at or before the cutoff $1, plus the entries posted after it whose transaction
+	// is effective on or before the business date $2, so a backdated posting counts on its
+	// effective date. Only opening entries come from the transactions table: they have no
+	// source account, so their debit side is reported as opening balance equity. A stored
+	// balance that has drifted from its postings then leaves the currency unbalanced.
+	// Archived months count through their totals once they have ended by $1; their
+	// transactions are gone, so a backdated posting in one counts from when it was posted.
+	querySumByCategory = `
+		WITH balances AS (
+			SELECT a.gl_category, a.currency, SUM(e.balance) AS balance
+			FROM (
+				SELECT account_id, balance
+				FROM (
+					SELECT DISTINCT ON (account_id) account_id, balance_after AS balance
+					FROM ledger_entries
+					WHERE created_at <= $1
+					ORDER BY account_id, entry_id DESC
+				) latest
+				UNION ALL
+				SELECT e.account_id, CASE WHEN e.direction = 'credit' THEN e.amount ELSE -e.amount END
+				FROM ledger_entries e
+				JOIN transactions t ON t.id = e.transaction_id AND t.created_at = e.created_at
+				WHERE e.created_at > $1 AND t.created_at > $1 AND t.effective_date <= $2
+			) e
+			JOIN accounts a ON a.account_id = e.account_id
+			GROUP BY a.account_id, a.gl_category, a.currency
+		), opening_equity AS (
+			SELECT 'equity' AS gl_category, a.currency, -SUM(o.amount) AS balance
+			FROM (
+				SELECT destination_account_id AS account_id, amount
+				FROM transactions
+				WHERE type = 'opening' AND (created_at <= $1 OR effective_date <= $2)
+				UNION ALL
+				SELECT t.account_id, t.credits
+				FROM transaction_archive_totals t
+				JOIN transaction_archives r ON r.month_start = t.month_start
+				WHERE t.type = 'opening' AND r.range_end <= $1
+			) o
+			JOIN accounts a ON a.account_id = o.account_id
+			GROUP BY a.currency
+		)` + sumBalancesByCategory
+
+	// As querySumByCategory, with the current accounts.balance of every account and every
+	// opening entry, read from the same snapshot
+	querySumCurrentByCategory = `
+		WITH balances AS (
+			SELECT gl_category, currency, balance
+			FROM accounts
+		), opening_equity AS (
+			SELECT 'equity' AS gl_category, a.currency, -SUM(o.amount) AS balance
+			FROM (
+				SELECT destination_account_id AS account_id, amount
+				FROM transactions
+				WHERE type = 'opening'
+				UNION ALL
+				SELECT account_id, credits
+				FROM transaction_archive_totals
+				WHERE type = 'opening'
+			) o
+			JOIN accounts a ON a.account_id = o.account_id
+			GROUP BY a.currency
+		)` + sumBalancesByCategory
+)
+
+// SumByCategory returns the debit and credit totals of every category and currency as of
+// the business date closing at asOf, from the running balances of the ledger entries
+// posted by asOf and the later ones effective on or before date
+func (r *Repository) SumByCategory(ctx context.Context, date, asOf time.Time) ([]*CategoryTotal, error) {
+	totals, err := r.sum(ctx, querySumByCategory, asOf, date)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToSumTrialBalance,
+			constants.LogFieldBusinessDate, date,
+			constants.LogFieldAsOf, asOf,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	return totals, nil
+}
+
+// SumCurrentByCategory returns the debit and credit totals of every category and currency,
+// from the balances currently stored on the accounts
+func (r *Repository) SumCurrentByCategory(ctx context.Context) ([]*CategoryTotal, error) {
+	totals, err := r.sum(ctx, querySumCurrentByCategory)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToSumTrialBalance,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	return totals, nil
+}
+
+// sum runs a trial balance query and scans its totals
+func (r *Repository) sum(ctx context.Context, query string, args ...any) ([]*CategoryTotal, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make([]*CategoryTotal, 0)
+	for rows.Next() {
+		var t CategoryTotal
+		if err := rows.Scan(&t.Category, &t.Currency, &t.Debit, &t.Credit); err != nil {
+			return nil, err
+		}
+		totals = append(totals, &t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,133 @@
+package ledger_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/ledger"
+	dbmock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in repository tests
+var errRepoQueryFailed = errors.New("query execution failed")
+
+// RepositoryTestSuite contains tests for ledger Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockPool *dbmock.MockIPool
+	mockRows *dbmock.MockRows
+	repo     ledger.IRepository
+	ctx      context.Context
+	date     time.Time
+	asOf     time.Time
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbmock.NewMockIPool(s.ctrl)
+	s.mockRows = dbmock.NewMockRows(s.ctrl)
+	s.ctx = context.Background()
+	s.date = time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	s.asOf = time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)
+	s.repo = ledger.NewRepository(s.mockPool)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// readsFrom matches a query that selects from table
+func readsFrom(table string) gomock.Matcher {
+	return gomock.Cond(func(query string) bool { return strings.Contains(query, "FROM "+table) })
+}
+
+func (s *RepositoryTestSuite) TestSumByCategoryScansTotals() {
+	s.mockPool.EXPECT().Query(s.ctx, readsFrom("ledger_entries"), s.asOf, s.date).Return(s.mockRows, nil).Times(1)
+	gomock.InOrder(
+		s.mockRows.EXPECT().Next().Return(true),
+		s.mockRows.EXPECT().
+			Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(dest ...any) error {
+				*dest[0].(*string) = "liability"
+				*dest[1].(*string) = "USD"
+				*dest[2].(*decimal.Decimal) = decimal.Zero
+				*dest[3].(*decimal.Decimal) = decimal.NewFromInt(250)
+				return nil
+			}),
+		s.mockRows.EXPECT().Next().Return(false),
+	)
+	s.mockRows.EXPECT().Err().Return(nil).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	totals, err := s.repo.SumByCategory(s.ctx, s.date, s.asOf)
+	s.NoError(err)
+	s.Require().Len(totals, 1)
+	s.Equal("liability", totals[0].Category)
+	s.True(totals[0].Credit.Equal(decimal.NewFromInt(250)))
+}
+
+func (s *RepositoryTestSuite) TestSumByCategoryCountsLaterPostingsByEffectiveDate() {
+	backdated := gomock.Cond(func(query string) bool {
+		return strings.Contains(query, "JOIN transactions t ON t.id = e.transaction_id") &&
+			strings.Contains(query, "e.created_at > $1") &&
+			strings.Contains(query, "t.effective_date <= $2") &&
+			strings.Contains(query, "effective_date <= $2)")
+	})
+	s.mockPool.EXPECT().Query(s.ctx, backdated, s.asOf, s.date).Return(s.mockRows, nil).Times(1)
+	s.mockRows.EXPECT().Next().Return(false).Times(1)
+	s.mockRows.EXPECT().Err().Return(nil).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	totals, err := s.repo.SumByCategory(s.ctx, s.date, s.asOf)
+	s.NoError(err)
+	s.Empty(totals)
+}
+
+func (s *RepositoryTestSuite) TestSumByCategoryWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), s.asOf, s.date).Return(nil, errRepoQueryFailed).Times(1)
+
+	totals, err := s.repo.SumByCategory(s.ctx, s.date, s.asOf)
+	s.Nil(totals)
+	s.Equal(errRepoQueryFailed, err)
+}
+
+func (s *RepositoryTestSuite) TestSumByCategoryWhenScanFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), s.asOf, s.date).Return(s.mockRows, nil).Times(1)
+	s.mockRows.EXPECT().Next().Return(true).Times(1)
+	s.mockRows.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errRepoQueryFailed).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	totals, err := s.repo.SumByCategory(s.ctx, s.date, s.asOf)
+	s.Nil(totals)
+	s.Equal(errRepoQueryFailed, err)
+}
+
+func (s *RepositoryTestSuite) TestSumCurrentByCategoryReadsStoredBalances() {
+	s.mockPool.EXPECT().Query(s.ctx, readsFrom("accounts")).Return(s.mockRows, nil).Times(1)
+	s.mockRows.EXPECT().Next().Return(false).Times(1)
+	s.mockRows.EXPECT().Err().Return(nil).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	totals, err := s.repo.SumCurrentByCategory(s.ctx)
+	s.NoError(err)
+	s.Empty(totals)
+}
+
+func (s *RepositoryTestSuite) TestSumCurrentByCategoryWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any()).Return(nil, errRepoQueryFailed).Times(1)
+
+	totals, err := s.repo.SumCurrentByCategory(s.ctx)
+	s.Nil(totals)
+	s.Equal(errRepoQueryFailed, err)
+}
@@ -0,0 +1,67 @@
+package ledger_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/modules/ledger"
+	"github.com/internal-transfers-service/internal/modules/ledger/entities"
+	"github.com/internal-transfers-service/internal/modules/ledger/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for ledger HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.router = chi.NewRouter()
+	ledger.NewHTTPHandler(s.mockCore).RegisterAdminRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestGetTrialBalancePassesDate() {
+	s.mockCore.EXPECT().
+		TrialBalance(gomock.Any(), "2026-03-10").
+		Return(&entities.TrialBalanceResponse{Date: "2026-03-10", Closed: true, Balanced: true}, nil).
+		Times(1)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/trial-balance?date=2026-03-10", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	var result entities.TrialBalanceResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &result))
+	s.Equal("2026-03-10", result.Date)
+	s.True(result.Balanced)
+}
+
+func (s *ServerTestSuite) TestGetTrialBalanceWithInvalidDateReturnsBadRequest() {
+	s.mockCore.EXPECT().
+		TrialBalance(gomock.Any(), "yesterday").
+		Return(nil, apperror.NewWithMessage(apperror.CodeBadRequest, ledger.ErrInvalidDate, apperror.MsgInvalidDate)).
+		Times(1)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/trial-balance?date=yesterday", nil))
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,232 @@
+package period
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/internal-transfers-service/internal/boot/app_context"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/period/entities"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/internal/modules/transaction"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/jackc/pgx/v5"
+)
+
+// Domain errors
+var (
+	ErrInvalidPeriod        = errors.New(entities.ErrMsgInvalidPeriod)
+	ErrPeriodNotEnded       = errors.New(entities.ErrMsgPeriodNotEnded)
+	ErrPeriodAlreadyClosed  = errors.New(entities.ErrMsgPeriodAlreadyClosed)
+	ErrPeriodClosed         = errors.New(entities.ErrMsgPeriodClosed)
+	ErrCorrectedPeriodOpen  = errors.New(entities.ErrMsgCorrectedPeriodOpen)
+	ErrCorrectedPeriodLater = errors.New(entities.ErrMsgCorrectedPeriodLater)
+)
+
+// ICore defines the interface for accounting period business logic. Periods are calendar
+// months of business dates; a closed period accepts no posting, so corrections post in an
+// open period and reference the closed one.
+type ICore interface {
+	ClosePeriod(ctx context.Context, period string) (*entities.PeriodResponse, apperror.IError)
+	GetPeriod(ctx context.Context, period string) (*entities.PeriodResponse, apperror.IError)
+	ListPeriods(ctx context.Context) (*entities.PeriodsResponse, apperror.IError)
+	Today() time.Time
+	CheckOpen(ctx context.Context, tx pgx.Tx, effectiveDate time.Time, correctsPeriod *time.Time) apperror.IError
+}
+
+// Core implements ICore
+type Core struct {
+	repo     IRepository
+	schedule *snapshot.Schedule
+	clock    clock.Clock
+}
+
+// Compile-time interface checks
+var (
+	_ ICore                    = (*Core)(nil)
+	_ transaction.IPeriodGuard = (*Core)(nil)
+)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance. Business dates follow the snapshot schedule, so a
+// period ends when the cutoff of its last day passes.
+func NewCore(_ context.Context, repo IRepository, schedule *snapshot.Schedule, clk clock.Clock) ICore {
+	coreInstance = &Core{
+		repo:     repo,
+		schedule: schedule,
+		clock:    clk,
+	}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// ClosePeriod closes an accounting period for good. Only periods whose last business date
+// has passed can be closed, and a period can be closed once.
+func (c *Core) ClosePeriod(ctx context.Context, period string) (*entities.PeriodResponse, apperror.IError) {
+	start, appErr := parsePeriod(period)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if nextPeriod(start).After(c.Today()) {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrPeriodNotEnded, apperror.MsgPeriodNotEnded).
+			WithField(apperror.FieldPeriod, period)
+	}
+
+	closedBy := app_context.GetActor(ctx)
+	if closedBy == "" {
+		closedBy = constants.ActorSystem
+	}
+
+	closed := &Period{Start: start, ClosedBy: closedBy}
+	if err := c.repo.Close(ctx, closed); err != nil {
+		return nil, toAppError(err, period)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgPeriodClosed,
+		constants.LogFieldPeriod, period,
+		constants.LogFieldActor, closedBy,
+	)
+	return toPeriodResponse(start, closed), nil
+}
+
+// GetPeriod returns the state of an accounting period
+func (c *Core) GetPeriod(ctx context.Context, period string) (*entities.PeriodResponse, apperror.IError) {
+	start, appErr := parsePeriod(period)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	closed, err := c.repo.Get(ctx, start)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).WithField(apperror.FieldPeriod, period)
+	}
+	return toPeriodResponse(start, closed), nil
+}
+
+// ListPeriods returns the closed periods, newest first, and the current period
+func (c *Core) ListPeriods(ctx context.Context) (*entities.PeriodsResponse, apperror.IError) {
+	periods, err := c.repo.List(ctx)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err)
+	}
+
+	response := &entities.PeriodsResponse{
+		CurrentPeriod: periodOf(c.Today()).Format(entities.PeriodLayout),
+		Periods:       make([]*entities.PeriodResponse, 0, len(periods)),
+	}
+	for _, p := range periods {
+		response.Periods = append(response.Periods, toPeriodResponse(p.Start, p))
+	}
+	return response, nil
+}
+
+// Today returns the current business date
+func (c *Core) Today() time.Time {
+	return c.schedule.Today(c.clock.Now())
+}
+
+// CheckOpen takes the period lock in shared mode for the rest of tx, then fails with
+// PERIOD_CLOSED if effectiveDate falls in a closed period. A correction must name a
+// closed period before the one it posts in.
+func (c *Core) CheckOpen(ctx context.Context, tx pgx.Tx, effectiveDate time.Time, correctsPeriod *time.Time) apperror.IError {
+	if err := c.repo.LockShared(ctx, tx); err != nil {
+		return apperror.New(apperror.CodeInternalError, err)
+	}
+
+	start := periodOf(effectiveDate)
+	closed, err := c.repo.IsClosed(ctx, tx, start)
+	if err != nil {
+		return apperror.New(apperror.CodeInternalError, err)
+	}
+	if closed {
+		logger.Ctx(ctx).Warnw(constants.LogMsgPostingInClosedPeriod,
+			constants.LogFieldEffectiveDate, effectiveDate.Format(entities.DateLayout),
+			constants.LogFieldPeriod, start.Format(entities.PeriodLayout),
+		)
+		return apperror.NewWithMessage(apperror.CodePeriodClosed, ErrPeriodClosed, apperror.MsgPeriodClosed).
+			WithField(apperror.FieldEffectiveDate, effectiveDate.Format(entities.DateLayout)).
+			WithField(apperror.FieldPeriod, start.Format(entities.PeriodLayout))
+	}
+
+	if correctsPeriod == nil {
+		return nil
+	}
+	return c.checkCorrectedPeriod(ctx, tx, start, periodOf(*correctsPeriod))
+}
+
+// checkCorrectedPeriod checks that the period a correction refers to is closed and
+// precedes the open period the correction posts in
+func (c *Core) checkCorrectedPeriod(ctx context.Context, tx pgx.Tx, postedIn, corrected time.Time) apperror.IError {
+	if !corrected.Before(postedIn) {
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrCorrectedPeriodLater, apperror.MsgInvalidCorrectedPeriod).
+			WithField(apperror.FieldCorrectsPeriod, corrected.Format(entities.PeriodLayout))
+	}
+
+	closed, err := c.repo.IsClosed(ctx, tx, corrected)
+	if err != nil {
+		return apperror.New(apperror.CodeInternalError, err)
+	}
+	if !closed {
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrCorrectedPeriodOpen, apperror.MsgInvalidCorrectedPeriod).
+			WithField(apperror.FieldCorrectsPeriod, corrected.Format(entities.PeriodLayout))
+	}
+	return nil
+}
+
+// parsePeriod parses a YYYY-MM period into its first day
+func parsePeriod(period string) (time.Time, apperror.IError) {
+	start, err := time.Parse(entities.PeriodLayout, period)
+	if err != nil {
+		return time.Time{}, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidPeriod, apperror.MsgInvalidAccountingPeriod).
+			WithField(apperror.FieldPeriod, period)
+	}
+	return start, nil
+}
+
+// periodOf returns the first day of the period containing date
+func periodOf(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// nextPeriod returns the first day of the period after the one starting on start
+func nextPeriod(start time.Time) time.Time {
+	return start.AddDate(0, 1, 0)
+}
+
+// toAppError passes apperrors from the repository through and wraps anything else as internal
+func toAppError(err error, period string) apperror.IError {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return apperror.New(apperror.CodeInternalError, err).WithField(apperror.FieldPeriod, period)
+}
+
+// toPeriodResponse converts a period to its wire form; closed is nil while the period is open
+func toPeriodResponse(start time.Time, closed *Period) *entities.PeriodResponse {
+	response := &entities.PeriodResponse{
+		Period: start.Format(entities.PeriodLayout),
+		Start:  start.Format(entities.DateLayout),
+		End:    nextPeriod(start).AddDate(0, 0, -1).Format(entities.DateLayout),
+		Status: entities.StatusOpen,
+	}
+	if closed != nil {
+		closedAt := closed.ClosedAt.UTC().Format(time.RFC3339)
+		response.Status = entities.StatusClosed
+		response.ClosedAt = &closedAt
+		response.ClosedBy = &closed.ClosedBy
+	}
+	return response
+}
@@ -0,0 +1,207 @@
+package period_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/boot/app_context"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/modules/period"
+	"github.com/internal-transfers-service/internal/modules/period/entities"
+	"github.com/internal-transfers-service/internal/modules/period/mock"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/clock"
+	dbMock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// CoreTestSuite contains tests for period Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockRepo *mock.MockIRepository
+	mockTx   *dbMock.MockTx
+	clock    *clock.Fake
+	core     period.ICore
+	ctx      context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.mockTx = dbMock.NewMockTx(s.ctrl)
+	s.clock = clock.NewFake(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC))
+	s.ctx = context.Background()
+
+	schedule, err := snapshot.NewSchedule("24:00", "UTC", 0)
+	s.Require().NoError(err)
+	s.core = period.NewCore(s.ctx, s.mockRepo, schedule, s.clock)
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// date returns midnight UTC of a day in 2026
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Test ClosePeriod
+
+func (s *CoreTestSuite) TestClosePeriodRecordsActor() {
+	ctx := app_context.SetActor(s.ctx, "controller")
+	s.mockRepo.EXPECT().
+		Close(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, p *period.Period) error {
+			s.Equal(date(time.February, 1), p.Start)
+			s.Equal("controller", p.ClosedBy)
+			p.ClosedAt = time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+			return nil
+		}).
+		Times(1)
+
+	response, err := s.core.ClosePeriod(ctx, "2026-02")
+	s.Nil(err)
+	s.Equal(entities.StatusClosed, response.Status)
+	s.Equal("2026-02-01", response.Start)
+	s.Equal("2026-02-28", response.End)
+	s.Require().NotNil(response.ClosedBy)
+	s.Equal("controller", *response.ClosedBy)
+	s.Require().NotNil(response.ClosedAt)
+	s.Equal("2026-03-15T12:00:00Z", *response.ClosedAt)
+}
+
+func (s *CoreTestSuite) TestClosePeriodWithoutActorIsAttributedToSystem() {
+	s.mockRepo.EXPECT().
+		Close(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, p *period.Period) error {
+			s.Equal(constants.ActorSystem, p.ClosedBy)
+			return nil
+		}).
+		Times(1)
+
+	_, err := s.core.ClosePeriod(s.ctx, "2026-02")
+	s.Nil(err)
+}
+
+func (s *CoreTestSuite) TestCloseCurrentPeriodReturnsBadRequest() {
+	response, err := s.core.ClosePeriod(s.ctx, "2026-03")
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.ErrorIs(err, period.ErrPeriodNotEnded)
+}
+
+func (s *CoreTestSuite) TestClosePeriodOnItsLastDayReturnsBadRequest() {
+	s.clock.Set(time.Date(2026, 2, 28, 23, 0, 0, 0, time.UTC))
+
+	_, err := s.core.ClosePeriod(s.ctx, "2026-02")
+	s.Require().NotNil(err)
+	s.ErrorIs(err, period.ErrPeriodNotEnded)
+}
+
+func (s *CoreTestSuite) TestClosePeriodWithInvalidPeriodReturnsBadRequest() {
+	_, err := s.core.ClosePeriod(s.ctx, "2026-2")
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidAccountingPeriod, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestClosePeriodTwiceReturnsConflict() {
+	conflict := apperror.NewWithMessage(apperror.CodeConflict, period.ErrPeriodAlreadyClosed, apperror.MsgPeriodAlreadyClosed)
+	s.mockRepo.EXPECT().Close(s.ctx, gomock.Any()).Return(conflict).Times(1)
+
+	_, err := s.core.ClosePeriod(s.ctx, "2026-02")
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeConflict, err.Code())
+}
+
+// Test GetPeriod and ListPeriods
+
+func (s *CoreTestSuite) TestGetOpenPeriodReportsOpen() {
+	s.mockRepo.EXPECT().Get(s.ctx, date(time.March, 1)).Return(nil, nil).Times(1)
+
+	response, err := s.core.GetPeriod(s.ctx, "2026-03")
+	s.Nil(err)
+	s.Equal(entities.StatusOpen, response.Status)
+	s.Equal("2026-03-31", response.End)
+	s.Nil(response.ClosedAt)
+}
+
+func (s *CoreTestSuite) TestListPeriodsIncludesCurrentPeriod() {
+	s.mockRepo.EXPECT().
+		List(s.ctx).
+		Return([]*period.Period{{Start: date(time.February, 1), ClosedBy: "controller"}}, nil).
+		Times(1)
+
+	response, err := s.core.ListPeriods(s.ctx)
+	s.Nil(err)
+	s.Equal("2026-03", response.CurrentPeriod)
+	s.Require().Len(response.Periods, 1)
+	s.Equal("2026-02", response.Periods[0].Period)
+	s.Equal(entities.StatusClosed, response.Periods[0].Status)
+}
+
+// Test CheckOpen
+
+func (s *CoreTestSuite) TestCheckOpenInOpenPeriodPasses() {
+	gomock.InOrder(
+		s.mockRepo.EXPECT().LockShared(s.ctx, s.mockTx).Return(nil),
+		s.mockRepo.EXPECT().IsClosed(s.ctx, s.mockTx, date(time.March, 1)).Return(false, nil),
+	)
+
+	s.Nil(s.core.CheckOpen(s.ctx, s.mockTx, date(time.March, 10), nil))
+}
+
+func (s *CoreTestSuite) TestCheckOpenInClosedPeriodReturnsPeriodClosed() {
+	s.mockRepo.EXPECT().LockShared(s.ctx, s.mockTx).Return(nil).Times(1)
+	s.mockRepo.EXPECT().IsClosed(s.ctx, s.mockTx, date(time.February, 1)).Return(true, nil).Times(1)
+
+	err := s.core.CheckOpen(s.ctx, s.mockTx, date(time.February, 27), nil)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodePeriodClosed, err.Code())
+	s.Equal("2026-02", err.Fields()[apperror.FieldPeriod])
+}
+
+func (s *CoreTestSuite) TestCheckOpenWithCorrectionOfClosedPeriodPasses() {
+	corrected := date(time.January, 1)
+	s.mockRepo.EXPECT().LockShared(s.ctx, s.mockTx).Return(nil).Times(1)
+	s.mockRepo.EXPECT().IsClosed(s.ctx, s.mockTx, date(time.March, 1)).Return(false, nil).Times(1)
+	s.mockRepo.EXPECT().IsClosed(s.ctx, s.mockTx, corrected).Return(true, nil).Times(1)
+
+	s.Nil(s.core.CheckOpen(s.ctx, s.mockTx, date(time.March, 10), &corrected))
+}
+
+func (s *CoreTestSuite) TestCheckOpenWithCorrectionOfOpenPeriodReturnsBadRequest() {
+	corrected := date(time.February, 1)
+	s.mockRepo.EXPECT().LockShared(s.ctx, s.mockTx).Return(nil).Times(1)
+	s.mockRepo.EXPECT().IsClosed(s.ctx, s.mockTx, date(time.March, 1)).Return(false, nil).Times(1)
+	s.mockRepo.EXPECT().IsClosed(s.ctx, s.mockTx, corrected).Return(false, nil).Times(1)
+
+	err := s.core.CheckOpen(s.ctx, s.mockTx, date(time.March, 10), &corrected)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.ErrorIs(err, period.ErrCorrectedPeriodOpen)
+}
+
+func (s *CoreTestSuite) TestCheckOpenWithCorrectionOfSamePeriodReturnsBadRequest() {
+	corrected := date(time.March, 1)
+	s.mockRepo.EXPECT().LockShared(s.ctx, s.mockTx).Return(nil).Times(1)
+	s.mockRepo.EXPECT().IsClosed(s.ctx, s.mockTx, date(time.March, 1)).Return(false, nil).Times(1)
+
+	err := s.core.CheckOpen(s.ctx, s.mockTx, date(time.March, 10), &corrected)
+	s.Require().NotNil(err)
+	s.ErrorIs(err, period.ErrCorrectedPeriodLater)
+}
+
+func (s *CoreTestSuite) TestTodayFollowsSchedule() {
+	s.Equal(date(time.March, 15), s.core.Today())
+}
@@ -0,0 +1,37 @@
+// Package entities provides response types and constants for the period module.
+package entities
+
+// Error messages for the period module
+const (
+	ErrMsgInvalidPeriod        = "invalid accounting period, expected YYYY-MM"
+	ErrMsgPeriodNotEnded       = "accounting period has not ended"
+	ErrMsgPeriodAlreadyClosed  = "accounting period already closed"
+	ErrMsgPeriodClosed         = "effective date falls in a closed accounting period"
+	ErrMsgCorrectedPeriodOpen  = "corrected accounting period is not closed"
+	ErrMsgCorrectedPeriodLater = "corrected accounting period does not precede the effective date"
+)
+
+// Route path constants for the period module (admin router)
+const (
+	RoutePeriods     = "/accounting-periods"
+	RoutePeriod      = "/accounting-periods/{period}"
+	RouteClosePeriod = "/accounting-periods/{period}/close"
+	ParamPeriod      = "period"
+)
+
+// Period states
+const (
+	StatusOpen   = "open"
+	StatusClosed = "closed"
+)
+
+// Wire formats of accounting periods (calendar months) and business dates
+const (
+	PeriodLayout = "2006-01"
+	DateLayout   = "2006-01-02"
+)
+
+// LockKey guards period closes. Closing takes it exclusively and every posting takes it
+// shared (pg_advisory_xact_lock_shared), so a posting either commits before the close or
+// sees the closed period.
+const LockKey int64 = 0x706572696f647321 // "periods!"
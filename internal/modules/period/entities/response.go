@@ -0,0 +1,19 @@
+package entities
+
+// PeriodResponse is an accounting period (a calendar month) and whether it is closed.
+// Start and End are the first and last business dates of the period.
+type PeriodResponse struct {
+	Period   string  `json:"period"`
+	Start    string  `json:"start"`
+	End      string  `json:"end"`
+	Status   string  `json:"status"`
+	ClosedAt *string `json:"closed_at,omitempty"`
+	ClosedBy *string `json:"closed_by,omitempty"`
+}
+
+// PeriodsResponse lists the closed accounting periods, newest first, with the period that
+// today's business date falls in
+type PeriodsResponse struct {
+	CurrentPeriod string            `json:"current_period"`
+	Periods       []*PeriodResponse `json:"periods"`
+}
@@ -0,0 +1,64 @@
+// Package period closes accounting periods and keeps postings out of closed ones.
+package period
+
+import (
+	"context"
+
+	"github.com/internal-transfers-service/internal/modules/audit"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var PeriodModule IModule
+
+// NewModule initializes the accounting period module
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool, snapshotModule snapshot.IModule, recorder audit.IRecorder) IModule {
+	if PeriodModule == nil {
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper, recorder)
+		core := NewCore(ctx, repo, snapshotModule.GetSchedule(), clock.Real{})
+		handler := NewHTTPHandler(core)
+
+		PeriodModule = &Module{
+			Core:    core,
+			Handler: handler,
+			Repo:    repo,
+		}
+	}
+	return PeriodModule
+}
+
+// IModule defines the interface for the accounting period module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+}
+
+// Module implements IModule
+type Module struct {
+	Core    ICore
+	Handler *HTTPHandler
+	Repo    IRepository
+}
+
+// Compile-time interface check
+var _ IModule = (*Module)(nil)
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
@@ -0,0 +1,178 @@
+package period
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/audit"
+	auditentities "github.com/internal-transfers-service/internal/modules/audit/entities"
+	"github.com/internal-transfers-service/internal/modules/period/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// Period is a closed accounting period. Start is the first day of the calendar month;
+// months without a row are open.
+type Period struct {
+	Start    time.Time `json:"period_start"`
+	ClosedAt time.Time `json:"closed_at"`
+	ClosedBy string    `json:"closed_by"`
+}
+
+// IRepository defines the interface for accounting period data access
+type IRepository interface {
+	Close(ctx context.Context, period *Period) error
+	Get(ctx context.Context, start time.Time) (*Period, error)
+	List(ctx context.Context) ([]*Period, error)
+	LockShared(ctx context.Context, tx pgx.Tx) error
+	IsClosed(ctx context.Context, tx pgx.Tx, start time.Time) (bool, error)
+}
+
+// Repository implements IRepository. Closes are recorded in the audit log within the
+// same transaction.
+type Repository struct {
+	pool  database.IPool
+	audit audit.IRecorder
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new accounting period repository
+func NewRepository(pool database.IPool, recorder audit.IRecorder) *Repository {
+	return &Repository{pool: pool, audit: recorder}
+}
+
+// SQL queries
+const (
+	queryLockExclusive = `
+		SELECT pg_advisory_xact_lock($1)`
+
+	queryLockShared = `
+		SELECT pg_advisory_xact_lock_shared($1)`
+
+	// Returns no row when the period is already closed
+	queryInsertPeriod = `
+		INSERT INTO accounting_periods (period_start, closed_by)
+		VALUES ($1, $2)
+		ON CONFLICT (period_start) DO NOTHING
+		RETURNING closed_at`
+
+	querySelectPeriod = `
+		SELECT period_start, closed_at, closed_by
+		FROM accounting_periods
+		WHERE period_start = $1`
+
+	querySelectPeriods = `
+		SELECT period_start, closed_at, closed_by
+		FROM accounting_periods
+		ORDER BY period_start DESC`
+
+	queryIsClosed = `
+		SELECT EXISTS(SELECT 1 FROM accounting_periods WHERE period_start = $1)`
+)
+
+// Close records a period as closed and sets its closing time. It waits for postings that
+// hold the period lock to commit first. Returns a CONFLICT apperror if the period is
+// already closed.
+func (r *Repository) Close(ctx context.Context, period *Period) error {
+	return database.InTx(ctx, r.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, queryLockExclusive, entities.LockKey); err != nil {
+			r.logCloseFailure(ctx, period.Start, err)
+			return err
+		}
+
+		err := tx.QueryRow(ctx, queryInsertPeriod, period.Start, period.ClosedBy).Scan(&period.ClosedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperror.NewWithMessage(apperror.CodeConflict, ErrPeriodAlreadyClosed, apperror.MsgPeriodAlreadyClosed).
+					WithField(apperror.FieldPeriod, period.Start.Format(entities.PeriodLayout))
+			}
+			r.logCloseFailure(ctx, period.Start, err)
+			return err
+		}
+
+		return r.audit.Record(ctx, tx, audit.NewEvent(ctx, auditentities.ActionPeriodClosed, auditentities.EntityPeriod,
+			period.Start.Format(entities.PeriodLayout), nil, period))
+	})
+}
+
+// Get returns the period starting on start, or nil if it is open
+func (r *Repository) Get(ctx context.Context, start time.Time) (*Period, error) {
+	var period Period
+	err := r.pool.QueryRow(ctx, querySelectPeriod, start).Scan(&period.Start, &period.ClosedAt, &period.ClosedBy)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		r.logReadFailure(ctx, err)
+		return nil, err
+	}
+	return &period, nil
+}
+
+// List returns the closed periods, newest first
+func (r *Repository) List(ctx context.Context) ([]*Period, error) {
+	rows, err := r.pool.Query(ctx, querySelectPeriods)
+	if err != nil {
+		r.logReadFailure(ctx, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := make([]*Period, 0)
+	for rows.Next() {
+		var period Period
+		if err := rows.Scan(&period.Start, &period.ClosedAt, &period.ClosedBy); err != nil {
+			return nil, err
+		}
+		periods = append(periods, &period)
+	}
+	if err := rows.Err(); err != nil {
+		r.logReadFailure(ctx, err)
+		return nil, err
+	}
+	return periods, nil
+}
+
+// LockShared takes the period lock in shared mode for the lifetime of tx, so that no
+// period can close until tx ends
+func (r *Repository) LockShared(ctx context.Context, tx pgx.Tx) error {
+	if _, err := tx.Exec(ctx, queryLockShared, entities.LockKey); err != nil {
+		r.logReadFailure(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// IsClosed reports whether the period starting on start is closed. Under READ COMMITTED
+// it sees every close committed before the statement runs.
+func (r *Repository) IsClosed(ctx context.Context, tx pgx.Tx, start time.Time) (bool, error) {
+	var closed bool
+	if err := tx.QueryRow(ctx, queryIsClosed, start).Scan(&closed); err != nil {
+		r.logReadFailure(ctx, err)
+		return false, err
+	}
+	return closed, nil
+}
+
+// logCloseFailure logs a failure to close a period
+func (r *Repository) logCloseFailure(ctx context.Context, start time.Time, err error) {
+	logger.Ctx(ctx).Errorw(constants.LogMsgFailedToClosePeriod,
+		constants.LogFieldPeriod, start.Format(entities.PeriodLayout),
+		constants.LogKeyError, err,
+	)
+}
+
+// logReadFailure logs a failure to read periods
+func (r *Repository) logReadFailure(ctx context.Context, err error) {
+	logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadPeriods,
+		constants.LogKeyError, err,
+	)
+}
@@ -0,0 +1,201 @@
+package period_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/audit"
+	auditentities "github.com/internal-transfers-service/internal/modules/audit/entities"
+	auditMock "github.com/internal-transfers-service/internal/modules/audit/mock"
+	"github.com/internal-transfers-service/internal/modules/period"
+	"github.com/internal-transfers-service/internal/modules/period/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+	dbMock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in repository tests
+var errRepoQueryFailed = errors.New("query execution failed")
+
+// testPeriodStart is the first day of the period used throughout the tests
+var testPeriodStart = time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+// RepositoryTestSuite contains tests for period Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	mockPool  *dbMock.MockIPool
+	mockRow   *dbMock.MockRow
+	mockRows  *dbMock.MockRows
+	mockTx    *dbMock.MockTx
+	mockAudit *auditMock.MockIRecorder
+	repo      period.IRepository
+	ctx       context.Context
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbMock.NewMockIPool(s.ctrl)
+	s.mockRow = dbMock.NewMockRow(s.ctrl)
+	s.mockRows = dbMock.NewMockRows(s.ctrl)
+	s.mockTx = dbMock.NewMockTx(s.ctrl)
+	s.mockAudit = auditMock.NewMockIRecorder(s.ctrl)
+	s.ctx = context.Background()
+	s.repo = period.NewRepository(s.mockPool, s.mockAudit)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// expectLockedTx expects the repository to open its own transaction and take the period
+// lock exclusively
+func (s *RepositoryTestSuite) expectLockedTx() {
+	s.mockPool.EXPECT().BeginTx(s.ctx, gomock.Any()).Return(s.mockTx, nil).Times(1)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil).AnyTimes()
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), entities.LockKey).
+		Return(pgconn.NewCommandTag("SELECT 1"), nil).
+		Times(1)
+}
+
+// Test Close
+
+func (s *RepositoryTestSuite) TestCloseInsertsPeriodAndRecordsAudit() {
+	closedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	p := &period.Period{Start: testPeriodStart, ClosedBy: "controller"}
+
+	s.expectLockedTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), testPeriodStart, "controller").Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*time.Time) = closedAt
+			return nil
+		}).
+		Times(1)
+	s.mockAudit.EXPECT().
+		Record(s.ctx, s.mockTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, events ...*audit.Event) error {
+			s.Require().Len(events, 1)
+			s.Equal(auditentities.ActionPeriodClosed, events[0].Action)
+			s.Equal(auditentities.EntityPeriod, events[0].EntityType)
+			s.Equal("2026-02", events[0].EntityID)
+			return nil
+		}).
+		Times(1)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+
+	s.NoError(s.repo.Close(s.ctx, p))
+	s.Equal(closedAt, p.ClosedAt)
+}
+
+func (s *RepositoryTestSuite) TestCloseOfClosedPeriodReturnsConflict() {
+	s.expectLockedTx()
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows).Times(1)
+
+	err := s.repo.Close(s.ctx, &period.Period{Start: testPeriodStart, ClosedBy: "controller"})
+
+	var appErr *apperror.Error
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(apperror.CodeConflict, appErr.Code())
+	s.Equal(apperror.MsgPeriodAlreadyClosed, appErr.PublicMessage())
+}
+
+func (s *RepositoryTestSuite) TestCloseWhenLockFailsReturnsError() {
+	s.mockPool.EXPECT().BeginTx(s.ctx, gomock.Any()).Return(s.mockTx, nil).Times(1)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil).AnyTimes()
+	s.mockTx.EXPECT().Exec(s.ctx, gomock.Any(), entities.LockKey).Return(pgconn.CommandTag{}, errRepoQueryFailed).Times(1)
+
+	err := s.repo.Close(s.ctx, &period.Period{Start: testPeriodStart, ClosedBy: "controller"})
+	s.ErrorIs(err, errRepoQueryFailed)
+}
+
+// Test Get
+
+func (s *RepositoryTestSuite) TestGetOfOpenPeriodReturnsNil() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any(), testPeriodStart).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).Return(pgx.ErrNoRows).Times(1)
+
+	p, err := s.repo.Get(s.ctx, testPeriodStart)
+	s.NoError(err)
+	s.Nil(p)
+}
+
+func (s *RepositoryTestSuite) TestGetWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any(), testPeriodStart).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).Return(errRepoQueryFailed).Times(1)
+
+	p, err := s.repo.Get(s.ctx, testPeriodStart)
+	s.ErrorIs(err, errRepoQueryFailed)
+	s.Nil(p)
+}
+
+// Test List
+
+func (s *RepositoryTestSuite) TestListScansPeriods() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any()).Return(s.mockRows, nil).Times(1)
+	gomock.InOrder(
+		s.mockRows.EXPECT().Next().Return(true),
+		s.mockRows.EXPECT().
+			Scan(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(dest ...any) error {
+				*dest[0].(*time.Time) = testPeriodStart
+				*dest[2].(*string) = "controller"
+				return nil
+			}),
+		s.mockRows.EXPECT().Next().Return(false),
+	)
+	s.mockRows.EXPECT().Err().Return(nil).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	periods, err := s.repo.List(s.ctx)
+	s.NoError(err)
+	s.Require().Len(periods, 1)
+	s.Equal(testPeriodStart, periods[0].Start)
+	s.Equal("controller", periods[0].ClosedBy)
+}
+
+func (s *RepositoryTestSuite) TestListWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any()).Return(nil, errRepoQueryFailed).Times(1)
+
+	periods, err := s.repo.List(s.ctx)
+	s.ErrorIs(err, errRepoQueryFailed)
+	s.Nil(periods)
+}
+
+// Test LockShared and IsClosed
+
+func (s *RepositoryTestSuite) TestLockSharedTakesAdvisoryLock() {
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), entities.LockKey).
+		Return(pgconn.NewCommandTag("SELECT 1"), nil).
+		Times(1)
+
+	s.NoError(s.repo.LockShared(s.ctx, s.mockTx))
+}
+
+func (s *RepositoryTestSuite) TestIsClosedScansFlag() {
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), testPeriodStart).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*bool) = true
+			return nil
+		}).
+		Times(1)
+
+	closed, err := s.repo.IsClosed(s.ctx, s.mockTx, testPeriodStart)
+	s.NoError(err)
+	s.True(closed)
+}
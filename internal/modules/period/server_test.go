@@ -0,0 +1,96 @@
+package period_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/modules/period"
+	"github.com/internal-transfers-service/internal/modules/period/entities"
+	"github.com/internal-transfers-service/internal/modules/period/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for period HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.router = chi.NewRouter()
+	period.NewHTTPHandler(s.mockCore).RegisterAdminRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestListPeriodsReturnsPeriods() {
+	s.mockCore.EXPECT().
+		ListPeriods(gomock.Any()).
+		Return(&entities.PeriodsResponse{CurrentPeriod: "2026-03", Periods: []*entities.PeriodResponse{}}, nil).
+		Times(1)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/accounting-periods", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	var result entities.PeriodsResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &result))
+	s.Equal("2026-03", result.CurrentPeriod)
+}
+
+func (s *ServerTestSuite) TestGetPeriodPassesPeriod() {
+	s.mockCore.EXPECT().
+		GetPeriod(gomock.Any(), "2026-02").
+		Return(&entities.PeriodResponse{Period: "2026-02", Status: entities.StatusOpen}, nil).
+		Times(1)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/accounting-periods/2026-02", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	var result entities.PeriodResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &result))
+	s.Equal(entities.StatusOpen, result.Status)
+}
+
+func (s *ServerTestSuite) TestClosePeriodReturnsClosedPeriod() {
+	s.mockCore.EXPECT().
+		ClosePeriod(gomock.Any(), "2026-02").
+		Return(&entities.PeriodResponse{Period: "2026-02", Status: entities.StatusClosed}, nil).
+		Times(1)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/accounting-periods/2026-02/close", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	var result entities.PeriodResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &result))
+	s.Equal(entities.StatusClosed, result.Status)
+}
+
+func (s *ServerTestSuite) TestClosePeriodTwiceReturnsConflict() {
+	s.mockCore.EXPECT().
+		ClosePeriod(gomock.Any(), "2026-02").
+		Return(nil, apperror.NewWithMessage(apperror.CodeConflict, period.ErrPeriodAlreadyClosed, apperror.MsgPeriodAlreadyClosed)).
+		Times(1)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/accounting-periods/2026-02/close", nil))
+
+	s.Equal(http.StatusConflict, rec.Code)
+}
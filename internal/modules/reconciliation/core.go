@@ -0,0 +1,152 @@
+package reconciliation
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/metrics"
+	"github.com/internal-transfers-service/internal/modules/reconciliation/entities"
+	"github.com/internal-transfers-service/pkg/clock"
+)
+
+// Domain errors
+var (
+	ErrRunInProgress = errors.New(entities.ErrMsgRunInProgress)
+	ErrNoReport      = errors.New(entities.ErrMsgNoReport)
+)
+
+// ICore defines the interface for balance reconciliation
+type ICore interface {
+	Run(ctx context.Context) (*entities.ReportResponse, error)
+	Latest() (*entities.ReportResponse, error)
+}
+
+// Core implements ICore
+type Core struct {
+	repo      IRepository
+	clock     clock.Clock
+	batchSize int
+
+	// running is held for the duration of a run so scheduled and on-demand runs never overlap
+	running sync.Mutex
+
+	mu     sync.RWMutex
+	latest *entities.ReportResponse
+}
+
+// Compile-time interface check
+var _ ICore = (*Core)(nil)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance
+func NewCore(_ context.Context, repo IRepository, clk clock.Clock, batchSize int) ICore {
+	coreInstance = &Core{
+		repo:      repo,
+		clock:     clk,
+		batchSize: max(batchSize, 1),
+	}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// Run streams over every account in ID order, recomputing its balance from its
+// transactions and ledger entries and comparing it with accounts.balance. Every
+// drifted account is logged; the report lists the first MaxReportedDrifts of them.
+// The ledger_drift_accounts gauge is only updated when the run completes.
+func (c *Core) Run(ctx context.Context) (*entities.ReportResponse, error) {
+	if !c.running.TryLock() {
+		return nil, ErrRunInProgress
+	}
+	defer c.running.Unlock()
+
+	report := &entities.ReportResponse{
+		StartedAt: c.clock.Now().UTC().Format(time.RFC3339),
+		Drifts:    make([]*entities.AccountDrift, 0),
+	}
+
+	var after int64
+	for {
+		balances, err := c.repo.ListBalances(ctx, after, c.batchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, b := range balances {
+			c.check(ctx, report, b)
+		}
+		report.AccountsScanned += len(balances)
+
+		if len(balances) < c.batchSize {
+			break
+		}
+		after = balances[len(balances)-1].AccountID
+	}
+
+	report.FinishedAt = c.clock.Now().UTC().Format(time.RFC3339)
+	c.publish(ctx, report)
+	return report, nil
+}
+
+// Latest returns the report of the last completed run
+func (c *Core) Latest() (*entities.ReportResponse, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.latest == nil {
+		return nil, ErrNoReport
+	}
+	return c.latest, nil
+}
+
+// check records the account in the report if its balance drifted
+func (c *Core) check(ctx context.Context, report *entities.ReportResponse, b *AccountBalance) {
+	if !b.Drifted() {
+		return
+	}
+
+	report.DriftedAccounts++
+	logger.Ctx(ctx).Errorw(constants.LogMsgBalanceDriftDetected,
+		constants.LogKeyAccountID, b.AccountID,
+		constants.LogFieldCurrentBalance, b.Balance.String(),
+		constants.LogFieldComputedBal, b.Computed.String(),
+		constants.LogFieldLedgerBal, b.Ledger.String(),
+	)
+
+	if len(report.Drifts) >= entities.MaxReportedDrifts {
+		report.Truncated = true
+		return
+	}
+	report.Drifts = append(report.Drifts, &entities.AccountDrift{
+		AccountID:       b.AccountID,
+		Balance:         b.Balance.String(),
+		ComputedBalance: b.Computed.String(),
+		LedgerBalance:   b.Ledger.String(),
+		Difference:      b.Balance.Sub(b.Computed).String(),
+	})
+}
+
+// publish exports a completed run's drift count and keeps the report for Latest
+func (c *Core) publish(ctx context.Context, report *entities.ReportResponse) {
+	metrics.SetLedgerDriftAccounts(report.DriftedAccounts)
+
+	c.mu.Lock()
+	c.latest = report
+	c.mu.Unlock()
+
+	logger.Ctx(ctx).Infow(constants.LogMsgReconcileCompleted,
+		constants.LogFieldScannedCount, report.AccountsScanned,
+		constants.LogFieldDriftCount, report.DriftedAccounts,
+	)
+}
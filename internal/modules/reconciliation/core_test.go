@@ -0,0 +1,167 @@
+package reconciliation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/metrics"
+	"github.com/internal-transfers-service/internal/modules/reconciliation"
+	"github.com/internal-transfers-service/internal/modules/reconciliation/entities"
+	"github.com/internal-transfers-service/internal/modules/reconciliation/mock"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in tests
+var errDatabaseError = errors.New("database error")
+
+// testBatchSize keeps batches small so tests exercise paging
+const testBatchSize = 2
+
+// CoreTestSuite contains tests for reconciliation Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockRepo *mock.MockIRepository
+	core     reconciliation.ICore
+	ctx      context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.ctx = context.Background()
+	s.core = reconciliation.NewCore(s.ctx, s.mockRepo, clock.NewFake(time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)), testBatchSize)
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// balance builds an account balance row from decimal strings
+func balance(accountID int64, stored, computed, ledger string) *reconciliation.AccountBalance {
+	return &reconciliation.AccountBalance{
+		AccountID: accountID,
+		Balance:   decimal.RequireFromString(stored),
+		Computed:  decimal.RequireFromString(computed),
+		Ledger:    decimal.RequireFromString(ledger),
+	}
+}
+
+// Test Run
+
+func (s *CoreTestSuite) TestRunPagesThroughAccountsUntilShortBatch() {
+	gomock.InOrder(
+		s.mockRepo.EXPECT().ListBalances(s.ctx, int64(0), testBatchSize).
+			Return([]*reconciliation.AccountBalance{balance(1, "10", "10", "10"), balance(2, "5", "5", "5")}, nil),
+		s.mockRepo.EXPECT().ListBalances(s.ctx, int64(2), testBatchSize).
+			Return([]*reconciliation.AccountBalance{balance(7, "0", "0", "0")}, nil),
+	)
+
+	report, err := s.core.Run(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(3, report.AccountsScanned)
+	s.Zero(report.DriftedAccounts)
+	s.Empty(report.Drifts)
+	s.Equal("2026-03-10T12:00:00Z", report.StartedAt)
+	s.Equal(float64(0), testutil.ToFloat64(metrics.LedgerDriftAccounts))
+}
+
+func (s *CoreTestSuite) TestRunStopsAfterEmptyBatch() {
+	gomock.InOrder(
+		s.mockRepo.EXPECT().ListBalances(s.ctx, int64(0), testBatchSize).
+			Return([]*reconciliation.AccountBalance{balance(1, "10", "10", "10"), balance(2, "5", "5", "5")}, nil),
+		s.mockRepo.EXPECT().ListBalances(s.ctx, int64(2), testBatchSize).
+			Return([]*reconciliation.AccountBalance{}, nil),
+	)
+
+	report, err := s.core.Run(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(2, report.AccountsScanned)
+}
+
+func (s *CoreTestSuite) TestRunReportsBalanceThatDisagreesWithTransactions() {
+	s.mockRepo.EXPECT().ListBalances(s.ctx, int64(0), testBatchSize).
+		Return([]*reconciliation.AccountBalance{balance(1, "110", "100", "100")}, nil)
+
+	report, err := s.core.Run(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(1, report.DriftedAccounts)
+	s.Require().Len(report.Drifts, 1)
+	s.Equal(&entities.AccountDrift{
+		AccountID:       1,
+		Balance:         "110",
+		ComputedBalance: "100",
+		LedgerBalance:   "100",
+		Difference:      "10",
+	}, report.Drifts[0])
+	s.Equal(float64(1), testutil.ToFloat64(metrics.LedgerDriftAccounts))
+}
+
+func (s *CoreTestSuite) TestRunReportsBalanceThatDisagreesWithLedgerOnly() {
+	s.mockRepo.EXPECT().ListBalances(s.ctx, int64(0), testBatchSize).
+		Return([]*reconciliation.AccountBalance{balance(1, "100", "100", "90")}, nil)
+
+	report, err := s.core.Run(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(1, report.DriftedAccounts)
+	s.Equal("0", report.Drifts[0].Difference)
+	s.Equal("90", report.Drifts[0].LedgerBalance)
+}
+
+func (s *CoreTestSuite) TestRunWhenRepositoryFailsReturnsErrorAndKeepsPreviousReport() {
+	s.mockRepo.EXPECT().ListBalances(s.ctx, int64(0), testBatchSize).
+		Return([]*reconciliation.AccountBalance{balance(1, "1", "1", "1")}, nil)
+	_, err := s.core.Run(s.ctx)
+	s.Require().NoError(err)
+
+	s.mockRepo.EXPECT().ListBalances(s.ctx, int64(0), testBatchSize).Return(nil, errDatabaseError)
+	report, err := s.core.Run(s.ctx)
+	s.Equal(errDatabaseError, err)
+	s.Nil(report)
+
+	latest, err := s.core.Latest()
+	s.Require().NoError(err)
+	s.Equal(1, latest.AccountsScanned)
+}
+
+func (s *CoreTestSuite) TestRunWhileRunningReturnsRunInProgress() {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s.mockRepo.EXPECT().ListBalances(gomock.Any(), int64(0), testBatchSize).
+		DoAndReturn(func(context.Context, int64, int) ([]*reconciliation.AccountBalance, error) {
+			close(started)
+			<-release
+			return nil, nil
+		})
+
+	done := make(chan error)
+	go func() {
+		_, err := s.core.Run(s.ctx)
+		done <- err
+	}()
+	<-started
+
+	_, err := s.core.Run(s.ctx)
+	s.ErrorIs(err, reconciliation.ErrRunInProgress)
+
+	close(release)
+	s.NoError(<-done)
+}
+
+// Test Latest
+
+func (s *CoreTestSuite) TestLatestBeforeAnyRunReturnsNoReport() {
+	report, err := s.core.Latest()
+	s.ErrorIs(err, reconciliation.ErrNoReport)
+	s.Nil(report)
+}
@@ -0,0 +1,20 @@
+// Package entities provides response types and constants for the reconciliation module.
+package entities
+
+// Error messages for the reconciliation module
+const (
+	ErrMsgRunInProgress = "balance reconciliation already running"
+	ErrMsgNoReport      = "no balance reconciliation has completed yet"
+)
+
+// Route path constants for the reconciliation module (admin router)
+const (
+	RouteReconciliations      = "/reconciliations"
+	RouteLatestReconciliation = "/reconciliations/latest"
+)
+
+// Report limits
+const (
+	// MaxReportedDrifts caps the drifted accounts listed in a report; all of them are still counted and logged
+	MaxReportedDrifts = 1000
+)
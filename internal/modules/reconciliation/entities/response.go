@@ -0,0 +1,25 @@
+package entities
+
+// AccountDrift describes an account whose stored balance disagrees with its history
+type AccountDrift struct {
+	AccountID int64 `json:"account_id"`
+	// Balance is the stored accounts.balance
+	Balance string `json:"balance"`
+	// ComputedBalance is the opening balance plus every transaction since
+	ComputedBalance string `json:"computed_balance"`
+	// LedgerBalance is the balance_after of the account's latest ledger entry
+	LedgerBalance string `json:"ledger_balance"`
+	// Difference is Balance minus ComputedBalance
+	Difference string `json:"difference"`
+}
+
+// ReportResponse is the outcome of one reconciliation run
+type ReportResponse struct {
+	StartedAt       string          `json:"started_at"`
+	FinishedAt      string          `json:"finished_at"`
+	AccountsScanned int             `json:"accounts_scanned"`
+	DriftedAccounts int             `json:"drifted_accounts"`
+	Drifts          []*AccountDrift `json:"drifts"`
+	// Truncated is set when more accounts drifted than the report lists
+	Truncated bool `json:"truncated,omitempty"`
+}
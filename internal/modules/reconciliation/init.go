@@ -0,0 +1,108 @@
+// Package reconciliation checks stored account balances against their transaction history.
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/internal-transfers-service/internal/config"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var ReconModule IModule
+
+// NewModule initializes the reconciliation module
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool, cfg config.ReconciliationConfig) IModule {
+	if ReconModule == nil {
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper)
+		core := NewCore(ctx, repo, clock.Real{}, cfg.GetBatchSize())
+		handler := NewHTTPHandler(core)
+
+		ReconModule = &Module{
+			Core:    core,
+			Handler: handler,
+			Repo:    repo,
+		}
+	}
+	return ReconModule
+}
+
+// IModule defines the interface for the reconciliation module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+	StartWorker(ctx context.Context, interval time.Duration)
+	StopWorker()
+}
+
+// Module implements IModule
+type Module struct {
+	Core       ICore
+	Handler    *HTTPHandler
+	Repo       IRepository
+	cancelFunc context.CancelFunc
+}
+
+// Compile-time interface check
+var _ IModule = (*Module)(nil)
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
+
+// StartWorker starts a background goroutine that reconciles all balances on every tick
+func (m *Module) StartWorker(ctx context.Context, interval time.Duration) {
+	workerCtx, cancel := context.WithCancel(ctx)
+	m.cancelFunc = cancel
+
+	go m.runLoop(workerCtx, interval)
+}
+
+// StopWorker stops the reconciliation worker
+func (m *Module) StopWorker() {
+	if m.cancelFunc != nil {
+		m.cancelFunc()
+	}
+}
+
+// runLoop reconciles immediately and then on every tick
+func (m *Module) runLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	m.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.run(ctx)
+		}
+	}
+}
+
+// run reconciles once and logs failures; a run already in progress is not an error
+func (m *Module) run(ctx context.Context) {
+	if _, err := m.Core.Run(ctx); err != nil && !errors.Is(err, ErrRunInProgress) && ctx.Err() == nil {
+		logger.Error(constants.LogMsgReconcileRunFailed, constants.LogKeyError, err)
+	}
+}
@@ -0,0 +1,100 @@
+package reconciliation
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/shopspring/decimal"
+)
+
+// AccountBalance is an account's stored balance alongside the balances its history implies
+type AccountBalance struct {
+	AccountID int64
+	// Balance is the stored accounts.balance
+	Balance decimal.Decimal
+	// Computed is the sum of the account's credits minus its debits in transactions,
+	// the opening entry included
+	Computed decimal.Decimal
+	// Ledger is the balance_after of the account's latest ledger entry, zero if it has none
+	Ledger decimal.Decimal
+}
+
+// Drifted reports whether the stored balance disagrees with the transactions or the ledger
+func (b *AccountBalance) Drifted() bool {
+	return !b.Balance.Equal(b.Computed) || !b.Balance.Equal(b.Ledger)
+}
+
+// IRepository defines the interface for reconciliation data access
+type IRepository interface {
+	ListBalances(ctx context.Context, afterAccountID int64, limit int) ([]*AccountBalance, error)
+}
+
+// Repository implements IRepository
+type Repository struct {
+	pool database.IPool
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new reconciliation repository
+func NewRepository(pool database.IPool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// SQL queries
+const (
+	// A single statement sees one snapshot, so the stored balance and both recomputations
+	// agree for every account in the batch even while transfers are committing. Archived
+	// months count through their totals.
+	querySelectBalances = `
+		SELECT a.account_id, a.balance,
+			COALESCE((SELECT SUM(t.amount) FROM transactions t WHERE t.destination_account_id = a.account_id), 0)
+				- COALESCE((SELECT SUM(t.amount) FROM transactions t WHERE t.source_account_id = a.account_id), 0)
+				+ archived_transaction_net(a.account_id, 'infinity'),
+			COALESCE((SELECT e.balance_after FROM ledger_entries e
+				WHERE e.account_id = a.account_id
+				ORDER BY e.entry_id DESC
+				LIMIT 1), 0)
+		FROM accounts a
+		WHERE a.account_id > $1
+		ORDER BY a.account_id
+		LIMIT $2`
+)
+
+// ListBalances returns up to limit accounts with IDs above afterAccountID, in ID order
+func (r *Repository) ListBalances(ctx context.Context, afterAccountID int64, limit int) ([]*AccountBalance, error) {
+	rows, err := r.pool.Query(ctx, querySelectBalances, afterAccountID, limit)
+	if err != nil {
+		return nil, r.logScanFailure(ctx, afterAccountID, err)
+	}
+	defer rows.Close()
+
+	balances := make([]*AccountBalance, 0, limit)
+	for rows.Next() {
+		var b AccountBalance
+		if err := rows.Scan(&b.AccountID, &b.Balance, &b.Computed, &b.Ledger); err != nil {
+			return nil, r.logScanFailure(ctx, afterAccountID, err)
+		}
+		balances = append(balances, &b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, r.logScanFailure(ctx, afterAccountID, err)
+	}
+
+	return balances, nil
+}
+
+// logScanFailure logs a failed balance scan and returns the error
+func (r *Repository) logScanFailure(ctx context.Context, afterAccountID int64, err error) error {
+	logger.Ctx(ctx).Errorw(constants.LogMsgFailedToScanBalances,
+		constants.LogFieldAfterAccount, afterAccountID,
+		constants.LogKeyError, err,
+	)
+	return err
+}
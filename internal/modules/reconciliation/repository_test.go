@@ -0,0 +1,85 @@
+package reconciliation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/internal-transfers-service/internal/modules/reconciliation"
+	dbmock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// RepositoryTestSuite contains tests for reconciliation Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockPool *dbmock.MockIPool
+	repo     reconciliation.IRepository
+	ctx      context.Context
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbmock.NewMockIPool(s.ctrl)
+	s.ctx = context.Background()
+	s.repo = reconciliation.NewRepository(s.mockPool)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *RepositoryTestSuite) TestListBalancesScansEachRow() {
+	mockRows := dbmock.NewMockRows(s.ctrl)
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), int64(10), 2).Return(mockRows, nil).Times(1)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	ids := []int64{11, 12}
+	call := 0
+	mockRows.EXPECT().
+		Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = ids[call]
+			*dest[1].(*decimal.Decimal) = decimal.NewFromInt(5)
+			*dest[2].(*decimal.Decimal) = decimal.NewFromInt(5)
+			*dest[3].(*decimal.Decimal) = decimal.NewFromInt(4)
+			call++
+			return nil
+		}).
+		Times(2)
+	mockRows.EXPECT().Err().Return(nil).Times(1)
+	mockRows.EXPECT().Close().Times(1)
+
+	balances, err := s.repo.ListBalances(s.ctx, 10, 2)
+	s.NoError(err)
+	s.Len(balances, 2)
+	s.Equal(int64(12), balances[1].AccountID)
+	s.True(balances[1].Drifted())
+}
+
+func (s *RepositoryTestSuite) TestListBalancesWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), int64(0), 2).Return(nil, errDatabaseError).Times(1)
+
+	balances, err := s.repo.ListBalances(s.ctx, 0, 2)
+	s.Equal(errDatabaseError, err)
+	s.Nil(balances)
+}
+
+func (s *RepositoryTestSuite) TestDriftedIsFalseWhenAllBalancesAgree() {
+	b := &reconciliation.AccountBalance{
+		AccountID: 1,
+		Balance:   decimal.RequireFromString("1.50"),
+		Computed:  decimal.RequireFromString("1.5"),
+		Ledger:    decimal.RequireFromString("1.500"),
+	}
+	s.False(b.Drifted())
+}
@@ -0,0 +1,90 @@
+package reconciliation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/reconciliation/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+)
+
+// HTTPHandler handles HTTP requests for balance reconciliation
+type HTTPHandler struct {
+	core ICore
+}
+
+// NewHTTPHandler creates a new HTTPHandler
+func NewHTTPHandler(core ICore) *HTTPHandler {
+	return &HTTPHandler{core: core}
+}
+
+// RegisterAdminRoutes registers the reconciliation routes.
+func (h *HTTPHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Post(entities.RouteReconciliations, h.Run)
+	r.Get(entities.RouteLatestReconciliation, h.GetLatest)
+}
+
+// Run handles POST /reconciliations by running a reconciliation and returning its report
+func (h *HTTPHandler) Run(w http.ResponseWriter, r *http.Request) {
+	report, err := h.core.Run(r.Context())
+	if err != nil {
+		if errors.Is(err, ErrRunInProgress) {
+			h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeConflict, err, apperror.MsgReconciliationRunning))
+			return
+		}
+		h.writeErrorWithContext(w, r, apperror.New(apperror.CodeInternalError, err))
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// GetLatest handles GET /reconciliations/latest
+func (h *HTTPHandler) GetLatest(w http.ResponseWriter, r *http.Request) {
+	report, err := h.core.Latest()
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeNotFound, err, apperror.MsgNoReconciliation))
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// writeJSON writes a JSON response
+func (h *HTTPHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(status)
+	if data != nil {
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			logger.Error(constants.LogMsgFailedToEncodeResponse, constants.LogKeyError, err)
+		}
+	}
+}
+
+// writeErrorWithContext writes an error response with request ID for tracing
+func (h *HTTPHandler) writeErrorWithContext(w http.ResponseWriter, r *http.Request, err apperror.IError) {
+	requestID := ""
+	if id, ok := r.Context().Value(contextkeys.RequestID).(string); ok {
+		requestID = id
+	}
+
+	response := apperror.ErrorResponse{
+		Error:     err.PublicMessage(),
+		Code:      err.Code().String(),
+		RequestID: requestID,
+		Details:   err.Fields(),
+	}
+
+	// Log error for debugging
+	logger.Ctx(r.Context()).Errorw(constants.LogMsgRequestFailed,
+		constants.LogKeyError, err.Error(),
+		constants.LogKeyStatusCode, err.HTTPStatus(),
+	)
+
+	h.writeJSON(w, err.HTTPStatus(), response)
+}
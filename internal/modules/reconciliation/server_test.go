@@ -0,0 +1,97 @@
+package reconciliation_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/modules/reconciliation"
+	"github.com/internal-transfers-service/internal/modules/reconciliation/entities"
+	"github.com/internal-transfers-service/internal/modules/reconciliation/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for reconciliation HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.router = chi.NewRouter()
+	reconciliation.NewHTTPHandler(s.mockCore).RegisterAdminRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestRunReturnsReport() {
+	s.mockCore.EXPECT().Run(gomock.Any()).Return(&entities.ReportResponse{
+		AccountsScanned: 3,
+		DriftedAccounts: 1,
+		Drifts:          []*entities.AccountDrift{{AccountID: 7, Difference: "1"}},
+	}, nil)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/reconciliations", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	var report entities.ReportResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &report))
+	s.Equal(1, report.DriftedAccounts)
+	s.Equal(int64(7), report.Drifts[0].AccountID)
+}
+
+func (s *ServerTestSuite) TestRunWhileRunningReturnsConflict() {
+	s.mockCore.EXPECT().Run(gomock.Any()).Return(nil, reconciliation.ErrRunInProgress)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/reconciliations", nil))
+
+	s.Equal(http.StatusConflict, rec.Code)
+	var body apperror.ErrorResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	s.Equal(apperror.MsgReconciliationRunning, body.Error)
+}
+
+func (s *ServerTestSuite) TestRunWhenCoreFailsReturnsInternalError() {
+	s.mockCore.EXPECT().Run(gomock.Any()).Return(nil, errors.New("database error"))
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/reconciliations", nil))
+
+	s.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func (s *ServerTestSuite) TestGetLatestReturnsReport() {
+	s.mockCore.EXPECT().Latest().Return(&entities.ReportResponse{AccountsScanned: 5}, nil)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/reconciliations/latest", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), `"accounts_scanned":5`)
+}
+
+func (s *ServerTestSuite) TestGetLatestBeforeAnyRunReturnsNotFound() {
+	s.mockCore.EXPECT().Latest().Return(nil, reconciliation.ErrNoReport)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/reconciliations/latest", nil))
+
+	s.Equal(http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,252 @@
+package snapshot
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/account"
+	"github.com/internal-transfers-service/internal/modules/snapshot/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/jackc/pgx/v5"
+)
+
+// Domain errors
+var (
+	ErrInvalidDate      = errors.New(entities.ErrMsgInvalidDate)
+	ErrInvalidRange     = errors.New(entities.ErrMsgInvalidRange)
+	ErrAccountNotFound  = errors.New(entities.ErrMsgAccountNotFound)
+	ErrInvalidAccountID = errors.New(entities.ErrMsgInvalidAccountID)
+	ErrDateNotClosed    = errors.New(entities.ErrMsgDateNotClosed)
+)
+
+// ICore defines the interface for balance snapshot business logic
+type ICore interface {
+	RunDue(ctx context.Context, now time.Time) (int, error)
+	EnsureDate(ctx context.Context, date, now time.Time) (bool, error)
+	ListSnapshots(ctx context.Context, req *entities.ListSnapshotsRequest) (*entities.ListSnapshotsResponse, apperror.IError)
+}
+
+// Core implements ICore
+type Core struct {
+	repo        IRepository
+	accountRepo account.IRepository
+	schedule    *Schedule
+	catchupDays int
+}
+
+// Compile-time interface check
+var _ ICore = (*Core)(nil)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance
+func NewCore(_ context.Context, repo IRepository, accountRepo account.IRepository, schedule *Schedule, catchupDays int) ICore {
+	coreInstance = &Core{
+		repo:        repo,
+		accountRepo: accountRepo,
+		schedule:    schedule,
+		catchupDays: max(catchupDays, 1),
+	}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// RunDue snapshots every business date that has closed since the last completed run,
+// oldest first. It stops early if another replica holds the snapshot lock.
+// Returns the number of business dates completed.
+func (c *Core) RunDue(ctx context.Context, now time.Time) (int, error) {
+	latest := c.schedule.LatestDue(now)
+
+	start, err := c.firstPendingDate(ctx, latest)
+	if err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	for date := start; !date.After(latest); date = date.AddDate(0, 0, 1) {
+		done, err := c.snapshotDate(ctx, date)
+		if err != nil {
+			return completed, err
+		}
+		if !done {
+			logger.Ctx(ctx).Debugw(constants.LogMsgSnapshotRunHeldElsewhere,
+				constants.LogFieldSnapshotDate, date.Format(entities.DateLayout),
+			)
+			break
+		}
+		completed++
+	}
+	return completed, nil
+}
+
+// EnsureDate snapshots a single closed business date if it has not been snapshotted yet,
+// for jobs that need end-of-day balances before the worker reached them (e.g. backfills).
+// Returns false if another replica holds the snapshot lock.
+func (c *Core) EnsureDate(ctx context.Context, date, now time.Time) (bool, error) {
+	date = DateOf(date)
+	if date.After(c.schedule.LatestDue(now)) {
+		return false, fmt.Errorf("%w: %s", ErrDateNotClosed, date.Format(entities.DateLayout))
+	}
+	return c.snapshotDate(ctx, date)
+}
+
+// firstPendingDate returns the day after the last completed run, or the start of
+// the catch-up window when no run has completed yet
+func (c *Core) firstPendingDate(ctx context.Context, latest time.Time) (time.Time, error) {
+	last, ok, err := c.repo.LastCompletedDate(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if ok {
+		return DateOf(last).AddDate(0, 0, 1), nil
+	}
+	return latest.AddDate(0, 0, 1-c.catchupDays), nil
+}
+
+// snapshotDate writes all snapshots for one business date in a single transaction.
+// Returns false if another replica holds the snapshot lock.
+func (c *Core) snapshotDate(ctx context.Context, date time.Time) (bool, error) {
+	tx, err := c.repo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	committed := false
+	defer c.rollbackIfNotCommitted(ctx, tx, &committed)
+
+	locked, err := c.repo.TryLock(ctx, tx)
+	if err != nil || !locked {
+		return false, err
+	}
+
+	// Another replica may have finished this date between our read and taking the lock
+	alreadyDone, err := c.repo.IsCompleted(ctx, tx, date)
+	if err != nil || alreadyDone {
+		return alreadyDone, err
+	}
+
+	cutoffAt := c.schedule.CutoffOf(date)
+	count, err := c.repo.CreateForDate(ctx, tx, date, cutoffAt)
+	if err != nil {
+		return false, err
+	}
+
+	if err := c.repo.MarkCompleted(ctx, tx, date, cutoffAt, count); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	committed = true
+
+	logger.Ctx(ctx).Infow(constants.LogMsgSnapshotDateCompleted,
+		constants.LogFieldSnapshotDate, date.Format(entities.DateLayout),
+		constants.LogFieldCutoffAt, cutoffAt,
+		constants.LogFieldAccountCount, count,
+	)
+	return true, nil
+}
+
+// rollbackIfNotCommitted rolls back the transaction if it was not committed
+func (c *Core) rollbackIfNotCommitted(ctx context.Context, tx pgx.Tx, committed *bool) {
+	if !*committed {
+		_ = tx.Rollback(ctx)
+	}
+}
+
+// ListSnapshots returns an account's daily snapshots over a date range.
+// to defaults to the current business date and from to DefaultRangeDays before it.
+func (c *Core) ListSnapshots(ctx context.Context, req *entities.ListSnapshotsRequest) (*entities.ListSnapshotsResponse, apperror.IError) {
+	if req.AccountID <= 0 {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, req.AccountID)
+	}
+
+	from, to, appErr := c.resolveRange(req)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	acc, err := c.accountRepo.GetByID(ctx, req.AccountID)
+	if err != nil {
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) && appErr.Code() == apperror.CodeNotFound {
+			return nil, apperror.NewWithMessage(apperror.CodeNotFound, ErrAccountNotFound, apperror.MsgAccountNotFound).
+				WithField(apperror.FieldAccountID, req.AccountID)
+		}
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, req.AccountID)
+	}
+
+	snapshots, err := c.repo.ListByAccount(ctx, req.AccountID, from, to)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, req.AccountID)
+	}
+
+	response := &entities.ListSnapshotsResponse{
+		AccountID: acc.AccountID,
+		Currency:  acc.Currency,
+		From:      from.Format(entities.DateLayout),
+		To:        to.Format(entities.DateLayout),
+		Snapshots: make([]*entities.SnapshotResponse, 0, len(snapshots)),
+	}
+	for _, s := range snapshots {
+		response.Snapshots = append(response.Snapshots, &entities.SnapshotResponse{
+			Date:     s.SnapshotDate.Format(entities.DateLayout),
+			Balance:  s.Balance.String(),
+			CutoffAt: s.CutoffAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return response, nil
+}
+
+// resolveRange parses the requested dates, applies defaults and bounds the range
+func (c *Core) resolveRange(req *entities.ListSnapshotsRequest) (time.Time, time.Time, apperror.IError) {
+	to := c.schedule.Today(time.Now())
+	if req.To != "" {
+		parsed, appErr := parseDate(req.To, apperror.FieldTo)
+		if appErr != nil {
+			return time.Time{}, time.Time{}, appErr
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, 1-entities.DefaultRangeDays)
+	if req.From != "" {
+		parsed, appErr := parseDate(req.From, apperror.FieldFrom)
+		if appErr != nil {
+			return time.Time{}, time.Time{}, appErr
+		}
+		from = parsed
+	}
+
+	if from.After(to) || from.AddDate(0, 0, entities.MaxRangeDays-1).Before(to) {
+		return time.Time{}, time.Time{}, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidRange, apperror.MsgInvalidDateRange).
+			WithField(apperror.FieldFrom, from.Format(entities.DateLayout)).
+			WithField(apperror.FieldTo, to.Format(entities.DateLayout))
+	}
+	return from, to, nil
+}
+
+// parseDate parses a YYYY-MM-DD business date
+func parseDate(value, field string) (time.Time, apperror.IError) {
+	date, err := time.Parse(entities.DateLayout, value)
+	if err != nil {
+		return time.Time{}, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidDate, apperror.MsgInvalidDate).
+			WithField(field, value)
+	}
+	return date, nil
+}
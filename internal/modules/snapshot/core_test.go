@@ -0,0 +1,312 @@
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/account"
+	accountEntities "github.com/internal-transfers-service/internal/modules/account/entities"
+	accountMock "github.com/internal-transfers-service/internal/modules/account/mock"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/internal/modules/snapshot/entities"
+	"github.com/internal-transfers-service/internal/modules/snapshot/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	dbmock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in tests
+var (
+	errDatabaseError = errors.New("database error")
+	errCommitFailed  = errors.New("commit failed")
+)
+
+// CoreTestSuite contains tests for snapshot Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl            *gomock.Controller
+	mockRepo        *mock.MockIRepository
+	mockAccountRepo *accountMock.MockIRepository
+	mockTx          *dbmock.MockTx
+	schedule        *snapshot.Schedule
+	core            snapshot.ICore
+	ctx             context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.mockAccountRepo = accountMock.NewMockIRepository(s.ctrl)
+	s.mockTx = dbmock.NewMockTx(s.ctrl)
+	s.ctx = context.Background()
+
+	schedule, err := snapshot.NewSchedule("24:00", "UTC", time.Minute)
+	s.Require().NoError(err)
+	s.schedule = schedule
+	s.core = snapshot.NewCore(s.ctx, s.mockRepo, s.mockAccountRepo, schedule, 3)
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// date returns the business date for the given day of March 2026
+func date(day int) time.Time {
+	return time.Date(2026, 3, day, 0, 0, 0, 0, time.UTC)
+}
+
+// expectSnapshot sets up a successful snapshot of one business date
+func (s *CoreTestSuite) expectSnapshot(d time.Time, count int64) {
+	cutoff := s.schedule.CutoffOf(d)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().TryLock(s.ctx, s.mockTx).Return(true, nil)
+	s.mockRepo.EXPECT().IsCompleted(s.ctx, s.mockTx, d).Return(false, nil)
+	s.mockRepo.EXPECT().CreateForDate(s.ctx, s.mockTx, d, cutoff).Return(count, nil)
+	s.mockRepo.EXPECT().MarkCompleted(s.ctx, s.mockTx, d, cutoff, count).Return(nil)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil)
+}
+
+// Test RunDue
+
+func (s *CoreTestSuite) TestRunDueResumesAfterLastCompletedDate() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	s.mockRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(7), true, nil)
+	gomock.InOrder(
+		s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil),
+		s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil),
+	)
+	s.mockRepo.EXPECT().TryLock(s.ctx, s.mockTx).Return(true, nil).Times(2)
+	s.mockRepo.EXPECT().IsCompleted(s.ctx, s.mockTx, date(8)).Return(false, nil)
+	s.mockRepo.EXPECT().IsCompleted(s.ctx, s.mockTx, date(9)).Return(false, nil)
+	s.mockRepo.EXPECT().CreateForDate(s.ctx, s.mockTx, date(8), date(9)).Return(int64(4), nil)
+	s.mockRepo.EXPECT().CreateForDate(s.ctx, s.mockTx, date(9), date(10)).Return(int64(5), nil)
+	s.mockRepo.EXPECT().MarkCompleted(s.ctx, s.mockTx, date(8), date(9), int64(4)).Return(nil)
+	s.mockRepo.EXPECT().MarkCompleted(s.ctx, s.mockTx, date(9), date(10), int64(5)).Return(nil)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(nil).Times(2)
+
+	completed, err := s.core.RunDue(s.ctx, now)
+	s.NoError(err)
+	s.Equal(2, completed)
+}
+
+func (s *CoreTestSuite) TestRunDueWithoutHistoryCatchesUpConfiguredDays() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	s.mockRepo.EXPECT().LastCompletedDate(s.ctx).Return(time.Time{}, false, nil)
+	s.expectSnapshot(date(7), 1)
+	s.expectSnapshot(date(8), 1)
+	s.expectSnapshot(date(9), 1)
+
+	completed, err := s.core.RunDue(s.ctx, now)
+	s.NoError(err)
+	s.Equal(3, completed)
+}
+
+func (s *CoreTestSuite) TestRunDueWhenUpToDateDoesNothing() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	s.mockRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(9), true, nil)
+
+	completed, err := s.core.RunDue(s.ctx, now)
+	s.NoError(err)
+	s.Equal(0, completed)
+}
+
+func (s *CoreTestSuite) TestRunDueWhenLockHeldElsewhereStops() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	s.mockRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(7), true, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().TryLock(s.ctx, s.mockTx).Return(false, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+
+	completed, err := s.core.RunDue(s.ctx, now)
+	s.NoError(err)
+	s.Equal(0, completed)
+}
+
+func (s *CoreTestSuite) TestRunDueWhenDateAlreadyCompletedSkipsWrite() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	s.mockRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(8), true, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().TryLock(s.ctx, s.mockTx).Return(true, nil)
+	s.mockRepo.EXPECT().IsCompleted(s.ctx, s.mockTx, date(9)).Return(true, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+
+	completed, err := s.core.RunDue(s.ctx, now)
+	s.NoError(err)
+	s.Equal(1, completed)
+}
+
+func (s *CoreTestSuite) TestRunDueWhenLastCompletedDateFailsReturnsError() {
+	s.mockRepo.EXPECT().LastCompletedDate(s.ctx).Return(time.Time{}, false, errDatabaseError)
+
+	completed, err := s.core.RunDue(s.ctx, time.Now())
+	s.Equal(errDatabaseError, err)
+	s.Equal(0, completed)
+}
+
+func (s *CoreTestSuite) TestRunDueWhenCreateFailsRollsBackAndReturnsError() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	s.mockRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(8), true, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().TryLock(s.ctx, s.mockTx).Return(true, nil)
+	s.mockRepo.EXPECT().IsCompleted(s.ctx, s.mockTx, date(9)).Return(false, nil)
+	s.mockRepo.EXPECT().CreateForDate(s.ctx, s.mockTx, date(9), date(10)).Return(int64(0), errDatabaseError)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+
+	completed, err := s.core.RunDue(s.ctx, now)
+	s.Equal(errDatabaseError, err)
+	s.Equal(0, completed)
+}
+
+func (s *CoreTestSuite) TestRunDueWhenCommitFailsReturnsError() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	s.mockRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(8), true, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockRepo.EXPECT().TryLock(s.ctx, s.mockTx).Return(true, nil)
+	s.mockRepo.EXPECT().IsCompleted(s.ctx, s.mockTx, date(9)).Return(false, nil)
+	s.mockRepo.EXPECT().CreateForDate(s.ctx, s.mockTx, date(9), date(10)).Return(int64(2), nil)
+	s.mockRepo.EXPECT().MarkCompleted(s.ctx, s.mockTx, date(9), date(10), int64(2)).Return(nil)
+	s.mockTx.EXPECT().Commit(s.ctx).Return(errCommitFailed)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+
+	_, err := s.core.RunDue(s.ctx, now)
+	s.Equal(errCommitFailed, err)
+}
+
+func (s *CoreTestSuite) TestRunDueWhenBeginTxFailsReturnsError() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	s.mockRepo.EXPECT().LastCompletedDate(s.ctx).Return(date(8), true, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(nil, errDatabaseError)
+
+	_, err := s.core.RunDue(s.ctx, now)
+	s.Equal(errDatabaseError, err)
+}
+
+// Test EnsureDate
+
+func (s *CoreTestSuite) TestEnsureDateSnapshotsClosedDate() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	s.expectSnapshot(date(2), 6)
+
+	done, err := s.core.EnsureDate(s.ctx, time.Date(2026, 3, 2, 15, 30, 0, 0, time.UTC), now)
+	s.NoError(err)
+	s.True(done)
+}
+
+func (s *CoreTestSuite) TestEnsureDateWhenDateNotClosedReturnsError() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	done, err := s.core.EnsureDate(s.ctx, date(10), now)
+	s.ErrorIs(err, snapshot.ErrDateNotClosed)
+	s.False(done)
+}
+
+// Test ListSnapshots
+
+func (s *CoreTestSuite) TestListSnapshotsReturnsSnapshotsInRange() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123, Currency: "USD", Kind: accountEntities.KindCustomer}, nil)
+	s.mockRepo.EXPECT().
+		ListByAccount(s.ctx, int64(123), date(1), date(2)).
+		Return([]*snapshot.Snapshot{
+			{AccountID: 123, SnapshotDate: date(1), Balance: decimal.RequireFromString("10.5"), CutoffAt: date(2)},
+			{AccountID: 123, SnapshotDate: date(2), Balance: decimal.RequireFromString("7.25"), CutoffAt: date(3)},
+		}, nil)
+
+	response, err := s.core.ListSnapshots(s.ctx, &entities.ListSnapshotsRequest{
+		AccountID: 123,
+		From:      "2026-03-01",
+		To:        "2026-03-02",
+	})
+	s.Nil(err)
+	s.Equal("USD", response.Currency)
+	s.Equal("2026-03-01", response.From)
+	s.Equal("2026-03-02", response.To)
+	s.Len(response.Snapshots, 2)
+	s.Equal("2026-03-01", response.Snapshots[0].Date)
+	s.Equal("10.5", response.Snapshots[0].Balance)
+	s.Equal("2026-03-02T00:00:00Z", response.Snapshots[0].CutoffAt)
+	s.Equal("7.25", response.Snapshots[1].Balance)
+}
+
+func (s *CoreTestSuite) TestListSnapshotsWithoutRangeDefaultsToRecentDays() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123, Currency: "USD"}, nil)
+	s.mockRepo.EXPECT().
+		ListByAccount(s.ctx, int64(123), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, from, to time.Time) ([]*snapshot.Snapshot, error) {
+			s.Equal(s.schedule.Today(time.Now()), to)
+			s.Equal(to.AddDate(0, 0, 1-entities.DefaultRangeDays), from)
+			return nil, nil
+		})
+
+	response, err := s.core.ListSnapshots(s.ctx, &entities.ListSnapshotsRequest{AccountID: 123})
+	s.Nil(err)
+	s.NotNil(response.Snapshots)
+	s.Empty(response.Snapshots)
+}
+
+func (s *CoreTestSuite) TestListSnapshotsWithInvalidAccountIDFails() {
+	_, err := s.core.ListSnapshots(s.ctx, &entities.ListSnapshotsRequest{AccountID: 0})
+	s.NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+}
+
+func (s *CoreTestSuite) TestListSnapshotsWithInvalidDateFails() {
+	_, err := s.core.ListSnapshots(s.ctx, &entities.ListSnapshotsRequest{AccountID: 123, From: "03/01/2026"})
+	s.NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidDate, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestListSnapshotsWithReversedRangeFails() {
+	_, err := s.core.ListSnapshots(s.ctx, &entities.ListSnapshotsRequest{AccountID: 123, From: "2026-03-02", To: "2026-03-01"})
+	s.NotNil(err)
+	s.Equal(apperror.MsgInvalidDateRange, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestListSnapshotsWithRangeOverLimitFails() {
+	_, err := s.core.ListSnapshots(s.ctx, &entities.ListSnapshotsRequest{AccountID: 123, From: "2025-01-01", To: "2026-03-01"})
+	s.NotNil(err)
+	s.Equal(apperror.MsgInvalidDateRange, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestListSnapshotsWhenAccountNotFoundReturnsNotFound() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(nil, apperror.New(apperror.CodeNotFound, errDatabaseError))
+
+	_, err := s.core.ListSnapshots(s.ctx, &entities.ListSnapshotsRequest{AccountID: 123})
+	s.NotNil(err)
+	s.Equal(apperror.CodeNotFound, err.Code())
+}
+
+func (s *CoreTestSuite) TestListSnapshotsWhenRepoFailsReturnsInternalError() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123, Currency: "USD"}, nil)
+	s.mockRepo.EXPECT().
+		ListByAccount(s.ctx, int64(123), gomock.Any(), gomock.Any()).
+		Return(nil, errDatabaseError)
+
+	_, err := s.core.ListSnapshots(s.ctx, &entities.ListSnapshotsRequest{AccountID: 123})
+	s.NotNil(err)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
@@ -0,0 +1,35 @@
+// Package entities provides request/response types and constants for the snapshot module.
+package entities
+
+// Error messages for the snapshot module
+const (
+	ErrMsgAccountNotFound  = "account not found"
+	ErrMsgDateNotClosed    = "business date has not closed yet"
+	ErrMsgInvalidAccountID = "invalid account ID"
+	ErrMsgInvalidCutoff    = "invalid snapshot cutoff, expected HH:MM"
+	ErrMsgInvalidDate      = "invalid date, expected YYYY-MM-DD"
+	ErrMsgInvalidRange     = "invalid snapshot date range"
+	ErrMsgInvalidTimezone  = "invalid snapshot time zone"
+)
+
+// Route path constants for the snapshot module
+const (
+	RouteAccountSnapshots = "/accounts/{accountID}/snapshots"
+	ParamAccountID        = "accountID"
+	QueryParamFrom        = "from"
+	QueryParamTo          = "to"
+)
+
+// DateLayout is the wire and query format of business dates
+const DateLayout = "2006-01-02"
+
+// Snapshot listing limits
+const (
+	// DefaultRangeDays is the number of days listed when the request omits from
+	DefaultRangeDays = 31
+	// MaxRangeDays bounds the number of days a single listing may cover
+	MaxRangeDays = 366
+)
+
+// AdvisoryLockKey serialises snapshot runs across replicas (pg_try_advisory_xact_lock)
+const AdvisoryLockKey int64 = 0x736e617073686f74 // "snapshot"
@@ -0,0 +1,9 @@
+package entities
+
+// ListSnapshotsRequest represents the request to list an account's daily snapshots.
+// From and To are optional YYYY-MM-DD business dates.
+type ListSnapshotsRequest struct {
+	AccountID int64  `json:"account_id"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+}
@@ -0,0 +1,17 @@
+package entities
+
+// SnapshotResponse represents one end-of-day balance
+type SnapshotResponse struct {
+	Date     string `json:"date"`
+	Balance  string `json:"balance"`
+	CutoffAt string `json:"cutoff_at"`
+}
+
+// ListSnapshotsResponse represents the snapshots of an account over a date range
+type ListSnapshotsResponse struct {
+	AccountID int64               `json:"account_id"`
+	Currency  string              `json:"currency"`
+	From      string              `json:"from"`
+	To        string              `json:"to"`
+	Snapshots []*SnapshotResponse `json:"snapshots"`
+}
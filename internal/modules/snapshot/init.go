@@ -0,0 +1,121 @@
+// Package snapshot records daily end-of-day account balances.
+package snapshot
+
+import (
+	"context"
+	"time"
+
+	"github.com/internal-transfers-service/internal/config"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/account"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var SnapModule IModule
+
+// NewModule initializes the snapshot module
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool, cfg config.SnapshotConfig, accountRepo account.IRepository) (IModule, error) {
+	if SnapModule == nil {
+		schedule, err := NewSchedule(cfg.Cutoff, cfg.Timezone, cfg.GetGrace())
+		if err != nil {
+			return nil, err
+		}
+
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper)
+		core := NewCore(ctx, repo, accountRepo, schedule, cfg.CatchupDays)
+		handler := NewHTTPHandler(core)
+
+		SnapModule = &Module{
+			Core:     core,
+			Handler:  handler,
+			Repo:     repo,
+			Schedule: schedule,
+		}
+	}
+	return SnapModule, nil
+}
+
+// IModule defines the interface for the snapshot module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+	GetSchedule() *Schedule
+	StartWorker(ctx context.Context, interval time.Duration)
+	StopWorker()
+}
+
+// Module implements IModule
+type Module struct {
+	Core       ICore
+	Handler    *HTTPHandler
+	Repo       IRepository
+	Schedule   *Schedule
+	cancelFunc context.CancelFunc
+}
+
+// Compile-time interface check
+var _ IModule = (*Module)(nil)
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
+
+// GetSchedule returns the business date schedule snapshots are taken on
+func (m *Module) GetSchedule() *Schedule {
+	return m.Schedule
+}
+
+// StartWorker starts a background goroutine that snapshots each business date once it closes.
+// Dates missed while the service was down are caught up on the first run.
+func (m *Module) StartWorker(ctx context.Context, interval time.Duration) {
+	workerCtx, cancel := context.WithCancel(ctx)
+	m.cancelFunc = cancel
+
+	go m.runLoop(workerCtx, interval)
+}
+
+// StopWorker stops the snapshot worker
+func (m *Module) StopWorker() {
+	if m.cancelFunc != nil {
+		m.cancelFunc()
+	}
+}
+
+// runLoop runs the snapshot job immediately and then on every tick
+func (m *Module) runLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	m.runDue(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.runDue(ctx)
+		}
+	}
+}
+
+// runDue snapshots any closed business dates and logs failures
+func (m *Module) runDue(ctx context.Context) {
+	if _, err := m.Core.RunDue(ctx, time.Now()); err != nil && ctx.Err() == nil {
+		logger.Error(constants.LogMsgSnapshotRunFailed, constants.LogKeyError, err)
+	}
+}
@@ -0,0 +1,208 @@
+package snapshot
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/snapshot/entities"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// Snapshot represents an account's balance at the close of a business date
+type Snapshot struct {
+	AccountID    int64           `json:"account_id"`
+	SnapshotDate time.Time       `json:"snapshot_date"`
+	Balance      decimal.Decimal `json:"balance"`
+	CutoffAt     time.Time       `json:"cutoff_at"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// IRepository defines the interface for snapshot data access
+type IRepository interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	TryLock(ctx context.Context, tx pgx.Tx) (bool, error)
+	LastCompletedDate(ctx context.Context) (time.Time, bool, error)
+	IsCompleted(ctx context.Context, tx pgx.Tx, date time.Time) (bool, error)
+	CreateForDate(ctx context.Context, tx pgx.Tx, date, cutoffAt time.Time) (int64, error)
+	MarkCompleted(ctx context.Context, tx pgx.Tx, date, cutoffAt time.Time, accountCount int64) error
+	ListByAccount(ctx context.Context, accountID int64, from, to time.Time) ([]*Snapshot, error)
+}
+
+// Repository implements IRepository
+type Repository struct {
+	pool database.IPool
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new snapshot repository
+func NewRepository(pool database.IPool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// SQL queries
+const (
+	queryTryLock = `
+		SELECT pg_try_advisory_xact_lock($1)`
+
+	queryLastCompletedDate = `
+		SELECT snapshot_date
+		FROM balance_snapshot_runs
+		ORDER BY snapshot_date DESC
+		LIMIT 1`
+
+	queryIsCompleted = `
+		SELECT EXISTS(SELECT 1 FROM balance_snapshot_runs WHERE snapshot_date = $1)`
+
+	// Each balance is the previous day's snapshot plus the movements since its cutoff,
+	// falling back to the full history, archived months included, for accounts without
+	// one. Rows that already exist are left alone, so re-running a date is harmless.
+	queryInsertSnapshots = `
+		INSERT INTO balance_snapshots (account_id, snapshot_date, balance, cutoff_at)
+		SELECT a.account_id, $1::date,
+			COALESCE(p.balance, 0) + COALESCE(m.delta, 0)
+				+ CASE WHEN p.account_id IS NULL THEN archived_transaction_net(a.account_id, $2) ELSE 0 END,
+			$2
+		FROM accounts a
+		LEFT JOIN balance_snapshots p
+			ON p.account_id = a.account_id AND p.snapshot_date = $1::date - 1
+		LEFT JOIN LATERAL (
+			SELECT SUM(CASE WHEN t.destination_account_id = a.account_id THEN t.amount ELSE -t.amount END) AS delta
+			FROM transactions t
+			WHERE (t.source_account_id = a.account_id OR t.destination_account_id = a.account_id)
+				AND t.created_at > COALESCE(p.cutoff_at, '-infinity')
+				AND t.created_at <= $2
+		) m ON TRUE
+		WHERE a.created_at <= $2
+		ON CONFLICT (account_id, snapshot_date) DO NOTHING`
+
+	queryInsertRun = `
+		INSERT INTO balance_snapshot_runs (snapshot_date, cutoff_at, account_count, completed_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (snapshot_date) DO NOTHING`
+
+	querySelectByAccount = `
+		SELECT account_id, snapshot_date, balance, cutoff_at, created_at
+		FROM balance_snapshots
+		WHERE account_id = $1 AND snapshot_date BETWEEN $2 AND $3
+		ORDER BY snapshot_date`
+)
+
+// BeginTx starts a new database transaction
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel: pgx.ReadCommitted,
+	})
+}
+
+// TryLock takes the snapshot advisory lock for the lifetime of tx.
+// Returns false if another replica holds it.
+func (r *Repository) TryLock(ctx context.Context, tx pgx.Tx) (bool, error) {
+	var locked bool
+	if err := tx.QueryRow(ctx, queryTryLock, entities.AdvisoryLockKey).Scan(&locked); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgSnapshotLockFailed,
+			constants.LogKeyError, err,
+		)
+		return false, err
+	}
+	return locked, nil
+}
+
+// LastCompletedDate returns the most recent fully snapshotted business date, if any
+func (r *Repository) LastCompletedDate(ctx context.Context) (time.Time, bool, error) {
+	var date time.Time
+	err := r.pool.QueryRow(ctx, queryLastCompletedDate).Scan(&date)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, false, nil
+		}
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToGetSnapshotRun,
+			constants.LogKeyError, err,
+		)
+		return time.Time{}, false, err
+	}
+	return date, true, nil
+}
+
+// IsCompleted reports whether the business date has already been snapshotted
+func (r *Repository) IsCompleted(ctx context.Context, tx pgx.Tx, date time.Time) (bool, error) {
+	var completed bool
+	if err := tx.QueryRow(ctx, queryIsCompleted, date).Scan(&completed); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToGetSnapshotRun,
+			constants.LogFieldSnapshotDate, date,
+			constants.LogKeyError, err,
+		)
+		return false, err
+	}
+	return completed, nil
+}
+
+// CreateForDate writes the snapshot of every account that existed at cutoffAt.
+// Returns the number of rows inserted.
+func (r *Repository) CreateForDate(ctx context.Context, tx pgx.Tx, date, cutoffAt time.Time) (int64, error) {
+	tag, err := tx.Exec(ctx, queryInsertSnapshots, date, cutoffAt)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToCreateSnapshots,
+			constants.LogFieldSnapshotDate, date,
+			constants.LogKeyError, err,
+		)
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// MarkCompleted records the business date as fully snapshotted
+func (r *Repository) MarkCompleted(ctx context.Context, tx pgx.Tx, date, cutoffAt time.Time, accountCount int64) error {
+	if _, err := tx.Exec(ctx, queryInsertRun, date, cutoffAt, accountCount, time.Now().UTC()); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToCreateSnapshots,
+			constants.LogFieldSnapshotDate, date,
+			constants.LogKeyError, err,
+		)
+		return err
+	}
+	return nil
+}
+
+// ListByAccount returns an account's snapshots between from and to (inclusive), oldest first
+func (r *Repository) ListByAccount(ctx context.Context, accountID int64, from, to time.Time) ([]*Snapshot, error) {
+	rows, err := r.pool.Query(ctx, querySelectByAccount, accountID, from, to)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToListSnapshots,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := make([]*Snapshot, 0)
+	for rows.Next() {
+		var s Snapshot
+		if err := rows.Scan(&s.AccountID, &s.SnapshotDate, &s.Balance, &s.CutoffAt, &s.CreatedAt); err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToListSnapshots,
+				constants.LogKeyAccountID, accountID,
+				constants.LogKeyError, err,
+			)
+			return nil, err
+		}
+		snapshots = append(snapshots, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToListSnapshots,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return nil, err
+	}
+
+	return snapshots, nil
+}
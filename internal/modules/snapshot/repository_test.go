@@ -0,0 +1,281 @@
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/internal/modules/snapshot/entities"
+	dbmock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in repository tests
+var (
+	errRepoQueryFailed = errors.New("query execution failed")
+	errRepoRowsFailed  = errors.New("rows iteration failed")
+)
+
+// RepositoryTestSuite contains tests for snapshot Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockPool *dbmock.MockIPool
+	mockRow  *dbmock.MockRow
+	mockRows *dbmock.MockRows
+	mockTx   *dbmock.MockTx
+	repo     snapshot.IRepository
+	ctx      context.Context
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbmock.NewMockIPool(s.ctrl)
+	s.mockRow = dbmock.NewMockRow(s.ctrl)
+	s.mockRows = dbmock.NewMockRows(s.ctrl)
+	s.mockTx = dbmock.NewMockTx(s.ctrl)
+	s.ctx = context.Background()
+	s.repo = snapshot.NewRepository(s.mockPool)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// Test BeginTx
+
+func (s *RepositoryTestSuite) TestBeginTxUsesReadCommitted() {
+	s.mockPool.EXPECT().
+		BeginTx(s.ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+		Return(s.mockTx, nil).
+		Times(1)
+
+	tx, err := s.repo.BeginTx(s.ctx)
+	s.NoError(err)
+	s.Equal(s.mockTx, tx)
+}
+
+// Test TryLock
+
+func (s *RepositoryTestSuite) TestTryLockReturnsLockResult() {
+	s.mockTx.EXPECT().
+		QueryRow(s.ctx, gomock.Any(), entities.AdvisoryLockKey).
+		Return(s.mockRow).
+		Times(1)
+
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*bool) = true
+			return nil
+		}).
+		Times(1)
+
+	locked, err := s.repo.TryLock(s.ctx, s.mockTx)
+	s.NoError(err)
+	s.True(locked)
+}
+
+func (s *RepositoryTestSuite) TestTryLockWhenQueryFailsReturnsError() {
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), gomock.Any()).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(errRepoQueryFailed).Times(1)
+
+	locked, err := s.repo.TryLock(s.ctx, s.mockTx)
+	s.Equal(errRepoQueryFailed, err)
+	s.False(locked)
+}
+
+// Test LastCompletedDate
+
+func (s *RepositoryTestSuite) TestLastCompletedDateReturnsDate() {
+	expected := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any()).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*time.Time) = expected
+			return nil
+		}).
+		Times(1)
+
+	last, ok, err := s.repo.LastCompletedDate(s.ctx)
+	s.NoError(err)
+	s.True(ok)
+	s.Equal(expected, last)
+}
+
+func (s *RepositoryTestSuite) TestLastCompletedDateWithoutRunsReturnsFalse() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any()).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows).Times(1)
+
+	_, ok, err := s.repo.LastCompletedDate(s.ctx)
+	s.NoError(err)
+	s.False(ok)
+}
+
+func (s *RepositoryTestSuite) TestLastCompletedDateWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().QueryRow(s.ctx, gomock.Any()).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(errRepoQueryFailed).Times(1)
+
+	_, ok, err := s.repo.LastCompletedDate(s.ctx)
+	s.Equal(errRepoQueryFailed, err)
+	s.False(ok)
+}
+
+// Test IsCompleted
+
+func (s *RepositoryTestSuite) TestIsCompletedReturnsExistence() {
+	date := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), date).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*bool) = true
+			return nil
+		}).
+		Times(1)
+
+	completed, err := s.repo.IsCompleted(s.ctx, s.mockTx, date)
+	s.NoError(err)
+	s.True(completed)
+}
+
+func (s *RepositoryTestSuite) TestIsCompletedWhenQueryFailsReturnsError() {
+	s.mockTx.EXPECT().QueryRow(s.ctx, gomock.Any(), gomock.Any()).Return(s.mockRow).Times(1)
+	s.mockRow.EXPECT().Scan(gomock.Any()).Return(errRepoQueryFailed).Times(1)
+
+	_, err := s.repo.IsCompleted(s.ctx, s.mockTx, time.Now())
+	s.Equal(errRepoQueryFailed, err)
+}
+
+// Test CreateForDate
+
+func (s *RepositoryTestSuite) TestCreateForDateReturnsRowsInserted() {
+	date := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	cutoff := date.AddDate(0, 0, 1)
+
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), date, cutoff).
+		Return(pgconn.NewCommandTag("INSERT 0 42"), nil).
+		Times(1)
+
+	count, err := s.repo.CreateForDate(s.ctx, s.mockTx, date, cutoff)
+	s.NoError(err)
+	s.Equal(int64(42), count)
+}
+
+func (s *RepositoryTestSuite) TestCreateForDateWhenExecFailsReturnsError() {
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgconn.CommandTag{}, errRepoQueryFailed).
+		Times(1)
+
+	count, err := s.repo.CreateForDate(s.ctx, s.mockTx, time.Now(), time.Now())
+	s.Equal(errRepoQueryFailed, err)
+	s.Equal(int64(0), count)
+}
+
+// Test MarkCompleted
+
+func (s *RepositoryTestSuite) TestMarkCompletedSucceeds() {
+	date := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	cutoff := date.AddDate(0, 0, 1)
+
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), date, cutoff, int64(42), gomock.Any()).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
+		Times(1)
+
+	s.NoError(s.repo.MarkCompleted(s.ctx, s.mockTx, date, cutoff, 42))
+}
+
+func (s *RepositoryTestSuite) TestMarkCompletedWhenExecFailsReturnsError() {
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgconn.CommandTag{}, errRepoQueryFailed).
+		Times(1)
+
+	s.Equal(errRepoQueryFailed, s.repo.MarkCompleted(s.ctx, s.mockTx, time.Now(), time.Now(), 1))
+}
+
+// Test ListByAccount
+
+func (s *RepositoryTestSuite) TestListByAccountReturnsSnapshots() {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	s.mockPool.EXPECT().Query(s.ctx, gomock.Any(), int64(123), from, to).Return(s.mockRows, nil).Times(1)
+	gomock.InOrder(
+		s.mockRows.EXPECT().Next().Return(true),
+		s.mockRows.EXPECT().Next().Return(false),
+	)
+	s.mockRows.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = 123
+			*dest[1].(*time.Time) = from
+			*dest[2].(*decimal.Decimal) = decimal.RequireFromString("10.5")
+			*dest[3].(*time.Time) = to
+			return nil
+		}).
+		Times(1)
+	s.mockRows.EXPECT().Err().Return(nil).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	snapshots, err := s.repo.ListByAccount(s.ctx, 123, from, to)
+	s.NoError(err)
+	s.Len(snapshots, 1)
+	s.Equal(from, snapshots[0].SnapshotDate)
+	s.True(snapshots[0].Balance.Equal(decimal.RequireFromString("10.5")))
+}
+
+func (s *RepositoryTestSuite) TestListByAccountWhenQueryFailsReturnsError() {
+	s.mockPool.EXPECT().
+		Query(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errRepoQueryFailed).
+		Times(1)
+
+	snapshots, err := s.repo.ListByAccount(s.ctx, 123, time.Now(), time.Now())
+	s.Equal(errRepoQueryFailed, err)
+	s.Nil(snapshots)
+}
+
+func (s *RepositoryTestSuite) TestListByAccountWhenScanFailsReturnsError() {
+	s.mockPool.EXPECT().
+		Query(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(s.mockRows, nil).
+		Times(1)
+	s.mockRows.EXPECT().Next().Return(true).Times(1)
+	s.mockRows.EXPECT().Scan(gomock.Any()).Return(errRepoQueryFailed).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	snapshots, err := s.repo.ListByAccount(s.ctx, 123, time.Now(), time.Now())
+	s.Equal(errRepoQueryFailed, err)
+	s.Nil(snapshots)
+}
+
+func (s *RepositoryTestSuite) TestListByAccountWhenRowsFailReturnsError() {
+	s.mockPool.EXPECT().
+		Query(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(s.mockRows, nil).
+		Times(1)
+	s.mockRows.EXPECT().Next().Return(false).Times(1)
+	s.mockRows.EXPECT().Err().Return(errRepoRowsFailed).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	snapshots, err := s.repo.ListByAccount(s.ctx, 123, time.Now(), time.Now())
+	s.Equal(errRepoRowsFailed, err)
+	s.Nil(snapshots)
+}
@@ -0,0 +1,64 @@
+package snapshot
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // ship the zone database so configured time zones resolve in minimal images
+
+	"github.com/internal-transfers-service/internal/modules/snapshot/entities"
+)
+
+// Schedule maps business dates to the instants at which they close.
+// Business dates are civil dates, represented as midnight UTC.
+type Schedule struct {
+	hour   int
+	minute int
+	loc    *time.Location
+	grace  time.Duration
+}
+
+// NewSchedule parses the cutoff ("HH:MM", "24:00" allowed) and time zone
+func NewSchedule(cutoff, timezone string, grace time.Duration) (*Schedule, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(cutoff, "%d:%d", &hour, &minute); err != nil ||
+		hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return nil, fmt.Errorf("%s: %q", entities.ErrMsgInvalidCutoff, cutoff)
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", entities.ErrMsgInvalidTimezone, err)
+	}
+
+	return &Schedule{hour: hour, minute: minute, loc: loc, grace: grace}, nil
+}
+
+// Location returns the time zone business dates are expressed in
+func (s *Schedule) Location() *time.Location {
+	return s.loc
+}
+
+// CutoffOf returns the instant at which the business date closes
+func (s *Schedule) CutoffOf(date time.Time) time.Time {
+	// time.Date normalises hour 24 to midnight of the following day
+	return time.Date(date.Year(), date.Month(), date.Day(), s.hour, s.minute, 0, 0, s.loc)
+}
+
+// Today returns the business date containing now in the schedule's time zone
+func (s *Schedule) Today(now time.Time) time.Time {
+	return DateOf(now.In(s.loc))
+}
+
+// LatestDue returns the most recent business date whose cutoff plus grace has passed
+func (s *Schedule) LatestDue(now time.Time) time.Time {
+	date := s.Today(now)
+	for s.CutoffOf(date).Add(s.grace).After(now) {
+		date = date.AddDate(0, 0, -1)
+	}
+	return date
+}
+
+// DateOf truncates t to its civil date (in t's location), returned as midnight UTC
+func DateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
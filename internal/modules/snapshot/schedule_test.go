@@ -0,0 +1,91 @@
+package snapshot_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/stretchr/testify/suite"
+)
+
+// ScheduleTestSuite contains tests for the snapshot Schedule
+type ScheduleTestSuite struct {
+	suite.Suite
+}
+
+func TestScheduleSuite(t *testing.T) {
+	suite.Run(t, new(ScheduleTestSuite))
+}
+
+func (s *ScheduleTestSuite) TestNewScheduleWithValidCutoffSucceeds() {
+	schedule, err := snapshot.NewSchedule("17:30", "UTC", 0)
+	s.NoError(err)
+
+	cutoff := schedule.CutoffOf(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+	s.Equal(time.Date(2026, 3, 10, 17, 30, 0, 0, time.UTC), cutoff)
+}
+
+func (s *ScheduleTestSuite) TestNewScheduleWithMidnightCutoffClosesAtStartOfNextDay() {
+	schedule, err := snapshot.NewSchedule("24:00", "UTC", 0)
+	s.NoError(err)
+
+	cutoff := schedule.CutoffOf(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+	s.True(cutoff.Equal(time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)))
+}
+
+func (s *ScheduleTestSuite) TestNewScheduleWithInvalidCutoffFails() {
+	for _, cutoff := range []string{"", "noon", "25:00", "24:30", "12:60", "-1:00"} {
+		_, err := snapshot.NewSchedule(cutoff, "UTC", 0)
+		s.Error(err, cutoff)
+	}
+}
+
+func (s *ScheduleTestSuite) TestNewScheduleWithInvalidTimezoneFails() {
+	_, err := snapshot.NewSchedule("24:00", "Mars/Olympus_Mons", 0)
+	s.Error(err)
+}
+
+func (s *ScheduleTestSuite) TestCutoffOfUsesConfiguredTimezone() {
+	schedule, err := snapshot.NewSchedule("18:00", "America/New_York", 0)
+	s.NoError(err)
+
+	cutoff := schedule.CutoffOf(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	s.True(cutoff.Equal(time.Date(2026, 1, 15, 23, 0, 0, 0, time.UTC)))
+}
+
+func (s *ScheduleTestSuite) TestTodayUsesConfiguredTimezone() {
+	schedule, err := snapshot.NewSchedule("24:00", "Asia/Tokyo", 0)
+	s.NoError(err)
+
+	// 20:00 UTC is already the next day in Tokyo
+	today := schedule.Today(time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC))
+	s.Equal(time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), today)
+}
+
+func (s *ScheduleTestSuite) TestLatestDueReturnsPreviousDayAfterMidnightCutoff() {
+	schedule, err := snapshot.NewSchedule("24:00", "UTC", time.Minute)
+	s.NoError(err)
+
+	latest := schedule.LatestDue(time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+	s.Equal(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), latest)
+}
+
+func (s *ScheduleTestSuite) TestLatestDueWaitsForGracePeriod() {
+	schedule, err := snapshot.NewSchedule("24:00", "UTC", 5*time.Minute)
+	s.NoError(err)
+
+	// Three minutes after midnight the previous day is still within its grace period
+	latest := schedule.LatestDue(time.Date(2026, 3, 10, 0, 3, 0, 0, time.UTC))
+	s.Equal(time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), latest)
+
+	latest = schedule.LatestDue(time.Date(2026, 3, 10, 0, 5, 0, 0, time.UTC))
+	s.Equal(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), latest)
+}
+
+func (s *ScheduleTestSuite) TestLatestDueReturnsTodayAfterIntradayCutoff() {
+	schedule, err := snapshot.NewSchedule("17:00", "UTC", 0)
+	s.NoError(err)
+
+	latest := schedule.LatestDue(time.Date(2026, 3, 10, 17, 0, 0, 0, time.UTC))
+	s.Equal(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), latest)
+}
@@ -0,0 +1,167 @@
+package snapshot_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	accountMock "github.com/internal-transfers-service/internal/modules/account/mock"
+	"github.com/internal-transfers-service/internal/modules/snapshot"
+	"github.com/internal-transfers-service/internal/modules/snapshot/entities"
+	"github.com/internal-transfers-service/internal/modules/snapshot/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for snapshot HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	handler  *snapshot.HTTPHandler
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.handler = snapshot.NewHTTPHandler(s.mockCore)
+	s.router = chi.NewRouter()
+	s.handler.RegisterRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestListSnapshotsPassesRangeToCore() {
+	expectedRequest := &entities.ListSnapshotsRequest{
+		AccountID: 123,
+		From:      "2026-03-01",
+		To:        "2026-03-02",
+	}
+
+	s.mockCore.EXPECT().
+		ListSnapshots(gomock.Any(), expectedRequest).
+		Return(&entities.ListSnapshotsResponse{
+			AccountID: 123,
+			Currency:  "USD",
+			From:      "2026-03-01",
+			To:        "2026-03-02",
+			Snapshots: []*entities.SnapshotResponse{
+				{Date: "2026-03-01", Balance: "10.5", CutoffAt: "2026-03-02T00:00:00Z"},
+			},
+		}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/123/snapshots?from=2026-03-01&to=2026-03-02", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+
+	var response entities.ListSnapshotsResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Len(response.Snapshots, 1)
+	s.Equal("10.5", response.Snapshots[0].Balance)
+}
+
+func (s *ServerTestSuite) TestListSnapshotsWithInvalidAccountIDReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodGet, "/accounts/abc/snapshots", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestListSnapshotsWhenCoreFailsReturnsError() {
+	s.mockCore.EXPECT().
+		ListSnapshots(gomock.Any(), gomock.Any()).
+		Return(nil, apperror.NewWithMessage(apperror.CodeNotFound, snapshot.ErrAccountNotFound, apperror.MsgAccountNotFound)).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/999/snapshots", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusNotFound, rec.Code)
+
+	var response apperror.ErrorResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal(apperror.MsgAccountNotFound, response.Error)
+}
+
+// InitTestSuite contains tests for snapshot module initialization
+type InitTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestInitSuite(t *testing.T) {
+	suite.Run(t, new(InitTestSuite))
+}
+
+func (s *InitTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+// TestModuleMethodsReturnCorrectValues verifies module methods
+func (s *InitTestSuite) TestModuleMethodsReturnCorrectValues() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockIRepository(ctrl)
+	schedule, err := snapshot.NewSchedule("24:00", "UTC", 0)
+	s.Require().NoError(err)
+	core := snapshot.NewCore(s.ctx, mockRepo, accountMock.NewMockIRepository(ctrl), schedule, 1)
+	handler := snapshot.NewHTTPHandler(core)
+
+	module := &snapshot.Module{
+		Core:    core,
+		Handler: handler,
+		Repo:    mockRepo,
+	}
+
+	s.Equal(core, module.GetCore())
+	s.Equal(handler, module.GetHandler())
+	s.Equal(mockRepo, module.GetRepository())
+	s.Equal(core, snapshot.GetCore())
+}
+
+// TestWorkerRunsImmediatelyAndStops verifies the worker runs on start and stops cleanly
+func (s *InitTestSuite) TestWorkerRunsImmediatelyAndStops() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	mockCore := mock.NewMockICore(ctrl)
+	ran := make(chan struct{})
+	mockCore.EXPECT().
+		RunDue(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ time.Time) (int, error) {
+			close(ran)
+			return 0, nil
+		}).
+		Times(1)
+
+	module := &snapshot.Module{Core: mockCore}
+	module.StartWorker(s.ctx, time.Hour)
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		s.Fail("snapshot worker did not run")
+	}
+	module.StopWorker()
+}
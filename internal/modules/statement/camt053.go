@@ -0,0 +1,237 @@
+package statement
+
+import (
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/modules/statement/entities"
+	"github.com/shopspring/decimal"
+)
+
+// camt053Writer streams an ISO 20022 BankToCustomerStatement (camt.053.001.02).
+// The schema places balances before entries, so both are taken from the header
+// and each entry is encoded as soon as it is read.
+type camt053Writer struct {
+	w        io.Writer
+	enc      *xml.Encoder
+	currency string
+}
+
+func newCAMT053Writer(w io.Writer) *camt053Writer {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return &camt053Writer{w: w, enc: enc}
+}
+
+// XML element names that are opened and closed around the streamed entries
+var (
+	camtDocument  = xml.StartElement{Name: xml.Name{Local: "Document"}, Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: entities.CAMT053Namespace}}}
+	camtStatement = xml.StartElement{Name: xml.Name{Local: "BkToCstmrStmt"}}
+	camtStmt      = xml.StartElement{Name: xml.Name{Local: "Stmt"}}
+)
+
+// camtElement is a named element written between streamed tokens
+type camtElement struct {
+	name  string
+	value any
+}
+
+type camtGroupHeader struct {
+	MsgID   string `xml:"MsgId"`
+	CreDtTm string `xml:"CreDtTm"`
+}
+
+type camtPeriod struct {
+	FrDtTm string `xml:"FrDtTm"`
+	ToDtTm string `xml:"ToDtTm"`
+}
+
+type camtGenericID struct {
+	ID string `xml:"Id"`
+}
+
+type camtAccountID struct {
+	Othr camtGenericID `xml:"Othr"`
+}
+
+type camtAccount struct {
+	ID  camtAccountID `xml:"Id"`
+	Ccy string        `xml:"Ccy,omitempty"`
+}
+
+type camtAmount struct {
+	Ccy   string `xml:"Ccy,attr"`
+	Value string `xml:",chardata"`
+}
+
+type camtDateTime struct {
+	DtTm string `xml:"DtTm"`
+}
+
+type camtCode struct {
+	Cd string `xml:"Cd"`
+}
+
+type camtBalanceType struct {
+	CdOrPrtry camtCode `xml:"CdOrPrtry"`
+}
+
+type camtBalance struct {
+	Tp        camtBalanceType `xml:"Tp"`
+	Amt       camtAmount      `xml:"Amt"`
+	CdtDbtInd string          `xml:"CdtDbtInd"`
+	Dt        camtDateTime    `xml:"Dt"`
+}
+
+type camtBankTxCode struct {
+	Prtry camtCode `xml:"Prtry"`
+}
+
+type camtRelatedParties struct {
+	DbtrAcct *camtAccount `xml:"DbtrAcct,omitempty"`
+	CdtrAcct *camtAccount `xml:"CdtrAcct,omitempty"`
+}
+
+type camtTxDetails struct {
+	RltdPties camtRelatedParties `xml:"RltdPties"`
+}
+
+type camtEntryDetails struct {
+	TxDtls camtTxDetails `xml:"TxDtls"`
+}
+
+// camtEntry is a ReportEntry2; field order follows the schema sequence
+type camtEntry struct {
+	NtryRef      string            `xml:"NtryRef"`
+	Amt          camtAmount        `xml:"Amt"`
+	CdtDbtInd    string            `xml:"CdtDbtInd"`
+	Sts          string            `xml:"Sts"`
+	BookgDt      camtDateTime      `xml:"BookgDt"`
+	ValDt        camtDateTime      `xml:"ValDt"`
+	AcctSvcrRef  string            `xml:"AcctSvcrRef"`
+	BkTxCd       camtBankTxCode    `xml:"BkTxCd"`
+	NtryDtls     *camtEntryDetails `xml:"NtryDtls,omitempty"`
+	AddtlNtryInf string            `xml:"AddtlNtryInf"`
+}
+
+// WriteHeader opens the document and writes the group header, account and balances
+func (c *camt053Writer) WriteHeader(h *entities.StatementHeader) error {
+	c.currency = h.Currency
+
+	// Written directly, ahead of anything the encoder has buffered
+	if _, err := io.WriteString(c.w, xml.Header); err != nil {
+		return err
+	}
+	for _, start := range []xml.StartElement{camtDocument, camtStatement} {
+		if err := c.enc.EncodeToken(start); err != nil {
+			return err
+		}
+	}
+
+	created := formatTimestamp(h.GeneratedAt)
+	msgID := uuid.New()
+	if err := c.encodeElements(
+		camtElement{"GrpHdr", camtGroupHeader{MsgID: hex.EncodeToString(msgID[:]), CreDtTm: created}},
+	); err != nil {
+		return err
+	}
+
+	if err := c.enc.EncodeToken(camtStmt); err != nil {
+		return err
+	}
+	return c.encodeElements(
+		camtElement{"Id", statementID(h)},
+		camtElement{"CreDtTm", created},
+		camtElement{"FrToDt", camtPeriod{FrDtTm: formatTimestamp(h.From), ToDtTm: formatTimestamp(h.To)}},
+		camtElement{"Acct", camtAccount{ID: accountID(h.AccountID), Ccy: h.Currency}},
+		camtElement{"Bal", c.balance(entities.CAMTBalanceOpening, h.OpeningBalance, formatTimestamp(h.From))},
+		camtElement{"Bal", c.balance(entities.CAMTBalanceClosing, h.ClosingBalance, formatTimestamp(h.To))},
+	)
+}
+
+// WriteLine writes one Ntry element
+func (c *camt053Writer) WriteLine(l *entities.StatementLine) error {
+	ref := hex.EncodeToString(l.TransactionID[:])
+	booked := camtDateTime{DtTm: formatTimestamp(l.BookedAt)}
+
+	entry := camtEntry{
+		NtryRef:      ref,
+		Amt:          c.amount(l.Amount),
+		CdtDbtInd:    creditDebit(l.Amount),
+		Sts:          entities.CAMTStatusBooked,
+		BookgDt:      booked,
+		ValDt:        booked,
+		AcctSvcrRef:  ref,
+		BkTxCd:       camtBankTxCode{Prtry: camtCode{Cd: l.Type}},
+		AddtlNtryInf: fmt.Sprintf(entities.CAMTRunningBalanceFormat, l.Balance.String(), c.currency),
+	}
+
+	if l.CounterpartyAccountID != nil {
+		counterparty := &camtAccount{ID: accountID(*l.CounterpartyAccountID)}
+		parties := camtRelatedParties{CdtrAcct: counterparty}
+		if l.Amount.IsPositive() {
+			parties = camtRelatedParties{DbtrAcct: counterparty}
+		}
+		entry.NtryDtls = &camtEntryDetails{TxDtls: camtTxDetails{RltdPties: parties}}
+	}
+
+	return c.enc.EncodeElement(entry, xml.StartElement{Name: xml.Name{Local: "Ntry"}})
+}
+
+// WriteFooter closes the open elements and flushes.
+// Closing balance was already written with the header.
+func (c *camt053Writer) WriteFooter(_ *entities.StatementHeader) error {
+	for _, start := range []xml.StartElement{camtStmt, camtStatement, camtDocument} {
+		if err := c.enc.EncodeToken(start.End()); err != nil {
+			return err
+		}
+	}
+	return c.enc.Flush()
+}
+
+// encodeElements encodes each value as an element with the given name
+func (c *camt053Writer) encodeElements(elements ...camtElement) error {
+	for _, e := range elements {
+		if err := c.enc.EncodeElement(e.value, xml.StartElement{Name: xml.Name{Local: e.name}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// balance builds a Bal element of the given type
+func (c *camt053Writer) balance(code string, amount decimal.Decimal, at string) camtBalance {
+	return camtBalance{
+		Tp:        camtBalanceType{CdOrPrtry: camtCode{Cd: code}},
+		Amt:       c.amount(amount),
+		CdtDbtInd: creditDebit(amount),
+		Dt:        camtDateTime{DtTm: at},
+	}
+}
+
+// amount renders an unsigned amount; the schema allows at most five fraction digits
+func (c *camt053Writer) amount(amount decimal.Decimal) camtAmount {
+	return camtAmount{Ccy: c.currency, Value: amount.Abs().Round(entities.CAMTAmountFracDigits).String()}
+}
+
+// creditDebit returns CRDT for zero or positive amounts and DBIT for negative ones
+func creditDebit(amount decimal.Decimal) string {
+	if amount.IsNegative() {
+		return entities.CAMTDebit
+	}
+	return entities.CAMTCredit
+}
+
+// accountID identifies an internal account by its numeric ID
+func accountID(id int64) camtAccountID {
+	return camtAccountID{Othr: camtGenericID{ID: strconv.FormatInt(id, 10)}}
+}
+
+// statementID derives a stable statement identifier (max 35 characters) from account and period end
+func statementID(h *entities.StatementHeader) string {
+	return strconv.FormatInt(h.AccountID, 10) + "-" + h.To.UTC().Format(entities.CAMTStatementIDTimeLayout)
+}
@@ -0,0 +1,213 @@
+package statement
+
+//go:generate mockgen -source=core.go -destination=mock/mock_core.go -package=mock
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/account"
+	"github.com/internal-transfers-service/internal/modules/statement/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/jackc/pgx/v5"
+)
+
+// Domain errors
+var (
+	ErrAccountNotFound    = errors.New(entities.ErrMsgAccountNotFound)
+	ErrInvalidAccountID   = errors.New(entities.ErrMsgInvalidAccountID)
+	ErrInvalidTimestamp   = errors.New(entities.ErrMsgInvalidTimestamp)
+	ErrInvalidPeriod      = errors.New(entities.ErrMsgInvalidPeriod)
+	ErrUnsupportedFormat  = errors.New(entities.ErrMsgUnsupportedFormat)
+	ErrStatementReadError = errors.New(entities.ErrMsgStatementReadError)
+)
+
+// ICore defines the interface for statement business logic
+type ICore interface {
+	Export(ctx context.Context, req *entities.StatementRequest, w io.Writer) apperror.IError
+}
+
+// Core implements ICore
+type Core struct {
+	repo        IRepository
+	accountRepo account.IRepository
+}
+
+// Compile-time interface check
+var _ ICore = (*Core)(nil)
+
+// coreInstance is the singleton instance
+var coreInstance ICore
+
+// NewCore creates a new Core instance
+func NewCore(_ context.Context, repo IRepository, accountRepo account.IRepository) ICore {
+	coreInstance = &Core{
+		repo:        repo,
+		accountRepo: accountRepo,
+	}
+	return coreInstance
+}
+
+// GetCore returns the singleton Core instance
+func GetCore() ICore {
+	return coreInstance
+}
+
+// Export streams an account statement for the requested period to w.
+// Nothing is written to w until the request has been validated and the opening and
+// closing balances are known, so an error returned before then can still be
+// reported to the client in place of the statement.
+func (c *Core) Export(ctx context.Context, req *entities.StatementRequest, w io.Writer) apperror.IError {
+	if req.AccountID <= 0 {
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, req.AccountID)
+	}
+
+	writer, ok := NewWriter(req.Format, w)
+	if !ok {
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrUnsupportedFormat, apperror.MsgUnsupportedFormat).
+			WithField(apperror.FieldFormat, req.Format)
+	}
+
+	now := time.Now().UTC()
+	from, to, appErr := resolvePeriod(req, now)
+	if appErr != nil {
+		return appErr
+	}
+
+	acc, err := c.accountRepo.GetByID(ctx, req.AccountID)
+	if err != nil {
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) && appErr.Code() == apperror.CodeNotFound {
+			return apperror.NewWithMessage(apperror.CodeNotFound, ErrAccountNotFound, apperror.MsgAccountNotFound).
+				WithField(apperror.FieldAccountID, req.AccountID)
+		}
+		return apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, req.AccountID)
+	}
+
+	tx, err := c.repo.BeginTx(ctx)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToBeginStatement,
+			constants.LogKeyAccountID, req.AccountID,
+			constants.LogKeyError, err,
+		)
+		return apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, req.AccountID)
+	}
+	// The transaction is read-only, so it is always rolled back
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	header := &entities.StatementHeader{
+		AccountID:   acc.AccountID,
+		Currency:    acc.Currency,
+		From:        from,
+		To:          to,
+		GeneratedAt: now,
+	}
+	if header.OpeningBalance, err = c.accountRepo.GetBalanceAsOfTx(ctx, tx, req.AccountID, from); err != nil {
+		return apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, req.AccountID)
+	}
+	if header.ClosingBalance, err = c.accountRepo.GetBalanceAsOfTx(ctx, tx, req.AccountID, to); err != nil {
+		return apperror.New(apperror.CodeInternalError, err).
+			WithField(apperror.FieldAccountID, req.AccountID)
+	}
+
+	count, err := c.stream(ctx, tx, header, writer)
+	if err != nil {
+		return apperror.New(apperror.CodeInternalError, errors.Join(ErrStatementReadError, err)).
+			WithField(apperror.FieldAccountID, req.AccountID)
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgStatementExported,
+		constants.LogKeyAccountID, req.AccountID,
+		constants.LogFieldFormat, req.Format,
+		constants.LogFieldEntryCount, count,
+	)
+	return nil
+}
+
+// stream writes the header, each entry with its running balance, and the footer.
+// Returns the number of entries written.
+func (c *Core) stream(ctx context.Context, tx pgx.Tx, header *entities.StatementHeader, writer Writer) (int, error) {
+	if err := writer.WriteHeader(header); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	running := header.OpeningBalance
+	err := c.repo.StreamEntries(ctx, tx, header.AccountID, header.From, header.To, func(e *Entry) error {
+		line := toLine(e, header.AccountID)
+		running = running.Add(line.Amount)
+		line.Balance = running
+		count++
+		return writer.WriteLine(line)
+	})
+	if err != nil {
+		return count, err
+	}
+
+	return count, writer.WriteFooter(header)
+}
+
+// toLine converts a transaction into a statement line from the account's point of view
+func toLine(e *Entry, accountID int64) *entities.StatementLine {
+	line := &entities.StatementLine{
+		TransactionID: e.ID,
+		Type:          e.Type,
+		BookedAt:      e.CreatedAt,
+	}
+	if e.DestinationAccountID == accountID {
+		line.Amount = e.Amount
+		line.CounterpartyAccountID = e.SourceAccountID
+	} else {
+		line.Amount = e.Amount.Neg()
+		counterparty := e.DestinationAccountID
+		line.CounterpartyAccountID = &counterparty
+	}
+	return line
+}
+
+// resolvePeriod parses the requested timestamps and applies defaults:
+// to defaults to now and from to DefaultPeriod before to
+func resolvePeriod(req *entities.StatementRequest, now time.Time) (time.Time, time.Time, apperror.IError) {
+	to := now
+	if req.To != "" {
+		parsed, appErr := parseTimestamp(req.To, apperror.FieldTo)
+		if appErr != nil {
+			return time.Time{}, time.Time{}, appErr
+		}
+		to = parsed
+	}
+
+	from := to.Add(-entities.DefaultPeriod)
+	if req.From != "" {
+		parsed, appErr := parseTimestamp(req.From, apperror.FieldFrom)
+		if appErr != nil {
+			return time.Time{}, time.Time{}, appErr
+		}
+		from = parsed
+	}
+
+	if !from.Before(to) || to.After(now) {
+		return time.Time{}, time.Time{}, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidPeriod, apperror.MsgInvalidPeriod).
+			WithField(apperror.FieldFrom, from.Format(time.RFC3339Nano)).
+			WithField(apperror.FieldTo, to.Format(time.RFC3339Nano))
+	}
+	return from, to, nil
+}
+
+// parseTimestamp parses an RFC3339 timestamp
+func parseTimestamp(value, field string) (time.Time, apperror.IError) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidTimestamp, apperror.MsgInvalidTimestamp).
+			WithField(field, value)
+	}
+	return t.UTC(), nil
+}
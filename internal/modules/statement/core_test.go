@@ -0,0 +1,429 @@
+package statement_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/modules/account"
+	accountEntities "github.com/internal-transfers-service/internal/modules/account/entities"
+	accountMock "github.com/internal-transfers-service/internal/modules/account/mock"
+	"github.com/internal-transfers-service/internal/modules/statement"
+	"github.com/internal-transfers-service/internal/modules/statement/entities"
+	"github.com/internal-transfers-service/internal/modules/statement/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	dbmock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in tests
+var (
+	errDatabaseError = errors.New("database error")
+)
+
+// Test fixtures
+var (
+	testFrom          = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	testTo            = time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	testDepositID     = uuid.MustParse("0b7e8d2a-5c1f-4e3a-9d6b-2f8a1c4e7b90")
+	testTransferID    = uuid.MustParse("6f1d2c3b-4a5e-4f60-8b7c-9d0e1f2a3b4c")
+	testClearingID    = int64(1)
+	testCounterpartID = int64(456)
+)
+
+// CoreTestSuite contains tests for statement Core
+type CoreTestSuite struct {
+	suite.Suite
+	ctrl            *gomock.Controller
+	mockRepo        *mock.MockIRepository
+	mockAccountRepo *accountMock.MockIRepository
+	mockTx          *dbmock.MockTx
+	core            statement.ICore
+	ctx             context.Context
+}
+
+func TestCoreSuite(t *testing.T) {
+	suite.Run(t, new(CoreTestSuite))
+}
+
+func (s *CoreTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = mock.NewMockIRepository(s.ctrl)
+	s.mockAccountRepo = accountMock.NewMockIRepository(s.ctrl)
+	s.mockTx = dbmock.NewMockTx(s.ctrl)
+	s.ctx = context.Background()
+	s.core = statement.NewCore(s.ctx, s.mockRepo, s.mockAccountRepo)
+}
+
+func (s *CoreTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// request returns a statement request for account 123 over the test period
+func request(format string) *entities.StatementRequest {
+	return &entities.StatementRequest{
+		AccountID: 123,
+		From:      testFrom.Format(time.RFC3339),
+		To:        testTo.Format(time.RFC3339),
+		Format:    format,
+	}
+}
+
+// expectStatement sets up an account with an opening balance of 100, a deposit of 50.5
+// and a transfer out of 30.25, closing at 120.25
+func (s *CoreTestSuite) expectStatement() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123, Currency: "USD", Kind: accountEntities.KindCustomer}, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+	s.mockAccountRepo.EXPECT().
+		GetBalanceAsOfTx(s.ctx, s.mockTx, int64(123), testFrom).
+		Return(decimal.RequireFromString("100"), nil)
+	s.mockAccountRepo.EXPECT().
+		GetBalanceAsOfTx(s.ctx, s.mockTx, int64(123), testTo).
+		Return(decimal.RequireFromString("120.25"), nil)
+	s.mockRepo.EXPECT().
+		StreamEntries(s.ctx, s.mockTx, int64(123), testFrom, testTo, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, _ int64, _, _ time.Time, fn func(*statement.Entry) error) error {
+			clearing := testClearingID
+			entries := []*statement.Entry{
+				{
+					ID:                   testDepositID,
+					SourceAccountID:      &clearing,
+					DestinationAccountID: 123,
+					Amount:               decimal.RequireFromString("50.5"),
+					Type:                 "deposit",
+					CreatedAt:            time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:                   testTransferID,
+					SourceAccountID:      func() *int64 { id := int64(123); return &id }(),
+					DestinationAccountID: testCounterpartID,
+					Amount:               decimal.RequireFromString("30.25"),
+					Type:                 "transfer",
+					CreatedAt:            time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC),
+				},
+			}
+			for _, e := range entries {
+				if err := fn(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+// Test Export - formats
+
+func (s *CoreTestSuite) TestExportCSVWritesBalancesAndRunningBalance() {
+	s.expectStatement()
+
+	var out bytes.Buffer
+	err := s.core.Export(s.ctx, request(entities.FormatCSV), &out)
+	s.Nil(err)
+
+	expected := strings.Join([]string{
+		"record,booked_at,transaction_id,type,counterparty_account_id,amount,balance",
+		"opening_balance,2026-03-01T00:00:00Z,,,,,100",
+		"entry,2026-03-02T09:00:00Z," + testDepositID.String() + ",deposit,1,50.5,150.5",
+		"entry,2026-03-03T09:00:00Z," + testTransferID.String() + ",transfer,456,-30.25,120.25",
+		"closing_balance,2026-03-31T00:00:00Z,,,,,120.25",
+		"",
+	}, "\n")
+	s.Equal(expected, out.String())
+}
+
+func (s *CoreTestSuite) TestExportJSONLWritesOneRecordPerLine() {
+	s.expectStatement()
+
+	var out bytes.Buffer
+	err := s.core.Export(s.ctx, request(entities.FormatJSONL), &out)
+	s.Nil(err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	s.Require().Len(lines, 4)
+
+	records := make([]entities.StatementRecord, len(lines))
+	for i, line := range lines {
+		s.Require().NoError(json.Unmarshal([]byte(line), &records[i]))
+	}
+	s.Equal(entities.RecordOpeningBalance, records[0].Record)
+	s.Equal("USD", records[0].Currency)
+	s.Equal("100", records[0].Balance)
+	s.Equal(entities.RecordEntry, records[1].Record)
+	s.Equal("50.5", records[1].Amount)
+	s.Equal("150.5", records[1].Balance)
+	s.Equal(testClearingID, *records[1].CounterpartyAccountID)
+	s.Equal("-30.25", records[2].Amount)
+	s.Equal("120.25", records[2].Balance)
+	s.Equal(entities.RecordClosingBalance, records[3].Record)
+	s.Equal("120.25", records[3].Balance)
+}
+
+// camtDocument mirrors the parts of a camt.053 document checked by the tests
+type camtDocument struct {
+	XMLName xml.Name `xml:"urn:iso:std:iso:20022:tech:xsd:camt.053.001.02 Document"`
+	Stmt    struct {
+		ID   string `xml:"Id"`
+		Acct struct {
+			ID  string `xml:"Id>Othr>Id"`
+			Ccy string `xml:"Ccy"`
+		} `xml:"Acct"`
+		Bal []struct {
+			Code      string `xml:"Tp>CdOrPrtry>Cd"`
+			Amt       string `xml:"Amt"`
+			Ccy       string `xml:"Amt,attr"`
+			CdtDbtInd string `xml:"CdtDbtInd"`
+		} `xml:"Bal"`
+		Ntry []struct {
+			NtryRef      string `xml:"NtryRef"`
+			Amt          string `xml:"Amt"`
+			CdtDbtInd    string `xml:"CdtDbtInd"`
+			Sts          string `xml:"Sts"`
+			Code         string `xml:"BkTxCd>Prtry>Cd"`
+			DbtrAcct     string `xml:"NtryDtls>TxDtls>RltdPties>DbtrAcct>Id>Othr>Id"`
+			CdtrAcct     string `xml:"NtryDtls>TxDtls>RltdPties>CdtrAcct>Id>Othr>Id"`
+			AddtlNtryInf string `xml:"AddtlNtryInf"`
+		} `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+func (s *CoreTestSuite) TestExportCAMT053WritesBalancesBeforeEntries() {
+	s.expectStatement()
+
+	var out bytes.Buffer
+	err := s.core.Export(s.ctx, request(entities.FormatCAMT053), &out)
+	s.Nil(err)
+	s.True(strings.HasPrefix(out.String(), `<?xml version="1.0" encoding="UTF-8"?>`))
+
+	var doc camtDocument
+	s.Require().NoError(xml.Unmarshal(out.Bytes(), &doc))
+
+	s.Equal("123-20260331T000000", doc.Stmt.ID)
+	s.Equal("123", doc.Stmt.Acct.ID)
+	s.Equal("USD", doc.Stmt.Acct.Ccy)
+
+	s.Require().Len(doc.Stmt.Bal, 2)
+	s.Equal(entities.CAMTBalanceOpening, doc.Stmt.Bal[0].Code)
+	s.Equal("100", doc.Stmt.Bal[0].Amt)
+	s.Equal(entities.CAMTBalanceClosing, doc.Stmt.Bal[1].Code)
+	s.Equal("120.25", doc.Stmt.Bal[1].Amt)
+	s.Equal(entities.CAMTCredit, doc.Stmt.Bal[1].CdtDbtInd)
+
+	s.Require().Len(doc.Stmt.Ntry, 2)
+	s.Equal(strings.ReplaceAll(testDepositID.String(), "-", ""), doc.Stmt.Ntry[0].NtryRef)
+	s.Equal("50.5", doc.Stmt.Ntry[0].Amt)
+	s.Equal(entities.CAMTCredit, doc.Stmt.Ntry[0].CdtDbtInd)
+	s.Equal(entities.CAMTStatusBooked, doc.Stmt.Ntry[0].Sts)
+	s.Equal("deposit", doc.Stmt.Ntry[0].Code)
+	s.Equal("1", doc.Stmt.Ntry[0].DbtrAcct)
+	s.Equal("Balance after entry: 150.5 USD", doc.Stmt.Ntry[0].AddtlNtryInf)
+	s.Equal("30.25", doc.Stmt.Ntry[1].Amt)
+	s.Equal(entities.CAMTDebit, doc.Stmt.Ntry[1].CdtDbtInd)
+	s.Equal("456", doc.Stmt.Ntry[1].CdtrAcct)
+}
+
+func (s *CoreTestSuite) TestExportCAMT053WritesNegativeBalancesAsDebitsWithFiveDecimals() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123, Currency: "USD"}, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+	s.mockAccountRepo.EXPECT().
+		GetBalanceAsOfTx(s.ctx, s.mockTx, int64(123), gomock.Any()).
+		Return(decimal.RequireFromString("-12.12345678"), nil).
+		Times(2)
+	s.mockRepo.EXPECT().
+		StreamEntries(s.ctx, s.mockTx, int64(123), testFrom, testTo, gomock.Any()).
+		Return(nil)
+
+	var out bytes.Buffer
+	s.Nil(s.core.Export(s.ctx, request(entities.FormatCAMT053), &out))
+
+	var doc camtDocument
+	s.Require().NoError(xml.Unmarshal(out.Bytes(), &doc))
+	s.Require().Len(doc.Stmt.Bal, 2)
+	s.Equal("12.12346", doc.Stmt.Bal[0].Amt)
+	s.Equal(entities.CAMTDebit, doc.Stmt.Bal[0].CdtDbtInd)
+	s.Empty(doc.Stmt.Ntry)
+}
+
+func (s *CoreTestSuite) TestExportOpeningEntryHasNoCounterparty() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123, Currency: "USD"}, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+	s.mockAccountRepo.EXPECT().
+		GetBalanceAsOfTx(s.ctx, s.mockTx, int64(123), testFrom).
+		Return(decimal.Zero, nil)
+	s.mockAccountRepo.EXPECT().
+		GetBalanceAsOfTx(s.ctx, s.mockTx, int64(123), testTo).
+		Return(decimal.RequireFromString("10"), nil)
+	s.mockRepo.EXPECT().
+		StreamEntries(s.ctx, s.mockTx, int64(123), testFrom, testTo, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, _ int64, _, _ time.Time, fn func(*statement.Entry) error) error {
+			return fn(&statement.Entry{
+				ID:                   testDepositID,
+				DestinationAccountID: 123,
+				Amount:               decimal.RequireFromString("10"),
+				Type:                 "opening",
+				CreatedAt:            time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+			})
+		})
+
+	var out bytes.Buffer
+	s.Nil(s.core.Export(s.ctx, request(entities.FormatCSV), &out))
+	s.Contains(out.String(), ",opening,,10,10\n")
+}
+
+// Test Export - validation
+
+func (s *CoreTestSuite) TestExportWithInvalidAccountIDFails() {
+	req := request(entities.FormatCSV)
+	req.AccountID = 0
+
+	var out bytes.Buffer
+	err := s.core.Export(s.ctx, req, &out)
+	s.NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Zero(out.Len())
+}
+
+func (s *CoreTestSuite) TestExportWithUnsupportedFormatFails() {
+	var out bytes.Buffer
+	err := s.core.Export(s.ctx, request("pdf"), &out)
+	s.NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgUnsupportedFormat, err.PublicMessage())
+	s.Zero(out.Len())
+}
+
+func (s *CoreTestSuite) TestExportWithInvalidTimestampFails() {
+	req := request(entities.FormatCSV)
+	req.From = "2026-03-01"
+
+	err := s.core.Export(s.ctx, req, &bytes.Buffer{})
+	s.NotNil(err)
+	s.Equal(apperror.MsgInvalidTimestamp, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestExportWithReversedPeriodFails() {
+	req := request(entities.FormatCSV)
+	req.From, req.To = req.To, req.From
+
+	err := s.core.Export(s.ctx, req, &bytes.Buffer{})
+	s.NotNil(err)
+	s.Equal(apperror.MsgInvalidPeriod, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestExportWithFutureEndFails() {
+	req := request(entities.FormatCSV)
+	req.To = time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	err := s.core.Export(s.ctx, req, &bytes.Buffer{})
+	s.NotNil(err)
+	s.Equal(apperror.MsgInvalidPeriod, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestExportWithoutPeriodDefaultsToLastThirtyDays() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123, Currency: "USD"}, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+	s.mockAccountRepo.EXPECT().
+		GetBalanceAsOfTx(s.ctx, s.mockTx, int64(123), gomock.Any()).
+		Return(decimal.Zero, nil).
+		Times(2)
+	s.mockRepo.EXPECT().
+		StreamEntries(s.ctx, s.mockTx, int64(123), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, _ int64, from, to time.Time, _ func(*statement.Entry) error) error {
+			s.Equal(entities.DefaultPeriod, to.Sub(from))
+			s.WithinDuration(time.Now(), to, time.Minute)
+			return nil
+		})
+
+	err := s.core.Export(s.ctx, &entities.StatementRequest{AccountID: 123, Format: entities.FormatCSV}, &bytes.Buffer{})
+	s.Nil(err)
+}
+
+// Test Export - failures
+
+func (s *CoreTestSuite) TestExportWhenAccountNotFoundReturnsNotFound() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(nil, apperror.New(apperror.CodeNotFound, errDatabaseError))
+
+	err := s.core.Export(s.ctx, request(entities.FormatCSV), &bytes.Buffer{})
+	s.NotNil(err)
+	s.Equal(apperror.CodeNotFound, err.Code())
+}
+
+func (s *CoreTestSuite) TestExportWhenAccountLookupFailsReturnsInternalError() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(nil, errDatabaseError)
+
+	err := s.core.Export(s.ctx, request(entities.FormatCSV), &bytes.Buffer{})
+	s.NotNil(err)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
+
+func (s *CoreTestSuite) TestExportWhenBeginTxFailsReturnsInternalError() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123, Currency: "USD"}, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(nil, errDatabaseError)
+
+	err := s.core.Export(s.ctx, request(entities.FormatCSV), &bytes.Buffer{})
+	s.NotNil(err)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
+
+func (s *CoreTestSuite) TestExportWhenBalanceFailsWritesNothing() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123, Currency: "USD"}, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+	s.mockAccountRepo.EXPECT().
+		GetBalanceAsOfTx(s.ctx, s.mockTx, int64(123), testFrom).
+		Return(decimal.Zero, errDatabaseError)
+
+	var out bytes.Buffer
+	err := s.core.Export(s.ctx, request(entities.FormatCSV), &out)
+	s.NotNil(err)
+	s.Equal(apperror.CodeInternalError, err.Code())
+	s.Zero(out.Len())
+}
+
+func (s *CoreTestSuite) TestExportWhenStreamFailsReturnsInternalError() {
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, int64(123)).
+		Return(&account.Account{AccountID: 123, Currency: "USD"}, nil)
+	s.mockRepo.EXPECT().BeginTx(s.ctx).Return(s.mockTx, nil)
+	s.mockTx.EXPECT().Rollback(s.ctx).Return(nil)
+	s.mockAccountRepo.EXPECT().
+		GetBalanceAsOfTx(s.ctx, s.mockTx, int64(123), gomock.Any()).
+		Return(decimal.Zero, nil).
+		Times(2)
+	s.mockRepo.EXPECT().
+		StreamEntries(s.ctx, s.mockTx, int64(123), testFrom, testTo, gomock.Any()).
+		Return(errDatabaseError)
+
+	err := s.core.Export(s.ctx, request(entities.FormatJSONL), &bytes.Buffer{})
+	s.NotNil(err)
+	s.Equal(apperror.CodeInternalError, err.Code())
+	s.ErrorIs(err.Unwrap(), errDatabaseError)
+}
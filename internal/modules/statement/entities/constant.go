@@ -0,0 +1,80 @@
+// Package entities provides request/response types and constants for the statement module.
+package entities
+
+import "time"
+
+// Error messages for the statement module
+const (
+	ErrMsgAccountNotFound    = "account not found"
+	ErrMsgInvalidAccountID   = "invalid account ID"
+	ErrMsgInvalidTimestamp   = "invalid timestamp, expected RFC3339"
+	ErrMsgInvalidPeriod      = "invalid statement period"
+	ErrMsgUnsupportedFormat  = "unsupported statement format"
+	ErrMsgStatementReadError = "failed to read statement"
+)
+
+// Route path constants for the statement module
+const (
+	RouteAccountStatement = "/accounts/{accountID}/statement"
+	ParamAccountID        = "accountID"
+	QueryParamFrom        = "from"
+	QueryParamTo          = "to"
+	QueryParamFormat      = "format"
+)
+
+// Statement formats
+const (
+	FormatCSV     = "csv"
+	FormatCAMT053 = "camt053"
+	FormatJSONL   = "jsonl"
+)
+
+// Response content types by format
+const (
+	ContentTypeCSV   = "text/csv; charset=utf-8"
+	ContentTypeXML   = "application/xml; charset=utf-8"
+	ContentTypeJSONL = "application/x-ndjson"
+)
+
+// Download headers
+const (
+	HeaderContentDisposition = "Content-Disposition"
+	ContentDispositionFormat = `attachment; filename="statement-%d.%s"`
+)
+
+// File extensions by format
+const (
+	ExtensionCSV   = "csv"
+	ExtensionXML   = "xml"
+	ExtensionJSONL = "jsonl"
+)
+
+// DefaultPeriod is the statement length when the request omits from
+const DefaultPeriod = 30 * 24 * time.Hour
+
+// Statement record kinds, used in the CSV record column and the JSONL record field
+const (
+	RecordOpeningBalance = "opening_balance"
+	RecordEntry          = "entry"
+	RecordClosingBalance = "closing_balance"
+)
+
+// CSVHeader is the header row of CSV statements
+var CSVHeader = []string{"record", "booked_at", "transaction_id", "type", "counterparty_account_id", "amount", "balance"}
+
+// ISO 20022 camt.053 constants
+const (
+	CAMT053Namespace     = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+	CAMTBalanceOpening   = "OPBD"
+	CAMTBalanceClosing   = "CLBD"
+	CAMTCredit           = "CRDT"
+	CAMTDebit            = "DBIT"
+	CAMTStatusBooked     = "BOOK"
+	CAMTAmountFracDigits = 5
+
+	// CAMTRunningBalanceFormat renders the running balance into AddtlNtryInf,
+	// since ReportEntry2 has no balance element
+	CAMTRunningBalanceFormat = "Balance after entry: %s %s"
+	// CAMTStatementIDTimeLayout keeps statement IDs within the 35-character limit
+	CAMTStatementIDTimeLayout = "20060102T150405"
+)
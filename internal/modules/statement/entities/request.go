@@ -0,0 +1,10 @@
+package entities
+
+// StatementRequest represents a request to export an account statement.
+// From and To are RFC3339 timestamps; the period covers transactions after From up to and including To.
+type StatementRequest struct {
+	AccountID int64
+	From      string
+	To        string
+	Format    string
+}
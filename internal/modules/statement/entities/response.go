@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// StatementHeader describes the account and period of a statement
+type StatementHeader struct {
+	AccountID      int64
+	Currency       string
+	From           time.Time
+	To             time.Time
+	OpeningBalance decimal.Decimal
+	ClosingBalance decimal.Decimal
+	GeneratedAt    time.Time
+}
+
+// StatementLine is one transaction on a statement.
+// Amount is signed from the account's point of view; Balance is the running balance after it.
+type StatementLine struct {
+	TransactionID         uuid.UUID
+	Type                  string
+	CounterpartyAccountID *int64
+	Amount                decimal.Decimal
+	Balance               decimal.Decimal
+	BookedAt              time.Time
+}
+
+// StatementRecord is one line of a JSONL statement
+type StatementRecord struct {
+	Record                string `json:"record"`
+	AccountID             int64  `json:"account_id,omitempty"`
+	Currency              string `json:"currency,omitempty"`
+	At                    string `json:"at"`
+	TransactionID         string `json:"transaction_id,omitempty"`
+	Type                  string `json:"type,omitempty"`
+	CounterpartyAccountID *int64 `json:"counterparty_account_id,omitempty"`
+	Amount                string `json:"amount,omitempty"`
+	Balance               string `json:"balance"`
+}
@@ -0,0 +1,59 @@
+// Package statement exports account statements in CSV, JSONL and ISO 20022 camt.053 formats.
+package statement
+
+import (
+	"context"
+
+	"github.com/internal-transfers-service/internal/modules/account"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module singleton instance
+var StmtModule IModule
+
+// NewModule initializes the statement module
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool, accountRepo account.IRepository) IModule {
+	if StmtModule == nil {
+		poolWrapper := database.NewPoolWrapper(pool)
+		repo := NewRepository(poolWrapper)
+		core := NewCore(ctx, repo, accountRepo)
+		handler := NewHTTPHandler(core)
+
+		StmtModule = &Module{
+			Core:    core,
+			Handler: handler,
+			Repo:    repo,
+		}
+	}
+	return StmtModule
+}
+
+// IModule defines the interface for the statement module
+type IModule interface {
+	GetCore() ICore
+	GetHandler() *HTTPHandler
+	GetRepository() IRepository
+}
+
+// Module implements IModule
+type Module struct {
+	Core    ICore
+	Handler *HTTPHandler
+	Repo    IRepository
+}
+
+// GetCore returns the core business logic
+func (m *Module) GetCore() ICore {
+	return m.Core
+}
+
+// GetHandler returns the HTTP handler
+func (m *Module) GetHandler() *HTTPHandler {
+	return m.Handler
+}
+
+// GetRepository returns the repository
+func (m *Module) GetRepository() IRepository {
+	return m.Repo
+}
@@ -0,0 +1,101 @@
+package statement
+
+//go:generate mockgen -source=repository.go -destination=mock/mock_repository.go -package=mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// Entry is a transaction touching the statement account
+type Entry struct {
+	ID                   uuid.UUID
+	SourceAccountID      *int64
+	DestinationAccountID int64
+	Amount               decimal.Decimal
+	Type                 string
+	CreatedAt            time.Time
+}
+
+// IRepository defines the interface for statement data access
+type IRepository interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	StreamEntries(ctx context.Context, tx pgx.Tx, accountID int64, from, to time.Time, fn func(*Entry) error) error
+}
+
+// Repository implements IRepository
+type Repository struct {
+	pool database.IPool
+}
+
+// Compile-time interface check
+var _ IRepository = (*Repository)(nil)
+
+// NewRepository creates a new statement repository
+func NewRepository(pool database.IPool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// SQL queries
+const (
+	querySelectEntries = `
+		SELECT id, source_account_id, destination_account_id, amount, type, created_at
+		FROM transactions
+		WHERE (source_account_id = $1 OR destination_account_id = $1)
+			AND created_at > $2 AND created_at <= $3
+		ORDER BY created_at, id`
+)
+
+// BeginTx starts a read-only repeatable-read transaction, so that the balances and
+// entries of one statement are read from the same view of the history
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+}
+
+// StreamEntries calls fn for each of the account's transactions in (from, to], oldest first.
+// Rows are read one at a time, so memory use does not grow with the period;
+// fn must not retain the entry, which is reused between calls.
+func (r *Repository) StreamEntries(ctx context.Context, tx pgx.Tx, accountID int64, from, to time.Time, fn func(*Entry) error) error {
+	rows, err := tx.Query(ctx, querySelectEntries, accountID, from, to)
+	if err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadStatement,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return err
+	}
+	defer rows.Close()
+
+	var entry Entry
+	for rows.Next() {
+		if err := rows.Scan(&entry.ID, &entry.SourceAccountID, &entry.DestinationAccountID, &entry.Amount, &entry.Type, &entry.CreatedAt); err != nil {
+			logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadStatement,
+				constants.LogKeyAccountID, accountID,
+				constants.LogKeyError, err,
+			)
+			return err
+		}
+		if err := fn(&entry); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToReadStatement,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, err,
+		)
+		return err
+	}
+	return nil
+}
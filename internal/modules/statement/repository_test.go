@@ -0,0 +1,148 @@
+package statement_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/statement"
+	dbmock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Test error constants - used for simulating database errors in repository tests
+var (
+	errRepoQueryFailed = errors.New("query execution failed")
+	errRepoRowsFailed  = errors.New("rows iteration failed")
+	errRepoCallback    = errors.New("write failed")
+)
+
+// RepositoryTestSuite contains tests for statement Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockPool *dbmock.MockIPool
+	mockRows *dbmock.MockRows
+	mockTx   *dbmock.MockTx
+	repo     statement.IRepository
+	ctx      context.Context
+}
+
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockPool = dbmock.NewMockIPool(s.ctrl)
+	s.mockRows = dbmock.NewMockRows(s.ctrl)
+	s.mockTx = dbmock.NewMockTx(s.ctrl)
+	s.ctx = context.Background()
+	s.repo = statement.NewRepository(s.mockPool)
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// Test BeginTx
+
+func (s *RepositoryTestSuite) TestBeginTxUsesReadOnlyRepeatableRead() {
+	s.mockPool.EXPECT().
+		BeginTx(s.ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}).
+		Return(s.mockTx, nil).
+		Times(1)
+
+	tx, err := s.repo.BeginTx(s.ctx)
+	s.NoError(err)
+	s.Equal(s.mockTx, tx)
+}
+
+// Test StreamEntries
+
+func (s *RepositoryTestSuite) TestStreamEntriesCallsFnForEachRow() {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	s.mockTx.EXPECT().Query(s.ctx, gomock.Any(), int64(123), from, to).Return(s.mockRows, nil).Times(1)
+	gomock.InOrder(
+		s.mockRows.EXPECT().Next().Return(true),
+		s.mockRows.EXPECT().Next().Return(true),
+		s.mockRows.EXPECT().Next().Return(false),
+	)
+	amounts := []string{"10", "2.5"}
+	call := 0
+	s.mockRows.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			*dest[2].(*int64) = 123
+			*dest[3].(*decimal.Decimal) = decimal.RequireFromString(amounts[call])
+			*dest[4].(*string) = "deposit"
+			call++
+			return nil
+		}).
+		Times(2)
+	s.mockRows.EXPECT().Err().Return(nil).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	var seen []string
+	err := s.repo.StreamEntries(s.ctx, s.mockTx, 123, from, to, func(e *statement.Entry) error {
+		seen = append(seen, e.Amount.String())
+		return nil
+	})
+	s.NoError(err)
+	s.Equal(amounts, seen)
+}
+
+func (s *RepositoryTestSuite) TestStreamEntriesWhenQueryFailsReturnsError() {
+	s.mockTx.EXPECT().
+		Query(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errRepoQueryFailed).
+		Times(1)
+
+	err := s.repo.StreamEntries(s.ctx, s.mockTx, 123, time.Now(), time.Now(), func(*statement.Entry) error { return nil })
+	s.Equal(errRepoQueryFailed, err)
+}
+
+func (s *RepositoryTestSuite) TestStreamEntriesWhenScanFailsReturnsError() {
+	s.mockTx.EXPECT().
+		Query(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(s.mockRows, nil).
+		Times(1)
+	s.mockRows.EXPECT().Next().Return(true).Times(1)
+	s.mockRows.EXPECT().Scan(gomock.Any()).Return(errRepoQueryFailed).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	err := s.repo.StreamEntries(s.ctx, s.mockTx, 123, time.Now(), time.Now(), func(*statement.Entry) error { return nil })
+	s.Equal(errRepoQueryFailed, err)
+}
+
+func (s *RepositoryTestSuite) TestStreamEntriesStopsWhenFnFails() {
+	s.mockTx.EXPECT().
+		Query(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(s.mockRows, nil).
+		Times(1)
+	s.mockRows.EXPECT().Next().Return(true).Times(1)
+	s.mockRows.EXPECT().Scan(gomock.Any()).Return(nil).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	err := s.repo.StreamEntries(s.ctx, s.mockTx, 123, time.Now(), time.Now(), func(*statement.Entry) error { return errRepoCallback })
+	s.Equal(errRepoCallback, err)
+}
+
+func (s *RepositoryTestSuite) TestStreamEntriesWhenRowsFailReturnsError() {
+	s.mockTx.EXPECT().
+		Query(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(s.mockRows, nil).
+		Times(1)
+	s.mockRows.EXPECT().Next().Return(false).Times(1)
+	s.mockRows.EXPECT().Err().Return(errRepoRowsFailed).Times(1)
+	s.mockRows.EXPECT().Close().Times(1)
+
+	err := s.repo.StreamEntries(s.ctx, s.mockTx, 123, time.Now(), time.Now(), func(*statement.Entry) error { return nil })
+	s.Equal(errRepoRowsFailed, err)
+}
@@ -0,0 +1,136 @@
+package statement
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
+	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/statement/entities"
+	"github.com/internal-transfers-service/pkg/apperror"
+)
+
+// media describes how a statement format is served
+type media struct {
+	contentType string
+	extension   string
+}
+
+// formatMedia maps each statement format to its content type and file extension
+var formatMedia = map[string]media{
+	entities.FormatCSV:     {contentType: entities.ContentTypeCSV, extension: entities.ExtensionCSV},
+	entities.FormatCAMT053: {contentType: entities.ContentTypeXML, extension: entities.ExtensionXML},
+	entities.FormatJSONL:   {contentType: entities.ContentTypeJSONL, extension: entities.ExtensionJSONL},
+}
+
+// HTTPHandler handles HTTP requests for account statements
+type HTTPHandler struct {
+	core ICore
+}
+
+// NewHTTPHandler creates a new HTTPHandler
+func NewHTTPHandler(core ICore) *HTTPHandler {
+	return &HTTPHandler{core: core}
+}
+
+// RegisterRoutes registers the statement routes with the router
+func (h *HTTPHandler) RegisterRoutes(r chi.Router) {
+	r.Get(entities.RouteAccountStatement, h.GetStatement)
+}
+
+// GetStatement handles GET /accounts/{accountID}/statement?from=&to=&format=csv|camt053|jsonl.
+// The statement is streamed; format defaults to csv.
+func (h *HTTPHandler) GetStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountIDStr := chi.URLParam(r, entities.ParamAccountID)
+	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+	if err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountIDStr))
+		return
+	}
+
+	query := r.URL.Query()
+	req := &entities.StatementRequest{
+		AccountID: accountID,
+		From:      query.Get(entities.QueryParamFrom),
+		To:        query.Get(entities.QueryParamTo),
+		Format:    query.Get(entities.QueryParamFormat),
+	}
+	if req.Format == "" {
+		req.Format = entities.FormatCSV
+	}
+
+	out := &downloadWriter{w: w, format: req.Format, accountID: accountID}
+	if appErr := h.core.Export(ctx, req, out); appErr != nil {
+		if !out.started {
+			h.writeErrorWithContext(w, r, appErr)
+			return
+		}
+		// Headers are already sent; the client sees a truncated body
+		logger.Ctx(ctx).Errorw(constants.LogMsgStatementStreamAborted,
+			constants.LogKeyAccountID, accountID,
+			constants.LogKeyError, appErr.Error(),
+		)
+	}
+}
+
+// downloadWriter sends the download headers on the first write, so that errors
+// raised before any output can still be returned as a JSON error response
+type downloadWriter struct {
+	w         http.ResponseWriter
+	format    string
+	accountID int64
+	started   bool
+}
+
+// Write writes p to the response, sending the headers first if needed
+func (d *downloadWriter) Write(p []byte) (int, error) {
+	if !d.started {
+		d.started = true
+		m := formatMedia[d.format]
+		d.w.Header().Set(constants.HeaderContentType, m.contentType)
+		d.w.Header().Set(entities.HeaderContentDisposition, fmt.Sprintf(entities.ContentDispositionFormat, d.accountID, m.extension))
+		d.w.WriteHeader(http.StatusOK)
+	}
+	return d.w.Write(p)
+}
+
+// writeJSON writes a JSON response
+func (h *HTTPHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(status)
+	if data != nil {
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			logger.Error(constants.LogMsgFailedToEncodeResponse, constants.LogKeyError, err)
+		}
+	}
+}
+
+// writeErrorWithContext writes an error response with request ID for tracing
+func (h *HTTPHandler) writeErrorWithContext(w http.ResponseWriter, r *http.Request, err apperror.IError) {
+	requestID := ""
+	if id, ok := r.Context().Value(contextkeys.RequestID).(string); ok {
+		requestID = id
+	}
+
+	response := apperror.ErrorResponse{
+		Error:     err.PublicMessage(),
+		Code:      err.Code().String(),
+		RequestID: requestID,
+		Details:   err.Fields(),
+	}
+
+	// Log error for debugging
+	logger.Ctx(r.Context()).Errorw(constants.LogMsgRequestFailed,
+		constants.LogKeyError, err.Error(),
+		constants.LogKeyStatusCode, err.HTTPStatus(),
+	)
+
+	h.writeJSON(w, err.HTTPStatus(), response)
+}
@@ -0,0 +1,171 @@
+package statement_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/internal-transfers-service/internal/constants"
+	accountMock "github.com/internal-transfers-service/internal/modules/account/mock"
+	"github.com/internal-transfers-service/internal/modules/statement"
+	"github.com/internal-transfers-service/internal/modules/statement/entities"
+	"github.com/internal-transfers-service/internal/modules/statement/mock"
+	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite contains tests for statement HTTPHandler
+type ServerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockCore *mock.MockICore
+	handler  *statement.HTTPHandler
+	router   chi.Router
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockCore = mock.NewMockICore(s.ctrl)
+	s.handler = statement.NewHTTPHandler(s.mockCore)
+	s.router = chi.NewRouter()
+	s.handler.RegisterRoutes(s.router)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *ServerTestSuite) TestGetStatementStreamsWithDownloadHeaders() {
+	expectedRequest := &entities.StatementRequest{
+		AccountID: 123,
+		From:      "2026-03-01T00:00:00Z",
+		To:        "2026-03-31T00:00:00Z",
+		Format:    entities.FormatCAMT053,
+	}
+
+	s.mockCore.EXPECT().
+		Export(gomock.Any(), expectedRequest, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *entities.StatementRequest, w io.Writer) apperror.IError {
+			_, _ = io.WriteString(w, "<Document/>")
+			return nil
+		}).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/123/statement?from=2026-03-01T00:00:00Z&to=2026-03-31T00:00:00Z&format=camt053", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(entities.ContentTypeXML, rec.Header().Get(constants.HeaderContentType))
+	s.Equal(`attachment; filename="statement-123.xml"`, rec.Header().Get(entities.HeaderContentDisposition))
+	s.Equal("<Document/>", rec.Body.String())
+}
+
+func (s *ServerTestSuite) TestGetStatementDefaultsToCSV() {
+	s.mockCore.EXPECT().
+		Export(gomock.Any(), &entities.StatementRequest{AccountID: 123, Format: entities.FormatCSV}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *entities.StatementRequest, w io.Writer) apperror.IError {
+			_, _ = io.WriteString(w, "record\n")
+			return nil
+		}).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/123/statement", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(entities.ContentTypeCSV, rec.Header().Get(constants.HeaderContentType))
+}
+
+func (s *ServerTestSuite) TestGetStatementWithInvalidAccountIDReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodGet, "/accounts/abc/statement", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestGetStatementWhenCoreFailsBeforeOutputReturnsError() {
+	s.mockCore.EXPECT().
+		Export(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(apperror.NewWithMessage(apperror.CodeBadRequest, statement.ErrUnsupportedFormat, apperror.MsgUnsupportedFormat)).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/123/statement?format=pdf", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+
+	var response apperror.ErrorResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal(apperror.MsgUnsupportedFormat, response.Error)
+}
+
+func (s *ServerTestSuite) TestGetStatementWhenCoreFailsMidStreamKeepsPartialBody() {
+	s.mockCore.EXPECT().
+		Export(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *entities.StatementRequest, w io.Writer) apperror.IError {
+			_, _ = io.WriteString(w, "{\"record\":\"opening_balance\"}\n")
+			return apperror.New(apperror.CodeInternalError, statement.ErrStatementReadError)
+		}).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/123/statement?format=jsonl", nil)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(entities.ContentTypeJSONL, rec.Header().Get(constants.HeaderContentType))
+	s.Equal("{\"record\":\"opening_balance\"}\n", rec.Body.String())
+}
+
+// InitTestSuite contains tests for statement module initialization
+type InitTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestInitSuite(t *testing.T) {
+	suite.Run(t, new(InitTestSuite))
+}
+
+func (s *InitTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+// TestModuleMethodsReturnCorrectValues verifies module methods
+func (s *InitTestSuite) TestModuleMethodsReturnCorrectValues() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockIRepository(ctrl)
+	core := statement.NewCore(s.ctx, mockRepo, accountMock.NewMockIRepository(ctrl))
+	handler := statement.NewHTTPHandler(core)
+
+	module := &statement.Module{
+		Core:    core,
+		Handler: handler,
+		Repo:    mockRepo,
+	}
+
+	s.Equal(core, module.GetCore())
+	s.Equal(handler, module.GetHandler())
+	s.Equal(mockRepo, module.GetRepository())
+	s.Equal(core, statement.GetCore())
+}
@@ -0,0 +1,136 @@
+package statement
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/internal-transfers-service/internal/modules/statement/entities"
+)
+
+// Writer renders a statement in one output format.
+// WriteHeader is called once, WriteLine once per transaction and WriteFooter last;
+// WriteFooter flushes any buffered output.
+type Writer interface {
+	WriteHeader(h *entities.StatementHeader) error
+	WriteLine(l *entities.StatementLine) error
+	WriteFooter(h *entities.StatementHeader) error
+}
+
+// NewWriter returns the writer for format, or false if the format is not supported
+func NewWriter(format string, w io.Writer) (Writer, bool) {
+	switch format {
+	case entities.FormatCSV:
+		return newCSVWriter(w), true
+	case entities.FormatJSONL:
+		return newJSONLWriter(w), true
+	case entities.FormatCAMT053:
+		return newCAMT053Writer(w), true
+	default:
+		return nil, false
+	}
+}
+
+// formatTimestamp renders statement timestamps as UTC RFC3339
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// formatCounterparty renders an optional counterparty account ID
+func formatCounterparty(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
+
+// csvWriter writes one record per row: the opening balance, each entry, then the closing balance
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+// WriteHeader writes the column header and opening balance row
+func (c *csvWriter) WriteHeader(h *entities.StatementHeader) error {
+	if err := c.w.Write(entities.CSVHeader); err != nil {
+		return err
+	}
+	return c.w.Write([]string{entities.RecordOpeningBalance, formatTimestamp(h.From), "", "", "", "", h.OpeningBalance.String()})
+}
+
+// WriteLine writes one entry row
+func (c *csvWriter) WriteLine(l *entities.StatementLine) error {
+	return c.w.Write([]string{
+		entities.RecordEntry,
+		formatTimestamp(l.BookedAt),
+		l.TransactionID.String(),
+		l.Type,
+		formatCounterparty(l.CounterpartyAccountID),
+		l.Amount.String(),
+		l.Balance.String(),
+	})
+}
+
+// WriteFooter writes the closing balance row and flushes
+func (c *csvWriter) WriteFooter(h *entities.StatementHeader) error {
+	if err := c.w.Write([]string{entities.RecordClosingBalance, formatTimestamp(h.To), "", "", "", "", h.ClosingBalance.String()}); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonlWriter writes one JSON object per line
+type jsonlWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	buf := bufio.NewWriter(w)
+	return &jsonlWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+// WriteHeader writes the opening balance record
+func (j *jsonlWriter) WriteHeader(h *entities.StatementHeader) error {
+	return j.enc.Encode(&entities.StatementRecord{
+		Record:    entities.RecordOpeningBalance,
+		AccountID: h.AccountID,
+		Currency:  h.Currency,
+		At:        formatTimestamp(h.From),
+		Balance:   h.OpeningBalance.String(),
+	})
+}
+
+// WriteLine writes one entry record
+func (j *jsonlWriter) WriteLine(l *entities.StatementLine) error {
+	return j.enc.Encode(&entities.StatementRecord{
+		Record:                entities.RecordEntry,
+		At:                    formatTimestamp(l.BookedAt),
+		TransactionID:         l.TransactionID.String(),
+		Type:                  l.Type,
+		CounterpartyAccountID: l.CounterpartyAccountID,
+		Amount:                l.Amount.String(),
+		Balance:               l.Balance.String(),
+	})
+}
+
+// WriteFooter writes the closing balance record and flushes
+func (j *jsonlWriter) WriteFooter(h *entities.StatementHeader) error {
+	if err := j.enc.Encode(&entities.StatementRecord{
+		Record:    entities.RecordClosingBalance,
+		AccountID: h.AccountID,
+		Currency:  h.Currency,
+		At:        formatTimestamp(h.To),
+		Balance:   h.ClosingBalance.String(),
+	}); err != nil {
+		return err
+	}
+	return j.buf.Flush()
+}
@@ -5,8 +5,11 @@ package transaction
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/internal-transfers-service/internal/constants"
+	"github.com/internal-transfers-service/internal/constants/contextkeys"
 	"github.com/internal-transfers-service/internal/logger"
 	"github.com/internal-transfers-service/internal/modules/account"
 	"github.com/internal-transfers-service/internal/modules/transaction/entities"
@@ -17,24 +20,93 @@ import (
 
 // Domain errors
 var (
-	ErrInsufficientBalance  = errors.New(entities.ErrMsgInsufficientBalance)
-	ErrSameAccountTransfer  = errors.New(entities.ErrMsgSameAccountTransfer)
-	ErrInvalidAmount        = errors.New(entities.ErrMsgInvalidAmount)
-	ErrInvalidDecimalAmt    = errors.New(entities.ErrMsgInvalidDecimalAmt)
-	ErrSourceNotFound       = errors.New(entities.ErrMsgSourceNotFound)
-	ErrDestNotFound         = errors.New(entities.ErrMsgDestNotFound)
-	ErrTooManyDecimalPlaces = errors.New(entities.ErrMsgTooManyDecimalPlaces)
+	ErrInsufficientBalance   = errors.New(entities.ErrMsgInsufficientBalance)
+	ErrSameAccountTransfer   = errors.New(entities.ErrMsgSameAccountTransfer)
+	ErrInvalidAmount         = errors.New(entities.ErrMsgInvalidAmount)
+	ErrInvalidDecimalAmt     = errors.New(entities.ErrMsgInvalidDecimalAmt)
+	ErrSourceNotFound        = errors.New(entities.ErrMsgSourceNotFound)
+	ErrDestNotFound          = errors.New(entities.ErrMsgDestNotFound)
+	ErrTooManyDecimalPlaces  = errors.New(entities.ErrMsgTooManyDecimalPlaces)
+	ErrAccountNotFound       = errors.New(entities.ErrMsgAccountNotFound)
+	ErrInvalidAccountID      = errors.New(entities.ErrMsgInvalidAccountID)
+	ErrCurrencyMismatch      = errors.New(entities.ErrMsgCurrencyMismatch)
+	ErrSystemAccount         = errors.New(entities.ErrMsgSystemAccount)
+	ErrNotLeafAccount        = errors.New(entities.ErrMsgNotLeafAccount)
+	ErrBelowMinimumBalance   = errors.New(entities.ErrMsgBelowMinimumBalance)
+	ErrAmbiguousAccountRef   = errors.New(entities.ErrMsgAmbiguousAccountRef)
+	ErrInvalidEffectiveDate  = errors.New(entities.ErrMsgInvalidEffectiveDate)
+	ErrInvalidCorrectsPeriod = errors.New(entities.ErrMsgInvalidCorrectsPeriod)
 )
 
 // ICore defines the interface for transaction business logic
 type ICore interface {
 	Transfer(ctx context.Context, req *entities.TransferRequest) (*entities.TransferResponse, apperror.IError)
+	Deposit(ctx context.Context, req *entities.DepositRequest) (*entities.TransferResponse, apperror.IError)
+	Withdraw(ctx context.Context, req *entities.WithdrawalRequest) (*entities.TransferResponse, apperror.IError)
+	PostTx(ctx context.Context, tx pgx.Tx, req *entities.PostingRequest) (*entities.TransferResponse, apperror.IError)
+}
+
+// ICommitListener is notified after a transfer, deposit or withdrawal commits. Listeners
+// run on the request goroutine once the response is decided, so they must be quick and
+// cannot fail the posting. System postings made through PostTx are not reported, since
+// their commit belongs to the caller.
+type ICommitListener interface {
+	OnCommitted(ctx context.Context, posting *entities.CommittedPosting)
+}
+
+// IPeriodGuard keeps postings out of closed accounting periods. CheckOpen runs inside the
+// posting's database transaction and must hold off any period close until it ends.
+type IPeriodGuard interface {
+	// Today returns the current business date, the default effective date of a posting
+	Today() time.Time
+	// CheckOpen fails if effectiveDate falls in a closed period or if correctsPeriod,
+	// when set, is not a closed period before it
+	CheckOpen(ctx context.Context, tx pgx.Tx, effectiveDate time.Time, correctsPeriod *time.Time) apperror.IError
+}
+
+// ITxParticipant writes its own records inside a transfer's, deposit's or withdrawal's
+// database transaction, so that they commit if and only if the posting does. Requests
+// carry their participant in the context; see WithTxParticipant.
+type ITxParticipant interface {
+	// Prepare runs once the posting has been written and before it commits, with the
+	// response the posting will return. If it fails, the posting is rolled back.
+	Prepare(ctx context.Context, tx pgx.Tx, response *entities.TransferResponse) error
+}
+
+// WithTxParticipant returns a copy of ctx whose postings include participant in their
+// database transaction.
+func WithTxParticipant(ctx context.Context, participant ITxParticipant) context.Context {
+	return context.WithValue(ctx, contextkeys.TxParticipant, participant)
+}
+
+// TxParticipantFromContext returns the participant carried by ctx, or nil if there is none.
+func TxParticipantFromContext(ctx context.Context) ITxParticipant {
+	participant, _ := ctx.Value(contextkeys.TxParticipant).(ITxParticipant)
+	return participant
+}
+
+// posting describes a single money movement between two accounts.
+// Transfers, deposits and withdrawals are all executed as postings.
+type posting struct {
+	transactionID   uuid.UUID
+	sourceAccountID int64
+	destAccountID   int64
+	amount          decimal.Decimal
+	txType          string
+	// effectiveDate is the business date of the posting; zero means today
+	effectiveDate  time.Time
+	correctsPeriod *time.Time
+	// sourceBalance and destBalance are set once the posting has been executed
+	sourceBalance decimal.Decimal
+	destBalance   decimal.Decimal
 }
 
 // Core implements ICore
 type Core struct {
 	txRepo      IRepository
 	accountRepo account.IRepository
+	periods     IPeriodGuard
+	listeners   []ICommitListener
 }
 
 // Compile-time interface check
@@ -43,20 +115,25 @@ var _ ICore = (*Core)(nil)
 // coreInstance is the singleton instance
 var coreInstance ICore
 
-// NewCore creates a new Core instance
-func NewCore(_ context.Context, txRepo IRepository, accountRepo account.IRepository) ICore {
+// NewCore creates a new Core instance. Every posting is checked against the accounting
+// periods, and listeners are notified of every committed transfer, deposit and withdrawal.
+func NewCore(_ context.Context, txRepo IRepository, accountRepo account.IRepository, periods IPeriodGuard, listeners ...ICommitListener) ICore {
 	coreInstance = &Core{
 		txRepo:      txRepo,
 		accountRepo: accountRepo,
+		periods:     periods,
+		listeners:   listeners,
 	}
 	return coreInstance
 }
 
 // NewCoreWithRepo creates a new Core instance with the given repositories (for testing)
-func NewCoreWithRepo(_ context.Context, txRepo IRepository, accountRepo account.IRepository) ICore {
+func NewCoreWithRepo(_ context.Context, txRepo IRepository, accountRepo account.IRepository, periods IPeriodGuard, listeners ...ICommitListener) ICore {
 	return &Core{
 		txRepo:      txRepo,
 		accountRepo: accountRepo,
+		periods:     periods,
+		listeners:   listeners,
 	}
 }
 
@@ -65,13 +142,152 @@ func GetCore() ICore {
 	return coreInstance
 }
 
-// Transfer executes a fund transfer between two accounts
+// Transfer executes a fund transfer between two accounts. Aliases are resolved to
+// account IDs before any account is locked. A transfer may be backdated to an effective
+// date in an open accounting period, and may name the closed period it corrects.
 func (c *Core) Transfer(ctx context.Context, req *entities.TransferRequest) (*entities.TransferResponse, apperror.IError) {
-	amount, appErr := c.validateTransferRequest(req)
+	sourceAccountID, destAccountID, appErr := c.resolveTransferAccounts(ctx, req)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	amount, appErr := c.validateTransferRequest(sourceAccountID, destAccountID, req.Amount)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	effectiveDate, correctsPeriod, appErr := c.parseEffectiveDates(req.EffectiveDate, req.CorrectsPeriod)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return c.post(ctx, &posting{
+		sourceAccountID: sourceAccountID,
+		destAccountID:   destAccountID,
+		amount:          amount,
+		txType:          entities.TypeTransfer,
+		effectiveDate:   effectiveDate,
+		correctsPeriod:  correctsPeriod,
+	})
+}
+
+// parseEffectiveDates parses a transfer's effective date, which defaults to today and may
+// not be in the future, and the period it corrects, if any. Whether the periods are open
+// or closed is checked once the posting holds the period lock.
+func (c *Core) parseEffectiveDates(effectiveDate, correctsPeriod string) (time.Time, *time.Time, apperror.IError) {
+	today := c.periods.Today()
+	date := today
+	if effectiveDate != "" {
+		parsed, err := time.Parse(entities.DateLayout, effectiveDate)
+		if err != nil || parsed.After(today) {
+			return time.Time{}, nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidEffectiveDate, apperror.MsgInvalidEffectiveDate).
+				WithField(apperror.FieldEffectiveDate, effectiveDate)
+		}
+		date = parsed
+	}
+
+	if correctsPeriod == "" {
+		return date, nil, nil
+	}
+	period, err := time.Parse(entities.PeriodLayout, correctsPeriod)
+	if err != nil {
+		return time.Time{}, nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidCorrectsPeriod, apperror.MsgInvalidCorrectedPeriod).
+			WithField(apperror.FieldCorrectsPeriod, correctsPeriod)
+	}
+	return date, &period, nil
+}
+
+// resolveTransferAccounts returns the source and destination account IDs of a transfer,
+// looking up the sides addressed by alias. An alias that resolves to nothing is reported
+// like an unknown account ID.
+func (c *Core) resolveTransferAccounts(ctx context.Context, req *entities.TransferRequest) (int64, int64, apperror.IError) {
+	sourceAccountID, appErr := c.resolveAccount(ctx, req.SourceAccountID, req.SourceAlias,
+		apperror.FieldSourceAlias, ErrSourceNotFound, apperror.MsgSourceNotFound)
+	if appErr != nil {
+		return 0, 0, appErr
+	}
+
+	destAccountID, appErr := c.resolveAccount(ctx, req.DestinationAccountID, req.DestinationAlias,
+		apperror.FieldDestAlias, ErrDestNotFound, apperror.MsgDestNotFound)
+	if appErr != nil {
+		return 0, 0, appErr
+	}
+
+	return sourceAccountID, destAccountID, nil
+}
+
+// resolveAccount returns accountID, or the account alias is registered to when one is given
+func (c *Core) resolveAccount(ctx context.Context, accountID int64, alias, field string, notFound error, notFoundMsg string) (int64, apperror.IError) {
+	if alias == "" {
+		return accountID, nil
+	}
+
+	if accountID != 0 {
+		return 0, apperror.NewWithMessage(apperror.CodeBadRequest, ErrAmbiguousAccountRef, apperror.MsgAmbiguousAccountRef).
+			WithField(field, alias)
+	}
+
+	resolved, err := c.accountRepo.ResolveAlias(ctx, alias)
+	if err != nil {
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) && appErr.Code() == apperror.CodeNotFound {
+			return 0, apperror.NewWithMessage(apperror.CodeNotFound, notFound, notFoundMsg).
+				WithField(field, alias)
+		}
+		return 0, apperror.New(apperror.CodeInternalError, err).
+			WithField(field, alias)
+	}
+
+	logger.Ctx(ctx).Debugw(constants.LogMsgAliasResolved,
+		constants.LogFieldAlias, alias,
+		constants.LogKeyAccountID, resolved,
+	)
+	return resolved, nil
+}
+
+// Deposit moves external funds into an account, debiting the currency's clearing account
+func (c *Core) Deposit(ctx context.Context, req *entities.DepositRequest) (*entities.TransferResponse, apperror.IError) {
+	amount, appErr := parseAmount(req.Amount)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	clearing, appErr := c.clearingAccountFor(ctx, req.AccountID)
 	if appErr != nil {
 		return nil, appErr
 	}
 
+	return c.post(ctx, &posting{
+		sourceAccountID: clearing.AccountID,
+		destAccountID:   req.AccountID,
+		amount:          amount,
+		txType:          entities.TypeDeposit,
+	})
+}
+
+// Withdraw moves funds out of an account, crediting the currency's clearing account
+func (c *Core) Withdraw(ctx context.Context, req *entities.WithdrawalRequest) (*entities.TransferResponse, apperror.IError) {
+	amount, appErr := parseAmount(req.Amount)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	clearing, appErr := c.clearingAccountFor(ctx, req.AccountID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return c.post(ctx, &posting{
+		sourceAccountID: req.AccountID,
+		destAccountID:   clearing.AccountID,
+		amount:          amount,
+		txType:          entities.TypeWithdrawal,
+	})
+}
+
+// post executes a posting atomically: lock both accounts, validate, move funds, record,
+// let the request's participant write its records, commit
+func (c *Core) post(ctx context.Context, p *posting) (*entities.TransferResponse, apperror.IError) {
 	tx, err := c.beginTransaction(ctx)
 	if err != nil {
 		return nil, err
@@ -80,17 +296,15 @@ func (c *Core) Transfer(ctx context.Context, req *entities.TransferRequest) (*en
 	committed := false
 	defer c.rollbackIfNotCommitted(ctx, tx, &committed)
 
-	sourceAccount, destAccount, appErr := c.lockAccountsInOrder(ctx, tx, req)
+	txRecord, appErr := c.postTx(ctx, tx, p)
 	if appErr != nil {
 		return nil, appErr
 	}
 
-	if appErr := c.validateSufficientBalance(ctx, sourceAccount, amount, req.SourceAccountID); appErr != nil {
-		return nil, appErr
+	response := &entities.TransferResponse{
+		TransactionID: txRecord.ID.String(),
 	}
-
-	txRecord, appErr := c.executeTransfer(ctx, tx, sourceAccount, destAccount, amount, req)
-	if appErr != nil {
+	if appErr := c.prepareParticipant(ctx, tx, response); appErr != nil {
 		return nil, appErr
 	}
 
@@ -99,30 +313,104 @@ func (c *Core) Transfer(ctx context.Context, req *entities.TransferRequest) (*en
 	}
 	committed = true
 
-	c.logTransferCompleted(ctx, txRecord, req, amount)
+	c.logTransferCompleted(ctx, txRecord, p)
+	c.notifyCommitted(ctx, txRecord, p)
+
+	return response, nil
+}
+
+// prepareParticipant lets the request's transaction participant, if any, write its
+// records inside the posting's transaction
+func (c *Core) prepareParticipant(ctx context.Context, tx pgx.Tx, response *entities.TransferResponse) apperror.IError {
+	participant := TxParticipantFromContext(ctx)
+	if participant == nil {
+		return nil
+	}
+
+	if err := participant.Prepare(ctx, tx, response); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgTxParticipantFailed,
+			constants.LogFieldTransactionID, response.TransactionID,
+			constants.LogKeyError, err,
+		)
+		return apperror.New(apperror.CodeInternalError, err)
+	}
+	return nil
+}
+
+// PostTx executes a system posting inside the caller's database transaction, so that
+// other modules can move money atomically with their own bookkeeping. The caller owns
+// the transaction and is responsible for committing or rolling it back.
+func (c *Core) PostTx(ctx context.Context, tx pgx.Tx, req *entities.PostingRequest) (*entities.TransferResponse, apperror.IError) {
+	p := &posting{
+		transactionID:   req.TransactionID,
+		sourceAccountID: req.SourceAccountID,
+		destAccountID:   req.DestinationAccountID,
+		amount:          req.Amount,
+		txType:          req.Type,
+	}
+
+	txRecord, appErr := c.postTx(ctx, tx, p)
+	if appErr != nil {
+		return nil, appErr
+	}
 
 	return &entities.TransferResponse{
 		TransactionID: txRecord.ID.String(),
 	}, nil
 }
 
-// validateTransferRequest validates the transfer request and returns the parsed amount
-func (c *Core) validateTransferRequest(req *entities.TransferRequest) (decimal.Decimal, apperror.IError) {
-	if req.SourceAccountID == req.DestinationAccountID {
+// postTx runs the posting steps against an open transaction without committing it.
+// A posting without an effective date takes today's, and none may land in a closed period.
+func (c *Core) postTx(ctx context.Context, tx pgx.Tx, p *posting) (*Transaction, apperror.IError) {
+	if p.effectiveDate.IsZero() {
+		p.effectiveDate = c.periods.Today()
+	}
+	if appErr := c.periods.CheckOpen(ctx, tx, p.effectiveDate, p.correctsPeriod); appErr != nil {
+		return nil, appErr
+	}
+
+	sourceAccount, destAccount, appErr := c.lockAccountsInOrder(ctx, tx, p)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := c.validateAccounts(ctx, sourceAccount, destAccount, p); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := c.validateLeafAccounts(ctx, tx, sourceAccount, destAccount); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := c.validateSufficientBalance(ctx, sourceAccount, p.amount, p.sourceAccountID); appErr != nil {
+		return nil, appErr
+	}
+
+	return c.executeTransfer(ctx, tx, sourceAccount, destAccount, p)
+}
+
+// validateTransferRequest validates the resolved transfer and returns the parsed amount
+func (c *Core) validateTransferRequest(sourceAccountID, destAccountID int64, amount string) (decimal.Decimal, apperror.IError) {
+	if sourceAccountID == destAccountID {
 		return decimal.Zero, apperror.NewWithMessage(apperror.CodeBadRequest, ErrSameAccountTransfer, apperror.MsgSameAccountTransfer).
-			WithField(apperror.FieldSourceAccount, req.SourceAccountID).
-			WithField(apperror.FieldDestAccount, req.DestinationAccountID)
+			WithField(apperror.FieldSourceAccount, sourceAccountID).
+			WithField(apperror.FieldDestAccount, destAccountID)
 	}
 
-	amount, err := decimal.NewFromString(req.Amount)
+	return parseAmount(amount)
+}
+
+// parseAmount parses and validates a positive amount with at most 8 decimal places
+func parseAmount(value string) (decimal.Decimal, apperror.IError) {
+	amount, err := decimal.NewFromString(value)
 	if err != nil {
 		return decimal.Zero, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidDecimalAmt, apperror.MsgInvalidAmount).
-			WithField(apperror.FieldAmount, req.Amount)
+			WithField(apperror.FieldAmount, value)
 	}
 
 	if amount.LessThanOrEqual(decimal.Zero) {
 		return decimal.Zero, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAmount, apperror.MsgInvalidAmount).
-			WithField(apperror.FieldAmount, req.Amount)
+			WithField(apperror.FieldAmount, value)
 	}
 
 	// Validate decimal precision (max 8 places to match DB schema)
@@ -133,6 +421,45 @@ func (c *Core) validateTransferRequest(req *entities.TransferRequest) (decimal.D
 	return amount, nil
 }
 
+// clearingAccountFor resolves the clearing account matching a customer account's currency
+func (c *Core) clearingAccountFor(ctx context.Context, accountID int64) (*account.Account, apperror.IError) {
+	if accountID <= 0 {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrInvalidAccountID, apperror.MsgInvalidAccountID).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	customer, err := c.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, toAppError(err, ErrAccountNotFound, apperror.MsgAccountNotFound).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	if customer.IsSystem() {
+		return nil, apperror.NewWithMessage(apperror.CodeBadRequest, ErrSystemAccount, apperror.MsgSystemAccountTransfer).
+			WithField(apperror.FieldAccountID, accountID)
+	}
+
+	clearing, err := c.accountRepo.GetClearingAccount(ctx, customer.Currency)
+	if err != nil {
+		return nil, toAppError(err, account.ErrClearingNotFound, apperror.MsgClearingNotFound).
+			WithField(apperror.FieldCurrency, customer.Currency)
+	}
+
+	return clearing, nil
+}
+
+// toAppError converts repository errors, mapping NOT_FOUND to the given cause and public message
+func toAppError(err error, notFoundCause error, notFoundMsg string) apperror.IError {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		if appErr.Code() == apperror.CodeNotFound {
+			return apperror.NewWithMessage(apperror.CodeNotFound, notFoundCause, notFoundMsg)
+		}
+		return appErr
+	}
+	return apperror.New(apperror.CodeInternalError, err)
+}
+
 // beginTransaction starts a new database transaction
 func (c *Core) beginTransaction(ctx context.Context) (pgx.Tx, apperror.IError) {
 	tx, err := c.txRepo.BeginTx(ctx)
@@ -153,25 +480,79 @@ func (c *Core) rollbackIfNotCommitted(ctx context.Context, tx pgx.Tx, committed
 }
 
 // lockAccountsInOrder locks accounts in consistent order to prevent deadlocks
-func (c *Core) lockAccountsInOrder(ctx context.Context, tx pgx.Tx, req *entities.TransferRequest) (*account.Account, *account.Account, apperror.IError) {
-	firstAccountID, secondAccountID := orderAccountIDs(req.SourceAccountID, req.DestinationAccountID)
+func (c *Core) lockAccountsInOrder(ctx context.Context, tx pgx.Tx, p *posting) (*account.Account, *account.Account, apperror.IError) {
+	firstAccountID, secondAccountID := orderAccountIDs(p.sourceAccountID, p.destAccountID)
 
 	firstAccount, err := c.accountRepo.GetForUpdate(ctx, tx, firstAccountID)
 	if err != nil {
-		return nil, nil, c.handleAccountError(err, firstAccountID, req.SourceAccountID)
+		return nil, nil, c.handleAccountError(err, firstAccountID, p.sourceAccountID)
 	}
 
 	secondAccount, err := c.accountRepo.GetForUpdate(ctx, tx, secondAccountID)
 	if err != nil {
-		return nil, nil, c.handleAccountError(err, secondAccountID, req.SourceAccountID)
+		return nil, nil, c.handleAccountError(err, secondAccountID, p.sourceAccountID)
 	}
 
-	sourceAccount, destAccount := assignSourceAndDest(firstAccountID, req.SourceAccountID, firstAccount, secondAccount)
+	sourceAccount, destAccount := assignSourceAndDest(firstAccountID, p.sourceAccountID, firstAccount, secondAccount)
 	return sourceAccount, destAccount, nil
 }
 
-// validateSufficientBalance checks if source account has sufficient balance
+// validateAccounts checks the locked accounts can take part in the posting:
+// both must share a currency, and plain transfers may not touch system accounts.
+func (c *Core) validateAccounts(ctx context.Context, sourceAccount, destAccount *account.Account, p *posting) apperror.IError {
+	if sourceAccount.Currency != destAccount.Currency {
+		logger.Ctx(ctx).Warnw(constants.LogMsgCurrencyMismatch,
+			constants.LogKeySourceAccount, sourceAccount.AccountID,
+			constants.LogKeyDestAccount, destAccount.AccountID,
+		)
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrCurrencyMismatch, apperror.MsgCurrencyMismatch).
+			WithField(apperror.FieldSourceAccount, sourceAccount.AccountID).
+			WithField(apperror.FieldDestAccount, destAccount.AccountID)
+	}
+
+	if p.txType == entities.TypeTransfer && (sourceAccount.IsSystem() || destAccount.IsSystem()) {
+		return apperror.NewWithMessage(apperror.CodeBadRequest, ErrSystemAccount, apperror.MsgSystemAccountTransfer).
+			WithField(apperror.FieldSourceAccount, sourceAccount.AccountID).
+			WithField(apperror.FieldDestAccount, destAccount.AccountID)
+	}
+
+	return nil
+}
+
+// validateLeafAccounts checks that neither customer account has sub-accounts.
+// Funds live only in leaf accounts; parents report them through roll-up balances.
+// System accounts never have sub-accounts and are not checked.
+func (c *Core) validateLeafAccounts(ctx context.Context, tx pgx.Tx, accounts ...*account.Account) apperror.IError {
+	for _, acc := range accounts {
+		if acc.IsSystem() {
+			continue
+		}
+
+		hasChildren, err := c.accountRepo.HasChildren(ctx, tx, acc.AccountID)
+		if err != nil {
+			return apperror.New(apperror.CodeInternalError, err).
+				WithField(apperror.FieldAccountID, acc.AccountID)
+		}
+		if hasChildren {
+			logger.Ctx(ctx).Warnw(constants.LogMsgPostingToParentAccount,
+				constants.LogKeyAccountID, acc.AccountID,
+			)
+			return apperror.NewWithMessage(apperror.CodeBadRequest, ErrNotLeafAccount, apperror.MsgNotLeafAccount).
+				WithField(apperror.FieldAccountID, acc.AccountID)
+		}
+	}
+	return nil
+}
+
+// validateSufficientBalance checks if source account has sufficient balance, and that the
+// debit keeps it at or above its minimum balance. The two failures have distinct codes so
+// that clients can tell an empty account from one whose remaining funds are reserved.
+// System accounts (e.g. clearing) are allowed to go negative.
 func (c *Core) validateSufficientBalance(ctx context.Context, sourceAccount *account.Account, amount decimal.Decimal, sourceAccountID int64) apperror.IError {
+	if sourceAccount.IsSystem() {
+		return nil
+	}
+
 	if sourceAccount.Balance.LessThan(amount) {
 		logger.Ctx(ctx).Warnw(constants.LogMsgInsufficientBalance,
 			constants.LogKeySourceAccount, sourceAccountID,
@@ -183,24 +564,46 @@ func (c *Core) validateSufficientBalance(ctx context.Context, sourceAccount *acc
 			WithField(constants.LogFieldCurrentBalance, sourceAccount.Balance.String()).
 			WithField(constants.LogFieldRequestedAmt, amount.String())
 	}
+
+	if sourceAccount.Balance.Sub(amount).LessThan(sourceAccount.MinimumBalance) {
+		available := decimal.Max(sourceAccount.Balance.Sub(sourceAccount.MinimumBalance), decimal.Zero)
+		logger.Ctx(ctx).Warnw(constants.LogMsgBelowMinimumBalance,
+			constants.LogKeySourceAccount, sourceAccountID,
+			constants.LogFieldCurrentBalance, sourceAccount.Balance.String(),
+			constants.LogFieldMinimumBalance, sourceAccount.MinimumBalance.String(),
+			constants.LogFieldRequestedAmt, amount.String(),
+		)
+		return apperror.NewWithMessage(apperror.CodeBelowMinimumBalance, ErrBelowMinimumBalance, apperror.MsgBelowMinimumBalance).
+			WithField(apperror.FieldSourceAccount, sourceAccountID).
+			WithField(apperror.FieldMinimumBalance, sourceAccount.MinimumBalance.String()).
+			WithField(apperror.FieldAvailable, available.String()).
+			WithField(constants.LogFieldRequestedAmt, amount.String())
+	}
 	return nil
 }
 
 // executeTransfer updates balances and creates the transaction record
-func (c *Core) executeTransfer(ctx context.Context, tx pgx.Tx, sourceAccount, destAccount *account.Account, amount decimal.Decimal, req *entities.TransferRequest) (*Transaction, apperror.IError) {
-	if appErr := c.updateSourceBalance(ctx, tx, sourceAccount, amount); appErr != nil {
+func (c *Core) executeTransfer(ctx context.Context, tx pgx.Tx, sourceAccount, destAccount *account.Account, p *posting) (*Transaction, apperror.IError) {
+	if appErr := c.updateSourceBalance(ctx, tx, sourceAccount, p.amount); appErr != nil {
 		return nil, appErr
 	}
 
-	if appErr := c.updateDestBalance(ctx, tx, destAccount, amount); appErr != nil {
+	if appErr := c.updateDestBalance(ctx, tx, destAccount, p.amount); appErr != nil {
 		return nil, appErr
 	}
 
-	txRecord, appErr := c.createTransactionRecord(ctx, tx, req, amount)
+	p.sourceBalance = sourceAccount.Balance.Sub(p.amount)
+	p.destBalance = destAccount.Balance.Add(p.amount)
+
+	txRecord, appErr := c.createTransactionRecord(ctx, tx, p)
 	if appErr != nil {
 		return nil, appErr
 	}
 
+	if appErr := c.writeLedgerEntries(ctx, tx, txRecord, p); appErr != nil {
+		return nil, appErr
+	}
+
 	return txRecord, nil
 }
 
@@ -231,11 +634,15 @@ func (c *Core) updateDestBalance(ctx context.Context, tx pgx.Tx, destAccount *ac
 }
 
 // createTransactionRecord creates the transaction audit record
-func (c *Core) createTransactionRecord(ctx context.Context, tx pgx.Tx, req *entities.TransferRequest, amount decimal.Decimal) (*Transaction, apperror.IError) {
+func (c *Core) createTransactionRecord(ctx context.Context, tx pgx.Tx, p *posting) (*Transaction, apperror.IError) {
 	txRecord := &Transaction{
-		SourceAccountID:      req.SourceAccountID,
-		DestinationAccountID: req.DestinationAccountID,
-		Amount:               amount,
+		ID:                   p.transactionID,
+		SourceAccountID:      p.sourceAccountID,
+		DestinationAccountID: p.destAccountID,
+		Amount:               p.amount,
+		Type:                 p.txType,
+		EffectiveDate:        p.effectiveDate,
+		CorrectsPeriod:       p.correctsPeriod,
 	}
 
 	if err := c.txRepo.Create(ctx, tx, txRecord); err != nil {
@@ -248,6 +655,38 @@ func (c *Core) createTransactionRecord(ctx context.Context, tx pgx.Tx, req *enti
 	return txRecord, nil
 }
 
+// writeLedgerEntries records the debit on the source and the credit on the destination,
+// each with the account's resulting balance
+func (c *Core) writeLedgerEntries(ctx context.Context, tx pgx.Tx, txRecord *Transaction, p *posting) apperror.IError {
+	entries := []*LedgerEntry{
+		{
+			TransactionID: txRecord.ID,
+			AccountID:     p.sourceAccountID,
+			Direction:     entities.DirectionDebit,
+			Amount:        p.amount,
+			BalanceAfter:  p.sourceBalance,
+			CreatedAt:     txRecord.CreatedAt,
+		},
+		{
+			TransactionID: txRecord.ID,
+			AccountID:     p.destAccountID,
+			Direction:     entities.DirectionCredit,
+			Amount:        p.amount,
+			BalanceAfter:  p.destBalance,
+			CreatedAt:     txRecord.CreatedAt,
+		},
+	}
+
+	if err := c.txRepo.CreateLedgerEntries(ctx, tx, entries); err != nil {
+		logger.Ctx(ctx).Errorw(constants.LogMsgFailedToWriteLedger,
+			constants.LogFieldTransactionID, txRecord.ID.String(),
+			constants.LogKeyError, err,
+		)
+		return apperror.New(apperror.CodeInternalError, err)
+	}
+	return nil
+}
+
 // commitTransaction commits the database transaction
 func (c *Core) commitTransaction(ctx context.Context, tx pgx.Tx) apperror.IError {
 	if err := tx.Commit(ctx); err != nil {
@@ -260,15 +699,36 @@ func (c *Core) commitTransaction(ctx context.Context, tx pgx.Tx) apperror.IError
 }
 
 // logTransferCompleted logs successful transfer completion
-func (c *Core) logTransferCompleted(ctx context.Context, txRecord *Transaction, req *entities.TransferRequest, amount decimal.Decimal) {
+func (c *Core) logTransferCompleted(ctx context.Context, txRecord *Transaction, p *posting) {
 	logger.Ctx(ctx).Infow(constants.LogMsgTransferCompleted,
 		constants.LogFieldTransactionID, txRecord.ID.String(),
-		constants.LogKeySourceAccount, req.SourceAccountID,
-		constants.LogKeyDestAccount, req.DestinationAccountID,
-		constants.LogKeyAmount, amount.String(),
+		constants.LogFieldTxType, p.txType,
+		constants.LogKeySourceAccount, p.sourceAccountID,
+		constants.LogKeyDestAccount, p.destAccountID,
+		constants.LogKeyAmount, p.amount.String(),
 	)
 }
 
+// notifyCommitted reports a committed posting to the commit listeners
+func (c *Core) notifyCommitted(ctx context.Context, txRecord *Transaction, p *posting) {
+	if len(c.listeners) == 0 {
+		return
+	}
+
+	committed := &entities.CommittedPosting{
+		TransactionID:        txRecord.ID,
+		Type:                 p.txType,
+		SourceAccountID:      p.sourceAccountID,
+		DestinationAccountID: p.destAccountID,
+		Amount:               p.amount,
+		SourceBalance:        p.sourceBalance,
+		DestinationBalance:   p.destBalance,
+	}
+	for _, listener := range c.listeners {
+		listener.OnCommitted(ctx, committed)
+	}
+}
+
 // orderAccountIDs returns account IDs in ascending order for consistent locking
 func orderAccountIDs(sourceID, destID int64) (first, second int64) {
 	if sourceID < destID {
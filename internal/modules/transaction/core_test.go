@@ -4,14 +4,22 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/internal-transfers-service/internal/modules/account"
+	accountEntities "github.com/internal-transfers-service/internal/modules/account/entities"
 	accountMock "github.com/internal-transfers-service/internal/modules/account/mock"
+	"github.com/internal-transfers-service/internal/modules/idempotency"
+	idempotencyEntities "github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	idempotencyMock "github.com/internal-transfers-service/internal/modules/idempotency/mock"
 	"github.com/internal-transfers-service/internal/modules/transaction"
 	"github.com/internal-transfers-service/internal/modules/transaction/entities"
 	txMock "github.com/internal-transfers-service/internal/modules/transaction/mock"
 	"github.com/internal-transfers-service/pkg/apperror"
 	dbMock "github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -23,8 +31,12 @@ const (
 	testDestinationAccountID = int64(200)
 	testValidAmount          = "50.00"
 	testLargeAmount          = "1000.00"
+	testClearingAccountID    = int64(1)
 )
 
+// testToday is the business date reported by the period guard
+var testToday = time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+
 // Test error constants - used for simulating database errors in tests
 var (
 	errDatabaseConnectionFailed = errors.New("database connection failed")
@@ -40,6 +52,7 @@ type CoreTestSuite struct {
 	mockTxRepo      *txMock.MockIRepository
 	mockAccountRepo *accountMock.MockIRepository
 	mockPgxTx       *dbMock.MockTx
+	mockPeriods     *txMock.MockIPeriodGuard
 	core            transaction.ICore
 	ctx             context.Context
 }
@@ -53,8 +66,11 @@ func (s *CoreTestSuite) SetupTest() {
 	s.mockTxRepo = txMock.NewMockIRepository(s.ctrl)
 	s.mockAccountRepo = accountMock.NewMockIRepository(s.ctrl)
 	s.mockPgxTx = dbMock.NewMockTx(s.ctrl)
+	s.mockPeriods = txMock.NewMockIPeriodGuard(s.ctrl)
+	s.mockPeriods.EXPECT().Today().Return(testToday).AnyTimes()
+	s.mockPeriods.EXPECT().CheckOpen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	s.ctx = context.Background()
-	s.core = transaction.NewCoreWithRepo(s.ctx, s.mockTxRepo, s.mockAccountRepo)
+	s.core = transaction.NewCoreWithRepo(s.ctx, s.mockTxRepo, s.mockAccountRepo, s.mockPeriods)
 }
 
 func (s *CoreTestSuite) TearDownTest() {
@@ -67,6 +83,8 @@ func (s *CoreTestSuite) createSourceAccount(balance string) *account.Account {
 	return &account.Account{
 		AccountID: testSourceAccountID,
 		Balance:   bal,
+		Currency:  accountEntities.DefaultCurrency,
+		Kind:      accountEntities.KindCustomer,
 	}
 }
 
@@ -76,9 +94,40 @@ func (s *CoreTestSuite) createDestAccount(balance string) *account.Account {
 	return &account.Account{
 		AccountID: testDestinationAccountID,
 		Balance:   bal,
+		Currency:  accountEntities.DefaultCurrency,
+		Kind:      accountEntities.KindCustomer,
+	}
+}
+
+// Helper method to create the USD clearing account with balance
+func (s *CoreTestSuite) createClearingAccount(balance string) *account.Account {
+	bal, _ := decimal.NewFromString(balance)
+	return &account.Account{
+		AccountID: testClearingAccountID,
+		Balance:   bal,
+		Currency:  accountEntities.DefaultCurrency,
+		Kind:      accountEntities.KindClearing,
 	}
 }
 
+// expectLeafAccounts expects the sub-account check for each customer account in a posting
+func (s *CoreTestSuite) expectLeafAccounts(accountIDs ...int64) {
+	for _, accountID := range accountIDs {
+		s.mockAccountRepo.EXPECT().
+			HasChildren(s.ctx, s.mockPgxTx, accountID).
+			Return(false, nil).
+			Times(1)
+	}
+}
+
+// expectLedgerEntries expects the posting's ledger entries to be written
+func (s *CoreTestSuite) expectLedgerEntries() {
+	s.mockTxRepo.EXPECT().
+		CreateLedgerEntries(s.ctx, s.mockPgxTx, gomock.Any()).
+		Return(nil).
+		Times(1)
+}
+
 // Test Transfer - Success Cases
 
 func (s *CoreTestSuite) TestTransferWithValidDataSucceeds() {
@@ -97,6 +146,8 @@ func (s *CoreTestSuite) TestTransferWithValidDataSucceeds() {
 		Return(s.mockPgxTx, nil).
 		Times(1)
 
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+
 	// Lock accounts in order (source=100 < dest=200, so source first)
 	s.mockAccountRepo.EXPECT().
 		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
@@ -133,6 +184,7 @@ func (s *CoreTestSuite) TestTransferWithValidDataSucceeds() {
 			return nil
 		}).
 		Times(1)
+	s.expectLedgerEntries()
 
 	// Commit transaction
 	s.mockPgxTx.EXPECT().
@@ -157,10 +209,14 @@ func (s *CoreTestSuite) TestTransferWithReversedAccountOrderLocksCorrectly() {
 	sourceAccount := &account.Account{
 		AccountID: testDestinationAccountID,
 		Balance:   decimal.NewFromFloat(100.00),
+		Currency:  accountEntities.DefaultCurrency,
+		Kind:      accountEntities.KindCustomer,
 	}
 	destAccount := &account.Account{
 		AccountID: testSourceAccountID,
 		Balance:   decimal.NewFromFloat(50.00),
+		Currency:  accountEntities.DefaultCurrency,
+		Kind:      accountEntities.KindCustomer,
 	}
 
 	s.mockTxRepo.EXPECT().
@@ -168,6 +224,8 @@ func (s *CoreTestSuite) TestTransferWithReversedAccountOrderLocksCorrectly() {
 		Return(s.mockPgxTx, nil).
 		Times(1)
 
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+
 	// Lock in order: 100 first, then 200
 	s.mockAccountRepo.EXPECT().
 		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID). // 100 first
@@ -194,6 +252,7 @@ func (s *CoreTestSuite) TestTransferWithReversedAccountOrderLocksCorrectly() {
 		Create(s.ctx, s.mockPgxTx, gomock.Any()).
 		Return(nil).
 		Times(1)
+	s.expectLedgerEntries()
 
 	s.mockPgxTx.EXPECT().
 		Commit(s.ctx).
@@ -220,6 +279,8 @@ func (s *CoreTestSuite) TestTransferWithHighPrecisionAmountSucceeds() {
 		Return(s.mockPgxTx, nil).
 		Times(1)
 
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+
 	s.mockAccountRepo.EXPECT().
 		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
 		Return(sourceAccount, nil).
@@ -252,6 +313,7 @@ func (s *CoreTestSuite) TestTransferWithHighPrecisionAmountSucceeds() {
 		Create(s.ctx, s.mockPgxTx, gomock.Any()).
 		Return(nil).
 		Times(1)
+	s.expectLedgerEntries()
 
 	s.mockPgxTx.EXPECT().
 		Commit(s.ctx).
@@ -423,6 +485,8 @@ func (s *CoreTestSuite) TestTransferWithInsufficientBalanceFails() {
 		Return(s.mockPgxTx, nil).
 		Times(1)
 
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+
 	s.mockAccountRepo.EXPECT().
 		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
 		Return(sourceAccount, nil).
@@ -459,6 +523,8 @@ func (s *CoreTestSuite) TestTransferWithExactBalanceSucceeds() {
 		Return(s.mockPgxTx, nil).
 		Times(1)
 
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+
 	s.mockAccountRepo.EXPECT().
 		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
 		Return(sourceAccount, nil).
@@ -492,6 +558,7 @@ func (s *CoreTestSuite) TestTransferWithExactBalanceSucceeds() {
 		Create(s.ctx, s.mockPgxTx, gomock.Any()).
 		Return(nil).
 		Times(1)
+	s.expectLedgerEntries()
 
 	s.mockPgxTx.EXPECT().
 		Commit(s.ctx).
@@ -520,6 +587,8 @@ func (s *CoreTestSuite) TestTransferWhenUpdateSourceBalanceFailsReturnsError() {
 		Return(s.mockPgxTx, nil).
 		Times(1)
 
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+
 	s.mockAccountRepo.EXPECT().
 		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
 		Return(sourceAccount, nil).
@@ -561,6 +630,8 @@ func (s *CoreTestSuite) TestTransferWhenUpdateDestBalanceFailsReturnsError() {
 		Return(s.mockPgxTx, nil).
 		Times(1)
 
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+
 	s.mockAccountRepo.EXPECT().
 		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
 		Return(sourceAccount, nil).
@@ -609,6 +680,8 @@ func (s *CoreTestSuite) TestTransferWhenCreateTransactionRecordFailsReturnsError
 		Return(s.mockPgxTx, nil).
 		Times(1)
 
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+
 	s.mockAccountRepo.EXPECT().
 		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
 		Return(sourceAccount, nil).
@@ -662,6 +735,8 @@ func (s *CoreTestSuite) TestTransferWhenCommitFailsReturnsError() {
 		Return(s.mockPgxTx, nil).
 		Times(1)
 
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+
 	s.mockAccountRepo.EXPECT().
 		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
 		Return(sourceAccount, nil).
@@ -686,6 +761,7 @@ func (s *CoreTestSuite) TestTransferWhenCommitFailsReturnsError() {
 		Create(s.ctx, s.mockPgxTx, gomock.Any()).
 		Return(nil).
 		Times(1)
+	s.expectLedgerEntries()
 
 	s.mockPgxTx.EXPECT().
 		Commit(s.ctx).
@@ -702,3 +778,904 @@ func (s *CoreTestSuite) TestTransferWhenCommitFailsReturnsError() {
 	s.Nil(response)
 	s.Equal(apperror.CodeInternalError, err.Code())
 }
+
+// Test Transfer - Account Compatibility
+
+func (s *CoreTestSuite) TestTransferWithCurrencyMismatchFails() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	destAccount := s.createDestAccount("50.00")
+	destAccount.Currency = "EUR"
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
+		Return(s.createSourceAccount("100.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).
+		Return(destAccount, nil).
+		Times(1)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgCurrencyMismatch, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestTransferFromClearingAccountFails() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testClearingAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testClearingAccountID).
+		Return(s.createClearingAccount("0"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).
+		Return(s.createDestAccount("50.00"), nil).
+		Times(1)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgSystemAccountTransfer, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestTransferToParentAccountFails() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).Return(s.createSourceAccount("100.00"), nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).Return(s.createDestAccount("0"), nil).Times(1)
+	s.mockAccountRepo.EXPECT().HasChildren(s.ctx, s.mockPgxTx, testSourceAccountID).Return(false, nil).Times(1)
+	s.mockAccountRepo.EXPECT().HasChildren(s.ctx, s.mockPgxTx, testDestinationAccountID).Return(true, nil).Times(1)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgNotLeafAccount, err.PublicMessage())
+	s.Equal(testDestinationAccountID, err.Fields()[apperror.FieldAccountID])
+}
+
+func (s *CoreTestSuite) TestTransferWhenChildCheckFailsReturnsError() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).Return(s.createSourceAccount("100.00"), nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).Return(s.createDestAccount("0"), nil).Times(1)
+	s.mockAccountRepo.EXPECT().HasChildren(s.ctx, s.mockPgxTx, testSourceAccountID).Return(false, errDatabaseConnectionFailed).Times(1)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
+
+func (s *CoreTestSuite) TestTransferBelowMinimumBalanceFailsWithDedicatedCode() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+	sourceAccount := s.createSourceAccount("100.00")
+	sourceAccount.MinimumBalance = decimal.RequireFromString("60")
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).Return(sourceAccount, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).Return(s.createDestAccount("0"), nil).Times(1)
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeBelowMinimumBalance, err.Code())
+	s.Equal(apperror.MsgBelowMinimumBalance, err.PublicMessage())
+	s.Equal("40", err.Fields()[apperror.FieldAvailable])
+}
+
+func (s *CoreTestSuite) TestTransferDownToMinimumBalanceSucceeds() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+	sourceAccount := s.createSourceAccount("100.00")
+	sourceAccount.MinimumBalance = decimal.RequireFromString("50")
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).Return(sourceAccount, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).Return(s.createDestAccount("0"), nil).Times(1)
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockAccountRepo.EXPECT().UpdateBalance(s.ctx, s.mockPgxTx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	s.mockTxRepo.EXPECT().Create(s.ctx, s.mockPgxTx, gomock.Any()).Return(nil).Times(1)
+	s.expectLedgerEntries()
+	s.mockPgxTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).AnyTimes()
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(err)
+	s.NotNil(response)
+}
+
+func (s *CoreTestSuite) TestTransferFromEmptyAccountWithFloorReportsInsufficientFunds() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+	sourceAccount := s.createSourceAccount("10.00")
+	sourceAccount.MinimumBalance = decimal.RequireFromString("5")
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).Return(sourceAccount, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).Return(s.createDestAccount("0"), nil).Times(1)
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeInsufficientFunds, err.Code())
+}
+
+// Test effective dates and accounting periods
+
+// expectTransferPosting expects a successful 50.00 transfer from the source to the
+// destination account and hands the transaction record to check
+func (s *CoreTestSuite) expectTransferPosting(check func(txRecord *transaction.Transaction)) {
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
+		Return(s.createSourceAccount("100.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).
+		Return(s.createDestAccount("50.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().UpdateBalance(s.ctx, s.mockPgxTx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	s.mockTxRepo.EXPECT().
+		Create(s.ctx, s.mockPgxTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, txRecord *transaction.Transaction) error {
+			check(txRecord)
+			return nil
+		}).
+		Times(1)
+	s.expectLedgerEntries()
+	s.mockPgxTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+}
+
+func (s *CoreTestSuite) TestTransferWithoutEffectiveDatePostsToday() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	s.expectTransferPosting(func(txRecord *transaction.Transaction) {
+		s.Equal(testToday, txRecord.EffectiveDate)
+		s.Nil(txRecord.CorrectsPeriod)
+	})
+
+	_, err := s.core.Transfer(s.ctx, req)
+	s.Nil(err)
+}
+
+func (s *CoreTestSuite) TestTransferWithBackdatedCorrectionRecordsDates() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+		EffectiveDate:        "2026-03-02",
+		CorrectsPeriod:       "2026-01",
+	}
+
+	s.expectTransferPosting(func(txRecord *transaction.Transaction) {
+		s.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), txRecord.EffectiveDate)
+		s.Require().NotNil(txRecord.CorrectsPeriod)
+		s.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), *txRecord.CorrectsPeriod)
+	})
+
+	_, err := s.core.Transfer(s.ctx, req)
+	s.Nil(err)
+}
+
+func (s *CoreTestSuite) TestTransferWithFutureEffectiveDateReturnsBadRequest() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+		EffectiveDate:        "2026-03-16",
+	}
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.ErrorIs(err, transaction.ErrInvalidEffectiveDate)
+}
+
+func (s *CoreTestSuite) TestTransferWithMalformedEffectiveDateReturnsBadRequest() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+		EffectiveDate:        "02/03/2026",
+	}
+
+	_, err := s.core.Transfer(s.ctx, req)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgInvalidEffectiveDate, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestTransferWithMalformedCorrectsPeriodReturnsBadRequest() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+		CorrectsPeriod:       "2026-02-01",
+	}
+
+	_, err := s.core.Transfer(s.ctx, req)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.ErrorIs(err, transaction.ErrInvalidCorrectsPeriod)
+}
+
+func (s *CoreTestSuite) TestTransferInClosedPeriodIsRejectedBeforeLockingAccounts() {
+	periods := txMock.NewMockIPeriodGuard(s.ctrl)
+	core := transaction.NewCoreWithRepo(s.ctx, s.mockTxRepo, s.mockAccountRepo, periods)
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+		EffectiveDate:        "2026-02-27",
+	}
+	closedErr := apperror.NewWithMessage(apperror.CodePeriodClosed, errors.New("closed"), apperror.MsgPeriodClosed)
+
+	periods.EXPECT().Today().Return(testToday).Times(1)
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	periods.EXPECT().
+		CheckOpen(s.ctx, s.mockPgxTx, time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC), (*time.Time)(nil)).
+		Return(closedErr).
+		Times(1)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodePeriodClosed, err.Code())
+}
+
+func (s *CoreTestSuite) TestPostTxChecksTodayAgainstPeriods() {
+	periods := txMock.NewMockIPeriodGuard(s.ctrl)
+	core := transaction.NewCoreWithRepo(s.ctx, s.mockTxRepo, s.mockAccountRepo, periods)
+	closedErr := apperror.NewWithMessage(apperror.CodePeriodClosed, errors.New("closed"), apperror.MsgPeriodClosed)
+
+	periods.EXPECT().Today().Return(testToday).Times(1)
+	periods.EXPECT().CheckOpen(s.ctx, s.mockPgxTx, testToday, (*time.Time)(nil)).Return(closedErr).Times(1)
+
+	response, err := core.PostTx(s.ctx, s.mockPgxTx, &entities.PostingRequest{
+		SourceAccountID:      testClearingAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               decimal.NewFromInt(5),
+		Type:                 entities.TypeInterest,
+	})
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodePeriodClosed, err.Code())
+}
+
+// Test commit listeners
+
+func (s *CoreTestSuite) TestTransferNotifiesListenersAfterCommit() {
+	listener := txMock.NewMockICommitListener(s.ctrl)
+	core := transaction.NewCoreWithRepo(s.ctx, s.mockTxRepo, s.mockAccountRepo, s.mockPeriods, listener)
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+	txID := uuid.New()
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
+		Return(s.createSourceAccount("100.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).
+		Return(s.createDestAccount("10.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().UpdateBalance(s.ctx, s.mockPgxTx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	s.mockTxRepo.EXPECT().
+		Create(s.ctx, s.mockPgxTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, txRecord *transaction.Transaction) error {
+			txRecord.ID = txID
+			return nil
+		}).
+		Times(1)
+	s.expectLedgerEntries()
+	gomock.InOrder(
+		s.mockPgxTx.EXPECT().Commit(s.ctx).Return(nil),
+		listener.EXPECT().
+			OnCommitted(s.ctx, gomock.Any()).
+			Do(func(_ context.Context, posting *entities.CommittedPosting) {
+				s.Equal(txID, posting.TransactionID)
+				s.Equal(entities.TypeTransfer, posting.Type)
+				s.Equal(testSourceAccountID, posting.SourceAccountID)
+				s.Equal("50", posting.SourceBalance.String())
+				s.Equal("60", posting.DestinationBalance.String())
+			}),
+	)
+
+	_, err := core.Transfer(s.ctx, req)
+	s.Nil(err)
+}
+
+// Test transaction participants
+
+func (s *CoreTestSuite) TestTransferPreparesParticipantBeforeCommit() {
+	participant := txMock.NewMockITxParticipant(s.ctrl)
+	s.ctx = transaction.WithTxParticipant(s.ctx, participant)
+	txID := uuid.New()
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
+		Return(s.createSourceAccount("100.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).
+		Return(s.createDestAccount("10.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().UpdateBalance(s.ctx, s.mockPgxTx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	s.mockTxRepo.EXPECT().
+		Create(s.ctx, s.mockPgxTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, txRecord *transaction.Transaction) error {
+			txRecord.ID = txID
+			return nil
+		}).
+		Times(1)
+	s.expectLedgerEntries()
+	gomock.InOrder(
+		participant.EXPECT().
+			Prepare(s.ctx, s.mockPgxTx, &entities.TransferResponse{TransactionID: txID.String()}).
+			Return(nil),
+		s.mockPgxTx.EXPECT().Commit(s.ctx).Return(nil),
+	)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(err)
+	s.Equal(txID.String(), response.TransactionID)
+}
+
+func (s *CoreTestSuite) TestTransferWhenParticipantFailsRollsBack() {
+	participant := txMock.NewMockITxParticipant(s.ctrl)
+	s.ctx = transaction.WithTxParticipant(s.ctx, participant)
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
+		Return(s.createSourceAccount("100.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).
+		Return(s.createDestAccount("10.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().UpdateBalance(s.ctx, s.mockPgxTx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	s.mockTxRepo.EXPECT().Create(s.ctx, s.mockPgxTx, gomock.Any()).Return(nil).Times(1)
+	s.expectLedgerEntries()
+	participant.EXPECT().Prepare(s.ctx, s.mockPgxTx, gomock.Any()).Return(errInsertFailed).Times(1)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
+
+func (s *CoreTestSuite) TestTransferWhenIdempotencyKeyAlreadyCompletedRollsBack() {
+	// Another request completed the key after this one reserved it, so storing the
+	// response matches no reservation and the transfer must not commit a second debit
+	idempotencyRepo := idempotencyMock.NewMockIRepository(s.ctrl)
+	key := idempotencyEntities.ScopedKey{Client: "payments-api", Method: "POST", Route: "/transactions", Key: "transfer-1"}
+	reservedAt := time.Date(2026, 3, 15, 9, 30, 0, 0, time.UTC)
+	reservation := idempotency.NewReservation(idempotencyRepo, key, "fingerprint", reservedAt)
+	participant := txMock.NewMockITxParticipant(s.ctrl)
+	s.ctx = transaction.WithTxParticipant(s.ctx, participant)
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
+		Return(s.createSourceAccount("100.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).
+		Return(s.createDestAccount("10.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().UpdateBalance(s.ctx, s.mockPgxTx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	s.mockTxRepo.EXPECT().Create(s.ctx, s.mockPgxTx, gomock.Any()).Return(nil).Times(1)
+	s.expectLedgerEntries()
+	participant.EXPECT().
+		Prepare(s.ctx, s.mockPgxTx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, tx pgx.Tx, _ *entities.TransferResponse) error {
+			return reservation.CompleteTx(ctx, tx, 201, []byte(`{}`))
+		}).
+		Times(1)
+	idempotencyRepo.EXPECT().
+		StoreTx(s.ctx, s.mockPgxTx, key, "fingerprint", reservedAt, 201, gomock.Any()).
+		Return(idempotency.ErrReservationLost).
+		Times(1)
+	s.mockPgxTx.EXPECT().Commit(gomock.Any()).Times(0)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeInternalError, err.Code())
+	s.False(reservation.CompletedTx())
+}
+
+// Test ledger entries
+
+func (s *CoreTestSuite) TestTransferWritesDebitAndCreditWithRunningBalances() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+	txID := uuid.New()
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
+		Return(s.createSourceAccount("100.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).
+		Return(s.createDestAccount("10.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().UpdateBalance(s.ctx, s.mockPgxTx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	s.mockTxRepo.EXPECT().
+		Create(s.ctx, s.mockPgxTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, txRecord *transaction.Transaction) error {
+			txRecord.ID = txID
+			return nil
+		}).
+		Times(1)
+	s.mockTxRepo.EXPECT().
+		CreateLedgerEntries(s.ctx, s.mockPgxTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, entries []*transaction.LedgerEntry) error {
+			s.Require().Len(entries, 2)
+			s.Equal(txID, entries[0].TransactionID)
+			s.Equal(testSourceAccountID, entries[0].AccountID)
+			s.Equal(entities.DirectionDebit, entries[0].Direction)
+			s.Equal("50", entries[0].Amount.String())
+			s.Equal("50", entries[0].BalanceAfter.String())
+			s.Equal(txID, entries[1].TransactionID)
+			s.Equal(testDestinationAccountID, entries[1].AccountID)
+			s.Equal(entities.DirectionCredit, entries[1].Direction)
+			s.Equal("60", entries[1].BalanceAfter.String())
+			return nil
+		}).
+		Times(1)
+	s.mockPgxTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+
+	_, err := s.core.Transfer(s.ctx, req)
+	s.Nil(err)
+}
+
+func (s *CoreTestSuite) TestTransferWhenLedgerWriteFailsRollsBack() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
+		Return(s.createSourceAccount("100.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).
+		Return(s.createDestAccount("10.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().UpdateBalance(s.ctx, s.mockPgxTx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	s.mockTxRepo.EXPECT().Create(s.ctx, s.mockPgxTx, gomock.Any()).Return(nil).Times(1)
+	s.mockTxRepo.EXPECT().
+		CreateLedgerEntries(s.ctx, s.mockPgxTx, gomock.Any()).
+		Return(errDatabaseConnectionFailed).
+		Times(1)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Require().NotNil(err)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
+
+func (s *CoreTestSuite) TestFailedTransferDoesNotNotifyListeners() {
+	listener := txMock.NewMockICommitListener(s.ctrl)
+	core := transaction.NewCoreWithRepo(s.ctx, s.mockTxRepo, s.mockAccountRepo, s.mockPeriods, listener)
+
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(nil, errDatabaseConnectionFailed).Times(1)
+
+	_, err := core.Transfer(s.ctx, &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	})
+	s.NotNil(err)
+}
+
+// Test Transfer by alias
+
+func (s *CoreTestSuite) TestTransferByAliasResolvesBeforeLocking() {
+	req := &entities.TransferRequest{
+		SourceAlias:      "@alice",
+		DestinationAlias: "ZZ6812345678901234",
+		Amount:           testValidAmount,
+	}
+
+	gomock.InOrder(
+		s.mockAccountRepo.EXPECT().ResolveAlias(s.ctx, "@alice").Return(testSourceAccountID, nil),
+		s.mockAccountRepo.EXPECT().ResolveAlias(s.ctx, "ZZ6812345678901234").Return(testDestinationAccountID, nil),
+		s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil),
+	)
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).
+		Return(s.createSourceAccount("100.00"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).
+		Return(s.createDestAccount("0"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().UpdateBalance(s.ctx, s.mockPgxTx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	s.mockTxRepo.EXPECT().
+		Create(s.ctx, s.mockPgxTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, txRecord *transaction.Transaction) error {
+			s.Equal(testSourceAccountID, txRecord.SourceAccountID)
+			s.Equal(testDestinationAccountID, txRecord.DestinationAccountID)
+			return nil
+		}).
+		Times(1)
+	s.expectLedgerEntries()
+	s.mockPgxTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(err)
+	s.NotNil(response)
+}
+
+func (s *CoreTestSuite) TestTransferWithUnknownSourceAliasReturnsSourceNotFound() {
+	req := &entities.TransferRequest{
+		SourceAlias:          "@nobody",
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	s.mockAccountRepo.EXPECT().
+		ResolveAlias(s.ctx, "@nobody").
+		Return(int64(0), apperror.New(apperror.CodeNotFound, account.ErrAliasNotFound)).
+		Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeNotFound, err.Code())
+	s.Equal(apperror.MsgSourceNotFound, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestTransferWithUnknownDestinationAliasReturnsDestinationNotFound() {
+	req := &entities.TransferRequest{
+		SourceAccountID:  testSourceAccountID,
+		DestinationAlias: "ZZ0000",
+		Amount:           testValidAmount,
+	}
+
+	s.mockAccountRepo.EXPECT().
+		ResolveAlias(s.ctx, "ZZ0000").
+		Return(int64(0), apperror.New(apperror.CodeNotFound, account.ErrAliasNotFound)).
+		Times(1)
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeNotFound, err.Code())
+	s.Equal(apperror.MsgDestNotFound, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestTransferWithIDAndAliasForSameSideFails() {
+	req := &entities.TransferRequest{
+		SourceAccountID:      testSourceAccountID,
+		SourceAlias:          "@alice",
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	response, err := s.core.Transfer(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+	s.Equal(apperror.MsgAmbiguousAccountRef, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestTransferWhenAliasLookupFailsReturnsInternalError() {
+	req := &entities.TransferRequest{
+		SourceAlias:          "@alice",
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               testValidAmount,
+	}
+
+	s.mockAccountRepo.EXPECT().
+		ResolveAlias(s.ctx, "@alice").
+		Return(int64(0), errDatabaseConnectionFailed).
+		Times(1)
+
+	_, err := s.core.Transfer(s.ctx, req)
+	s.Equal(apperror.CodeInternalError, err.Code())
+}
+
+// Test Deposit
+
+func (s *CoreTestSuite) TestDepositCreditsAccountFromClearing() {
+	req := &entities.DepositRequest{AccountID: testDestinationAccountID, Amount: testValidAmount}
+	destAccount := s.createDestAccount("10.00")
+	clearing := s.createClearingAccount("0")
+
+	s.mockAccountRepo.EXPECT().GetByID(s.ctx, testDestinationAccountID).Return(destAccount, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetClearingAccount(s.ctx, accountEntities.DefaultCurrency).Return(clearing, nil).Times(1)
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+
+	s.expectLeafAccounts(testDestinationAccountID)
+
+	// Clearing account (1) is locked before the customer account (200)
+	gomock.InOrder(
+		s.mockAccountRepo.EXPECT().
+			GetForUpdate(s.ctx, s.mockPgxTx, testClearingAccountID).
+			Return(clearing, nil),
+		s.mockAccountRepo.EXPECT().
+			GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).
+			Return(destAccount, nil),
+	)
+
+	// Clearing goes negative without an insufficient-balance error
+	s.mockAccountRepo.EXPECT().
+		UpdateBalance(s.ctx, s.mockPgxTx, testClearingAccountID, decimal.RequireFromString("-50.00")).
+		Return(nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		UpdateBalance(s.ctx, s.mockPgxTx, testDestinationAccountID, decimal.RequireFromString("60.00")).
+		Return(nil).
+		Times(1)
+	s.mockTxRepo.EXPECT().
+		Create(s.ctx, s.mockPgxTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, tx *transaction.Transaction) error {
+			s.Equal(entities.TypeDeposit, tx.Type)
+			s.Equal(testClearingAccountID, tx.SourceAccountID)
+			s.Equal(testDestinationAccountID, tx.DestinationAccountID)
+			return nil
+		}).
+		Times(1)
+	s.expectLedgerEntries()
+	s.mockPgxTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Deposit(s.ctx, req)
+	s.Nil(err)
+	s.NotNil(response)
+}
+
+func (s *CoreTestSuite) TestDepositWithInvalidAmountFails() {
+	req := &entities.DepositRequest{AccountID: testDestinationAccountID, Amount: "-5"}
+
+	response, err := s.core.Deposit(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+}
+
+func (s *CoreTestSuite) TestDepositWithInvalidAccountIDFails() {
+	req := &entities.DepositRequest{AccountID: 0, Amount: testValidAmount}
+
+	response, err := s.core.Deposit(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+}
+
+func (s *CoreTestSuite) TestDepositToUnknownAccountReturnsNotFound() {
+	req := &entities.DepositRequest{AccountID: testDestinationAccountID, Amount: testValidAmount}
+
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, testDestinationAccountID).
+		Return(nil, apperror.New(apperror.CodeNotFound, errDatabaseConnectionFailed)).
+		Times(1)
+
+	response, err := s.core.Deposit(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeNotFound, err.Code())
+	s.Equal(apperror.MsgAccountNotFound, err.PublicMessage())
+}
+
+func (s *CoreTestSuite) TestDepositToClearingAccountFails() {
+	req := &entities.DepositRequest{AccountID: testClearingAccountID, Amount: testValidAmount}
+
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, testClearingAccountID).
+		Return(s.createClearingAccount("0"), nil).
+		Times(1)
+
+	response, err := s.core.Deposit(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeBadRequest, err.Code())
+}
+
+func (s *CoreTestSuite) TestDepositWithoutClearingAccountReturnsNotFound() {
+	req := &entities.DepositRequest{AccountID: testDestinationAccountID, Amount: testValidAmount}
+
+	s.mockAccountRepo.EXPECT().
+		GetByID(s.ctx, testDestinationAccountID).
+		Return(s.createDestAccount("0"), nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		GetClearingAccount(s.ctx, accountEntities.DefaultCurrency).
+		Return(nil, apperror.New(apperror.CodeNotFound, account.ErrClearingNotFound)).
+		Times(1)
+
+	response, err := s.core.Deposit(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeNotFound, err.Code())
+	s.Equal(apperror.MsgClearingNotFound, err.PublicMessage())
+}
+
+// Test Withdraw
+
+func (s *CoreTestSuite) TestWithdrawDebitsAccountToClearing() {
+	req := &entities.WithdrawalRequest{AccountID: testSourceAccountID, Amount: testValidAmount}
+	sourceAccount := s.createSourceAccount("80.00")
+	clearing := s.createClearingAccount("-100.00")
+
+	s.mockAccountRepo.EXPECT().GetByID(s.ctx, testSourceAccountID).Return(sourceAccount, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetClearingAccount(s.ctx, accountEntities.DefaultCurrency).Return(clearing, nil).Times(1)
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+
+	s.expectLeafAccounts(testSourceAccountID)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testClearingAccountID).Return(clearing, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).Return(sourceAccount, nil).Times(1)
+	s.mockAccountRepo.EXPECT().
+		UpdateBalance(s.ctx, s.mockPgxTx, testSourceAccountID, decimal.RequireFromString("30.00")).
+		Return(nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		UpdateBalance(s.ctx, s.mockPgxTx, testClearingAccountID, decimal.RequireFromString("-50.00")).
+		Return(nil).
+		Times(1)
+	s.mockTxRepo.EXPECT().
+		Create(s.ctx, s.mockPgxTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, tx *transaction.Transaction) error {
+			s.Equal(entities.TypeWithdrawal, tx.Type)
+			return nil
+		}).
+		Times(1)
+	s.expectLedgerEntries()
+	s.mockPgxTx.EXPECT().Commit(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Withdraw(s.ctx, req)
+	s.Nil(err)
+	s.NotNil(response)
+}
+
+func (s *CoreTestSuite) TestWithdrawWithInsufficientBalanceFails() {
+	req := &entities.WithdrawalRequest{AccountID: testSourceAccountID, Amount: testLargeAmount}
+	sourceAccount := s.createSourceAccount("80.00")
+	clearing := s.createClearingAccount("0")
+
+	s.mockAccountRepo.EXPECT().GetByID(s.ctx, testSourceAccountID).Return(sourceAccount, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetClearingAccount(s.ctx, accountEntities.DefaultCurrency).Return(clearing, nil).Times(1)
+	s.mockTxRepo.EXPECT().BeginTx(s.ctx).Return(s.mockPgxTx, nil).Times(1)
+
+	s.expectLeafAccounts(testSourceAccountID)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testClearingAccountID).Return(clearing, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).Return(sourceAccount, nil).Times(1)
+	s.mockPgxTx.EXPECT().Rollback(s.ctx).Return(nil).Times(1)
+
+	response, err := s.core.Withdraw(s.ctx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeInsufficientFunds, err.Code())
+}
+
+// Test PostTx
+
+func (s *CoreTestSuite) TestPostTxPostsWithCallerIDWithoutCommitting() {
+	transactionID := uuid.New()
+	req := &entities.PostingRequest{
+		TransactionID:        transactionID,
+		SourceAccountID:      testClearingAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               decimal.RequireFromString("0.1369863"),
+		Type:                 entities.TypeInterest,
+	}
+	clearing := s.createClearingAccount("0")
+	destAccount := s.createDestAccount("10.00")
+
+	s.expectLeafAccounts(testDestinationAccountID)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testClearingAccountID).Return(clearing, nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).Return(destAccount, nil).Times(1)
+	s.mockAccountRepo.EXPECT().
+		UpdateBalance(s.ctx, s.mockPgxTx, testClearingAccountID, decimal.RequireFromString("-0.1369863")).
+		Return(nil).
+		Times(1)
+	s.mockAccountRepo.EXPECT().
+		UpdateBalance(s.ctx, s.mockPgxTx, testDestinationAccountID, decimal.RequireFromString("10.1369863")).
+		Return(nil).
+		Times(1)
+	s.mockTxRepo.EXPECT().
+		Create(s.ctx, s.mockPgxTx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, tx *transaction.Transaction) error {
+			s.Equal(transactionID, tx.ID)
+			s.Equal(entities.TypeInterest, tx.Type)
+			return nil
+		}).
+		Times(1)
+	s.expectLedgerEntries()
+
+	response, err := s.core.PostTx(s.ctx, s.mockPgxTx, req)
+	s.Nil(err)
+	s.Equal(transactionID.String(), response.TransactionID)
+}
+
+func (s *CoreTestSuite) TestPostTxWithInsufficientBalanceFails() {
+	req := &entities.PostingRequest{
+		SourceAccountID:      testSourceAccountID,
+		DestinationAccountID: testDestinationAccountID,
+		Amount:               decimal.RequireFromString(testLargeAmount),
+		Type:                 entities.TypeTransfer,
+	}
+
+	s.expectLeafAccounts(testSourceAccountID, testDestinationAccountID)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testSourceAccountID).Return(s.createSourceAccount("10.00"), nil).Times(1)
+	s.mockAccountRepo.EXPECT().GetForUpdate(s.ctx, s.mockPgxTx, testDestinationAccountID).Return(s.createDestAccount("0"), nil).Times(1)
+
+	response, err := s.core.PostTx(s.ctx, s.mockPgxTx, req)
+	s.Nil(response)
+	s.Equal(apperror.CodeInsufficientFunds, err.Code())
+}
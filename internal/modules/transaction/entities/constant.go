@@ -3,16 +3,55 @@ package entities
 
 // Error messages for the transaction module
 const (
-	ErrMsgInsufficientBalance  = "insufficient balance for this transaction"
-	ErrMsgSameAccountTransfer  = "source and destination accounts must be different"
-	ErrMsgInvalidAmount        = "amount must be a positive number"
-	ErrMsgInvalidDecimalAmt    = "invalid decimal format for amount"
-	ErrMsgSourceNotFound       = "source account not found"
-	ErrMsgDestNotFound         = "destination account not found"
-	ErrMsgTooManyDecimalPlaces = "amount exceeds maximum precision"
+	ErrMsgInsufficientBalance   = "insufficient balance for this transaction"
+	ErrMsgSameAccountTransfer   = "source and destination accounts must be different"
+	ErrMsgInvalidAmount         = "amount must be a positive number"
+	ErrMsgInvalidDecimalAmt     = "invalid decimal format for amount"
+	ErrMsgSourceNotFound        = "source account not found"
+	ErrMsgDestNotFound          = "destination account not found"
+	ErrMsgTooManyDecimalPlaces  = "amount exceeds maximum precision"
+	ErrMsgAccountNotFound       = "account not found"
+	ErrMsgInvalidAccountID      = "invalid account ID"
+	ErrMsgCurrencyMismatch      = "source and destination currencies differ"
+	ErrMsgSystemAccount         = "system accounts cannot be used in this operation"
+	ErrMsgNotLeafAccount        = "account has sub-accounts and cannot be posted to"
+	ErrMsgBelowMinimumBalance   = "debit would take the balance below the account's minimum balance"
+	ErrMsgAmbiguousAccountRef   = "account addressed by both ID and alias"
+	ErrMsgInvalidEffectiveDate  = "invalid or future effective date"
+	ErrMsgInvalidCorrectsPeriod = "invalid corrected period, expected YYYY-MM"
 )
 
 // Route path constants for the transaction module
 const (
 	RouteTransactions = "/transactions"
+	RouteDeposits     = "/deposits"
+	RouteWithdrawals  = "/withdrawals"
+)
+
+// Transaction types (transactions.type column)
+const (
+	// TypeTransfer moves funds between two customer accounts
+	TypeTransfer = "transfer"
+	// TypeDeposit moves funds from the currency's clearing account into a customer account
+	TypeDeposit = "deposit"
+	// TypeWithdrawal moves funds from a customer account out to the currency's clearing account
+	TypeWithdrawal = "withdrawal"
+	// TypeOpening records an account's initial balance at creation; it has no source account
+	TypeOpening = "opening"
+	// TypeInterest pays accrued interest from the currency's interest-expense account into a customer account
+	TypeInterest = "interest"
+)
+
+// Wire formats of effective dates and of the accounting periods corrections refer to
+const (
+	DateLayout   = "2006-01-02"
+	PeriodLayout = "2006-01"
+)
+
+// Ledger entry directions (ledger_entries.direction column)
+const (
+	// DirectionDebit decreases the account's balance
+	DirectionDebit = "debit"
+	// DirectionCredit increases the account's balance
+	DirectionCredit = "credit"
 )
@@ -1,8 +1,44 @@
 package entities
 
-// TransferRequest represents the request to transfer funds between accounts
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TransferRequest represents the request to transfer funds between accounts.
+// Each side is addressed either by account ID or by one of the account's aliases.
+// EffectiveDate (YYYY-MM-DD) backdates the transfer in the books and defaults to today's
+// business date; it must fall in an open accounting period. A correction of a closed
+// period posts in an open one and names the period it corrects in CorrectsPeriod (YYYY-MM).
 type TransferRequest struct {
 	SourceAccountID      int64  `json:"source_account_id"`
+	SourceAlias          string `json:"source_alias,omitempty"`
 	DestinationAccountID int64  `json:"destination_account_id"`
+	DestinationAlias     string `json:"destination_alias,omitempty"`
 	Amount               string `json:"amount"`
+	EffectiveDate        string `json:"effective_date,omitempty"`
+	CorrectsPeriod       string `json:"corrects_period,omitempty"`
+}
+
+// DepositRequest represents the request to deposit external funds into an account
+type DepositRequest struct {
+	AccountID int64  `json:"account_id"`
+	Amount    string `json:"amount"`
+}
+
+// WithdrawalRequest represents the request to withdraw funds from an account to the outside world
+type WithdrawalRequest struct {
+	AccountID int64  `json:"account_id"`
+	Amount    string `json:"amount"`
+}
+
+// PostingRequest describes a system posting executed by another module inside its own
+// database transaction (see ICore.PostTx). Amount must already be validated by the caller.
+// TransactionID is optional; a zero value lets the repository generate one.
+type PostingRequest struct {
+	TransactionID        uuid.UUID
+	SourceAccountID      int64
+	DestinationAccountID int64
+	Amount               decimal.Decimal
+	Type                 string
 }
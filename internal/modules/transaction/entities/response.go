@@ -1,9 +1,26 @@
 package entities
 
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
 // TransferResponse represents the response for a successful transfer
 type TransferResponse struct {
 	TransactionID string `json:"transaction_id"`
 }
 
+// CommittedPosting describes a posting whose database transaction has committed.
+// SourceBalance and DestinationBalance are the account balances it left behind.
+type CommittedPosting struct {
+	TransactionID        uuid.UUID
+	Type                 string
+	SourceAccountID      int64
+	DestinationAccountID int64
+	Amount               decimal.Decimal
+	SourceBalance        decimal.Decimal
+	DestinationBalance   decimal.Decimal
+}
+
 // NOTE: ErrorResponse has been consolidated to pkg/apperror/response.go
 // Import github.com/internal-transfers-service/pkg/apperror for ErrorResponse
@@ -11,12 +11,14 @@ import (
 // Module singleton instance
 var TxModule IModule
 
-// NewModule initializes the transaction module
-var NewModule = func(ctx context.Context, pool *pgxpool.Pool, accountRepo account.IRepository) IModule {
+// NewModule initializes the transaction module. Postings are kept out of closed accounting
+// periods by periods, and listeners are notified of every committed transfer, deposit and
+// withdrawal.
+var NewModule = func(ctx context.Context, pool *pgxpool.Pool, accountRepo account.IRepository, periods IPeriodGuard, listeners ...ICommitListener) IModule {
 	if TxModule == nil {
 		poolWrapper := database.NewPoolWrapper(pool)
 		repo := NewRepository(poolWrapper)
-		core := NewCore(ctx, repo, accountRepo)
+		core := NewCore(ctx, repo, accountRepo, periods, listeners...)
 		handler := NewHTTPHandler(core)
 
 		TxModule = &Module{
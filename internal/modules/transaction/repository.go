@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/internal-transfers-service/internal/constants"
 	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/transaction/entities"
 	"github.com/internal-transfers-service/pkg/database"
 	"github.com/jackc/pgx/v5"
 	"github.com/shopspring/decimal"
@@ -20,12 +21,28 @@ type Transaction struct {
 	SourceAccountID      int64           `json:"source_account_id"`
 	DestinationAccountID int64           `json:"destination_account_id"`
 	Amount               decimal.Decimal `json:"amount"`
+	Type                 string          `json:"type"`
+	EffectiveDate        time.Time       `json:"effective_date"`
+	CorrectsPeriod       *time.Time      `json:"corrects_period,omitempty"`
 	CreatedAt            time.Time       `json:"created_at"`
 }
 
+// LedgerEntry is one side of a posting: a debit or credit against a single account,
+// carrying that account's balance immediately after the entry
+type LedgerEntry struct {
+	EntryID       int64           `json:"entry_id"`
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	AccountID     int64           `json:"account_id"`
+	Direction     string          `json:"direction"`
+	Amount        decimal.Decimal `json:"amount"`
+	BalanceAfter  decimal.Decimal `json:"balance_after"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
 // IRepository defines the interface for transaction data access
 type IRepository interface {
 	Create(ctx context.Context, tx pgx.Tx, transaction *Transaction) error
+	CreateLedgerEntries(ctx context.Context, tx pgx.Tx, entries []*LedgerEntry) error
 	BeginTx(ctx context.Context) (pgx.Tx, error)
 }
 
@@ -45,8 +62,18 @@ func NewRepository(pool database.IPool) *Repository {
 // SQL queries
 const (
 	queryInsertTransaction = `
-		INSERT INTO transactions (id, source_account_id, destination_account_id, amount, created_at)
-		VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO transactions (id, source_account_id, destination_account_id, amount, created_at, type,
+			effective_date, corrects_period)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	// Entries are inserted in slice order so entry_id follows posting order
+	queryInsertLedgerEntries = `
+		INSERT INTO ledger_entries (transaction_id, account_id, direction, amount, balance_after, created_at)
+		SELECT transaction_id, account_id, direction, amount, balance_after, created_at
+		FROM unnest($1::uuid[], $2::bigint[], $3::text[], $4::numeric[], $5::numeric[], $6::timestamptz[])
+			WITH ORDINALITY AS e(transaction_id, account_id, direction, amount, balance_after, created_at, ord)
+		ORDER BY ord
+		RETURNING entry_id`
 )
 
 // Create inserts a new transaction into the database
@@ -54,6 +81,9 @@ func (r *Repository) Create(ctx context.Context, tx pgx.Tx, transaction *Transac
 	if transaction.ID == uuid.Nil {
 		transaction.ID = uuid.New()
 	}
+	if transaction.Type == "" {
+		transaction.Type = entities.TypeTransfer
+	}
 	transaction.CreatedAt = time.Now().UTC()
 
 	_, err := tx.Exec(ctx, queryInsertTransaction,
@@ -62,6 +92,9 @@ func (r *Repository) Create(ctx context.Context, tx pgx.Tx, transaction *Transac
 		transaction.DestinationAccountID,
 		transaction.Amount,
 		transaction.CreatedAt,
+		transaction.Type,
+		transaction.EffectiveDate,
+		transaction.CorrectsPeriod,
 	)
 
 	if err != nil {
@@ -84,6 +117,54 @@ func (r *Repository) Create(ctx context.Context, tx pgx.Tx, transaction *Transac
 	return nil
 }
 
+// CreateLedgerEntries appends the entries to the ledger within the caller's transaction
+// and fills in their entry IDs
+func (r *Repository) CreateLedgerEntries(ctx context.Context, tx pgx.Tx, entries []*LedgerEntry) error {
+	txIDs := make([]uuid.UUID, len(entries))
+	accountIDs := make([]int64, len(entries))
+	directions := make([]string, len(entries))
+	amounts := make([]decimal.Decimal, len(entries))
+	balances := make([]decimal.Decimal, len(entries))
+	createdAts := make([]time.Time, len(entries))
+	for i, entry := range entries {
+		txIDs[i] = entry.TransactionID
+		accountIDs[i] = entry.AccountID
+		directions[i] = entry.Direction
+		amounts[i] = entry.Amount
+		balances[i] = entry.BalanceAfter
+		createdAts[i] = entry.CreatedAt
+	}
+
+	rows, err := tx.Query(ctx, queryInsertLedgerEntries, txIDs, accountIDs, directions, amounts, balances, createdAts)
+	if err != nil {
+		return r.logLedgerFailure(ctx, entries, err)
+	}
+	defer rows.Close()
+
+	for i := 0; rows.Next(); i++ {
+		if err := rows.Scan(&entries[i].EntryID); err != nil {
+			return r.logLedgerFailure(ctx, entries, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return r.logLedgerFailure(ctx, entries, err)
+	}
+	return nil
+}
+
+// logLedgerFailure logs a failed ledger insert and returns the error
+func (r *Repository) logLedgerFailure(ctx context.Context, entries []*LedgerEntry, err error) error {
+	var txID string
+	if len(entries) > 0 {
+		txID = entries[0].TransactionID.String()
+	}
+	logger.Ctx(ctx).Errorw(constants.LogMsgFailedToInsertLedger,
+		constants.LogFieldTransactionID, txID,
+		constants.LogKeyError, err,
+	)
+	return err
+}
+
 // BeginTx starts a new database transaction with explicit isolation level.
 // Uses ReadCommitted isolation which is appropriate for financial transactions
 // when combined with pessimistic locking (SELECT ... FOR UPDATE).
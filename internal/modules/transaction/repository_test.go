@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/internal-transfers-service/internal/modules/transaction"
+	"github.com/internal-transfers-service/internal/modules/transaction/entities"
 	dbmock "github.com/internal-transfers-service/pkg/database/mock"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/shopspring/decimal"
@@ -60,7 +61,7 @@ func (s *RepositoryTestSuite) TestCreateTransactionSucceeds() {
 	}
 
 	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), gomock.Any(), int64(123), int64(456), tx.Amount, gomock.Any()).
+		Exec(s.ctx, gomock.Any(), gomock.Any(), int64(123), int64(456), tx.Amount, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
 		Times(1)
 
@@ -80,7 +81,7 @@ func (s *RepositoryTestSuite) TestCreateTransactionWithExistingIDSucceeds() {
 	}
 
 	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), existingID, int64(123), int64(456), tx.Amount, gomock.Any()).
+		Exec(s.ctx, gomock.Any(), existingID, int64(123), int64(456), tx.Amount, gomock.Any(), entities.TypeTransfer, gomock.Any(), gomock.Any()).
 		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
 		Times(1)
 
@@ -89,6 +90,44 @@ func (s *RepositoryTestSuite) TestCreateTransactionWithExistingIDSucceeds() {
 	s.Equal(existingID, tx.ID)
 }
 
+func (s *RepositoryTestSuite) TestCreateTransactionPersistsExplicitType() {
+	tx := &transaction.Transaction{
+		SourceAccountID:      1,
+		DestinationAccountID: 456,
+		Amount:               decimal.NewFromFloat(50.00),
+		Type:                 entities.TypeDeposit,
+	}
+
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), gomock.Any(), int64(1), int64(456), tx.Amount, gomock.Any(), entities.TypeDeposit, gomock.Any(), gomock.Any()).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
+		Times(1)
+
+	err := s.repo.Create(s.ctx, s.mockTx, tx)
+	s.Nil(err)
+	s.Equal(entities.TypeDeposit, tx.Type)
+}
+
+func (s *RepositoryTestSuite) TestCreateTransactionPersistsEffectiveDateAndCorrectedPeriod() {
+	effectiveDate := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	correctsPeriod := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	tx := &transaction.Transaction{
+		SourceAccountID:      123,
+		DestinationAccountID: 456,
+		Amount:               decimal.NewFromFloat(25.00),
+		EffectiveDate:        effectiveDate,
+		CorrectsPeriod:       &correctsPeriod,
+	}
+
+	s.mockTx.EXPECT().
+		Exec(s.ctx, gomock.Any(), gomock.Any(), int64(123), int64(456), tx.Amount, gomock.Any(), gomock.Any(), effectiveDate, &correctsPeriod).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
+		Times(1)
+
+	err := s.repo.Create(s.ctx, s.mockTx, tx)
+	s.Nil(err)
+}
+
 func (s *RepositoryTestSuite) TestCreateTransactionGeneratesNewIDWhenNil() {
 	tx := &transaction.Transaction{
 		ID:                   uuid.Nil,
@@ -98,7 +137,7 @@ func (s *RepositoryTestSuite) TestCreateTransactionGeneratesNewIDWhenNil() {
 	}
 
 	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), gomock.Not(uuid.Nil), int64(100), int64(200), tx.Amount, gomock.Any()).
+		Exec(s.ctx, gomock.Any(), gomock.Not(uuid.Nil), int64(100), int64(200), tx.Amount, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
 		Times(1)
 
@@ -117,7 +156,7 @@ func (s *RepositoryTestSuite) TestCreateTransactionSetsCreatedAtTimestamp() {
 	before := time.Now().UTC()
 
 	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
 		Times(1)
 
@@ -139,7 +178,7 @@ func (s *RepositoryTestSuite) TestCreateTransactionWithHighPrecisionAmount() {
 	}
 
 	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), gomock.Any(), int64(111), int64(222), highPrecisionAmount, gomock.Any()).
+		Exec(s.ctx, gomock.Any(), gomock.Any(), int64(111), int64(222), highPrecisionAmount, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
 		Times(1)
 
@@ -156,7 +195,7 @@ func (s *RepositoryTestSuite) TestCreateTransactionWithSmallAmount() {
 	}
 
 	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), gomock.Any(), int64(333), int64(444), smallAmount, gomock.Any()).
+		Exec(s.ctx, gomock.Any(), gomock.Any(), int64(333), int64(444), smallAmount, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).
 		Times(1)
 
@@ -174,7 +213,7 @@ func (s *RepositoryTestSuite) TestCreateTransactionWhenExecFailsReturnsError() {
 	}
 
 	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgconn.CommandTag{}, errRepoTxDBConnectionFailed).
 		Times(1)
 
@@ -191,7 +230,7 @@ func (s *RepositoryTestSuite) TestCreateTransactionWhenConstraintViolationReturn
 	}
 
 	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgconn.CommandTag{}, errRepoTxForeignKey).
 		Times(1)
 
@@ -208,7 +247,7 @@ func (s *RepositoryTestSuite) TestCreateTransactionWhenTransactionAbortedReturns
 	}
 
 	s.mockTx.EXPECT().
-		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Exec(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(pgconn.CommandTag{}, errRepoTxAborted).
 		Times(1)
 
@@ -254,3 +293,56 @@ func (s *RepositoryTestSuite) TestBeginTxWhenConnectionTimeoutReturnsError() {
 	s.NotNil(err)
 	s.Nil(tx)
 }
+
+// Test CreateLedgerEntries
+
+func (s *RepositoryTestSuite) TestCreateLedgerEntriesAssignsEntryIDsInOrder() {
+	txID := uuid.New()
+	entries := []*transaction.LedgerEntry{
+		{TransactionID: txID, AccountID: 123, Direction: entities.DirectionDebit, Amount: decimal.NewFromInt(10), BalanceAfter: decimal.NewFromInt(90)},
+		{TransactionID: txID, AccountID: 456, Direction: entities.DirectionCredit, Amount: decimal.NewFromInt(10), BalanceAfter: decimal.NewFromInt(10)},
+	}
+	mockRows := dbmock.NewMockRows(s.ctrl)
+	s.mockTx.EXPECT().
+		Query(s.ctx, gomock.Any(),
+			[]uuid.UUID{txID, txID},
+			[]int64{123, 456},
+			[]string{entities.DirectionDebit, entities.DirectionCredit},
+			gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(mockRows, nil).
+		Times(1)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	nextID := int64(41)
+	mockRows.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			nextID++
+			*dest[0].(*int64) = nextID
+			return nil
+		}).
+		Times(2)
+	mockRows.EXPECT().Err().Return(nil).Times(1)
+	mockRows.EXPECT().Close().Times(1)
+
+	err := s.repo.CreateLedgerEntries(s.ctx, s.mockTx, entries)
+	s.NoError(err)
+	s.Equal(int64(42), entries[0].EntryID)
+	s.Equal(int64(43), entries[1].EntryID)
+}
+
+func (s *RepositoryTestSuite) TestCreateLedgerEntriesWhenQueryFailsReturnsError() {
+	entries := []*transaction.LedgerEntry{
+		{TransactionID: uuid.New(), AccountID: 123, Direction: entities.DirectionDebit, Amount: decimal.NewFromInt(10)},
+	}
+	s.mockTx.EXPECT().
+		Query(s.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errRepoTxAborted).
+		Times(1)
+
+	err := s.repo.CreateLedgerEntries(s.ctx, s.mockTx, entries)
+	s.Equal(errRepoTxAborted, err)
+}
@@ -1,6 +1,8 @@
 package transaction
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -8,8 +10,10 @@ import (
 	"github.com/internal-transfers-service/internal/constants"
 	"github.com/internal-transfers-service/internal/constants/contextkeys"
 	"github.com/internal-transfers-service/internal/logger"
+	"github.com/internal-transfers-service/internal/modules/idempotency"
 	"github.com/internal-transfers-service/internal/modules/transaction/entities"
 	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/jackc/pgx/v5"
 )
 
 // HTTPHandler handles HTTP requests for transaction operations
@@ -25,6 +29,8 @@ func NewHTTPHandler(core ICore) *HTTPHandler {
 // RegisterRoutes registers the transaction routes with the router
 func (h *HTTPHandler) RegisterRoutes(r chi.Router) {
 	r.Post(entities.RouteTransactions, h.CreateTransaction)
+	r.Post(entities.RouteDeposits, h.CreateDeposit)
+	r.Post(entities.RouteWithdrawals, h.CreateWithdrawal)
 }
 
 // CreateTransaction handles POST /transactions
@@ -37,7 +43,7 @@ func (h *HTTPHandler) CreateTransaction(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	response, appErr := h.core.Transfer(ctx, &req)
+	response, appErr := h.core.Transfer(withIdempotentResponse(ctx, http.StatusCreated), &req)
 	if appErr != nil {
 		h.writeErrorWithContext(w, r, appErr)
 		return
@@ -52,6 +58,82 @@ func (h *HTTPHandler) CreateTransaction(w http.ResponseWriter, r *http.Request)
 	h.writeJSON(w, http.StatusCreated, response)
 }
 
+// CreateDeposit handles POST /deposits
+func (h *HTTPHandler) CreateDeposit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req entities.DepositRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	response, appErr := h.core.Deposit(withIdempotentResponse(ctx, http.StatusCreated), &req)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgDepositCreatedHTTP,
+		constants.LogFieldTransactionID, response.TransactionID,
+		constants.LogKeyAccountID, req.AccountID,
+	)
+
+	h.writeJSON(w, http.StatusCreated, response)
+}
+
+// CreateWithdrawal handles POST /withdrawals
+func (h *HTTPHandler) CreateWithdrawal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req entities.WithdrawalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorWithContext(w, r, apperror.NewWithMessage(apperror.CodeBadRequest, err, apperror.MsgInvalidJSONBody))
+		return
+	}
+
+	response, appErr := h.core.Withdraw(withIdempotentResponse(ctx, http.StatusCreated), &req)
+	if appErr != nil {
+		h.writeErrorWithContext(w, r, appErr)
+		return
+	}
+
+	logger.Ctx(ctx).Infow(constants.LogMsgWithdrawalCreatedHTTP,
+		constants.LogFieldTransactionID, response.TransactionID,
+		constants.LogKeyAccountID, req.AccountID,
+	)
+
+	h.writeJSON(w, http.StatusCreated, response)
+}
+
+// withIdempotentResponse makes a posting store its response under the request's
+// idempotency key inside the posting's database transaction, so that a committed posting
+// always has its key and a retry cannot post it again. Requests without a reserved key
+// are left as they are.
+func withIdempotentResponse(ctx context.Context, status int) context.Context {
+	reservation := idempotency.FromContext(ctx)
+	if reservation == nil {
+		return ctx
+	}
+	return WithTxParticipant(ctx, &idempotentResponse{reservation: reservation, status: status})
+}
+
+// idempotentResponse completes an idempotency key with a posting's response, encoded the
+// way writeJSON writes it
+type idempotentResponse struct {
+	reservation *idempotency.Reservation
+	status      int
+}
+
+// Prepare stores the response under the reserved key inside the posting's transaction
+func (i *idempotentResponse) Prepare(ctx context.Context, tx pgx.Tx, response *entities.TransferResponse) error {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(response); err != nil {
+		return err
+	}
+	return i.reservation.CompleteTx(ctx, tx, i.status, body.Bytes())
+}
+
 // writeJSON writes a JSON response
 func (h *HTTPHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
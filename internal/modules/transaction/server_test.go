@@ -7,14 +7,19 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/internal-transfers-service/internal/constants"
 	accountMock "github.com/internal-transfers-service/internal/modules/account/mock"
+	"github.com/internal-transfers-service/internal/modules/idempotency"
+	idempotencyEntities "github.com/internal-transfers-service/internal/modules/idempotency/entities"
+	idempotencyMock "github.com/internal-transfers-service/internal/modules/idempotency/mock"
 	"github.com/internal-transfers-service/internal/modules/transaction"
 	"github.com/internal-transfers-service/internal/modules/transaction/entities"
 	"github.com/internal-transfers-service/internal/modules/transaction/mock"
 	"github.com/internal-transfers-service/pkg/apperror"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 )
@@ -194,6 +199,102 @@ func (s *ServerTestSuite) TestCreateTransactionSameAccountReturnsError() {
 	s.Equal(http.StatusBadRequest, rec.Code)
 }
 
+// CreateDeposit Tests
+
+func (s *ServerTestSuite) TestCreateDepositSuccessReturnsCreated() {
+	s.mockCore.EXPECT().
+		Deposit(gomock.Any(), &entities.DepositRequest{AccountID: int64(100), Amount: "25.00"}).
+		Return(&entities.TransferResponse{TransactionID: "dep-1"}, nil).
+		Times(1)
+
+	body := `{"account_id": 100, "amount": "25.00"}`
+	req := httptest.NewRequest(http.MethodPost, "/deposits", bytes.NewBufferString(body))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+
+	var response entities.TransferResponse
+	s.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	s.Equal("dep-1", response.TransactionID)
+}
+
+func (s *ServerTestSuite) TestCreateDepositWithInvalidJSONReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodPost, "/deposits", bytes.NewBufferString(`{invalid json}`))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestCreateDepositUnknownAccountReturnsNotFound() {
+	coreError := apperror.NewWithMessage(apperror.CodeNotFound, transaction.ErrAccountNotFound, apperror.MsgAccountNotFound)
+
+	s.mockCore.EXPECT().
+		Deposit(gomock.Any(), gomock.Any()).
+		Return(nil, coreError).
+		Times(1)
+
+	body := `{"account_id": 999, "amount": "25.00"}`
+	req := httptest.NewRequest(http.MethodPost, "/deposits", bytes.NewBufferString(body))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusNotFound, rec.Code)
+}
+
+// CreateWithdrawal Tests
+
+func (s *ServerTestSuite) TestCreateWithdrawalSuccessReturnsCreated() {
+	s.mockCore.EXPECT().
+		Withdraw(gomock.Any(), &entities.WithdrawalRequest{AccountID: int64(100), Amount: "10.00"}).
+		Return(&entities.TransferResponse{TransactionID: "wd-1"}, nil).
+		Times(1)
+
+	body := `{"account_id": 100, "amount": "10.00"}`
+	req := httptest.NewRequest(http.MethodPost, "/withdrawals", bytes.NewBufferString(body))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+}
+
+func (s *ServerTestSuite) TestCreateWithdrawalWithInvalidJSONReturnsBadRequest() {
+	req := httptest.NewRequest(http.MethodPost, "/withdrawals", bytes.NewBufferString(`{invalid json}`))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *ServerTestSuite) TestCreateWithdrawalInsufficientBalanceReturnsError() {
+	coreError := apperror.NewWithMessage(apperror.CodeInsufficientFunds, transaction.ErrInsufficientBalance, apperror.MsgInsufficientBalance)
+
+	s.mockCore.EXPECT().
+		Withdraw(gomock.Any(), gomock.Any()).
+		Return(nil, coreError).
+		Times(1)
+
+	body := `{"account_id": 100, "amount": "999999.00"}`
+	req := httptest.NewRequest(http.MethodPost, "/withdrawals", bytes.NewBufferString(body))
+	req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusUnprocessableEntity, rec.Code)
+}
+
 // InitTestSuite contains tests for transaction module initialization
 type InitTestSuite struct {
 	suite.Suite
@@ -215,7 +316,7 @@ func (s *InitTestSuite) TestModuleMethodsReturnCorrectValues() {
 
 	mockRepo := mock.NewMockIRepository(ctrl)
 	mockAcctRepo := accountMock.NewMockIRepository(ctrl)
-	core := transaction.NewCoreWithRepo(s.ctx, mockRepo, mockAcctRepo)
+	core := transaction.NewCoreWithRepo(s.ctx, mockRepo, mockAcctRepo, mock.NewMockIPeriodGuard(ctrl))
 	handler := transaction.NewHTTPHandler(core)
 
 	module := &transaction.Module{
@@ -236,7 +337,7 @@ func (s *InitTestSuite) TestNewCoreCreatesCore() {
 
 	mockRepo := mock.NewMockIRepository(ctrl)
 	mockAcctRepo := accountMock.NewMockIRepository(ctrl)
-	core := transaction.NewCore(s.ctx, mockRepo, mockAcctRepo)
+	core := transaction.NewCore(s.ctx, mockRepo, mockAcctRepo, mock.NewMockIPeriodGuard(ctrl))
 
 	s.NotNil(core)
 }
@@ -249,8 +350,64 @@ func (s *InitTestSuite) TestGetCoreReturnsSingleton() {
 	mockRepo := mock.NewMockIRepository(ctrl)
 	mockAcctRepo := accountMock.NewMockIRepository(ctrl)
 
-	core1 := transaction.NewCore(s.ctx, mockRepo, mockAcctRepo)
+	core1 := transaction.NewCore(s.ctx, mockRepo, mockAcctRepo, mock.NewMockIPeriodGuard(ctrl))
 	core2 := transaction.GetCore()
 
 	s.Equal(core1, core2)
 }
+
+// Idempotent response Tests
+
+func (s *ServerTestSuite) TestCreateTransactionStoresIdempotentResponseInPostingTx() {
+	idempotencyRepo := idempotencyMock.NewMockIRepository(s.ctrl)
+	key := idempotencyEntities.ScopedKey{Client: "payments-api", Method: http.MethodPost, Route: "/transactions", Key: "transfer-1"}
+	reservedAt := time.Date(2026, time.March, 14, 9, 30, 0, 0, time.UTC)
+	reservation := idempotency.NewReservation(idempotencyRepo, key, "fingerprint", reservedAt)
+	response := &entities.TransferResponse{TransactionID: "abc-123"}
+
+	var stored []byte
+	idempotencyRepo.EXPECT().
+		StoreTx(gomock.Any(), nil, key, "fingerprint", reservedAt, http.StatusCreated, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgx.Tx, _ idempotencyEntities.ScopedKey, _ string, _ time.Time, _ int, body []byte) error {
+			stored = body
+			return nil
+		}).
+		Times(1)
+	s.mockCore.EXPECT().
+		Transfer(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *entities.TransferRequest) (*entities.TransferResponse, apperror.IError) {
+			participant := transaction.TxParticipantFromContext(ctx)
+			s.Require().NotNil(participant)
+			s.Require().NoError(participant.Prepare(ctx, nil, response))
+			return response, nil
+		}).
+		Times(1)
+
+	body := `{"source_account_id": 1, "destination_account_id": 2, "amount": "100.00"}`
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(body))
+	req = req.WithContext(idempotency.NewContext(req.Context(), reservation))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+	s.Equal(rec.Body.String(), string(stored), "the stored response must replay byte for byte")
+	s.True(reservation.CompletedTx())
+}
+
+func (s *ServerTestSuite) TestCreateDepositWithoutIdempotencyKeyHasNoParticipant() {
+	s.mockCore.EXPECT().
+		Deposit(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *entities.DepositRequest) (*entities.TransferResponse, apperror.IError) {
+			s.Nil(transaction.TxParticipantFromContext(ctx))
+			return &entities.TransferResponse{TransactionID: "dep-123"}, nil
+		}).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodPost, "/deposits", bytes.NewBufferString(`{"account_id": 1, "amount": "10.00"}`))
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusCreated, rec.Code)
+}
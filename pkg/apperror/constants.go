@@ -16,6 +16,42 @@ const (
 	FieldAmount         = "amount"
 	FieldIdempotencyKey = "idempotency_key"
 	FieldRequestID      = "request_id"
+	FieldCurrency       = "currency"
+	FieldAsOf           = "as_of"
+	FieldFrom           = "from"
+	FieldTo             = "to"
+	FieldFormat         = "format"
+	FieldParentAccount  = "parent_account_id"
+	FieldMinimumBalance = "minimum_balance"
+	FieldAvailable      = "available_balance"
+	FieldMode           = "mode"
+	FieldRow            = "row"
+	FieldColumn         = "column"
+	FieldProductID      = "product_id"
+	FieldName           = "name"
+	FieldDayCount       = "day_count"
+	FieldRounding       = "rounding"
+	FieldFrequency      = "payout_frequency"
+	FieldEffectiveFrom  = "effective_from"
+	FieldAnnualRate     = "annual_rate"
+	FieldAlias          = "alias"
+	FieldAliasKind      = "kind"
+	FieldSourceAlias    = "source_alias"
+	FieldDestAlias      = "destination_alias"
+	FieldRuleID         = "rule_id"
+	FieldRuleKind       = "kind"
+	FieldThreshold      = "threshold"
+	FieldLimit          = "limit"
+	FieldBefore         = "before"
+	FieldDate           = "date"
+	FieldPeriod         = "period"
+	FieldEffectiveDate  = "effective_date"
+	FieldCorrectsPeriod = "corrects_period"
+	FieldNextDate       = "next_date"
+	FieldUnmapped       = "unmapped"
+	FieldExportID       = "export_id"
+	FieldGLCategory     = "category"
+	FieldGLCode         = "gl_code"
 )
 
 // Public error messages - user-facing messages
@@ -26,18 +62,93 @@ const (
 	MsgSourceNotFound      = "Source account not found."
 	MsgDestNotFound        = "Destination account not found."
 	MsgInsufficientBalance = "Insufficient balance for this transaction."
+	MsgBelowMinimumBalance = "This transaction would take the account below its minimum balance."
 	MsgDuplicateAccount    = "An account with this ID already exists."
 	MsgInvalidAmount       = "The amount must be a positive number."
 	MsgSameAccountTransfer = "Source and destination accounts must be different."
 	MsgServiceUnavailable  = "Service is temporarily unavailable."
 
 	// More descriptive validation messages
-	MsgInvalidAccountID      = "Account ID must be a positive integer."
-	MsgInvalidInitialBalance = "Initial balance must be a valid non-negative decimal number."
-	MsgInvalidDecimalFormat  = "The provided value is not a valid decimal number."
-	MsgNegativeBalance       = "Balance cannot be negative."
-	MsgInvalidJSONBody       = "Invalid JSON in request body."
-	MsgTooManyDecimalPlaces  = "Value exceeds maximum precision of 8 decimal places."
+	MsgInvalidAccountID        = "Account ID must be a positive integer."
+	MsgInvalidInitialBalance   = "Initial balance must be a valid non-negative decimal number."
+	MsgInvalidDecimalFormat    = "The provided value is not a valid decimal number."
+	MsgNegativeBalance         = "Balance cannot be negative."
+	MsgInvalidJSONBody         = "Invalid JSON in request body."
+	MsgTooManyDecimalPlaces    = "Value exceeds maximum precision of 8 decimal places."
+	MsgInvalidCurrency         = "The currency is not supported."
+	MsgClearingNotFound        = "No clearing account is configured for this currency."
+	MsgInterestExpenseNotFound = "No interest-expense account is configured for this currency."
+	MsgCurrencyMismatch        = "Source and destination accounts must use the same currency."
+	MsgSystemAccountTransfer   = "Transfers cannot use system accounts; use deposits or withdrawals instead."
+	MsgInvalidAsOf             = "as_of must be an RFC3339 timestamp."
+	MsgFutureAsOf              = "as_of must not be in the future."
+	MsgInvalidDate             = "Dates must use the YYYY-MM-DD format."
+	MsgInvalidDateRange        = "from must not be after to, and the range may span at most 366 days."
+	MsgInvalidTimestamp        = "from and to must be RFC3339 timestamps."
+	MsgInvalidPeriod           = "from must be before to, and to must not be in the future."
+	MsgUnsupportedFormat       = "format must be one of csv, camt053 or jsonl."
+	MsgParentNotFound          = "Parent account not found."
+	MsgInvalidParentAccount    = "Only customer accounts in the same currency can be nested under one another."
+	MsgParentHasBalance        = "An account must have a zero balance before it can take sub-accounts."
+	MsgHierarchyCycle          = "An account cannot be moved under itself or one of its sub-accounts."
+	MsgParentRequired          = "parent_account_id is required; use null to make the account a root."
+	MsgInvalidMinimumBalance   = "minimum_balance must be a valid non-negative decimal number."
+	MsgMinimumBalanceSystem    = "Minimum balances can only be set on customer accounts."
+	MsgNotLeafAccount          = "Postings must use leaf accounts; this account has sub-accounts."
+	MsgInvalidBulkMode         = "mode must be all_or_nothing or best_effort."
+	MsgInvalidBulkSize         = "A bulk request must contain between 1 and 10000 accounts."
+	MsgInvalidCSV              = "The CSV upload is malformed."
+	MsgProductNotFound         = "The specified interest product was not found."
+	MsgInvalidProductID        = "Product ID must be a positive integer."
+	MsgInvalidProductName      = "name is required and may be at most 64 characters."
+	MsgDuplicateProduct        = "An interest product with this name already exists."
+	MsgInvalidDayCount         = "day_count must be ACT/365 or 30/360."
+	MsgInvalidRounding         = "rounding must be half_even, half_up or down."
+	MsgInvalidPayoutFrequency  = "payout_frequency must be monthly, quarterly or annually."
+	MsgInvalidAnnualRate       = "annual_rate must be a non-negative decimal fraction below 100 with at most 8 decimal places."
+	MsgRatesRequired           = "At least one rate is required."
+	MsgDuplicateRateDate       = "Each rate must have a distinct effective_from date."
+	MsgInterestSystemAccount   = "Interest products can only be attached to customer accounts."
+	MsgInvalidAliasKind        = "kind must be handle, email or iban."
+	MsgInvalidHandle           = "Handles must be 3-32 letters, digits, '.', '_' or '-', starting with a letter or digit, and must not read as an IBAN."
+	MsgInvalidEmailAlias       = "alias must be a plain email address."
+	MsgIBANAliasGenerated      = "IBAN aliases are generated by the service; omit alias."
+	MsgAliasTaken              = "This alias is already registered."
+	MsgAliasSystemAccount      = "Aliases can only be registered for customer accounts."
+	MsgAmbiguousAccountRef     = "Address each account by either its ID or an alias, not both."
+	MsgInvalidAlertRuleKind    = "kind must be low_balance or large_debit."
+	MsgInvalidAlertThreshold   = "threshold must be a decimal number with at most 8 decimal places; large_debit thresholds must be positive."
+	MsgInvalidRuleID           = "Rule ID must be a positive integer."
+	MsgAlertRuleNotFound       = "The specified alert rule was not found."
+	MsgTooManyAlertRules       = "This account already has the maximum number of alert rules."
+	MsgInvalidLimit            = "limit must be an integer between 1 and 500."
+	MsgInvalidBefore           = "before must be a positive alert ID."
+	MsgReconciliationRunning   = "A balance reconciliation is already running; try again when it finishes."
+	MsgNoReconciliation        = "No balance reconciliation has completed yet."
+	MsgConservationRunning     = "A money conservation check is already running; try again when it finishes."
+	MsgNoConservationCheck     = "No money conservation check has completed yet."
+	MsgArchiveRunning          = "A transaction archive run is already in progress; try again when it finishes."
+	MsgChainVerifyRunning      = "A hash chain verification is already running; try again when it finishes."
+	MsgInvalidAuditBefore      = "before must be a positive audit event ID."
+	MsgInvalidAuditRange       = "from must be before to."
+	MsgFutureDate              = "date must not be in the future."
+	MsgInvalidAccountingPeriod = "Accounting periods must use the YYYY-MM format."
+	MsgPeriodNotEnded          = "Only accounting periods that have ended can be closed."
+	MsgPeriodAlreadyClosed     = "This accounting period is already closed."
+	MsgPeriodClosed            = "The effective date falls in a closed accounting period; post a correction in an open period instead."
+	MsgInvalidEffectiveDate    = "effective_date must be a YYYY-MM-DD date that is not in the future."
+	MsgInvalidCorrectedPeriod  = "corrects_period must name a closed accounting period before the effective date."
+	MsgInvalidJournalRange     = "from must not be after to."
+	MsgJournalDayNotClosed     = "Only business dates that have closed can be exported."
+	MsgJournalFromRequired     = "from is required for the first journal export."
+	MsgJournalOutOfOrder       = "Business dates must be exported in order without gaps; from must be the day after the last exported date."
+	MsgJournalUnmapped         = "Some postings have legs without a GL account mapping; map their category and currency first."
+	MsgJournalArchived         = "Some of these business dates fall in archived months and can no longer be exported."
+	MsgJournalChanged          = "The postings of this export have changed since it was made, so it cannot be repeated."
+	MsgInvalidExportID         = "Export ID must be a positive integer."
+	MsgJournalExportNotFound   = "The specified journal export was not found."
+	MsgInvalidGLCategory       = "category must be asset, liability, equity, revenue or expense."
+	MsgInvalidGLCode           = "gl_code is required and may be at most 32 characters."
 )
 
 // Additional field keys
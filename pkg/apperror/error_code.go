@@ -7,14 +7,19 @@ type Code string
 
 // Error codes used throughout the application
 const (
-	CodeBadRequest         Code = "BAD_REQUEST"
-	CodeNotFound           Code = "NOT_FOUND"
-	CodeConflict           Code = "CONFLICT"
-	CodeInsufficientFunds  Code = "INSUFFICIENT_FUNDS"
+	CodeBadRequest        Code = "BAD_REQUEST"
+	CodeNotFound          Code = "NOT_FOUND"
+	CodeConflict          Code = "CONFLICT"
+	CodeInsufficientFunds Code = "INSUFFICIENT_FUNDS"
+	// CodeBelowMinimumBalance means the balance covers the debit but it would breach the account's floor
+	CodeBelowMinimumBalance Code = "BELOW_MINIMUM_BALANCE"
+	// CodePeriodClosed means the posting's effective date falls in a closed accounting period
+	CodePeriodClosed       Code = "PERIOD_CLOSED"
 	CodeInternalError      Code = "INTERNAL_ERROR"
 	CodeServiceUnavailable Code = "SERVICE_UNAVAILABLE"
 	CodeValidationError    Code = "VALIDATION_ERROR"
-	CodeDuplicateRequest   Code = "DUPLICATE_REQUEST"
+	// CodeDuplicateRequest means an idempotency key was reused for a different request
+	CodeDuplicateRequest Code = "DUPLICATE_REQUEST"
 )
 
 // HTTPStatus returns the HTTP status code for an error code
@@ -24,9 +29,9 @@ func (c Code) HTTPStatus() int {
 		return http.StatusBadRequest
 	case CodeNotFound:
 		return http.StatusNotFound
-	case CodeConflict, CodeDuplicateRequest:
+	case CodeConflict:
 		return http.StatusConflict
-	case CodeInsufficientFunds:
+	case CodeInsufficientFunds, CodeBelowMinimumBalance, CodePeriodClosed, CodeDuplicateRequest:
 		return http.StatusUnprocessableEntity
 	case CodeServiceUnavailable:
 		return http.StatusServiceUnavailable
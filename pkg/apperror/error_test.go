@@ -46,6 +46,16 @@ func (s *ErrorTestSuite) TestNewWithInsufficientFundsReturnsCorrectHTTPStatus()
 	s.Equal(http.StatusUnprocessableEntity, err.HTTPStatus())
 }
 
+func (s *ErrorTestSuite) TestNewWithBelowMinimumBalanceReturnsCorrectHTTPStatus() {
+	err := New(CodeBelowMinimumBalance, errors.New("test"))
+	s.Equal(http.StatusUnprocessableEntity, err.HTTPStatus())
+}
+
+func (s *ErrorTestSuite) TestNewWithPeriodClosedReturnsCorrectHTTPStatus() {
+	err := New(CodePeriodClosed, errors.New("test"))
+	s.Equal(http.StatusUnprocessableEntity, err.HTTPStatus())
+}
+
 func (s *ErrorTestSuite) TestNewWithInternalErrorReturnsCorrectHTTPStatus() {
 	err := New(CodeInternalError, errors.New("test"))
 	s.Equal(http.StatusInternalServerError, err.HTTPStatus())
@@ -194,6 +204,10 @@ func (s *ErrorTestSuite) TestCodeInsufficientFundsHTTPStatusReturnsCorrectValue(
 	s.Equal(http.StatusUnprocessableEntity, CodeInsufficientFunds.HTTPStatus())
 }
 
+func (s *ErrorTestSuite) TestCodeDuplicateRequestHTTPStatusReturnsCorrectValue() {
+	s.Equal(http.StatusUnprocessableEntity, CodeDuplicateRequest.HTTPStatus())
+}
+
 func (s *ErrorTestSuite) TestCodeInternalErrorHTTPStatusReturnsCorrectValue() {
 	s.Equal(http.StatusInternalServerError, CodeInternalError.HTTPStatus())
 }
@@ -0,0 +1,59 @@
+// Package clock abstracts the current time so that time-dependent jobs can be
+// driven deterministically in tests.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock reports the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the wall clock
+type Real struct{}
+
+// Compile-time interface check
+var _ Clock = Real{}
+
+// Now returns the current wall-clock time
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Fake is a manually controlled clock. It only moves when Set or Advance is called.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// Compile-time interface check
+var _ Clock = (*Fake)(nil)
+
+// NewFake returns a fake clock stopped at now
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the fake clock's current time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set moves the fake clock to now
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+// Advance moves the fake clock forward by d
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
@@ -0,0 +1,50 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/internal-transfers-service/pkg/clock"
+	"github.com/stretchr/testify/suite"
+)
+
+// ClockTestSuite contains tests for the clock package
+type ClockTestSuite struct {
+	suite.Suite
+}
+
+func TestClockSuite(t *testing.T) {
+	suite.Run(t, new(ClockTestSuite))
+}
+
+func (s *ClockTestSuite) TestFakeStaysWhereItWasSet() {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+
+	s.Equal(start, fake.Now())
+	s.Equal(start, fake.Now())
+}
+
+func (s *ClockTestSuite) TestFakeAdvanceMovesForward() {
+	fake := clock.NewFake(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+
+	fake.Advance(36 * time.Hour)
+
+	s.Equal(time.Date(2024, 3, 2, 21, 0, 0, 0, time.UTC), fake.Now())
+}
+
+func (s *ClockTestSuite) TestFakeSetReplacesTime() {
+	fake := clock.NewFake(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	target := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	fake.Set(target)
+
+	s.Equal(target, fake.Now())
+}
+
+func (s *ClockTestSuite) TestRealReturnsCurrentTime() {
+	before := time.Now()
+	now := clock.Real{}.Now()
+
+	s.False(now.Before(before))
+}
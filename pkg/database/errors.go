@@ -0,0 +1,22 @@
+// Package database provides PostgreSQL connection management.
+package database
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// PostgreSQL error codes (SQLSTATE) the repositories need to distinguish
+const (
+	PgCodeUniqueViolation = "23505"
+)
+
+// IsUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == PgCodeUniqueViolation
+	}
+	return false
+}
@@ -4,6 +4,7 @@ package database
 //go:generate mockgen -source=pool.go -destination=mock/mock_pool.go -package=mock
 //go:generate mockgen -destination=mock/mock_row.go -package=mock github.com/jackc/pgx/v5 Row
 //go:generate mockgen -destination=mock/mock_tx.go -package=mock github.com/jackc/pgx/v5 Tx
+//go:generate mockgen -destination=mock/mock_rows.go -package=mock github.com/jackc/pgx/v5 Rows
 
 import (
 	"context"
@@ -65,3 +66,19 @@ func (p *PoolWrapper) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx
 func (p *PoolWrapper) GetUnderlyingPool() *pgxpool.Pool {
 	return p.pool
 }
+
+// InTx runs fn in a new read-committed transaction on pool and commits it if fn succeeds.
+// The transaction is rolled back if fn or the commit fails.
+func InTx(ctx context.Context, pool IPool, fn func(tx pgx.Tx) error) error {
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	// Rollback is a no-op once the transaction has committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
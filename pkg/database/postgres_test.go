@@ -1,10 +1,17 @@
 package database
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/internal-transfers-service/pkg/database/mock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
 )
 
 // DatabaseHelperTestSuite tests database helper functions
@@ -81,3 +88,72 @@ func (s *DatabaseHelperTestSuite) TestCalculateNextBackoffChainingMultipleTimes(
 	current = calculateNextBackoff(current, max)
 	s.Equal(30*time.Second, current)
 }
+
+// TestIsUniqueViolationDetectsPgError verifies unique violations are recognised
+func (s *DatabaseHelperTestSuite) TestIsUniqueViolationDetectsPgError() {
+	err := fmt.Errorf("insert failed: %w", &pgconn.PgError{Code: PgCodeUniqueViolation})
+
+	s.True(IsUniqueViolation(err))
+}
+
+// TestIsUniqueViolationIgnoresOtherErrors verifies other errors are not treated as unique violations
+func (s *DatabaseHelperTestSuite) TestIsUniqueViolationIgnoresOtherErrors() {
+	s.False(IsUniqueViolation(&pgconn.PgError{Code: "23503"}))
+	s.False(IsUniqueViolation(errors.New("duplicate key value violates unique constraint")))
+	s.False(IsUniqueViolation(nil))
+}
+
+// TestInTxCommitsWhenFnSucceeds verifies the transaction is committed after fn
+func (s *DatabaseHelperTestSuite) TestInTxCommitsWhenFnSucceeds() {
+	ctrl := gomock.NewController(s.T())
+	pool := mock.NewMockIPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+	ctx := context.Background()
+
+	pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil).Times(1)
+	tx.EXPECT().Commit(ctx).Return(nil).Times(1)
+	tx.EXPECT().Rollback(ctx).Return(pgx.ErrTxClosed).Times(1)
+
+	called := false
+	err := InTx(ctx, pool, func(got pgx.Tx) error {
+		called = true
+		s.Same(tx, got)
+		return nil
+	})
+
+	s.NoError(err)
+	s.True(called)
+}
+
+// TestInTxRollsBackWhenFnFails verifies a failing fn is not committed
+func (s *DatabaseHelperTestSuite) TestInTxRollsBackWhenFnFails() {
+	ctrl := gomock.NewController(s.T())
+	pool := mock.NewMockIPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+	ctx := context.Background()
+	fnErr := errors.New("insert failed")
+
+	pool.EXPECT().BeginTx(ctx, gomock.Any()).Return(tx, nil).Times(1)
+	tx.EXPECT().Rollback(ctx).Return(nil).Times(1)
+
+	err := InTx(ctx, pool, func(pgx.Tx) error { return fnErr })
+
+	s.Equal(fnErr, err)
+}
+
+// TestInTxReturnsBeginError verifies fn is not run without a transaction
+func (s *DatabaseHelperTestSuite) TestInTxReturnsBeginError() {
+	ctrl := gomock.NewController(s.T())
+	pool := mock.NewMockIPool(ctrl)
+	ctx := context.Background()
+	beginErr := errors.New("connection refused")
+
+	pool.EXPECT().BeginTx(ctx, gomock.Any()).Return(nil, beginErr).Times(1)
+
+	err := InTx(ctx, pool, func(pgx.Tx) error {
+		s.Fail("fn must not run")
+		return nil
+	})
+
+	s.Equal(beginErr, err)
+}